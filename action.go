@@ -1,13 +1,15 @@
 package rpi
 
 // Action represents the result of an action : Action = Σ Exec
+// IsRebootRequired is set by the actions taking effect at the next boot only.
 type Action struct {
-	Name          string          `json:"name"`
-	NumberOfSteps uint16          `json:"numberOfSteps"`
-	Progress      map[string]Exec `json:"executions"`
-	ExitStatus    uint8           `json:"exitStatus"`
-	StartTime     uint64          `json:"startTime"`
-	EndTime       uint64          `json:"endTime"`
+	Name             string          `json:"name"`
+	NumberOfSteps    uint16          `json:"numberOfSteps"`
+	Progress         map[string]Exec `json:"executions"`
+	ExitStatus       uint8           `json:"exitStatus"`
+	StartTime        uint64          `json:"startTime"`
+	EndTime          uint64          `json:"endTime"`
+	IsRebootRequired bool            `json:"isRebootRequired,omitempty"`
 }
 
 // Exec represents the result of an execute.
@@ -0,0 +1,26 @@
+package rpi
+
+// Board represents the current Raspberry Pi board decoded from its revision code.
+type Board struct {
+	Revision                   string            `json:"revision"`
+	IsNewStyleRevision         bool              `json:"isNewStyleRevision"`
+	Model                      string            `json:"model"`
+	Type                       string            `json:"type"`
+	SoC                        string            `json:"soc"`
+	RAM                        uint64            `json:"ram"`
+	Manufacturer               string            `json:"manufacturer"`
+	PCBRevision                string            `json:"pcbRevision"`
+	IsOvervoltageDisallowed    bool              `json:"isOvervoltageDisallowed"`
+	IsOTPProgrammingDisallowed bool              `json:"isOtpProgrammingDisallowed"`
+	IsOTPReadingDisallowed     bool              `json:"isOtpReadingDisallowed"`
+	IsWarrantyVoided           bool              `json:"isWarrantyVoided"`
+	Capabilities               BoardCapabilities `json:"capabilities"`
+}
+
+// BoardCapabilities represents the hardware features of a Raspberry Pi model.
+type BoardCapabilities struct {
+	Wifi      bool `json:"wifi"`
+	Bluetooth bool `json:"bluetooth"`
+	Ethernet  bool `json:"ethernet"`
+	PoEHeader bool `json:"poeHeader"`
+}
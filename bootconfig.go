@@ -0,0 +1,25 @@
+package rpi
+
+// BootConfig represents the content of /boot/config.txt and of the files it includes
+type BootConfig struct {
+	Path     string              `json:"path"`
+	Sections []BootConfigSection `json:"sections"`
+	Includes []BootConfig        `json:"includes"`
+}
+
+// BootConfigSection represents a conditional filter section of config.txt (ex: [pi4]).
+// Settings written before the first filter belong to the [all] section.
+type BootConfigSection struct {
+	Filter   string              `json:"filter"`
+	Line     int                 `json:"line"`
+	Settings []BootConfigSetting `json:"settings"`
+}
+
+// BootConfigSetting represents a key of config.txt, commented or not.
+// dtparam and dtoverlay keys carry their parameter or overlay name (ex: dtparam=spi).
+type BootConfigSetting struct {
+	Key       string `json:"key"`
+	Value     string `json:"value"`
+	Commented bool   `json:"commented"`
+	Line      int    `json:"line"`
+}
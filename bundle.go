@@ -0,0 +1,43 @@
+package rpi
+
+// BundleManifest represents the manifest of a device configuration archive
+type BundleManifest struct {
+	Hostname  string        `json:"hostname"`
+	CreatedAt uint64        `json:"createdAt"`
+	Entries   []BundleEntry `json:"entries"`
+}
+
+// BundleEntry represents a file listed in a device configuration archive
+type BundleEntry struct {
+	Path   string `json:"path"`
+	Kind   string `json:"kind"`
+	Mode   uint32 `json:"mode"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// BundleFile represents the content of a file stored in a device configuration archive
+type BundleFile struct {
+	Path    string
+	Kind    string
+	Mode    uint32
+	Content []byte
+}
+
+// BundleDiff represents the difference between an archived file and the one on the device
+type BundleDiff struct {
+	Path    string   `json:"path"`
+	Status  string   `json:"status"`
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+// BundleImport represents the result of a device configuration archive import
+type BundleImport struct {
+	Manifest        BundleManifest `json:"manifest"`
+	Files           []BundleDiff   `json:"files"`
+	MissingUsers    []string       `json:"missingUsers"`
+	MissingGroups   []string       `json:"missingGroups"`
+	MissingPackages []string       `json:"missingPackages"`
+	Action          *Action        `json:"action,omitempty"`
+}
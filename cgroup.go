@@ -0,0 +1,17 @@
+package rpi
+
+// CGroup represents the resource usage of a systemd slice or service, read from cgroup v2.
+type CGroup struct {
+	ID              string  `json:"id"`
+	Path            string  `json:"path"`
+	Type            string  `json:"type"`
+	CPUUsageSeconds float64 `json:"cpuUsageSeconds"`
+	CPUPercent      float64 `json:"cpuPercent"`
+	MemoryCurrent   uint64  `json:"memoryCurrent"`
+	SwapCurrent     uint64  `json:"swapCurrent"`
+	IORead          uint64  `json:"ioRead"`
+	IOWrite         uint64  `json:"ioWrite"`
+	IOReadRate      float64 `json:"ioReadRate"`
+	IOWriteRate     float64 `json:"ioWriteRate"`
+	PIDs            uint64  `json:"pids"`
+}
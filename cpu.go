@@ -16,3 +16,21 @@ type CPUStats struct {
 	System float64 `json:"system"`
 	Idle   float64 `json:"idle"`
 }
+
+// CPUFreq represents the frequency scaling state of a current host cpu core.
+type CPUFreq struct {
+	ID                 int           `json:"id"`
+	CurrentMhz         float64       `json:"currentMhz"`
+	MinMhz             float64       `json:"minMhz"`
+	MaxMhz             float64       `json:"maxMhz"`
+	Governor           string        `json:"governor"`
+	AvailableGovernors []string      `json:"availableGovernors"`
+	TimeInState        []CPUFreqTime `json:"timeInState"`
+}
+
+// CPUFreqTime represents the time spent by a cpu core at a given frequency.
+type CPUFreqTime struct {
+	Mhz     float64 `json:"mhz"`
+	Time    float64 `json:"time"`
+	Percent float64 `json:"percent"`
+}
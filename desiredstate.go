@@ -0,0 +1,57 @@
+package rpi
+
+// DesiredState represents the configuration a device should converge to.
+// Fields left empty are not checked.
+type DesiredState struct {
+	Hostname       string        `json:"hostname" yaml:"hostname"`
+	SSH            *bool         `json:"ssh" yaml:"ssh"`
+	VNC            *bool         `json:"vnc" yaml:"vnc"`
+	SPI            *bool         `json:"spi" yaml:"spi"`
+	I2C            *bool         `json:"i2c" yaml:"i2c"`
+	OneWire        *bool         `json:"oneWire" yaml:"oneWire"`
+	Camera         *bool         `json:"camera" yaml:"camera"`
+	RemoteGpio     *bool         `json:"remoteGpio" yaml:"remoteGpio"`
+	WaitForNetwork *bool         `json:"waitForNetwork" yaml:"waitForNetwork"`
+	Overscan       *bool         `json:"overscan" yaml:"overscan"`
+	Blanking       *bool         `json:"blanking" yaml:"blanking"`
+	WifiCountry    string        `json:"wifiCountry" yaml:"wifiCountry"`
+	Users          []DesiredUser `json:"users" yaml:"users"`
+	Packages       []string      `json:"packages" yaml:"packages"`
+}
+
+// DesiredUser represents a human user which must exist on the device
+type DesiredUser struct {
+	Username string `json:"username" yaml:"username"`
+	Password string `json:"password" yaml:"password"`
+}
+
+// ObservedState represents the current readings a DesiredState is compared to
+type ObservedState struct {
+	Hostname    string          `json:"hostname"`
+	WifiCountry string          `json:"wifiCountry"`
+	RpInterface RpInterface     `json:"rpInterface"`
+	Boot        Boot            `json:"boot"`
+	Display     Display         `json:"display"`
+	Users       []HumanUser     `json:"users"`
+	Packages    map[string]bool `json:"packages"`
+}
+
+// Drift represents a difference between the desired and the observed state
+type Drift struct {
+	Field   string `json:"field"`
+	Desired string `json:"desired"`
+	Current string `json:"current"`
+}
+
+// DriftReport represents the result of a drift detection
+type DriftReport struct {
+	InSync bool    `json:"inSync"`
+	Drifts []Drift `json:"drifts"`
+}
+
+// Reconciliation represents the actions executed to converge to a DesiredState
+type Reconciliation struct {
+	Drifts     []Drift  `json:"drifts"`
+	Actions    []Action `json:"actions"`
+	ExitStatus uint8    `json:"exitStatus"`
+}
@@ -22,3 +22,42 @@ type MountPoint struct {
 	InodesFree        uint64  `json:"inodesFree"`
 	InodesUsedPercent float64 `json:"inodesUsedPercent"`
 }
+
+// DiskIO represents the I/O counters and rates of a current host block device.
+type DiskIO struct {
+	ID               string   `json:"id"`
+	Device           string   `json:"device"`
+	IsPartition      bool     `json:"isPartition"`
+	ReadCount        uint64   `json:"readCount"`
+	MergedReadCount  uint64   `json:"mergedReadCount"`
+	WriteCount       uint64   `json:"writeCount"`
+	MergedWriteCount uint64   `json:"mergedWriteCount"`
+	ReadBytes        uint64   `json:"readBytes"`
+	WriteBytes       uint64   `json:"writeBytes"`
+	ReadTime         uint64   `json:"readTime"`
+	WriteTime        uint64   `json:"writeTime"`
+	IopsInProgress   uint64   `json:"iopsInProgress"`
+	IoTime           uint64   `json:"ioTime"`
+	WeightedIoTime   uint64   `json:"weightedIoTime"`
+	ReadsPerSec      float64  `json:"readsPerSec"`
+	WritesPerSec     float64  `json:"writesPerSec"`
+	ReadBytesPerSec  float64  `json:"readBytesPerSec"`
+	WriteBytesPerSec float64  `json:"writeBytesPerSec"`
+	UtilPercent      float64  `json:"utilPercent"`
+	Partitions       []string `json:"partitions,omitempty"`
+}
+
+// DiskForecast represents the fill-rate forecast of a current host mountpoint.
+type DiskForecast struct {
+	Mountpoint               string  `json:"mountpoint"`
+	Total                    uint64  `json:"total"`
+	Used                     uint64  `json:"used"`
+	UsedPercent              float64 `json:"usedPercent"`
+	Samples                  int     `json:"samples"`
+	GrowthPerHour            float64 `json:"growthPerHour"`
+	FullAt                   int64   `json:"fullAt"`
+	HoursUntilFull           float64 `json:"hoursUntilFull"`
+	Confidence               float64 `json:"confidence"`
+	HorizonHours             float64 `json:"horizonHours"`
+	IsExhaustedWithinHorizon bool    `json:"isExhaustedWithinHorizon"`
+}
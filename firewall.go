@@ -0,0 +1,39 @@
+package rpi
+
+// Firewall represents the nftables ruleset of the system and the rules of the raspibuddy table.
+// A change applied safely is pending until it is confirmed, and rolled back otherwise.
+type Firewall struct {
+	IsInstalled bool            `json:"isInstalled"`
+	IsPending   bool            `json:"isPending"`
+	Policy      string          `json:"policy"`
+	Rules       []FirewallRule  `json:"rules"`
+	Tables      []FirewallTable `json:"tables"`
+}
+
+// FirewallRule represents an allow or deny rule of the raspibuddy table.
+// An empty protocol, a zero port or an empty source match any.
+type FirewallRule struct {
+	ID       int    `json:"id"`
+	Action   string `json:"action"`
+	Protocol string `json:"protocol"`
+	Port     uint16 `json:"port"`
+	Source   string `json:"source"`
+}
+
+// FirewallTable represents a table of the nftables ruleset
+type FirewallTable struct {
+	Family string          `json:"family"`
+	Name   string          `json:"name"`
+	Chains []FirewallChain `json:"chains"`
+}
+
+// FirewallChain represents a chain of a nftables table, with its number of rules.
+// Type, hook, priority and policy are only set for the base chains.
+type FirewallChain struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Hook     string `json:"hook"`
+	Priority int    `json:"priority"`
+	Policy   string `json:"policy"`
+	Rules    int    `json:"rules"`
+}
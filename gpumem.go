@@ -0,0 +1,25 @@
+package rpi
+
+// GPUMemory represents the memory split between the GPU and the ARM cores (in MB)
+type GPUMemory struct {
+	IsSupported      bool               `json:"isSupported"`
+	RAM              uint64             `json:"ram"`
+	Settings         []GPUMemorySetting `json:"settings"`
+	Configured       uint64             `json:"configured"`
+	Running          uint64             `json:"running"`
+	Min              uint64             `json:"min"`
+	Max              uint64             `json:"max"`
+	IsCameraEnabled  bool               `json:"isCameraEnabled"`
+	CameraMin        uint64             `json:"cameraMin"`
+	IsRebootRequired bool               `json:"isRebootRequired"`
+}
+
+// GPUMemorySetting represents a gpu_mem key of config.txt.
+// gpu_mem_256, gpu_mem_512 and gpu_mem_1024 override gpu_mem on the boards having this amount of RAM.
+type GPUMemorySetting struct {
+	Key         string `json:"key"`
+	Value       uint64 `json:"value"`
+	IsSet       bool   `json:"isSet"`
+	Max         uint64 `json:"max"`
+	IsEffective bool   `json:"isEffective"`
+}
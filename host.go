@@ -4,6 +4,7 @@ package rpi
 type Host struct {
 	ID                 string  `json:"id"`
 	RaspModel          string  `json:"raspModel"`
+	Board              Board   `json:"board"`
 	Hostname           string  `json:"hostname"`
 	UpTime             uint64  `json:"upTime"`
 	BootTime           uint64  `json:"bootTime"`
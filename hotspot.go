@@ -0,0 +1,35 @@
+package rpi
+
+// Hotspot represents the access point hosted on a wifi interface, by hostapd and dnsmasq
+// or by a NetworkManager shared connection (backend)
+type Hotspot struct {
+	Backend    string          `json:"backend"`
+	Interface  string          `json:"interface"`
+	IsEnabled  bool            `json:"isEnabled"`
+	SSID       string          `json:"ssid"`
+	IsSecured  bool            `json:"isSecured"`
+	Channel    int             `json:"channel"`
+	Address    string          `json:"address"`
+	RangeStart string          `json:"rangeStart"`
+	RangeEnd   string          `json:"rangeEnd"`
+	Clients    []HotspotClient `json:"clients"`
+	Leases     []HotspotLease  `json:"leases"`
+}
+
+// HotspotClient represents a station associated with the access point.
+// The ip address and the hostname come from its dhcp lease, if any.
+type HotspotClient struct {
+	MAC           string `json:"mac"`
+	IP            string `json:"ip"`
+	Hostname      string `json:"hostname"`
+	Signal        int    `json:"signal"`
+	ConnectedTime uint64 `json:"connectedTime"`
+}
+
+// HotspotLease represents a dhcp lease given by dnsmasq
+type HotspotLease struct {
+	MAC      string `json:"mac"`
+	IP       string `json:"ip"`
+	Hostname string `json:"hostname"`
+	Expiry   uint64 `json:"expiry"`
+}
@@ -1,12 +1,24 @@
 package rpi
 
-// HumanUser represents an active linux human user
+// HumanUser represents an active linux human user.
+// LastLogin is 0 when the user never logged in, PasswordMaxDays is 0 when the password does not expire.
 type HumanUser struct {
-	Username       string   `json:"username"`
-	Password       string   `json:"password"`
-	Uid            int      `json:"uid"`
-	Gid            int      `json:"gid"`
-	AdditionalInfo []string `json:"additionalInfo"`
-	HomeDirectory  string   `json:"homeDirectory"`
-	DefaultShell   string   `json:"defaultShell"`
+	Username        string   `json:"username"`
+	Uid             int      `json:"uid"`
+	Gid             int      `json:"gid"`
+	AdditionalInfo  []string `json:"additionalInfo"`
+	HomeDirectory   string   `json:"homeDirectory"`
+	DefaultShell    string   `json:"defaultShell"`
+	Groups          []string `json:"groups"`
+	LastLogin       uint64   `json:"lastLogin"`
+	IsLocked        bool     `json:"isLocked"`
+	PasswordMaxDays int      `json:"passwordMaxDays"`
+}
+
+// UserGroup represents a linux group along with the users belonging to it
+type UserGroup struct {
+	Name      string   `json:"name"`
+	Gid       int      `json:"gid"`
+	Members   []string `json:"members"`
+	IsManaged bool     `json:"isManaged"`
 }
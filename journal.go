@@ -0,0 +1,27 @@
+package rpi
+
+// JournalEntry represents an entry of the systemd journal.
+type JournalEntry struct {
+	Cursor string `json:"cursor"`
+	// Timestamp is the wallclock time of the entry (in microseconds since epoch)
+	Timestamp  uint64 `json:"timestamp"`
+	BootID     string `json:"bootId"`
+	Hostname   string `json:"hostname"`
+	Unit       string `json:"unit"`
+	Identifier string `json:"identifier"`
+	PID        int    `json:"pid"`
+	Priority   int    `json:"priority"`
+	Level      string `json:"level"`
+	Message    string `json:"message"`
+}
+
+// JournalQuery represents the filters applied when reading the systemd journal.
+type JournalQuery struct {
+	Unit     string
+	Priority string
+	Boot     string
+	Since    string
+	Until    string
+	Cursor   string
+	Lines    int
+}
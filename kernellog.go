@@ -0,0 +1,14 @@
+package rpi
+
+// KernelLog represents a record of the kernel ring buffer, read from /dev/kmsg.
+type KernelLog struct {
+	Sequence     uint64 `json:"sequence"`
+	Priority     uint8  `json:"priority"`
+	Level        string `json:"level"`
+	Facility     uint8  `json:"facility"`
+	FacilityName string `json:"facilityName"`
+	// Timestamp is the time elapsed since boot (in microseconds)
+	Timestamp  uint64            `json:"timestamp"`
+	Message    string            `json:"message"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
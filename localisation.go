@@ -0,0 +1,35 @@
+package rpi
+
+// Localisation represents the timezone, locale and keyboard of the system
+type Localisation struct {
+	Timezone string   `json:"timezone"`
+	Locale   string   `json:"locale"`
+	Keyboard Keyboard `json:"keyboard"`
+}
+
+// Keyboard represents the keyboard configured in /etc/default/keyboard
+type Keyboard struct {
+	Model   string `json:"model"`
+	Layout  string `json:"layout"`
+	Variant string `json:"variant"`
+	Options string `json:"options"`
+}
+
+// Locale represents a locale supported by the system (ex: en_GB.UTF-8 with the UTF-8 charset)
+type Locale struct {
+	Name    string `json:"name"`
+	Charset string `json:"charset"`
+}
+
+// KeyboardLayout represents a keyboard layout and its variants
+type KeyboardLayout struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Variants    []KeyboardVariant `json:"variants"`
+}
+
+// KeyboardVariant represents a variant of a keyboard layout
+type KeyboardVariant struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
@@ -11,3 +11,35 @@ type Mem struct {
 	VUsed        uint64  `json:"virtMemUsed"`
 	VUsedPercent float64 `json:"virtMemUsedPercent"`
 }
+
+// MemDetail represents a detailed breakdown of the host memory, read from /proc/meminfo (in bytes).
+type MemDetail struct {
+	Total        uint64 `json:"total"`
+	Free         uint64 `json:"free"`
+	Available    uint64 `json:"available"`
+	Buffers      uint64 `json:"buffers"`
+	Cached       uint64 `json:"cached"`
+	SwapCached   uint64 `json:"swapCached"`
+	Active       uint64 `json:"active"`
+	Inactive     uint64 `json:"inactive"`
+	Slab         uint64 `json:"slab"`
+	SReclaimable uint64 `json:"slabReclaimable"`
+	SUnreclaim   uint64 `json:"slabUnreclaimable"`
+	Shmem        uint64 `json:"shmem"`
+	Dirty        uint64 `json:"dirty"`
+	Writeback    uint64 `json:"writeback"`
+	CMATotal     uint64 `json:"cmaTotal"`
+	CMAFree      uint64 `json:"cmaFree"`
+	SwapTotal    uint64 `json:"swapTotal"`
+	SwapFree     uint64 `json:"swapFree"`
+	GPU          uint64 `json:"gpu"`
+}
+
+// MemConsumer represents the memory usage of a process (in bytes).
+type MemConsumer struct {
+	PID      int32  `json:"pid"`
+	Name     string `json:"name"`
+	Username string `json:"username"`
+	RSS      uint64 `json:"rss"`
+	Swap     uint64 `json:"swap"`
+}
@@ -0,0 +1,23 @@
+package rpi
+
+// NetworkConfig represents the ip configuration of the network interfaces and the backend keeping it
+// (dhcpcd or networkmanager, empty when none is found)
+type NetworkConfig struct {
+	Backend    string            `json:"backend"`
+	Interfaces []InterfaceConfig `json:"interfaces"`
+}
+
+// InterfaceConfig represents the ip configuration of a network interface.
+// Addresses are in CIDR notation. Connection is the NetworkManager keyfile of the interface, if any.
+type InterfaceConfig struct {
+	Interface  string   `json:"interface"`
+	IsUp       bool     `json:"isUp"`
+	Addresses  []string `json:"addresses"`
+	Connection string   `json:"connection"`
+	IsStatic   bool     `json:"isStatic"`
+	IPv4       []string `json:"ipv4"`
+	Router     string   `json:"router"`
+	IPv6       []string `json:"ipv6"`
+	Router6    string   `json:"router6"`
+	DNS        []string `json:"dns"`
+}
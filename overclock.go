@@ -0,0 +1,62 @@
+package rpi
+
+// Overclock represents the overclocking settings of the board and the profiles that can be applied
+type Overclock struct {
+	Board       string             `json:"board"`
+	Section     string             `json:"section"`
+	IsSupported bool               `json:"isSupported"`
+	Current     []OverclockSetting `json:"current"`
+	Profiles    []OverclockProfile `json:"profiles"`
+	Limits      map[string]int     `json:"limits"`
+	Temperature OverclockHistory   `json:"temperature"`
+	Throttled   string             `json:"throttled"`
+	State       OverclockState     `json:"state"`
+}
+
+// OverclockProfile represents a set of overclock values for a board model.
+// An empty value means the key is removed so that the firmware default applies.
+type OverclockProfile struct {
+	Name      string            `json:"name"`
+	Settings  map[string]string `json:"settings"`
+	Checks    []OverclockCheck  `json:"checks"`
+	IsAllowed bool              `json:"isAllowed"`
+}
+
+// OverclockCheck represents a safety check run before applying a profile.
+// A failed blocking check prevents the profile from being applied unless forced.
+type OverclockCheck struct {
+	Name       string `json:"name"`
+	IsPassed   bool   `json:"isPassed"`
+	IsBlocking bool   `json:"isBlocking"`
+	Message    string `json:"message"`
+}
+
+// OverclockSetting represents an overclock key of a config.txt section
+type OverclockSetting struct {
+	Section string `json:"section"`
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	IsSet   bool   `json:"isSet"`
+}
+
+// OverclockHistory summarizes the cpu temperatures recorded (in °C)
+type OverclockHistory struct {
+	Current float64 `json:"current"`
+	Max     float64 `json:"max"`
+	Samples int     `json:"samples"`
+	Since   int64   `json:"since"`
+}
+
+// OverclockState represents the last profile applied and the values it replaced
+type OverclockState struct {
+	Profile          string             `json:"profile"`
+	Section          string             `json:"section"`
+	Previous         []OverclockSetting `json:"previous"`
+	Applied          []OverclockSetting `json:"applied"`
+	AppliedAt        int64              `json:"appliedAt"`
+	IsPending        bool               `json:"isPending"`
+	IsBootSuccessful bool               `json:"isBootSuccessful"`
+	SuccessAt        int64              `json:"successAt"`
+	IsRestored       bool               `json:"isRestored"`
+	RestoredReason   string             `json:"restoredReason"`
+}
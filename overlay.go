@@ -0,0 +1,44 @@
+package rpi
+
+// Overlays represents the device tree overlays and parameters of the board
+type Overlays struct {
+	Overlays []Overlay `json:"overlays"`
+	DtParams []DtParam `json:"dtParams"`
+	// Runtime holds the status of the device tree nodes having an alias (ex: spi0: okay)
+	Runtime map[string]string `json:"runtime"`
+}
+
+// Overlay represents a device tree overlay with its README documentation
+type Overlay struct {
+	Name         string         `json:"name"`
+	Info         string         `json:"info"`
+	Params       []OverlayParam `json:"params"`
+	IsFile       bool           `json:"isFile"`
+	IsActive     bool           `json:"isActive"`
+	Active       []OverlayUsage `json:"active"`
+	IsLoaded     bool           `json:"isLoaded"`
+	LoadedParams string         `json:"loadedParams"`
+}
+
+// DtParam represents a parameter of the base device tree
+type DtParam struct {
+	Name     string         `json:"name"`
+	Info     string         `json:"info"`
+	IsActive bool           `json:"isActive"`
+	Active   []OverlayUsage `json:"active"`
+}
+
+// OverlayParam represents a documented parameter of an overlay
+type OverlayParam struct {
+	Name string `json:"name"`
+	Info string `json:"info"`
+}
+
+// OverlayUsage represents a dtoverlay or dtparam line of config.txt.
+// Value is the raw value of the line, Params holds the overlay parameters it sets.
+type OverlayUsage struct {
+	Section string            `json:"section"`
+	Line    int               `json:"line"`
+	Value   string            `json:"value"`
+	Params  map[string]string `json:"params"`
+}
@@ -0,0 +1,63 @@
+package bootconfig
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// View populates and returns the BootConfig model, along with the files it includes.
+func (bc *BootConfig) View() (rpi.BootConfig, error) {
+	return bc.view(bc.i.GetConfigFiles()["bootconfig"].Path, map[string]bool{})
+}
+
+// view parses a config file then its included files, each file being read only once
+func (bc *BootConfig) view(path string, visited map[string]bool) (rpi.BootConfig, error) {
+	lines, err := bc.i.ReadFile(path)
+	if err != nil {
+		return rpi.BootConfig{}, echo.NewHTTPError(http.StatusInternalServerError, "could not read the boot config")
+	}
+	visited[path] = true
+
+	result, err := bc.bcsys.View(path, lines)
+	if err != nil {
+		return rpi.BootConfig{}, err
+	}
+
+	for k, include := range result.Includes {
+		if visited[include.Path] {
+			continue
+		}
+
+		if included, err := bc.view(include.Path, visited); err == nil {
+			result.Includes[k] = included
+		}
+	}
+
+	return result, nil
+}
+
+// ExecuteBCO applies an operation to a key within a section of /boot/config.txt and returns an action.
+func (bc *BootConfig) ExecuteBCO(operation string, section string, key string, value string) (rpi.Action, error) {
+	plan := map[int](map[int]actions.Func){
+		1: {
+			1: {
+				Name:      actions.EditBootConfig,
+				Reference: bc.a.EditBootConfig,
+				Argument: []interface{}{
+					actions.BCO{
+						Path:      bc.i.GetConfigFiles()["bootconfig"].Path,
+						Operation: operation,
+						Section:   section,
+						Key:       key,
+						Value:     value,
+					},
+				},
+			},
+		},
+	}
+
+	return bc.bcsys.ExecuteBCO(plan)
+}
@@ -0,0 +1,121 @@
+package bootconfig_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/bootconfig"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestView(t *testing.T) {
+	cases := []struct {
+		name       string
+		infos      *mock.Infos
+		bcsys      *mocksys.BootConfig
+		wantedData rpi.BootConfig
+		wantedErr  error
+	}{
+		{
+			name: "error: ReadFile",
+			infos: &mock.Infos{
+				GetConfigFilesFn: func() map[string]rpi.ConfigFileDetails {
+					return map[string]rpi.ConfigFileDetails{"bootconfig": {Path: "/boot/config.txt"}}
+				},
+				ReadFileFn: func(string) ([]string, error) {
+					return nil, errors.New("test error")
+				},
+			},
+			wantedData: rpi.BootConfig{},
+			wantedErr:  echo.NewHTTPError(http.StatusInternalServerError, "could not read the boot config"),
+		},
+		{
+			name: "success: included files are read once",
+			infos: &mock.Infos{
+				GetConfigFilesFn: func() map[string]rpi.ConfigFileDetails {
+					return map[string]rpi.ConfigFileDetails{"bootconfig": {Path: "/boot/config.txt"}}
+				},
+				ReadFileFn: func(path string) ([]string, error) {
+					if path == "/boot/missing.txt" {
+						return nil, errors.New("test error")
+					}
+					return []string{path}, nil
+				},
+			},
+			bcsys: &mocksys.BootConfig{
+				ViewFn: func(path string, lines []string) (rpi.BootConfig, error) {
+					result := rpi.BootConfig{
+						Path:     path,
+						Sections: []rpi.BootConfigSection{{Filter: "all", Settings: []rpi.BootConfigSetting{{Key: lines[0]}}}},
+						Includes: []rpi.BootConfig{},
+					}
+					if path == "/boot/config.txt" {
+						result.Includes = []rpi.BootConfig{{Path: "/boot/extra.txt"}, {Path: "/boot/missing.txt"}}
+					} else {
+						result.Includes = []rpi.BootConfig{{Path: "/boot/config.txt"}}
+					}
+					return result, nil
+				},
+			},
+			wantedData: rpi.BootConfig{
+				Path:     "/boot/config.txt",
+				Sections: []rpi.BootConfigSection{{Filter: "all", Settings: []rpi.BootConfigSetting{{Key: "/boot/config.txt"}}}},
+				Includes: []rpi.BootConfig{
+					{
+						Path:     "/boot/extra.txt",
+						Sections: []rpi.BootConfigSection{{Filter: "all", Settings: []rpi.BootConfigSetting{{Key: "/boot/extra.txt"}}}},
+						Includes: []rpi.BootConfig{{Path: "/boot/config.txt"}},
+					},
+					{Path: "/boot/missing.txt"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := bootconfig.New(tc.bcsys, nil, tc.infos)
+			bootConfig, err := s.View()
+			assert.Equal(t, tc.wantedData, bootConfig)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestExecuteBCO(t *testing.T) {
+	var arg actions.BCO
+
+	infos := &mock.Infos{
+		GetConfigFilesFn: func() map[string]rpi.ConfigFileDetails {
+			return map[string]rpi.ConfigFileDetails{"bootconfig": {Path: "/boot/config.txt"}}
+		},
+	}
+	bcsys := &mocksys.BootConfig{
+		ExecuteBCOFn: func(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+			arg = plan[1][1].Argument[0].(actions.BCO)
+			return rpi.Action{
+				Name:          actions.BootConfig,
+				NumberOfSteps: 1,
+				ExitStatus:    0,
+			}, nil
+		},
+	}
+
+	s := bootconfig.New(bcsys, &mock.Actions{}, infos)
+	action, err := s.ExecuteBCO("set", "pi4", "arm_freq", "1800")
+	assert.Nil(t, err)
+	assert.Equal(t, rpi.Action{Name: actions.BootConfig, NumberOfSteps: 1}, action)
+	assert.Equal(t, actions.BCO{
+		Path:      "/boot/config.txt",
+		Operation: "set",
+		Section:   "pi4",
+		Key:       "arm_freq",
+		Value:     "1800",
+	}, arg)
+}
@@ -0,0 +1,56 @@
+package bootconfig
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/bootconfig"
+)
+
+// New creates a new BootConfig logging service instance.
+func New(svc bootconfig.Service, logger rpi.Logger) *LogService {
+	return &LogService{
+		Service: svc,
+		logger:  logger,
+	}
+}
+
+// LogService represents a BootConfig logging service.
+type LogService struct {
+	bootconfig.Service
+	logger rpi.Logger
+}
+
+const name = "bootconfig"
+
+// View is the logging function attached to the View bootconfig services and responsible for logging it out.
+func (ls *LogService) View(ctx echo.Context) (resp rpi.BootConfig, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, "request: view boot config", err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.View()
+}
+
+// ExecuteBCO is the logging function attached to the ExecuteBCO bootconfig services and responsible for logging it out.
+func (ls *LogService) ExecuteBCO(ctx echo.Context, operation string, section string, key string, value string) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: %v %v in section %v", operation, key, section), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteBCO(operation, section, key, value)
+}
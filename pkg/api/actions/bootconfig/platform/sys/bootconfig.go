@@ -0,0 +1,77 @@
+package sys
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/bootconfig"
+)
+
+// BootConfig represents an empty BootConfig entity on the current system.
+type BootConfig struct{}
+
+// View returns the sections and settings of a config file.
+// Included files are returned with their path only, relative paths being resolved from the config file directory.
+func (bc BootConfig) View(path string, lines []string) (rpi.BootConfig, error) {
+	c := bootconfig.Parse(lines)
+	result := rpi.BootConfig{
+		Path:     path,
+		Sections: []rpi.BootConfigSection{},
+		Includes: []rpi.BootConfig{},
+	}
+
+	for _, include := range c.Includes() {
+		if !filepath.IsAbs(include) {
+			include = filepath.Join(filepath.Dir(path), include)
+		}
+		result.Includes = append(result.Includes, rpi.BootConfig{Path: include})
+	}
+
+	current := -1
+	for k, l := range c.Lines {
+		switch l.Kind {
+		case bootconfig.Section:
+			result.Sections = append(result.Sections, rpi.BootConfigSection{
+				Filter:   l.Section,
+				Line:     k + 1,
+				Settings: []rpi.BootConfigSetting{},
+			})
+			current = len(result.Sections) - 1
+		case bootconfig.Setting:
+			if current == -1 {
+				result.Sections = append(result.Sections, rpi.BootConfigSection{
+					Filter:   bootconfig.DefaultSection,
+					Settings: []rpi.BootConfigSetting{},
+				})
+				current = 0
+			}
+
+			result.Sections[current].Settings = append(result.Sections[current].Settings, rpi.BootConfigSetting{
+				Key:       l.Key,
+				Value:     l.Value,
+				Commented: l.Commented,
+				Line:      k + 1,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// ExecuteBCO returns an action response after editing /boot/config.txt
+func (bc BootConfig) ExecuteBCO(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	actionStartTime := uint64(time.Now().Unix())
+	progressInit := actions.FlattenPlan(plan)
+	progress, exitStatus := actions.ExecutePlan(plan, progressInit)
+
+	return rpi.Action{
+		Name:          actions.BootConfig,
+		NumberOfSteps: uint16(len(progressInit)),
+		Progress:      progress,
+		ExitStatus:    exitStatus,
+		StartTime:     actionStartTime,
+		EndTime:       uint64(time.Now().Unix()),
+	}, nil
+}
@@ -0,0 +1,54 @@
+package sys_test
+
+import (
+	"testing"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/bootconfig/platform/sys"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestView(t *testing.T) {
+	lines := []string{
+		"# For more options and information see",
+		"#dtparam=spi=on",
+		"dtparam=audio=on",
+		"include extraconfig.txt",
+		"include /etc/absolute.txt",
+		"",
+		"[pi4]",
+		"dtoverlay=vc4-fkms-v3d",
+		"",
+		"[all]",
+	}
+
+	s := sys.BootConfig{}
+	bootConfig, err := s.View("/boot/config.txt", lines)
+	assert.Nil(t, err)
+	assert.Equal(t, rpi.BootConfig{
+		Path: "/boot/config.txt",
+		Sections: []rpi.BootConfigSection{
+			{
+				Filter: "all",
+				Settings: []rpi.BootConfigSetting{
+					{Key: "dtparam=spi", Value: "on", Commented: true, Line: 2},
+					{Key: "dtparam=audio", Value: "on", Line: 3},
+				},
+			},
+			{
+				Filter:   "pi4",
+				Line:     7,
+				Settings: []rpi.BootConfigSetting{{Key: "dtoverlay=vc4-fkms-v3d", Line: 8}},
+			},
+			{
+				Filter:   "all",
+				Line:     10,
+				Settings: []rpi.BootConfigSetting{},
+			},
+		},
+		Includes: []rpi.BootConfig{
+			{Path: "/boot/extraconfig.txt"},
+			{Path: "/etc/absolute.txt"},
+		},
+	}, bootConfig)
+}
@@ -0,0 +1,41 @@
+package bootconfig
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// Service represents all BootConfig application services.
+type Service interface {
+	View() (rpi.BootConfig, error)
+	ExecuteBCO(string, string, string, string) (rpi.Action, error)
+}
+
+// BootConfig represents a BootConfig application service.
+type BootConfig struct {
+	bcsys BCSYS
+	a     Actions
+	i     Infos
+}
+
+// BCSYS represents a BootConfig repository service.
+type BCSYS interface {
+	View(string, []string) (rpi.BootConfig, error)
+	ExecuteBCO(map[int](map[int]actions.Func)) (rpi.Action, error)
+}
+
+// Actions represents the actions interface
+type Actions interface {
+	EditBootConfig(interface{}) (rpi.Exec, error)
+}
+
+// Infos represents the infos interface
+type Infos interface {
+	GetConfigFiles() map[string]rpi.ConfigFileDetails
+	ReadFile(string) ([]string, error)
+}
+
+// New creates a BCSYS application service instance.
+func New(bcsys BCSYS, a Actions, i Infos) *BootConfig {
+	return &BootConfig{bcsys: bcsys, a: a, i: i}
+}
@@ -0,0 +1,63 @@
+package transport
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi/pkg/api/actions/bootconfig"
+	bc "github.com/raspibuddy/rpi/pkg/utl/bootconfig"
+	"github.com/raspibuddy/rpi/pkg/utl/infos"
+)
+
+// HTTP is a struct implementing a core application service.
+type HTTP struct {
+	svc bootconfig.Service
+}
+
+// NewHTTP creates new bootconfig http service
+func NewHTTP(svc bootconfig.Service, r *echo.Group) {
+	h := HTTP{svc}
+	cr := r.Group("/bootconfig")
+	cr.GET("", h.view)
+	cr.PATCH("/:operation", h.edit)
+}
+
+func (h *HTTP) view(ctx echo.Context) error {
+	result, err := h.svc.View()
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) edit(ctx echo.Context) error {
+	operation := ctx.Param("operation")
+	if !infos.StringItemExists(bc.Operations, operation) {
+		return echo.NewHTTPError(http.StatusNotFound, "Not found - operation should be one of "+strings.Join(bc.Operations, ", "))
+	}
+
+	section := ctx.QueryParam("section")
+	if section == "" {
+		section = bc.DefaultSection
+	}
+	if !bc.IsSection(section) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid section - should be a conditional filter such as all, pi4 or HDMI:0")
+	}
+
+	key := ctx.QueryParam("key")
+	if !bc.IsKey(key) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid key - should be a config.txt key such as gpu_mem, dtparam=spi or dtoverlay=w1-gpio")
+	}
+
+	value := ctx.QueryParam("value")
+	if strings.ContainsAny(value, "\r\n") || ((operation == bc.Set || operation == bc.Min) && value == "") {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid value - should be a single line, required to set a key")
+	}
+
+	result, err := h.svc.ExecuteBCO(operation, section, key, value)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,169 @@
+package transport_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/bootconfig"
+	"github.com/raspibuddy/rpi/pkg/api/actions/bootconfig/transport"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/raspibuddy/rpi/pkg/utl/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestView(t *testing.T) {
+	cases := []struct {
+		name         string
+		infos        mock.Infos
+		bcsys        *mocksys.BootConfig
+		wantedStatus int
+	}{
+		{
+			name: "error: ReadFile",
+			infos: mock.Infos{
+				ReadFileFn: func(string) ([]string, error) {
+					return nil, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			infos: mock.Infos{
+				ReadFileFn: func(string) ([]string, error) {
+					return []string{"dtparam=spi=on"}, nil
+				},
+			},
+			bcsys: &mocksys.BootConfig{
+				ViewFn: func(path string, lines []string) (rpi.BootConfig, error) {
+					return rpi.BootConfig{Path: path}, nil
+				},
+			},
+			wantedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			tc.infos.GetConfigFilesFn = func() map[string]rpi.ConfigFileDetails {
+				return map[string]rpi.ConfigFileDetails{"bootconfig": {Path: "/boot/config.txt"}}
+			}
+			s := bootconfig.New(tc.bcsys, actions.New(), tc.infos)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/bootconfig"
+
+			res, err := http.Get(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestExecuteBCO(t *testing.T) {
+	cases := []struct {
+		name         string
+		req          string
+		bcsys        *mocksys.BootConfig
+		wantedStatus int
+	}{
+		{
+			name:         "error: invalid operation",
+			req:          "toggle?key=gpu_mem",
+			wantedStatus: http.StatusNotFound,
+		},
+		{
+			name:         "error: invalid section",
+			req:          "set?section=pi4%5D&key=gpu_mem&value=128",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid key",
+			req:          "comment?key=gpu%20mem",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: missing value",
+			req:          "set?key=gpu_mem",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: multiline value",
+			req:          "set?key=gpu_mem&value=128%0Astart_x=1",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error: ExecuteBCO result is nil",
+			req:  "unset?section=pi4&key=arm_freq",
+			bcsys: &mocksys.BootConfig{
+				ExecuteBCOFn: func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+					return rpi.Action{}, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			req:  "set?key=dtparam%3Dspi&value=on",
+			bcsys: &mocksys.BootConfig{
+				ExecuteBCOFn: func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+					return rpi.Action{
+						Name:          actions.BootConfig,
+						NumberOfSteps: 1,
+						StartTime:     uint64(time.Now().Unix()),
+						EndTime:       uint64(time.Now().Unix()),
+						ExitStatus:    0,
+					}, nil
+				},
+			},
+			wantedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			i := mock.Infos{
+				GetConfigFilesFn: func() map[string]rpi.ConfigFileDetails {
+					return map[string]rpi.ConfigFileDetails{"bootconfig": {Path: "/boot/config.txt"}}
+				},
+			}
+			s := bootconfig.New(tc.bcsys, actions.New(), i)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/bootconfig/" + tc.req
+
+			req, err := http.NewRequest(http.MethodPatch, path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
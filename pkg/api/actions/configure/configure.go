@@ -7,6 +7,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/raspibuddy/rpi"
 	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/bootconfig"
 	"github.com/raspibuddy/rpi/pkg/utl/constants"
 )
 
@@ -87,51 +88,25 @@ func (con *Configure) ExecuteOV(action string) (rpi.Action, error) {
 	if action == "enable" {
 		plan = map[int](map[int]actions.Func){
 			1: {
-				1: {
-					Name:      actions.DisableOrEnableOverscan,
-					Reference: con.a.DisableOrEnableConfig,
-					Argument: []interface{}{
-						actions.EODC{
-							DirOrFilePath: con.i.GetConfigFiles()["bootconfig"].Path,
-							Action:        action,
-							Data:          "disable_overscan=0",
-							Regex:         actions.DisableOrEnableOverscanRegex,
-							FunctionName:  actions.DisableOrEnableOverscan,
-							AssetFile:     "../assets/config.txt",
-						},
-					},
-				},
+				1: con.bootConfig(actions.DisableOrEnableOverscan, bootconfig.Set, "disable_overscan", "0"),
 			},
 		}
 	} else if action == "disable" {
 		plan = map[int](map[int]actions.Func){
 			1: {
-				1: {
-					Name:      actions.DisableOrEnableOverscan,
-					Reference: con.a.DisableOrEnableConfig,
-					Argument: []interface{}{
-						actions.EODC{
-							DirOrFilePath: con.i.GetConfigFiles()["bootconfig"].Path,
-							Action:        action,
-							Data:          "#disable_overscan=1",
-							Regex:         actions.DisableOrEnableOverscanRegex,
-							FunctionName:  actions.DisableOrEnableOverscan,
-							AssetFile:     "../assets/config.txt",
-						},
-					},
-				},
+				1: con.bootConfig(actions.DisableOrEnableOverscan, bootconfig.Comment, "disable_overscan", ""),
 			},
 			2: {
-				1: {
-					Name:      actions.CommentOverscan,
-					Reference: con.a.CommentOverscan,
-					Argument: []interface{}{
-						actions.CommentOrUncommentConfig{
-							DirOrFilePath: con.i.GetConfigFiles()["bootconfig"].Path,
-							Action:        "comment",
-						},
-					},
-				},
+				1: con.bootConfig(actions.CommentOverscan, bootconfig.Comment, "overscan_left", ""),
+			},
+			3: {
+				1: con.bootConfig(actions.CommentOverscan, bootconfig.Comment, "overscan_right", ""),
+			},
+			4: {
+				1: con.bootConfig(actions.CommentOverscan, bootconfig.Comment, "overscan_top", ""),
+			},
+			5: {
+				1: con.bootConfig(actions.CommentOverscan, bootconfig.Comment, "overscan_bottom", ""),
 			},
 		}
 	} else {
@@ -215,134 +190,31 @@ func (con *Configure) ExecuteCA(action string) (rpi.Action, error) {
 	if action == "enable" {
 		plan = map[int](map[int]actions.Func){
 			1: {
-				1: {
-					Name:      actions.CommentOrUncommentInFile,
-					Reference: con.a.CommentOrUncommentInFile,
-					Argument: []interface{}{
-						actions.COUSLINF{
-							DirOrFilePath: con.i.GetConfigFiles()["bootconfig"].Path,
-							Action:        "comment",
-							DefaultData:   "#startx=",
-							Regex:         actions.StartxCameraRegex,
-							FunctionName:  "comment_startx",
-							AssetFile:     "../assets/config.txt",
-						},
-					},
-				},
+				1: con.bootConfig("comment_startx", bootconfig.Comment, "startx", ""),
 			},
 			2: {
-				1: {
-					Name:      actions.CommentOrUncommentInFile,
-					Reference: con.a.CommentOrUncommentInFile,
-					Argument: []interface{}{
-						actions.COUSLINF{
-							DirOrFilePath: con.i.GetConfigFiles()["bootconfig"].Path,
-							Action:        "comment",
-							DefaultData:   "#fixup_file=",
-							Regex:         actions.FixupFileCameraRegex,
-							FunctionName:  "comment_fixup_file",
-							AssetFile:     "../assets/config.txt",
-						},
-					},
-				},
+				1: con.bootConfig("comment_fixup_file", bootconfig.Comment, "fixup_file", ""),
 			},
 			3: {
-				1: {
-					Name:      actions.DisableOrEnableConfig,
-					Reference: con.a.DisableOrEnableConfig,
-					Argument: []interface{}{
-						actions.EODC{
-							DirOrFilePath: con.i.GetConfigFiles()["bootconfig"].Path,
-							Action:        action,
-							Data:          "start_x=1",
-							Regex:         actions.DisableOrEnableCameraRegex,
-							FunctionName:  actions.DisableOrEnableCameraInterface,
-							AssetFile:     "../assets/config.txt",
-						},
-					},
-				},
+				1: con.bootConfig(actions.DisableOrEnableCameraInterface, bootconfig.Set, "start_x", "1"),
 			},
 			4: {
-				1: {
-					Name:      actions.SetVariableInConfigFile,
-					Reference: con.a.SetVariableInConfigFile,
-					Argument: []interface{}{
-						actions.SVICF{
-							File:      con.i.GetConfigFiles()["bootconfig"].Path,
-							Regex:     actions.GpuMemRegex,
-							Data:      "gpu_mem=128",
-							Threshold: "128",
-							AssetFile: "../assets/config.txt",
-						},
-					},
-				},
+				1: con.bootConfig("set_gpu_mem", bootconfig.Min, "gpu_mem", "128"),
 			},
 		}
 	} else if action == "disable" {
 		plan = map[int](map[int]actions.Func){
 			1: {
-				1: {
-					Name:      actions.CommentOrUncommentInFile,
-					Reference: con.a.CommentOrUncommentInFile,
-					Argument: []interface{}{
-						actions.COUSLINF{
-							DirOrFilePath: con.i.GetConfigFiles()["bootconfig"].Path,
-							Action:        "comment",
-							DefaultData:   "#startx=",
-							Regex:         actions.StartxCameraRegex,
-							FunctionName:  "comment_startx",
-							AssetFile:     "../assets/config.txt",
-						},
-					},
-				},
+				1: con.bootConfig("comment_startx", bootconfig.Comment, "startx", ""),
 			},
 			2: {
-				1: {
-					Name:      actions.CommentOrUncommentInFile,
-					Reference: con.a.CommentOrUncommentInFile,
-					Argument: []interface{}{
-						actions.COUSLINF{
-							DirOrFilePath: con.i.GetConfigFiles()["bootconfig"].Path,
-							Action:        "comment",
-							DefaultData:   "#fixup_file=",
-							Regex:         actions.FixupFileCameraRegex,
-							FunctionName:  "comment_fixup_file",
-							AssetFile:     "../assets/config.txt",
-						},
-					},
-				},
+				1: con.bootConfig("comment_fixup_file", bootconfig.Comment, "fixup_file", ""),
 			},
 			3: {
-				1: {
-					Name:      actions.DisableOrEnableConfig,
-					Reference: con.a.DisableOrEnableConfig,
-					Argument: []interface{}{
-						actions.EODC{
-							DirOrFilePath: con.i.GetConfigFiles()["bootconfig"].Path,
-							Action:        action,
-							Data:          "start_x=0",
-							Regex:         actions.DisableOrEnableCameraRegex,
-							FunctionName:  actions.DisableOrEnableCameraInterface,
-							AssetFile:     "../assets/config.txt",
-						},
-					},
-				},
+				1: con.bootConfig(actions.DisableOrEnableCameraInterface, bootconfig.Set, "start_x", "0"),
 			},
 			4: {
-				1: {
-					Name:      actions.CommentOrUncommentInFile,
-					Reference: con.a.CommentOrUncommentInFile,
-					Argument: []interface{}{
-						actions.COUSLINF{
-							DirOrFilePath: con.i.GetConfigFiles()["bootconfig"].Path,
-							Action:        "comment",
-							DefaultData:   "#start_file=",
-							Regex:         actions.StartFileCameraRegex,
-							FunctionName:  "comment_start_file",
-							AssetFile:     "../assets/config.txt",
-						},
-					},
-				},
+				1: con.bootConfig("comment_start_file", bootconfig.Comment, "start_file", ""),
 			},
 		}
 	} else {
@@ -426,20 +298,7 @@ func (con *Configure) ExecuteSPI(action string) (rpi.Action, error) {
 
 	plan = map[int](map[int]actions.Func){
 		1: {
-			1: {
-				Name:      actions.DisableOrEnableConfig,
-				Reference: con.a.DisableOrEnableConfig,
-				Argument: []interface{}{
-					actions.EODC{
-						DirOrFilePath: con.i.GetConfigFiles()["bootconfig"].Path,
-						Action:        action,
-						Data:          "dtparam=spi=" + data,
-						Regex:         actions.DisableOrEnableSPIRegex,
-						FunctionName:  actions.DisableOrEnableSPIInterface,
-						AssetFile:     "../assets/config.txt",
-					},
-				},
-			},
+			1: con.bootConfig(actions.DisableOrEnableSPIInterface, bootconfig.Set, "dtparam=spi", data),
 		},
 		2: {
 			1: {
@@ -500,20 +359,7 @@ func (con *Configure) ExecuteI2C(action string) (rpi.Action, error) {
 
 	plan = map[int](map[int]actions.Func){
 		1: {
-			1: {
-				Name:      actions.DisableOrEnableConfig,
-				Reference: con.a.DisableOrEnableConfig,
-				Argument: []interface{}{
-					actions.EODC{
-						DirOrFilePath: con.i.GetConfigFiles()["bootconfig"].Path,
-						Action:        action,
-						Data:          "dtparam=i2c_arm=" + data,
-						Regex:         actions.DisableOrEnableI2CRegex,
-						FunctionName:  actions.DisableOrEnableI2CInterface,
-						AssetFile:     "../assets/config.txt",
-					},
-				},
-			},
+			1: con.bootConfig(actions.DisableOrEnableI2CInterface, bootconfig.Set, "dtparam=i2c_arm", data),
 		},
 		2: {
 			1: {
@@ -593,39 +439,13 @@ func (con *Configure) ExecuteONW(action string) (rpi.Action, error) {
 	if action == "enable" {
 		plan = map[int](map[int]actions.Func){
 			1: {
-				1: {
-					Name:      actions.CommentOrUncommentInFile,
-					Reference: con.a.CommentOrUncommentInFile,
-					Argument: []interface{}{
-						actions.COUSLINF{
-							DirOrFilePath: con.i.GetConfigFiles()["bootconfig"].Path,
-							Action:        "uncomment",
-							DefaultData:   "dtoverlay=w1-gpio",
-							Regex:         actions.OneWireCommentRegex,
-							FunctionName:  "uncomment_dtoverlay_w1_gpio",
-							AssetFile:     "../assets/config.txt",
-						},
-					},
-				},
+				1: con.bootConfig("uncomment_dtoverlay_w1_gpio", bootconfig.Uncomment, "dtoverlay=w1-gpio", ""),
 			},
 		}
 	} else if action == "disable" {
 		plan = map[int](map[int]actions.Func){
 			1: {
-				1: {
-					Name:      actions.CommentOrUncommentInFile,
-					Reference: con.a.CommentOrUncommentInFile,
-					Argument: []interface{}{
-						actions.COUSLINF{
-							DirOrFilePath: con.i.GetConfigFiles()["bootconfig"].Path,
-							Action:        "comment",
-							DefaultData:   "#dtoverlay=w1-gpio",
-							Regex:         actions.OneWireCommentRegex,
-							FunctionName:  "comment_dtoverlay_w1_gpio",
-							AssetFile:     "../assets/config.txt",
-						},
-					},
-				},
+				1: con.bootConfig("comment_dtoverlay_w1_gpio", bootconfig.Comment, "dtoverlay=w1-gpio", ""),
 			},
 		}
 	} else {
@@ -796,3 +616,63 @@ func (con *Configure) ExecuteWC(iface string, country string) (rpi.Action, error
 
 	return con.consys.ExecuteWC(plan)
 }
+
+// ExecuteCG sets the cpu frequency governor, now and at boot, and returns an action
+func (con *Configure) ExecuteCG(governor string) (rpi.Action, error) {
+	plan := map[int](map[int]actions.Func){
+		1: {
+			1: {
+				Name:      actions.SetCPUGovernor,
+				Reference: con.a.SetCPUGovernor,
+				Argument: []interface{}{
+					actions.SCG{
+						Governor: governor,
+						Path:     constants.CPUDEVICES,
+					},
+				},
+			},
+		},
+		2: {
+			1: {
+				Name:      actions.PersistCPUGovernor,
+				Reference: con.a.PersistCPUGovernor,
+				Argument: []interface{}{
+					actions.SCG{
+						Governor: governor,
+						Path:     constants.CPUGOVERNORSERVICE,
+					},
+				},
+			},
+		},
+		3: {
+			1: {
+				Name:      actions.ExecuteBashCommand,
+				Reference: con.a.ExecuteBashCommand,
+				Argument: []interface{}{
+					actions.EBC{
+						Command: "systemctl daemon-reload && systemctl enable raspibuddy-cpugovernor.service",
+					},
+				},
+			},
+		},
+	}
+
+	return con.consys.ExecuteCG(plan)
+}
+
+// bootConfig returns the exec applying an operation to a key of the [all] section of /boot/config.txt
+func (con *Configure) bootConfig(name string, operation string, key string, value string) actions.Func {
+	return actions.Func{
+		Name:      name,
+		Reference: con.a.EditBootConfig,
+		Argument: []interface{}{
+			actions.BCO{
+				Path:      con.i.GetConfigFiles()["bootconfig"].Path,
+				Operation: operation,
+				Section:   bootconfig.DefaultSection,
+				Key:       key,
+				Value:     value,
+			},
+		},
+	}
+}
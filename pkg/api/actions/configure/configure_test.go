@@ -401,7 +401,7 @@ func TestExecuteOV(t *testing.T) {
 				},
 			},
 			actions: &mock.Actions{
-				DisableOrEnableConfigFn: func(interface{}) (rpi.Exec, error) {
+				EditBootConfigFn: func(interface{}) (rpi.Exec, error) {
 					return rpi.Exec{
 						Name:       actions.DisableOrEnableOverscan,
 						StartTime:  1,
@@ -488,7 +488,7 @@ func TestExecuteOV(t *testing.T) {
 				},
 			},
 			actions: &mock.Actions{
-				DisableOrEnableConfigFn: func(interface{}) (rpi.Exec, error) {
+				EditBootConfigFn: func(interface{}) (rpi.Exec, error) {
 					return rpi.Exec{
 						Name:       actions.DisableOrEnableOverscan,
 						StartTime:  1,
@@ -497,15 +497,6 @@ func TestExecuteOV(t *testing.T) {
 						Stdout:     "path-enable",
 					}, nil
 				},
-				CommentOverscanFn: func(interface{}) (rpi.Exec, error) {
-					return rpi.Exec{
-						Name:       actions.CommentOverscan,
-						StartTime:  1,
-						EndTime:    2,
-						ExitStatus: 0,
-						Stdout:     "path-comment",
-					}, nil
-				},
 			},
 			consys: &mocksys.Action{
 				ExecuteOVFn: func(map[int](map[int]actions.Func)) (rpi.Action, error) {
@@ -571,6 +562,39 @@ func TestExecuteOV(t *testing.T) {
 	}
 }
 
+func TestExecuteOVDisable(t *testing.T) {
+	var plan map[int](map[int]actions.Func)
+
+	s := configure.New(
+		&mocksys.Action{
+			ExecuteOVFn: func(p map[int](map[int]actions.Func)) (rpi.Action, error) {
+				plan = p
+				return rpi.Action{}, nil
+			},
+		},
+		&mock.Actions{},
+		&mock.Infos{
+			GetConfigFilesFn: func() map[string]rpi.ConfigFileDetails {
+				return map[string]rpi.ConfigFileDetails{"bootconfig": {Path: "/dummy/path"}}
+			},
+		},
+	)
+	_, err := s.ExecuteOV("disable")
+	assert.Nil(t, err)
+
+	// disable_overscan and the overscan values are commented, not set
+	keys := []string{"disable_overscan", "overscan_left", "overscan_right", "overscan_top", "overscan_bottom"}
+	assert.Equal(t, len(keys), len(plan))
+	for i, key := range keys {
+		assert.Equal(t, actions.BCO{
+			Path:      "/dummy/path",
+			Operation: "comment",
+			Section:   "all",
+			Key:       key,
+		}, plan[i+1][1].Argument[0].(actions.BCO))
+	}
+}
+
 func TestExecuteBL(t *testing.T) {
 	cases := []struct {
 		name       string
@@ -879,11 +903,11 @@ func TestExecuteCA(t *testing.T) {
 				1: {
 					1: {
 						Name:      actions.DisableOrEnableCameraInterface,
-						Reference: actions.DisableOrEnableConfig,
+						Reference: actions.EditBootConfig,
 						Argument: []interface{}{
-							actions.EODC{
-								DirOrFilePath: "path",
-								Action:        "enable",
+							actions.BCO{
+								Path:      "path",
+								Operation: "uncomment",
 							},
 						},
 					},
@@ -899,11 +923,11 @@ func TestExecuteCA(t *testing.T) {
 				1: {
 					1: {
 						Name:      actions.DisableOrEnableCameraInterface,
-						Reference: actions.DisableOrEnableConfig,
+						Reference: actions.EditBootConfig,
 						Argument: []interface{}{
-							actions.EODC{
-								DirOrFilePath: "path",
-								Action:        "enable",
+							actions.BCO{
+								Path:      "path",
+								Operation: "uncomment",
 							},
 						},
 					},
@@ -919,9 +943,9 @@ func TestExecuteCA(t *testing.T) {
 				},
 			},
 			actions: &mock.Actions{
-				DisableOrEnableConfigFn: func(interface{}) (rpi.Exec, error) {
+				EditBootConfigFn: func(interface{}) (rpi.Exec, error) {
 					return rpi.Exec{
-						Name:       actions.DisableOrEnableConfig,
+						Name:       actions.EditBootConfig,
 						StartTime:  1,
 						EndTime:    2,
 						ExitStatus: 0,
@@ -936,7 +960,7 @@ func TestExecuteCA(t *testing.T) {
 						NumberOfSteps: 1,
 						Progress: map[string]rpi.Exec{
 							"1": {
-								Name:       actions.DisableOrEnableConfig,
+								Name:       actions.EditBootConfig,
 								StartTime:  1,
 								EndTime:    2,
 								ExitStatus: 0,
@@ -954,7 +978,7 @@ func TestExecuteCA(t *testing.T) {
 				NumberOfSteps: 1,
 				Progress: map[string]rpi.Exec{
 					"1": {
-						Name:       actions.DisableOrEnableConfig,
+						Name:       actions.EditBootConfig,
 						StartTime:  1,
 						EndTime:    2,
 						ExitStatus: 0,
@@ -974,11 +998,11 @@ func TestExecuteCA(t *testing.T) {
 				1: {
 					1: {
 						Name:      actions.DisableOrEnableCameraRegex,
-						Reference: actions.DisableOrEnableConfig,
+						Reference: actions.EditBootConfig,
 						Argument: []interface{}{
-							actions.EODC{
-								DirOrFilePath: "path",
-								Action:        "disable",
+							actions.BCO{
+								Path:      "path",
+								Operation: "comment",
 							},
 						},
 					},
@@ -994,9 +1018,9 @@ func TestExecuteCA(t *testing.T) {
 				},
 			},
 			actions: &mock.Actions{
-				DisableOrEnableConfigFn: func(interface{}) (rpi.Exec, error) {
+				EditBootConfigFn: func(interface{}) (rpi.Exec, error) {
 					return rpi.Exec{
-						Name:       actions.DisableOrEnableConfig,
+						Name:       actions.EditBootConfig,
 						StartTime:  1,
 						EndTime:    2,
 						ExitStatus: 0,
@@ -1011,7 +1035,7 @@ func TestExecuteCA(t *testing.T) {
 						NumberOfSteps: 1,
 						Progress: map[string]rpi.Exec{
 							"1": {
-								Name:       actions.DisableOrEnableConfig,
+								Name:       actions.EditBootConfig,
 								StartTime:  1,
 								EndTime:    2,
 								ExitStatus: 0,
@@ -1029,7 +1053,7 @@ func TestExecuteCA(t *testing.T) {
 				NumberOfSteps: 1,
 				Progress: map[string]rpi.Exec{
 					"1": {
-						Name:       actions.DisableOrEnableConfig,
+						Name:       actions.EditBootConfig,
 						StartTime:  1,
 						EndTime:    2,
 						ExitStatus: 0,
@@ -1448,7 +1472,7 @@ func TestExecuteSPI(t *testing.T) {
 				},
 			},
 			actions: &mock.Actions{
-				DisableOrEnableConfigFn: func(interface{}) (rpi.Exec, error) {
+				EditBootConfigFn: func(interface{}) (rpi.Exec, error) {
 					return rpi.Exec{
 						Name:       actions.SPI,
 						StartTime:  1,
@@ -1522,7 +1546,7 @@ func TestExecuteSPI(t *testing.T) {
 				},
 			},
 			actions: &mock.Actions{
-				DisableOrEnableConfigFn: func(interface{}) (rpi.Exec, error) {
+				EditBootConfigFn: func(interface{}) (rpi.Exec, error) {
 					return rpi.Exec{
 						Name:       actions.SPI,
 						StartTime:  1,
@@ -1636,7 +1660,7 @@ func TestExecuteI2C(t *testing.T) {
 				},
 			},
 			actions: &mock.Actions{
-				DisableOrEnableConfigFn: func(interface{}) (rpi.Exec, error) {
+				EditBootConfigFn: func(interface{}) (rpi.Exec, error) {
 					return rpi.Exec{
 						Name:       actions.SPI,
 						StartTime:  1,
@@ -1710,7 +1734,7 @@ func TestExecuteI2C(t *testing.T) {
 				},
 			},
 			actions: &mock.Actions{
-				DisableOrEnableConfigFn: func(interface{}) (rpi.Exec, error) {
+				EditBootConfigFn: func(interface{}) (rpi.Exec, error) {
 					return rpi.Exec{
 						Name:       actions.SPI,
 						StartTime:  1,
@@ -1824,7 +1848,7 @@ func TestExecuteONW(t *testing.T) {
 				},
 			},
 			actions: &mock.Actions{
-				DisableOrEnableConfigFn: func(interface{}) (rpi.Exec, error) {
+				EditBootConfigFn: func(interface{}) (rpi.Exec, error) {
 					return rpi.Exec{
 						Name:       actions.OneWire,
 						StartTime:  1,
@@ -1898,7 +1922,7 @@ func TestExecuteONW(t *testing.T) {
 				},
 			},
 			actions: &mock.Actions{
-				DisableOrEnableConfigFn: func(interface{}) (rpi.Exec, error) {
+				EditBootConfigFn: func(interface{}) (rpi.Exec, error) {
 					return rpi.Exec{
 						Name:       actions.OneWire,
 						StartTime:  1,
@@ -2401,3 +2425,73 @@ func TestExecuteWC(t *testing.T) {
 		})
 	}
 }
+
+func TestExecuteCG(t *testing.T) {
+	cases := []struct {
+		name       string
+		governor   string
+		actions    *mock.Actions
+		consys     *mocksys.Action
+		wantedData rpi.Action
+		wantedErr  error
+	}{
+		{
+			name:     "success",
+			governor: "performance",
+			actions: &mock.Actions{
+				SetCPUGovernorFn: func(interface{}) (rpi.Exec, error) {
+					return rpi.Exec{
+						Name:       actions.SetCPUGovernor,
+						StartTime:  1,
+						EndTime:    2,
+						ExitStatus: 0,
+					}, nil
+				},
+			},
+			consys: &mocksys.Action{
+				ExecuteCGFn: func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+					return rpi.Action{
+						Name:          actions.CPUGovernor,
+						NumberOfSteps: 3,
+						Progress: map[string]rpi.Exec{
+							"1": {
+								Name:       actions.SetCPUGovernor,
+								StartTime:  1,
+								EndTime:    2,
+								ExitStatus: 0,
+							},
+						},
+						ExitStatus: 0,
+						StartTime:  2,
+						EndTime:    3,
+					}, nil
+				},
+			},
+			wantedData: rpi.Action{
+				Name:          actions.CPUGovernor,
+				NumberOfSteps: 3,
+				Progress: map[string]rpi.Exec{
+					"1": {
+						Name:       actions.SetCPUGovernor,
+						StartTime:  1,
+						EndTime:    2,
+						ExitStatus: 0,
+					},
+				},
+				ExitStatus: 0,
+				StartTime:  2,
+				EndTime:    3,
+			},
+			wantedErr: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := configure.New(tc.consys, tc.actions, &mock.Infos{})
+			cpuGovernor, err := s.ExecuteCG(tc.governor)
+			assert.Equal(t, tc.wantedData, cpuGovernor)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
@@ -336,3 +336,20 @@ func (ls *LogService) ExecuteWC(ctx echo.Context, iface string, country string)
 	}(time.Now())
 	return ls.Service.ExecuteWC(iface, country)
 }
+
+// ExecuteCG is the logging function attached to the execute cpu governor service and responsible for logging it out.
+func (ls *LogService) ExecuteCG(ctx echo.Context, governor string) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name,
+			fmt.Sprintf("request: execute %v cpu governor", governor),
+			err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteCG(governor)
+}
@@ -297,3 +297,19 @@ func (con Configure) ExecuteWC(plan map[int](map[int]actions.Func)) (rpi.Action,
 		EndTime:       uint64(time.Now().Unix()),
 	}, nil
 }
+
+// ExecuteCG returns an action response after setting the cpu frequency governor
+func (con Configure) ExecuteCG(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	actionStartTime := uint64(time.Now().Unix())
+	progressInit := actions.FlattenPlan(plan)
+	progress, exitStatus := actions.ExecutePlan(plan, progressInit)
+
+	return rpi.Action{
+		Name:          actions.CPUGovernor,
+		NumberOfSteps: uint16(len(progressInit)),
+		Progress:      progress,
+		ExitStatus:    exitStatus,
+		StartTime:     actionStartTime,
+		EndTime:       uint64(time.Now().Unix()),
+	}, nil
+}
@@ -855,3 +855,50 @@ func TestExecuteWC(t *testing.T) {
 		})
 	}
 }
+
+func TestExecuteCG(t *testing.T) {
+	cases := []struct {
+		name                  string
+		plan                  map[int](map[int]actions.Func)
+		wantedDataName        string
+		wantedDataNumSteps    uint16
+		wantedDataStdOutStep1 string
+		wantedDataExitStatus  uint8
+		wantedErr             error
+	}{
+		{
+			name: "success",
+			plan: map[int](map[int]actions.Func){
+				1: {
+					1: {
+						Name:      actions.SetCPUGovernor,
+						Reference: test_utl.FuncA,
+						Argument: []interface{}{
+							test_utl.ArgFuncA{
+								Arg0: "string0",
+								Arg1: "string1",
+							},
+						},
+					},
+				},
+			},
+			wantedDataName:        "cpu_governor",
+			wantedDataNumSteps:    1,
+			wantedDataStdOutStep1: "string0-string1",
+			wantedDataExitStatus:  0,
+			wantedErr:             nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := configure.CONSYS(Configure{})
+			cpuGovernor, err := s.ExecuteCG(tc.plan)
+			assert.Equal(t, tc.wantedDataName, cpuGovernor.Name)
+			assert.Equal(t, tc.wantedDataNumSteps, cpuGovernor.NumberOfSteps)
+			assert.Equal(t, tc.wantedDataStdOutStep1, cpuGovernor.Progress["1<|>1"].Stdout)
+			assert.Equal(t, tc.wantedDataExitStatus, cpuGovernor.ExitStatus)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
@@ -25,6 +25,7 @@ type Service interface {
 	ExecuteUPG() (rpi.Action, error)
 	ExecuteUPDG() (rpi.Action, error)
 	ExecuteWC(string, string) (rpi.Action, error)
+	ExecuteCG(string) (rpi.Action, error)
 }
 
 // Configure represents a Configure application service.
@@ -54,6 +55,7 @@ type CONSYS interface {
 	ExecuteUPG(map[int](map[int]actions.Func)) (rpi.Action, error)
 	ExecuteUPDG(map[int](map[int]actions.Func)) (rpi.Action, error)
 	ExecuteWC(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteCG(map[int](map[int]actions.Func)) (rpi.Action, error)
 }
 
 // Actions represents the actions interface
@@ -62,15 +64,14 @@ type Actions interface {
 	ChangeHostnameInHostsFile(interface{}) (rpi.Exec, error)
 	ChangePassword(interface{}) (rpi.Exec, error)
 	WaitForNetworkAtBoot(interface{}) (rpi.Exec, error)
-	CommentOverscan(interface{}) (rpi.Exec, error)
 	DisableOrEnableBlanking(interface{}) (rpi.Exec, error)
 	AddUser(interface{}) (rpi.Exec, error)
 	DeleteUser(interface{}) (rpi.Exec, error)
-	DisableOrEnableConfig(interface{}) (rpi.Exec, error)
-	CommentOrUncommentInFile(interface{}) (rpi.Exec, error)
-	SetVariableInConfigFile(interface{}) (rpi.Exec, error)
 	ExecuteBashCommand(interface{}) (rpi.Exec, error)
 	DisableOrEnableRemoteGpio(interface{}) (rpi.Exec, error)
+	SetCPUGovernor(interface{}) (rpi.Exec, error)
+	PersistCPUGovernor(interface{}) (rpi.Exec, error)
+	EditBootConfig(interface{}) (rpi.Exec, error)
 }
 
 // Infos represents the infos interface
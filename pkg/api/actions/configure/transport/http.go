@@ -35,6 +35,7 @@ func NewHTTP(svc configure.Service, r *echo.Group) {
 	cr.POST("/upgrade", h.upgrade)
 	cr.POST("/updateupgrade", h.updateupgrade)
 	cr.POST("/wificountry", h.wificountry)
+	cr.POST("/cpugovernor", h.cpugovernor)
 }
 
 func ActionCheck(action string, regex string) error {
@@ -299,3 +300,17 @@ func (h *HTTP) wificountry(ctx echo.Context) error {
 
 	return ctx.JSON(http.StatusOK, result)
 }
+
+func (h *HTTP) cpugovernor(ctx echo.Context) error {
+	governor := ctx.QueryParam("governor")
+	if err := ActionCheck(governor, `ondemand|performance|powersave`); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Not found - bad governor type")
+	}
+
+	result, err := h.svc.ExecuteCG(governor)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, result)
+}
@@ -1623,3 +1623,94 @@ func TestExecuteWC(t *testing.T) {
 		})
 	}
 }
+
+func TestExecuteCG(t *testing.T) {
+	var response rpi.Action
+
+	cases := []struct {
+		name         string
+		req          string
+		consys       *mocksys.Action
+		wantedStatus int
+		wantedResp   rpi.Action
+	}{
+		{
+			name:         "error: invalid request response",
+			req:          "",
+			wantedStatus: http.StatusNotFound,
+		},
+		{
+			name:         "error: invalid request response (bad governor)",
+			req:          "?governor=userspace",
+			wantedStatus: http.StatusNotFound,
+		},
+		{
+			name: "error: ExecuteCG result is nil",
+			req:  "?governor=ondemand",
+			consys: &mocksys.Action{
+				ExecuteCGFn: func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+					return rpi.Action{}, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:         "success",
+			wantedStatus: http.StatusOK,
+			req:          "?governor=powersave",
+			consys: &mocksys.Action{
+				ExecuteCGFn: func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+					return rpi.Action{
+						Name:          actions.CPUGovernor,
+						NumberOfSteps: 3,
+						StartTime:     1,
+						EndTime:       2,
+						ExitStatus:    0,
+					}, nil
+				},
+			},
+			wantedResp: rpi.Action{
+				Name:          actions.CPUGovernor,
+				NumberOfSteps: 3,
+				StartTime:     1,
+				EndTime:       2,
+				ExitStatus:    0,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			a := actions.New()
+			i := infos.New()
+			s := configure.New(tc.consys, a, i)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/configure/cpugovernor" + tc.req
+
+			res, err := http.Post(path, "application/json", bytes.NewBufferString(tc.req))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer res.Body.Close()
+
+			body, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				panic(err)
+			}
+
+			if tc.wantedResp.Name != "" {
+				if err := json.Unmarshal(body, &response); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, tc.wantedResp, response)
+			}
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,402 @@
+package firewall
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/constants"
+)
+
+const (
+	// Table is the nftables table holding the raspibuddy rules
+	Table = "raspibuddy"
+
+	// RollbackUnit is the transient systemd unit rolling back a pending change
+	RollbackUnit = "raspibuddy-firewall-rollback"
+
+	// MaxTimeout is the longest time, in seconds, a change can wait for its confirmation
+	MaxTimeout = 3600
+
+	// Allow is the action of the rules accepting packets
+	Allow = "allow"
+
+	// Deny is the action of the rules dropping packets
+	Deny = "deny"
+)
+
+// Presets are the rulesets replacing the raspibuddy rules: open accepts everything,
+// lan-only accepts the private networks only and api-only accepts the api port only
+var Presets = []string{"open", "lan-only", "api-only"}
+
+// LAN are the private networks accepted by the lan-only preset
+var LAN = []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "fe80::/10", "fc00::/7"}
+
+// List returns the nftables ruleset along with the policy and the rules of the raspibuddy table
+func (f *Firewall) List() (rpi.Firewall, error) {
+	isPending := false
+	if unit, err := f.i.ShowUnit(RollbackUnit + ".timer"); err == nil {
+		isPending = unit["ActiveState"] == "active"
+	}
+
+	return f.fsys.List(f.i.IsUnit("nftables.service"), isPending, f.i.NftRuleset())
+}
+
+// ExecuteAFR adds an allow or a deny rule to the raspibuddy table, then returns an action.
+// The deny rules come first, so that an allow rule does not shadow them.
+func (f *Firewall) ExecuteAFR(rule rpi.FirewallRule, timeout int) (rpi.Action, error) {
+	if rule.Action != Allow && rule.Action != Deny {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid action - should be allow or deny")
+	}
+	if rule.Protocol != "" && rule.Protocol != "tcp" && rule.Protocol != "udp" {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid protocol - should be tcp or udp")
+	}
+	if rule.Port != 0 && rule.Protocol == "" {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to a missing protocol - a port needs tcp or udp")
+	}
+	if rule.Source != "" {
+		source, err := source(rule.Source)
+		if err != nil {
+			return rpi.Action{}, err
+		}
+		rule.Source = source
+	}
+
+	current, err := f.current()
+	if err != nil {
+		return rpi.Action{}, err
+	}
+
+	for _, r := range current.Rules {
+		if r.Action == rule.Action && r.Protocol == rule.Protocol && r.Port == rule.Port && r.Source == rule.Source {
+			return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to an existing rule - %v", r.ID))
+		}
+	}
+
+	return f.apply(current.Policy, append(current.Rules, rule), timeout)
+}
+
+// ExecuteRFR removes a rule of the raspibuddy table, then returns an action
+func (f *Firewall) ExecuteRFR(id int, timeout int) (rpi.Action, error) {
+	current, err := f.current()
+	if err != nil {
+		return rpi.Action{}, err
+	}
+
+	rules := []rpi.FirewallRule{}
+	for _, r := range current.Rules {
+		if r.ID != id {
+			rules = append(rules, r)
+		}
+	}
+	if len(rules) == len(current.Rules) {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Not found - rule %v does not exist", id))
+	}
+
+	return f.apply(current.Policy, rules, timeout)
+}
+
+// ExecuteFP replaces the policy and the rules of the raspibuddy table with a preset, then returns an action
+func (f *Firewall) ExecuteFP(preset string, timeout int) (rpi.Action, error) {
+	policy := "drop"
+	rules := []rpi.FirewallRule{}
+
+	switch preset {
+	case "open":
+		policy = "accept"
+	case "lan-only":
+		for _, s := range LAN {
+			rules = append(rules, rpi.FirewallRule{Action: Allow, Source: s})
+		}
+	case "api-only":
+		if f.port == 0 {
+			return rpi.Action{}, echo.NewHTTPError(http.StatusInternalServerError, "could not find the port of the api")
+		}
+		rules = append(rules, rpi.FirewallRule{Action: Allow, Protocol: "tcp", Port: f.port})
+	default:
+		return rpi.Action{}, echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Not found - %v should be one of %v", preset, strings.Join(Presets, ", ")))
+	}
+
+	if _, err := f.current(); err != nil {
+		return rpi.Action{}, err
+	}
+
+	return f.apply(policy, rules, timeout)
+}
+
+// ExecuteSFP sets the policy of the raspibuddy table, the verdict of the packets matching no rule, then returns an action
+func (f *Firewall) ExecuteSFP(policy string, timeout int) (rpi.Action, error) {
+	if policy != "accept" && policy != "drop" {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid policy - should be accept or drop")
+	}
+
+	current, err := f.current()
+	if err != nil {
+		return rpi.Action{}, err
+	}
+
+	return f.apply(policy, current.Rules, timeout)
+}
+
+// ExecuteCF confirms the pending change: the rollback is cancelled and the rules are kept at boot.
+// Then it returns an action.
+func (f *Firewall) ExecuteCF() (rpi.Action, error) {
+	current, err := f.current()
+	if err != nil {
+		return rpi.Action{}, err
+	}
+	if !current.IsPending {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusNotFound, "Not found - no firewall change is waiting for its confirmation")
+	}
+
+	lines, err := f.i.ReadFile(constants.FIREWALLPENDING)
+	if err != nil {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusInternalServerError, "could not read the pending firewall rules")
+	}
+
+	plan, err := f.persist(map[int](map[int]actions.Func){}, []byte(strings.Join(lines, "\n")+"\n"))
+	if err != nil {
+		return rpi.Action{}, err
+	}
+
+	return f.fsys.ExecuteCF(plan)
+}
+
+// current returns the firewall, an error when nftables is not installed
+func (f *Firewall) current() (rpi.Firewall, error) {
+	current, err := f.List()
+	if err != nil {
+		return rpi.Firewall{}, err
+	}
+	if !current.IsInstalled {
+		return rpi.Firewall{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to missing packages - nftables should be installed")
+	}
+	return current, nil
+}
+
+// apply returns an action after loading the raspibuddy table built from a policy and rules.
+// With a timeout, the change is pending: the table is rolled back to its last confirmed state
+// when it is not confirmed within timeout seconds. Without, the change is kept at boot right away.
+func (f *Firewall) apply(policy string, rules []rpi.FirewallRule, timeout int) (rpi.Action, error) {
+	if timeout < 0 || timeout > MaxTimeout {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to an invalid timeout - should be 0 to apply without confirmation, or up to %v seconds", MaxTimeout))
+	}
+
+	table := ruleset(policy, rules, f.hotspots())
+	plan := f.files(map[int](map[int]actions.Func){}, actions.RF{Path: constants.FIREWALLPENDING, Content: table, Mode: 0600})
+
+	if timeout == 0 {
+		f.commands(plan, fmt.Sprintf("nft -f %v", constants.FIREWALLPENDING))
+		plan, err := f.persist(plan, table)
+		if err != nil {
+			return rpi.Action{}, err
+		}
+		return f.fsys.ExecuteFR(plan)
+	}
+
+	// the last confirmed table, or none
+	rollback := fmt.Sprintf("nft delete table inet %v", Table)
+	if f.i.IsFileExists(constants.NFTABLESRULES) {
+		rollback = fmt.Sprintf("nft -f %v", constants.NFTABLESRULES)
+	}
+
+	f.commands(
+		plan,
+		fmt.Sprintf(
+			"systemctl stop %[1]v.timer 2>/dev/null; systemctl reset-failed %[1]v.service 2>/dev/null; "+
+				"systemd-run --unit=%[1]v --on-active=%[2]v --timer-property=AccuracySec=1s /bin/sh -c '%[3]v'",
+			RollbackUnit, timeout, rollback,
+		),
+		fmt.Sprintf("nft -f %v", constants.FIREWALLPENDING),
+	)
+
+	return f.fsys.ExecuteFR(plan)
+}
+
+// persist appends the steps cancelling the rollback and keeping a table at boot to a plan:
+// it is written to the rules file, included by nftables.conf, and nftables is enabled
+func (f *Firewall) persist(plan map[int](map[int]actions.Func), table []byte) (map[int](map[int]actions.Func), error) {
+	include := fmt.Sprintf(`include "%v"`, constants.NFTABLESRULES)
+
+	conf := []string{"#!/usr/sbin/nft -f", "", "flush ruleset"}
+	if f.i.IsFileExists(constants.NFTABLESCONF) {
+		var err error
+		if conf, err = f.i.ReadFile(constants.NFTABLESCONF); err != nil {
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, "could not read nftables.conf")
+		}
+	}
+
+	f.commands(plan, fmt.Sprintf("systemctl stop %v.timer 2>/dev/null || true", RollbackUnit))
+	f.files(plan, actions.RF{Path: constants.NFTABLESRULES, Content: table, Mode: 0644})
+	if !contains(conf, include) {
+		f.files(plan, actions.RF{Path: constants.NFTABLESCONF, Content: []byte(strings.Join(append(conf, "", include), "\n") + "\n"), Mode: 0755})
+	}
+
+	plan[len(plan)+1] = map[int]actions.Func{
+		1: {
+			Name:      actions.ManageUnit,
+			Reference: f.a.ManageUnit,
+			Argument: []interface{}{
+				actions.MU{
+					Action: "enable",
+					Unit:   "nftables.service",
+				},
+			},
+		},
+	}
+
+	return plan, nil
+}
+
+// files appends a step to a plan for each file, the previous files being kept in the firewall directory
+func (f *Firewall) files(plan map[int](map[int]actions.Func), files ...actions.RF) map[int](map[int]actions.Func) {
+	for _, file := range files {
+		file.Backup = filepath.Join(constants.FIREWALL, filepath.Base(file.Path))
+		plan[len(plan)+1] = map[int]actions.Func{
+			1: {
+				Name:      actions.RestoreFile,
+				Reference: f.a.RestoreFile,
+				Argument:  []interface{}{file},
+			},
+		}
+	}
+
+	return plan
+}
+
+// commands appends a step to a plan for each command, in order
+func (f *Firewall) commands(plan map[int](map[int]actions.Func), commands ...string) map[int](map[int]actions.Func) {
+	for _, c := range commands {
+		plan[len(plan)+1] = map[int]actions.Func{
+			1: {
+				Name:      actions.ExecuteBashCommand,
+				Reference: f.a.ExecuteBashCommand,
+				Argument: []interface{}{
+					actions.EBC{
+						Command: c,
+					},
+				},
+			},
+		}
+	}
+
+	return plan
+}
+
+// hotspots returns the interfaces running an access point of raspibuddy, with hostapd or NetworkManager
+func (f *Firewall) hotspots() []string {
+	ifaces := []string{}
+	for path, key := range map[string]string{constants.HOSTAPDCONF: "interface=", constants.NMHOTSPOT: "interface-name="} {
+		if !f.i.IsFileExists(path) {
+			continue
+		}
+		lines, err := f.i.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		for _, l := range lines {
+			l = strings.TrimSpace(l)
+			if strings.HasPrefix(l, key) && len(l) > len(key) {
+				ifaces = append(ifaces, fmt.Sprintf("%q", strings.TrimPrefix(l, key)))
+			}
+		}
+	}
+	sort.Strings(ifaces)
+	return ifaces
+}
+
+// ruleset returns the nftables script replacing the raspibuddy table.
+// Its input chain first accepts the established connections, the loopback, icmp, dhcp,
+// and the dns requests of the clients of the access points, then applies the deny rules and the allow rules,
+// the other packets getting the policy.
+func ruleset(policy string, rules []rpi.FirewallRule, hotspots []string) []byte {
+	sorted := append([]rpi.FirewallRule{}, rules...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Action == Deny && sorted[j].Action != Deny
+	})
+
+	lines := []string{
+		"#!/usr/sbin/nft -f",
+		"# managed by raspibuddy",
+		"",
+		"table inet " + Table,
+		"delete table inet " + Table,
+		"",
+		"table inet " + Table + " {",
+		"\tchain base {",
+		"\t\tct state established,related accept",
+		"\t\tiifname \"lo\" accept",
+		"\t\tmeta l4proto { icmp, ipv6-icmp } accept",
+		"\t\tudp dport { 67, 68, 546 } accept",
+	}
+	if len(hotspots) > 0 {
+		lines = append(lines, fmt.Sprintf("\t\tiifname { %v } meta l4proto { tcp, udp } th dport 53 accept", strings.Join(hotspots, ", ")))
+	}
+	lines = append(
+		lines,
+		"\t}",
+		"",
+		"\tchain input {",
+		fmt.Sprintf("\t\ttype filter hook input priority filter; policy %v;", policy),
+		"\t\tjump base",
+	)
+	for _, r := range sorted {
+		lines = append(lines, "\t\t"+rule(r))
+	}
+	lines = append(lines, "\t}", "}")
+
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
+
+// rule returns the nftables statement of a rule (ex: ip saddr 10.0.0.0/8 tcp dport 22 accept)
+func rule(r rpi.FirewallRule) string {
+	matches := []string{}
+
+	if r.Source != "" {
+		family := "ip"
+		if strings.Contains(r.Source, ":") {
+			family = "ip6"
+		}
+		matches = append(matches, fmt.Sprintf("%v saddr %v", family, r.Source))
+	}
+
+	if r.Port != 0 {
+		matches = append(matches, fmt.Sprintf("%v dport %v", r.Protocol, r.Port))
+	} else if r.Protocol != "" {
+		matches = append(matches, fmt.Sprintf("meta l4proto %v", r.Protocol))
+	}
+
+	verdict := "accept"
+	if r.Action == Deny {
+		verdict = "drop"
+	}
+
+	return strings.Join(append(matches, verdict), " ")
+}
+
+// source validates the source of a rule, an ip address or a network in CIDR notation, and returns it
+// with the host bits cleared (ex: 192.168.1.10/24 is 192.168.1.0/24)
+func source(value string) (string, error) {
+	if ip := net.ParseIP(value); ip != nil {
+		return ip.String(), nil
+	}
+	if _, network, err := net.ParseCIDR(value); err == nil {
+		return network.String(), nil
+	}
+	return "", echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid source - should be an ip address or a network in CIDR notation (ex: 192.168.1.0/24)")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
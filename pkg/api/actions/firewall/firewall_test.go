@@ -0,0 +1,317 @@
+package firewall_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/firewall"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/constants"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/stretchr/testify/assert"
+)
+
+const base = "#!/usr/sbin/nft -f\n# managed by raspibuddy\n\ntable inet raspibuddy\ndelete table inet raspibuddy\n\n" +
+	"table inet raspibuddy {\n\tchain base {\n\t\tct state established,related accept\n\t\tiifname \"lo\" accept\n" +
+	"\t\tmeta l4proto { icmp, ipv6-icmp } accept\n\t\tudp dport { 67, 68, 546 } accept\n"
+
+const header = base + "\t}\n\n\tchain input {\n"
+
+var rules = []rpi.FirewallRule{
+	{ID: 0, Action: "deny", Source: "192.168.1.66"},
+	{ID: 1, Action: "allow", Protocol: "tcp", Port: 22, Source: "192.168.1.0/24"},
+}
+
+func infos(isInstalled bool, isPending bool, isConfirmed bool) *mock.Infos {
+	return &mock.Infos{
+		ReadFileFn: func(path string) ([]string, error) {
+			if path == constants.NFTABLESCONF {
+				return []string{"#!/usr/sbin/nft -f", "", "flush ruleset"}, nil
+			}
+			return []string{"table inet raspibuddy {", "}"}, nil
+		},
+		IsFileExistsFn: func(path string) bool {
+			return path == constants.NFTABLESCONF || isConfirmed
+		},
+		ShowUnitFn: func(string) (map[string]string, error) {
+			if isPending {
+				return map[string]string{"ActiveState": "active"}, nil
+			}
+			return map[string]string{"ActiveState": "inactive"}, nil
+		},
+		IsUnitFn: func(string) bool {
+			return isInstalled
+		},
+		NftRulesetFn: func() []string {
+			return []string{}
+		},
+	}
+}
+
+func fsys(plan *map[int](map[int]actions.Func)) *mocksys.Firewall {
+	execute := func(p map[int](map[int]actions.Func)) (rpi.Action, error) {
+		*plan = p
+		return rpi.Action{NumberOfSteps: uint16(len(p))}, nil
+	}
+
+	return &mocksys.Firewall{
+		ListFn: func(isInstalled bool, isPending bool, ruleset []string) (rpi.Firewall, error) {
+			return rpi.Firewall{IsInstalled: isInstalled, IsPending: isPending, Policy: "drop", Rules: rules}, nil
+		},
+		ExecuteFRFn: execute,
+		ExecuteCFFn: execute,
+	}
+}
+
+// steps returns the name and the main argument of each step of a plan
+func steps(plan map[int](map[int]actions.Func)) []string {
+	result := []string{}
+	for i := 1; i <= len(plan); i++ {
+		switch arg := plan[i][1].Argument[0].(type) {
+		case actions.RF:
+			result = append(result, plan[i][1].Name+" "+arg.Path)
+		case actions.MU:
+			result = append(result, plan[i][1].Name+" "+arg.Action+" "+arg.Unit)
+		case actions.EBC:
+			result = append(result, plan[i][1].Name+" "+arg.Command)
+		}
+	}
+	return result
+}
+
+var (
+	safe = []string{
+		"restore_file " + constants.FIREWALLPENDING,
+		"execute_bash_command systemctl stop raspibuddy-firewall-rollback.timer 2>/dev/null; systemctl reset-failed raspibuddy-firewall-rollback.service 2>/dev/null; " +
+			"systemd-run --unit=raspibuddy-firewall-rollback --on-active=60 --timer-property=AccuracySec=1s /bin/sh -c 'nft -f " + constants.NFTABLESRULES + "'",
+		"execute_bash_command nft -f " + constants.FIREWALLPENDING,
+	}
+
+	persist = []string{
+		"execute_bash_command systemctl stop raspibuddy-firewall-rollback.timer 2>/dev/null || true",
+		"restore_file " + constants.NFTABLESRULES,
+		"restore_file " + constants.NFTABLESCONF,
+		"manage_unit enable nftables.service",
+	}
+)
+
+func TestExecute(t *testing.T) {
+	cases := []struct {
+		name        string
+		isInstalled bool
+		isPending   bool
+		isConfirmed bool
+		execute     func(*firewall.Firewall) (rpi.Action, error)
+		wantedErr   error
+		wantedSteps []string
+		wantedTable string
+	}{
+		{
+			name:      "error: nftables not installed",
+			execute:   func(f *firewall.Firewall) (rpi.Action, error) { return f.ExecuteSFP("drop", 60) },
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to missing packages - nftables should be installed"),
+		},
+		{
+			name:        "error: invalid action",
+			isInstalled: true,
+			execute: func(f *firewall.Firewall) (rpi.Action, error) {
+				return f.ExecuteAFR(rpi.FirewallRule{Action: "reject"}, 60)
+			},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid action - should be allow or deny"),
+		},
+		{
+			name:        "error: invalid protocol",
+			isInstalled: true,
+			execute: func(f *firewall.Firewall) (rpi.Action, error) {
+				return f.ExecuteAFR(rpi.FirewallRule{Action: "allow", Protocol: "sctp"}, 60)
+			},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid protocol - should be tcp or udp"),
+		},
+		{
+			name:        "error: port without protocol",
+			isInstalled: true,
+			execute: func(f *firewall.Firewall) (rpi.Action, error) {
+				return f.ExecuteAFR(rpi.FirewallRule{Action: "allow", Port: 80}, 60)
+			},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to a missing protocol - a port needs tcp or udp"),
+		},
+		{
+			name:        "error: invalid source",
+			isInstalled: true,
+			execute: func(f *firewall.Firewall) (rpi.Action, error) {
+				return f.ExecuteAFR(rpi.FirewallRule{Action: "allow", Source: "192.168.1.0/33"}, 60)
+			},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid source - should be an ip address or a network in CIDR notation (ex: 192.168.1.0/24)"),
+		},
+		{
+			name:        "error: existing rule",
+			isInstalled: true,
+			execute: func(f *firewall.Firewall) (rpi.Action, error) {
+				return f.ExecuteAFR(rpi.FirewallRule{Action: "allow", Protocol: "tcp", Port: 22, Source: "192.168.1.10/24"}, 60)
+			},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an existing rule - 1"),
+		},
+		{
+			name:        "error: invalid timeout",
+			isInstalled: true,
+			execute: func(f *firewall.Firewall) (rpi.Action, error) {
+				return f.ExecuteAFR(rpi.FirewallRule{Action: "allow", Protocol: "udp", Port: 53}, 3601)
+			},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid timeout - should be 0 to apply without confirmation, or up to 3600 seconds"),
+		},
+		{
+			name:        "success: add a rule safely",
+			isInstalled: true,
+			isConfirmed: true,
+			execute: func(f *firewall.Firewall) (rpi.Action, error) {
+				return f.ExecuteAFR(rpi.FirewallRule{Action: "deny", Protocol: "udp", Source: "fd00::1"}, 60)
+			},
+			wantedSteps: safe,
+			wantedTable: header + "\t\ttype filter hook input priority filter; policy drop;\n\t\tjump base\n" +
+				"\t\tip saddr 192.168.1.66 drop\n\t\tip6 saddr fd00::1 meta l4proto udp drop\n\t\tip saddr 192.168.1.0/24 tcp dport 22 accept\n\t}\n}\n",
+		},
+		{
+			name:        "success: add a rule without confirmation",
+			isInstalled: true,
+			execute: func(f *firewall.Firewall) (rpi.Action, error) {
+				return f.ExecuteAFR(rpi.FirewallRule{Action: "allow", Protocol: "tcp", Port: 3333}, 0)
+			},
+			wantedSteps: append([]string{"restore_file " + constants.FIREWALLPENDING, "execute_bash_command nft -f " + constants.FIREWALLPENDING}, persist...),
+			wantedTable: header + "\t\ttype filter hook input priority filter; policy drop;\n\t\tjump base\n" +
+				"\t\tip saddr 192.168.1.66 drop\n\t\tip saddr 192.168.1.0/24 tcp dport 22 accept\n\t\ttcp dport 3333 accept\n\t}\n}\n",
+		},
+		{
+			name:        "error: unknown rule",
+			isInstalled: true,
+			execute:     func(f *firewall.Firewall) (rpi.Action, error) { return f.ExecuteRFR(2, 60) },
+			wantedErr:   echo.NewHTTPError(http.StatusNotFound, "Not found - rule 2 does not exist"),
+		},
+		{
+			name:        "success: remove a rule",
+			isInstalled: true,
+			execute:     func(f *firewall.Firewall) (rpi.Action, error) { return f.ExecuteRFR(0, 60) },
+			wantedSteps: []string{
+				"restore_file " + constants.FIREWALLPENDING,
+				"execute_bash_command systemctl stop raspibuddy-firewall-rollback.timer 2>/dev/null; systemctl reset-failed raspibuddy-firewall-rollback.service 2>/dev/null; " +
+					"systemd-run --unit=raspibuddy-firewall-rollback --on-active=60 --timer-property=AccuracySec=1s /bin/sh -c 'nft delete table inet raspibuddy'",
+				"execute_bash_command nft -f " + constants.FIREWALLPENDING,
+			},
+			wantedTable: header + "\t\ttype filter hook input priority filter; policy drop;\n\t\tjump base\n" +
+				"\t\tip saddr 192.168.1.0/24 tcp dport 22 accept\n\t}\n}\n",
+		},
+		{
+			name:        "error: unknown preset",
+			isInstalled: true,
+			execute:     func(f *firewall.Firewall) (rpi.Action, error) { return f.ExecuteFP("ssh-only", 60) },
+			wantedErr:   echo.NewHTTPError(http.StatusNotFound, "Not found - ssh-only should be one of open, lan-only, api-only"),
+		},
+		{
+			name:        "success: lan-only preset",
+			isInstalled: true,
+			isConfirmed: true,
+			execute:     func(f *firewall.Firewall) (rpi.Action, error) { return f.ExecuteFP("lan-only", 60) },
+			wantedSteps: safe,
+			wantedTable: header + "\t\ttype filter hook input priority filter; policy drop;\n\t\tjump base\n" +
+				"\t\tip saddr 10.0.0.0/8 accept\n\t\tip saddr 172.16.0.0/12 accept\n\t\tip saddr 192.168.0.0/16 accept\n" +
+				"\t\tip6 saddr fe80::/10 accept\n\t\tip6 saddr fc00::/7 accept\n\t}\n}\n",
+		},
+		{
+			name:        "success: api-only preset",
+			isInstalled: true,
+			isConfirmed: true,
+			execute:     func(f *firewall.Firewall) (rpi.Action, error) { return f.ExecuteFP("api-only", 60) },
+			wantedSteps: safe,
+			wantedTable: header + "\t\ttype filter hook input priority filter; policy drop;\n\t\tjump base\n\t\ttcp dport 3333 accept\n\t}\n}\n",
+		},
+		{
+			name:        "success: open preset",
+			isInstalled: true,
+			isConfirmed: true,
+			execute:     func(f *firewall.Firewall) (rpi.Action, error) { return f.ExecuteFP("open", 60) },
+			wantedSteps: safe,
+			wantedTable: header + "\t\ttype filter hook input priority filter; policy accept;\n\t\tjump base\n\t}\n}\n",
+		},
+		{
+			name:        "error: invalid policy",
+			isInstalled: true,
+			execute:     func(f *firewall.Firewall) (rpi.Action, error) { return f.ExecuteSFP("reject", 60) },
+			wantedErr:   echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid policy - should be accept or drop"),
+		},
+		{
+			name:        "success: policy",
+			isInstalled: true,
+			isConfirmed: true,
+			execute:     func(f *firewall.Firewall) (rpi.Action, error) { return f.ExecuteSFP("accept", 60) },
+			wantedSteps: safe,
+			wantedTable: header + "\t\ttype filter hook input priority filter; policy accept;\n\t\tjump base\n" +
+				"\t\tip saddr 192.168.1.66 drop\n\t\tip saddr 192.168.1.0/24 tcp dport 22 accept\n\t}\n}\n",
+		},
+		{
+			name:        "error: nothing to confirm",
+			isInstalled: true,
+			execute:     func(f *firewall.Firewall) (rpi.Action, error) { return f.ExecuteCF() },
+			wantedErr:   echo.NewHTTPError(http.StatusNotFound, "Not found - no firewall change is waiting for its confirmation"),
+		},
+		{
+			name:        "success: confirm",
+			isInstalled: true,
+			isPending:   true,
+			execute:     func(f *firewall.Firewall) (rpi.Action, error) { return f.ExecuteCF() },
+			wantedSteps: persist,
+			wantedTable: "table inet raspibuddy {\n}\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var plan map[int](map[int]actions.Func)
+
+			f := firewall.New(fsys(&plan), actions.New(), infos(tc.isInstalled, tc.isPending, tc.isConfirmed), 3333)
+			_, err := tc.execute(f)
+			assert.Equal(t, tc.wantedErr, err)
+			if tc.wantedErr != nil {
+				return
+			}
+
+			assert.Equal(t, tc.wantedSteps, steps(plan))
+			for i := 1; i <= len(plan); i++ {
+				rf, ok := plan[i][1].Argument[0].(actions.RF)
+				switch {
+				case ok && (rf.Path == constants.FIREWALLPENDING || rf.Path == constants.NFTABLESRULES):
+					assert.Equal(t, tc.wantedTable, string(rf.Content))
+				case ok && rf.Path == constants.NFTABLESCONF:
+					assert.Equal(t, "#!/usr/sbin/nft -f\n\nflush ruleset\n\ninclude \""+constants.NFTABLESRULES+"\"\n", string(rf.Content))
+				}
+			}
+		})
+	}
+}
+
+func TestExecuteFPHotspot(t *testing.T) {
+	var plan map[int](map[int]actions.Func)
+
+	i := infos(true, false, true)
+	i.ReadFileFn = func(path string) ([]string, error) {
+		switch path {
+		case constants.HOSTAPDCONF:
+			return []string{"interface=wlan0", "driver=nl80211", "ssid=IEEE"}, nil
+		case constants.NMHOTSPOT:
+			return []string{"[connection]", "id=raspibuddy-hotspot", "interface-name=wlan1"}, nil
+		}
+		return []string{"table inet raspibuddy {", "}"}, nil
+	}
+
+	f := firewall.New(fsys(&plan), actions.New(), i, 3333)
+	_, err := f.ExecuteFP("api-only", 60)
+	assert.Nil(t, err)
+	assert.Equal(t, safe, steps(plan))
+	assert.Equal(
+		t,
+		base+"\t\tiifname { \"wlan0\", \"wlan1\" } meta l4proto { tcp, udp } th dport 53 accept\n\t}\n\n\tchain input {\n"+
+			"\t\ttype filter hook input priority filter; policy drop;\n\t\tjump base\n\t\ttcp dport 3333 accept\n\t}\n}\n",
+		string(plan[1][1].Argument[0].(actions.RF).Content),
+	)
+}
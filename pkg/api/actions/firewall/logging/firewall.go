@@ -0,0 +1,116 @@
+package firewall
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/firewall"
+)
+
+// New creates a new Firewall logging service instance.
+func New(svc firewall.Service, logger rpi.Logger) *LogService {
+	return &LogService{
+		Service: svc,
+		logger:  logger,
+	}
+}
+
+// LogService represents a Firewall logging service.
+type LogService struct {
+	firewall.Service
+	logger rpi.Logger
+}
+
+const name = "firewall"
+
+// List is the logging function attached to the List firewall services and responsible for logging it out.
+func (ls *LogService) List(ctx echo.Context) (resp rpi.Firewall, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, "request: list firewall rules", err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.List()
+}
+
+// ExecuteAFR is the logging function attached to the ExecuteAFR firewall services and responsible for logging it out.
+func (ls *LogService) ExecuteAFR(ctx echo.Context, rule rpi.FirewallRule, timeout int) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: add firewall rule %v %v %v from %v", rule.Action, rule.Protocol, rule.Port, rule.Source), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteAFR(rule, timeout)
+}
+
+// ExecuteRFR is the logging function attached to the ExecuteRFR firewall services and responsible for logging it out.
+func (ls *LogService) ExecuteRFR(ctx echo.Context, id int, timeout int) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: remove firewall rule %v", id), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteRFR(id, timeout)
+}
+
+// ExecuteFP is the logging function attached to the ExecuteFP firewall services and responsible for logging it out.
+func (ls *LogService) ExecuteFP(ctx echo.Context, preset string, timeout int) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: apply firewall preset %v", preset), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteFP(preset, timeout)
+}
+
+// ExecuteSFP is the logging function attached to the ExecuteSFP firewall services and responsible for logging it out.
+func (ls *LogService) ExecuteSFP(ctx echo.Context, policy string, timeout int) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: set firewall policy to %v", policy), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteSFP(policy, timeout)
+}
+
+// ExecuteCF is the logging function attached to the ExecuteCF firewall services and responsible for logging it out.
+func (ls *LogService) ExecuteCF(ctx echo.Context) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, "request: confirm firewall rules", err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteCF()
+}
@@ -0,0 +1,212 @@
+package sys
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// table is the nftables table holding the raspibuddy rules, and input its chain
+const (
+	table = "raspibuddy"
+	input = "input"
+)
+
+// ruleset is the output of 'nft -j list ruleset', each object holding one of a table, a chain or a rule
+type ruleset struct {
+	Nftables []struct {
+		Table *struct {
+			Family string `json:"family"`
+			Name   string `json:"name"`
+		} `json:"table"`
+		Chain *struct {
+			Family string `json:"family"`
+			Table  string `json:"table"`
+			Name   string `json:"name"`
+			Type   string `json:"type"`
+			Hook   string `json:"hook"`
+			Prio   int    `json:"prio"`
+			Policy string `json:"policy"`
+		} `json:"chain"`
+		Rule *struct {
+			Family string                       `json:"family"`
+			Table  string                       `json:"table"`
+			Chain  string                       `json:"chain"`
+			Expr   []map[string]json.RawMessage `json:"expr"`
+		} `json:"rule"`
+	} `json:"nftables"`
+}
+
+// match is the expression comparing a payload field or a meta key (left) to a value (right)
+type match struct {
+	Left struct {
+		Payload *struct {
+			Protocol string `json:"protocol"`
+			Field    string `json:"field"`
+		} `json:"payload"`
+		Meta *struct {
+			Key string `json:"key"`
+		} `json:"meta"`
+	} `json:"left"`
+	Right json.RawMessage `json:"right"`
+}
+
+// Firewall represents an empty Firewall entity on the current system.
+type Firewall struct{}
+
+// List returns the tables and chains of the nftables ruleset, along with the policy and the rules
+// of the raspibuddy table. The rules not matching a source, a protocol or a port are left out.
+func (f Firewall) List(isInstalled bool, isPending bool, lines []string) (rpi.Firewall, error) {
+	result := rpi.Firewall{
+		IsInstalled: isInstalled,
+		IsPending:   isPending,
+		Policy:      "accept",
+		Rules:       []rpi.FirewallRule{},
+		Tables:      []rpi.FirewallTable{},
+	}
+
+	rs := ruleset{}
+	if len(lines) > 0 {
+		if err := json.Unmarshal([]byte(strings.Join(lines, "")), &rs); err != nil {
+			return rpi.Firewall{}, err
+		}
+	}
+
+	for _, o := range rs.Nftables {
+		switch {
+		case o.Table != nil:
+			result.Tables = append(result.Tables, rpi.FirewallTable{Family: o.Table.Family, Name: o.Table.Name, Chains: []rpi.FirewallChain{}})
+		case o.Chain != nil:
+			if t := find(result.Tables, o.Chain.Family, o.Chain.Table); t != nil {
+				t.Chains = append(t.Chains, rpi.FirewallChain{
+					Name:     o.Chain.Name,
+					Type:     o.Chain.Type,
+					Hook:     o.Chain.Hook,
+					Priority: o.Chain.Prio,
+					Policy:   o.Chain.Policy,
+				})
+			}
+			if o.Chain.Family == "inet" && o.Chain.Table == table && o.Chain.Name == input && o.Chain.Policy != "" {
+				result.Policy = o.Chain.Policy
+			}
+		case o.Rule != nil:
+			if t := find(result.Tables, o.Rule.Family, o.Rule.Table); t != nil {
+				for i := range t.Chains {
+					if t.Chains[i].Name == o.Rule.Chain {
+						t.Chains[i].Rules++
+					}
+				}
+			}
+			if o.Rule.Family != "inet" || o.Rule.Table != table || o.Rule.Chain != input {
+				continue
+			}
+			if r, ok := rule(o.Rule.Expr); ok {
+				r.ID = len(result.Rules)
+				result.Rules = append(result.Rules, r)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// ExecuteFR returns an action response after applying the firewall rules
+func (f Firewall) ExecuteFR(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return execute(actions.FirewallRules, plan)
+}
+
+// ExecuteCF returns an action response after confirming the firewall rules
+func (f Firewall) ExecuteCF(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return execute(actions.ConfirmFirewall, plan)
+}
+
+func execute(name string, plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	actionStartTime := uint64(time.Now().Unix())
+	progressInit := actions.FlattenPlan(plan)
+	progress, exitStatus := actions.ExecutePlan(plan, progressInit)
+
+	return rpi.Action{
+		Name:          name,
+		NumberOfSteps: uint16(len(progressInit)),
+		Progress:      progress,
+		ExitStatus:    exitStatus,
+		StartTime:     actionStartTime,
+		EndTime:       uint64(time.Now().Unix()),
+	}, nil
+}
+
+// find returns the table of a family with a name, nil when missing
+func find(tables []rpi.FirewallTable, family string, name string) *rpi.FirewallTable {
+	for i := range tables {
+		if tables[i].Family == family && tables[i].Name == name {
+			return &tables[i]
+		}
+	}
+	return nil
+}
+
+// rule returns the rule made of the expressions of a nftables rule (ex: ip saddr 10.0.0.0/8 tcp dport 22 accept),
+// false when one of them is not a source, a protocol, a port or an accept or drop verdict
+func rule(exprs []map[string]json.RawMessage) (rpi.FirewallRule, bool) {
+	result := rpi.FirewallRule{}
+
+	for _, e := range exprs {
+		switch {
+		case e["accept"] != nil:
+			result.Action = "allow"
+		case e["drop"] != nil:
+			result.Action = "deny"
+		case e["match"] != nil:
+			m := match{}
+			if err := json.Unmarshal(e["match"], &m); err != nil {
+				return rpi.FirewallRule{}, false
+			}
+
+			switch {
+			case m.Left.Payload != nil && m.Left.Payload.Field == "saddr" && (m.Left.Payload.Protocol == "ip" || m.Left.Payload.Protocol == "ip6"):
+				if result.Source = address(m.Right); result.Source == "" {
+					return rpi.FirewallRule{}, false
+				}
+			case m.Left.Payload != nil && m.Left.Payload.Field == "dport" && (m.Left.Payload.Protocol == "tcp" || m.Left.Payload.Protocol == "udp"):
+				if err := json.Unmarshal(m.Right, &result.Port); err != nil {
+					return rpi.FirewallRule{}, false
+				}
+				result.Protocol = m.Left.Payload.Protocol
+			case m.Left.Meta != nil && m.Left.Meta.Key == "l4proto":
+				if err := json.Unmarshal(m.Right, &result.Protocol); err != nil {
+					return rpi.FirewallRule{}, false
+				}
+			default:
+				return rpi.FirewallRule{}, false
+			}
+		default:
+			return rpi.FirewallRule{}, false
+		}
+	}
+
+	return result, result.Action != ""
+}
+
+// address returns the address matched by a rule, an ip address or a prefix in CIDR notation
+func address(right json.RawMessage) string {
+	var ip string
+	if err := json.Unmarshal(right, &ip); err == nil {
+		return ip
+	}
+
+	prefix := struct {
+		Prefix struct {
+			Addr string `json:"addr"`
+			Len  int    `json:"len"`
+		} `json:"prefix"`
+	}{}
+	if err := json.Unmarshal(right, &prefix); err == nil && prefix.Prefix.Addr != "" {
+		return fmt.Sprintf("%v/%v", prefix.Prefix.Addr, prefix.Prefix.Len)
+	}
+
+	return ""
+}
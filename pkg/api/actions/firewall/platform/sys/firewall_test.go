@@ -0,0 +1,128 @@
+package sys_test
+
+import (
+	"testing"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/firewall/platform/sys"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/stretchr/testify/assert"
+)
+
+var ruleset = []string{
+	`{"nftables": [{"metainfo": {"version": "1.0.6", "release_name": "Lester Gooch #5", "json_schema_version": 1}}, ` +
+		`{"table": {"family": "ip", "name": "nat", "handle": 1}}, ` +
+		`{"chain": {"family": "ip", "table": "nat", "name": "POSTROUTING", "handle": 1, "type": "nat", "hook": "postrouting", "prio": 100, "policy": "accept"}}, ` +
+		`{"rule": {"family": "ip", "table": "nat", "chain": "POSTROUTING", "handle": 2, "expr": [{"masquerade": null}]}}, ` +
+		`{"table": {"family": "inet", "name": "raspibuddy", "handle": 2}}, ` +
+		`{"chain": {"family": "inet", "table": "raspibuddy", "name": "base", "handle": 1}}, ` +
+		`{"chain": {"family": "inet", "table": "raspibuddy", "name": "input", "handle": 2, "type": "filter", "hook": "input", "prio": 0, "policy": "drop"}}, ` +
+		`{"rule": {"family": "inet", "table": "raspibuddy", "chain": "base", "handle": 3, "expr": [{"match": {"op": "in", "left": {"ct": {"key": "state"}}, "right": ["established", "related"]}}, {"accept": null}]}}, ` +
+		`{"rule": {"family": "inet", "table": "raspibuddy", "chain": "input", "handle": 4, "expr": [{"jump": {"target": "base"}}]}}, ` +
+		`{"rule": {"family": "inet", "table": "raspibuddy", "chain": "input", "handle": 5, "expr": [{"match": {"op": "==", "left": {"payload": {"protocol": "ip", "field": "saddr"}}, "right": "192.168.1.66"}}, {"drop": null}]}}, ` +
+		`{"rule": {"family": "inet", "table": "raspibuddy", "chain": "input", "handle": 6, "expr": [{"match": {"op": "==", "left": {"payload": {"protocol": "ip", "field": "saddr"}}, "right": {"prefix": {"addr": "192.168.1.0", "len": 24}}}}, {"match": {"op": "==", "left": {"payload": {"protocol": "tcp", "field": "dport"}}, "right": 22}}, {"accept": null}]}}, ` +
+		`{"rule": {"family": "inet", "table": "raspibuddy", "chain": "input", "handle": 7, "expr": [{"match": {"op": "==", "left": {"meta": {"key": "l4proto"}}, "right": "udp"}}, {"accept": null}]}}, ` +
+		`{"rule": {"family": "inet", "table": "raspibuddy", "chain": "input", "handle": 8, "expr": [{"match": {"op": "==", "left": {"payload": {"protocol": "ip6", "field": "saddr"}}, "right": {"prefix": {"addr": "fc00::", "len": 7}}}}, {"accept": null}]}}, ` +
+		`{"rule": {"family": "inet", "table": "raspibuddy", "chain": "input", "handle": 9, "expr": [{"counter": {"packets": 0, "bytes": 0}}, {"accept": null}]}}]}`,
+}
+
+func TestList(t *testing.T) {
+	cases := []struct {
+		name         string
+		isInstalled  bool
+		isPending    bool
+		ruleset      []string
+		wantedResult rpi.Firewall
+		wantedErr    bool
+	}{
+		{
+			name:        "no ruleset",
+			isInstalled: false,
+			ruleset:     []string{},
+			wantedResult: rpi.Firewall{
+				Policy: "accept",
+				Rules:  []rpi.FirewallRule{},
+				Tables: []rpi.FirewallTable{},
+			},
+		},
+		{
+			name:        "invalid json",
+			isInstalled: true,
+			ruleset:     []string{`{"nftables": [`},
+			wantedErr:   true,
+		},
+		{
+			name:        "raspibuddy table",
+			isInstalled: true,
+			isPending:   true,
+			ruleset:     ruleset,
+			wantedResult: rpi.Firewall{
+				IsInstalled: true,
+				IsPending:   true,
+				Policy:      "drop",
+				Rules: []rpi.FirewallRule{
+					{ID: 0, Action: "deny", Source: "192.168.1.66"},
+					{ID: 1, Action: "allow", Protocol: "tcp", Port: 22, Source: "192.168.1.0/24"},
+					{ID: 2, Action: "allow", Protocol: "udp"},
+					{ID: 3, Action: "allow", Source: "fc00::/7"},
+				},
+				Tables: []rpi.FirewallTable{
+					{
+						Family: "ip",
+						Name:   "nat",
+						Chains: []rpi.FirewallChain{
+							{Name: "POSTROUTING", Type: "nat", Hook: "postrouting", Priority: 100, Policy: "accept", Rules: 1},
+						},
+					},
+					{
+						Family: "inet",
+						Name:   "raspibuddy",
+						Chains: []rpi.FirewallChain{
+							{Name: "base", Rules: 1},
+							{Name: "input", Type: "filter", Hook: "input", Policy: "drop", Rules: 6},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := sys.Firewall{}
+			result, err := s.List(tc.isInstalled, tc.isPending, tc.ruleset)
+			assert.Equal(t, tc.wantedResult, result)
+			assert.Equal(t, tc.wantedErr, err != nil)
+		})
+	}
+}
+
+func TestExecute(t *testing.T) {
+	s := sys.Firewall{}
+	cases := []struct {
+		name       string
+		execute    func(map[int](map[int]actions.Func)) (rpi.Action, error)
+		wantedName string
+	}{
+		{name: "rules", execute: s.ExecuteFR, wantedName: actions.FirewallRules},
+		{name: "confirm", execute: s.ExecuteCF, wantedName: actions.ConfirmFirewall},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.execute(map[int](map[int]actions.Func){
+				1: {
+					1: {
+						Name:      "funcA",
+						Reference: func(arg interface{}) (rpi.Exec, error) { return rpi.Exec{ExitStatus: 1}, nil },
+						Argument:  []interface{}{actions.EBC{}},
+					},
+				},
+			})
+			assert.Equal(t, tc.wantedName, result.Name)
+			assert.Equal(t, uint16(1), result.NumberOfSteps)
+			assert.Equal(t, uint8(1), result.ExitStatus)
+			assert.Nil(t, err)
+		})
+	}
+}
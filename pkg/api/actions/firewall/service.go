@@ -0,0 +1,53 @@
+package firewall
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// Service represents all Firewall application services.
+type Service interface {
+	List() (rpi.Firewall, error)
+	ExecuteAFR(rpi.FirewallRule, int) (rpi.Action, error)
+	ExecuteRFR(int, int) (rpi.Action, error)
+	ExecuteFP(string, int) (rpi.Action, error)
+	ExecuteSFP(string, int) (rpi.Action, error)
+	ExecuteCF() (rpi.Action, error)
+}
+
+// Firewall represents a Firewall application service.
+type Firewall struct {
+	fsys FSYS
+	a    Actions
+	i    Infos
+	port uint16
+}
+
+// FSYS represents a Firewall repository service.
+type FSYS interface {
+	List(bool, bool, []string) (rpi.Firewall, error)
+	ExecuteFR(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteCF(map[int](map[int]actions.Func)) (rpi.Action, error)
+}
+
+// Actions represents the actions interface
+type Actions interface {
+	RestoreFile(interface{}) (rpi.Exec, error)
+	ManageUnit(interface{}) (rpi.Exec, error)
+	ExecuteBashCommand(interface{}) (rpi.Exec, error)
+}
+
+// Infos represents the infos interface
+type Infos interface {
+	ReadFile(string) ([]string, error)
+	IsFileExists(string) bool
+	ShowUnit(string) (map[string]string, error)
+	IsUnit(string) bool
+	NftRuleset() []string
+}
+
+// New creates a Firewall application service instance.
+// port is the port the api listens on, allowed by the api-only preset.
+func New(fsys FSYS, a Actions, i Infos, port uint16) *Firewall {
+	return &Firewall{fsys: fsys, a: a, i: i, port: port}
+}
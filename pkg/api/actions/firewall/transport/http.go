@@ -0,0 +1,138 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/firewall"
+)
+
+// DefaultTimeout is the time, in seconds, given to confirm a change when none is given.
+// A timeout of 0 applies a change without confirmation.
+const DefaultTimeout = 60
+
+// HTTP is a struct implementing a core application service.
+type HTTP struct {
+	svc firewall.Service
+}
+
+// NewHTTP creates new firewall http service
+func NewHTTP(svc firewall.Service, r *echo.Group) {
+	h := HTTP{svc}
+	cr := r.Group("/firewall")
+	cr.GET("", h.list)
+	cr.POST("/allow", h.allow)
+	cr.POST("/deny", h.deny)
+	cr.POST("/remove/:id", h.remove)
+	cr.POST("/preset/:preset", h.preset)
+	cr.POST("/policy/:policy", h.policy)
+	cr.POST("/confirm", h.confirm)
+}
+
+// timeout returns the timeout query parameter, DefaultTimeout by default
+func timeout(ctx echo.Context) (int, error) {
+	if ctx.QueryParam("timeout") == "" {
+		return DefaultTimeout, nil
+	}
+	timeout, err := strconv.Atoi(ctx.QueryParam("timeout"))
+	if err != nil {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid timeout - should be a number of seconds")
+	}
+	return timeout, nil
+}
+
+func (h *HTTP) list(ctx echo.Context) error {
+	result, err := h.svc.List()
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) allow(ctx echo.Context) error {
+	return h.add(ctx, firewall.Allow)
+}
+
+func (h *HTTP) deny(ctx echo.Context) error {
+	return h.add(ctx, firewall.Deny)
+}
+
+func (h *HTTP) add(ctx echo.Context, action string) error {
+	port := uint64(0)
+	if ctx.QueryParam("port") != "" {
+		var err error
+		if port, err = strconv.ParseUint(ctx.QueryParam("port"), 10, 16); err != nil || port == 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid port - should be 1 to 65535")
+		}
+	}
+
+	t, err := timeout(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.svc.ExecuteAFR(rpi.FirewallRule{
+		Action:   action,
+		Protocol: ctx.QueryParam("protocol"),
+		Port:     uint16(port),
+		Source:   ctx.QueryParam("source"),
+	}, t)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) remove(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || id < 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid id - should be a rule id")
+	}
+
+	t, err := timeout(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.svc.ExecuteRFR(id, t)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) preset(ctx echo.Context) error {
+	t, err := timeout(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.svc.ExecuteFP(ctx.Param("preset"), t)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) policy(ctx echo.Context) error {
+	t, err := timeout(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.svc.ExecuteSFP(ctx.Param("policy"), t)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) confirm(ctx echo.Context) error {
+	result, err := h.svc.ExecuteCF()
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
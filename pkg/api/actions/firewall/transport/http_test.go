@@ -0,0 +1,161 @@
+package transport_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/firewall"
+	"github.com/raspibuddy/rpi/pkg/api/actions/firewall/transport"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/raspibuddy/rpi/pkg/utl/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFirewall(t *testing.T) {
+	cases := []struct {
+		name         string
+		method       string
+		req          string
+		executeErr   error
+		wantedStatus int
+	}{
+		{
+			name:         "success: list",
+			method:       http.MethodGet,
+			req:          "",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "error: invalid port",
+			method:       http.MethodPost,
+			req:          "/allow?protocol=tcp&port=70000",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid timeout",
+			method:       http.MethodPost,
+			req:          "/allow?protocol=tcp&port=22&timeout=soon",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: ExecuteAFR result is nil",
+			method:       http.MethodPost,
+			req:          "/allow?protocol=tcp&port=22",
+			executeErr:   errors.New("test error"),
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:         "success: allow",
+			method:       http.MethodPost,
+			req:          "/allow?protocol=tcp&port=22&source=192.168.1.0/24",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "success: deny",
+			method:       http.MethodPost,
+			req:          "/deny?source=192.168.1.66&timeout=0",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "error: invalid id",
+			method:       http.MethodPost,
+			req:          "/remove/first",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: unknown id",
+			method:       http.MethodPost,
+			req:          "/remove/3",
+			wantedStatus: http.StatusNotFound,
+		},
+		{
+			name:         "success: remove",
+			method:       http.MethodPost,
+			req:          "/remove/0",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "error: unknown preset",
+			method:       http.MethodPost,
+			req:          "/preset/closed",
+			wantedStatus: http.StatusNotFound,
+		},
+		{
+			name:         "success: preset",
+			method:       http.MethodPost,
+			req:          "/preset/lan-only?timeout=30",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "success: policy",
+			method:       http.MethodPost,
+			req:          "/policy/drop",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "success: confirm",
+			method:       http.MethodPost,
+			req:          "/confirm",
+			wantedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			i := mock.Infos{
+				ReadFileFn: func(string) ([]string, error) {
+					return []string{}, nil
+				},
+				IsFileExistsFn: func(string) bool {
+					return true
+				},
+				ShowUnitFn: func(string) (map[string]string, error) {
+					return map[string]string{"ActiveState": "active"}, nil
+				},
+				IsUnitFn: func(string) bool {
+					return true
+				},
+				NftRulesetFn: func() []string {
+					return []string{}
+				},
+			}
+			execute := func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+				return rpi.Action{NumberOfSteps: 1}, tc.executeErr
+			}
+			fsys := &mocksys.Firewall{
+				ListFn: func(isInstalled bool, isPending bool, ruleset []string) (rpi.Firewall, error) {
+					return rpi.Firewall{IsInstalled: isInstalled, IsPending: isPending, Policy: "accept", Rules: []rpi.FirewallRule{{ID: 0, Action: "deny", Source: "10.0.0.1"}}}, nil
+				},
+				ExecuteFRFn: execute,
+				ExecuteCFFn: execute,
+			}
+			s := firewall.New(fsys, actions.New(), i, 3333)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/firewall" + tc.req
+
+			req, err := http.NewRequest(tc.method, path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
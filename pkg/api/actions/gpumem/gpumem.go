@@ -0,0 +1,68 @@
+package gpumem
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/bootconfig"
+)
+
+// List populates and returns the GPUMemory model.
+func (gm *GPUMem) List() (rpi.GPUMemory, error) {
+	board, err := gm.b.List()
+	if err != nil {
+		return rpi.GPUMemory{}, echo.NewHTTPError(http.StatusInternalServerError, "could not read the board")
+	}
+
+	config, err := gm.i.ReadFile(gm.i.GetConfigFiles()["bootconfig"].Path)
+	if err != nil {
+		return rpi.GPUMemory{}, echo.NewHTTPError(http.StatusInternalServerError, "could not read the boot config")
+	}
+
+	// vcgencmd is missing outside of raspberry pi os, the running split is then unknown
+	running, _ := gm.m.GPUMemory()
+
+	return gm.gmsys.List(board, config, running/1024/1024)
+}
+
+// ExecuteGM sets a gpu_mem key in the [all] section of /boot/config.txt and returns an action.
+// The new split applies at the next boot.
+func (gm *GPUMem) ExecuteGM(key string, value uint64) (rpi.Action, error) {
+	current, err := gm.List()
+	if err != nil {
+		return rpi.Action{}, err
+	}
+
+	if err := gm.gmsys.Check(current, key, value); err != nil {
+		return rpi.Action{}, err
+	}
+
+	plan := map[int](map[int]actions.Func){
+		1: {
+			1: {
+				Name:      actions.EditBootConfig,
+				Reference: gm.a.EditBootConfig,
+				Argument: []interface{}{
+					actions.BCO{
+						Path:      gm.i.GetConfigFiles()["bootconfig"].Path,
+						Operation: bootconfig.Set,
+						Section:   bootconfig.DefaultSection,
+						Key:       key,
+						Value:     strconv.FormatUint(value, 10),
+					},
+				},
+			},
+		},
+	}
+
+	result, err := gm.gmsys.ExecuteGM(plan)
+	if err != nil {
+		return result, err
+	}
+
+	result.IsRebootRequired = result.ExitStatus == 0
+	return result, nil
+}
@@ -0,0 +1,115 @@
+package gpumem_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/gpumem"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/stretchr/testify/assert"
+)
+
+func infos(readErr error) *mock.Infos {
+	return &mock.Infos{
+		GetConfigFilesFn: func() map[string]rpi.ConfigFileDetails {
+			return map[string]rpi.ConfigFileDetails{"bootconfig": {Path: "/boot/config.txt"}}
+		},
+		ReadFileFn: func(string) ([]string, error) {
+			return []string{"gpu_mem=64"}, readErr
+		},
+	}
+}
+
+func board(err error) *mock.Board {
+	return &mock.Board{
+		ListFn: func() (rpi.Board, error) {
+			return rpi.Board{SoC: "BCM2711", RAM: 4096}, err
+		},
+	}
+}
+
+var metrics = &mock.Metrics{
+	GPUMemoryFn: func() (uint64, error) {
+		return 76 * 1024 * 1024, nil
+	},
+}
+
+func gmsys(arg *actions.BCO, running *uint64, checkErr error, exitStatus uint8) *mocksys.GPUMem {
+	return &mocksys.GPUMem{
+		ListFn: func(b rpi.Board, config []string, r uint64) (rpi.GPUMemory, error) {
+			*running = r
+			return rpi.GPUMemory{IsSupported: true, RAM: b.RAM, Running: r}, nil
+		},
+		CheckFn: func(rpi.GPUMemory, string, uint64) error {
+			return checkErr
+		},
+		ExecuteGMFn: func(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+			*arg = plan[1][1].Argument[0].(actions.BCO)
+			return rpi.Action{NumberOfSteps: 1, ExitStatus: exitStatus}, nil
+		},
+	}
+}
+
+func TestList(t *testing.T) {
+	var arg actions.BCO
+	var running uint64
+
+	s := gpumem.New(gmsys(&arg, &running, nil, 0), nil, infos(nil), metrics, board(errors.New("test error")))
+	_, err := s.List()
+	assert.Equal(t, echo.NewHTTPError(http.StatusInternalServerError, "could not read the board"), err)
+
+	s = gpumem.New(gmsys(&arg, &running, nil, 0), nil, infos(errors.New("test error")), metrics, board(nil))
+	_, err = s.List()
+	assert.Equal(t, echo.NewHTTPError(http.StatusInternalServerError, "could not read the boot config"), err)
+
+	s = gpumem.New(gmsys(&arg, &running, nil, 0), nil, infos(nil), metrics, board(nil))
+	result, err := s.List()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(76), running)
+	assert.Equal(t, uint64(4096), result.RAM)
+}
+
+func TestExecuteGM(t *testing.T) {
+	cases := []struct {
+		name         string
+		checkErr     error
+		exitStatus   uint8
+		wantedArg    actions.BCO
+		wantedReboot bool
+		wantedErr    error
+	}{
+		{
+			name:      "error: check",
+			checkErr:  echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid value - the camera requires at least 128"),
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid value - the camera requires at least 128"),
+		},
+		{
+			name:       "failed execution does not require a reboot",
+			exitStatus: 1,
+			wantedArg:  actions.BCO{Path: "/boot/config.txt", Operation: "set", Section: "all", Key: "gpu_mem_1024", Value: "256"},
+		},
+		{
+			name:         "success",
+			wantedArg:    actions.BCO{Path: "/boot/config.txt", Operation: "set", Section: "all", Key: "gpu_mem_1024", Value: "256"},
+			wantedReboot: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var arg actions.BCO
+			var running uint64
+
+			s := gpumem.New(gmsys(&arg, &running, tc.checkErr, tc.exitStatus), actions.New(), infos(nil), metrics, board(nil))
+			result, err := s.ExecuteGM("gpu_mem_1024", 256)
+			assert.Equal(t, tc.wantedErr, err)
+			assert.Equal(t, tc.wantedArg, arg)
+			assert.Equal(t, tc.wantedReboot, result.IsRebootRequired)
+		})
+	}
+}
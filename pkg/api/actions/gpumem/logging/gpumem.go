@@ -0,0 +1,56 @@
+package gpumem
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/gpumem"
+)
+
+// New creates a new GPUMem logging service instance.
+func New(svc gpumem.Service, logger rpi.Logger) *LogService {
+	return &LogService{
+		Service: svc,
+		logger:  logger,
+	}
+}
+
+// LogService represents a GPUMem logging service.
+type LogService struct {
+	gpumem.Service
+	logger rpi.Logger
+}
+
+const name = "gpumem"
+
+// List is the logging function attached to the List gpumem services and responsible for logging it out.
+func (ls *LogService) List(ctx echo.Context) (resp rpi.GPUMemory, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, "request: list gpu memory split", err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.List()
+}
+
+// ExecuteGM is the logging function attached to the ExecuteGM gpumem services and responsible for logging it out.
+func (ls *LogService) ExecuteGM(ctx echo.Context, key string, value uint64) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: set %v to %v", key, value), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteGM(key, value)
+}
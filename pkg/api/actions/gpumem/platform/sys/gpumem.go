@@ -0,0 +1,164 @@
+package sys
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/bootconfig"
+)
+
+const (
+	// Min is the lowest gpu memory accepted by the firmware (in MB)
+	Min = 16
+
+	// CameraMin is the gpu memory required by the legacy camera stack (in MB)
+	CameraMin = 128
+
+	// unsupportedSoC ignores gpu_mem, its gpu memory being allocated dynamically
+	unsupportedSoC = "BCM2712"
+)
+
+// GPUMem represents an empty GPUMem entity on the current system.
+type GPUMem struct{}
+
+// List returns the gpu_mem keys set in the [all] section of config.txt, the value they configure for the board
+// and the gpu memory allocated at boot (in MB, 0 when unknown)
+func (g GPUMem) List(board rpi.Board, config []string, running uint64) (rpi.GPUMemory, error) {
+	result := rpi.GPUMemory{
+		IsSupported: board.SoC != unsupportedSoC,
+		RAM:         board.RAM,
+		Settings:    []rpi.GPUMemorySetting{},
+		Running:     running,
+		Min:         Min,
+		Max:         Max(board.RAM),
+		CameraMin:   CameraMin,
+	}
+
+	c := bootconfig.Parse(config)
+	cameraValue, _ := c.Get(bootconfig.DefaultSection, "start_x")
+	result.IsCameraEnabled = cameraValue == "1"
+
+	effective := EffectiveKey(board.RAM)
+	result.Configured = Default(board)
+	for _, key := range actions.GPUMemoryKeys {
+		setting := rpi.GPUMemorySetting{Key: key, Max: KeyMax(key, board.RAM)}
+		if value, isFound := c.Get(bootconfig.DefaultSection, key); isFound {
+			if n, err := strconv.ParseUint(value, 10, 64); err == nil {
+				setting.Value = n
+				setting.IsSet = true
+			}
+		}
+		result.Settings = append(result.Settings, setting)
+	}
+
+	// a key matching the board RAM overrides gpu_mem
+	for k, s := range result.Settings {
+		if s.IsSet && (s.Key == effective || (s.Key == "gpu_mem" && !isSet(result.Settings, effective))) {
+			result.Settings[k].IsEffective = true
+			result.Configured = s.Value
+		}
+	}
+
+	result.IsRebootRequired = running > 0 && running != result.Configured
+
+	return result, nil
+}
+
+// Check validates a gpu memory value set with a key against the RAM of the board and the camera requirements
+func (g GPUMem) Check(gm rpi.GPUMemory, key string, value uint64) error {
+	if !gm.IsSupported {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an unsupported board - the gpu memory is allocated dynamically")
+	}
+
+	max := KeyMax(key, gm.RAM)
+	if value < Min || value > max {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to an invalid value - %v should be between %v and %v", key, Min, max))
+	}
+
+	effective := EffectiveKey(gm.RAM)
+	if key == "gpu_mem" && isSet(gm.Settings, effective) {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to an invalid key - %v overrides gpu_mem on this board", effective))
+	}
+
+	if gm.IsCameraEnabled && value < CameraMin && (key == effective || key == "gpu_mem") {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to an invalid value - the camera requires at least %v", CameraMin))
+	}
+
+	return nil
+}
+
+// ExecuteGM returns an action response after setting the gpu memory split
+func (g GPUMem) ExecuteGM(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	actionStartTime := uint64(time.Now().Unix())
+	progressInit := actions.FlattenPlan(plan)
+	progress, exitStatus := actions.ExecutePlan(plan, progressInit)
+
+	return rpi.Action{
+		Name:          actions.GPUMemory,
+		NumberOfSteps: uint16(len(progressInit)),
+		Progress:      progress,
+		ExitStatus:    exitStatus,
+		StartTime:     actionStartTime,
+		EndTime:       uint64(time.Now().Unix()),
+	}, nil
+}
+
+// Max returns the highest gpu memory (in MB) leaving enough memory to the ARM cores of a board
+func Max(ram uint64) uint64 {
+	switch {
+	case ram <= 256:
+		return 192
+	case ram <= 512:
+		return 448
+	default:
+		return 944
+	}
+}
+
+// KeyMax returns the highest value of a gpu_mem key, gpu_mem_<n> keys applying to boards having n MB of RAM
+func KeyMax(key string, ram uint64) uint64 {
+	switch key {
+	case "gpu_mem_256":
+		return Max(256)
+	case "gpu_mem_512":
+		return Max(512)
+	case "gpu_mem_1024":
+		return Max(1024)
+	default:
+		return Max(ram)
+	}
+}
+
+// EffectiveKey returns the gpu_mem_<n> key overriding gpu_mem on a board
+func EffectiveKey(ram uint64) string {
+	switch {
+	case ram <= 256:
+		return "gpu_mem_256"
+	case ram <= 512:
+		return "gpu_mem_512"
+	default:
+		return "gpu_mem_1024"
+	}
+}
+
+// Default returns the gpu memory (in MB) of a board without gpu_mem key
+func Default(board rpi.Board) uint64 {
+	if board.SoC == "BCM2711" {
+		return 76
+	}
+	return 64
+}
+
+func isSet(settings []rpi.GPUMemorySetting, key string) bool {
+	for _, s := range settings {
+		if s.Key == key && s.IsSet {
+			return true
+		}
+	}
+	return false
+}
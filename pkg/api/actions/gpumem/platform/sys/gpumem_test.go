@@ -0,0 +1,172 @@
+package sys_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/gpumem/platform/sys"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	cases := []struct {
+		name             string
+		board            rpi.Board
+		config           []string
+		running          uint64
+		wantedConfigured uint64
+		wantedEffective  string
+		wantedCamera     bool
+		wantedReboot     bool
+		wantedSupported  bool
+	}{
+		{
+			name:             "default on a pi 4",
+			board:            rpi.Board{SoC: "BCM2711", RAM: 4096},
+			config:           []string{"dtparam=audio=on"},
+			running:          76,
+			wantedConfigured: 76,
+			wantedSupported:  true,
+		},
+		{
+			name:             "gpu_mem set",
+			board:            rpi.Board{SoC: "BCM2837", RAM: 1024},
+			config:           []string{"gpu_mem=128", "start_x=1", "[pi4]", "gpu_mem_1024=256"},
+			running:          64,
+			wantedConfigured: 128,
+			wantedEffective:  "gpu_mem",
+			wantedCamera:     true,
+			wantedReboot:     true,
+			wantedSupported:  true,
+		},
+		{
+			name:             "gpu_mem_512 overrides gpu_mem",
+			board:            rpi.Board{SoC: "BCM2835", RAM: 512},
+			config:           []string{"gpu_mem=128", "gpu_mem_512=256", "gpu_mem_1024=512"},
+			wantedConfigured: 256,
+			wantedEffective:  "gpu_mem_512",
+			wantedSupported:  true,
+		},
+		{
+			name:             "unsupported board",
+			board:            rpi.Board{SoC: "BCM2712", RAM: 8192},
+			wantedConfigured: 64,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := sys.GPUMem{}
+			result, err := s.List(tc.board, tc.config, tc.running)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.wantedConfigured, result.Configured)
+			assert.Equal(t, tc.wantedCamera, result.IsCameraEnabled)
+			assert.Equal(t, tc.wantedReboot, result.IsRebootRequired)
+			assert.Equal(t, tc.wantedSupported, result.IsSupported)
+			assert.Equal(t, len(actions.GPUMemoryKeys), len(result.Settings))
+
+			effective := ""
+			for _, s := range result.Settings {
+				if s.IsEffective {
+					effective = s.Key
+				}
+			}
+			assert.Equal(t, tc.wantedEffective, effective)
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	pi3 := rpi.GPUMemory{
+		IsSupported: true,
+		RAM:         1024,
+		Settings:    []rpi.GPUMemorySetting{{Key: "gpu_mem"}, {Key: "gpu_mem_1024"}},
+	}
+	camera := pi3
+	camera.IsCameraEnabled = true
+	override := pi3
+	override.Settings = []rpi.GPUMemorySetting{{Key: "gpu_mem"}, {Key: "gpu_mem_1024", IsSet: true, Value: 256}}
+
+	cases := []struct {
+		name      string
+		gm        rpi.GPUMemory
+		key       string
+		value     uint64
+		wantedErr error
+	}{
+		{
+			name:      "error: unsupported board",
+			gm:        rpi.GPUMemory{},
+			key:       "gpu_mem",
+			value:     128,
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an unsupported board - the gpu memory is allocated dynamically"),
+		},
+		{
+			name:      "error: too low",
+			gm:        pi3,
+			key:       "gpu_mem",
+			value:     8,
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid value - gpu_mem should be between 16 and 944"),
+		},
+		{
+			name:      "error: too high for the ram",
+			gm:        pi3,
+			key:       "gpu_mem_512",
+			value:     512,
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid value - gpu_mem_512 should be between 16 and 448"),
+		},
+		{
+			name:      "error: overridden key",
+			gm:        override,
+			key:       "gpu_mem",
+			value:     128,
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid key - gpu_mem_1024 overrides gpu_mem on this board"),
+		},
+		{
+			name:      "error: camera requirements",
+			gm:        camera,
+			key:       "gpu_mem_1024",
+			value:     64,
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid value - the camera requires at least 128"),
+		},
+		{
+			name:  "success: camera ignores another ram size",
+			gm:    camera,
+			key:   "gpu_mem_256",
+			value: 64,
+		},
+		{
+			name:  "success",
+			gm:    override,
+			key:   "gpu_mem_1024",
+			value: 128,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := sys.GPUMem{}
+			assert.Equal(t, tc.wantedErr, s.Check(tc.gm, tc.key, tc.value))
+		})
+	}
+}
+
+func TestExecuteGM(t *testing.T) {
+	s := sys.GPUMem{}
+	result, err := s.ExecuteGM(map[int](map[int]actions.Func){
+		1: {
+			1: {
+				Name:      "funcA",
+				Reference: func(arg interface{}) (rpi.Exec, error) { return rpi.Exec{ExitStatus: 1}, nil },
+				Argument:  []interface{}{actions.BCO{}},
+			},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, actions.GPUMemory, result.Name)
+	assert.Equal(t, uint16(1), result.NumberOfSteps)
+	assert.Equal(t, uint8(1), result.ExitStatus)
+}
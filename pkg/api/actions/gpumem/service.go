@@ -0,0 +1,54 @@
+package gpumem
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// Service represents all GPUMem application services.
+type Service interface {
+	List() (rpi.GPUMemory, error)
+	ExecuteGM(string, uint64) (rpi.Action, error)
+}
+
+// GPUMem represents a GPUMem application service.
+type GPUMem struct {
+	gmsys GMSYS
+	a     Actions
+	i     Infos
+	m     Metrics
+	b     Board
+}
+
+// GMSYS represents a GPUMem repository service.
+type GMSYS interface {
+	List(rpi.Board, []string, uint64) (rpi.GPUMemory, error)
+	Check(rpi.GPUMemory, string, uint64) error
+	ExecuteGM(map[int](map[int]actions.Func)) (rpi.Action, error)
+}
+
+// Actions represents the actions interface
+type Actions interface {
+	EditBootConfig(interface{}) (rpi.Exec, error)
+}
+
+// Infos represents the infos interface
+type Infos interface {
+	GetConfigFiles() map[string]rpi.ConfigFileDetails
+	ReadFile(string) ([]string, error)
+}
+
+// Metrics represents the system metrics interface
+type Metrics interface {
+	GPUMemory() (uint64, error)
+}
+
+// Board represents the board interface
+type Board interface {
+	List() (rpi.Board, error)
+}
+
+// New creates a GPUMem application service instance.
+func New(gmsys GMSYS, a Actions, i Infos, m Metrics, b Board) *GPUMem {
+	return &GPUMem{gmsys: gmsys, a: a, i: i, m: m, b: b}
+}
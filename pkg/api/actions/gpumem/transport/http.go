@@ -0,0 +1,53 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi/pkg/api/actions/gpumem"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/infos"
+)
+
+// HTTP is a struct implementing a core application service.
+type HTTP struct {
+	svc gpumem.Service
+}
+
+// NewHTTP creates new gpumem http service
+func NewHTTP(svc gpumem.Service, r *echo.Group) {
+	h := HTTP{svc}
+	cr := r.Group("/gpumem")
+	cr.GET("", h.list)
+	cr.POST("/:value", h.set)
+}
+
+func (h *HTTP) list(ctx echo.Context) error {
+	result, err := h.svc.List()
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) set(ctx echo.Context) error {
+	value, err := strconv.ParseUint(ctx.Param("value"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid value - should be an amount of memory in MB")
+	}
+
+	key := ctx.QueryParam("key")
+	if key == "" {
+		key = "gpu_mem"
+	}
+	if !infos.StringItemExists(actions.GPUMemoryKeys, key) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid key - should be gpu_mem, gpu_mem_256, gpu_mem_512 or gpu_mem_1024")
+	}
+
+	result, err := h.svc.ExecuteGM(key, value)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,123 @@
+package transport_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/gpumem"
+	"github.com/raspibuddy/rpi/pkg/api/actions/gpumem/transport"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/raspibuddy/rpi/pkg/utl/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGPUMem(t *testing.T) {
+	cases := []struct {
+		name         string
+		method       string
+		req          string
+		readErr      error
+		executeErr   error
+		wantedStatus int
+	}{
+		{
+			name:         "error: list read config",
+			method:       http.MethodGet,
+			req:          "",
+			readErr:      errors.New("test error"),
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:         "success: list",
+			method:       http.MethodGet,
+			req:          "",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "error: invalid value",
+			method:       http.MethodPost,
+			req:          "/lots",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid key",
+			method:       http.MethodPost,
+			req:          "/128?key=gpu_mem_2048",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: ExecuteGM result is nil",
+			method:       http.MethodPost,
+			req:          "/128",
+			executeErr:   errors.New("test error"),
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:         "success: set",
+			method:       http.MethodPost,
+			req:          "/128?key=gpu_mem_1024",
+			wantedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			i := mock.Infos{
+				GetConfigFilesFn: func() map[string]rpi.ConfigFileDetails {
+					return map[string]rpi.ConfigFileDetails{"bootconfig": {Path: "/boot/config.txt"}}
+				},
+				ReadFileFn: func(string) ([]string, error) {
+					return []string{}, tc.readErr
+				},
+			}
+			m := mock.Metrics{
+				GPUMemoryFn: func() (uint64, error) {
+					return 0, errors.New("vcgencmd not found")
+				},
+			}
+			b := mock.Board{
+				ListFn: func() (rpi.Board, error) {
+					return rpi.Board{SoC: "BCM2711", RAM: 4096}, nil
+				},
+			}
+			gmsys := &mocksys.GPUMem{
+				ListFn: func(rpi.Board, []string, uint64) (rpi.GPUMemory, error) {
+					return rpi.GPUMemory{IsSupported: true}, nil
+				},
+				CheckFn: func(rpi.GPUMemory, string, uint64) error {
+					return nil
+				},
+				ExecuteGMFn: func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+					return rpi.Action{Name: actions.GPUMemory, NumberOfSteps: 1}, tc.executeErr
+				},
+			}
+			s := gpumem.New(gmsys, actions.New(), i, m, b)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/gpumem" + tc.req
+
+			req, err := http.NewRequest(tc.method, path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,483 @@
+package hotspot
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/constants"
+	"github.com/raspibuddy/rpi/pkg/utl/netconf"
+	"github.com/raspibuddy/rpi/pkg/utl/wpaconf"
+)
+
+const (
+	// ConnectionID is the id of the NetworkManager connection hosting the hotspot
+	ConnectionID = "raspibuddy-hotspot"
+
+	// DefaultChannel is the channel used when none is given
+	DefaultChannel = 7
+
+	// DefaultAddress is the address of the hotspot used when none is given
+	DefaultAddress = "192.168.4.1/24"
+
+	// RangeSize is the number of addresses of the dhcp range built when none is given
+	RangeSize = 19
+)
+
+// List returns the access point of a wifi interface with its clients and dhcp leases.
+// NetworkManager hosts it when it runs (Bookworm), hostapd and dnsmasq when dhcpcd configures the network.
+func (h *Hotspot) List(iface string) (rpi.Hotspot, error) {
+	if !contains(h.i.ListWifiInterfaces(constants.NETWORKINTERFACES), iface) {
+		return rpi.Hotspot{}, echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Not found - %v is not a wifi interface", iface))
+	}
+
+	backend := h.backend()
+	isEnabled := false
+	var config, dnsmasq, dhcpcd, leases []string
+
+	switch backend {
+	case netconf.Hostapd:
+		config, _ = h.i.ReadFile(constants.HOSTAPDCONF)
+		dnsmasq, _ = h.i.ReadFile(constants.DNSMASQHOTSPOT)
+		dhcpcd, _ = h.i.ReadFile(constants.DHCPCDCONF)
+		leases, _ = h.i.ReadFile(constants.DNSMASQLEASES)
+		if unit, err := h.i.ShowUnit("hostapd.service"); err == nil {
+			isEnabled = unit["ActiveState"] == "active"
+		}
+	case netconf.NetworkManager:
+		config, _ = h.i.ReadFile(constants.NMHOTSPOT)
+		dnsmasq, _ = h.i.ReadFile(constants.NMDNSMASQHOTSPOT)
+		leases, _ = h.i.ReadFile(filepath.Join(constants.NMLEASES, fmt.Sprintf("dnsmasq-%v.leases", iface)))
+		isEnabled = h.isActive(iface)
+	}
+
+	return h.hsys.List(backend, iface, isEnabled, config, dnsmasq, dhcpcd, h.i.StationDump(iface), leases)
+}
+
+// ExecuteEH configures the access point of a wifi interface and starts it, then returns an action.
+// The passphrase is stored as its PBKDF2 hash, no passphrase making an open access point.
+// With hostapd, the static address of the interface is set in dhcpcd.conf and wpa_supplicant is not started on it anymore.
+// The interface is returned to client mode when a step fails, hostapd refusing to start for instance.
+func (h *Hotspot) ExecuteEH(iface string, hs rpi.Hotspot, passphrase string) (rpi.Action, error) {
+	current, err := h.List(iface)
+	if err != nil {
+		return rpi.Action{}, err
+	}
+
+	for i := 0; i < len(hs.SSID); i++ {
+		if hs.SSID[i] < 0x20 || hs.SSID[i] > 0x7e {
+			return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid ssid - should be 1 to 32 printable ASCII characters")
+		}
+	}
+	if len(hs.SSID) == 0 || len(hs.SSID) > 32 {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid ssid - should be 1 to 32 printable ASCII characters")
+	}
+
+	psk := ""
+	if passphrase != "" {
+		if psk, err = wpaconf.PSK(hs.SSID, passphrase); err != nil {
+			return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid passphrase - should be 8 to 63 printable ASCII characters")
+		}
+	}
+
+	if hs.Channel == 0 {
+		hs.Channel = DefaultChannel
+	}
+	if hs.Channel < 1 || hs.Channel > 13 {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid channel - should be a 2.4GHz channel, from 1 to 13")
+	}
+
+	if hs.Address == "" {
+		hs.Address = DefaultAddress
+	}
+	gateway, network, err := net.ParseCIDR(hs.Address)
+	if err != nil || !isHost(gateway, network) {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid address - should be an ipv4 host address in CIDR notation (ex: 192.168.4.1/24)")
+	}
+
+	if hs.RangeStart, hs.RangeEnd, err = dhcpRange(gateway, network, hs.RangeStart, hs.RangeEnd); err != nil {
+		return rpi.Action{}, err
+	}
+
+	switch current.Backend {
+	case netconf.Hostapd:
+		if !h.i.IsUnit("hostapd.service") || !h.i.IsUnit("dnsmasq.service") {
+			return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to missing packages - hostapd and dnsmasq should be installed")
+		}
+
+		original, err := h.i.ReadFile(constants.DHCPCDCONF)
+		if err != nil {
+			return rpi.Action{}, echo.NewHTTPError(http.StatusInternalServerError, "could not read dhcpcd.conf")
+		}
+		dhcpcd := netconf.SetDhcpcd(original, iface, netconf.Settings{IsStatic: true, IPv4: []string{hs.Address}})
+		dhcpcd = netconf.SetDhcpcdOption(dhcpcd, iface, "nohook wpa_supplicant", true)
+
+		country := ""
+		if config, err := h.i.ReadFile(constants.WPASUPPLICANT); err == nil {
+			country, _ = wpaconf.Parse(config).Get("country")
+		}
+
+		plan := h.files(
+			actions.RF{Path: constants.HOSTAPDCONF, Content: hostapd(iface, hs, psk, country), Mode: 0600},
+			actions.RF{Path: constants.DNSMASQHOTSPOT, Content: dnsmasq(iface, gateway, network, hs, true), Mode: 0644},
+			actions.RF{Path: constants.DHCPCDCONF, Content: content(dhcpcd), Mode: 0664},
+		)
+		h.units(plan, "unmask hostapd.service", "enable hostapd.service", "enable dnsmasq.service")
+		h.commands(plan, fmt.Sprintf("wpa_cli -i %v terminate || true", iface))
+		h.units(plan, "restart dhcpcd.service", "restart dnsmasq.service", "restart hostapd.service")
+
+		action, err := h.hsys.ExecuteEH(plan)
+		if err != nil || action.ExitStatus == 0 {
+			return action, err
+		}
+
+		// wpa_supplicant being stopped, a failing hostapd (channel, country or driver refused) would leave
+		// the interface with neither an access point nor client mode: it is returned to client mode
+		fallback, err := h.hsys.ExecuteDH(h.clientMode(iface, current, original))
+		if err != nil {
+			return action, nil
+		}
+		return merge(action, len(plan), fallback), nil
+	case netconf.NetworkManager:
+		uuid := netconf.ParseKeyfile(h.keyfile()).UUID
+		if uuid == "" {
+			uuid = netconf.NewUUID()
+		}
+
+		plan := h.files(
+			actions.RF{Path: constants.NMHOTSPOT, Content: keyfile(iface, uuid, hs, psk), Mode: 0600},
+			actions.RF{Path: constants.NMDNSMASQHOTSPOT, Content: dnsmasq(iface, gateway, network, hs, false), Mode: 0644},
+		)
+		h.commands(plan, fmt.Sprintf("nmcli connection reload && nmcli connection up id %v", ConnectionID))
+
+		return h.hsys.ExecuteEH(plan)
+	}
+
+	return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an unsupported system - neither NetworkManager nor dhcpcd configure the network")
+}
+
+// ExecuteDH stops the access point of a wifi interface and returns it to client mode, then returns an action.
+// The hotspot config is kept, it is not started at boot anymore.
+func (h *Hotspot) ExecuteDH(iface string) (rpi.Action, error) {
+	current, err := h.List(iface)
+	if err != nil {
+		return rpi.Action{}, err
+	}
+
+	switch current.Backend {
+	case netconf.Hostapd:
+		dhcpcd, err := h.i.ReadFile(constants.DHCPCDCONF)
+		if err != nil {
+			return rpi.Action{}, echo.NewHTTPError(http.StatusInternalServerError, "could not read dhcpcd.conf")
+		}
+
+		return h.hsys.ExecuteDH(h.clientMode(iface, current, dhcpcd))
+	case netconf.NetworkManager:
+		if !h.i.IsFileExists(constants.NMHOTSPOT) {
+			return rpi.Action{}, echo.NewHTTPError(http.StatusNotFound, "Not found - no hotspot is configured")
+		}
+
+		plan := h.commands(
+			map[int](map[int]actions.Func){},
+			fmt.Sprintf("nmcli connection modify id %v connection.autoconnect no", ConnectionID),
+		)
+		if current.IsEnabled {
+			h.commands(plan, fmt.Sprintf("nmcli connection down id %v", ConnectionID))
+		}
+		h.commands(plan, fmt.Sprintf("nmcli device set %v autoconnect yes", iface))
+
+		return h.hsys.ExecuteDH(plan)
+	}
+
+	return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an unsupported system - neither NetworkManager nor dhcpcd configure the network")
+}
+
+// clientMode returns a plan stopping hostapd and returning an interface to client mode, the static address
+// of the current access point and the nohook wpa_supplicant option being removed from the lines of dhcpcd.conf
+func (h *Hotspot) clientMode(iface string, current rpi.Hotspot, dhcpcd []string) map[int](map[int]actions.Func) {
+	settings := netconf.DhcpcdSettings(dhcpcd, iface)
+	if current.Address != "" && len(settings.IPv4) == 1 && settings.IPv4[0] == current.Address {
+		settings = netconf.Settings{DNS: settings.DNS}
+	}
+	dhcpcd = netconf.SetDhcpcd(dhcpcd, iface, settings)
+	dhcpcd = netconf.SetDhcpcdOption(dhcpcd, iface, "nohook wpa_supplicant", false)
+
+	plan := h.commands(
+		map[int](map[int]actions.Func){},
+		"systemctl disable --now hostapd.service",
+		fmt.Sprintf("rm -f %v", constants.DNSMASQHOTSPOT),
+	)
+	for k, v := range h.files(actions.RF{Path: constants.DHCPCDCONF, Content: content(dhcpcd), Mode: 0664}) {
+		plan[len(plan)+k] = v
+	}
+	h.units(plan, "restart dnsmasq.service", "restart dhcpcd.service")
+
+	return plan
+}
+
+// backend returns the backend hosting the access point, empty when none is found
+func (h *Hotspot) backend() string {
+	if unit, err := h.i.ShowUnit("NetworkManager.service"); err == nil && unit["ActiveState"] == "active" {
+		return netconf.NetworkManager
+	}
+	if h.i.IsFileExists(constants.DHCPCDCONF) {
+		return netconf.Hostapd
+	}
+	return ""
+}
+
+// isActive returns true when the hotspot connection is active on an interface
+func (h *Hotspot) isActive(iface string) bool {
+	// name / uuid / device / filename
+	for _, line := range h.i.NMConnections() {
+		fields := strings.Split(line, ":")
+		if len(fields) > 2 && fields[0] == ConnectionID && fields[2] == iface {
+			return true
+		}
+	}
+	return false
+}
+
+// keyfile returns the lines of the hotspot keyfile, empty when missing
+func (h *Hotspot) keyfile() []string {
+	if !h.i.IsFileExists(constants.NMHOTSPOT) {
+		return []string{}
+	}
+	lines, _ := h.i.ReadFile(constants.NMHOTSPOT)
+	return lines
+}
+
+// files returns a plan writing each file in its own step, the previous files being kept in the hotspot directory
+func (h *Hotspot) files(files ...actions.RF) map[int](map[int]actions.Func) {
+	plan := map[int](map[int]actions.Func){}
+
+	for _, f := range files {
+		f.Backup = filepath.Join(constants.HOTSPOT, filepath.Base(f.Path))
+		plan[len(plan)+1] = map[int]actions.Func{
+			1: {
+				Name:      actions.RestoreFile,
+				Reference: h.a.RestoreFile,
+				Argument:  []interface{}{f},
+			},
+		}
+	}
+
+	return plan
+}
+
+// units appends a step to a plan for each systemctl command (ex: restart hostapd.service)
+func (h *Hotspot) units(plan map[int](map[int]actions.Func), commands ...string) map[int](map[int]actions.Func) {
+	for _, c := range commands {
+		fields := strings.Fields(c)
+		plan[len(plan)+1] = map[int]actions.Func{
+			1: {
+				Name:      actions.ManageUnit,
+				Reference: h.a.ManageUnit,
+				Argument: []interface{}{
+					actions.MU{
+						Action: fields[0],
+						Unit:   fields[1],
+					},
+				},
+			},
+		}
+	}
+
+	return plan
+}
+
+// commands appends a step to a plan for each command, in order
+func (h *Hotspot) commands(plan map[int](map[int]actions.Func), commands ...string) map[int](map[int]actions.Func) {
+	for _, c := range commands {
+		plan[len(plan)+1] = map[int]actions.Func{
+			1: {
+				Name:      actions.ExecuteBashCommand,
+				Reference: h.a.ExecuteBashCommand,
+				Argument: []interface{}{
+					actions.EBC{
+						Command: c,
+					},
+				},
+			},
+		}
+	}
+
+	return plan
+}
+
+// merge returns an action followed by the steps of a second one, numbered after the steps of its plan
+func merge(action rpi.Action, steps int, next rpi.Action) rpi.Action {
+	if action.Progress == nil {
+		action.Progress = map[string]rpi.Exec{}
+	}
+	for k, v := range next.Progress {
+		index := strings.SplitN(k, actions.Separator, 2)
+		if n, err := strconv.Atoi(index[0]); err == nil && len(index) == 2 {
+			action.Progress[fmt.Sprint(steps+n)+actions.Separator+index[1]] = v
+		}
+	}
+	action.NumberOfSteps += next.NumberOfSteps
+	action.EndTime = next.EndTime
+
+	return action
+}
+
+// hostapd returns the content of hostapd.conf
+func hostapd(iface string, hs rpi.Hotspot, psk string, country string) []byte {
+	lines := []string{
+		"interface=" + iface,
+		"driver=nl80211",
+		"ssid=" + hs.SSID,
+		"hw_mode=g",
+		fmt.Sprintf("channel=%v", hs.Channel),
+		"ieee80211n=1",
+		"wmm_enabled=1",
+		"auth_algs=1",
+		"ignore_broadcast_ssid=0",
+	}
+	if country != "" {
+		lines = append(lines, "country_code="+country)
+	}
+	if psk != "" {
+		lines = append(lines, "wpa=2", "wpa_key_mgmt=WPA-PSK", "rsn_pairwise=CCMP", "wpa_psk="+psk)
+	}
+
+	return content(lines)
+}
+
+// dnsmasq returns the content of the dnsmasq config serving the dhcp range.
+// NetworkManager binds its own dnsmasq to the interface, which is then left out.
+func dnsmasq(iface string, gateway net.IP, network *net.IPNet, hs rpi.Hotspot, isInterface bool) []byte {
+	lines := []string{}
+	if isInterface {
+		lines = append(lines, "interface="+iface)
+	}
+	lines = append(lines,
+		fmt.Sprintf("dhcp-range=%v,%v,%v,24h", hs.RangeStart, hs.RangeEnd, net.IP(network.Mask)),
+		"domain=wlan",
+		fmt.Sprintf("address=/gw.wlan/%v", gateway),
+	)
+
+	return content(lines)
+}
+
+// keyfile returns the content of the NetworkManager keyfile sharing the connection of the board
+func keyfile(iface string, uuid string, hs rpi.Hotspot, psk string) []byte {
+	lines := []string{
+		"[connection]",
+		"id=" + ConnectionID,
+		"uuid=" + uuid,
+		"type=wifi",
+		"interface-name=" + iface,
+		"autoconnect=true",
+		"autoconnect-priority=100",
+		"",
+		"[wifi]",
+		"mode=ap",
+		"ssid=" + hs.SSID,
+		"band=bg",
+		fmt.Sprintf("channel=%v", hs.Channel),
+		"",
+	}
+	if psk != "" {
+		lines = append(lines,
+			"[wifi-security]",
+			"key-mgmt=wpa-psk",
+			"proto=rsn",
+			"pairwise=ccmp",
+			"group=ccmp",
+			"psk="+psk,
+			"",
+		)
+	}
+	lines = append(lines,
+		"[ipv4]",
+		"method=shared",
+		"address1="+hs.Address,
+		"",
+		"[ipv6]",
+		"method=disabled",
+		"",
+		"[proxy]",
+	)
+
+	return content(lines)
+}
+
+// dhcpRange returns the dhcp range given, checked against the network of the hotspot, or else the
+// range of RangeSize addresses following the address of the hotspot
+func dhcpRange(gateway net.IP, network *net.IPNet, start string, end string) (string, string, error) {
+	first, last := uint32Of(network.IP)+1, broadcast(network)-1
+	invalid := echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to an invalid dhcp range - should be inside %v and leave out %v", network, gateway))
+
+	if start == "" && end == "" {
+		s := first
+		if s == uint32Of(gateway) {
+			s++
+		}
+		e := s + RangeSize - 1
+		if e > last || e < s {
+			e = last
+		}
+		if uint32Of(gateway) >= s && uint32Of(gateway) <= e {
+			return "", "", invalid
+		}
+		return ipOf(s).String(), ipOf(e).String(), nil
+	}
+
+	startIP, endIP := net.ParseIP(start), net.ParseIP(end)
+	if startIP == nil || endIP == nil || startIP.To4() == nil || endIP.To4() == nil {
+		return "", "", invalid
+	}
+
+	s, e := uint32Of(startIP), uint32Of(endIP)
+	if s < first || e > last || s > e || (uint32Of(gateway) >= s && uint32Of(gateway) <= e) {
+		return "", "", invalid
+	}
+
+	return startIP.String(), endIP.String(), nil
+}
+
+// isHost returns true when an ipv4 address is a host address of its network, from /8 to /30
+func isHost(ip net.IP, network *net.IPNet) bool {
+	ones, _ := network.Mask.Size()
+	if ip.To4() == nil || ones < 8 || ones > 30 {
+		return false
+	}
+	return !ip.Equal(network.IP) && uint32Of(ip) != broadcast(network)
+}
+
+func uint32Of(ip net.IP) uint32 {
+	return binary.BigEndian.Uint32(ip.To4())
+}
+
+func ipOf(value uint32) net.IP {
+	ip := make(net.IP, 4)
+	binary.BigEndian.PutUint32(ip, value)
+	return ip
+}
+
+func broadcast(network *net.IPNet) uint32 {
+	return uint32Of(network.IP) | ^binary.BigEndian.Uint32(network.Mask)
+}
+
+// content returns the content of a file made of lines
+func content(lines []string) []byte {
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,313 @@
+package hotspot_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/hotspot"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/constants"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/raspibuddy/rpi/pkg/utl/netconf"
+	"github.com/stretchr/testify/assert"
+)
+
+func infos(backend string, isInstalled bool, isActive bool) *mock.Infos {
+	return &mock.Infos{
+		ReadFileFn: func(path string) ([]string, error) {
+			switch path {
+			case constants.DHCPCDCONF:
+				return []string{"hostname", "", "interface wlan0", "static ip_address=192.168.4.1/24", "nohook wpa_supplicant"}, nil
+			case constants.WPASUPPLICANT:
+				return []string{"country=FR"}, nil
+			case constants.NMHOTSPOT:
+				return []string{"[connection]", "id=raspibuddy-hotspot", "uuid=7d1a4b1e-0000-4000-8000-000000000003"}, nil
+			}
+			return []string{path}, nil
+		},
+		IsFileExistsFn: func(string) bool {
+			return backend != ""
+		},
+		ShowUnitFn: func(name string) (map[string]string, error) {
+			if (name == "NetworkManager.service" && backend == netconf.NetworkManager) || (name == "hostapd.service" && isActive) {
+				return map[string]string{"ActiveState": "active"}, nil
+			}
+			return map[string]string{"ActiveState": "inactive"}, nil
+		},
+		IsUnitFn: func(string) bool {
+			return isInstalled
+		},
+		ListWifiInterfacesFn: func(string) []string {
+			return []string{"wlan0"}
+		},
+		NMConnectionsFn: func() []string {
+			if isActive {
+				return []string{"raspibuddy-hotspot:7d1a4b1e-0000-4000-8000-000000000003:wlan0:" + constants.NMHOTSPOT}
+			}
+			return []string{"raspibuddy-hotspot:7d1a4b1e-0000-4000-8000-000000000003::" + constants.NMHOTSPOT}
+		},
+		StationDumpFn: func(iface string) []string {
+			return []string{"Station aa:bb:cc:dd:ee:01 (on " + iface + ")"}
+		},
+	}
+}
+
+func hsys(plan *map[int](map[int]actions.Func), args *[]interface{}) *mocksys.Hotspot {
+	execute := func(p map[int](map[int]actions.Func)) (rpi.Action, error) {
+		*plan = p
+		return rpi.Action{NumberOfSteps: uint16(len(p))}, nil
+	}
+
+	return &mocksys.Hotspot{
+		ListFn: func(backend string, iface string, isEnabled bool, config []string, dnsmasq []string, dhcpcd []string, stations []string, leases []string) (rpi.Hotspot, error) {
+			*args = []interface{}{config, dnsmasq, dhcpcd, stations, leases}
+			return rpi.Hotspot{Backend: backend, Interface: iface, IsEnabled: isEnabled, Address: "192.168.4.1/24"}, nil
+		},
+		ExecuteEHFn: execute,
+		ExecuteDHFn: execute,
+	}
+}
+
+// steps returns the name and the main argument of each step of a plan
+func steps(plan map[int](map[int]actions.Func)) []string {
+	result := []string{}
+	for i := 1; i <= len(plan); i++ {
+		switch arg := plan[i][1].Argument[0].(type) {
+		case actions.RF:
+			result = append(result, plan[i][1].Name+" "+arg.Path)
+		case actions.MU:
+			result = append(result, plan[i][1].Name+" "+arg.Action+" "+arg.Unit)
+		case actions.EBC:
+			result = append(result, plan[i][1].Name+" "+arg.Command)
+		}
+	}
+	return result
+}
+
+func TestList(t *testing.T) {
+	var plan map[int](map[int]actions.Func)
+	var args []interface{}
+
+	s := hotspot.New(hsys(&plan, &args), actions.New(), infos(netconf.Hostapd, true, true))
+	_, err := s.List("eth0")
+	assert.Equal(t, echo.NewHTTPError(http.StatusNotFound, "Not found - eth0 is not a wifi interface"), err)
+
+	result, err := s.List("wlan0")
+	assert.Nil(t, err)
+	assert.Equal(t, netconf.Hostapd, result.Backend)
+	assert.True(t, result.IsEnabled)
+	assert.Equal(t, []interface{}{
+		[]string{constants.HOSTAPDCONF},
+		[]string{constants.DNSMASQHOTSPOT},
+		[]string{"hostname", "", "interface wlan0", "static ip_address=192.168.4.1/24", "nohook wpa_supplicant"},
+		[]string{"Station aa:bb:cc:dd:ee:01 (on wlan0)"},
+		[]string{constants.DNSMASQLEASES},
+	}, args)
+
+	s = hotspot.New(hsys(&plan, &args), actions.New(), infos(netconf.NetworkManager, true, true))
+	result, err = s.List("wlan0")
+	assert.Nil(t, err)
+	assert.Equal(t, netconf.NetworkManager, result.Backend)
+	assert.True(t, result.IsEnabled)
+	assert.Equal(t, []string{constants.NMLEASES + "/dnsmasq-wlan0.leases"}, args[4])
+
+	s = hotspot.New(hsys(&plan, &args), actions.New(), infos(netconf.NetworkManager, true, false))
+	result, err = s.List("wlan0")
+	assert.Nil(t, err)
+	assert.False(t, result.IsEnabled)
+}
+
+func TestExecuteEH(t *testing.T) {
+	cases := []struct {
+		name        string
+		backend     string
+		isInstalled bool
+		hotspot     rpi.Hotspot
+		passphrase  string
+		wantedErr   error
+		wantedSteps []string
+		wantedFiles map[string]string
+	}{
+		{
+			name:      "error: invalid ssid",
+			backend:   netconf.Hostapd,
+			hotspot:   rpi.Hotspot{SSID: "café"},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid ssid - should be 1 to 32 printable ASCII characters"),
+		},
+		{
+			name:       "error: invalid passphrase",
+			backend:    netconf.Hostapd,
+			hotspot:    rpi.Hotspot{SSID: "field"},
+			passphrase: "short",
+			wantedErr:  echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid passphrase - should be 8 to 63 printable ASCII characters"),
+		},
+		{
+			name:      "error: invalid channel",
+			backend:   netconf.Hostapd,
+			hotspot:   rpi.Hotspot{SSID: "field", Channel: 36},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid channel - should be a 2.4GHz channel, from 1 to 13"),
+		},
+		{
+			name:      "error: invalid address",
+			backend:   netconf.Hostapd,
+			hotspot:   rpi.Hotspot{SSID: "field", Address: "192.168.4.0/24"},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid address - should be an ipv4 host address in CIDR notation (ex: 192.168.4.1/24)"),
+		},
+		{
+			name:      "error: dhcp range including the address",
+			backend:   netconf.Hostapd,
+			hotspot:   rpi.Hotspot{SSID: "field", RangeStart: "192.168.4.1", RangeEnd: "192.168.4.9"},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid dhcp range - should be inside 192.168.4.0/24 and leave out 192.168.4.1"),
+		},
+		{
+			name:      "error: dhcp range outside the network",
+			backend:   netconf.Hostapd,
+			hotspot:   rpi.Hotspot{SSID: "field", RangeStart: "192.168.4.2", RangeEnd: "192.168.5.9"},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid dhcp range - should be inside 192.168.4.0/24 and leave out 192.168.4.1"),
+		},
+		{
+			name:      "error: missing packages",
+			backend:   netconf.Hostapd,
+			hotspot:   rpi.Hotspot{SSID: "field"},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to missing packages - hostapd and dnsmasq should be installed"),
+		},
+		{
+			name:      "error: unsupported system",
+			hotspot:   rpi.Hotspot{SSID: "field"},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an unsupported system - neither NetworkManager nor dhcpcd configure the network"),
+		},
+		{
+			name:        "success: hostapd",
+			backend:     netconf.Hostapd,
+			isInstalled: true,
+			hotspot:     rpi.Hotspot{SSID: "IEEE", Address: "10.0.0.1/28"},
+			passphrase:  "password",
+			wantedSteps: []string{
+				"restore_file " + constants.HOSTAPDCONF,
+				"restore_file " + constants.DNSMASQHOTSPOT,
+				"restore_file " + constants.DHCPCDCONF,
+				"manage_unit unmask hostapd.service",
+				"manage_unit enable hostapd.service",
+				"manage_unit enable dnsmasq.service",
+				"execute_bash_command wpa_cli -i wlan0 terminate || true",
+				"manage_unit restart dhcpcd.service",
+				"manage_unit restart dnsmasq.service",
+				"manage_unit restart hostapd.service",
+			},
+			wantedFiles: map[string]string{
+				constants.HOSTAPDCONF: "interface=wlan0\ndriver=nl80211\nssid=IEEE\nhw_mode=g\nchannel=7\nieee80211n=1\nwmm_enabled=1\nauth_algs=1\nignore_broadcast_ssid=0\ncountry_code=FR\n" +
+					"wpa=2\nwpa_key_mgmt=WPA-PSK\nrsn_pairwise=CCMP\nwpa_psk=f42c6fc52df0ebef9ebb4b90b38a5f902e83fe1b135a70e23aed762e9710a12e\n",
+				constants.DNSMASQHOTSPOT: "interface=wlan0\ndhcp-range=10.0.0.2,10.0.0.14,255.255.255.240,24h\ndomain=wlan\naddress=/gw.wlan/10.0.0.1\n",
+				constants.DHCPCDCONF:     "hostname\n\ninterface wlan0\nnohook wpa_supplicant\nstatic ip_address=10.0.0.1/28\n",
+			},
+		},
+		{
+			name:    "success: networkmanager",
+			backend: netconf.NetworkManager,
+			hotspot: rpi.Hotspot{SSID: "field", Channel: 11, RangeStart: "192.168.4.100", RangeEnd: "192.168.4.200"},
+			wantedSteps: []string{
+				"restore_file " + constants.NMHOTSPOT,
+				"restore_file " + constants.NMDNSMASQHOTSPOT,
+				"execute_bash_command nmcli connection reload && nmcli connection up id raspibuddy-hotspot",
+			},
+			wantedFiles: map[string]string{
+				constants.NMHOTSPOT: "[connection]\nid=raspibuddy-hotspot\nuuid=7d1a4b1e-0000-4000-8000-000000000003\ntype=wifi\ninterface-name=wlan0\nautoconnect=true\nautoconnect-priority=100\n\n" +
+					"[wifi]\nmode=ap\nssid=field\nband=bg\nchannel=11\n\n[ipv4]\nmethod=shared\naddress1=192.168.4.1/24\n\n[ipv6]\nmethod=disabled\n\n[proxy]\n",
+				constants.NMDNSMASQHOTSPOT: "dhcp-range=192.168.4.100,192.168.4.200,255.255.255.0,24h\ndomain=wlan\naddress=/gw.wlan/192.168.4.1\n",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var plan map[int](map[int]actions.Func)
+			var args []interface{}
+
+			s := hotspot.New(hsys(&plan, &args), actions.New(), infos(tc.backend, tc.isInstalled, false))
+			_, err := s.ExecuteEH("wlan0", tc.hotspot, tc.passphrase)
+			assert.Equal(t, tc.wantedErr, err)
+			if tc.wantedErr != nil {
+				return
+			}
+
+			assert.Equal(t, tc.wantedSteps, steps(plan))
+			for i := 1; i <= len(plan); i++ {
+				if rf, ok := plan[i][1].Argument[0].(actions.RF); ok {
+					assert.Equal(t, tc.wantedFiles[rf.Path], string(rf.Content))
+					assert.True(t, strings.HasPrefix(rf.Backup, constants.HOTSPOT+"/"))
+				}
+			}
+		})
+	}
+}
+
+func TestExecuteEHFallback(t *testing.T) {
+	var plan, fallback map[int](map[int]actions.Func)
+	var args []interface{}
+
+	hs := hsys(&plan, &args)
+	hs.ExecuteEHFn = func(p map[int](map[int]actions.Func)) (rpi.Action, error) {
+		plan = p
+		return rpi.Action{
+			NumberOfSteps: uint16(len(p)),
+			Progress:      map[string]rpi.Exec{"10" + actions.Separator + "1": {Name: actions.ManageUnit, ExitStatus: 1}},
+			ExitStatus:    1,
+		}, nil
+	}
+	hs.ExecuteDHFn = func(p map[int](map[int]actions.Func)) (rpi.Action, error) {
+		fallback = p
+		return rpi.Action{
+			NumberOfSteps: uint16(len(p)),
+			Progress:      map[string]rpi.Exec{"1" + actions.Separator + "1": {Name: actions.ExecuteBashCommand}},
+		}, nil
+	}
+
+	s := hotspot.New(hs, actions.New(), infos(netconf.Hostapd, true, false))
+	action, err := s.ExecuteEH("wlan0", rpi.Hotspot{SSID: "IEEE", Address: "10.0.0.1/28"}, "")
+	assert.Nil(t, err)
+	assert.Equal(t, uint8(1), action.ExitStatus)
+	assert.Equal(t, uint16(15), action.NumberOfSteps)
+	assert.Equal(t, rpi.Exec{Name: actions.ExecuteBashCommand}, action.Progress["11"+actions.Separator+"1"])
+	assert.Equal(t, []string{
+		"execute_bash_command systemctl disable --now hostapd.service",
+		"execute_bash_command rm -f " + constants.DNSMASQHOTSPOT,
+		"restore_file " + constants.DHCPCDCONF,
+		"manage_unit restart dnsmasq.service",
+		"manage_unit restart dhcpcd.service",
+	}, steps(fallback))
+	assert.Equal(t, "hostname\n", string(fallback[3][1].Argument[0].(actions.RF).Content))
+}
+
+func TestExecuteDH(t *testing.T) {
+	var plan map[int](map[int]actions.Func)
+	var args []interface{}
+
+	s := hotspot.New(hsys(&plan, &args), actions.New(), infos(netconf.Hostapd, true, true))
+	_, err := s.ExecuteDH("wlan0")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"execute_bash_command systemctl disable --now hostapd.service",
+		"execute_bash_command rm -f " + constants.DNSMASQHOTSPOT,
+		"restore_file " + constants.DHCPCDCONF,
+		"manage_unit restart dnsmasq.service",
+		"manage_unit restart dhcpcd.service",
+	}, steps(plan))
+	assert.Equal(t, "hostname\n", string(plan[3][1].Argument[0].(actions.RF).Content))
+
+	s = hotspot.New(hsys(&plan, &args), actions.New(), infos(netconf.NetworkManager, true, true))
+	_, err = s.ExecuteDH("wlan0")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"execute_bash_command nmcli connection modify id raspibuddy-hotspot connection.autoconnect no",
+		"execute_bash_command nmcli connection down id raspibuddy-hotspot",
+		"execute_bash_command nmcli device set wlan0 autoconnect yes",
+	}, steps(plan))
+
+	s = hotspot.New(hsys(&plan, &args), actions.New(), infos("", true, true))
+	_, err = s.ExecuteDH("wlan0")
+	assert.Equal(t, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an unsupported system - neither NetworkManager nor dhcpcd configure the network"), err)
+}
@@ -0,0 +1,72 @@
+package hotspot
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/hotspot"
+)
+
+// New creates a new Hotspot logging service instance.
+func New(svc hotspot.Service, logger rpi.Logger) *LogService {
+	return &LogService{
+		Service: svc,
+		logger:  logger,
+	}
+}
+
+// LogService represents a Hotspot logging service.
+type LogService struct {
+	hotspot.Service
+	logger rpi.Logger
+}
+
+const name = "hotspot"
+
+// List is the logging function attached to the List hotspot services and responsible for logging it out.
+func (ls *LogService) List(ctx echo.Context, iface string) (resp rpi.Hotspot, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: list hotspot of %v", iface), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.List(iface)
+}
+
+// ExecuteEH is the logging function attached to the ExecuteEH hotspot services and responsible for logging it out.
+// The passphrase is never logged.
+func (ls *LogService) ExecuteEH(ctx echo.Context, iface string, hs rpi.Hotspot, passphrase string) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: enable hotspot %v on %v", hs.SSID, iface), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteEH(iface, hs, passphrase)
+}
+
+// ExecuteDH is the logging function attached to the ExecuteDH hotspot services and responsible for logging it out.
+func (ls *LogService) ExecuteDH(ctx echo.Context, iface string) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: disable hotspot on %v", iface), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteDH(iface)
+}
@@ -0,0 +1,149 @@
+package sys
+
+import (
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/netconf"
+)
+
+var (
+	settingRegex = regexp.MustCompile(`^\s*([A-Za-z0-9_\-]+)\s*=\s*(.*?)\s*$`)
+	stationRegex = regexp.MustCompile(`^Station\s+([0-9a-fA-F:]{17})`)
+	signalRegex  = regexp.MustCompile(`^\s*signal:\s*(-?\d+)`)
+	timeRegex    = regexp.MustCompile(`^\s*connected time:\s*(\d+)`)
+)
+
+// Hotspot represents an empty Hotspot entity on the current system.
+type Hotspot struct{}
+
+// List returns the access point of a wifi interface, read from hostapd.conf or from the NetworkManager
+// keyfile (config), along with the dnsmasq config, the clients listed by 'iw station dump' and the leases
+func (h Hotspot) List(backend string, iface string, isEnabled bool, config []string, dnsmasq []string, dhcpcd []string, stations []string, leases []string) (rpi.Hotspot, error) {
+	result := rpi.Hotspot{
+		Backend:   backend,
+		Interface: iface,
+		IsEnabled: isEnabled,
+		Clients:   []rpi.HotspotClient{},
+		Leases:    []rpi.HotspotLease{},
+	}
+
+	switch backend {
+	case netconf.Hostapd:
+		keys := settings(config)
+		result.SSID = keys["ssid"]
+		result.Channel, _ = strconv.Atoi(keys["channel"])
+		result.IsSecured = keys["wpa"] != "" && keys["wpa"] != "0"
+		if s := netconf.DhcpcdSettings(dhcpcd, iface); len(s.IPv4) > 0 {
+			result.Address = s.IPv4[0]
+		}
+	case netconf.NetworkManager:
+		sections := netconf.KeyfileSections(config)
+		result.SSID = sections["wifi"]["ssid"]
+		result.Channel, _ = strconv.Atoi(sections["wifi"]["channel"])
+		result.IsSecured = sections["wifi-security"]["key-mgmt"] != "" && sections["wifi-security"]["key-mgmt"] != "none"
+		result.Address = strings.Split(sections["ipv4"]["address1"], ",")[0]
+	}
+
+	result.RangeStart, result.RangeEnd = dhcpRange(dnsmasq)
+
+	for _, line := range leases {
+		// expiry / mac / ip / hostname / client id
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		expiry, _ := strconv.ParseUint(fields[0], 10, 64)
+		l := rpi.HotspotLease{MAC: strings.ToLower(fields[1]), IP: fields[2], Expiry: expiry}
+		if fields[3] != "*" {
+			l.Hostname = fields[3]
+		}
+		result.Leases = append(result.Leases, l)
+	}
+
+	for _, line := range stations {
+		if m := stationRegex.FindStringSubmatch(line); m != nil {
+			c := rpi.HotspotClient{MAC: strings.ToLower(m[1])}
+			for _, l := range result.Leases {
+				if l.MAC == c.MAC {
+					c.IP = l.IP
+					c.Hostname = l.Hostname
+				}
+			}
+			result.Clients = append(result.Clients, c)
+			continue
+		}
+
+		if len(result.Clients) == 0 {
+			continue
+		}
+		c := &result.Clients[len(result.Clients)-1]
+		if m := signalRegex.FindStringSubmatch(line); m != nil {
+			c.Signal, _ = strconv.Atoi(m[1])
+		} else if m := timeRegex.FindStringSubmatch(line); m != nil {
+			c.ConnectedTime, _ = strconv.ParseUint(m[1], 10, 64)
+		}
+	}
+
+	return result, nil
+}
+
+// ExecuteEH returns an action response after enabling the hotspot
+func (h Hotspot) ExecuteEH(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return execute(actions.EnableHotspot, plan)
+}
+
+// ExecuteDH returns an action response after disabling the hotspot
+func (h Hotspot) ExecuteDH(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return execute(actions.DisableHotspot, plan)
+}
+
+func execute(name string, plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	actionStartTime := uint64(time.Now().Unix())
+	progressInit := actions.FlattenPlan(plan)
+	progress, exitStatus := actions.ExecutePlan(plan, progressInit)
+
+	return rpi.Action{
+		Name:          name,
+		NumberOfSteps: uint16(len(progressInit)),
+		Progress:      progress,
+		ExitStatus:    exitStatus,
+		StartTime:     actionStartTime,
+		EndTime:       uint64(time.Now().Unix()),
+	}, nil
+}
+
+// settings returns the key=value settings of a config file
+func settings(lines []string) map[string]string {
+	result := map[string]string{}
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		if m := settingRegex.FindStringSubmatch(line); m != nil {
+			result[m[1]] = m[2]
+		}
+	}
+	return result
+}
+
+// dhcpRange returns the first and the last address of the dhcp-range of a dnsmasq config
+// (ex: dhcp-range=192.168.4.2,192.168.4.20,255.255.255.0,24h)
+func dhcpRange(lines []string) (string, string) {
+	addresses := []string{}
+	for _, v := range strings.Split(settings(lines)["dhcp-range"], ",") {
+		if ip := net.ParseIP(v); ip != nil && ip.To4() != nil && len(addresses) < 2 {
+			addresses = append(addresses, v)
+		}
+	}
+
+	if len(addresses) < 2 {
+		return "", ""
+	}
+	return addresses[0], addresses[1]
+}
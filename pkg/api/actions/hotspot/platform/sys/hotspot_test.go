@@ -0,0 +1,144 @@
+package sys_test
+
+import (
+	"testing"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/hotspot/platform/sys"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	dnsmasq = []string{
+		"interface=wlan0",
+		"dhcp-range=192.168.4.2,192.168.4.20,255.255.255.0,24h",
+	}
+
+	stations = []string{
+		"Station AA:BB:CC:DD:EE:01 (on wlan0)",
+		"	inactive time:	300 ms",
+		"	signal:  	-45 [-45, -47] dBm",
+		"	connected time:	120 seconds",
+		"Station aa:bb:cc:dd:ee:02 (on wlan0)",
+		"	signal:  	-70 dBm",
+	}
+
+	leases = []string{
+		"1700000000 aa:bb:cc:dd:ee:01 192.168.4.10 phone 01:aa:bb:cc:dd:ee:01",
+		"1700000100 aa:bb:cc:dd:ee:03 192.168.4.11 * *",
+	}
+
+	wantedLeases = []rpi.HotspotLease{
+		{MAC: "aa:bb:cc:dd:ee:01", IP: "192.168.4.10", Hostname: "phone", Expiry: 1700000000},
+		{MAC: "aa:bb:cc:dd:ee:03", IP: "192.168.4.11", Expiry: 1700000100},
+	}
+
+	wantedClients = []rpi.HotspotClient{
+		{MAC: "aa:bb:cc:dd:ee:01", IP: "192.168.4.10", Hostname: "phone", Signal: -45, ConnectedTime: 120},
+		{MAC: "aa:bb:cc:dd:ee:02", Signal: -70},
+	}
+)
+
+func TestList(t *testing.T) {
+	cases := []struct {
+		name         string
+		backend      string
+		isEnabled    bool
+		config       []string
+		dhcpcd       []string
+		wantedResult rpi.Hotspot
+	}{
+		{
+			name:    "hostapd",
+			backend: "hostapd",
+			config: []string{
+				"interface=wlan0",
+				"ssid=field",
+				"channel=6",
+				"# wpa=0",
+				"wpa=2",
+			},
+			dhcpcd:    []string{"interface wlan0", "static ip_address=192.168.4.1/24", "nohook wpa_supplicant"},
+			isEnabled: true,
+			wantedResult: rpi.Hotspot{
+				Backend:    "hostapd",
+				Interface:  "wlan0",
+				IsEnabled:  true,
+				SSID:       "field",
+				IsSecured:  true,
+				Channel:    6,
+				Address:    "192.168.4.1/24",
+				RangeStart: "192.168.4.2",
+				RangeEnd:   "192.168.4.20",
+				Clients:    wantedClients,
+				Leases:     wantedLeases,
+			},
+		},
+		{
+			name:    "networkmanager open",
+			backend: "networkmanager",
+			config: []string{
+				"[connection]",
+				"id=raspibuddy-hotspot",
+				"[wifi]",
+				"mode=ap",
+				"ssid=field",
+				"channel=11",
+				"[ipv4]",
+				"method=shared",
+				"address1=10.42.0.1/24",
+			},
+			wantedResult: rpi.Hotspot{
+				Backend:    "networkmanager",
+				Interface:  "wlan0",
+				SSID:       "field",
+				Channel:    11,
+				Address:    "10.42.0.1/24",
+				RangeStart: "192.168.4.2",
+				RangeEnd:   "192.168.4.20",
+				Clients:    wantedClients,
+				Leases:     wantedLeases,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := sys.Hotspot{}
+			result, err := s.List(tc.backend, "wlan0", tc.isEnabled, tc.config, dnsmasq, tc.dhcpcd, stations, leases)
+			assert.Equal(t, tc.wantedResult, result)
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestExecute(t *testing.T) {
+	s := sys.Hotspot{}
+	cases := []struct {
+		name       string
+		execute    func(map[int](map[int]actions.Func)) (rpi.Action, error)
+		wantedName string
+	}{
+		{name: "enable", execute: s.ExecuteEH, wantedName: actions.EnableHotspot},
+		{name: "disable", execute: s.ExecuteDH, wantedName: actions.DisableHotspot},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.execute(map[int](map[int]actions.Func){
+				1: {
+					1: {
+						Name:      "funcA",
+						Reference: func(arg interface{}) (rpi.Exec, error) { return rpi.Exec{ExitStatus: 1}, nil },
+						Argument:  []interface{}{actions.EBC{}},
+					},
+				},
+			})
+			assert.Equal(t, tc.wantedName, result.Name)
+			assert.Equal(t, uint16(1), result.NumberOfSteps)
+			assert.Equal(t, uint8(1), result.ExitStatus)
+			assert.Nil(t, err)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package hotspot
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// Service represents all Hotspot application services.
+type Service interface {
+	List(string) (rpi.Hotspot, error)
+	ExecuteEH(string, rpi.Hotspot, string) (rpi.Action, error)
+	ExecuteDH(string) (rpi.Action, error)
+}
+
+// Hotspot represents a Hotspot application service.
+type Hotspot struct {
+	hsys HSYS
+	a    Actions
+	i    Infos
+}
+
+// HSYS represents a Hotspot repository service.
+type HSYS interface {
+	List(string, string, bool, []string, []string, []string, []string, []string) (rpi.Hotspot, error)
+	ExecuteEH(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteDH(map[int](map[int]actions.Func)) (rpi.Action, error)
+}
+
+// Actions represents the actions interface
+type Actions interface {
+	RestoreFile(interface{}) (rpi.Exec, error)
+	ManageUnit(interface{}) (rpi.Exec, error)
+	ExecuteBashCommand(interface{}) (rpi.Exec, error)
+}
+
+// Infos represents the infos interface
+type Infos interface {
+	ReadFile(string) ([]string, error)
+	IsFileExists(string) bool
+	ShowUnit(string) (map[string]string, error)
+	IsUnit(string) bool
+	ListWifiInterfaces(string) []string
+	NMConnections() []string
+	StationDump(string) []string
+}
+
+// New creates a Hotspot application service instance.
+func New(hsys HSYS, a Actions, i Infos) *Hotspot {
+	return &Hotspot{hsys: hsys, a: a, i: i}
+}
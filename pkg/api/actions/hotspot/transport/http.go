@@ -0,0 +1,86 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/hotspot"
+)
+
+// DefaultInterface is the wifi interface used when none is given
+const DefaultInterface = "wlan0"
+
+// HTTP is a struct implementing a core application service.
+type HTTP struct {
+	svc hotspot.Service
+}
+
+// NewHTTP creates new hotspot http service
+func NewHTTP(svc hotspot.Service, r *echo.Group) {
+	h := HTTP{svc}
+	cr := r.Group("/hotspot")
+	cr.GET("", h.list)
+	cr.POST("/enable", h.enable)
+	cr.POST("/disable", h.disable)
+}
+
+// iface returns the iface query parameter, wlan0 by default
+func iface(ctx echo.Context) string {
+	if iface := ctx.QueryParam("iface"); iface != "" {
+		return iface
+	}
+	return DefaultInterface
+}
+
+// passphrase returns the passphrase of the form body. The query string is refused since it ends up in the logs.
+func passphrase(ctx echo.Context) (string, error) {
+	if ctx.QueryParam("passphrase") != "" {
+		return "", echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to a passphrase in the query - should be sent in the form body")
+	}
+	return ctx.Request().PostFormValue("passphrase"), nil
+}
+
+func (h *HTTP) list(ctx echo.Context) error {
+	result, err := h.svc.List(iface(ctx))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) enable(ctx echo.Context) error {
+	channel := 0
+	if ctx.QueryParam("channel") != "" {
+		var err error
+		if channel, err = strconv.Atoi(ctx.QueryParam("channel")); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid channel - should be a 2.4GHz channel, from 1 to 13")
+		}
+	}
+
+	psk, err := passphrase(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.svc.ExecuteEH(iface(ctx), rpi.Hotspot{
+		SSID:       ctx.QueryParam("ssid"),
+		Channel:    channel,
+		Address:    ctx.QueryParam("address"),
+		RangeStart: ctx.QueryParam("start"),
+		RangeEnd:   ctx.QueryParam("end"),
+	}, psk)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) disable(ctx echo.Context) error {
+	result, err := h.svc.ExecuteDH(iface(ctx))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,150 @@
+package transport_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/hotspot"
+	"github.com/raspibuddy/rpi/pkg/api/actions/hotspot/transport"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/raspibuddy/rpi/pkg/utl/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHotspot(t *testing.T) {
+	cases := []struct {
+		name         string
+		method       string
+		req          string
+		body         string
+		executeErr   error
+		wantedStatus int
+	}{
+		{
+			name:         "success: list",
+			method:       http.MethodGet,
+			req:          "",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "error: not a wifi interface",
+			method:       http.MethodGet,
+			req:          "?iface=eth0",
+			wantedStatus: http.StatusNotFound,
+		},
+		{
+			name:         "error: invalid channel",
+			method:       http.MethodPost,
+			req:          "/enable?ssid=field&channel=six",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid ssid",
+			method:       http.MethodPost,
+			req:          "/enable",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid passphrase of the form body",
+			method:       http.MethodPost,
+			req:          "/enable?ssid=field",
+			body:         "passphrase=short",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: passphrase in the query",
+			method:       http.MethodPost,
+			req:          "/enable?ssid=field&passphrase=password",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: ExecuteEH result is nil",
+			method:       http.MethodPost,
+			req:          "/enable?ssid=field",
+			body:         "passphrase=password",
+			executeErr:   errors.New("test error"),
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:         "success: enable",
+			method:       http.MethodPost,
+			req:          "/enable?ssid=field&channel=6&address=10.0.0.1/24&start=10.0.0.10&end=10.0.0.50",
+			body:         "passphrase=password",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "success: disable",
+			method:       http.MethodPost,
+			req:          "/disable?iface=wlan0",
+			wantedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			i := mock.Infos{
+				ReadFileFn: func(string) ([]string, error) {
+					return []string{}, nil
+				},
+				IsFileExistsFn: func(string) bool {
+					return true
+				},
+				ShowUnitFn: func(string) (map[string]string, error) {
+					return map[string]string{"ActiveState": "active"}, nil
+				},
+				IsUnitFn: func(string) bool {
+					return true
+				},
+				ListWifiInterfacesFn: func(string) []string {
+					return []string{"wlan0"}
+				},
+				NMConnectionsFn: func() []string {
+					return []string{}
+				},
+				StationDumpFn: func(string) []string {
+					return []string{}
+				},
+			}
+			execute := func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+				return rpi.Action{NumberOfSteps: 1}, tc.executeErr
+			}
+			hsys := &mocksys.Hotspot{
+				ListFn: func(backend string, iface string, isEnabled bool, config []string, dnsmasq []string, dhcpcd []string, stations []string, leases []string) (rpi.Hotspot, error) {
+					return rpi.Hotspot{Backend: backend, Interface: iface, IsEnabled: isEnabled}, nil
+				},
+				ExecuteEHFn: execute,
+				ExecuteDHFn: execute,
+			}
+			s := hotspot.New(hsys, actions.New(), i)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/hotspot" + tc.req
+
+			req, err := http.NewRequest(tc.method, path, strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package localisation
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/constants"
+	"github.com/raspibuddy/rpi/pkg/utl/infos"
+)
+
+// List populates and returns the Localisation model.
+func (l *Localisation) List() (rpi.Localisation, error) {
+	// each file is optional, the matching value is then empty
+	timezone, _ := l.i.ReadFile(constants.ETCTIMEZONE)
+	locale, _ := l.i.ReadFile(constants.DEFAULTLOCALE)
+	keyboard, _ := l.i.ReadFile(constants.DEFAULTKEYBOARD)
+
+	return l.locsys.List(timezone, locale, keyboard)
+}
+
+// Timezones returns the timezones of the zoneinfo database.
+func (l *Localisation) Timezones() ([]string, error) {
+	result := l.i.Timezones(constants.ZONEINFO)
+	if len(result) == 0 {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "could not list the timezones")
+	}
+
+	return result, nil
+}
+
+// Locales returns the locales supported by the system.
+func (l *Localisation) Locales() ([]rpi.Locale, error) {
+	supported, err := l.i.ReadFile(constants.I18NSUPPORTED)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "could not read the supported locales")
+	}
+
+	return l.locsys.Locales(supported)
+}
+
+// Keyboards returns the keyboard layouts and their variants.
+func (l *Localisation) Keyboards() ([]rpi.KeyboardLayout, error) {
+	rules, err := l.i.ReadFile(constants.XKBRULES)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "could not read the keyboard layouts")
+	}
+
+	return l.locsys.Keyboards(rules)
+}
+
+// ExecuteTZ changes the timezone of the system, as raspi-config do_change_timezone does, and returns an action.
+func (l *Localisation) ExecuteTZ(timezone string) (rpi.Action, error) {
+	timezones, err := l.Timezones()
+	if err != nil {
+		return rpi.Action{}, err
+	}
+
+	if !infos.StringItemExists(timezones, timezone) {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to an invalid timezone - %v is not a known timezone", timezone))
+	}
+
+	plan := l.commands(
+		"rm -f /etc/localtime",
+		fmt.Sprintf("echo \"%v\" > %v", timezone, constants.ETCTIMEZONE),
+		"dpkg-reconfigure -f noninteractive tzdata",
+	)
+
+	return l.locsys.ExecuteTZ(plan)
+}
+
+// ExecuteLC generates a locale and sets it as the default one, as raspi-config do_change_locale does, and returns an action.
+func (l *Localisation) ExecuteLC(locale string) (rpi.Action, error) {
+	locales, err := l.Locales()
+	if err != nil {
+		return rpi.Action{}, err
+	}
+
+	charset := ""
+	for _, lc := range locales {
+		if lc.Name == locale {
+			charset = lc.Charset
+		}
+	}
+
+	if charset == "" {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to an invalid locale - %v is not a supported locale", locale))
+	}
+
+	plan := l.commands(
+		// locale.gen may be a link to the supported locales which must not be overwritten
+		fmt.Sprintf("[ ! -L %[1]v ] || rm -f %[1]v", constants.LOCALEGEN),
+		fmt.Sprintf("echo \"%v %v\" > %v", locale, charset, constants.LOCALEGEN),
+		fmt.Sprintf("LC_ALL=C LANG=C update-locale --no-checks LANG=%v", locale),
+		"LC_ALL=C LANG=C dpkg-reconfigure -f noninteractive locales",
+	)
+
+	return l.locsys.ExecuteLC(plan)
+}
+
+// ExecuteKB sets the keyboard layout and variant, as raspi-config do_configure_keyboard does, and returns an action.
+// An empty variant selects the default variant of the layout.
+func (l *Localisation) ExecuteKB(layout string, variant string) (rpi.Action, error) {
+	layouts, err := l.Keyboards()
+	if err != nil {
+		return rpi.Action{}, err
+	}
+
+	isLayout, isVariant := false, variant == ""
+	for _, kl := range layouts {
+		if kl.Name != layout {
+			continue
+		}
+		isLayout = true
+		for _, kv := range kl.Variants {
+			if kv.Name == variant {
+				isVariant = true
+			}
+		}
+	}
+
+	if !isLayout {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to an invalid layout - %v is not a known keyboard layout", layout))
+	}
+
+	if !isVariant {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to an invalid variant - %v is not a variant of %v", variant, layout))
+	}
+
+	plan := l.commands(
+		fmt.Sprintf("sed -i %v -e 's/^XKBLAYOUT=.*/XKBLAYOUT=\"%v\"/' -e 's/^XKBVARIANT=.*/XKBVARIANT=\"%v\"/'", constants.DEFAULTKEYBOARD, layout, variant),
+		"dpkg-reconfigure -f noninteractive keyboard-configuration",
+		"invoke-rc.d keyboard-setup start",
+		"setsid sh -c 'exec setupcon -k --force <> /dev/tty1 >&0 2>&1'",
+		"udevadm trigger --subsystem-match=input --action=change",
+	)
+
+	return l.locsys.ExecuteKB(plan)
+}
+
+// commands returns a plan running each command in its own step, in order
+func (l *Localisation) commands(commands ...string) map[int](map[int]actions.Func) {
+	plan := map[int](map[int]actions.Func){}
+
+	for _, c := range commands {
+		plan[len(plan)+1] = map[int]actions.Func{
+			1: {
+				Name:      actions.ExecuteBashCommand,
+				Reference: l.a.ExecuteBashCommand,
+				Argument: []interface{}{
+					actions.EBC{
+						Command: c,
+					},
+				},
+			},
+		}
+	}
+
+	return plan
+}
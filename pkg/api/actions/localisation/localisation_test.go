@@ -0,0 +1,173 @@
+package localisation_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/localisation"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/constants"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/stretchr/testify/assert"
+)
+
+func infos(readErr error, timezones []string) *mock.Infos {
+	return &mock.Infos{
+		ReadFileFn: func(path string) ([]string, error) {
+			return []string{path}, readErr
+		},
+		TimezonesFn: func(string) []string {
+			return timezones
+		},
+	}
+}
+
+func locsys(commands *[]string, paths *[]string) *mocksys.Localisation {
+	execute := func(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+		for k := 1; k <= len(plan); k++ {
+			*commands = append(*commands, plan[k][1].Argument[0].(actions.EBC).Command)
+		}
+		return rpi.Action{NumberOfSteps: uint16(len(plan))}, nil
+	}
+
+	return &mocksys.Localisation{
+		ListFn: func(timezone []string, locale []string, keyboard []string) (rpi.Localisation, error) {
+			*paths = append(append(append(*paths, timezone...), locale...), keyboard...)
+			return rpi.Localisation{Timezone: "Europe/London"}, nil
+		},
+		LocalesFn: func([]string) ([]rpi.Locale, error) {
+			return []rpi.Locale{{Name: "fr_FR.UTF-8", Charset: "UTF-8"}}, nil
+		},
+		KeyboardsFn: func([]string) ([]rpi.KeyboardLayout, error) {
+			return []rpi.KeyboardLayout{{Name: "fr", Variants: []rpi.KeyboardVariant{{Name: "azerty"}}}}, nil
+		},
+		ExecuteTZFn: execute,
+		ExecuteLCFn: execute,
+		ExecuteKBFn: execute,
+	}
+}
+
+func TestList(t *testing.T) {
+	var commands, paths []string
+
+	s := localisation.New(locsys(&commands, &paths), actions.New(), infos(nil, nil))
+	result, err := s.List()
+	assert.Nil(t, err)
+	assert.Equal(t, "Europe/London", result.Timezone)
+	assert.Equal(t, []string{constants.ETCTIMEZONE, constants.DEFAULTLOCALE, constants.DEFAULTKEYBOARD}, paths)
+}
+
+func TestLists(t *testing.T) {
+	var commands, paths []string
+
+	s := localisation.New(locsys(&commands, &paths), actions.New(), infos(errors.New("test error"), []string{}))
+	_, err := s.Timezones()
+	assert.Equal(t, echo.NewHTTPError(http.StatusInternalServerError, "could not list the timezones"), err)
+	_, err = s.Locales()
+	assert.Equal(t, echo.NewHTTPError(http.StatusInternalServerError, "could not read the supported locales"), err)
+	_, err = s.Keyboards()
+	assert.Equal(t, echo.NewHTTPError(http.StatusInternalServerError, "could not read the keyboard layouts"), err)
+
+	s = localisation.New(locsys(&commands, &paths), actions.New(), infos(nil, []string{"Europe/Paris"}))
+	timezones, err := s.Timezones()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Europe/Paris"}, timezones)
+	locales, err := s.Locales()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(locales))
+	keyboards, err := s.Keyboards()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(keyboards))
+}
+
+func TestExecuteTZ(t *testing.T) {
+	var commands, paths []string
+
+	s := localisation.New(locsys(&commands, &paths), actions.New(), infos(nil, []string{"Europe/Paris"}))
+	_, err := s.ExecuteTZ("Europe/Nowhere")
+	assert.Equal(t, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid timezone - Europe/Nowhere is not a known timezone"), err)
+
+	_, err = s.ExecuteTZ("Europe/Paris")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"rm -f /etc/localtime",
+		`echo "Europe/Paris" > /etc/timezone`,
+		"dpkg-reconfigure -f noninteractive tzdata",
+	}, commands)
+}
+
+func TestExecuteLC(t *testing.T) {
+	var commands, paths []string
+
+	s := localisation.New(locsys(&commands, &paths), actions.New(), infos(nil, nil))
+	_, err := s.ExecuteLC("xx_XX.UTF-8")
+	assert.Equal(t, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid locale - xx_XX.UTF-8 is not a supported locale"), err)
+
+	_, err = s.ExecuteLC("fr_FR.UTF-8")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"[ ! -L /etc/locale.gen ] || rm -f /etc/locale.gen",
+		`echo "fr_FR.UTF-8 UTF-8" > /etc/locale.gen`,
+		"LC_ALL=C LANG=C update-locale --no-checks LANG=fr_FR.UTF-8",
+		"LC_ALL=C LANG=C dpkg-reconfigure -f noninteractive locales",
+	}, commands)
+}
+
+func TestExecuteKB(t *testing.T) {
+	cases := []struct {
+		name           string
+		layout         string
+		variant        string
+		wantedCommands []string
+		wantedErr      error
+	}{
+		{
+			name:      "error: invalid layout",
+			layout:    "xx",
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid layout - xx is not a known keyboard layout"),
+		},
+		{
+			name:      "error: invalid variant",
+			layout:    "fr",
+			variant:   "qwerty",
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid variant - qwerty is not a variant of fr"),
+		},
+		{
+			name:    "success: variant",
+			layout:  "fr",
+			variant: "azerty",
+			wantedCommands: []string{
+				`sed -i /etc/default/keyboard -e 's/^XKBLAYOUT=.*/XKBLAYOUT="fr"/' -e 's/^XKBVARIANT=.*/XKBVARIANT="azerty"/'`,
+				"dpkg-reconfigure -f noninteractive keyboard-configuration",
+				"invoke-rc.d keyboard-setup start",
+				"setsid sh -c 'exec setupcon -k --force <> /dev/tty1 >&0 2>&1'",
+				"udevadm trigger --subsystem-match=input --action=change",
+			},
+		},
+		{
+			name:   "success: default variant",
+			layout: "fr",
+			wantedCommands: []string{
+				`sed -i /etc/default/keyboard -e 's/^XKBLAYOUT=.*/XKBLAYOUT="fr"/' -e 's/^XKBVARIANT=.*/XKBVARIANT=""/'`,
+				"dpkg-reconfigure -f noninteractive keyboard-configuration",
+				"invoke-rc.d keyboard-setup start",
+				"setsid sh -c 'exec setupcon -k --force <> /dev/tty1 >&0 2>&1'",
+				"udevadm trigger --subsystem-match=input --action=change",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var commands, paths []string
+			s := localisation.New(locsys(&commands, &paths), actions.New(), infos(nil, nil))
+			_, err := s.ExecuteKB(tc.layout, tc.variant)
+			assert.Equal(t, tc.wantedErr, err)
+			assert.Equal(t, tc.wantedCommands, commands)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package localisation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/localisation"
+)
+
+// New creates a new Localisation logging service instance.
+func New(svc localisation.Service, logger rpi.Logger) *LogService {
+	return &LogService{
+		Service: svc,
+		logger:  logger,
+	}
+}
+
+// LogService represents a Localisation logging service.
+type LogService struct {
+	localisation.Service
+	logger rpi.Logger
+}
+
+const name = "localisation"
+
+// List is the logging function attached to the List localisation services and responsible for logging it out.
+func (ls *LogService) List(ctx echo.Context) (resp rpi.Localisation, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, "request: list localisation", err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.List()
+}
+
+// Timezones is the logging function attached to the Timezones localisation services and responsible for logging it out.
+func (ls *LogService) Timezones(ctx echo.Context) (resp []string, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, "request: list timezones", err,
+			map[string]interface{}{
+				"resp": len(resp),
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.Timezones()
+}
+
+// Locales is the logging function attached to the Locales localisation services and responsible for logging it out.
+func (ls *LogService) Locales(ctx echo.Context) (resp []rpi.Locale, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, "request: list locales", err,
+			map[string]interface{}{
+				"resp": len(resp),
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.Locales()
+}
+
+// Keyboards is the logging function attached to the Keyboards localisation services and responsible for logging it out.
+func (ls *LogService) Keyboards(ctx echo.Context) (resp []rpi.KeyboardLayout, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, "request: list keyboard layouts", err,
+			map[string]interface{}{
+				"resp": len(resp),
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.Keyboards()
+}
+
+// ExecuteTZ is the logging function attached to the ExecuteTZ localisation services and responsible for logging it out.
+func (ls *LogService) ExecuteTZ(ctx echo.Context, timezone string) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: change timezone to %v", timezone), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteTZ(timezone)
+}
+
+// ExecuteLC is the logging function attached to the ExecuteLC localisation services and responsible for logging it out.
+func (ls *LogService) ExecuteLC(ctx echo.Context, locale string) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: change locale to %v", locale), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteLC(locale)
+}
+
+// ExecuteKB is the logging function attached to the ExecuteKB localisation services and responsible for logging it out.
+func (ls *LogService) ExecuteKB(ctx echo.Context, layout string, variant string) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: change keyboard to %v %v", layout, variant), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteKB(layout, variant)
+}
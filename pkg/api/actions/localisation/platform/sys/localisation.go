@@ -0,0 +1,145 @@
+package sys
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// Localisation represents an empty Localisation entity on the current system.
+type Localisation struct{}
+
+// List returns the timezone, locale and keyboard read from /etc/timezone, /etc/default/locale and /etc/default/keyboard
+func (l Localisation) List(timezone []string, locale []string, keyboard []string) (rpi.Localisation, error) {
+	result := rpi.Localisation{}
+
+	for _, line := range timezone {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			result.Timezone = line
+			break
+		}
+	}
+
+	result.Locale = variables(locale)["LANG"]
+
+	k := variables(keyboard)
+	result.Keyboard = rpi.Keyboard{
+		Model:   k["XKBMODEL"],
+		Layout:  k["XKBLAYOUT"],
+		Variant: k["XKBVARIANT"],
+		Options: k["XKBOPTIONS"],
+	}
+
+	return result, nil
+}
+
+// Locales returns the locales listed in /usr/share/i18n/SUPPORTED (ex: en_GB.UTF-8 UTF-8)
+func (l Localisation) Locales(supported []string) ([]rpi.Locale, error) {
+	result := []rpi.Locale{}
+
+	for _, line := range supported {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		result = append(result, rpi.Locale{Name: fields[0], Charset: fields[1]})
+	}
+
+	return result, nil
+}
+
+// Keyboards returns the layouts and their variants listed in the layout and variant sections of an xkb rules file.
+// A variant line starts with the name of its layout (ex: "  extd   gb: English (UK, extended, Windows)").
+func (l Localisation) Keyboards(rules []string) ([]rpi.KeyboardLayout, error) {
+	result := []rpi.KeyboardLayout{}
+	index := map[string]int{}
+
+	section := ""
+	for _, line := range rules {
+		if strings.HasPrefix(line, "!") {
+			section = strings.TrimSpace(strings.TrimPrefix(line, "!"))
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		description := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), fields[0]))
+
+		switch section {
+		case "layout":
+			index[fields[0]] = len(result)
+			result = append(result, rpi.KeyboardLayout{
+				Name:        fields[0],
+				Description: description,
+				Variants:    []rpi.KeyboardVariant{},
+			})
+		case "variant":
+			s := strings.SplitN(description, ":", 2)
+			k, isFound := index[s[0]]
+			if len(s) != 2 || !isFound {
+				continue
+			}
+			result[k].Variants = append(result[k].Variants, rpi.KeyboardVariant{
+				Name:        fields[0],
+				Description: strings.TrimSpace(s[1]),
+			})
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// ExecuteTZ returns an action response after changing the timezone
+func (l Localisation) ExecuteTZ(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return execute(actions.Timezone, plan)
+}
+
+// ExecuteLC returns an action response after changing the locale
+func (l Localisation) ExecuteLC(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return execute(actions.Locale, plan)
+}
+
+// ExecuteKB returns an action response after changing the keyboard layout
+func (l Localisation) ExecuteKB(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return execute(actions.Keyboard, plan)
+}
+
+func execute(name string, plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	actionStartTime := uint64(time.Now().Unix())
+	progressInit := actions.FlattenPlan(plan)
+	progress, exitStatus := actions.ExecutePlan(plan, progressInit)
+
+	return rpi.Action{
+		Name:          name,
+		NumberOfSteps: uint16(len(progressInit)),
+		Progress:      progress,
+		ExitStatus:    exitStatus,
+		StartTime:     actionStartTime,
+		EndTime:       uint64(time.Now().Unix()),
+	}, nil
+}
+
+// variables returns the KEY=value lines of a shell variables file, without quotes
+func variables(lines []string) map[string]string {
+	result := map[string]string{}
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#") || !strings.Contains(line, "=") {
+			continue
+		}
+		s := strings.SplitN(line, "=", 2)
+		result[strings.TrimSpace(s[0])] = strings.Trim(strings.TrimSpace(s[1]), `"'`)
+	}
+
+	return result
+}
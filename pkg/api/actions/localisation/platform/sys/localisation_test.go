@@ -0,0 +1,142 @@
+package sys_test
+
+import (
+	"testing"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/localisation/platform/sys"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	cases := []struct {
+		name         string
+		timezone     []string
+		locale       []string
+		keyboard     []string
+		wantedResult rpi.Localisation
+	}{
+		{
+			name:         "missing files",
+			wantedResult: rpi.Localisation{},
+		},
+		{
+			name:     "success",
+			timezone: []string{"Europe/London", ""},
+			locale:   []string{"#  File generated by update-locale", "LANG=en_GB.UTF-8"},
+			keyboard: []string{
+				"# KEYBOARD CONFIGURATION FILE",
+				"",
+				`XKBMODEL="pc105"`,
+				`XKBLAYOUT="gb"`,
+				`XKBVARIANT=""`,
+				`XKBOPTIONS="ctrl:nocaps"`,
+				"",
+				`BACKSPACE="guess"`,
+			},
+			wantedResult: rpi.Localisation{
+				Timezone: "Europe/London",
+				Locale:   "en_GB.UTF-8",
+				Keyboard: rpi.Keyboard{
+					Model:   "pc105",
+					Layout:  "gb",
+					Variant: "",
+					Options: "ctrl:nocaps",
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := sys.Localisation{}
+			result, err := s.List(tc.timezone, tc.locale, tc.keyboard)
+			assert.Equal(t, tc.wantedResult, result)
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestLocales(t *testing.T) {
+	s := sys.Localisation{}
+	result, err := s.Locales([]string{
+		"aa_DJ.UTF-8 UTF-8",
+		"en_GB ISO-8859-1",
+		"",
+		"# comment",
+		"en_GB.UTF-8 UTF-8",
+	})
+
+	assert.Equal(t, []rpi.Locale{
+		{Name: "aa_DJ.UTF-8", Charset: "UTF-8"},
+		{Name: "en_GB", Charset: "ISO-8859-1"},
+		{Name: "en_GB.UTF-8", Charset: "UTF-8"},
+	}, result)
+	assert.Nil(t, err)
+}
+
+func TestKeyboards(t *testing.T) {
+	s := sys.Localisation{}
+	result, err := s.Keyboards([]string{
+		"! model",
+		"  pc105           Generic 105-key PC",
+		"",
+		"! layout",
+		"  us              English (US)",
+		"  gb              English (UK)",
+		"",
+		"! variant",
+		"  extd            gb: English (UK, extended, Windows)",
+		"  intl            us: English (US, intl., with dead keys)",
+		"  dummy           xx: Unknown layout",
+		"",
+		"! option",
+		"  grp             Switching to another layout",
+	})
+
+	assert.Equal(t, []rpi.KeyboardLayout{
+		{
+			Name:        "gb",
+			Description: "English (UK)",
+			Variants:    []rpi.KeyboardVariant{{Name: "extd", Description: "English (UK, extended, Windows)"}},
+		},
+		{
+			Name:        "us",
+			Description: "English (US)",
+			Variants:    []rpi.KeyboardVariant{{Name: "intl", Description: "English (US, intl., with dead keys)"}},
+		},
+	}, result)
+	assert.Nil(t, err)
+}
+
+func TestExecute(t *testing.T) {
+	s := sys.Localisation{}
+	cases := []struct {
+		name       string
+		execute    func(map[int](map[int]actions.Func)) (rpi.Action, error)
+		wantedName string
+	}{
+		{name: "timezone", execute: s.ExecuteTZ, wantedName: actions.Timezone},
+		{name: "locale", execute: s.ExecuteLC, wantedName: actions.Locale},
+		{name: "keyboard", execute: s.ExecuteKB, wantedName: actions.Keyboard},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.execute(map[int](map[int]actions.Func){
+				1: {
+					1: {
+						Name:      "funcA",
+						Reference: func(arg interface{}) (rpi.Exec, error) { return rpi.Exec{ExitStatus: 1}, nil },
+						Argument:  []interface{}{actions.EBC{}},
+					},
+				},
+			})
+			assert.Equal(t, tc.wantedName, result.Name)
+			assert.Equal(t, uint16(1), result.NumberOfSteps)
+			assert.Equal(t, uint8(1), result.ExitStatus)
+			assert.Nil(t, err)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package localisation
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// Service represents all Localisation application services.
+type Service interface {
+	List() (rpi.Localisation, error)
+	Timezones() ([]string, error)
+	Locales() ([]rpi.Locale, error)
+	Keyboards() ([]rpi.KeyboardLayout, error)
+	ExecuteTZ(string) (rpi.Action, error)
+	ExecuteLC(string) (rpi.Action, error)
+	ExecuteKB(string, string) (rpi.Action, error)
+}
+
+// Localisation represents a Localisation application service.
+type Localisation struct {
+	locsys LOCSYS
+	a      Actions
+	i      Infos
+}
+
+// LOCSYS represents a Localisation repository service.
+type LOCSYS interface {
+	List([]string, []string, []string) (rpi.Localisation, error)
+	Locales([]string) ([]rpi.Locale, error)
+	Keyboards([]string) ([]rpi.KeyboardLayout, error)
+	ExecuteTZ(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteLC(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteKB(map[int](map[int]actions.Func)) (rpi.Action, error)
+}
+
+// Actions represents the actions interface
+type Actions interface {
+	ExecuteBashCommand(interface{}) (rpi.Exec, error)
+}
+
+// Infos represents the infos interface
+type Infos interface {
+	ReadFile(string) ([]string, error)
+	Timezones(string) []string
+}
+
+// New creates a Localisation application service instance.
+func New(locsys LOCSYS, a Actions, i Infos) *Localisation {
+	return &Localisation{locsys: locsys, a: a, i: i}
+}
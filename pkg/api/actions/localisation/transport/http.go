@@ -0,0 +1,97 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi/pkg/api/actions/localisation"
+)
+
+// HTTP is a struct implementing a core application service.
+type HTTP struct {
+	svc localisation.Service
+}
+
+// NewHTTP creates new localisation http service
+func NewHTTP(svc localisation.Service, r *echo.Group) {
+	h := HTTP{svc}
+	cr := r.Group("/localisation")
+	cr.GET("", h.list)
+	cr.GET("/timezones", h.timezones)
+	cr.GET("/locales", h.locales)
+	cr.GET("/keyboards", h.keyboards)
+	cr.POST("/timezone", h.timezone)
+	cr.POST("/locale", h.locale)
+	cr.POST("/keyboard", h.keyboard)
+}
+
+func (h *HTTP) list(ctx echo.Context) error {
+	result, err := h.svc.List()
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) timezones(ctx echo.Context) error {
+	result, err := h.svc.Timezones()
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) locales(ctx echo.Context) error {
+	result, err := h.svc.Locales()
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) keyboards(ctx echo.Context) error {
+	result, err := h.svc.Keyboards()
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) timezone(ctx echo.Context) error {
+	timezone := ctx.QueryParam("timezone")
+	if timezone == "" {
+		return echo.NewHTTPError(http.StatusNotFound, "Not found - timezone is null")
+	}
+
+	result, err := h.svc.ExecuteTZ(timezone)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) locale(ctx echo.Context) error {
+	locale := ctx.QueryParam("locale")
+	if locale == "" {
+		return echo.NewHTTPError(http.StatusNotFound, "Not found - locale is null")
+	}
+
+	result, err := h.svc.ExecuteLC(locale)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) keyboard(ctx echo.Context) error {
+	layout := ctx.QueryParam("layout")
+	if layout == "" {
+		return echo.NewHTTPError(http.StatusNotFound, "Not found - layout is null")
+	}
+
+	result, err := h.svc.ExecuteKB(layout, ctx.QueryParam("variant"))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,161 @@
+package transport_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/localisation"
+	"github.com/raspibuddy/rpi/pkg/api/actions/localisation/transport"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/raspibuddy/rpi/pkg/utl/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalisation(t *testing.T) {
+	cases := []struct {
+		name         string
+		method       string
+		req          string
+		readErr      error
+		executeErr   error
+		wantedStatus int
+	}{
+		{
+			name:         "success: list",
+			method:       http.MethodGet,
+			req:          "",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "success: timezones",
+			method:       http.MethodGet,
+			req:          "/timezones",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "error: locales read",
+			method:       http.MethodGet,
+			req:          "/locales",
+			readErr:      errors.New("test error"),
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:         "success: keyboards",
+			method:       http.MethodGet,
+			req:          "/keyboards",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "error: timezone is null",
+			method:       http.MethodPost,
+			req:          "/timezone",
+			wantedStatus: http.StatusNotFound,
+		},
+		{
+			name:         "error: invalid timezone",
+			method:       http.MethodPost,
+			req:          "/timezone?timezone=Europe/Nowhere",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: ExecuteTZ result is nil",
+			method:       http.MethodPost,
+			req:          "/timezone?timezone=Europe/Paris",
+			executeErr:   errors.New("test error"),
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:         "success: timezone",
+			method:       http.MethodPost,
+			req:          "/timezone?timezone=Europe/Paris",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "error: locale is null",
+			method:       http.MethodPost,
+			req:          "/locale",
+			wantedStatus: http.StatusNotFound,
+		},
+		{
+			name:         "success: locale",
+			method:       http.MethodPost,
+			req:          "/locale?locale=fr_FR.UTF-8",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "error: layout is null",
+			method:       http.MethodPost,
+			req:          "/keyboard?variant=azerty",
+			wantedStatus: http.StatusNotFound,
+		},
+		{
+			name:         "error: invalid variant",
+			method:       http.MethodPost,
+			req:          "/keyboard?layout=fr&variant=qwerty",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "success: keyboard",
+			method:       http.MethodPost,
+			req:          "/keyboard?layout=fr&variant=azerty",
+			wantedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			i := mock.Infos{
+				ReadFileFn: func(string) ([]string, error) {
+					return []string{}, tc.readErr
+				},
+				TimezonesFn: func(string) []string {
+					return []string{"Europe/Paris"}
+				},
+			}
+			execute := func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+				return rpi.Action{NumberOfSteps: 1}, tc.executeErr
+			}
+			locsys := &mocksys.Localisation{
+				ListFn: func([]string, []string, []string) (rpi.Localisation, error) {
+					return rpi.Localisation{}, nil
+				},
+				LocalesFn: func([]string) ([]rpi.Locale, error) {
+					return []rpi.Locale{{Name: "fr_FR.UTF-8", Charset: "UTF-8"}}, nil
+				},
+				KeyboardsFn: func([]string) ([]rpi.KeyboardLayout, error) {
+					return []rpi.KeyboardLayout{{Name: "fr", Variants: []rpi.KeyboardVariant{{Name: "azerty"}}}}, nil
+				},
+				ExecuteTZFn: execute,
+				ExecuteLCFn: execute,
+				ExecuteKBFn: execute,
+			}
+			s := localisation.New(locsys, actions.New(), i)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/localisation" + tc.req
+
+			req, err := http.NewRequest(tc.method, path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
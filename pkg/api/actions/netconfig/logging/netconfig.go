@@ -0,0 +1,56 @@
+package netconfig
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/netconfig"
+)
+
+// New creates a new NetConfig logging service instance.
+func New(svc netconfig.Service, logger rpi.Logger) *LogService {
+	return &LogService{
+		Service: svc,
+		logger:  logger,
+	}
+}
+
+// LogService represents a NetConfig logging service.
+type LogService struct {
+	netconfig.Service
+	logger rpi.Logger
+}
+
+const name = "netconfig"
+
+// List is the logging function attached to the List netconfig services and responsible for logging it out.
+func (ls *LogService) List(ctx echo.Context) (resp rpi.NetworkConfig, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, "request: list network interfaces configuration", err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.List()
+}
+
+// ExecuteNC is the logging function attached to the ExecuteNC netconfig services and responsible for logging it out.
+func (ls *LogService) ExecuteNC(ctx echo.Context, iface string, ic rpi.InterfaceConfig) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: configure network interface %v", iface), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteNC(iface, ic)
+}
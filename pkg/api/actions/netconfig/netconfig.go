@@ -0,0 +1,218 @@
+package netconfig
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/constants"
+	"github.com/raspibuddy/rpi/pkg/utl/netconf"
+)
+
+// Timeout is the time given to an interface to come up with its new configuration before it is rolled back
+const Timeout = 30 * time.Second
+
+// List returns the ip configuration of the network interfaces.
+// NetworkManager is the backend when it runs (Bookworm), dhcpcd when dhcpcd.conf exists.
+func (n *NetConfig) List() (rpi.NetworkConfig, error) {
+	backend := n.backend()
+
+	dhcpcd := []string{}
+	if backend == netconf.Dhcpcd {
+		var err error
+		if dhcpcd, err = n.i.ReadFile(constants.DHCPCDCONF); err != nil {
+			return rpi.NetworkConfig{}, echo.NewHTTPError(http.StatusInternalServerError, "could not read dhcpcd.conf")
+		}
+	}
+
+	connections := []string{}
+	keyfiles := map[string][]string{}
+	if backend == netconf.NetworkManager {
+		connections = n.i.NMConnections()
+		for _, path := range n.i.ListFiles(constants.NMCONNECTIONS) {
+			if lines, err := n.i.ReadFile(path); err == nil {
+				keyfiles[path] = lines
+			}
+		}
+	}
+
+	interfaces, err := n.m.NetInfo()
+	if err != nil {
+		return rpi.NetworkConfig{}, echo.NewHTTPError(http.StatusInternalServerError, "could not list the network interfaces")
+	}
+
+	return n.ncsys.List(backend, dhcpcd, connections, keyfiles, interfaces)
+}
+
+// ExecuteNC writes the ip configuration of an interface, static or dhcp, applies it and returns an action.
+// The previous configuration is restored when the interface does not come up with its new addresses.
+func (n *NetConfig) ExecuteNC(iface string, ic rpi.InterfaceConfig) (rpi.Action, error) {
+	s, err := settings(ic)
+	if err != nil {
+		return rpi.Action{}, err
+	}
+
+	config, err := n.List()
+	if err != nil {
+		return rpi.Action{}, err
+	}
+
+	current, isFound := rpi.InterfaceConfig{}, false
+	for _, c := range config.Interfaces {
+		if c.Interface == iface {
+			current, isFound = c, true
+		}
+	}
+	if !isFound {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Not found - %v is not a network interface", iface))
+	}
+
+	arg := actions.ANC{
+		Interface: iface,
+		Timeout:   Timeout,
+	}
+	if s.IsStatic {
+		arg.Addresses = append(append([]string{}, s.IPv4...), s.IPv6...)
+	}
+
+	var lines []string
+	var mode uint32
+
+	switch config.Backend {
+	case netconf.Dhcpcd:
+		if s.Router6 != "" {
+			return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid ipv6 router - dhcpcd learns it from the router advertisements")
+		}
+		if lines, err = n.i.ReadFile(constants.DHCPCDCONF); err != nil {
+			return rpi.Action{}, echo.NewHTTPError(http.StatusInternalServerError, "could not read dhcpcd.conf")
+		}
+		lines = netconf.SetDhcpcd(lines, iface, s)
+		mode = 0664
+		arg.Path = constants.DHCPCDCONF
+		arg.Command = fmt.Sprintf("ip addr flush dev %v scope global && dhcpcd -n %v", iface, iface)
+		arg.Rollback = arg.Command
+	case netconf.NetworkManager:
+		arg.Path = current.Connection
+		if arg.Path == "" {
+			if contains(n.i.ListWifiInterfaces(constants.NETWORKINTERFACES), iface) {
+				return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to an unsupported interface - %v has no NetworkManager connection to edit", iface))
+			}
+			arg.Path = filepath.Join(constants.NMCONNECTIONS, fmt.Sprintf("raspibuddy-%v.nmconnection", iface))
+			arg.IsNew = true
+			lines = netconf.NewKeyfile(fmt.Sprintf("raspibuddy-%v", iface), netconf.NewUUID(), netconf.Ethernet, iface)
+		} else if lines, err = n.i.ReadFile(arg.Path); err != nil {
+			return rpi.Action{}, echo.NewHTTPError(http.StatusInternalServerError, "could not read the NetworkManager connection")
+		}
+
+		k := netconf.ParseKeyfile(lines)
+		lines = netconf.SetKeyfile(lines, s)
+		mode = 0600
+
+		connection := fmt.Sprintf("uuid %v", k.UUID)
+		if k.UUID == "" {
+			connection = fmt.Sprintf("id '%v'", strings.ReplaceAll(k.ID, "'", `'\''`))
+		}
+		arg.Command = fmt.Sprintf("nmcli connection reload && nmcli connection up %v", connection)
+		arg.Rollback = arg.Command
+		if arg.IsNew {
+			arg.Rollback = fmt.Sprintf("nmcli connection reload && nmcli device connect %v", iface)
+		}
+	default:
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an unsupported system - neither NetworkManager nor dhcpcd configure the network")
+	}
+
+	arg.Backup = filepath.Join(constants.NETCONFIG, filepath.Base(arg.Path))
+
+	plan := map[int](map[int]actions.Func){
+		1: {
+			1: {
+				Name:      actions.RestoreFile,
+				Reference: n.a.RestoreFile,
+				Argument: []interface{}{
+					actions.RF{
+						Path:    arg.Path,
+						Backup:  arg.Backup,
+						Content: []byte(strings.Join(lines, "\n") + "\n"),
+						Mode:    mode,
+					},
+				},
+			},
+		},
+		2: {
+			1: {
+				Name:      actions.ApplyNetworkConfig,
+				Reference: n.a.ApplyNetworkConfig,
+				Argument:  []interface{}{arg},
+			},
+		},
+	}
+
+	return n.ncsys.ExecuteNC(plan)
+}
+
+// backend returns the backend keeping the ip configuration, empty when none is found
+func (n *NetConfig) backend() string {
+	if unit, err := n.i.ShowUnit("NetworkManager.service"); err == nil && unit["ActiveState"] == "active" {
+		return netconf.NetworkManager
+	}
+	if n.i.IsFileExists(constants.DHCPCDCONF) {
+		return netconf.Dhcpcd
+	}
+	return ""
+}
+
+// settings validates an ip configuration. The addresses and the routers are dropped when it is not static.
+func settings(ic rpi.InterfaceConfig) (netconf.Settings, error) {
+	s := netconf.Settings{IsStatic: ic.IsStatic, IPv4: []string{}, IPv6: []string{}, DNS: []string{}}
+
+	if ic.IsStatic {
+		if len(ic.IPv4) == 0 {
+			return netconf.Settings{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid ipv4 address - a static interface needs at least one")
+		}
+		for _, a := range ic.IPv4 {
+			if ip, _, err := net.ParseCIDR(a); err != nil || ip.To4() == nil {
+				return netconf.Settings{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid ipv4 address - should be in CIDR notation (ex: 192.168.1.10/24)")
+			}
+		}
+		for _, a := range ic.IPv6 {
+			if ip, _, err := net.ParseCIDR(a); err != nil || ip.To4() != nil {
+				return netconf.Settings{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid ipv6 address - should be in CIDR notation (ex: fd00::10/64)")
+			}
+		}
+		if ip := net.ParseIP(ic.Router); ic.Router != "" && (ip == nil || ip.To4() == nil) {
+			return netconf.Settings{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid router - should be an ipv4 address")
+		}
+		if ip := net.ParseIP(ic.Router6); ic.Router6 != "" && (ip == nil || ip.To4() != nil) {
+			return netconf.Settings{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid ipv6 router - should be an ipv6 address")
+		}
+
+		s.IPv4 = ic.IPv4
+		s.Router = ic.Router
+		s.IPv6 = append(s.IPv6, ic.IPv6...)
+		s.Router6 = ic.Router6
+	}
+
+	for _, d := range ic.DNS {
+		if net.ParseIP(d) == nil {
+			return netconf.Settings{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid dns server - should be an ip address")
+		}
+	}
+	s.DNS = append(s.DNS, ic.DNS...)
+
+	return s, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
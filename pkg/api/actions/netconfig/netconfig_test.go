@@ -0,0 +1,272 @@
+package netconfig_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/netconfig"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/constants"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/raspibuddy/rpi/pkg/utl/netconf"
+	"github.com/shirou/gopsutil/net"
+	"github.com/stretchr/testify/assert"
+)
+
+const keyfile = "/etc/NetworkManager/system-connections/home.nmconnection"
+
+func infos(backend string, readErr error) *mock.Infos {
+	return &mock.Infos{
+		ReadFileFn: func(path string) ([]string, error) {
+			if path == keyfile {
+				return []string{"[connection]", "id=home", "uuid=7d1a4b1e-0000-4000-8000-000000000002", "type=wifi", "", "[ipv4]", "method=auto"}, readErr
+			}
+			return []string{"hostname"}, readErr
+		},
+		IsFileExistsFn: func(string) bool {
+			return backend == netconf.Dhcpcd
+		},
+		ShowUnitFn: func(string) (map[string]string, error) {
+			if backend == netconf.NetworkManager {
+				return map[string]string{"ActiveState": "active"}, nil
+			}
+			return map[string]string{"ActiveState": "inactive"}, nil
+		},
+		ListFilesFn: func(string) []string {
+			return []string{keyfile}
+		},
+		ListWifiInterfacesFn: func(string) []string {
+			return []string{"wlan0", "wlan1"}
+		},
+		NMConnectionsFn: func() []string {
+			return []string{"home:7d1a4b1e-0000-4000-8000-000000000002:wlan0:" + keyfile}
+		},
+	}
+}
+
+func metrics(err error) *mock.Metrics {
+	return &mock.Metrics{
+		NetInfoFn: func() ([]net.InterfaceStat, error) {
+			return []net.InterfaceStat{{Name: "eth0"}}, err
+		},
+	}
+}
+
+func ncsys(plan *map[int](map[int]actions.Func), args *[]interface{}) *mocksys.NetConfig {
+	return &mocksys.NetConfig{
+		ListFn: func(backend string, dhcpcd []string, connections []string, keyfiles map[string][]string, interfaces []net.InterfaceStat) (rpi.NetworkConfig, error) {
+			*args = []interface{}{backend, dhcpcd, connections, keyfiles}
+			result := rpi.NetworkConfig{
+				Backend: backend,
+				Interfaces: []rpi.InterfaceConfig{
+					{Interface: "eth0"},
+					{Interface: "wlan1"},
+				},
+			}
+			if backend == netconf.NetworkManager {
+				result.Interfaces = append(result.Interfaces, rpi.InterfaceConfig{Interface: "wlan0", Connection: keyfile})
+			}
+			return result, nil
+		},
+		ExecuteNCFn: func(p map[int](map[int]actions.Func)) (rpi.Action, error) {
+			*plan = p
+			return rpi.Action{NumberOfSteps: uint16(len(p))}, nil
+		},
+	}
+}
+
+func TestList(t *testing.T) {
+	var plan map[int](map[int]actions.Func)
+	var args []interface{}
+
+	s := netconfig.New(ncsys(&plan, &args), actions.New(), infos(netconf.Dhcpcd, errors.New("test error")), metrics(nil))
+	_, err := s.List()
+	assert.Equal(t, echo.NewHTTPError(http.StatusInternalServerError, "could not read dhcpcd.conf"), err)
+
+	s = netconfig.New(ncsys(&plan, &args), actions.New(), infos("", nil), metrics(errors.New("test error")))
+	_, err = s.List()
+	assert.Equal(t, echo.NewHTTPError(http.StatusInternalServerError, "could not list the network interfaces"), err)
+
+	s = netconfig.New(ncsys(&plan, &args), actions.New(), infos("", nil), metrics(nil))
+	_, err = s.List()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"", []string{}, []string{}, map[string][]string{}}, args)
+
+	s = netconfig.New(ncsys(&plan, &args), actions.New(), infos(netconf.Dhcpcd, nil), metrics(nil))
+	result, err := s.List()
+	assert.Nil(t, err)
+	assert.Equal(t, netconf.Dhcpcd, result.Backend)
+	assert.Equal(t, []string{"hostname"}, args[1])
+
+	s = netconfig.New(ncsys(&plan, &args), actions.New(), infos(netconf.NetworkManager, nil), metrics(nil))
+	result, err = s.List()
+	assert.Nil(t, err)
+	assert.Equal(t, netconf.NetworkManager, result.Backend)
+	assert.Equal(t, 1, len(args[2].([]string)))
+	assert.Contains(t, args[3], keyfile)
+}
+
+func TestExecuteNC(t *testing.T) {
+	cases := []struct {
+		name          string
+		backend       string
+		iface         string
+		ic            rpi.InterfaceConfig
+		wantedErr     error
+		wantedArg     actions.ANC
+		wantedContent string
+	}{
+		{
+			name:      "error: static without address",
+			backend:   netconf.Dhcpcd,
+			iface:     "eth0",
+			ic:        rpi.InterfaceConfig{IsStatic: true},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid ipv4 address - a static interface needs at least one"),
+		},
+		{
+			name:      "error: invalid ipv4 address",
+			backend:   netconf.Dhcpcd,
+			iface:     "eth0",
+			ic:        rpi.InterfaceConfig{IsStatic: true, IPv4: []string{"192.168.1.10"}},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid ipv4 address - should be in CIDR notation (ex: 192.168.1.10/24)"),
+		},
+		{
+			name:      "error: invalid ipv6 address",
+			backend:   netconf.Dhcpcd,
+			iface:     "eth0",
+			ic:        rpi.InterfaceConfig{IsStatic: true, IPv4: []string{"192.168.1.10/24"}, IPv6: []string{"192.168.1.11/24"}},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid ipv6 address - should be in CIDR notation (ex: fd00::10/64)"),
+		},
+		{
+			name:      "error: invalid router",
+			backend:   netconf.Dhcpcd,
+			iface:     "eth0",
+			ic:        rpi.InterfaceConfig{IsStatic: true, IPv4: []string{"192.168.1.10/24"}, Router: "fd00::1"},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid router - should be an ipv4 address"),
+		},
+		{
+			name:      "error: invalid ipv6 router",
+			backend:   netconf.Dhcpcd,
+			iface:     "eth0",
+			ic:        rpi.InterfaceConfig{IsStatic: true, IPv4: []string{"192.168.1.10/24"}, Router6: "192.168.1.1"},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid ipv6 router - should be an ipv6 address"),
+		},
+		{
+			name:      "error: invalid dns server",
+			backend:   netconf.Dhcpcd,
+			iface:     "eth0",
+			ic:        rpi.InterfaceConfig{DNS: []string{"dns.google"}},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid dns server - should be an ip address"),
+		},
+		{
+			name:      "error: unknown interface",
+			backend:   netconf.Dhcpcd,
+			iface:     "eth1",
+			wantedErr: echo.NewHTTPError(http.StatusNotFound, "Not found - eth1 is not a network interface"),
+		},
+		{
+			name:      "error: unsupported system",
+			iface:     "eth0",
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an unsupported system - neither NetworkManager nor dhcpcd configure the network"),
+		},
+		{
+			name:      "error: ipv6 router with dhcpcd",
+			backend:   netconf.Dhcpcd,
+			iface:     "eth0",
+			ic:        rpi.InterfaceConfig{IsStatic: true, IPv4: []string{"192.168.1.10/24"}, Router6: "fd00::1"},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid ipv6 router - dhcpcd learns it from the router advertisements"),
+		},
+		{
+			name:      "error: wifi interface without connection",
+			backend:   netconf.NetworkManager,
+			iface:     "wlan1",
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an unsupported interface - wlan1 has no NetworkManager connection to edit"),
+		},
+		{
+			name:    "success: dhcpcd static",
+			backend: netconf.Dhcpcd,
+			iface:   "eth0",
+			ic:      rpi.InterfaceConfig{IsStatic: true, IPv4: []string{"192.168.1.10/24"}, Router: "192.168.1.1", DNS: []string{"192.168.1.1"}},
+			wantedArg: actions.ANC{
+				Interface: "eth0",
+				Command:   "ip addr flush dev eth0 scope global && dhcpcd -n eth0",
+				Rollback:  "ip addr flush dev eth0 scope global && dhcpcd -n eth0",
+				Path:      constants.DHCPCDCONF,
+				Backup:    constants.NETCONFIG + "/dhcpcd.conf",
+				Addresses: []string{"192.168.1.10/24"},
+				Timeout:   30 * time.Second,
+			},
+			wantedContent: "hostname\n\ninterface eth0\nstatic ip_address=192.168.1.10/24\nstatic routers=192.168.1.1\nstatic domain_name_servers=192.168.1.1\n",
+		},
+		{
+			name:    "success: networkmanager dhcp",
+			backend: netconf.NetworkManager,
+			iface:   "wlan0",
+			ic:      rpi.InterfaceConfig{IPv4: []string{"ignored"}},
+			wantedArg: actions.ANC{
+				Interface: "wlan0",
+				Command:   "nmcli connection reload && nmcli connection up uuid 7d1a4b1e-0000-4000-8000-000000000002",
+				Rollback:  "nmcli connection reload && nmcli connection up uuid 7d1a4b1e-0000-4000-8000-000000000002",
+				Path:      keyfile,
+				Backup:    constants.NETCONFIG + "/home.nmconnection",
+				Timeout:   30 * time.Second,
+			},
+			wantedContent: "[connection]\nid=home\nuuid=7d1a4b1e-0000-4000-8000-000000000002\ntype=wifi\n\n[ipv4]\nmethod=auto\n\n[ipv6]\nmethod=auto\n",
+		},
+		{
+			name:    "success: networkmanager new connection",
+			backend: netconf.NetworkManager,
+			iface:   "eth0",
+			ic:      rpi.InterfaceConfig{IsStatic: true, IPv4: []string{"192.168.1.10/24"}},
+			wantedArg: actions.ANC{
+				Interface: "eth0",
+				Rollback:  "nmcli connection reload && nmcli device connect eth0",
+				Path:      constants.NMCONNECTIONS + "/raspibuddy-eth0.nmconnection",
+				Backup:    constants.NETCONFIG + "/raspibuddy-eth0.nmconnection",
+				IsNew:     true,
+				Addresses: []string{"192.168.1.10/24"},
+				Timeout:   30 * time.Second,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var plan map[int](map[int]actions.Func)
+			var args []interface{}
+
+			s := netconfig.New(ncsys(&plan, &args), actions.New(), infos(tc.backend, nil), metrics(nil))
+			result, err := s.ExecuteNC(tc.iface, tc.ic)
+			assert.Equal(t, tc.wantedErr, err)
+			if tc.wantedErr != nil {
+				return
+			}
+
+			assert.Equal(t, uint16(2), result.NumberOfSteps)
+			assert.Equal(t, actions.RestoreFile, plan[1][1].Name)
+			assert.Equal(t, actions.ApplyNetworkConfig, plan[2][1].Name)
+
+			rf := plan[1][1].Argument[0].(actions.RF)
+			arg := plan[2][1].Argument[0].(actions.ANC)
+			assert.Equal(t, tc.wantedArg.Path, rf.Path)
+			assert.Equal(t, tc.wantedArg.Backup, rf.Backup)
+
+			if tc.wantedContent != "" {
+				assert.Equal(t, tc.wantedContent, string(rf.Content))
+				assert.Equal(t, tc.wantedArg, arg)
+			} else {
+				assert.True(t, strings.HasPrefix(arg.Command, "nmcli connection reload && nmcli connection up uuid "))
+				arg.Command = ""
+				assert.Equal(t, tc.wantedArg, arg)
+				assert.Contains(t, string(rf.Content), "interface-name=eth0\n")
+				assert.Contains(t, string(rf.Content), "address1=192.168.1.10/24\n")
+			}
+		})
+	}
+}
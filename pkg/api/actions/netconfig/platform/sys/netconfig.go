@@ -0,0 +1,140 @@
+package sys
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/netconf"
+	"github.com/shirou/gopsutil/net"
+)
+
+// NetConfig represents an empty NetConfig entity on the current system.
+type NetConfig struct{}
+
+// List returns the ip configuration of the network interfaces other than loopback, read from dhcpcd.conf
+// or from the keyfiles (path: lines) of the connections listed by 'nmcli -t connection show'
+func (n NetConfig) List(backend string, dhcpcd []string, connections []string, keyfiles map[string][]string, interfaces []net.InterfaceStat) (rpi.NetworkConfig, error) {
+	result := rpi.NetworkConfig{
+		Backend:    backend,
+		Interfaces: []rpi.InterfaceConfig{},
+	}
+
+	for _, iface := range interfaces {
+		if contains(iface.Flags, "loopback") {
+			continue
+		}
+
+		ic := rpi.InterfaceConfig{
+			Interface: iface.Name,
+			IsUp:      contains(iface.Flags, "up"),
+			Addresses: []string{},
+		}
+		for _, a := range iface.Addrs {
+			ic.Addresses = append(ic.Addresses, a.Addr)
+		}
+
+		s := netconf.Settings{IPv4: []string{}, IPv6: []string{}, DNS: []string{}}
+		switch backend {
+		case netconf.Dhcpcd:
+			s = netconf.DhcpcdSettings(dhcpcd, iface.Name)
+		case netconf.NetworkManager:
+			if path := keyfile(iface.Name, connections, keyfiles); path != "" {
+				ic.Connection = path
+				s = netconf.ParseKeyfile(keyfiles[path]).Settings
+			}
+		}
+
+		ic.IsStatic = s.IsStatic
+		ic.IPv4 = s.IPv4
+		ic.Router = s.Router
+		ic.IPv6 = s.IPv6
+		ic.Router6 = s.Router6
+		ic.DNS = s.DNS
+
+		result.Interfaces = append(result.Interfaces, ic)
+	}
+
+	return result, nil
+}
+
+// ExecuteNC returns an action response after configuring a network interface
+func (n NetConfig) ExecuteNC(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return execute(actions.NetworkConfig, plan)
+}
+
+func execute(name string, plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	actionStartTime := uint64(time.Now().Unix())
+	progressInit := actions.FlattenPlan(plan)
+	progress, exitStatus := actions.ExecutePlan(plan, progressInit)
+
+	return rpi.Action{
+		Name:          name,
+		NumberOfSteps: uint16(len(progressInit)),
+		Progress:      progress,
+		ExitStatus:    exitStatus,
+		StartTime:     actionStartTime,
+		EndTime:       uint64(time.Now().Unix()),
+	}, nil
+}
+
+// keyfile returns the keyfile of the connection active on an interface, or else of the first connection
+// bound to it, empty when the interface has no keyfile that can be edited (ex: generated in /run)
+func keyfile(iface string, connections []string, keyfiles map[string][]string) string {
+	// name / uuid / device / filename
+	for _, line := range connections {
+		fields := terse(line)
+		if len(fields) != 4 || fields[2] != iface {
+			continue
+		}
+		if _, isFound := keyfiles[fields[3]]; isFound {
+			return fields[3]
+		}
+	}
+
+	paths := []string{}
+	for path := range keyfiles {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		if netconf.ParseKeyfile(keyfiles[path]).Interface == iface {
+			return path
+		}
+	}
+
+	return ""
+}
+
+// terse returns the fields of a line of the nmcli terse output, where ':' and '\' are escaped
+func terse(line string) []string {
+	result := []string{}
+	var field strings.Builder
+
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line):
+			i++
+			field.WriteByte(line[i])
+		case line[i] == ':':
+			result = append(result, field.String())
+			field.Reset()
+		default:
+			field.WriteByte(line[i])
+		}
+	}
+
+	return append(result, field.String())
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package sys_test
+
+import (
+	"testing"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/netconfig/platform/sys"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/shirou/gopsutil/net"
+	"github.com/stretchr/testify/assert"
+)
+
+var interfaces = []net.InterfaceStat{
+	{Name: "lo", Flags: []string{"up", "loopback"}, Addrs: []net.InterfaceAddr{{Addr: "127.0.0.1/8"}}},
+	{Name: "eth0", Flags: []string{"up", "broadcast", "multicast"}, Addrs: []net.InterfaceAddr{{Addr: "192.168.1.10/24"}}},
+	{Name: "wlan0", Flags: []string{"broadcast", "multicast"}},
+}
+
+func TestList(t *testing.T) {
+	cases := []struct {
+		name         string
+		backend      string
+		dhcpcd       []string
+		connections  []string
+		keyfiles     map[string][]string
+		wantedResult rpi.NetworkConfig
+	}{
+		{
+			name: "no backend",
+			wantedResult: rpi.NetworkConfig{
+				Interfaces: []rpi.InterfaceConfig{
+					{Interface: "eth0", IsUp: true, Addresses: []string{"192.168.1.10/24"}, IPv4: []string{}, IPv6: []string{}, DNS: []string{}},
+					{Interface: "wlan0", Addresses: []string{}, IPv4: []string{}, IPv6: []string{}, DNS: []string{}},
+				},
+			},
+		},
+		{
+			name:    "dhcpcd",
+			backend: "dhcpcd",
+			dhcpcd: []string{
+				"interface eth0",
+				"static ip_address=192.168.1.10/24",
+				"static routers=192.168.1.1",
+				"static domain_name_servers=192.168.1.1",
+			},
+			wantedResult: rpi.NetworkConfig{
+				Backend: "dhcpcd",
+				Interfaces: []rpi.InterfaceConfig{
+					{
+						Interface: "eth0",
+						IsUp:      true,
+						Addresses: []string{"192.168.1.10/24"},
+						IsStatic:  true,
+						IPv4:      []string{"192.168.1.10/24"},
+						Router:    "192.168.1.1",
+						IPv6:      []string{},
+						DNS:       []string{"192.168.1.1"},
+					},
+					{Interface: "wlan0", Addresses: []string{}, IPv4: []string{}, IPv6: []string{}, DNS: []string{}},
+				},
+			},
+		},
+		{
+			name:    "networkmanager",
+			backend: "networkmanager",
+			connections: []string{
+				`Wired connection 1:7d1a4b1e-0000-4000-8000-000000000001:eth0:/run/NetworkManager/system-connections/Wired connection 1.nmconnection`,
+				`home\:5G:7d1a4b1e-0000-4000-8000-000000000002::/etc/NetworkManager/system-connections/home5G.nmconnection`,
+			},
+			keyfiles: map[string][]string{
+				"/etc/NetworkManager/system-connections/home5G.nmconnection": {
+					"[connection]",
+					"id=home:5G",
+					"type=wifi",
+					"interface-name=wlan0",
+					"[ipv4]",
+					"method=manual",
+					"address1=10.0.0.2/24,10.0.0.1",
+				},
+			},
+			wantedResult: rpi.NetworkConfig{
+				Backend: "networkmanager",
+				Interfaces: []rpi.InterfaceConfig{
+					{Interface: "eth0", IsUp: true, Addresses: []string{"192.168.1.10/24"}, IPv4: []string{}, IPv6: []string{}, DNS: []string{}},
+					{
+						Interface:  "wlan0",
+						Addresses:  []string{},
+						Connection: "/etc/NetworkManager/system-connections/home5G.nmconnection",
+						IsStatic:   true,
+						IPv4:       []string{"10.0.0.2/24"},
+						Router:     "10.0.0.1",
+						IPv6:       []string{},
+						DNS:        []string{},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := sys.NetConfig{}
+			result, err := s.List(tc.backend, tc.dhcpcd, tc.connections, tc.keyfiles, interfaces)
+			assert.Equal(t, tc.wantedResult, result)
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestExecuteNC(t *testing.T) {
+	s := sys.NetConfig{}
+	result, err := s.ExecuteNC(map[int](map[int]actions.Func){
+		1: {
+			1: {
+				Name:      "funcA",
+				Reference: func(arg interface{}) (rpi.Exec, error) { return rpi.Exec{ExitStatus: 1}, nil },
+				Argument:  []interface{}{actions.EBC{}},
+			},
+		},
+	})
+	assert.Equal(t, actions.NetworkConfig, result.Name)
+	assert.Equal(t, uint16(1), result.NumberOfSteps)
+	assert.Equal(t, uint8(1), result.ExitStatus)
+	assert.Nil(t, err)
+}
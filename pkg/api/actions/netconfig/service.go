@@ -0,0 +1,53 @@
+package netconfig
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/shirou/gopsutil/net"
+)
+
+// Service represents all NetConfig application services.
+type Service interface {
+	List() (rpi.NetworkConfig, error)
+	ExecuteNC(string, rpi.InterfaceConfig) (rpi.Action, error)
+}
+
+// NetConfig represents a NetConfig application service.
+type NetConfig struct {
+	ncsys NCSYS
+	a     Actions
+	i     Infos
+	m     Metrics
+}
+
+// NCSYS represents a NetConfig repository service.
+type NCSYS interface {
+	List(string, []string, []string, map[string][]string, []net.InterfaceStat) (rpi.NetworkConfig, error)
+	ExecuteNC(map[int](map[int]actions.Func)) (rpi.Action, error)
+}
+
+// Actions represents the actions interface
+type Actions interface {
+	RestoreFile(interface{}) (rpi.Exec, error)
+	ApplyNetworkConfig(interface{}) (rpi.Exec, error)
+}
+
+// Infos represents the infos interface
+type Infos interface {
+	ReadFile(string) ([]string, error)
+	IsFileExists(string) bool
+	ShowUnit(string) (map[string]string, error)
+	ListFiles(string) []string
+	ListWifiInterfaces(string) []string
+	NMConnections() []string
+}
+
+// Metrics represents the system metrics interface
+type Metrics interface {
+	NetInfo() ([]net.InterfaceStat, error)
+}
+
+// New creates a NetConfig application service instance.
+func New(ncsys NCSYS, a Actions, i Infos, m Metrics) *NetConfig {
+	return &NetConfig{ncsys: ncsys, a: a, i: i, m: m}
+}
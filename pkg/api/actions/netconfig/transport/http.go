@@ -0,0 +1,68 @@
+package transport
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/netconfig"
+)
+
+// HTTP is a struct implementing a core application service.
+type HTTP struct {
+	svc netconfig.Service
+}
+
+// NewHTTP creates new netconfig http service
+func NewHTTP(svc netconfig.Service, r *echo.Group) {
+	h := HTTP{svc}
+	cr := r.Group("/netconfig")
+	cr.GET("", h.list)
+	cr.POST("/:iface/static", h.static)
+	cr.POST("/:iface/dhcp", h.dhcp)
+}
+
+// list returns the values of a comma separated query parameter
+func list(ctx echo.Context, name string) []string {
+	result := []string{}
+	for _, v := range strings.Split(ctx.QueryParam(name), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func (h *HTTP) list(ctx echo.Context) error {
+	result, err := h.svc.List()
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) static(ctx echo.Context) error {
+	result, err := h.svc.ExecuteNC(ctx.Param("iface"), rpi.InterfaceConfig{
+		IsStatic: true,
+		IPv4:     list(ctx, "ipv4"),
+		Router:   ctx.QueryParam("router"),
+		IPv6:     list(ctx, "ipv6"),
+		Router6:  ctx.QueryParam("router6"),
+		DNS:      list(ctx, "dns"),
+	})
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) dhcp(ctx echo.Context) error {
+	result, err := h.svc.ExecuteNC(ctx.Param("iface"), rpi.InterfaceConfig{
+		DNS: list(ctx, "dns"),
+	})
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
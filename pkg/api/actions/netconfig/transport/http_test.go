@@ -0,0 +1,114 @@
+package transport_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/netconfig"
+	"github.com/raspibuddy/rpi/pkg/api/actions/netconfig/transport"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/raspibuddy/rpi/pkg/utl/server"
+	"github.com/shirou/gopsutil/net"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetConfig(t *testing.T) {
+	cases := []struct {
+		name         string
+		method       string
+		req          string
+		wantedStatus int
+	}{
+		{
+			name:         "success: list",
+			method:       http.MethodGet,
+			req:          "",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "error: unknown interface",
+			method:       http.MethodPost,
+			req:          "/eth1/dhcp",
+			wantedStatus: http.StatusNotFound,
+		},
+		{
+			name:         "error: invalid address",
+			method:       http.MethodPost,
+			req:          "/eth0/static?ipv4=192.168.1.10",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid dns server",
+			method:       http.MethodPost,
+			req:          "/eth0/dhcp?dns=1.1.1.1,dummy",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "success: static",
+			method:       http.MethodPost,
+			req:          "/eth0/static?ipv4=192.168.1.10/24,192.168.1.11/24&router=192.168.1.1&dns=1.1.1.1,8.8.8.8",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "success: dhcp",
+			method:       http.MethodPost,
+			req:          "/eth0/dhcp",
+			wantedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			i := mock.Infos{
+				ReadFileFn: func(string) ([]string, error) {
+					return []string{}, nil
+				},
+				IsFileExistsFn: func(string) bool {
+					return true
+				},
+				ShowUnitFn: func(string) (map[string]string, error) {
+					return map[string]string{"ActiveState": "inactive"}, nil
+				},
+			}
+			m := mock.Metrics{
+				NetInfoFn: func() ([]net.InterfaceStat, error) {
+					return []net.InterfaceStat{}, nil
+				},
+			}
+			ncsys := &mocksys.NetConfig{
+				ListFn: func(backend string, dhcpcd []string, connections []string, keyfiles map[string][]string, interfaces []net.InterfaceStat) (rpi.NetworkConfig, error) {
+					return rpi.NetworkConfig{Backend: backend, Interfaces: []rpi.InterfaceConfig{{Interface: "eth0"}}}, nil
+				},
+				ExecuteNCFn: func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+					return rpi.Action{NumberOfSteps: 2}, nil
+				},
+			}
+			s := netconfig.New(ncsys, actions.New(), i, m)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/netconfig" + tc.req
+
+			req, err := http.NewRequest(tc.method, path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package overclock
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/overclock"
+)
+
+// New creates a new Overclock logging service instance.
+func New(svc overclock.Service, logger rpi.Logger) *LogService {
+	return &LogService{
+		Service: svc,
+		logger:  logger,
+	}
+}
+
+// LogService represents an Overclock logging service.
+type LogService struct {
+	overclock.Service
+	logger rpi.Logger
+}
+
+const name = "overclock"
+
+// List is the logging function attached to the List overclock services and responsible for logging it out.
+func (ls *LogService) List(ctx echo.Context) (resp rpi.Overclock, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, "request: list overclock settings and profiles", err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.List()
+}
+
+// ExecuteOC is the logging function attached to the ExecuteOC overclock services and responsible for logging it out.
+func (ls *LogService) ExecuteOC(ctx echo.Context, profile string, custom map[string]string, force bool) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: apply overclock profile %v (force: %v)", profile, force), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteOC(profile, custom, force)
+}
+
+// ExecuteOCR is the logging function attached to the ExecuteOCR overclock services and responsible for logging it out.
+func (ls *LogService) ExecuteOCR(ctx echo.Context) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, "request: restore previous overclock settings", err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteOCR()
+}
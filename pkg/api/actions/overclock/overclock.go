@@ -0,0 +1,301 @@
+package overclock
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/bootconfig"
+	"github.com/raspibuddy/rpi/pkg/utl/constants"
+	"github.com/raspibuddy/rpi/pkg/utl/history"
+)
+
+const (
+	// TemperatureKey is the key of the cpu temperature history
+	TemperatureKey = "cpu"
+
+	// TemperatureInterval is the period between two temperature samples
+	TemperatureInterval = time.Minute
+
+	// TemperatureSamples is the number of temperature samples kept (24 hours)
+	TemperatureSamples = 1440
+
+	// GuardDelay is the time (in seconds) the board has to run healthy after boot before the profile is confirmed
+	GuardDelay = 120
+
+	// GuardTemperature is the temperature (°C) above which the guard restores the previous values
+	GuardTemperature = 80
+)
+
+var temperatureRegex = regexp.MustCompile(`[0-9]+(\.[0-9]+)?`)
+
+// List populates and returns the Overclock model.
+func (oc *Overclock) List() (rpi.Overclock, error) {
+	result, _, err := oc.status()
+	return result, err
+}
+
+// status returns the Overclock model and the board it was computed for
+func (oc *Overclock) status() (rpi.Overclock, rpi.Board, error) {
+	board, err := oc.b.List()
+	if err != nil {
+		return rpi.Overclock{}, rpi.Board{}, echo.NewHTTPError(http.StatusInternalServerError, "could not read the board")
+	}
+
+	config, err := oc.i.ReadFile(oc.i.GetConfigFiles()["bootconfig"].Path)
+	if err != nil {
+		return rpi.Overclock{}, rpi.Board{}, echo.NewHTTPError(http.StatusInternalServerError, "could not read the boot config")
+	}
+
+	// the state files do not exist until a profile is applied
+	state, _ := oc.i.ReadFile(filepath.Join(constants.OVERCLOCK, actions.OverclockStateFile))
+	markers := map[string]string{}
+	for _, f := range []string{actions.OverclockPendingFile, actions.OverclockSuccessFile, actions.OverclockRestoredFile} {
+		if content, err := oc.i.ReadFile(filepath.Join(constants.OVERCLOCK, f)); err == nil {
+			markers[f] = strings.Join(content, "\n")
+		}
+	}
+
+	throttled, _, _ := oc.m.Throttled()
+
+	result, err := oc.ocsys.List(
+		board,
+		config,
+		strings.Join(state, "\n"),
+		markers,
+		oc.i.CoolingDevices(constants.THERMAL),
+		oc.h.Samples()[TemperatureKey],
+		throttled,
+	)
+
+	return result, board, err
+}
+
+// ExecuteOC applies an overclock profile to the section of the board model in /boot/config.txt and returns an action.
+// The previous values and a snapshot of the boot config are saved first, then a guard unit restores the previous values at boot
+// unless the board boots healthy with the profile, config.txt being edited once the guard is enabled. The saved state is undone
+// when a later step fails. Failed blocking pre-checks prevent the profile unless forced.
+func (oc *Overclock) ExecuteOC(profile string, custom map[string]string, force bool) (rpi.Action, error) {
+	status, board, err := oc.status()
+	if err != nil {
+		return rpi.Action{}, err
+	}
+
+	if status.State.IsPending {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to a pending profile - reboot to confirm it or restore the previous values first")
+	}
+
+	settings, err := oc.ocsys.Settings(status, board, profile, custom)
+	if err != nil {
+		return rpi.Action{}, err
+	}
+
+	if !force {
+		failed := []string{}
+		for _, p := range status.Profiles {
+			for _, check := range p.Checks {
+				if p.Name == profile && check.IsBlocking && !check.IsPassed {
+					failed = append(failed, check.Name)
+				}
+			}
+		}
+
+		if len(failed) > 0 {
+			return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to failed pre-checks - %v, use force to bypass them", strings.Join(failed, ", ")))
+		}
+	}
+
+	previous := []rpi.OverclockSetting{}
+	for _, s := range settings {
+		p := rpi.OverclockSetting{Section: s.Section, Key: s.Key}
+		for _, c := range status.Current {
+			if c.Section == s.Section && c.Key == s.Key {
+				p = c
+			}
+		}
+		previous = append(previous, p)
+	}
+
+	state, err := json.Marshal(rpi.OverclockState{
+		Profile:   profile,
+		Section:   status.Section,
+		Previous:  previous,
+		Applied:   settings,
+		AppliedAt: time.Now().Unix(),
+	})
+	if err != nil {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusInternalServerError, "could not encode the overclock state")
+	}
+
+	path := oc.i.GetConfigFiles()["bootconfig"].Path
+
+	plan := map[int](map[int]actions.Func){
+		1: {
+			1: {
+				Name:      actions.SaveOverclockState,
+				Reference: oc.a.SaveOverclockState,
+				Argument: []interface{}{
+					actions.OCS{
+						Dir:        constants.OVERCLOCK,
+						BootConfig: path,
+						State:      state,
+					},
+				},
+			},
+		},
+	}
+
+	plan[len(plan)+1] = map[int]actions.Func{
+		1: {
+			Name:      actions.PersistOverclockGuard,
+			Reference: oc.a.PersistOverclockGuard,
+			Argument: []interface{}{
+				actions.OCG{
+					Dir:            constants.OVERCLOCK,
+					BootConfig:     path,
+					Path:           constants.OVERCLOCKGUARDSERVICE,
+					Delay:          GuardDelay,
+					MaxTemperature: GuardTemperature,
+					Previous:       previous,
+				},
+			},
+		},
+	}
+
+	plan[len(plan)+1] = map[int]actions.Func{
+		1: {
+			Name:      actions.ManageUnit,
+			Reference: oc.a.ManageUnit,
+			Argument: []interface{}{
+				actions.MU{
+					Action: "enable",
+					Unit:   filepath.Base(constants.OVERCLOCKGUARDSERVICE),
+				},
+			},
+		},
+	}
+
+	// config.txt is only edited once the guard is enabled, each edit rewriting it hence one step per key
+	for _, s := range settings {
+		plan[len(plan)+1] = map[int]actions.Func{1: oc.bootConfig(path, s)}
+	}
+
+	action, err := oc.ocsys.ExecuteOC(plan)
+	if err != nil {
+		return action, err
+	}
+
+	// a failure once the state is saved would leave the profile pending, hence blocking a new one
+	if action.ExitStatus != 0 && action.Progress["1"+actions.Separator+"1"].ExitStatus == 0 {
+		oc.ocsys.ExecuteOCR(oc.restore(path, previous))
+	}
+
+	return action, nil
+}
+
+// ExecuteOCR restores the values replaced by the last overclock profile, disables the guard unit and returns an action.
+func (oc *Overclock) ExecuteOCR() (rpi.Action, error) {
+	status, _, err := oc.status()
+	if err != nil {
+		return rpi.Action{}, err
+	}
+
+	if status.State.Profile == "" {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusNotFound, "Not found - no overclock profile has been applied")
+	}
+
+	path := oc.i.GetConfigFiles()["bootconfig"].Path
+
+	return oc.ocsys.ExecuteOCR(oc.restore(path, status.State.Previous))
+}
+
+// restore returns the plan putting the previous values back in config.txt, ending the pending profile and disabling the guard unit
+func (oc *Overclock) restore(path string, previous []rpi.OverclockSetting) map[int](map[int]actions.Func) {
+	plan := map[int](map[int]actions.Func){}
+	for _, s := range previous {
+		plan[len(plan)+1] = map[int]actions.Func{1: oc.bootConfig(path, s)}
+	}
+
+	plan[len(plan)+1] = map[int]actions.Func{
+		1: {
+			Name:      actions.ClearOverclockState,
+			Reference: oc.a.ClearOverclockState,
+			Argument: []interface{}{
+				actions.FileOrDirectory{
+					Path: constants.OVERCLOCK,
+				},
+			},
+		},
+	}
+
+	plan[len(plan)+1] = map[int]actions.Func{
+		1: {
+			Name:      actions.ManageUnit,
+			Reference: oc.a.ManageUnit,
+			Argument: []interface{}{
+				actions.MU{
+					Action: "disable",
+					Unit:   filepath.Base(constants.OVERCLOCKGUARDSERVICE),
+				},
+			},
+		},
+	}
+
+	return plan
+}
+
+// bootConfig returns the step setting or removing an overclock key of config.txt
+func (oc *Overclock) bootConfig(path string, s rpi.OverclockSetting) actions.Func {
+	operation := bootconfig.Unset
+	if s.IsSet {
+		operation = bootconfig.Set
+	}
+
+	return actions.Func{
+		Name:      actions.EditBootConfig,
+		Reference: oc.a.EditBootConfig,
+		Argument: []interface{}{
+			actions.BCO{
+				Path:      path,
+				Operation: operation,
+				Section:   s.Section,
+				Key:       s.Key,
+				Value:     s.Value,
+			},
+		},
+	}
+}
+
+// Collect returns a function sampling the cpu temperature (in m°C),
+// meant to feed the temperature history used by the pre-checks.
+func Collect(m Metrics) func() map[string]history.Sample {
+	return func() map[string]history.Sample {
+		result := make(map[string]history.Sample)
+
+		out, _, err := m.Temperature()
+		if err != nil {
+			return result
+		}
+
+		t, err := strconv.ParseFloat(temperatureRegex.FindString(out), 64)
+		if err != nil || t <= 0 {
+			return result
+		}
+
+		result[TemperatureKey] = history.Sample{
+			Time:  time.Now().Unix(),
+			Value: uint64(math.Round(t * 1000)),
+		}
+
+		return result
+	}
+}
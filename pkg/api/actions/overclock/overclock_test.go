@@ -0,0 +1,300 @@
+package overclock_test
+
+import (
+	"errors"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/overclock"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/constants"
+	"github.com/raspibuddy/rpi/pkg/utl/history"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/stretchr/testify/assert"
+)
+
+var status = rpi.Overclock{
+	Board:       "4B",
+	Section:     "pi4",
+	IsSupported: true,
+	Current: []rpi.OverclockSetting{
+		{Section: "all", Key: "arm_freq", Value: "1600", IsSet: true},
+	},
+	Profiles: []rpi.OverclockProfile{
+		{Name: "modest", IsAllowed: true, Checks: []rpi.OverclockCheck{{Name: "cooling", IsBlocking: false}}},
+		{Name: "high", Checks: []rpi.OverclockCheck{{Name: "cooling", IsBlocking: true}, {Name: "power", IsPassed: true}}},
+	},
+}
+
+var settings = []rpi.OverclockSetting{
+	{Section: "all", Key: "arm_freq"},
+	{Section: "pi4", Key: "arm_freq", Value: "1750", IsSet: true},
+}
+
+func infos(readErr error, markers map[string][]string) *mock.Infos {
+	return &mock.Infos{
+		GetConfigFilesFn: func() map[string]rpi.ConfigFileDetails {
+			return map[string]rpi.ConfigFileDetails{"bootconfig": {Path: "/boot/config.txt"}}
+		},
+		ReadFileFn: func(path string) ([]string, error) {
+			if path == "/boot/config.txt" {
+				return []string{}, readErr
+			}
+			if content, ok := markers[filepath.Base(path)]; ok {
+				return content, nil
+			}
+			return nil, errors.New("file does not exist")
+		},
+		CoolingDevicesFn: func(string) []string {
+			return []string{}
+		},
+	}
+}
+
+func metrics() *mock.Metrics {
+	return &mock.Metrics{
+		TemperatureFn: func() (string, string, error) {
+			return "temp=48.3'C\n", "", nil
+		},
+		ThrottledFn: func() (string, string, error) {
+			return "throttled=0x0", "", nil
+		},
+	}
+}
+
+func board(err error) *mock.Board {
+	return &mock.Board{
+		ListFn: func() (rpi.Board, error) {
+			return rpi.Board{Type: "4B"}, err
+		},
+	}
+}
+
+func ocsys(plan *map[int](map[int]actions.Func)) *mocksys.Overclock {
+	execute := func(p map[int](map[int]actions.Func)) (rpi.Action, error) {
+		*plan = p
+		return rpi.Action{NumberOfSteps: uint16(len(p))}, nil
+	}
+
+	return &mocksys.Overclock{
+		ListFn: func(b rpi.Board, config []string, state string, markers map[string]string, cooling []string, temperatures []history.Sample, throttled string) (rpi.Overclock, error) {
+			result := status
+			result.State = rpi.OverclockState{IsPending: markers[actions.OverclockPendingFile] != ""}
+			if state != "" {
+				result.State.Profile = "modest"
+				result.State.Previous = []rpi.OverclockSetting{{Section: "all", Key: "arm_freq", Value: "1600", IsSet: true}}
+			}
+			return result, nil
+		},
+		SettingsFn: func(oc rpi.Overclock, b rpi.Board, profile string, custom map[string]string) ([]rpi.OverclockSetting, error) {
+			return settings, nil
+		},
+		ExecuteOCFn:  execute,
+		ExecuteOCRFn: execute,
+	}
+}
+
+func TestList(t *testing.T) {
+	var plan map[int](map[int]actions.Func)
+	h := history.New(10, "")
+
+	s := overclock.New(ocsys(&plan), nil, infos(nil, nil), metrics(), board(errors.New("test error")), h)
+	_, err := s.List()
+	assert.Equal(t, echo.NewHTTPError(http.StatusInternalServerError, "could not read the board"), err)
+
+	s = overclock.New(ocsys(&plan), nil, infos(errors.New("test error"), nil), metrics(), board(nil), h)
+	_, err = s.List()
+	assert.Equal(t, echo.NewHTTPError(http.StatusInternalServerError, "could not read the boot config"), err)
+
+	s = overclock.New(ocsys(&plan), nil, infos(nil, nil), metrics(), board(nil), h)
+	result, err := s.List()
+	assert.Nil(t, err)
+	assert.Equal(t, "4B", result.Board)
+}
+
+func TestExecuteOC(t *testing.T) {
+	cases := []struct {
+		name        string
+		profile     string
+		force       bool
+		markers     map[string][]string
+		wantedSteps []string
+		wantedErr   error
+	}{
+		{
+			name:      "error: pending profile",
+			profile:   "modest",
+			markers:   map[string][]string{actions.OverclockPendingFile: {"1600000000"}},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to a pending profile - reboot to confirm it or restore the previous values first"),
+		},
+		{
+			name:      "error: failed blocking pre-check",
+			profile:   "high",
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to failed pre-checks - cooling, use force to bypass them"),
+		},
+		{
+			name:    "success: forced",
+			profile: "high",
+			force:   true,
+			wantedSteps: []string{
+				actions.SaveOverclockState,
+				actions.PersistOverclockGuard,
+				actions.ManageUnit,
+				actions.EditBootConfig,
+				actions.EditBootConfig,
+			},
+		},
+		{
+			name:    "success: non blocking pre-check",
+			profile: "modest",
+			wantedSteps: []string{
+				actions.SaveOverclockState,
+				actions.PersistOverclockGuard,
+				actions.ManageUnit,
+				actions.EditBootConfig,
+				actions.EditBootConfig,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var plan map[int](map[int]actions.Func)
+			s := overclock.New(ocsys(&plan), actions.New(), infos(nil, tc.markers), metrics(), board(nil), history.New(10, ""))
+			_, err := s.ExecuteOC(tc.profile, map[string]string{}, tc.force)
+			assert.Equal(t, tc.wantedErr, err)
+
+			if tc.wantedErr == nil {
+				steps := []string{}
+				for k := 1; k <= len(plan); k++ {
+					steps = append(steps, plan[k][1].Name)
+				}
+				assert.Equal(t, tc.wantedSteps, steps)
+				assert.Equal(t, constants.OVERCLOCK, plan[1][1].Argument[0].(actions.OCS).Dir)
+				assert.Equal(t, actions.BCO{
+					Path:      "/boot/config.txt",
+					Operation: "unset",
+					Section:   "all",
+					Key:       "arm_freq",
+				}, plan[4][1].Argument[0].(actions.BCO))
+				assert.Equal(t, actions.BCO{
+					Path:      "/boot/config.txt",
+					Operation: "set",
+					Section:   "pi4",
+					Key:       "arm_freq",
+					Value:     "1750",
+				}, plan[5][1].Argument[0].(actions.BCO))
+				assert.Equal(t, []rpi.OverclockSetting{
+					{Section: "all", Key: "arm_freq", Value: "1600", IsSet: true},
+					{Section: "pi4", Key: "arm_freq"},
+				}, plan[2][1].Argument[0].(actions.OCG).Previous)
+				assert.Equal(t, actions.MU{Action: "enable", Unit: "raspibuddy-overclock-guard.service"}, plan[3][1].Argument[0].(actions.MU))
+			}
+		})
+	}
+}
+
+func TestExecuteOCUndo(t *testing.T) {
+	cases := []struct {
+		name        string
+		progress    map[string]rpi.Exec
+		wantedSteps []string
+	}{
+		{
+			name: "failed save",
+			progress: map[string]rpi.Exec{
+				"1<|>1": {ExitStatus: 1},
+			},
+			wantedSteps: []string{},
+		},
+		{
+			name: "failed edit",
+			progress: map[string]rpi.Exec{
+				"1<|>1": {ExitStatus: 0},
+				"2<|>1": {ExitStatus: 0},
+				"3<|>1": {ExitStatus: 0},
+				"4<|>1": {ExitStatus: 1},
+			},
+			wantedSteps: []string{
+				actions.EditBootConfig,
+				actions.EditBootConfig,
+				actions.ClearOverclockState,
+				actions.ManageUnit,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var plan, undo map[int](map[int]actions.Func)
+			o := ocsys(&plan)
+			o.ExecuteOCFn = func(p map[int](map[int]actions.Func)) (rpi.Action, error) {
+				plan = p
+				return rpi.Action{NumberOfSteps: uint16(len(p)), Progress: tc.progress, ExitStatus: 1}, nil
+			}
+			o.ExecuteOCRFn = func(p map[int](map[int]actions.Func)) (rpi.Action, error) {
+				undo = p
+				return rpi.Action{}, nil
+			}
+
+			s := overclock.New(o, actions.New(), infos(nil, nil), metrics(), board(nil), history.New(10, ""))
+			result, err := s.ExecuteOC("modest", map[string]string{}, false)
+			assert.Nil(t, err)
+			assert.Equal(t, uint8(1), result.ExitStatus)
+
+			steps := []string{}
+			for k := 1; k <= len(undo); k++ {
+				steps = append(steps, undo[k][1].Name)
+			}
+			assert.Equal(t, tc.wantedSteps, steps)
+
+			if len(undo) > 0 {
+				assert.Equal(t, actions.BCO{
+					Path:      "/boot/config.txt",
+					Operation: "set",
+					Section:   "all",
+					Key:       "arm_freq",
+					Value:     "1600",
+				}, undo[1][1].Argument[0].(actions.BCO))
+				assert.Equal(t, actions.MU{Action: "disable", Unit: "raspibuddy-overclock-guard.service"}, undo[4][1].Argument[0].(actions.MU))
+			}
+		})
+	}
+}
+
+func TestExecuteOCR(t *testing.T) {
+	var plan map[int](map[int]actions.Func)
+
+	s := overclock.New(ocsys(&plan), actions.New(), infos(nil, nil), metrics(), board(nil), history.New(10, ""))
+	_, err := s.ExecuteOCR()
+	assert.Equal(t, echo.NewHTTPError(http.StatusNotFound, "Not found - no overclock profile has been applied"), err)
+
+	s = overclock.New(ocsys(&plan), actions.New(), infos(nil, map[string][]string{actions.OverclockStateFile: {"{}"}}), metrics(), board(nil), history.New(10, ""))
+	result, err := s.ExecuteOCR()
+	assert.Nil(t, err)
+	assert.Equal(t, uint16(3), result.NumberOfSteps)
+	assert.Equal(t, actions.BCO{
+		Path:      "/boot/config.txt",
+		Operation: "set",
+		Section:   "all",
+		Key:       "arm_freq",
+		Value:     "1600",
+	}, plan[1][1].Argument[0].(actions.BCO))
+	assert.Equal(t, actions.ClearOverclockState, plan[2][1].Name)
+	assert.Equal(t, actions.MU{Action: "disable", Unit: "raspibuddy-overclock-guard.service"}, plan[3][1].Argument[0].(actions.MU))
+}
+
+func TestCollect(t *testing.T) {
+	samples := overclock.Collect(metrics())()
+	assert.Equal(t, uint64(48300), samples[overclock.TemperatureKey].Value)
+
+	m := metrics()
+	m.TemperatureFn = func() (string, string, error) {
+		return "", "vcgencmd: not found", nil
+	}
+	assert.Equal(t, 0, len(overclock.Collect(m)()))
+}
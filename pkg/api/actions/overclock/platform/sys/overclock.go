@@ -0,0 +1,381 @@
+package sys
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/bootconfig"
+	"github.com/raspibuddy/rpi/pkg/utl/history"
+)
+
+// get_throttled bits, the lower ones being the current state and the upper ones what occurred since boot
+const (
+	underVoltage       = 0x1
+	throttled          = 0x4
+	softTempLimit      = 0x8
+	underVoltageOccurs = 0x10000
+	throttledOccurs    = 0x40000
+	softTempOccurs     = 0x80000
+)
+
+const (
+	// ModestTemperature is the max temperature (°C) recorded allowing the modest profile
+	ModestTemperature = 75
+
+	// HighTemperature is the max temperature (°C) recorded allowing the high and custom profiles
+	HighTemperature = 70
+)
+
+// Model represents the overclock limits and profiles of a board model.
+// Its values are written in its own config.txt filter section.
+type Model struct {
+	Section string
+	Limits  map[string]int
+	Modest  map[string]string
+	High    map[string]string
+}
+
+var (
+	pi4 = Model{
+		Section: "pi4",
+		Limits:  map[string]int{"arm_freq": 2147, "over_voltage": 8, "gpu_freq": 750, "force_turbo": 1},
+		Modest:  map[string]string{"arm_freq": "1750", "over_voltage": "2"},
+		High:    map[string]string{"arm_freq": "2000", "over_voltage": "6", "gpu_freq": "750"},
+	}
+
+	pi3plus = Model{
+		Section: "pi3+",
+		Limits:  map[string]int{"arm_freq": 1570, "over_voltage": 6, "gpu_freq": 600, "force_turbo": 1},
+		Modest:  map[string]string{"arm_freq": "1450", "over_voltage": "2"},
+		High:    map[string]string{"arm_freq": "1500", "over_voltage": "4", "gpu_freq": "500"},
+	}
+
+	// Models lists the board types, as decoded from the revision code, supporting overclock profiles
+	Models = map[string]Model{
+		"4B": pi4,
+		"CM4": {
+			Section: "cm4",
+			Limits:  pi4.Limits,
+			Modest:  pi4.Modest,
+			High:    pi4.High,
+		},
+		"400": {
+			Section: "pi400",
+			Limits:  map[string]int{"arm_freq": 2300, "over_voltage": 8, "gpu_freq": 800, "force_turbo": 1},
+			Modest:  map[string]string{"arm_freq": "2000", "over_voltage": "6"},
+			High:    map[string]string{"arm_freq": "2147", "over_voltage": "8", "gpu_freq": "750"},
+		},
+		"3B+": pi3plus,
+		"3A+": pi3plus,
+		"3B": {
+			Section: "pi3",
+			Limits:  map[string]int{"arm_freq": 1450, "over_voltage": 6, "gpu_freq": 600, "force_turbo": 1},
+			Modest:  map[string]string{"arm_freq": "1300", "over_voltage": "2"},
+			High:    map[string]string{"arm_freq": "1350", "over_voltage": "4", "gpu_freq": "500"},
+		},
+		"Zero 2 W": {
+			Section: "pi02",
+			Limits:  map[string]int{"arm_freq": 1300, "over_voltage": 6, "gpu_freq": 600, "force_turbo": 1},
+			Modest:  map[string]string{"arm_freq": "1100", "over_voltage": "2"},
+			High:    map[string]string{"arm_freq": "1200", "over_voltage": "4", "gpu_freq": "500"},
+		},
+	}
+
+	// coolingOverlays lists the overlays driving a fan
+	coolingOverlays = []string{"gpio-fan", "pwm-fan", "rpi-poe", "rpi-poe-plus"}
+)
+
+// Overclock represents an empty Overclock entity on the current system.
+type Overclock struct{}
+
+// List returns the overclock values set in config.txt for the board, the profiles of its model with their
+// pre-checks and the state of the last profile applied
+func (o Overclock) List(
+	board rpi.Board,
+	config []string,
+	state string,
+	markers map[string]string,
+	cooling []string,
+	temperatures []history.Sample,
+	throttledState string,
+) (rpi.Overclock, error) {
+	model, isSupported := Models[board.Type]
+
+	result := rpi.Overclock{
+		Board:       board.Type,
+		Section:     model.Section,
+		IsSupported: isSupported,
+		Current:     []rpi.OverclockSetting{},
+		Profiles:    []rpi.OverclockProfile{},
+		Limits:      model.Limits,
+		Temperature: Temperature(temperatures),
+		Throttled:   strings.TrimPrefix(strings.TrimSpace(throttledState), "throttled="),
+		State:       State(state, markers),
+	}
+
+	c := bootconfig.Parse(config)
+	sections := []string{bootconfig.DefaultSection}
+	if isSupported {
+		sections = append(sections, model.Section)
+	}
+	for _, section := range sections {
+		for _, key := range actions.OverclockKeys {
+			if value, isFound := c.Get(section, key); isFound {
+				result.Current = append(result.Current, rpi.OverclockSetting{Section: section, Key: key, Value: value, IsSet: true})
+			}
+		}
+	}
+
+	if !isSupported {
+		return result, nil
+	}
+
+	fans := Cooling(c, cooling)
+	flags, err := strconv.ParseUint(result.Throttled, 0, 64)
+	isThrottledKnown := err == nil
+
+	for _, name := range actions.OverclockProfiles {
+		profile := rpi.OverclockProfile{Name: name, Settings: map[string]string{}, Checks: []rpi.OverclockCheck{}}
+		switch name {
+		case "modest":
+			profile.Settings = model.Modest
+		case "high":
+			profile.Settings = model.High
+		}
+
+		if name != "none" {
+			isHigh := name != "modest"
+			limit := ModestTemperature
+			if isHigh {
+				limit = HighTemperature
+			}
+
+			profile.Checks = []rpi.OverclockCheck{
+				powerCheck(flags, isThrottledKnown),
+				throttlingCheck(flags, isThrottledKnown, isHigh),
+				coolingCheck(fans, isHigh),
+				temperatureCheck(result.Temperature, limit),
+			}
+		}
+
+		profile.IsAllowed = true
+		for _, check := range profile.Checks {
+			profile.IsAllowed = profile.IsAllowed && (check.IsPassed || !check.IsBlocking)
+		}
+
+		result.Profiles = append(result.Profiles, profile)
+	}
+
+	return result, nil
+}
+
+// Settings returns the overclock keys to write in config.txt for a profile.
+// The keys are set or removed in the section of the board model
+// and removed from the [all] section so that they do not override the profile.
+func (o Overclock) Settings(oc rpi.Overclock, board rpi.Board, profile string, custom map[string]string) ([]rpi.OverclockSetting, error) {
+	model, isSupported := Models[board.Type]
+	if !isSupported {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to an unsupported board - no overclock profile for %v", board.Type))
+	}
+
+	values := map[string]string{}
+	switch profile {
+	case "modest":
+		values = model.Modest
+	case "high":
+		values = model.High
+	case "custom":
+		values = custom
+	}
+
+	numbers := map[string]int{}
+	for key, value := range values {
+		max, isKnown := model.Limits[key]
+		if !isKnown {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to an invalid key - %v is not an overclock setting", key))
+		}
+
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 || n > max {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to an invalid value - %v should be between 0 and %v on a %v", key, max, board.Type))
+		}
+		numbers[key] = n
+	}
+
+	if numbers["over_voltage"] > 0 && board.IsOvervoltageDisallowed {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid value - over_voltage is disallowed on this board")
+	}
+
+	if numbers["over_voltage"] > 0 && numbers["force_turbo"] == 1 {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid value - force_turbo with over_voltage would permanently void the warranty")
+	}
+
+	result := []rpi.OverclockSetting{}
+	for _, s := range oc.Current {
+		if s.Section == bootconfig.DefaultSection {
+			result = append(result, rpi.OverclockSetting{Section: s.Section, Key: s.Key})
+		}
+	}
+
+	for _, key := range actions.OverclockKeys {
+		value, isSet := values[key]
+		result = append(result, rpi.OverclockSetting{Section: model.Section, Key: key, Value: value, IsSet: isSet})
+	}
+
+	return result, nil
+}
+
+// ExecuteOC returns an action response after applying an overclock profile
+func (o Overclock) ExecuteOC(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	actionStartTime := uint64(time.Now().Unix())
+	progressInit := actions.FlattenPlan(plan)
+	progress, exitStatus := actions.ExecutePlan(plan, progressInit)
+
+	return rpi.Action{
+		Name:          actions.Overclock,
+		NumberOfSteps: uint16(len(progressInit)),
+		Progress:      progress,
+		ExitStatus:    exitStatus,
+		StartTime:     actionStartTime,
+		EndTime:       uint64(time.Now().Unix()),
+	}, nil
+}
+
+// ExecuteOCR returns an action response after restoring the values replaced by the last overclock profile
+func (o Overclock) ExecuteOCR(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	actionStartTime := uint64(time.Now().Unix())
+	progressInit := actions.FlattenPlan(plan)
+	progress, exitStatus := actions.ExecutePlan(plan, progressInit)
+
+	return rpi.Action{
+		Name:          actions.RestoreOverclock,
+		NumberOfSteps: uint16(len(progressInit)),
+		Progress:      progress,
+		ExitStatus:    exitStatus,
+		StartTime:     actionStartTime,
+		EndTime:       uint64(time.Now().Unix()),
+	}, nil
+}
+
+// Temperature summarizes a temperature history, the samples being recorded in m°C
+func Temperature(samples []history.Sample) rpi.OverclockHistory {
+	result := rpi.OverclockHistory{Samples: len(samples)}
+	for k, s := range samples {
+		t := float64(s.Value) / 1000
+		if k == 0 {
+			result.Since = s.Time
+		}
+		if t > result.Max {
+			result.Max = t
+		}
+		result.Current = t
+	}
+	return result
+}
+
+// State returns the state of the last profile applied from its json file and the markers found next to it
+func State(state string, markers map[string]string) rpi.OverclockState {
+	result := rpi.OverclockState{}
+	if state != "" {
+		_ = json.Unmarshal([]byte(state), &result)
+	}
+
+	if _, ok := markers[actions.OverclockPendingFile]; ok {
+		result.IsPending = true
+	}
+
+	if success, ok := markers[actions.OverclockSuccessFile]; ok {
+		result.IsBootSuccessful = true
+		result.SuccessAt, _ = strconv.ParseInt(strings.TrimSpace(success), 10, 64)
+	}
+
+	if reason, ok := markers[actions.OverclockRestoredFile]; ok {
+		result.IsRestored = true
+		result.RestoredReason = strings.TrimSpace(reason)
+	}
+
+	return result
+}
+
+// Cooling returns the active cooling found, either a fan cooling device or a fan overlay set in config.txt
+func Cooling(c bootconfig.Config, devices []string) []string {
+	result := []string{}
+	for _, d := range devices {
+		if strings.Contains(d, "fan") {
+			result = append(result, d)
+		}
+	}
+
+	for _, l := range c.Lines {
+		if l.Kind != bootconfig.Setting || l.Commented {
+			continue
+		}
+		for _, ov := range coolingOverlays {
+			if l.Key == "dtoverlay="+ov {
+				result = append(result, l.Key)
+			}
+		}
+	}
+
+	return result
+}
+
+func powerCheck(flags uint64, isKnown bool) rpi.OverclockCheck {
+	check := rpi.OverclockCheck{Name: "power", IsBlocking: true}
+	switch {
+	case !isKnown:
+		check.Message = "the throttling state could not be read"
+	case flags&(underVoltage|underVoltageOccurs) != 0:
+		check.Message = "under-voltage detected since boot, check the power supply"
+	default:
+		check.IsPassed = true
+		check.Message = "no under-voltage detected since boot"
+	}
+	return check
+}
+
+func throttlingCheck(flags uint64, isKnown bool, isBlocking bool) rpi.OverclockCheck {
+	check := rpi.OverclockCheck{Name: "throttling", IsBlocking: isBlocking}
+	switch {
+	case !isKnown:
+		check.Message = "the throttling state could not be read"
+	case flags&(throttled|softTempLimit|throttledOccurs|softTempOccurs) != 0:
+		check.Message = "the board has been throttled or reached the soft temperature limit since boot"
+	default:
+		check.IsPassed = true
+		check.Message = "no throttling detected since boot"
+	}
+	return check
+}
+
+func coolingCheck(fans []string, isBlocking bool) rpi.OverclockCheck {
+	check := rpi.OverclockCheck{Name: "cooling", IsBlocking: isBlocking}
+	if len(fans) == 0 {
+		check.Message = "no active cooling found (fan or PoE HAT)"
+	} else {
+		check.IsPassed = true
+		check.Message = "active cooling found: " + strings.Join(fans, ", ")
+	}
+	return check
+}
+
+func temperatureCheck(t rpi.OverclockHistory, limit int) rpi.OverclockCheck {
+	check := rpi.OverclockCheck{Name: "temperature", IsBlocking: true}
+	switch {
+	case t.Samples == 0:
+		check.Message = "no temperature recorded yet"
+	case t.Max >= float64(limit):
+		check.Message = fmt.Sprintf("max temperature recorded %.1f'C reaches %v'C", t.Max, limit)
+	default:
+		check.IsPassed = true
+		check.Message = fmt.Sprintf("max temperature recorded %.1f'C is below %v'C", t.Max, limit)
+	}
+	return check
+}
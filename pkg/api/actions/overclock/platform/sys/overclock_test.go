@@ -0,0 +1,304 @@
+package sys_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/overclock/platform/sys"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/history"
+	"github.com/stretchr/testify/assert"
+)
+
+var config = []string{
+	"arm_freq=1600",
+	"",
+	"[pi4]",
+	"over_voltage=2",
+	"#gpu_freq=600",
+	"",
+	"[all]",
+	"dtoverlay=gpio-fan,gpiopin=14",
+}
+
+func TestList(t *testing.T) {
+	cases := []struct {
+		name            string
+		board           rpi.Board
+		cooling         []string
+		temperatures    []history.Sample
+		throttled       string
+		wantedSupported bool
+		wantedCurrent   []rpi.OverclockSetting
+		wantedAllowed   map[string]bool
+		wantedFailed    map[string][]string
+	}{
+		{
+			name:            "unsupported board",
+			board:           rpi.Board{Type: "Zero W"},
+			wantedSupported: false,
+			wantedCurrent: []rpi.OverclockSetting{
+				{Section: "all", Key: "arm_freq", Value: "1600", IsSet: true},
+			},
+			wantedAllowed: map[string]bool{},
+			wantedFailed:  map[string][]string{},
+		},
+		{
+			name:            "healthy board",
+			board:           rpi.Board{Type: "4B"},
+			temperatures:    []history.Sample{{Time: 10, Value: 52000}, {Time: 70, Value: 58500}},
+			throttled:       "throttled=0x0\n",
+			wantedSupported: true,
+			wantedCurrent: []rpi.OverclockSetting{
+				{Section: "all", Key: "arm_freq", Value: "1600", IsSet: true},
+				{Section: "pi4", Key: "over_voltage", Value: "2", IsSet: true},
+			},
+			wantedAllowed: map[string]bool{"none": true, "modest": true, "high": true, "custom": true},
+			wantedFailed:  map[string][]string{"none": {}, "modest": {}, "high": {}, "custom": {}},
+		},
+		{
+			name:            "hot and throttled board",
+			board:           rpi.Board{Type: "4B"},
+			temperatures:    []history.Sample{{Time: 10, Value: 72000}},
+			throttled:       "throttled=0x80000",
+			wantedSupported: true,
+			wantedCurrent: []rpi.OverclockSetting{
+				{Section: "all", Key: "arm_freq", Value: "1600", IsSet: true},
+				{Section: "pi4", Key: "over_voltage", Value: "2", IsSet: true},
+			},
+			wantedAllowed: map[string]bool{"none": true, "modest": true, "high": false, "custom": false},
+			wantedFailed: map[string][]string{
+				"none":   {},
+				"modest": {"throttling"},
+				"high":   {"throttling", "temperature"},
+				"custom": {"throttling", "temperature"},
+			},
+		},
+		{
+			name:            "unknown throttling state and no history",
+			board:           rpi.Board{Type: "4B"},
+			wantedSupported: true,
+			wantedCurrent: []rpi.OverclockSetting{
+				{Section: "all", Key: "arm_freq", Value: "1600", IsSet: true},
+				{Section: "pi4", Key: "over_voltage", Value: "2", IsSet: true},
+			},
+			wantedAllowed: map[string]bool{"none": true, "modest": false, "high": false, "custom": false},
+			wantedFailed: map[string][]string{
+				"none":   {},
+				"modest": {"power", "throttling", "temperature"},
+				"high":   {"power", "throttling", "temperature"},
+				"custom": {"power", "throttling", "temperature"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := sys.Overclock{}
+			result, err := s.List(tc.board, config, "", map[string]string{}, tc.cooling, tc.temperatures, tc.throttled)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.wantedSupported, result.IsSupported)
+			assert.Equal(t, tc.wantedCurrent, result.Current)
+
+			allowed := map[string]bool{}
+			failed := map[string][]string{}
+			for _, p := range result.Profiles {
+				allowed[p.Name] = p.IsAllowed
+				failed[p.Name] = []string{}
+				for _, c := range p.Checks {
+					if !c.IsPassed {
+						failed[p.Name] = append(failed[p.Name], c.Name)
+					}
+				}
+			}
+			assert.Equal(t, tc.wantedAllowed, allowed)
+			assert.Equal(t, tc.wantedFailed, failed)
+		})
+	}
+}
+
+func TestTemperature(t *testing.T) {
+	assert.Equal(t, rpi.OverclockHistory{}, sys.Temperature(nil))
+	assert.Equal(t, rpi.OverclockHistory{
+		Current: 48.5,
+		Max:     61.2,
+		Samples: 3,
+		Since:   10,
+	}, sys.Temperature([]history.Sample{{Time: 10, Value: 52000}, {Time: 70, Value: 61200}, {Time: 130, Value: 48500}}))
+}
+
+func TestState(t *testing.T) {
+	state := sys.State(
+		`{"profile":"high","section":"pi4","previous":[{"section":"pi4","key":"arm_freq","value":"1500","isSet":true}],"appliedAt":1600000000}`,
+		map[string]string{
+			actions.OverclockSuccessFile:  "1600000300\n",
+			actions.OverclockRestoredFile: "temperature above 80'C\n",
+		},
+	)
+
+	assert.Equal(t, rpi.OverclockState{
+		Profile:          "high",
+		Section:          "pi4",
+		Previous:         []rpi.OverclockSetting{{Section: "pi4", Key: "arm_freq", Value: "1500", IsSet: true}},
+		AppliedAt:        1600000000,
+		IsBootSuccessful: true,
+		SuccessAt:        1600000300,
+		IsRestored:       true,
+		RestoredReason:   "temperature above 80'C",
+	}, state)
+
+	assert.Equal(t, rpi.OverclockState{IsPending: true}, sys.State("", map[string]string{actions.OverclockPendingFile: ""}))
+}
+
+func TestSettings(t *testing.T) {
+	current := rpi.Overclock{
+		Current: []rpi.OverclockSetting{
+			{Section: "all", Key: "arm_freq", Value: "1600", IsSet: true},
+			{Section: "pi4", Key: "over_voltage", Value: "2", IsSet: true},
+		},
+	}
+
+	cases := []struct {
+		name         string
+		board        rpi.Board
+		profile      string
+		custom       map[string]string
+		wantedResult []rpi.OverclockSetting
+		wantedErr    error
+	}{
+		{
+			name:      "error: unsupported board",
+			board:     rpi.Board{Type: "Zero W"},
+			profile:   "modest",
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an unsupported board - no overclock profile for Zero W"),
+		},
+		{
+			name:      "error: custom value above the limit",
+			board:     rpi.Board{Type: "4B"},
+			profile:   "custom",
+			custom:    map[string]string{"arm_freq": "2500"},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid value - arm_freq should be between 0 and 2147 on a 4B"),
+		},
+		{
+			name:      "error: custom value not a number",
+			board:     rpi.Board{Type: "4B"},
+			profile:   "custom",
+			custom:    map[string]string{"gpu_freq": "fast"},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid value - gpu_freq should be between 0 and 750 on a 4B"),
+		},
+		{
+			name:      "error: over_voltage disallowed",
+			board:     rpi.Board{Type: "4B", IsOvervoltageDisallowed: true},
+			profile:   "high",
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid value - over_voltage is disallowed on this board"),
+		},
+		{
+			name:      "error: force_turbo with over_voltage",
+			board:     rpi.Board{Type: "4B"},
+			profile:   "custom",
+			custom:    map[string]string{"over_voltage": "2", "force_turbo": "1"},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid value - force_turbo with over_voltage would permanently void the warranty"),
+		},
+		{
+			name:    "success: modest",
+			board:   rpi.Board{Type: "4B"},
+			profile: "modest",
+			wantedResult: []rpi.OverclockSetting{
+				{Section: "all", Key: "arm_freq"},
+				{Section: "pi4", Key: "arm_freq", Value: "1750", IsSet: true},
+				{Section: "pi4", Key: "over_voltage", Value: "2", IsSet: true},
+				{Section: "pi4", Key: "gpu_freq"},
+				{Section: "pi4", Key: "force_turbo"},
+			},
+		},
+		{
+			name:    "success: modest on a 3B+",
+			board:   rpi.Board{Type: "3B+"},
+			profile: "modest",
+			wantedResult: []rpi.OverclockSetting{
+				{Section: "all", Key: "arm_freq"},
+				{Section: "pi3+", Key: "arm_freq", Value: "1450", IsSet: true},
+				{Section: "pi3+", Key: "over_voltage", Value: "2", IsSet: true},
+				{Section: "pi3+", Key: "gpu_freq"},
+				{Section: "pi3+", Key: "force_turbo"},
+			},
+		},
+		{
+			name:    "success: none",
+			board:   rpi.Board{Type: "4B"},
+			profile: "none",
+			wantedResult: []rpi.OverclockSetting{
+				{Section: "all", Key: "arm_freq"},
+				{Section: "pi4", Key: "arm_freq"},
+				{Section: "pi4", Key: "over_voltage"},
+				{Section: "pi4", Key: "gpu_freq"},
+				{Section: "pi4", Key: "force_turbo"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := sys.Overclock{}
+			result, err := s.Settings(current, tc.board, tc.profile, tc.custom)
+			assert.Equal(t, tc.wantedResult, result)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestExecuteOC(t *testing.T) {
+	cases := []struct {
+		name         string
+		execPlan     map[int](map[int]actions.Func)
+		wantedResult rpi.Action
+	}{
+		{
+			name: "error",
+			execPlan: map[int](map[int]actions.Func){
+				1: {
+					1: {
+						Name:      "funcA",
+						Reference: func(arg interface{}) (rpi.Exec, error) { return rpi.Exec{ExitStatus: 1}, nil },
+						Argument:  []interface{}{actions.BCO{}},
+					},
+				},
+			},
+			wantedResult: rpi.Action{
+				Name:          actions.Overclock,
+				NumberOfSteps: 1,
+				ExitStatus:    1,
+			},
+		},
+		{
+			name: "success",
+			execPlan: map[int](map[int]actions.Func){
+				1: {
+					1: {
+						Name:      "funcA",
+						Reference: func(arg interface{}) (rpi.Exec, error) { return rpi.Exec{ExitStatus: 0}, nil },
+						Argument:  []interface{}{actions.BCO{}},
+					},
+				},
+			},
+			wantedResult: rpi.Action{
+				Name:          actions.Overclock,
+				NumberOfSteps: 1,
+				ExitStatus:    0,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := sys.Overclock{}
+			oc, err := s.ExecuteOC(tc.execPlan)
+			assert.Equal(t, tc.wantedResult.Name, oc.Name)
+			assert.Equal(t, tc.wantedResult.NumberOfSteps, oc.NumberOfSteps)
+			assert.Equal(t, tc.wantedResult.ExitStatus, oc.ExitStatus)
+			assert.Nil(t, err)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package overclock
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/history"
+)
+
+// Service represents all Overclock application services.
+type Service interface {
+	List() (rpi.Overclock, error)
+	ExecuteOC(string, map[string]string, bool) (rpi.Action, error)
+	ExecuteOCR() (rpi.Action, error)
+}
+
+// Overclock represents an Overclock application service.
+type Overclock struct {
+	ocsys OCSYS
+	a     Actions
+	i     Infos
+	m     Metrics
+	b     Board
+	h     History
+}
+
+// OCSYS represents an Overclock repository service.
+type OCSYS interface {
+	List(rpi.Board, []string, string, map[string]string, []string, []history.Sample, string) (rpi.Overclock, error)
+	Settings(rpi.Overclock, rpi.Board, string, map[string]string) ([]rpi.OverclockSetting, error)
+	ExecuteOC(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteOCR(map[int](map[int]actions.Func)) (rpi.Action, error)
+}
+
+// Actions represents the actions interface
+type Actions interface {
+	SaveOverclockState(interface{}) (rpi.Exec, error)
+	EditBootConfig(interface{}) (rpi.Exec, error)
+	PersistOverclockGuard(interface{}) (rpi.Exec, error)
+	ManageUnit(interface{}) (rpi.Exec, error)
+	ClearOverclockState(interface{}) (rpi.Exec, error)
+}
+
+// Infos represents the infos interface
+type Infos interface {
+	GetConfigFiles() map[string]rpi.ConfigFileDetails
+	ReadFile(string) ([]string, error)
+	CoolingDevices(string) []string
+}
+
+// Metrics represents the system metrics interface
+type Metrics interface {
+	Temperature() (string, string, error)
+	Throttled() (string, string, error)
+}
+
+// Board represents the board interface
+type Board interface {
+	List() (rpi.Board, error)
+}
+
+// History represents the temperature history interface
+type History interface {
+	Samples() map[string][]history.Sample
+}
+
+// New creates an Overclock application service instance.
+// b decodes the board model and h is the cpu temperature history fed by Collect.
+func New(ocsys OCSYS, a Actions, i Infos, m Metrics, b Board, h History) *Overclock {
+	return &Overclock{ocsys: ocsys, a: a, i: i, m: m, b: b, h: h}
+}
@@ -0,0 +1,74 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi/pkg/api/actions/overclock"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// HTTP is a struct implementing a core application service.
+type HTTP struct {
+	svc overclock.Service
+}
+
+// NewHTTP creates new overclock http service
+func NewHTTP(svc overclock.Service, r *echo.Group) {
+	h := HTTP{svc}
+	cr := r.Group("/overclock")
+	cr.GET("", h.list)
+	for _, profile := range actions.OverclockProfiles {
+		cr.POST("/apply/"+profile, h.apply(profile))
+	}
+	cr.POST("/restore", h.restore)
+}
+
+func (h *HTTP) list(ctx echo.Context) error {
+	result, err := h.svc.List()
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) apply(profile string) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		custom := map[string]string{}
+		for _, key := range actions.OverclockKeys {
+			if value := ctx.QueryParam(key); value != "" {
+				custom[key] = value
+			}
+		}
+
+		if profile != "custom" && len(custom) > 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to invalid values - only the custom profile accepts values")
+		}
+		if profile == "custom" && len(custom) == 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to invalid values - at least one of arm_freq, over_voltage, gpu_freq or force_turbo is required")
+		}
+
+		force := false
+		if value := ctx.QueryParam("force"); value != "" {
+			var err error
+			if force, err = strconv.ParseBool(value); err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid force - should be true or false")
+			}
+		}
+
+		result, err := h.svc.ExecuteOC(profile, custom, force)
+		if err != nil {
+			return err
+		}
+		return ctx.JSON(http.StatusOK, result)
+	}
+}
+
+func (h *HTTP) restore(ctx echo.Context) error {
+	result, err := h.svc.ExecuteOCR()
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,147 @@
+package transport_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/overclock"
+	"github.com/raspibuddy/rpi/pkg/api/actions/overclock/transport"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/history"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/raspibuddy/rpi/pkg/utl/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverclock(t *testing.T) {
+	cases := []struct {
+		name         string
+		method       string
+		req          string
+		readErr      error
+		executeErr   error
+		wantedStatus int
+	}{
+		{
+			name:         "error: list read config",
+			method:       http.MethodGet,
+			req:          "",
+			readErr:      errors.New("test error"),
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:         "success: list",
+			method:       http.MethodGet,
+			req:          "",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "error: invalid profile",
+			method:       http.MethodPost,
+			req:          "/apply/extreme",
+			wantedStatus: http.StatusNotFound,
+		},
+		{
+			name:         "error: values with a predefined profile",
+			method:       http.MethodPost,
+			req:          "/apply/high?arm_freq=2000",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: custom profile without values",
+			method:       http.MethodPost,
+			req:          "/apply/custom",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid force",
+			method:       http.MethodPost,
+			req:          "/apply/high?force=maybe",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: ExecuteOC result is nil",
+			method:       http.MethodPost,
+			req:          "/apply/high",
+			executeErr:   errors.New("test error"),
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:         "success: apply custom",
+			method:       http.MethodPost,
+			req:          "/apply/custom?arm_freq=1800&over_voltage=3&force=true",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "success: restore",
+			method:       http.MethodPost,
+			req:          "/restore",
+			wantedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			i := mock.Infos{
+				GetConfigFilesFn: func() map[string]rpi.ConfigFileDetails {
+					return map[string]rpi.ConfigFileDetails{"bootconfig": {Path: "/boot/config.txt"}}
+				},
+				ReadFileFn: func(string) ([]string, error) {
+					return []string{}, tc.readErr
+				},
+				CoolingDevicesFn: func(string) []string {
+					return []string{}
+				},
+			}
+			m := mock.Metrics{
+				ThrottledFn: func() (string, string, error) {
+					return "throttled=0x0", "", nil
+				},
+			}
+			b := mock.Board{
+				ListFn: func() (rpi.Board, error) {
+					return rpi.Board{Type: "4B"}, nil
+				},
+			}
+			execute := func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+				return rpi.Action{Name: actions.Overclock, NumberOfSteps: 1}, tc.executeErr
+			}
+			ocsys := &mocksys.Overclock{
+				ListFn: func(rpi.Board, []string, string, map[string]string, []string, []history.Sample, string) (rpi.Overclock, error) {
+					return rpi.Overclock{Board: "4B", IsSupported: true, State: rpi.OverclockState{Profile: "high"}}, nil
+				},
+				SettingsFn: func(rpi.Overclock, rpi.Board, string, map[string]string) ([]rpi.OverclockSetting, error) {
+					return []rpi.OverclockSetting{}, nil
+				},
+				ExecuteOCFn:  execute,
+				ExecuteOCRFn: execute,
+			}
+			s := overclock.New(ocsys, actions.New(), i, m, b, history.New(10, ""))
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/overclock" + tc.req
+
+			req, err := http.NewRequest(tc.method, path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
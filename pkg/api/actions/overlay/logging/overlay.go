@@ -0,0 +1,86 @@
+package overlay
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/overlay"
+)
+
+// New creates a new Overlay logging service instance.
+func New(svc overlay.Service, logger rpi.Logger) *LogService {
+	return &LogService{
+		Service: svc,
+		logger:  logger,
+	}
+}
+
+// LogService represents an Overlay logging service.
+type LogService struct {
+	overlay.Service
+	logger rpi.Logger
+}
+
+const name = "overlay"
+
+// List is the logging function attached to the List overlay services and responsible for logging it out.
+func (ls *LogService) List(ctx echo.Context) (resp rpi.Overlays, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, "request: list overlays", err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.List()
+}
+
+// View is the logging function attached to the View overlay services and responsible for logging it out.
+func (ls *LogService) View(ctx echo.Context, id string) (resp rpi.Overlay, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: view overlay %v", id), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.View(id)
+}
+
+// ExecuteOVL is the logging function attached to the ExecuteOVL overlay services and responsible for logging it out.
+func (ls *LogService) ExecuteOVL(ctx echo.Context, action string, section string, id string, params map[string]string) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: %v overlay %v in section %v", action, id, section), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteOVL(action, section, id, params)
+}
+
+// ExecuteDTP is the logging function attached to the ExecuteDTP overlay services and responsible for logging it out.
+func (ls *LogService) ExecuteDTP(ctx echo.Context, action string, section string, id string, value string) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: %v dtparam %v in section %v", action, id, section), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteDTP(action, section, id, value)
+}
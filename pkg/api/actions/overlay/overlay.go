@@ -0,0 +1,178 @@
+package overlay
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/bootconfig"
+	"github.com/raspibuddy/rpi/pkg/utl/constants"
+)
+
+// List populates and returns the Overlays model.
+func (ovl *Overlay) List() (rpi.Overlays, error) {
+	config, err := ovl.i.ReadFile(ovl.i.GetConfigFiles()["bootconfig"].Path)
+	if err != nil {
+		return rpi.Overlays{}, echo.NewHTTPError(http.StatusInternalServerError, "could not read the boot config")
+	}
+
+	// the README is not shipped on every image, overlays are then listed without documentation
+	readme, _ := ovl.i.ReadFile(filepath.Join(constants.OVERLAYS, "README"))
+
+	return ovl.ovlsys.List(
+		ovl.i.ListFiles(filepath.Join(constants.OVERLAYS, "*.dtbo")),
+		readme,
+		config,
+		ovl.i.DtoverlayList(),
+		ovl.i.DeviceTreeStatus(constants.DEVICETREE),
+	)
+}
+
+// View populates and returns one Overlay model.
+func (ovl *Overlay) View(name string) (rpi.Overlay, error) {
+	overlays, err := ovl.List()
+	if err != nil {
+		return rpi.Overlay{}, err
+	}
+
+	return ovl.ovlsys.View(name, overlays)
+}
+
+// ExecuteOVL adds, updates or removes a dtoverlay line within a section of /boot/config.txt and returns an action.
+// Adding replaces the parameters of an active overlay while updating merges the given parameters into them.
+// An overlay active several times in the section (ex: two gpio-key buttons) is not edited.
+func (ovl *Overlay) ExecuteOVL(action string, section string, name string, params map[string]string) (rpi.Action, error) {
+	overlays, err := ovl.List()
+	if err != nil {
+		return rpi.Action{}, err
+	}
+
+	overlay, err := ovl.ovlsys.View(name, overlays)
+	if err != nil || (action == "add" && !overlay.IsFile) {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusNotFound, "Not found - overlay does not exist")
+	}
+
+	var active *rpi.OverlayUsage
+	instances := 0
+	for k := range overlay.Active {
+		if overlay.Active[k].Section == section {
+			active = &overlay.Active[k]
+			instances++
+		}
+	}
+
+	if action != "add" && active == nil {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusNotFound, "Not found - overlay is not active in section "+section)
+	}
+
+	// every dtoverlay line of the overlay is edited at once, which would make the instances identical or remove them all
+	if instances > 1 {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to several instances - %v is active %v times in section %v", name, instances, section))
+	}
+
+	if len(overlay.Params) > 0 {
+		documented := map[string]bool{}
+		for _, p := range overlay.Params {
+			documented[p.Name] = true
+		}
+
+		unknown := []string{}
+		for p := range params {
+			if !documented[p] {
+				unknown = append(unknown, p)
+			}
+		}
+
+		if len(unknown) > 0 {
+			sort.Strings(unknown)
+			return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to an invalid param - %v not documented for %v", strings.Join(unknown, ", "), name))
+		}
+	}
+
+	operation := bootconfig.Set
+	switch action {
+	case "update":
+		merged := map[string]string{}
+		for p, v := range active.Params {
+			merged[p] = v
+		}
+		for p, v := range params {
+			merged[p] = v
+		}
+		params = merged
+	case "remove":
+		operation = bootconfig.Unset
+		params = map[string]string{}
+	}
+
+	plan := map[int](map[int]actions.Func){
+		1: {
+			1: {
+				Name:      actions.EditBootConfig,
+				Reference: ovl.a.EditBootConfig,
+				Argument: []interface{}{
+					actions.BCO{
+						Path:      ovl.i.GetConfigFiles()["bootconfig"].Path,
+						Operation: operation,
+						Section:   section,
+						Key:       "dtoverlay=" + name,
+						Value:     bootconfig.FormatOverlayParams(params),
+					},
+				},
+			},
+		},
+	}
+
+	return ovl.ovlsys.ExecuteOVL(plan)
+}
+
+// ExecuteDTP sets or removes a dtparam line within a section of /boot/config.txt and returns an action.
+func (ovl *Overlay) ExecuteDTP(action string, section string, name string, value string) (rpi.Action, error) {
+	operation := bootconfig.Set
+
+	if action == "remove" {
+		overlays, err := ovl.List()
+		if err != nil {
+			return rpi.Action{}, err
+		}
+
+		isActive := false
+		for _, dp := range overlays.DtParams {
+			for _, usage := range dp.Active {
+				isActive = isActive || (dp.Name == name && usage.Section == section)
+			}
+		}
+
+		if !isActive {
+			return rpi.Action{}, echo.NewHTTPError(http.StatusNotFound, "Not found - dtparam is not active in section "+section)
+		}
+
+		operation = bootconfig.Unset
+		value = ""
+	}
+
+	plan := map[int](map[int]actions.Func){
+		1: {
+			1: {
+				Name:      actions.EditBootConfig,
+				Reference: ovl.a.EditBootConfig,
+				Argument: []interface{}{
+					actions.BCO{
+						Path:      ovl.i.GetConfigFiles()["bootconfig"].Path,
+						Operation: operation,
+						Section:   section,
+						Key:       "dtparam=" + name,
+						Value:     value,
+					},
+				},
+			},
+		},
+	}
+
+	return ovl.ovlsys.ExecuteDTP(plan)
+}
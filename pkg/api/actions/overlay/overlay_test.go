@@ -0,0 +1,297 @@
+package overlay_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/overlay"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/stretchr/testify/assert"
+)
+
+var overlays = rpi.Overlays{
+	Overlays: []rpi.Overlay{
+		{
+			Name:     "w1-gpio",
+			Params:   []rpi.OverlayParam{{Name: "gpiopin"}, {Name: "pullup"}},
+			IsFile:   true,
+			IsActive: true,
+			Active: []rpi.OverlayUsage{
+				{Section: "all", Value: "gpiopin=17", Params: map[string]string{"gpiopin": "17"}},
+			},
+		},
+		{
+			Name:   "vc4-fkms-v3d",
+			Params: []rpi.OverlayParam{},
+			IsFile: true,
+			Active: []rpi.OverlayUsage{},
+		},
+		{
+			Name:     "gpio-key",
+			Params:   []rpi.OverlayParam{{Name: "gpio"}, {Name: "keycode"}},
+			IsFile:   true,
+			IsActive: true,
+			Active: []rpi.OverlayUsage{
+				{Section: "all", Value: "gpio=3,keycode=116", Params: map[string]string{"gpio": "3", "keycode": "116"}},
+				{Section: "all", Value: "gpio=21,keycode=28", Params: map[string]string{"gpio": "21", "keycode": "28"}},
+				{Section: "pi4", Value: "gpio=3,keycode=116", Params: map[string]string{"gpio": "3", "keycode": "116"}},
+			},
+		},
+		{
+			Name:     "removed",
+			IsActive: true,
+			Active:   []rpi.OverlayUsage{{Section: "pi4", Params: map[string]string{}}},
+		},
+	},
+	DtParams: []rpi.DtParam{
+		{
+			Name:     "spi",
+			IsActive: true,
+			Active:   []rpi.OverlayUsage{{Section: "all", Value: "on"}},
+		},
+	},
+}
+
+func infos(readErr error) *mock.Infos {
+	return &mock.Infos{
+		GetConfigFilesFn: func() map[string]rpi.ConfigFileDetails {
+			return map[string]rpi.ConfigFileDetails{"bootconfig": {Path: "/boot/config.txt"}}
+		},
+		ReadFileFn: func(path string) ([]string, error) {
+			if path == "/boot/config.txt" {
+				return []string{}, readErr
+			}
+			return nil, errors.New("no README")
+		},
+		ListFilesFn: func(string) []string {
+			return []string{}
+		},
+		DtoverlayListFn: func() []string {
+			return []string{}
+		},
+		DeviceTreeStatusFn: func(string) map[string]string {
+			return map[string]string{}
+		},
+	}
+}
+
+func ovlsys(arg *actions.BCO) *mocksys.Overlay {
+	execute := func(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+		*arg = plan[1][1].Argument[0].(actions.BCO)
+		return rpi.Action{NumberOfSteps: 1}, nil
+	}
+
+	return &mocksys.Overlay{
+		ListFn: func([]string, []string, []string, []string, map[string]string) (rpi.Overlays, error) {
+			return overlays, nil
+		},
+		ViewFn: func(name string, overlays rpi.Overlays) (rpi.Overlay, error) {
+			for _, ov := range overlays.Overlays {
+				if ov.Name == name {
+					return ov, nil
+				}
+			}
+			return rpi.Overlay{}, echo.NewHTTPError(http.StatusNotFound, "overlay does not exist")
+		},
+		ExecuteOVLFn: execute,
+		ExecuteDTPFn: execute,
+	}
+}
+
+func TestList(t *testing.T) {
+	var arg actions.BCO
+
+	s := overlay.New(ovlsys(&arg), nil, infos(errors.New("test error")))
+	_, err := s.List()
+	assert.Equal(t, echo.NewHTTPError(http.StatusInternalServerError, "could not read the boot config"), err)
+
+	s = overlay.New(ovlsys(&arg), nil, infos(nil))
+	result, err := s.List()
+	assert.Nil(t, err)
+	assert.Equal(t, overlays, result)
+
+	view, err := s.View("w1-gpio")
+	assert.Nil(t, err)
+	assert.Equal(t, overlays.Overlays[0], view)
+}
+
+func TestExecuteOVL(t *testing.T) {
+	cases := []struct {
+		name      string
+		action    string
+		section   string
+		overlay   string
+		params    map[string]string
+		wantedArg actions.BCO
+		wantedErr error
+	}{
+		{
+			name:      "error: unknown overlay",
+			action:    "add",
+			section:   "all",
+			overlay:   "dummy",
+			wantedErr: echo.NewHTTPError(http.StatusNotFound, "Not found - overlay does not exist"),
+		},
+		{
+			name:      "error: add overlay without dtbo file",
+			action:    "add",
+			section:   "pi4",
+			overlay:   "removed",
+			wantedErr: echo.NewHTTPError(http.StatusNotFound, "Not found - overlay does not exist"),
+		},
+		{
+			name:      "error: update inactive overlay",
+			action:    "update",
+			section:   "pi4",
+			overlay:   "w1-gpio",
+			params:    map[string]string{"gpiopin": "4"},
+			wantedErr: echo.NewHTTPError(http.StatusNotFound, "Not found - overlay is not active in section pi4"),
+		},
+		{
+			name:      "error: undocumented param",
+			action:    "add",
+			section:   "all",
+			overlay:   "w1-gpio",
+			params:    map[string]string{"gpiopin": "4", "speed": "1", "baud": "2"},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid param - baud, speed not documented for w1-gpio"),
+		},
+		{
+			name:      "error: update one of several instances",
+			action:    "update",
+			section:   "all",
+			overlay:   "gpio-key",
+			params:    map[string]string{"keycode": "1"},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to several instances - gpio-key is active 2 times in section all"),
+		},
+		{
+			name:      "error: remove one of several instances",
+			action:    "remove",
+			section:   "all",
+			overlay:   "gpio-key",
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to several instances - gpio-key is active 2 times in section all"),
+		},
+		{
+			name:    "success: remove the single instance of a section",
+			action:  "remove",
+			section: "pi4",
+			overlay: "gpio-key",
+			wantedArg: actions.BCO{
+				Path:      "/boot/config.txt",
+				Operation: "unset",
+				Section:   "pi4",
+				Key:       "dtoverlay=gpio-key",
+			},
+		},
+		{
+			name:    "success: add",
+			action:  "add",
+			section: "pi4",
+			overlay: "vc4-fkms-v3d",
+			params:  map[string]string{"cma-128": ""},
+			wantedArg: actions.BCO{
+				Path:      "/boot/config.txt",
+				Operation: "set",
+				Section:   "pi4",
+				Key:       "dtoverlay=vc4-fkms-v3d",
+				Value:     "cma-128",
+			},
+		},
+		{
+			name:    "success: update merges params",
+			action:  "update",
+			section: "all",
+			overlay: "w1-gpio",
+			params:  map[string]string{"pullup": ""},
+			wantedArg: actions.BCO{
+				Path:      "/boot/config.txt",
+				Operation: "set",
+				Section:   "all",
+				Key:       "dtoverlay=w1-gpio",
+				Value:     "gpiopin=17,pullup",
+			},
+		},
+		{
+			name:    "success: remove overlay without dtbo file",
+			action:  "remove",
+			section: "pi4",
+			overlay: "removed",
+			wantedArg: actions.BCO{
+				Path:      "/boot/config.txt",
+				Operation: "unset",
+				Section:   "pi4",
+				Key:       "dtoverlay=removed",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var arg actions.BCO
+			s := overlay.New(ovlsys(&arg), &mock.Actions{}, infos(nil))
+			_, err := s.ExecuteOVL(tc.action, tc.section, tc.overlay, tc.params)
+			assert.Equal(t, tc.wantedErr, err)
+			assert.Equal(t, tc.wantedArg, arg)
+		})
+	}
+}
+
+func TestExecuteDTP(t *testing.T) {
+	cases := []struct {
+		name      string
+		action    string
+		section   string
+		dtparam   string
+		value     string
+		wantedArg actions.BCO
+		wantedErr error
+	}{
+		{
+			name:      "error: remove inactive dtparam",
+			action:    "remove",
+			section:   "pi4",
+			dtparam:   "spi",
+			wantedErr: echo.NewHTTPError(http.StatusNotFound, "Not found - dtparam is not active in section pi4"),
+		},
+		{
+			name:    "success: set",
+			action:  "set",
+			section: "all",
+			dtparam: "i2c_arm",
+			value:   "on",
+			wantedArg: actions.BCO{
+				Path:      "/boot/config.txt",
+				Operation: "set",
+				Section:   "all",
+				Key:       "dtparam=i2c_arm",
+				Value:     "on",
+			},
+		},
+		{
+			name:    "success: remove",
+			action:  "remove",
+			section: "all",
+			dtparam: "spi",
+			wantedArg: actions.BCO{
+				Path:      "/boot/config.txt",
+				Operation: "unset",
+				Section:   "all",
+				Key:       "dtparam=spi",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var arg actions.BCO
+			s := overlay.New(ovlsys(&arg), &mock.Actions{}, infos(nil))
+			_, err := s.ExecuteDTP(tc.action, tc.section, tc.dtparam, tc.value)
+			assert.Equal(t, tc.wantedErr, err)
+			assert.Equal(t, tc.wantedArg, arg)
+		})
+	}
+}
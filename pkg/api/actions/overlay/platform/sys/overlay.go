@@ -0,0 +1,227 @@
+package sys
+
+import (
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/bootconfig"
+)
+
+// BaseDTB is the name of the README entry documenting the base device tree parameters
+const BaseDTB = "<The base DTB>"
+
+var (
+	readmeLabelRegex = regexp.MustCompile(`^(Name|Info|Load|Params):`)
+	loadedRegex      = regexp.MustCompile(`^\s*[0-9]+:\s+(\S+)\s*(.*)$`)
+)
+
+// Overlay represents an empty Overlay entity on the current system.
+type Overlay struct{}
+
+// List returns the overlays found in /boot/overlays or in its README, the overlays and parameters
+// set in config.txt, the overlays loaded at runtime and the status of the device tree nodes
+func (o Overlay) List(
+	files []string,
+	readme []string,
+	config []string,
+	loaded []string,
+	runtime map[string]string,
+) (rpi.Overlays, error) {
+	overlays := map[string]*rpi.Overlay{}
+	dtParams := map[string]*rpi.DtParam{}
+
+	overlay := func(name string) *rpi.Overlay {
+		if _, ok := overlays[name]; !ok {
+			overlays[name] = &rpi.Overlay{Name: name, Params: []rpi.OverlayParam{}, Active: []rpi.OverlayUsage{}}
+		}
+		return overlays[name]
+	}
+
+	dtParam := func(name string) *rpi.DtParam {
+		if _, ok := dtParams[name]; !ok {
+			dtParams[name] = &rpi.DtParam{Name: name, Active: []rpi.OverlayUsage{}}
+		}
+		return dtParams[name]
+	}
+
+	for _, doc := range Readme(readme) {
+		if doc.Name == BaseDTB {
+			for _, p := range doc.Params {
+				dtParam(p.Name).Info = p.Info
+			}
+			continue
+		}
+
+		ov := overlay(doc.Name)
+		ov.Info = doc.Info
+		ov.Params = doc.Params
+	}
+
+	for _, f := range files {
+		if strings.HasSuffix(f, ".dtbo") {
+			overlay(strings.TrimSuffix(filepath.Base(f), ".dtbo")).IsFile = true
+		}
+	}
+
+	c := bootconfig.Parse(config)
+	for k, l := range c.Lines {
+		if l.Kind != bootconfig.Setting || l.Commented {
+			continue
+		}
+
+		if name := strings.TrimPrefix(l.Key, "dtoverlay="); name != l.Key && name != "" {
+			ov := overlay(name)
+			ov.IsActive = true
+			ov.Active = append(ov.Active, rpi.OverlayUsage{
+				Section: l.Section,
+				Line:    k + 1,
+				Value:   l.Value,
+				Params:  bootconfig.OverlayParams(l.Value),
+			})
+		} else if name := strings.TrimPrefix(l.Key, "dtparam="); name != l.Key && name != "" {
+			dp := dtParam(name)
+			dp.IsActive = true
+			dp.Active = append(dp.Active, rpi.OverlayUsage{
+				Section: l.Section,
+				Line:    k + 1,
+				Value:   l.Value,
+			})
+		}
+	}
+
+	for _, l := range loaded {
+		if m := loadedRegex.FindStringSubmatch(l); m != nil {
+			ov := overlay(m[1])
+			ov.IsLoaded = true
+			ov.LoadedParams = strings.TrimSpace(m[2])
+		}
+	}
+
+	result := rpi.Overlays{
+		Overlays: []rpi.Overlay{},
+		DtParams: []rpi.DtParam{},
+		Runtime:  runtime,
+	}
+
+	names := []string{}
+	for name := range overlays {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		result.Overlays = append(result.Overlays, *overlays[name])
+	}
+
+	names = []string{}
+	for name := range dtParams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		result.DtParams = append(result.DtParams, *dtParams[name])
+	}
+
+	return result, nil
+}
+
+// View returns one overlay of a list
+func (o Overlay) View(name string, overlays rpi.Overlays) (rpi.Overlay, error) {
+	for _, ov := range overlays.Overlays {
+		if ov.Name == name {
+			return ov, nil
+		}
+	}
+
+	return rpi.Overlay{}, echo.NewHTTPError(http.StatusNotFound, "overlay does not exist")
+}
+
+// ExecuteOVL returns an action response after editing a dtoverlay line of /boot/config.txt
+func (o Overlay) ExecuteOVL(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	actionStartTime := uint64(time.Now().Unix())
+	progressInit := actions.FlattenPlan(plan)
+	progress, exitStatus := actions.ExecutePlan(plan, progressInit)
+
+	return rpi.Action{
+		Name:          actions.Overlay,
+		NumberOfSteps: uint16(len(progressInit)),
+		Progress:      progress,
+		ExitStatus:    exitStatus,
+		StartTime:     actionStartTime,
+		EndTime:       uint64(time.Now().Unix()),
+	}, nil
+}
+
+// ExecuteDTP returns an action response after editing a dtparam line of /boot/config.txt
+func (o Overlay) ExecuteDTP(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	actionStartTime := uint64(time.Now().Unix())
+	progressInit := actions.FlattenPlan(plan)
+	progress, exitStatus := actions.ExecutePlan(plan, progressInit)
+
+	return rpi.Action{
+		Name:          actions.DtParam,
+		NumberOfSteps: uint16(len(progressInit)),
+		Progress:      progress,
+		ExitStatus:    exitStatus,
+		StartTime:     actionStartTime,
+		EndTime:       uint64(time.Now().Unix()),
+	}, nil
+}
+
+// Readme parses the entries of /boot/overlays/README.
+// Each entry starts with a Name: label, the values of the labels being aligned on the 9th column.
+// Blank lines may separate the params of an entry so only a new label ends the previous one.
+func Readme(lines []string) []rpi.Overlay {
+	result := []rpi.Overlay{}
+	label := ""
+
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if m := readmeLabelRegex.FindStringSubmatch(line); m != nil {
+			label = m[1]
+		} else if label == "" || !strings.HasPrefix(line, " ") {
+			continue
+		}
+
+		text := ""
+		if len(line) > 8 {
+			text = line[8:]
+		}
+
+		switch label {
+		case "Name":
+			result = append(result, rpi.Overlay{Name: strings.TrimSpace(text), Params: []rpi.OverlayParam{}})
+		case "Info":
+			if len(result) > 0 {
+				result[len(result)-1].Info = strings.TrimSpace(result[len(result)-1].Info + " " + strings.TrimSpace(text))
+			}
+		case "Params":
+			if len(result) == 0 || strings.TrimSpace(text) == "" || strings.TrimSpace(text) == "<None>" {
+				continue
+			}
+
+			current := &result[len(result)-1]
+			if !strings.HasPrefix(text, " ") {
+				fields := strings.Fields(text)
+				current.Params = append(current.Params, rpi.OverlayParam{
+					Name: fields[0],
+					Info: strings.TrimSpace(strings.TrimPrefix(text, fields[0])),
+				})
+			} else if len(current.Params) > 0 {
+				param := &current.Params[len(current.Params)-1]
+				param.Info = strings.TrimSpace(param.Info + " " + strings.TrimSpace(text))
+			}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,149 @@
+package sys_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/overlay/platform/sys"
+	"github.com/stretchr/testify/assert"
+)
+
+var readme = []string{
+	"Introduction",
+	"============",
+	"",
+	"Name:   <The base DTB>",
+	"Info:   Configures the base Raspberry Pi hardware",
+	"Load:   <loaded automatically>",
+	"Params:",
+	"        audio                   Set to \"on\" to enable the onboard ALSA audio",
+	"                                interface (default \"off\")",
+	"",
+	"        spi                     Set to \"on\" to enable the spi interfaces",
+	"                                (default \"off\")",
+	"",
+	"",
+	"Name:   w1-gpio",
+	"Info:   Configures the w1-gpio Onewire interface module.",
+	"        Use this overlay if you *don't* need a GPIO to drive an external pullup.",
+	"Load:   dtoverlay=w1-gpio,<param>=<val>",
+	"Params: gpiopin                 GPIO for I/O (default \"4\")",
+	"        pullup                  Now enabled by default (ignored)",
+	"",
+	"",
+	"Name:   vc4-fkms-v3d",
+	"Info:   Enable Eric Anholt's DRM VC4 V3D driver on top of the dispmanx",
+	"        display stack.",
+	"Load:   dtoverlay=vc4-fkms-v3d,<param>",
+	"Params: <None>",
+}
+
+func TestReadme(t *testing.T) {
+	assert.Equal(t, []rpi.Overlay{
+		{
+			Name: "<The base DTB>",
+			Info: "Configures the base Raspberry Pi hardware",
+			Params: []rpi.OverlayParam{
+				{Name: "audio", Info: "Set to \"on\" to enable the onboard ALSA audio interface (default \"off\")"},
+				{Name: "spi", Info: "Set to \"on\" to enable the spi interfaces (default \"off\")"},
+			},
+		},
+		{
+			Name: "w1-gpio",
+			Info: "Configures the w1-gpio Onewire interface module. Use this overlay if you *don't* need a GPIO to drive an external pullup.",
+			Params: []rpi.OverlayParam{
+				{Name: "gpiopin", Info: "GPIO for I/O (default \"4\")"},
+				{Name: "pullup", Info: "Now enabled by default (ignored)"},
+			},
+		},
+		{
+			Name:   "vc4-fkms-v3d",
+			Info:   "Enable Eric Anholt's DRM VC4 V3D driver on top of the dispmanx display stack.",
+			Params: []rpi.OverlayParam{},
+		},
+	}, sys.Readme(readme))
+}
+
+func TestList(t *testing.T) {
+	files := []string{
+		"/boot/overlays/w1-gpio.dtbo",
+		"/boot/overlays/vc4-fkms-v3d.dtbo",
+		"/boot/overlays/README",
+	}
+	config := []string{
+		"dtparam=audio=on",
+		"#dtparam=spi=on",
+		"dtoverlay=w1-gpio,gpiopin=17",
+		"dtoverlay=",
+		"[pi4]",
+		"dtoverlay=vc4-fkms-v3d",
+		"dtparam=i2c_arm=on",
+	}
+	loaded := []string{
+		"Overlays (in load order):",
+		"0:  w1-gpio  gpiopin=17",
+	}
+	runtime := map[string]string{"spi0": "disabled"}
+
+	s := sys.Overlay{}
+	overlays, err := s.List(files, readme, config, loaded, runtime)
+	assert.Nil(t, err)
+	assert.Equal(t, rpi.Overlays{
+		Overlays: []rpi.Overlay{
+			{
+				Name:     "vc4-fkms-v3d",
+				Info:     "Enable Eric Anholt's DRM VC4 V3D driver on top of the dispmanx display stack.",
+				Params:   []rpi.OverlayParam{},
+				IsFile:   true,
+				IsActive: true,
+				Active:   []rpi.OverlayUsage{{Section: "pi4", Line: 6, Params: map[string]string{}}},
+			},
+			{
+				Name: "w1-gpio",
+				Info: "Configures the w1-gpio Onewire interface module. Use this overlay if you *don't* need a GPIO to drive an external pullup.",
+				Params: []rpi.OverlayParam{
+					{Name: "gpiopin", Info: "GPIO for I/O (default \"4\")"},
+					{Name: "pullup", Info: "Now enabled by default (ignored)"},
+				},
+				IsFile:       true,
+				IsActive:     true,
+				Active:       []rpi.OverlayUsage{{Section: "all", Line: 3, Value: "gpiopin=17", Params: map[string]string{"gpiopin": "17"}}},
+				IsLoaded:     true,
+				LoadedParams: "gpiopin=17",
+			},
+		},
+		DtParams: []rpi.DtParam{
+			{
+				Name:     "audio",
+				Info:     "Set to \"on\" to enable the onboard ALSA audio interface (default \"off\")",
+				IsActive: true,
+				Active:   []rpi.OverlayUsage{{Section: "all", Line: 1, Value: "on"}},
+			},
+			{
+				Name:     "i2c_arm",
+				IsActive: true,
+				Active:   []rpi.OverlayUsage{{Section: "pi4", Line: 7, Value: "on"}},
+			},
+			{
+				Name:   "spi",
+				Info:   "Set to \"on\" to enable the spi interfaces (default \"off\")",
+				Active: []rpi.OverlayUsage{},
+			},
+		},
+		Runtime: runtime,
+	}, overlays)
+}
+
+func TestView(t *testing.T) {
+	overlays := rpi.Overlays{Overlays: []rpi.Overlay{{Name: "w1-gpio"}}}
+	s := sys.Overlay{}
+
+	overlay, err := s.View("w1-gpio", overlays)
+	assert.Nil(t, err)
+	assert.Equal(t, rpi.Overlay{Name: "w1-gpio"}, overlay)
+
+	_, err = s.View("dummy", overlays)
+	assert.Equal(t, echo.NewHTTPError(http.StatusNotFound, "overlay does not exist"), err)
+}
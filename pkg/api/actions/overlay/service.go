@@ -0,0 +1,48 @@
+package overlay
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// Service represents all Overlay application services.
+type Service interface {
+	List() (rpi.Overlays, error)
+	View(string) (rpi.Overlay, error)
+	ExecuteOVL(string, string, string, map[string]string) (rpi.Action, error)
+	ExecuteDTP(string, string, string, string) (rpi.Action, error)
+}
+
+// Overlay represents an Overlay application service.
+type Overlay struct {
+	ovlsys OVLSYS
+	a      Actions
+	i      Infos
+}
+
+// OVLSYS represents an Overlay repository service.
+type OVLSYS interface {
+	List([]string, []string, []string, []string, map[string]string) (rpi.Overlays, error)
+	View(string, rpi.Overlays) (rpi.Overlay, error)
+	ExecuteOVL(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteDTP(map[int](map[int]actions.Func)) (rpi.Action, error)
+}
+
+// Actions represents the actions interface
+type Actions interface {
+	EditBootConfig(interface{}) (rpi.Exec, error)
+}
+
+// Infos represents the infos interface
+type Infos interface {
+	GetConfigFiles() map[string]rpi.ConfigFileDetails
+	ReadFile(string) ([]string, error)
+	ListFiles(string) []string
+	DtoverlayList() []string
+	DeviceTreeStatus(string) map[string]string
+}
+
+// New creates an OVLSYS application service instance.
+func New(ovlsys OVLSYS, a Actions, i Infos) *Overlay {
+	return &Overlay{ovlsys: ovlsys, a: a, i: i}
+}
@@ -0,0 +1,118 @@
+package transport
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi/pkg/api/actions/overlay"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/bootconfig"
+)
+
+var (
+	nameRegex  = regexp.MustCompile(`^[A-Za-z0-9_\-]+$`)
+	valueRegex = regexp.MustCompile(`^[^,=\s]*$`)
+)
+
+// HTTP is a struct implementing a core application service.
+type HTTP struct {
+	svc overlay.Service
+}
+
+// NewHTTP creates new overlay http service
+func NewHTTP(svc overlay.Service, r *echo.Group) {
+	h := HTTP{svc}
+	cr := r.Group("/overlays")
+	cr.GET("", h.list)
+	cr.GET("/:id", h.view)
+	for _, action := range actions.OverlayActions {
+		cr.POST("/"+action+"/:id", h.overlay(action))
+	}
+	for _, action := range actions.DtParamActions {
+		cr.POST("/dtparam/"+action+"/:id", h.dtparam(action))
+	}
+}
+
+func (h *HTTP) list(ctx echo.Context) error {
+	result, err := h.svc.List()
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) view(ctx echo.Context) error {
+	result, err := h.svc.View(ctx.Param("id"))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+// section returns the section query parameter, [all] by default
+func section(ctx echo.Context) (string, error) {
+	section := ctx.QueryParam("section")
+	if section == "" {
+		section = bootconfig.DefaultSection
+	}
+	if !bootconfig.IsSection(section) {
+		return "", echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid section - should be a conditional filter such as all, pi4 or HDMI:0")
+	}
+	return section, nil
+}
+
+func (h *HTTP) overlay(action string) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		id := ctx.Param("id")
+		if !nameRegex.MatchString(id) {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid id - should be an overlay name")
+		}
+
+		section, err := section(ctx)
+		if err != nil {
+			return err
+		}
+
+		params := bootconfig.OverlayParams(ctx.QueryParam("params"))
+		for p, v := range params {
+			if !nameRegex.MatchString(p) || !valueRegex.MatchString(v) {
+				return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to invalid params - should be a comma separated list of param=value")
+			}
+		}
+		if action == "update" && len(params) == 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to invalid params - at least one param is required to update an overlay")
+		}
+
+		result, err := h.svc.ExecuteOVL(action, section, id, params)
+		if err != nil {
+			return err
+		}
+		return ctx.JSON(http.StatusOK, result)
+	}
+}
+
+func (h *HTTP) dtparam(action string) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		id := ctx.Param("id")
+		if !nameRegex.MatchString(id) {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid id - should be a dtparam name")
+		}
+
+		section, err := section(ctx)
+		if err != nil {
+			return err
+		}
+
+		value := ctx.QueryParam("value")
+		if !valueRegex.MatchString(value) || (action == "set" && value == "") {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid value - required to set a dtparam")
+		}
+
+		result, err := h.svc.ExecuteDTP(action, section, id, value)
+		if err != nil {
+			return err
+		}
+		return ctx.JSON(http.StatusOK, result)
+	}
+}
@@ -0,0 +1,170 @@
+package transport_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/overlay"
+	"github.com/raspibuddy/rpi/pkg/api/actions/overlay/transport"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/raspibuddy/rpi/pkg/utl/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverlays(t *testing.T) {
+	cases := []struct {
+		name         string
+		method       string
+		req          string
+		readErr      error
+		executeErr   error
+		wantedStatus int
+	}{
+		{
+			name:         "error: list read config",
+			method:       http.MethodGet,
+			req:          "",
+			readErr:      errors.New("test error"),
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:         "success: list",
+			method:       http.MethodGet,
+			req:          "",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "error: view unknown overlay",
+			method:       http.MethodGet,
+			req:          "/dummy",
+			wantedStatus: http.StatusNotFound,
+		},
+		{
+			name:         "success: view",
+			method:       http.MethodGet,
+			req:          "/w1-gpio",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "error: invalid action",
+			method:       http.MethodPost,
+			req:          "/load/w1-gpio",
+			wantedStatus: http.StatusNotFound,
+		},
+		{
+			name:         "error: invalid overlay name",
+			method:       http.MethodPost,
+			req:          "/add/w1%20gpio",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid section",
+			method:       http.MethodPost,
+			req:          "/add/w1-gpio?section=pi4%5D",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid params",
+			method:       http.MethodPost,
+			req:          "/add/w1-gpio?params=gpiopin%3D4%20pullup",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: update without params",
+			method:       http.MethodPost,
+			req:          "/update/w1-gpio",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: ExecuteOVL result is nil",
+			method:       http.MethodPost,
+			req:          "/add/w1-gpio?params=gpiopin%3D4",
+			executeErr:   errors.New("test error"),
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:         "success: add",
+			method:       http.MethodPost,
+			req:          "/add/w1-gpio?params=gpiopin%3D4,pullup",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "error: dtparam set without value",
+			method:       http.MethodPost,
+			req:          "/dtparam/set/spi",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "success: dtparam set",
+			method:       http.MethodPost,
+			req:          "/dtparam/set/spi?value=on",
+			wantedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			i := mock.Infos{
+				GetConfigFilesFn: func() map[string]rpi.ConfigFileDetails {
+					return map[string]rpi.ConfigFileDetails{"bootconfig": {Path: "/boot/config.txt"}}
+				},
+				ReadFileFn: func(string) ([]string, error) {
+					return []string{}, tc.readErr
+				},
+				ListFilesFn: func(string) []string {
+					return []string{}
+				},
+				DtoverlayListFn: func() []string {
+					return []string{}
+				},
+				DeviceTreeStatusFn: func(string) map[string]string {
+					return map[string]string{}
+				},
+			}
+			execute := func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+				return rpi.Action{Name: actions.Overlay, NumberOfSteps: 1}, tc.executeErr
+			}
+			ovlsys := &mocksys.Overlay{
+				ListFn: func([]string, []string, []string, []string, map[string]string) (rpi.Overlays, error) {
+					return rpi.Overlays{Overlays: []rpi.Overlay{{Name: "w1-gpio", IsFile: true}}}, nil
+				},
+				ViewFn: func(name string, overlays rpi.Overlays) (rpi.Overlay, error) {
+					if name == "w1-gpio" {
+						return overlays.Overlays[0], nil
+					}
+					return rpi.Overlay{}, echo.NewHTTPError(http.StatusNotFound, "overlay does not exist")
+				},
+				ExecuteOVLFn: execute,
+				ExecuteDTPFn: execute,
+			}
+			s := overlay.New(ovlsys, actions.New(), i)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/overlays" + tc.req
+
+			req, err := http.NewRequest(tc.method, path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
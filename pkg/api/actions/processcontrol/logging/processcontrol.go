@@ -0,0 +1,116 @@
+package processcontrol
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/processcontrol"
+)
+
+// New creates a new ProcessControl logging service instance.
+func New(svc processcontrol.Service, logger rpi.Logger) *LogService {
+	return &LogService{
+		Service: svc,
+		logger:  logger,
+	}
+}
+
+// LogService represents a ProcessControl logging service.
+type LogService struct {
+	processcontrol.Service
+	logger rpi.Logger
+}
+
+const name = "processcontrol"
+
+// ExecuteSG is the logging function attached to the processcontrol services and responsible for logging it out.
+func (ls *LogService) ExecuteSG(ctx echo.Context, pid int, signal string) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: send signal %v to process %v", signal, pid), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteSG(pid, signal)
+}
+
+// ExecuteRN is the logging function attached to the processcontrol services and responsible for logging it out.
+func (ls *LogService) ExecuteRN(ctx echo.Context, pid int, priority int) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: renice process %v to %v", pid, priority), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteRN(pid, priority)
+}
+
+// ExecuteIN is the logging function attached to the processcontrol services and responsible for logging it out.
+func (ls *LogService) ExecuteIN(ctx echo.Context, pid int, class string, level string) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: ionice process %v to class %v and level %v", pid, class, level), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteIN(pid, class, level)
+}
+
+// ExecuteAF is the logging function attached to the processcontrol services and responsible for logging it out.
+func (ls *LogService) ExecuteAF(ctx echo.Context, pid int, cpuList string) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: pin process %v to cpus %v", pid, cpuList), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteAF(pid, cpuList)
+}
+
+// ExecuteSPS is the logging function attached to the processcontrol services and responsible for logging it out.
+func (ls *LogService) ExecuteSPS(ctx echo.Context, pid int) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: suspend process %v", pid), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteSPS(pid)
+}
+
+// ExecuteRPS is the logging function attached to the processcontrol services and responsible for logging it out.
+func (ls *LogService) ExecuteRPS(ctx echo.Context, pid int) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: resume process %v", pid), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteRPS(pid)
+}
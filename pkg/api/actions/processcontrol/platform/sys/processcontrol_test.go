@@ -0,0 +1,299 @@
+package sys
+
+import (
+	"testing"
+
+	"github.com/raspibuddy/rpi/pkg/api/actions/processcontrol"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/test_utl"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteSG(t *testing.T) {
+	cases := []struct {
+		name                  string
+		plan                  map[int](map[int]actions.Func)
+		wantedDataName        string
+		wantedDataNumSteps    uint16
+		wantedDataStdOutStep1 string
+		wantedDataExitStatus  uint8
+		wantedErr             error
+	}{
+		{
+			name: "success",
+			plan: map[int](map[int]actions.Func){
+				1: {
+					1: {
+						Name:      actions.SignalProcess,
+						Reference: test_utl.FuncA,
+						Argument: []interface{}{
+							test_utl.ArgFuncA{
+								Arg0: "string0",
+								Arg1: "string1",
+							},
+						},
+					},
+				},
+			},
+			wantedDataName:        "signal_process",
+			wantedDataNumSteps:    1,
+			wantedDataStdOutStep1: "string0-string1",
+			wantedDataExitStatus:  0,
+			wantedErr:             nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := processcontrol.PCSYS(ProcessControl{})
+			result, err := s.ExecuteSG(tc.plan)
+
+			assert.Equal(t, tc.wantedDataName, result.Name)
+			assert.Equal(t, tc.wantedDataNumSteps, result.NumberOfSteps)
+			assert.Equal(t, tc.wantedDataStdOutStep1, result.Progress["1<|>1"].Stdout)
+			assert.Equal(t, tc.wantedDataExitStatus, result.ExitStatus)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestExecuteRN(t *testing.T) {
+	cases := []struct {
+		name                  string
+		plan                  map[int](map[int]actions.Func)
+		wantedDataName        string
+		wantedDataNumSteps    uint16
+		wantedDataStdOutStep1 string
+		wantedDataExitStatus  uint8
+		wantedErr             error
+	}{
+		{
+			name: "success",
+			plan: map[int](map[int]actions.Func){
+				1: {
+					1: {
+						Name:      actions.ReniceProcess,
+						Reference: test_utl.FuncA,
+						Argument: []interface{}{
+							test_utl.ArgFuncA{
+								Arg0: "string0",
+								Arg1: "string1",
+							},
+						},
+					},
+				},
+			},
+			wantedDataName:        "renice_process",
+			wantedDataNumSteps:    1,
+			wantedDataStdOutStep1: "string0-string1",
+			wantedDataExitStatus:  0,
+			wantedErr:             nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := processcontrol.PCSYS(ProcessControl{})
+			result, err := s.ExecuteRN(tc.plan)
+
+			assert.Equal(t, tc.wantedDataName, result.Name)
+			assert.Equal(t, tc.wantedDataNumSteps, result.NumberOfSteps)
+			assert.Equal(t, tc.wantedDataStdOutStep1, result.Progress["1<|>1"].Stdout)
+			assert.Equal(t, tc.wantedDataExitStatus, result.ExitStatus)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestExecuteIN(t *testing.T) {
+	cases := []struct {
+		name                  string
+		plan                  map[int](map[int]actions.Func)
+		wantedDataName        string
+		wantedDataNumSteps    uint16
+		wantedDataStdOutStep1 string
+		wantedDataExitStatus  uint8
+		wantedErr             error
+	}{
+		{
+			name: "success",
+			plan: map[int](map[int]actions.Func){
+				1: {
+					1: {
+						Name:      actions.IoniceProcess,
+						Reference: test_utl.FuncA,
+						Argument: []interface{}{
+							test_utl.ArgFuncA{
+								Arg0: "string0",
+								Arg1: "string1",
+							},
+						},
+					},
+				},
+			},
+			wantedDataName:        "ionice_process",
+			wantedDataNumSteps:    1,
+			wantedDataStdOutStep1: "string0-string1",
+			wantedDataExitStatus:  0,
+			wantedErr:             nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := processcontrol.PCSYS(ProcessControl{})
+			result, err := s.ExecuteIN(tc.plan)
+
+			assert.Equal(t, tc.wantedDataName, result.Name)
+			assert.Equal(t, tc.wantedDataNumSteps, result.NumberOfSteps)
+			assert.Equal(t, tc.wantedDataStdOutStep1, result.Progress["1<|>1"].Stdout)
+			assert.Equal(t, tc.wantedDataExitStatus, result.ExitStatus)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestExecuteAF(t *testing.T) {
+	cases := []struct {
+		name                  string
+		plan                  map[int](map[int]actions.Func)
+		wantedDataName        string
+		wantedDataNumSteps    uint16
+		wantedDataStdOutStep1 string
+		wantedDataExitStatus  uint8
+		wantedErr             error
+	}{
+		{
+			name: "success",
+			plan: map[int](map[int]actions.Func){
+				1: {
+					1: {
+						Name:      actions.SetProcessAffinity,
+						Reference: test_utl.FuncA,
+						Argument: []interface{}{
+							test_utl.ArgFuncA{
+								Arg0: "string0",
+								Arg1: "string1",
+							},
+						},
+					},
+				},
+			},
+			wantedDataName:        "set_process_affinity",
+			wantedDataNumSteps:    1,
+			wantedDataStdOutStep1: "string0-string1",
+			wantedDataExitStatus:  0,
+			wantedErr:             nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := processcontrol.PCSYS(ProcessControl{})
+			result, err := s.ExecuteAF(tc.plan)
+
+			assert.Equal(t, tc.wantedDataName, result.Name)
+			assert.Equal(t, tc.wantedDataNumSteps, result.NumberOfSteps)
+			assert.Equal(t, tc.wantedDataStdOutStep1, result.Progress["1<|>1"].Stdout)
+			assert.Equal(t, tc.wantedDataExitStatus, result.ExitStatus)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestExecuteSPS(t *testing.T) {
+	cases := []struct {
+		name                  string
+		plan                  map[int](map[int]actions.Func)
+		wantedDataName        string
+		wantedDataNumSteps    uint16
+		wantedDataStdOutStep1 string
+		wantedDataExitStatus  uint8
+		wantedErr             error
+	}{
+		{
+			name: "success",
+			plan: map[int](map[int]actions.Func){
+				1: {
+					1: {
+						Name:      actions.SuspendProcess,
+						Reference: test_utl.FuncA,
+						Argument: []interface{}{
+							test_utl.ArgFuncA{
+								Arg0: "string0",
+								Arg1: "string1",
+							},
+						},
+					},
+				},
+			},
+			wantedDataName:        "suspend_process",
+			wantedDataNumSteps:    1,
+			wantedDataStdOutStep1: "string0-string1",
+			wantedDataExitStatus:  0,
+			wantedErr:             nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := processcontrol.PCSYS(ProcessControl{})
+			result, err := s.ExecuteSPS(tc.plan)
+
+			assert.Equal(t, tc.wantedDataName, result.Name)
+			assert.Equal(t, tc.wantedDataNumSteps, result.NumberOfSteps)
+			assert.Equal(t, tc.wantedDataStdOutStep1, result.Progress["1<|>1"].Stdout)
+			assert.Equal(t, tc.wantedDataExitStatus, result.ExitStatus)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestExecuteRPS(t *testing.T) {
+	cases := []struct {
+		name                  string
+		plan                  map[int](map[int]actions.Func)
+		wantedDataName        string
+		wantedDataNumSteps    uint16
+		wantedDataStdOutStep1 string
+		wantedDataExitStatus  uint8
+		wantedErr             error
+	}{
+		{
+			name: "success",
+			plan: map[int](map[int]actions.Func){
+				1: {
+					1: {
+						Name:      actions.ResumeProcess,
+						Reference: test_utl.FuncA,
+						Argument: []interface{}{
+							test_utl.ArgFuncA{
+								Arg0: "string0",
+								Arg1: "string1",
+							},
+						},
+					},
+				},
+			},
+			wantedDataName:        "resume_process",
+			wantedDataNumSteps:    1,
+			wantedDataStdOutStep1: "string0-string1",
+			wantedDataExitStatus:  0,
+			wantedErr:             nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := processcontrol.PCSYS(ProcessControl{})
+			result, err := s.ExecuteRPS(tc.plan)
+
+			assert.Equal(t, tc.wantedDataName, result.Name)
+			assert.Equal(t, tc.wantedDataNumSteps, result.NumberOfSteps)
+			assert.Equal(t, tc.wantedDataStdOutStep1, result.Progress["1<|>1"].Stdout)
+			assert.Equal(t, tc.wantedDataExitStatus, result.ExitStatus)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package processcontrol
+
+import (
+	"fmt"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// ExecuteSG sends a signal to a process and returns an action.
+func (pc *ProcessControl) ExecuteSG(pid int, signal string) (rpi.Action, error) {
+	plan := map[int](map[int]actions.Func){
+		1: {
+			1: {
+				Name:      actions.SignalProcess,
+				Reference: pc.a.SignalProcess,
+				Argument: []interface{}{
+					actions.SP{
+						Pid:    fmt.Sprint(pid),
+						Signal: signal,
+					},
+				},
+			},
+		},
+	}
+
+	return pc.pcsys.ExecuteSG(plan)
+}
+
+// ExecuteRN changes the priority of a process and returns an action.
+func (pc *ProcessControl) ExecuteRN(pid int, priority int) (rpi.Action, error) {
+	plan := map[int](map[int]actions.Func){
+		1: {
+			1: {
+				Name:      actions.ReniceProcess,
+				Reference: pc.a.ReniceProcess,
+				Argument: []interface{}{
+					actions.RNP{
+						Pid:      fmt.Sprint(pid),
+						Priority: fmt.Sprint(priority),
+					},
+				},
+			},
+		},
+	}
+
+	return pc.pcsys.ExecuteRN(plan)
+}
+
+// ExecuteIN changes the io scheduling class and level of a process and returns an action.
+func (pc *ProcessControl) ExecuteIN(pid int, class string, level string) (rpi.Action, error) {
+	plan := map[int](map[int]actions.Func){
+		1: {
+			1: {
+				Name:      actions.IoniceProcess,
+				Reference: pc.a.IoniceProcess,
+				Argument: []interface{}{
+					actions.INP{
+						Pid:   fmt.Sprint(pid),
+						Class: class,
+						Level: level,
+					},
+				},
+			},
+		},
+	}
+
+	return pc.pcsys.ExecuteIN(plan)
+}
+
+// ExecuteAF pins a process to a list of cpus and returns an action.
+func (pc *ProcessControl) ExecuteAF(pid int, cpuList string) (rpi.Action, error) {
+	plan := map[int](map[int]actions.Func){
+		1: {
+			1: {
+				Name:      actions.SetProcessAffinity,
+				Reference: pc.a.SetProcessAffinity,
+				Argument: []interface{}{
+					actions.SPA{
+						Pid:     fmt.Sprint(pid),
+						CPUList: cpuList,
+					},
+				},
+			},
+		},
+	}
+
+	return pc.pcsys.ExecuteAF(plan)
+}
+
+// ExecuteSPS suspends a process (SIGSTOP) and returns an action.
+func (pc *ProcessControl) ExecuteSPS(pid int) (rpi.Action, error) {
+	plan := map[int](map[int]actions.Func){
+		1: {
+			1: {
+				Name:      actions.SuspendProcess,
+				Reference: pc.a.SignalProcess,
+				Argument: []interface{}{
+					actions.SP{
+						Pid:    fmt.Sprint(pid),
+						Signal: "STOP",
+					},
+				},
+			},
+		},
+	}
+
+	return pc.pcsys.ExecuteSPS(plan)
+}
+
+// ExecuteRPS resumes a suspended process (SIGCONT) and returns an action.
+func (pc *ProcessControl) ExecuteRPS(pid int) (rpi.Action, error) {
+	plan := map[int](map[int]actions.Func){
+		1: {
+			1: {
+				Name:      actions.ResumeProcess,
+				Reference: pc.a.SignalProcess,
+				Argument: []interface{}{
+					actions.SP{
+						Pid:    fmt.Sprint(pid),
+						Signal: "CONT",
+					},
+				},
+			},
+		},
+	}
+
+	return pc.pcsys.ExecuteRPS(plan)
+}
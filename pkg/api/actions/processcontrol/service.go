@@ -0,0 +1,45 @@
+package processcontrol
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// Service represents all ProcessControl application services.
+type Service interface {
+	ExecuteSG(int, string) (rpi.Action, error)
+	ExecuteRN(int, int) (rpi.Action, error)
+	ExecuteIN(int, string, string) (rpi.Action, error)
+	ExecuteAF(int, string) (rpi.Action, error)
+	ExecuteSPS(int) (rpi.Action, error)
+	ExecuteRPS(int) (rpi.Action, error)
+}
+
+// ProcessControl represents a ProcessControl application service.
+type ProcessControl struct {
+	pcsys PCSYS
+	a     Actions
+}
+
+// PCSYS represents a ProcessControl repository service.
+type PCSYS interface {
+	ExecuteSG(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteRN(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteIN(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteAF(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteSPS(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteRPS(map[int](map[int]actions.Func)) (rpi.Action, error)
+}
+
+// Actions represents the actions interface
+type Actions interface {
+	SignalProcess(interface{}) (rpi.Exec, error)
+	ReniceProcess(interface{}) (rpi.Exec, error)
+	IoniceProcess(interface{}) (rpi.Exec, error)
+	SetProcessAffinity(interface{}) (rpi.Exec, error)
+}
+
+// New creates a PCSYS application service instance.
+func New(pcsys PCSYS, a Actions) *ProcessControl {
+	return &ProcessControl{pcsys: pcsys, a: a}
+}
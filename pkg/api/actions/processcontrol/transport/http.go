@@ -0,0 +1,145 @@
+package transport
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi/pkg/api/actions/processcontrol"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// HTTP is a struct implementing a core application service.
+type HTTP struct {
+	svc processcontrol.Service
+}
+
+// NewHTTP creates new processcontrol http service
+func NewHTTP(svc processcontrol.Service, r *echo.Group) {
+	h := HTTP{svc}
+	cr := r.Group("/processcontrol")
+	cr.POST("/signal/:pid", h.signal)
+	cr.POST("/renice/:pid", h.renice)
+	cr.POST("/ionice/:pid", h.ionice)
+	cr.POST("/affinity/:pid", h.affinity)
+	cr.POST("/suspend/:pid", h.suspend)
+	cr.POST("/resume/:pid", h.resume)
+}
+
+// pidParam returns the pid of the path, which must target a single process.
+// pid 0 and negative pids would target the api itself, a process group or every process.
+func pidParam(ctx echo.Context) (int, error) {
+	pid, err := strconv.Atoi(ctx.Param("pid"))
+	if err != nil || pid <= 0 {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid pid - should be an integer greater than 0")
+	}
+	return pid, nil
+}
+
+func (h *HTTP) signal(ctx echo.Context) error {
+	pid, err := pidParam(ctx)
+	if err != nil {
+		return err
+	}
+
+	signal := strings.TrimPrefix(strings.ToUpper(ctx.QueryParam("signal")), "SIG")
+	if _, ok := actions.Signals[signal]; !ok {
+		return echo.NewHTTPError(http.StatusNotFound, "Not found - signal is null or not supported")
+	}
+
+	result, err := h.svc.ExecuteSG(pid, signal)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) renice(ctx echo.Context) error {
+	pid, err := pidParam(ctx)
+	if err != nil {
+		return err
+	}
+
+	priority, err := strconv.Atoi(ctx.QueryParam("priority"))
+	if err != nil || priority < -20 || priority > 19 {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid priority - should be an integer between -20 and 19")
+	}
+
+	result, err := h.svc.ExecuteRN(pid, priority)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) ionice(ctx echo.Context) error {
+	pid, err := pidParam(ctx)
+	if err != nil {
+		return err
+	}
+
+	class := ctx.QueryParam("class")
+	if _, ok := actions.IoniceClasses[class]; !ok {
+		return echo.NewHTTPError(http.StatusNotFound, "Not found - class should be realtime, best-effort or idle")
+	}
+
+	level := ctx.QueryParam("level")
+	if class != "idle" {
+		if l, err := strconv.Atoi(level); err != nil || l < 0 || l > 7 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid level - should be an integer between 0 and 7")
+		}
+	}
+
+	result, err := h.svc.ExecuteIN(pid, class, level)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) affinity(ctx echo.Context) error {
+	pid, err := pidParam(ctx)
+	if err != nil {
+		return err
+	}
+
+	cpus := ctx.QueryParam("cpus")
+	re := regexp.MustCompile(actions.CPUListRegex)
+	if !re.MatchString(cpus) {
+		return echo.NewHTTPError(http.StatusNotFound, "Not found - cpus is null or badly formatted")
+	}
+
+	result, err := h.svc.ExecuteAF(pid, cpus)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) suspend(ctx echo.Context) error {
+	pid, err := pidParam(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.svc.ExecuteSPS(pid)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) resume(ctx echo.Context) error {
+	pid, err := pidParam(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.svc.ExecuteRPS(pid)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,487 @@
+package transport_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/processcontrol"
+	"github.com/raspibuddy/rpi/pkg/api/actions/processcontrol/transport"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/raspibuddy/rpi/pkg/utl/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteSG(t *testing.T) {
+	cases := []struct {
+		name         string
+		req          string
+		pcsys        *mocksys.Action
+		wantedStatus int
+	}{
+		{
+			name:         "error: invalid pid",
+			req:          "1A2B?signal=HUP",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: pid of every process",
+			req:          "-1?signal=HUP",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: pid of the api",
+			req:          "0?signal=HUP",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid signal",
+			req:          "1234?signal=FOO",
+			wantedStatus: http.StatusNotFound,
+		},
+		{
+			name: "error: ExecuteSG result is nil",
+			req:  "1234?signal=HUP",
+			pcsys: &mocksys.Action{
+				ExecuteSGFn: func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+					return rpi.Action{}, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:         "success",
+			req:          "1234?signal=HUP",
+			wantedStatus: http.StatusOK,
+			pcsys: &mocksys.Action{
+				ExecuteSGFn: func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+					return rpi.Action{
+						Name:          actions.SignalProcess,
+						NumberOfSteps: 1,
+						StartTime:     uint64(time.Now().Unix()),
+						EndTime:       uint64(time.Now().Unix()),
+						ExitStatus:    0,
+					}, nil
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			a := actions.New()
+			s := processcontrol.New(tc.pcsys, a)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/processcontrol/signal/" + tc.req
+
+			res, err := http.Post(path, "application/json", bytes.NewBufferString(tc.req))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestExecuteRN(t *testing.T) {
+	cases := []struct {
+		name         string
+		req          string
+		pcsys        *mocksys.Action
+		wantedStatus int
+	}{
+		{
+			name:         "error: invalid pid",
+			req:          "1A2B?priority=10",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: pid of every process",
+			req:          "-1?priority=10",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: pid of the api",
+			req:          "0?priority=10",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: priority out of range",
+			req:          "1234?priority=42",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error: ExecuteRN result is nil",
+			req:  "1234?priority=10",
+			pcsys: &mocksys.Action{
+				ExecuteRNFn: func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+					return rpi.Action{}, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:         "success",
+			req:          "1234?priority=10",
+			wantedStatus: http.StatusOK,
+			pcsys: &mocksys.Action{
+				ExecuteRNFn: func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+					return rpi.Action{
+						Name:          actions.ReniceProcess,
+						NumberOfSteps: 1,
+						StartTime:     uint64(time.Now().Unix()),
+						EndTime:       uint64(time.Now().Unix()),
+						ExitStatus:    0,
+					}, nil
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			a := actions.New()
+			s := processcontrol.New(tc.pcsys, a)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/processcontrol/renice/" + tc.req
+
+			res, err := http.Post(path, "application/json", bytes.NewBufferString(tc.req))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestExecuteIN(t *testing.T) {
+	cases := []struct {
+		name         string
+		req          string
+		pcsys        *mocksys.Action
+		wantedStatus int
+	}{
+		{
+			name:         "error: invalid pid",
+			req:          "1A2B?class=idle",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: pid of every process",
+			req:          "-1?class=idle",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: pid of the api",
+			req:          "0?class=idle",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid class",
+			req:          "1234?class=dummy&level=1",
+			wantedStatus: http.StatusNotFound,
+		},
+		{
+			name:         "error: invalid level",
+			req:          "1234?class=realtime&level=8",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error: ExecuteIN result is nil",
+			req:  "1234?class=best-effort&level=7",
+			pcsys: &mocksys.Action{
+				ExecuteINFn: func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+					return rpi.Action{}, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:         "success",
+			req:          "1234?class=best-effort&level=7",
+			wantedStatus: http.StatusOK,
+			pcsys: &mocksys.Action{
+				ExecuteINFn: func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+					return rpi.Action{
+						Name:          actions.IoniceProcess,
+						NumberOfSteps: 1,
+						StartTime:     uint64(time.Now().Unix()),
+						EndTime:       uint64(time.Now().Unix()),
+						ExitStatus:    0,
+					}, nil
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			a := actions.New()
+			s := processcontrol.New(tc.pcsys, a)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/processcontrol/ionice/" + tc.req
+
+			res, err := http.Post(path, "application/json", bytes.NewBufferString(tc.req))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestExecuteAF(t *testing.T) {
+	cases := []struct {
+		name         string
+		req          string
+		pcsys        *mocksys.Action
+		wantedStatus int
+	}{
+		{
+			name:         "error: invalid pid",
+			req:          "1A2B?cpus=0",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: pid of every process",
+			req:          "-1?cpus=0",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: pid of the api",
+			req:          "0?cpus=0",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid cpus",
+			req:          "1234?cpus=a-b",
+			wantedStatus: http.StatusNotFound,
+		},
+		{
+			name: "error: ExecuteAF result is nil",
+			req:  "1234?cpus=0,2-3",
+			pcsys: &mocksys.Action{
+				ExecuteAFFn: func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+					return rpi.Action{}, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:         "success",
+			req:          "1234?cpus=0,2-3",
+			wantedStatus: http.StatusOK,
+			pcsys: &mocksys.Action{
+				ExecuteAFFn: func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+					return rpi.Action{
+						Name:          actions.SetProcessAffinity,
+						NumberOfSteps: 1,
+						StartTime:     uint64(time.Now().Unix()),
+						EndTime:       uint64(time.Now().Unix()),
+						ExitStatus:    0,
+					}, nil
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			a := actions.New()
+			s := processcontrol.New(tc.pcsys, a)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/processcontrol/affinity/" + tc.req
+
+			res, err := http.Post(path, "application/json", bytes.NewBufferString(tc.req))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestExecuteSPS(t *testing.T) {
+	cases := []struct {
+		name         string
+		req          string
+		pcsys        *mocksys.Action
+		wantedStatus int
+	}{
+		{
+			name:         "error: invalid pid",
+			req:          "1A2B",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: pid of every process",
+			req:          "-1",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: pid of the api",
+			req:          "0",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error: ExecuteSPS result is nil",
+			req:  "1234",
+			pcsys: &mocksys.Action{
+				ExecuteSPSFn: func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+					return rpi.Action{}, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:         "success",
+			req:          "1234",
+			wantedStatus: http.StatusOK,
+			pcsys: &mocksys.Action{
+				ExecuteSPSFn: func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+					return rpi.Action{
+						Name:          actions.SuspendProcess,
+						NumberOfSteps: 1,
+						StartTime:     uint64(time.Now().Unix()),
+						EndTime:       uint64(time.Now().Unix()),
+						ExitStatus:    0,
+					}, nil
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			a := actions.New()
+			s := processcontrol.New(tc.pcsys, a)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/processcontrol/suspend/" + tc.req
+
+			res, err := http.Post(path, "application/json", bytes.NewBufferString(tc.req))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestExecuteRPS(t *testing.T) {
+	cases := []struct {
+		name         string
+		req          string
+		pcsys        *mocksys.Action
+		wantedStatus int
+	}{
+		{
+			name:         "error: invalid pid",
+			req:          "1A2B",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: pid of every process",
+			req:          "-1",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: pid of the api",
+			req:          "0",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error: ExecuteRPS result is nil",
+			req:  "1234",
+			pcsys: &mocksys.Action{
+				ExecuteRPSFn: func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+					return rpi.Action{}, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:         "success",
+			req:          "1234",
+			wantedStatus: http.StatusOK,
+			pcsys: &mocksys.Action{
+				ExecuteRPSFn: func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+					return rpi.Action{
+						Name:          actions.ResumeProcess,
+						NumberOfSteps: 1,
+						StartTime:     uint64(time.Now().Unix()),
+						EndTime:       uint64(time.Now().Unix()),
+						ExitStatus:    0,
+					}, nil
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			a := actions.New()
+			s := processcontrol.New(tc.pcsys, a)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/processcontrol/resume/" + tc.req
+
+			res, err := http.Post(path, "application/json", bytes.NewBufferString(tc.req))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
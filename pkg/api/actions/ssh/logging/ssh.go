@@ -0,0 +1,116 @@
+package ssh
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/ssh"
+)
+
+// New creates a new SSH logging service instance.
+func New(svc ssh.Service, logger rpi.Logger) *LogService {
+	return &LogService{
+		Service: svc,
+		logger:  logger,
+	}
+}
+
+// LogService represents a SSH logging service.
+type LogService struct {
+	ssh.Service
+	logger rpi.Logger
+}
+
+const name = "ssh"
+
+// List is the logging function attached to the List ssh services and responsible for logging it out.
+func (ls *LogService) List(ctx echo.Context) (resp rpi.SSH, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, "request: list sshd options and host keys", err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.List()
+}
+
+// ListAK is the logging function attached to the ListAK ssh services and responsible for logging it out.
+func (ls *LogService) ListAK(ctx echo.Context, username string) (resp []rpi.SSHKey, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: list authorized keys of %v", username), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ListAK(username)
+}
+
+// ExecuteAAK is the logging function attached to the ExecuteAAK ssh services and responsible for logging it out.
+func (ls *LogService) ExecuteAAK(ctx echo.Context, username string, key string, fingerprint string) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: add authorized key to %v", username), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteAAK(username, key, fingerprint)
+}
+
+// ExecuteRAK is the logging function attached to the ExecuteRAK ssh services and responsible for logging it out.
+func (ls *LogService) ExecuteRAK(ctx echo.Context, username string, id int) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: remove authorized key %v of %v", id, username), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteRAK(username, id)
+}
+
+// ExecuteSO is the logging function attached to the ExecuteSO ssh services and responsible for logging it out.
+func (ls *LogService) ExecuteSO(ctx echo.Context, passwordAuthentication string, permitRootLogin string, port int) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: set sshd options passwordAuthentication=%v permitRootLogin=%v port=%v", passwordAuthentication, permitRootLogin, port), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteSO(passwordAuthentication, permitRootLogin, port)
+}
+
+// ExecuteRHK is the logging function attached to the ExecuteRHK ssh services and responsible for logging it out.
+func (ls *LogService) ExecuteRHK(ctx echo.Context) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, "request: regenerate ssh host keys", err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteRHK()
+}
@@ -0,0 +1,91 @@
+package sys
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/sshconf"
+)
+
+// SSH represents an empty SSH entity on the current system.
+type SSH struct{}
+
+// List returns the options printed by 'sshd -T' (ex: permitrootlogin without-password) along with the host keys.
+// The first port is kept when sshd listens on several.
+func (s SSH) List(options []string, hostKeys []string) (rpi.SSH, error) {
+	result := rpi.SSH{HostKeys: keys(hostKeys)}
+
+	for _, line := range options {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		switch fields[0] {
+		case "passwordauthentication":
+			result.PasswordAuthentication = fields[1] == "yes"
+		case "permitrootlogin":
+			// without-password is the former name of prohibit-password
+			result.PermitRootLogin = strings.Replace(fields[1], "without-password", "prohibit-password", 1)
+		case "port":
+			if result.Port == 0 {
+				result.Port, _ = strconv.Atoi(fields[1])
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// ListAK returns the keys of the lines of an authorized_keys file
+func (s SSH) ListAK(lines []string) ([]rpi.SSHKey, error) {
+	return keys(lines), nil
+}
+
+// ExecuteAK returns an action response after adding or removing an authorized key
+func (s SSH) ExecuteAK(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return execute(actions.AuthorizedKeys, plan)
+}
+
+// ExecuteSO returns an action response after setting the sshd options
+func (s SSH) ExecuteSO(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return execute(actions.SSHOptions, plan)
+}
+
+// ExecuteRHK returns an action response after regenerating the host keys
+func (s SSH) ExecuteRHK(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return execute(actions.RegenerateHostKeys, plan)
+}
+
+func execute(name string, plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	actionStartTime := uint64(time.Now().Unix())
+	progressInit := actions.FlattenPlan(plan)
+	progress, exitStatus := actions.ExecutePlan(plan, progressInit)
+
+	return rpi.Action{
+		Name:          name,
+		NumberOfSteps: uint16(len(progressInit)),
+		Progress:      progress,
+		ExitStatus:    exitStatus,
+		StartTime:     actionStartTime,
+		EndTime:       uint64(time.Now().Unix()),
+	}, nil
+}
+
+// keys returns the public keys of lines
+func keys(lines []string) []rpi.SSHKey {
+	result := []rpi.SSHKey{}
+	for _, k := range sshconf.Keys(lines) {
+		result = append(result, rpi.SSHKey{
+			ID:          k.ID,
+			Type:        k.Type,
+			Bits:        k.Bits,
+			Fingerprint: k.Fingerprint,
+			Comment:     k.Comment,
+		})
+	}
+	return result
+}
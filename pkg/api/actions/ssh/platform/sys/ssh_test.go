@@ -0,0 +1,97 @@
+package sys_test
+
+import (
+	"testing"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/ssh/platform/sys"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/stretchr/testify/assert"
+)
+
+const ed25519 = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIHk5jHIU3SV09U8gHZ6MtUb1NLPfJ1m4aE5l7IwfEyDd root@raspberrypi"
+
+func TestList(t *testing.T) {
+	cases := []struct {
+		name         string
+		options      []string
+		hostKeys     []string
+		wantedResult rpi.SSH
+	}{
+		{
+			name:         "no options",
+			options:      []string{},
+			hostKeys:     []string{},
+			wantedResult: rpi.SSH{HostKeys: []rpi.SSHKey{}},
+		},
+		{
+			name: "success",
+			options: []string{
+				"port 2222",
+				"port 22",
+				"addressfamily any",
+				"permitrootlogin without-password",
+				"passwordauthentication yes",
+				"usepam yes",
+			},
+			hostKeys: []string{ed25519, "invalid"},
+			wantedResult: rpi.SSH{
+				PasswordAuthentication: true,
+				PermitRootLogin:        "prohibit-password",
+				Port:                   2222,
+				HostKeys: []rpi.SSHKey{
+					{ID: 0, Type: "ssh-ed25519", Bits: 256, Fingerprint: "SHA256:M5WiaiGAUUDnyQ11r6OesYH3EZ0nXJ7/oRjX4pp8zhA", Comment: "root@raspberrypi"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := sys.SSH{}
+			result, err := s.List(tc.options, tc.hostKeys)
+			assert.Equal(t, tc.wantedResult, result)
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestListAK(t *testing.T) {
+	s := sys.SSH{}
+	result, err := s.ListAK([]string{"# laptop", ed25519})
+	assert.Equal(t, []rpi.SSHKey{
+		{ID: 0, Type: "ssh-ed25519", Bits: 256, Fingerprint: "SHA256:M5WiaiGAUUDnyQ11r6OesYH3EZ0nXJ7/oRjX4pp8zhA", Comment: "root@raspberrypi"},
+	}, result)
+	assert.Nil(t, err)
+}
+
+func TestExecute(t *testing.T) {
+	s := sys.SSH{}
+	cases := []struct {
+		name       string
+		execute    func(map[int](map[int]actions.Func)) (rpi.Action, error)
+		wantedName string
+	}{
+		{name: "authorized keys", execute: s.ExecuteAK, wantedName: actions.AuthorizedKeys},
+		{name: "options", execute: s.ExecuteSO, wantedName: actions.SSHOptions},
+		{name: "host keys", execute: s.ExecuteRHK, wantedName: actions.RegenerateHostKeys},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.execute(map[int](map[int]actions.Func){
+				1: {
+					1: {
+						Name:      "funcA",
+						Reference: func(arg interface{}) (rpi.Exec, error) { return rpi.Exec{ExitStatus: 1}, nil },
+						Argument:  []interface{}{actions.EBC{}},
+					},
+				},
+			})
+			assert.Equal(t, tc.wantedName, result.Name)
+			assert.Equal(t, uint16(1), result.NumberOfSteps)
+			assert.Equal(t, uint8(1), result.ExitStatus)
+			assert.Nil(t, err)
+		})
+	}
+}
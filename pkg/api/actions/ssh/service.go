@@ -0,0 +1,58 @@
+package ssh
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// Service represents all SSH application services.
+type Service interface {
+	List() (rpi.SSH, error)
+	ListAK(string) ([]rpi.SSHKey, error)
+	ExecuteAAK(string, string, string) (rpi.Action, error)
+	ExecuteRAK(string, int) (rpi.Action, error)
+	ExecuteSO(string, string, int) (rpi.Action, error)
+	ExecuteRHK() (rpi.Action, error)
+}
+
+// SSH represents a SSH application service.
+type SSH struct {
+	sshsys SSHSYS
+	a      Actions
+	i      Infos
+	hu     HumanUser
+}
+
+// SSHSYS represents a SSH repository service.
+type SSHSYS interface {
+	List([]string, []string) (rpi.SSH, error)
+	ListAK([]string) ([]rpi.SSHKey, error)
+	ExecuteAK(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteSO(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteRHK(map[int](map[int]actions.Func)) (rpi.Action, error)
+}
+
+// Actions represents the actions interface
+type Actions interface {
+	RestoreFile(interface{}) (rpi.Exec, error)
+	ExecuteBashCommand(interface{}) (rpi.Exec, error)
+}
+
+// Infos represents the infos interface
+type Infos interface {
+	ReadFile(string) ([]string, error)
+	IsFileExists(string) bool
+	IsSymlink(string) bool
+	ListFiles(string) []string
+	SshdConfig() []string
+}
+
+// HumanUser represents the human user interface
+type HumanUser interface {
+	List() ([]rpi.HumanUser, error)
+}
+
+// New creates a SSH application service instance.
+func New(sshsys SSHSYS, a Actions, i Infos, hu HumanUser) *SSH {
+	return &SSH{sshsys: sshsys, a: a, i: i, hu: hu}
+}
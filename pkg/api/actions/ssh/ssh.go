@@ -0,0 +1,302 @@
+package ssh
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/constants"
+	"github.com/raspibuddy/rpi/pkg/utl/sshconf"
+)
+
+const (
+	// MinRSABits is the smallest size accepted for a rsa key
+	MinRSABits = 2048
+
+	// Reload is the command applying the sshd options and host keys, starting no stopped sshd
+	Reload = "systemctl try-reload-or-restart ssh.service"
+)
+
+var (
+	// PermitRootLogin lists the values of the PermitRootLogin option
+	PermitRootLogin = []string{"yes", "no", "prohibit-password", "forced-commands-only"}
+
+	includeRegex = regexp.MustCompile(`^\s*(?i:include)\s+` + regexp.QuoteMeta(constants.SSHDCONFIGDIR) + `/`)
+)
+
+// List returns the options used by sshd along with the host keys
+func (s *SSH) List() (rpi.SSH, error) {
+	options := s.i.SshdConfig()
+	if len(options) == 0 {
+		return rpi.SSH{}, echo.NewHTTPError(http.StatusInternalServerError, "could not read the sshd options")
+	}
+
+	hostKeys := []string{}
+	for _, path := range s.i.ListFiles(constants.SSHHOSTKEYS) {
+		if lines, err := s.i.ReadFile(path); err == nil {
+			hostKeys = append(hostKeys, lines...)
+		}
+	}
+
+	return s.sshsys.List(options, hostKeys)
+}
+
+// ListAK returns the keys authorized to log in as a human user
+func (s *SSH) ListAK(username string) ([]rpi.SSHKey, error) {
+	user, err := s.user(username)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := s.authorizedKeys(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.sshsys.ListAK(lines)
+}
+
+// ExecuteAAK authorizes a public key to log in as a human user, then returns an action.
+// The key must be an ed25519, ecdsa or rsa key of 2048 bits at least. When a fingerprint is given, it must be the one of the key.
+func (s *SSH) ExecuteAAK(username string, key string, fingerprint string) (rpi.Action, error) {
+	user, err := s.user(username)
+	if err != nil {
+		return rpi.Action{}, err
+	}
+
+	key = strings.TrimSpace(key)
+	k, err := sshconf.ParseKey(key)
+	if err != nil || strings.ContainsAny(key, "\r\n") {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid key - should be a public key in the authorized_keys format (ex: ssh-ed25519 AAAA... user@host)")
+	}
+	if !contains(sshconf.Types, k.Type) {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to an unsupported key type - should be one of %v", strings.Join(sshconf.Types, ", ")))
+	}
+	if k.Type == "ssh-rsa" && k.Bits < MinRSABits {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to a weak key - rsa keys should have %v bits at least", MinRSABits))
+	}
+	if fingerprint != "" && strings.TrimPrefix(fingerprint, "SHA256:") != strings.TrimPrefix(k.Fingerprint, "SHA256:") {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to an invalid fingerprint - the fingerprint of the key is %v", k.Fingerprint))
+	}
+
+	lines, err := s.authorizedKeys(user)
+	if err != nil {
+		return rpi.Action{}, err
+	}
+	for _, existing := range sshconf.Keys(lines) {
+		if existing.Fingerprint == k.Fingerprint {
+			return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to an existing key - %v", existing.ID))
+		}
+	}
+
+	return s.sshsys.ExecuteAK(s.writeAuthorizedKeys(user, append(lines, key)))
+}
+
+// ExecuteRAK removes a key authorized to log in as a human user, then returns an action
+func (s *SSH) ExecuteRAK(username string, id int) (rpi.Action, error) {
+	user, err := s.user(username)
+	if err != nil {
+		return rpi.Action{}, err
+	}
+
+	lines, err := s.authorizedKeys(user)
+	if err != nil {
+		return rpi.Action{}, err
+	}
+
+	lines, isFound := sshconf.RemoveKey(lines, id)
+	if !isFound {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Not found - key %v does not exist", id))
+	}
+
+	return s.sshsys.ExecuteAK(s.writeAuthorizedKeys(user, lines))
+}
+
+// ExecuteSO sets the PasswordAuthentication, PermitRootLogin and Port options of sshd, then returns an action.
+// Empty values and a zero port are left unchanged. The options are written to a file of sshd_config.d
+// when sshd_config includes them, to sshd_config otherwise. The file is put back when 'sshd -t' rejects it.
+func (s *SSH) ExecuteSO(passwordAuthentication string, permitRootLogin string, port int) (rpi.Action, error) {
+	if passwordAuthentication != "" && passwordAuthentication != "yes" && passwordAuthentication != "no" {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid passwordAuthentication - should be yes or no")
+	}
+	if permitRootLogin != "" && !contains(PermitRootLogin, permitRootLogin) {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to an invalid permitRootLogin - should be one of %v", strings.Join(PermitRootLogin, ", ")))
+	}
+	if port < 0 || port > 65535 {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid port - should be 1 to 65535")
+	}
+	if passwordAuthentication == "" && permitRootLogin == "" && port == 0 {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to missing options - should set passwordAuthentication, permitRootLogin or port")
+	}
+
+	config, err := s.i.ReadFile(constants.SSHDCONFIG)
+	if err != nil {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusInternalServerError, "could not read sshd_config")
+	}
+
+	path := constants.SSHDCONFIG
+	lines := config
+	for _, line := range config {
+		if includeRegex.MatchString(line) {
+			path = constants.SSHDRASPIBUDDY
+			lines = []string{"# managed by raspibuddy"}
+		}
+	}
+
+	isNew := !s.i.IsFileExists(path)
+	if path == constants.SSHDRASPIBUDDY && !isNew {
+		if lines, err = s.i.ReadFile(path); err != nil {
+			return rpi.Action{}, echo.NewHTTPError(http.StatusInternalServerError, "could not read the sshd options")
+		}
+	}
+
+	if passwordAuthentication != "" {
+		lines = sshconf.SetOption(lines, "PasswordAuthentication", passwordAuthentication)
+	}
+	if permitRootLogin != "" {
+		lines = sshconf.SetOption(lines, "PermitRootLogin", permitRootLogin)
+	}
+	if port != 0 {
+		lines = sshconf.SetOption(lines, "Port", fmt.Sprint(port))
+	}
+
+	file := actions.RF{
+		Path:    path,
+		Backup:  filepath.Join(constants.SSH, filepath.Base(path)),
+		Content: []byte(strings.Join(lines, "\n") + "\n"),
+		Mode:    0644,
+	}
+
+	restore := fmt.Sprintf("cp %v %v", file.Backup, file.Path)
+	if isNew {
+		restore = fmt.Sprintf("rm -f %v", file.Path)
+	}
+
+	plan := s.file(file)
+
+	// sshd listening on every port it reads, the ones of sshd_config are commented
+	if port != 0 && path == constants.SSHDRASPIBUDDY {
+		if commented, isCommented := sshconf.CommentOption(config, "Port"); isCommented {
+			sshdConfig := actions.RF{
+				Path:    constants.SSHDCONFIG,
+				Backup:  filepath.Join(constants.SSH, filepath.Base(constants.SSHDCONFIG)),
+				Content: []byte(strings.Join(commented, "\n") + "\n"),
+				Mode:    0644,
+			}
+			plan[len(plan)+1] = s.file(sshdConfig)[1]
+			restore = fmt.Sprintf("%v; cp %v %v", restore, sshdConfig.Backup, sshdConfig.Path)
+		}
+	}
+
+	s.commands(plan, fmt.Sprintf("sshd -t || { %v; exit 1; }", restore), Reload)
+
+	return s.sshsys.ExecuteSO(plan)
+}
+
+// ExecuteRHK generates new host keys replacing the current ones, then returns an action.
+// The keys are generated aside first, so that sshd is never left without host keys.
+func (s *SSH) ExecuteRHK() (rpi.Action, error) {
+	plan := s.commands(
+		map[int](map[int]actions.Func){},
+		`d=$(mktemp -d) && mkdir -p "$d/etc/ssh" && ssh-keygen -A -f "$d" && mv -f "$d"/etc/ssh/ssh_host_* /etc/ssh/ && rm -rf "$d"`,
+		Reload,
+	)
+
+	return s.sshsys.ExecuteRHK(plan)
+}
+
+// user returns the human user having a username
+func (s *SSH) user(username string) (rpi.HumanUser, error) {
+	users, err := s.hu.List()
+	if err != nil {
+		return rpi.HumanUser{}, err
+	}
+
+	for _, u := range users {
+		if u.Username == username {
+			return u, nil
+		}
+	}
+
+	return rpi.HumanUser{}, echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Not found - %v is not a human user", username))
+}
+
+// authorizedKeys returns the lines of the authorized_keys file of a user, empty when missing.
+// ~/.ssh and authorized_keys being owned by the user, they are refused when they are symbolic links,
+// which could point to any file of the device.
+func (s *SSH) authorizedKeys(user rpi.HumanUser) ([]string, error) {
+	dir := filepath.Join(user.HomeDirectory, ".ssh")
+	path := filepath.Join(dir, "authorized_keys")
+	if s.i.IsSymlink(dir) || s.i.IsSymlink(path) {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to a symbolic link - %v and %v should not be symbolic links", dir, path))
+	}
+	if !s.i.IsFileExists(path) {
+		return []string{}, nil
+	}
+
+	lines, err := s.i.ReadFile(path)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "could not read the authorized keys")
+	}
+	return lines, nil
+}
+
+// writeAuthorizedKeys returns a plan writing the authorized_keys file of a user.
+// The file is written by the user, not root, so that a link put in place of ~/.ssh or authorized_keys
+// in the meantime reaches no file the user could not already write. It is replaced at once by a rename.
+func (s *SSH) writeAuthorizedKeys(user rpi.HumanUser, lines []string) map[int](map[int]actions.Func) {
+	content := base64.StdEncoding.EncodeToString([]byte(strings.Join(lines, "\n") + "\n"))
+
+	return s.commands(
+		map[int](map[int]actions.Func){},
+		fmt.Sprintf(`runuser -u '%v' -- sh -c 'umask 077 && mkdir -p "$1" && chmod 700 "$1" && printf %%s "$2" | base64 -d > "$1/authorized_keys.tmp" && mv -f "$1/authorized_keys.tmp" "$1/authorized_keys"' sh '%v' '%v'`,
+			user.Username, filepath.Join(user.HomeDirectory, ".ssh"), content),
+	)
+}
+
+// file returns a plan writing a file
+func (s *SSH) file(file actions.RF) map[int](map[int]actions.Func) {
+	return map[int](map[int]actions.Func){
+		1: {
+			1: {
+				Name:      actions.RestoreFile,
+				Reference: s.a.RestoreFile,
+				Argument:  []interface{}{file},
+			},
+		},
+	}
+}
+
+// commands appends a step to a plan for each command, in order
+func (s *SSH) commands(plan map[int](map[int]actions.Func), commands ...string) map[int](map[int]actions.Func) {
+	for _, c := range commands {
+		plan[len(plan)+1] = map[int]actions.Func{
+			1: {
+				Name:      actions.ExecuteBashCommand,
+				Reference: s.a.ExecuteBashCommand,
+				Argument: []interface{}{
+					actions.EBC{
+						Command: c,
+					},
+				},
+			},
+		}
+	}
+
+	return plan
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,331 @@
+package ssh_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/ssh"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/constants"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	ed25519 = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIHk5jHIU3SV09U8gHZ6MtUb1NLPfJ1m4aE5l7IwfEyDd pi@raspberrypi"
+	ecdsa   = "ecdsa-sha2-nistp384 AAAAE2VjZHNhLXNoYTItbmlzdHAzODQAAAAIbmlzdHAzODQAAABhBCwNMK/sj3KIjPDq1801Np3PaJoPRDURrdgdQTgBd68xO/AzLezK1tINllE4vySvF89YxzeIqbePRaLlFQ70zKr3a3b/g2JIZ4JKnyuO7MNgCk6/Lm8gs9zfqbUHY641DQ== laptop"
+	rsa     = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAAAgQCo4dGs0c6edG0hcoIt4fd7Nszp+9X8H6SfSmqzO5TaSomqA1IKBHarL1jXr3zdrwJvMbBPCauQIY8AtPxCPiJ6ZPMt8nuThfFl+P7e7cpEZik4OKRhc/ZlJlfz7I2LpN7rHKJnFePCCBNoY1bR/sug8ysg+IaPl6o/IZUIeEA6ew== old"
+	dsa     = "ssh-dss AAAAB3NzaC1kc3MAAACBAKM6O3Gn9ivXTJY/c8o6l6sdwryO07XFWdscailwPwNN3rV0DiRKoDh0Mb8sya0TaQ/zp6P+YxmupEGx4pyIVOfOc9LS7V5Ghu7Oq2MZn59IOz+8rgXSXjuhA1J9D7Y3/urJh6WUILeub8Tt3iaoiQuOEzMMMXMpY3f3W1qzM+dPAAAAFQDgumpeNMrUTSl49R5k+6N5fDcdsQAAAIAMLLuKDPX5/hOKOXL/n2d8M6JFT/STgehBVk7OqfMaFxXNcRNYu2WEHyDcHOfPOXUlhikbdoTq+Ld0xw7g15d0OXj6AJ59FZaimAAiBPId2fBKl8YAbHsg6npI1X4rX/+as2ErkbECsYZGDBdIHPpU+MK5s5+77GJyRJs92pcD+AAAAIAaEJgMIwzHyvhAJj13vDHHjWMGtaRACO8uw2N2GGZAJ2nWJmdjpOTLhUeeVVyXx9MDqVuQGv8OSHcxaQdu2/IhuWeWTByTCtG7hwytbrjRBnNI0mDnwzdChVXJUYufj2RkZCVoJNFFsQi4puUVgmiK4IgxeIOyjE3eCxoC0Wy3Dg== root@vm"
+)
+
+var humanUser = &mock.HumanUser{
+	ListFn: func() ([]rpi.HumanUser, error) {
+		return []rpi.HumanUser{{Username: "pi", Uid: 1000, Gid: 1000, HomeDirectory: "/home/pi"}}, nil
+	},
+}
+
+func infos(files map[string][]string) *mock.Infos {
+	return &mock.Infos{
+		ReadFileFn: func(path string) ([]string, error) {
+			if lines, ok := files[path]; ok {
+				return lines, nil
+			}
+			return nil, errors.New("test error")
+		},
+		IsFileExistsFn: func(path string) bool {
+			_, ok := files[path]
+			return ok
+		},
+		IsSymlinkFn: func(string) bool {
+			return false
+		},
+		ListFilesFn: func(string) []string {
+			return []string{"/etc/ssh/ssh_host_ed25519_key.pub"}
+		},
+		SshdConfigFn: func() []string {
+			return []string{"port 22"}
+		},
+	}
+}
+
+func sshsys(plan *map[int](map[int]actions.Func), args *[]string) *mocksys.SSH {
+	execute := func(p map[int](map[int]actions.Func)) (rpi.Action, error) {
+		*plan = p
+		return rpi.Action{NumberOfSteps: uint16(len(p))}, nil
+	}
+
+	return &mocksys.SSH{
+		ListFn: func(options []string, hostKeys []string) (rpi.SSH, error) {
+			*args = append(options, hostKeys...)
+			return rpi.SSH{}, nil
+		},
+		ListAKFn: func(lines []string) ([]rpi.SSHKey, error) {
+			*args = lines
+			return []rpi.SSHKey{}, nil
+		},
+		ExecuteAKFn:  execute,
+		ExecuteSOFn:  execute,
+		ExecuteRHKFn: execute,
+	}
+}
+
+// steps returns the name and the main argument of each step of a plan, the content of the files included
+func steps(plan map[int](map[int]actions.Func)) []string {
+	result := []string{}
+	for i := 1; i <= len(plan); i++ {
+		switch arg := plan[i][1].Argument[0].(type) {
+		case actions.RF:
+			result = append(result, plan[i][1].Name+" "+arg.Path+" "+arg.Backup, string(arg.Content))
+		case actions.EBC:
+			result = append(result, plan[i][1].Name+" "+arg.Command)
+		}
+	}
+	return result
+}
+
+func TestList(t *testing.T) {
+	var plan map[int](map[int]actions.Func)
+	var args []string
+
+	s := ssh.New(sshsys(&plan, &args), actions.New(), infos(map[string][]string{"/etc/ssh/ssh_host_ed25519_key.pub": {ed25519}}), humanUser)
+	_, err := s.List()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"port 22", ed25519}, args)
+
+	_, err = s.ListAK("root")
+	assert.Equal(t, echo.NewHTTPError(http.StatusNotFound, "Not found - root is not a human user"), err)
+
+	_, err = s.ListAK("pi")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{}, args)
+
+	s = ssh.New(sshsys(&plan, &args), actions.New(), infos(map[string][]string{"/home/pi/.ssh/authorized_keys": {ecdsa}}), humanUser)
+	_, err = s.ListAK("pi")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{ecdsa}, args)
+}
+
+func TestExecuteAK(t *testing.T) {
+	// authorizedKeys returns the step writing the authorized_keys file of pi
+	authorizedKeys := func(content string) string {
+		return "execute_bash_command runuser -u 'pi' -- sh -c 'umask 077 && mkdir -p \"$1\" && chmod 700 \"$1\" && " +
+			"printf %s \"$2\" | base64 -d > \"$1/authorized_keys.tmp\" && mv -f \"$1/authorized_keys.tmp\" \"$1/authorized_keys\"' " +
+			"sh '/home/pi/.ssh' '" + base64.StdEncoding.EncodeToString([]byte(content)) + "'"
+	}
+
+	cases := []struct {
+		name        string
+		link        string
+		execute     func(*ssh.SSH) (rpi.Action, error)
+		wantedErr   error
+		wantedSteps []string
+	}{
+		{
+			name:      "error: unknown user",
+			execute:   func(s *ssh.SSH) (rpi.Action, error) { return s.ExecuteAAK("root", ed25519, "") },
+			wantedErr: echo.NewHTTPError(http.StatusNotFound, "Not found - root is not a human user"),
+		},
+		{
+			name:      "error: invalid key",
+			execute:   func(s *ssh.SSH) (rpi.Action, error) { return s.ExecuteAAK("pi", "ssh-ed25519 AAAAtruncated", "") },
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid key - should be a public key in the authorized_keys format (ex: ssh-ed25519 AAAA... user@host)"),
+		},
+		{
+			name:      "error: several lines",
+			execute:   func(s *ssh.SSH) (rpi.Action, error) { return s.ExecuteAAK("pi", ed25519+"\n"+ecdsa, "") },
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid key - should be a public key in the authorized_keys format (ex: ssh-ed25519 AAAA... user@host)"),
+		},
+		{
+			name:    "error: unsupported type",
+			execute: func(s *ssh.SSH) (rpi.Action, error) { return s.ExecuteAAK("pi", dsa, "") },
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an unsupported key type - should be one of "+
+				"ssh-ed25519, sk-ssh-ed25519@openssh.com, ecdsa-sha2-nistp256, ecdsa-sha2-nistp384, ecdsa-sha2-nistp521, sk-ecdsa-sha2-nistp256@openssh.com, ssh-rsa"),
+		},
+		{
+			name:      "error: weak key",
+			execute:   func(s *ssh.SSH) (rpi.Action, error) { return s.ExecuteAAK("pi", rsa, "") },
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to a weak key - rsa keys should have 2048 bits at least"),
+		},
+		{
+			name: "error: invalid fingerprint",
+			execute: func(s *ssh.SSH) (rpi.Action, error) {
+				return s.ExecuteAAK("pi", ed25519, "SHA256:7ylZMr0Kw/MdGxwqHOkyR9Soe9/H2iBVhTVJA9vfY4A")
+			},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid fingerprint - the fingerprint of the key is SHA256:M5WiaiGAUUDnyQ11r6OesYH3EZ0nXJ7/oRjX4pp8zhA"),
+		},
+		{
+			name:      "error: existing key",
+			execute:   func(s *ssh.SSH) (rpi.Action, error) { return s.ExecuteAAK("pi", "no-pty "+ecdsa+" other comment", "") },
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an existing key - 0"),
+		},
+		{
+			name: "success: add",
+			execute: func(s *ssh.SSH) (rpi.Action, error) {
+				return s.ExecuteAAK("pi", " "+ed25519+"\n", "M5WiaiGAUUDnyQ11r6OesYH3EZ0nXJ7/oRjX4pp8zhA")
+			},
+			wantedSteps: []string{authorizedKeys("# laptop\n" + ecdsa + "\n" + ed25519 + "\n")},
+		},
+		{
+			name:      "error: unknown key",
+			execute:   func(s *ssh.SSH) (rpi.Action, error) { return s.ExecuteRAK("pi", 1) },
+			wantedErr: echo.NewHTTPError(http.StatusNotFound, "Not found - key 1 does not exist"),
+		},
+		{
+			name:        "success: remove",
+			execute:     func(s *ssh.SSH) (rpi.Action, error) { return s.ExecuteRAK("pi", 0) },
+			wantedSteps: []string{authorizedKeys("# laptop\n")},
+		},
+		{
+			name:      "error: linked ssh directory",
+			link:      "/home/pi/.ssh",
+			execute:   func(s *ssh.SSH) (rpi.Action, error) { return s.ExecuteAAK("pi", ed25519, "") },
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to a symbolic link - /home/pi/.ssh and /home/pi/.ssh/authorized_keys should not be symbolic links"),
+		},
+		{
+			name:      "error: linked authorized_keys",
+			link:      "/home/pi/.ssh/authorized_keys",
+			execute:   func(s *ssh.SSH) (rpi.Action, error) { return s.ExecuteRAK("pi", 0) },
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to a symbolic link - /home/pi/.ssh and /home/pi/.ssh/authorized_keys should not be symbolic links"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var plan map[int](map[int]actions.Func)
+			var args []string
+
+			i := infos(map[string][]string{"/home/pi/.ssh/authorized_keys": {"# laptop", ecdsa}})
+			i.IsSymlinkFn = func(path string) bool {
+				return path == tc.link
+			}
+
+			s := ssh.New(sshsys(&plan, &args), actions.New(), i, humanUser)
+			_, err := tc.execute(s)
+			assert.Equal(t, tc.wantedErr, err)
+			if tc.wantedErr == nil {
+				assert.Equal(t, tc.wantedSteps, steps(plan))
+			}
+		})
+	}
+}
+
+func TestExecuteSO(t *testing.T) {
+	debian := []string{"Include /etc/ssh/sshd_config.d/*.conf", "", "#Port 22", "PasswordAuthentication yes"}
+	buster := []string{"#Port 22", "PasswordAuthentication yes", "Match User backup", "\tPasswordAuthentication yes"}
+
+	cases := []struct {
+		name                   string
+		files                  map[string][]string
+		passwordAuthentication string
+		permitRootLogin        string
+		port                   int
+		wantedErr              error
+		wantedSteps            []string
+	}{
+		{
+			name:                   "error: invalid passwordAuthentication",
+			passwordAuthentication: "true",
+			wantedErr:              echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid passwordAuthentication - should be yes or no"),
+		},
+		{
+			name:            "error: invalid permitRootLogin",
+			permitRootLogin: "without-password",
+			wantedErr:       echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid permitRootLogin - should be one of yes, no, prohibit-password, forced-commands-only"),
+		},
+		{
+			name:      "error: invalid port",
+			port:      65536,
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid port - should be 1 to 65535"),
+		},
+		{
+			name:      "error: no option",
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to missing options - should set passwordAuthentication, permitRootLogin or port"),
+		},
+		{
+			name:                   "error: sshd_config not read",
+			files:                  map[string][]string{},
+			passwordAuthentication: "no",
+			wantedErr:              echo.NewHTTPError(http.StatusInternalServerError, "could not read sshd_config"),
+		},
+		{
+			name:                   "success: new included file",
+			files:                  map[string][]string{constants.SSHDCONFIG: debian},
+			passwordAuthentication: "no",
+			port:                   2222,
+			wantedSteps: []string{
+				"restore_file " + constants.SSHDRASPIBUDDY + " " + constants.SSH + "/00-raspibuddy.conf",
+				"# managed by raspibuddy\nPasswordAuthentication no\nPort 2222\n",
+				"execute_bash_command sshd -t || { rm -f " + constants.SSHDRASPIBUDDY + "; exit 1; }",
+				"execute_bash_command systemctl try-reload-or-restart ssh.service",
+			},
+		},
+		{
+			name:            "success: existing included file",
+			files:           map[string][]string{constants.SSHDCONFIG: debian, constants.SSHDRASPIBUDDY: {"# managed by raspibuddy", "PasswordAuthentication no"}},
+			permitRootLogin: "no",
+			wantedSteps: []string{
+				"restore_file " + constants.SSHDRASPIBUDDY + " " + constants.SSH + "/00-raspibuddy.conf",
+				"# managed by raspibuddy\nPasswordAuthentication no\nPermitRootLogin no\n",
+				"execute_bash_command sshd -t || { cp " + constants.SSH + "/00-raspibuddy.conf " + constants.SSHDRASPIBUDDY + "; exit 1; }",
+				"execute_bash_command systemctl try-reload-or-restart ssh.service",
+			},
+		},
+		{
+			name:  "success: port of sshd_config",
+			files: map[string][]string{constants.SSHDCONFIG: {"Include /etc/ssh/sshd_config.d/*.conf", "Port 22"}},
+			port:  2222,
+			wantedSteps: []string{
+				"restore_file " + constants.SSHDRASPIBUDDY + " " + constants.SSH + "/00-raspibuddy.conf",
+				"# managed by raspibuddy\nPort 2222\n",
+				"restore_file " + constants.SSHDCONFIG + " " + constants.SSH + "/sshd_config",
+				"Include /etc/ssh/sshd_config.d/*.conf\n#Port 22\n",
+				"execute_bash_command sshd -t || { rm -f " + constants.SSHDRASPIBUDDY + "; cp " + constants.SSH + "/sshd_config " + constants.SSHDCONFIG + "; exit 1; }",
+				"execute_bash_command systemctl try-reload-or-restart ssh.service",
+			},
+		},
+		{
+			name:                   "success: sshd_config",
+			files:                  map[string][]string{constants.SSHDCONFIG: buster},
+			passwordAuthentication: "no",
+			permitRootLogin:        "prohibit-password",
+			wantedSteps: []string{
+				"restore_file " + constants.SSHDCONFIG + " " + constants.SSH + "/sshd_config",
+				"#Port 22\nPasswordAuthentication no\nPermitRootLogin prohibit-password\n\nMatch User backup\n\tPasswordAuthentication yes\n",
+				"execute_bash_command sshd -t || { cp " + constants.SSH + "/sshd_config " + constants.SSHDCONFIG + "; exit 1; }",
+				"execute_bash_command systemctl try-reload-or-restart ssh.service",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var plan map[int](map[int]actions.Func)
+			var args []string
+
+			s := ssh.New(sshsys(&plan, &args), actions.New(), infos(tc.files), humanUser)
+			_, err := s.ExecuteSO(tc.passwordAuthentication, tc.permitRootLogin, tc.port)
+			assert.Equal(t, tc.wantedErr, err)
+			if tc.wantedErr == nil {
+				assert.Equal(t, tc.wantedSteps, steps(plan))
+			}
+		})
+	}
+}
+
+func TestExecuteRHK(t *testing.T) {
+	var plan map[int](map[int]actions.Func)
+	var args []string
+
+	s := ssh.New(sshsys(&plan, &args), actions.New(), infos(map[string][]string{}), humanUser)
+	_, err := s.ExecuteRHK()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		`execute_bash_command d=$(mktemp -d) && mkdir -p "$d/etc/ssh" && ssh-keygen -A -f "$d" && mv -f "$d"/etc/ssh/ssh_host_* /etc/ssh/ && rm -rf "$d"`,
+		"execute_bash_command systemctl try-reload-or-restart ssh.service",
+	}, steps(plan))
+}
@@ -0,0 +1,87 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi/pkg/api/actions/ssh"
+)
+
+// HTTP is a struct implementing a core application service.
+type HTTP struct {
+	svc ssh.Service
+}
+
+// NewHTTP creates new ssh http service
+func NewHTTP(svc ssh.Service, r *echo.Group) {
+	h := HTTP{svc}
+	cr := r.Group("/ssh")
+	cr.GET("", h.list)
+	cr.GET("/keys/:username", h.listKeys)
+	cr.POST("/keys/:username/add", h.addKey)
+	cr.POST("/keys/:username/remove/:id", h.removeKey)
+	cr.POST("/options", h.options)
+	cr.POST("/hostkeys", h.regenerateHostKeys)
+}
+
+func (h *HTTP) list(ctx echo.Context) error {
+	result, err := h.svc.List()
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) listKeys(ctx echo.Context) error {
+	result, err := h.svc.ListAK(ctx.Param("username"))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) addKey(ctx echo.Context) error {
+	result, err := h.svc.ExecuteAAK(ctx.Param("username"), ctx.QueryParam("key"), ctx.QueryParam("fingerprint"))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) removeKey(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || id < 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid id - should be a key id")
+	}
+
+	result, err := h.svc.ExecuteRAK(ctx.Param("username"), id)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) options(ctx echo.Context) error {
+	port := 0
+	if ctx.QueryParam("port") != "" {
+		var err error
+		if port, err = strconv.Atoi(ctx.QueryParam("port")); err != nil || port < 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid port - should be 1 to 65535")
+		}
+	}
+
+	result, err := h.svc.ExecuteSO(ctx.QueryParam("passwordAuthentication"), ctx.QueryParam("permitRootLogin"), port)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) regenerateHostKeys(ctx echo.Context) error {
+	result, err := h.svc.ExecuteRHK()
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,167 @@
+package transport_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/ssh"
+	"github.com/raspibuddy/rpi/pkg/api/actions/ssh/transport"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/raspibuddy/rpi/pkg/utl/server"
+	"github.com/stretchr/testify/assert"
+)
+
+const ed25519 = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIHk5jHIU3SV09U8gHZ6MtUb1NLPfJ1m4aE5l7IwfEyDd pi@raspberrypi"
+
+func TestSSH(t *testing.T) {
+	cases := []struct {
+		name         string
+		method       string
+		req          string
+		executeErr   error
+		wantedStatus int
+	}{
+		{
+			name:         "success: list",
+			method:       http.MethodGet,
+			req:          "",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "error: unknown user",
+			method:       http.MethodGet,
+			req:          "/keys/root",
+			wantedStatus: http.StatusNotFound,
+		},
+		{
+			name:         "success: list keys",
+			method:       http.MethodGet,
+			req:          "/keys/pi",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "error: invalid key",
+			method:       http.MethodPost,
+			req:          "/keys/pi/add?key=ssh-ed25519",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: ExecuteAAK result is nil",
+			method:       http.MethodPost,
+			req:          "/keys/pi/add?key=" + url.QueryEscape(ed25519),
+			executeErr:   errors.New("test error"),
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:         "success: add key",
+			method:       http.MethodPost,
+			req:          "/keys/pi/add?key=" + url.QueryEscape(ed25519) + "&fingerprint=" + url.QueryEscape("SHA256:M5WiaiGAUUDnyQ11r6OesYH3EZ0nXJ7/oRjX4pp8zhA"),
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "error: invalid id",
+			method:       http.MethodPost,
+			req:          "/keys/pi/remove/first",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: unknown id",
+			method:       http.MethodPost,
+			req:          "/keys/pi/remove/0",
+			wantedStatus: http.StatusNotFound,
+		},
+		{
+			name:         "error: invalid port",
+			method:       http.MethodPost,
+			req:          "/options?port=ssh",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid permitRootLogin",
+			method:       http.MethodPost,
+			req:          "/options?permitRootLogin=maybe",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "success: options",
+			method:       http.MethodPost,
+			req:          "/options?passwordAuthentication=no&permitRootLogin=no&port=2222",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "success: host keys",
+			method:       http.MethodPost,
+			req:          "/hostkeys",
+			wantedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			i := mock.Infos{
+				ReadFileFn: func(string) ([]string, error) {
+					return []string{}, nil
+				},
+				IsFileExistsFn: func(string) bool {
+					return false
+				},
+				IsSymlinkFn: func(string) bool {
+					return false
+				},
+				ListFilesFn: func(string) []string {
+					return []string{}
+				},
+				SshdConfigFn: func() []string {
+					return []string{"port 22"}
+				},
+			}
+			hu := mock.HumanUser{
+				ListFn: func() ([]rpi.HumanUser, error) {
+					return []rpi.HumanUser{{Username: "pi", Uid: 1000, Gid: 1000, HomeDirectory: "/home/pi"}}, nil
+				},
+			}
+			execute := func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+				return rpi.Action{NumberOfSteps: 1}, tc.executeErr
+			}
+			sshsys := &mocksys.SSH{
+				ListFn: func([]string, []string) (rpi.SSH, error) {
+					return rpi.SSH{Port: 22}, nil
+				},
+				ListAKFn: func([]string) ([]rpi.SSHKey, error) {
+					return []rpi.SSHKey{}, nil
+				},
+				ExecuteAKFn:  execute,
+				ExecuteSOFn:  execute,
+				ExecuteRHKFn: execute,
+			}
+			s := ssh.New(sshsys, actions.New(), i, hu)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/ssh" + tc.req
+
+			req, err := http.NewRequest(tc.method, path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
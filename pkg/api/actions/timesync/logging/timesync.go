@@ -0,0 +1,87 @@
+package timesync
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/timesync"
+)
+
+// New creates a new TimeSync logging service instance.
+func New(svc timesync.Service, logger rpi.Logger) *LogService {
+	return &LogService{
+		Service: svc,
+		logger:  logger,
+	}
+}
+
+// LogService represents a TimeSync logging service.
+type LogService struct {
+	timesync.Service
+	logger rpi.Logger
+}
+
+const name = "timesync"
+
+// List is the logging function attached to the List timesync services and responsible for logging it out.
+func (ls *LogService) List(ctx echo.Context) (resp rpi.Time, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, "request: list time", err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.List()
+}
+
+// ExecuteNTP is the logging function attached to the ExecuteNTP timesync services and responsible for logging it out.
+func (ls *LogService) ExecuteNTP(ctx echo.Context, servers []string) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: set ntp servers to %v", strings.Join(servers, " ")), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteNTP(servers)
+}
+
+// ExecuteTS is the logging function attached to the ExecuteTS timesync services and responsible for logging it out.
+func (ls *LogService) ExecuteTS(ctx echo.Context, action string) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: %v time synchronization", action), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteTS(action)
+}
+
+// ExecuteST is the logging function attached to the ExecuteST timesync services and responsible for logging it out.
+func (ls *LogService) ExecuteST(ctx echo.Context, t time.Time) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: set time to %v", t.Format(time.RFC3339)), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteST(t)
+}
@@ -0,0 +1,106 @@
+package sys
+
+import (
+	"strings"
+	"time"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// TimeSync represents an empty TimeSync entity on the current system.
+type TimeSync struct{}
+
+// List returns the clock and synchronization state read from 'timedatectl show' and 'timedatectl show-timesync',
+// and the servers configured in the [Time] section of timesyncd.conf
+func (ts TimeSync) List(show string, timesync string, config []string, now int64) (rpi.Time, error) {
+	s := properties(show)
+	t := properties(timesync)
+
+	result := rpi.Time{
+		Time:            now,
+		Timezone:        s["Timezone"],
+		IsLocalRTC:      s["LocalRTC"] == "yes",
+		IsNTPAvailable:  s["CanNTP"] == "yes",
+		IsNTPEnabled:    s["NTP"] == "yes",
+		IsSynchronized:  s["NTPSynchronized"] == "yes",
+		Servers:         []string{},
+		FallbackServers: []string{},
+		ServerName:      t["ServerName"],
+		ServerAddress:   t["ServerAddress"],
+	}
+
+	section := ""
+	for _, line := range config {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") {
+			section = line
+			continue
+		}
+		if section != "[Time]" || strings.HasPrefix(line, "#") || !strings.Contains(line, "=") {
+			continue
+		}
+
+		kv := strings.SplitN(line, "=", 2)
+		switch strings.TrimSpace(kv[0]) {
+		case "NTP":
+			result.Servers = strings.Fields(kv[1])
+		case "FallbackNTP":
+			result.FallbackServers = strings.Fields(kv[1])
+		}
+	}
+
+	// timesyncd uses its built-in servers when none is configured
+	if len(result.Servers) == 0 && t["SystemNTPServers"] != "" {
+		result.Servers = strings.Fields(t["SystemNTPServers"])
+	}
+	if len(result.FallbackServers) == 0 && t["FallbackNTPServers"] != "" {
+		result.FallbackServers = strings.Fields(t["FallbackNTPServers"])
+	}
+
+	return result, nil
+}
+
+// ExecuteNTP returns an action response after setting the ntp servers
+func (ts TimeSync) ExecuteNTP(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return execute(actions.NTPServers, plan)
+}
+
+// ExecuteTS returns an action response after enabling or disabling the time synchronization
+func (ts TimeSync) ExecuteTS(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return execute(actions.TimeSync, plan)
+}
+
+// ExecuteST returns an action response after setting the time
+func (ts TimeSync) ExecuteST(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return execute(actions.SetTime, plan)
+}
+
+func execute(name string, plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	actionStartTime := uint64(time.Now().Unix())
+	progressInit := actions.FlattenPlan(plan)
+	progress, exitStatus := actions.ExecutePlan(plan, progressInit)
+
+	return rpi.Action{
+		Name:          name,
+		NumberOfSteps: uint16(len(progressInit)),
+		Progress:      progress,
+		ExitStatus:    exitStatus,
+		StartTime:     actionStartTime,
+		EndTime:       uint64(time.Now().Unix()),
+	}, nil
+}
+
+// properties returns the Key=value lines printed by timedatectl show
+func properties(out string) map[string]string {
+	result := map[string]string{}
+
+	for _, line := range strings.Split(out, "\n") {
+		kv := strings.SplitN(strings.TrimSpace(line), "=", 2)
+		if len(kv) == 2 {
+			result[kv[0]] = kv[1]
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,108 @@
+package sys_test
+
+import (
+	"testing"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/timesync/platform/sys"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	cases := []struct {
+		name         string
+		show         string
+		timesync     string
+		config       []string
+		wantedResult rpi.Time
+	}{
+		{
+			name: "without systemd",
+			wantedResult: rpi.Time{
+				Time:            1600000000,
+				Servers:         []string{},
+				FallbackServers: []string{},
+			},
+		},
+		{
+			name: "configured servers",
+			show: "Timezone=Europe/London\nLocalRTC=no\nCanNTP=yes\nNTP=yes\nNTPSynchronized=yes\nTimeUSec=Sun 2020-09-13 13:26:40 BST",
+			timesync: "SystemNTPServers=\nFallbackNTPServers=0.debian.pool.ntp.org 1.debian.pool.ntp.org\n" +
+				"ServerName=time.cloudflare.com\nServerAddress=162.159.200.1",
+			config: []string{
+				"[Time]",
+				"NTP=time.cloudflare.com 192.168.1.1",
+				"#FallbackNTP=0.debian.pool.ntp.org",
+				"",
+				"[Other]",
+				"FallbackNTP=dummy",
+			},
+			wantedResult: rpi.Time{
+				Time:            1600000000,
+				Timezone:        "Europe/London",
+				IsNTPAvailable:  true,
+				IsNTPEnabled:    true,
+				IsSynchronized:  true,
+				Servers:         []string{"time.cloudflare.com", "192.168.1.1"},
+				FallbackServers: []string{"0.debian.pool.ntp.org", "1.debian.pool.ntp.org"},
+				ServerName:      "time.cloudflare.com",
+				ServerAddress:   "162.159.200.1",
+			},
+		},
+		{
+			name:     "offline without sync",
+			show:     "Timezone=Etc/UTC\nLocalRTC=yes\nCanNTP=yes\nNTP=no\nNTPSynchronized=no",
+			timesync: "SystemNTPServers=pool.ntp.org",
+			config:   []string{"[Time]", "FallbackNTP=time.google.com"},
+			wantedResult: rpi.Time{
+				Time:            1600000000,
+				Timezone:        "Etc/UTC",
+				IsLocalRTC:      true,
+				IsNTPAvailable:  true,
+				Servers:         []string{"pool.ntp.org"},
+				FallbackServers: []string{"time.google.com"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := sys.TimeSync{}
+			result, err := s.List(tc.show, tc.timesync, tc.config, 1600000000)
+			assert.Equal(t, tc.wantedResult, result)
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestExecute(t *testing.T) {
+	s := sys.TimeSync{}
+	cases := []struct {
+		name       string
+		execute    func(map[int](map[int]actions.Func)) (rpi.Action, error)
+		wantedName string
+	}{
+		{name: "ntp servers", execute: s.ExecuteNTP, wantedName: actions.NTPServers},
+		{name: "time sync", execute: s.ExecuteTS, wantedName: actions.TimeSync},
+		{name: "set time", execute: s.ExecuteST, wantedName: actions.SetTime},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.execute(map[int](map[int]actions.Func){
+				1: {
+					1: {
+						Name:      "funcA",
+						Reference: func(arg interface{}) (rpi.Exec, error) { return rpi.Exec{ExitStatus: 0}, nil },
+						Argument:  []interface{}{actions.EBC{}},
+					},
+				},
+			})
+			assert.Equal(t, tc.wantedName, result.Name)
+			assert.Equal(t, uint16(1), result.NumberOfSteps)
+			assert.Equal(t, uint8(0), result.ExitStatus)
+			assert.Nil(t, err)
+		})
+	}
+}
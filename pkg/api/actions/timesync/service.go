@@ -0,0 +1,55 @@
+package timesync
+
+import (
+	"time"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// Service represents all TimeSync application services.
+type Service interface {
+	List() (rpi.Time, error)
+	ExecuteNTP([]string) (rpi.Action, error)
+	ExecuteTS(string) (rpi.Action, error)
+	ExecuteST(time.Time) (rpi.Action, error)
+}
+
+// TimeSync represents a TimeSync application service.
+type TimeSync struct {
+	tssys TSSYS
+	a     Actions
+	i     Infos
+	m     Metrics
+}
+
+// TSSYS represents a TimeSync repository service.
+type TSSYS interface {
+	List(string, string, []string, int64) (rpi.Time, error)
+	ExecuteNTP(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteTS(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteST(map[int](map[int]actions.Func)) (rpi.Action, error)
+}
+
+// Actions represents the actions interface
+type Actions interface {
+	SetNTPServers(interface{}) (rpi.Exec, error)
+	ManageUnit(interface{}) (rpi.Exec, error)
+	ExecuteBashCommand(interface{}) (rpi.Exec, error)
+}
+
+// Infos represents the infos interface
+type Infos interface {
+	ReadFile(string) ([]string, error)
+}
+
+// Metrics represents the system metrics interface
+type Metrics interface {
+	TimeDate() (string, string, error)
+	TimeSync() (string, string, error)
+}
+
+// New creates a TimeSync application service instance.
+func New(tssys TSSYS, a Actions, i Infos, m Metrics) *TimeSync {
+	return &TimeSync{tssys: tssys, a: a, i: i, m: m}
+}
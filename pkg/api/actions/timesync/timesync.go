@@ -0,0 +1,120 @@
+package timesync
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/constants"
+)
+
+// Unit is the systemd unit synchronizing the clock
+const Unit = "systemd-timesyncd.service"
+
+// List populates and returns the Time model.
+func (ts *TimeSync) List() (rpi.Time, error) {
+	// timedatectl fails without systemd and timesyncd.conf may not exist, the matching values are then empty
+	show, _, _ := ts.m.TimeDate()
+	timesync, _, _ := ts.m.TimeSync()
+	config, _ := ts.i.ReadFile(constants.TIMESYNCDCONF)
+
+	return ts.tssys.List(show, timesync, config, time.Now().Unix())
+}
+
+// ExecuteNTP sets the ntp servers of systemd-timesyncd and restarts it, then returns an action.
+// No server restores the default ones.
+func (ts *TimeSync) ExecuteNTP(servers []string) (rpi.Action, error) {
+	for _, s := range servers {
+		if !regexp.MustCompile(actions.NTPServerRegex).MatchString(s) {
+			return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to an invalid server - %v", s))
+		}
+	}
+
+	plan := map[int](map[int]actions.Func){
+		1: {
+			1: {
+				Name:      actions.SetNTPServers,
+				Reference: ts.a.SetNTPServers,
+				Argument: []interface{}{
+					actions.NTPS{
+						Path:    constants.TIMESYNCDCONF,
+						Servers: servers,
+					},
+				},
+			},
+		},
+		2: {
+			1: {
+				Name:      actions.ManageUnit,
+				Reference: ts.a.ManageUnit,
+				Argument: []interface{}{
+					actions.MU{
+						Action: "restart",
+						Unit:   Unit,
+					},
+				},
+			},
+		},
+	}
+
+	return ts.tssys.ExecuteNTP(plan)
+}
+
+// ExecuteTS enables or disables the time synchronization and returns an action.
+func (ts *TimeSync) ExecuteTS(action string) (rpi.Action, error) {
+	ntp := "false"
+	if action == "enable" {
+		ntp = "true"
+	}
+
+	plan := ts.commands(fmt.Sprintf("timedatectl set-ntp %v", ntp))
+
+	return ts.tssys.ExecuteTS(plan)
+}
+
+// ExecuteST sets the time of the system, meant for boards without network hence without synchronization,
+// and returns an action. The synchronization is paused while the time is set and enabled again even when
+// setting the time fails, and the time is saved to fake-hwclock when installed so that it survives a reboot.
+func (ts *TimeSync) ExecuteST(t time.Time) (rpi.Action, error) {
+	current, err := ts.List()
+	if err != nil {
+		return rpi.Action{}, err
+	}
+
+	setTime := fmt.Sprintf("timedatectl set-time \"%v\"", t.UTC().Format("2006-01-02 15:04:05 UTC"))
+	commands := []string{setTime}
+
+	// timedatectl refuses to set the time while the synchronization is enabled
+	if current.IsNTPEnabled {
+		commands = []string{"timedatectl set-ntp false", setTime + "; rc=$?; timedatectl set-ntp true; exit $rc"}
+	}
+
+	plan := ts.commands(append(commands, "if hash fake-hwclock 2> /dev/null ; then fake-hwclock save force ; fi")...)
+
+	return ts.tssys.ExecuteST(plan)
+}
+
+// commands returns a plan running each command in its own step, in order
+func (ts *TimeSync) commands(commands ...string) map[int](map[int]actions.Func) {
+	plan := map[int](map[int]actions.Func){}
+
+	for _, c := range commands {
+		plan[len(plan)+1] = map[int]actions.Func{
+			1: {
+				Name:      actions.ExecuteBashCommand,
+				Reference: ts.a.ExecuteBashCommand,
+				Argument: []interface{}{
+					actions.EBC{
+						Command: c,
+					},
+				},
+			},
+		}
+	}
+
+	return plan
+}
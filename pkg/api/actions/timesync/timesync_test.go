@@ -0,0 +1,170 @@
+package timesync_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/timesync"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/constants"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/stretchr/testify/assert"
+)
+
+var infos = &mock.Infos{
+	ReadFileFn: func(path string) ([]string, error) {
+		return []string{path}, nil
+	},
+}
+
+var metrics = &mock.Metrics{
+	TimeDateFn: func() (string, string, error) {
+		return "NTP=yes", "", nil
+	},
+	TimeSyncFn: func() (string, string, error) {
+		return "ServerName=pool.ntp.org", "", nil
+	},
+}
+
+func tssys(plan *map[int](map[int]actions.Func), isNTPEnabled bool) *mocksys.TimeSync {
+	execute := func(p map[int](map[int]actions.Func)) (rpi.Action, error) {
+		*plan = p
+		return rpi.Action{NumberOfSteps: uint16(len(p))}, nil
+	}
+
+	return &mocksys.TimeSync{
+		ListFn: func(show string, timesync string, config []string, now int64) (rpi.Time, error) {
+			return rpi.Time{
+				Time:          now,
+				IsNTPEnabled:  isNTPEnabled,
+				ServerName:    timesync,
+				ServerAddress: config[0],
+			}, nil
+		},
+		ExecuteNTPFn: execute,
+		ExecuteTSFn:  execute,
+		ExecuteSTFn:  execute,
+	}
+}
+
+func commands(plan map[int](map[int]actions.Func)) []string {
+	result := []string{}
+	for k := 1; k <= len(plan); k++ {
+		result = append(result, plan[k][1].Argument[0].(actions.EBC).Command)
+	}
+	return result
+}
+
+func TestList(t *testing.T) {
+	var plan map[int](map[int]actions.Func)
+
+	s := timesync.New(tssys(&plan, true), actions.New(), infos, metrics)
+	result, err := s.List()
+	assert.Nil(t, err)
+	assert.Equal(t, "ServerName=pool.ntp.org", result.ServerName)
+	assert.Equal(t, constants.TIMESYNCDCONF, result.ServerAddress)
+	assert.NotZero(t, result.Time)
+}
+
+func TestExecuteNTP(t *testing.T) {
+	var plan map[int](map[int]actions.Func)
+
+	s := timesync.New(tssys(&plan, true), actions.New(), infos, metrics)
+	_, err := s.ExecuteNTP([]string{"pool.ntp.org", "pool.ntp.org;reboot"})
+	assert.Equal(t, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid server - pool.ntp.org;reboot"), err)
+
+	result, err := s.ExecuteNTP([]string{"time.cloudflare.com"})
+	assert.Nil(t, err)
+	assert.Equal(t, uint16(2), result.NumberOfSteps)
+	assert.Equal(t, actions.NTPS{Path: constants.TIMESYNCDCONF, Servers: []string{"time.cloudflare.com"}}, plan[1][1].Argument[0].(actions.NTPS))
+	assert.Equal(t, actions.MU{Action: "restart", Unit: timesync.Unit}, plan[2][1].Argument[0].(actions.MU))
+}
+
+func TestExecuteTS(t *testing.T) {
+	var plan map[int](map[int]actions.Func)
+
+	s := timesync.New(tssys(&plan, true), actions.New(), infos, metrics)
+	_, err := s.ExecuteTS("enable")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"timedatectl set-ntp true"}, commands(plan))
+
+	_, err = s.ExecuteTS("disable")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"timedatectl set-ntp false"}, commands(plan))
+}
+
+func TestExecuteST(t *testing.T) {
+	date := time.Date(2020, 6, 1, 14, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	cases := []struct {
+		name           string
+		isNTPEnabled   bool
+		wantedCommands []string
+	}{
+		{
+			name:         "synchronization disabled",
+			isNTPEnabled: false,
+			wantedCommands: []string{
+				`timedatectl set-time "2020-06-01 12:30:00 UTC"`,
+				"if hash fake-hwclock 2> /dev/null ; then fake-hwclock save force ; fi",
+			},
+		},
+		{
+			name:         "synchronization paused",
+			isNTPEnabled: true,
+			wantedCommands: []string{
+				"timedatectl set-ntp false",
+				`timedatectl set-time "2020-06-01 12:30:00 UTC"; rc=$?; timedatectl set-ntp true; exit $rc`,
+				"if hash fake-hwclock 2> /dev/null ; then fake-hwclock save force ; fi",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var plan map[int](map[int]actions.Func)
+			s := timesync.New(tssys(&plan, tc.isNTPEnabled), actions.New(), infos, metrics)
+			_, err := s.ExecuteST(date)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.wantedCommands, commands(plan))
+		})
+	}
+}
+
+func TestExecuteSTFailure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "timesync")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// timedatectl logs its arguments and fails to set the time
+	log := filepath.Join(dir, "calls")
+	script := "#!/bin/sh\necho \"$@\" >> " + log + "\n[ \"$1\" != set-time ]\n"
+	if err := ioutil.WriteFile(filepath.Join(dir, "timedatectl"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := os.Getenv("PATH")
+	os.Setenv("PATH", dir+string(os.PathListSeparator)+path)
+	defer os.Setenv("PATH", path)
+
+	var plan map[int](map[int]actions.Func)
+	s := timesync.New(tssys(&plan, true), actions.New(), infos, metrics)
+	_, err = s.ExecuteST(time.Date(2020, 6, 1, 12, 30, 0, 0, time.UTC))
+	assert.Nil(t, err)
+
+	progress, exitStatus := actions.ExecutePlan(plan, actions.FlattenPlan(plan))
+	assert.Equal(t, uint8(1), exitStatus)
+	assert.Equal(t, uint8(1), progress["2"+actions.Separator+"1"].ExitStatus)
+
+	calls, err := ioutil.ReadFile(log)
+	assert.Nil(t, err)
+	assert.Equal(t, "set-ntp false\nset-time 2020-06-01 12:30:00 UTC\nset-ntp true\n", string(calls))
+}
@@ -0,0 +1,72 @@
+package transport
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi/pkg/api/actions/timesync"
+)
+
+// HTTP is a struct implementing a core application service.
+type HTTP struct {
+	svc timesync.Service
+}
+
+// NewHTTP creates new timesync http service
+func NewHTTP(svc timesync.Service, r *echo.Group) {
+	h := HTTP{svc}
+	cr := r.Group("/time")
+	cr.GET("", h.list)
+	cr.POST("/ntp", h.ntp)
+	cr.POST("/sync/:action", h.sync)
+	cr.POST("/set", h.set)
+}
+
+func (h *HTTP) list(ctx echo.Context) error {
+	result, err := h.svc.List()
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) ntp(ctx echo.Context) error {
+	// no server restores the default ones
+	servers := strings.FieldsFunc(ctx.QueryParam("servers"), func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+
+	result, err := h.svc.ExecuteNTP(servers)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) sync(ctx echo.Context) error {
+	action := ctx.Param("action")
+	if action != "enable" && action != "disable" {
+		return echo.NewHTTPError(http.StatusNotFound, "Not found - bad action type or action type is null")
+	}
+
+	result, err := h.svc.ExecuteTS(action)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) set(ctx echo.Context) error {
+	t, err := time.Parse(time.RFC3339, ctx.QueryParam("time"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid time - should be formatted as RFC 3339 (ex: 2020-06-01T12:00:00Z)")
+	}
+
+	result, err := h.svc.ExecuteST(t)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
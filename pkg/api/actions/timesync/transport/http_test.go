@@ -0,0 +1,134 @@
+package transport_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/timesync"
+	"github.com/raspibuddy/rpi/pkg/api/actions/timesync/transport"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/raspibuddy/rpi/pkg/utl/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTime(t *testing.T) {
+	cases := []struct {
+		name         string
+		method       string
+		req          string
+		executeErr   error
+		wantedStatus int
+	}{
+		{
+			name:         "success: list",
+			method:       http.MethodGet,
+			req:          "",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "error: invalid server",
+			method:       http.MethodPost,
+			req:          "/ntp?servers=pool.ntp.org,time%26reboot",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: ExecuteNTP result is nil",
+			method:       http.MethodPost,
+			req:          "/ntp?servers=pool.ntp.org",
+			executeErr:   errors.New("test error"),
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:         "success: ntp servers",
+			method:       http.MethodPost,
+			req:          "/ntp?servers=time.cloudflare.com,192.168.1.1",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "success: default ntp servers",
+			method:       http.MethodPost,
+			req:          "/ntp",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "error: invalid sync action",
+			method:       http.MethodPost,
+			req:          "/sync/restart",
+			wantedStatus: http.StatusNotFound,
+		},
+		{
+			name:         "success: disable sync",
+			method:       http.MethodPost,
+			req:          "/sync/disable",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "error: invalid time",
+			method:       http.MethodPost,
+			req:          "/set?time=2020-06-01",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "success: set time",
+			method:       http.MethodPost,
+			req:          "/set?time=2020-06-01T12:30:00Z",
+			wantedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			i := mock.Infos{
+				ReadFileFn: func(string) ([]string, error) {
+					return []string{}, nil
+				},
+			}
+			m := mock.Metrics{
+				TimeDateFn: func() (string, string, error) {
+					return "", "", nil
+				},
+				TimeSyncFn: func() (string, string, error) {
+					return "", "", nil
+				},
+			}
+			execute := func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+				return rpi.Action{NumberOfSteps: 1}, tc.executeErr
+			}
+			tssys := &mocksys.TimeSync{
+				ListFn: func(string, string, []string, int64) (rpi.Time, error) {
+					return rpi.Time{}, nil
+				},
+				ExecuteNTPFn: execute,
+				ExecuteTSFn:  execute,
+				ExecuteSTFn:  execute,
+			}
+			s := timesync.New(tssys, actions.New(), i, m)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/time" + tc.req
+
+			req, err := http.NewRequest(tc.method, path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
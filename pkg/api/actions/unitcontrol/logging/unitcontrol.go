@@ -0,0 +1,41 @@
+package unitcontrol
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/unitcontrol"
+)
+
+// New creates a new UnitControl logging service instance.
+func New(svc unitcontrol.Service, logger rpi.Logger) *LogService {
+	return &LogService{
+		Service: svc,
+		logger:  logger,
+	}
+}
+
+// LogService represents a UnitControl logging service.
+type LogService struct {
+	unitcontrol.Service
+	logger rpi.Logger
+}
+
+const name = "unitcontrol"
+
+// ExecuteMU is the logging function attached to the unitcontrol services and responsible for logging it out.
+func (ls *LogService) ExecuteMU(ctx echo.Context, action string, unit string) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: %v unit %v", action, unit), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteMU(action, unit)
+}
@@ -0,0 +1,59 @@
+package sys_test
+
+import (
+	"testing"
+
+	"github.com/raspibuddy/rpi/pkg/api/actions/unitcontrol"
+	"github.com/raspibuddy/rpi/pkg/api/actions/unitcontrol/platform/sys"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/test_utl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteMU(t *testing.T) {
+	cases := []struct {
+		name                  string
+		plan                  map[int](map[int]actions.Func)
+		wantedDataName        string
+		wantedDataNumSteps    uint16
+		wantedDataStdOutStep1 string
+		wantedDataExitStatus  uint8
+		wantedErr             error
+	}{
+		{
+			name: "success",
+			plan: map[int](map[int]actions.Func){
+				1: {
+					1: {
+						Name:      actions.ManageUnit,
+						Reference: test_utl.FuncA,
+						Argument: []interface{}{
+							test_utl.ArgFuncA{
+								Arg0: "string0",
+								Arg1: "string1",
+							},
+						},
+					},
+				},
+			},
+			wantedDataName:        "unit_control",
+			wantedDataNumSteps:    1,
+			wantedDataStdOutStep1: "string0-string1",
+			wantedDataExitStatus:  0,
+			wantedErr:             nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := unitcontrol.UCSYS(sys.UnitControl{})
+			result, err := s.ExecuteMU(tc.plan)
+
+			assert.Equal(t, tc.wantedDataName, result.Name)
+			assert.Equal(t, tc.wantedDataNumSteps, result.NumberOfSteps)
+			assert.Equal(t, tc.wantedDataStdOutStep1, result.Progress["1<|>1"].Stdout)
+			assert.Equal(t, tc.wantedDataExitStatus, result.ExitStatus)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
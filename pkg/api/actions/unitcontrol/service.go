@@ -0,0 +1,38 @@
+package unitcontrol
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// Service represents all UnitControl application services.
+type Service interface {
+	ExecuteMU(string, string) (rpi.Action, error)
+}
+
+// UnitControl represents a UnitControl application service.
+type UnitControl struct {
+	ucsys UCSYS
+	a     Actions
+	i     Infos
+}
+
+// UCSYS represents a UnitControl repository service.
+type UCSYS interface {
+	ExecuteMU(map[int](map[int]actions.Func)) (rpi.Action, error)
+}
+
+// Actions represents the actions interface
+type Actions interface {
+	ManageUnit(interface{}) (rpi.Exec, error)
+}
+
+// Infos represents the infos interface
+type Infos interface {
+	IsUnit(string) bool
+}
+
+// New creates a UCSYS application service instance.
+func New(ucsys UCSYS, a Actions, i Infos) *UnitControl {
+	return &UnitControl{ucsys: ucsys, a: a, i: i}
+}
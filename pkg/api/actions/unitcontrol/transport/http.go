@@ -0,0 +1,39 @@
+package transport
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi/pkg/api/actions/unitcontrol"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// HTTP is a struct implementing a core application service.
+type HTTP struct {
+	svc unitcontrol.Service
+}
+
+// NewHTTP creates new unitcontrol http service
+func NewHTTP(svc unitcontrol.Service, r *echo.Group) {
+	h := HTTP{svc}
+	cr := r.Group("/unitcontrol")
+	for _, action := range actions.UnitActions {
+		cr.POST("/"+action+"/:id", h.manage(action))
+	}
+}
+
+func (h *HTTP) manage(action string) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		unit := ctx.Param("id")
+		if !regexp.MustCompile(actions.UnitNameRegex).MatchString(unit) {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid id - should be a service unit name")
+		}
+
+		result, err := h.svc.ExecuteMU(action, unit)
+		if err != nil {
+			return err
+		}
+		return ctx.JSON(http.StatusOK, result)
+	}
+}
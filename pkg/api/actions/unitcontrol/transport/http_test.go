@@ -0,0 +1,98 @@
+package transport_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/unitcontrol"
+	"github.com/raspibuddy/rpi/pkg/api/actions/unitcontrol/transport"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/raspibuddy/rpi/pkg/utl/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteMU(t *testing.T) {
+	cases := []struct {
+		name         string
+		req          string
+		ucsys        *mocksys.Action
+		wantedStatus int
+	}{
+		{
+			name:         "error: invalid action",
+			req:          "start/ssh.service",
+			wantedStatus: http.StatusNotFound,
+		},
+		{
+			name:         "error: invalid unit",
+			req:          "restart/ssh.socket",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: unit not listed",
+			req:          "restart/dummy.service",
+			wantedStatus: http.StatusNotFound,
+		},
+		{
+			name: "error: ExecuteMU result is nil",
+			req:  "mask/ssh.service",
+			ucsys: &mocksys.Action{
+				ExecuteMUFn: func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+					return rpi.Action{}, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:         "success",
+			req:          "enable/ssh.service",
+			wantedStatus: http.StatusOK,
+			ucsys: &mocksys.Action{
+				ExecuteMUFn: func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+					return rpi.Action{
+						Name:          actions.UnitControl,
+						NumberOfSteps: 1,
+						StartTime:     uint64(time.Now().Unix()),
+						EndTime:       uint64(time.Now().Unix()),
+						ExitStatus:    0,
+					}, nil
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			a := actions.New()
+			i := mock.Infos{
+				IsUnitFn: func(unit string) bool {
+					return unit == "ssh.service"
+				},
+			}
+			s := unitcontrol.New(tc.ucsys, a, i)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/unitcontrol/" + tc.req
+
+			res, err := http.Post(path, "application/json", bytes.NewBufferString(tc.req))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package unitcontrol
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// ExecuteMU runs a systemctl action against a unit listed by systemctl and returns an action.
+func (uc *UnitControl) ExecuteMU(action string, unit string) (rpi.Action, error) {
+	if !uc.i.IsUnit(unit) {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusNotFound, "Not found - unit does not exist")
+	}
+
+	plan := map[int](map[int]actions.Func){
+		1: {
+			1: {
+				Name:      actions.ManageUnit,
+				Reference: uc.a.ManageUnit,
+				Argument: []interface{}{
+					actions.MU{
+						Action: action,
+						Unit:   unit,
+					},
+				},
+			},
+		},
+	}
+
+	return uc.ucsys.ExecuteMU(plan)
+}
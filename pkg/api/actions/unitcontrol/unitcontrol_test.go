@@ -0,0 +1,104 @@
+package unitcontrol_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/unitcontrol"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteMU(t *testing.T) {
+	cases := []struct {
+		name       string
+		action     string
+		unit       string
+		infos      *mock.Infos
+		actions    *mock.Actions
+		ucsys      *mocksys.Action
+		wantedData rpi.Action
+		wantedErr  error
+	}{
+		{
+			name:   "error: unit not listed",
+			action: "restart",
+			unit:   "dummy.service",
+			infos: &mock.Infos{
+				IsUnitFn: func(string) bool {
+					return false
+				},
+			},
+			wantedData: rpi.Action{},
+			wantedErr:  echo.NewHTTPError(http.StatusNotFound, "Not found - unit does not exist"),
+		},
+		{
+			name:   "success",
+			action: "restart",
+			unit:   "ssh.service",
+			infos: &mock.Infos{
+				IsUnitFn: func(string) bool {
+					return true
+				},
+			},
+			actions: &mock.Actions{
+				ManageUnitFn: func(interface{}) (rpi.Exec, error) {
+					return rpi.Exec{
+						Name:       actions.ManageUnit,
+						StartTime:  1,
+						EndTime:    2,
+						ExitStatus: 0,
+					}, nil
+				},
+			},
+			ucsys: &mocksys.Action{
+				ExecuteMUFn: func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+					return rpi.Action{
+						Name:          actions.UnitControl,
+						NumberOfSteps: 1,
+						Progress: map[string]rpi.Exec{
+							"1": {
+								Name:       actions.ManageUnit,
+								StartTime:  1,
+								EndTime:    2,
+								ExitStatus: 0,
+							},
+						},
+						ExitStatus: 0,
+						StartTime:  2,
+						EndTime:    3,
+					}, nil
+				},
+			},
+			wantedData: rpi.Action{
+				Name:          actions.UnitControl,
+				NumberOfSteps: 1,
+				Progress: map[string]rpi.Exec{
+					"1": {
+						Name:       actions.ManageUnit,
+						StartTime:  1,
+						EndTime:    2,
+						ExitStatus: 0,
+					},
+				},
+				ExitStatus: 0,
+				StartTime:  2,
+				EndTime:    3,
+			},
+			wantedErr: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := unitcontrol.New(tc.ucsys, tc.actions, tc.infos)
+			result, err := s.ExecuteMU(tc.action, tc.unit)
+			assert.Equal(t, tc.wantedData, result)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
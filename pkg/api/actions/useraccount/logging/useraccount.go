@@ -0,0 +1,131 @@
+package useraccount
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/useraccount"
+)
+
+// New creates a new UserAccount logging service instance.
+func New(svc useraccount.Service, logger rpi.Logger) *LogService {
+	return &LogService{
+		Service: svc,
+		logger:  logger,
+	}
+}
+
+// LogService represents a UserAccount logging service.
+type LogService struct {
+	useraccount.Service
+	logger rpi.Logger
+}
+
+const name = "useraccount"
+
+// ListG is the logging function attached to the ListG useraccount services and responsible for logging it out.
+func (ls *LogService) ListG(ctx echo.Context) (resp []rpi.UserGroup, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, "request: list groups", err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ListG()
+}
+
+// ExecuteAG is the logging function attached to the ExecuteAG useraccount services and responsible for logging it out.
+func (ls *LogService) ExecuteAG(ctx echo.Context, username string, group string) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: add %v to group %v", username, group), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteAG(username, group)
+}
+
+// ExecuteRG is the logging function attached to the ExecuteRG useraccount services and responsible for logging it out.
+func (ls *LogService) ExecuteRG(ctx echo.Context, username string, group string) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: remove %v from group %v", username, group), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteRG(username, group)
+}
+
+// ExecuteLU is the logging function attached to the ExecuteLU useraccount services and responsible for logging it out.
+func (ls *LogService) ExecuteLU(ctx echo.Context, username string) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: lock user %v", username), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteLU(username)
+}
+
+// ExecuteUU is the logging function attached to the ExecuteUU useraccount services and responsible for logging it out.
+func (ls *LogService) ExecuteUU(ctx echo.Context, username string) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: unlock user %v", username), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteUU(username)
+}
+
+// ExecutePE is the logging function attached to the ExecutePE useraccount services and responsible for logging it out.
+func (ls *LogService) ExecutePE(ctx echo.Context, username string, maxDays int, isExpired bool) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: set password expiry of %v maxDays=%v expire=%v", username, maxDays, isExpired), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecutePE(username, maxDays, isExpired)
+}
+
+// ExecuteCS is the logging function attached to the ExecuteCS useraccount services and responsible for logging it out.
+func (ls *LogService) ExecuteCS(ctx echo.Context, username string, shell string) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: change shell of %v to %v", username, shell), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteCS(username, shell)
+}
@@ -0,0 +1,81 @@
+package sys
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// UserAccount represents an empty UserAccount entity on the current system.
+type UserAccount struct{}
+
+// ListG returns the groups of the lines of /etc/group (ex: gpio:x:997:pi,bob), skipping the comments and the invalid lines
+func (ua UserAccount) ListG(lines []string) ([]rpi.UserGroup, error) {
+	result := []rpi.UserGroup{}
+
+	for _, line := range lines {
+		fields := strings.Split(strings.TrimSpace(line), ":")
+		if len(fields) != 4 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		gid, err := strconv.Atoi(fields[2])
+		if err != nil {
+			continue
+		}
+
+		members := []string{}
+		for _, m := range strings.Split(fields[3], ",") {
+			if m = strings.TrimSpace(m); m != "" {
+				members = append(members, m)
+			}
+		}
+
+		result = append(result, rpi.UserGroup{Name: fields[0], Gid: gid, Members: members})
+	}
+
+	return result, nil
+}
+
+// ExecuteUG returns an action response after adding or removing a user to a group
+func (ua UserAccount) ExecuteUG(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return execute(actions.UserGroups, plan)
+}
+
+// ExecuteLU returns an action response after locking a user
+func (ua UserAccount) ExecuteLU(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return execute(actions.LockUser, plan)
+}
+
+// ExecuteUU returns an action response after unlocking a user
+func (ua UserAccount) ExecuteUU(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return execute(actions.UnlockUser, plan)
+}
+
+// ExecutePE returns an action response after setting the password expiry of a user
+func (ua UserAccount) ExecutePE(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return execute(actions.PasswordExpiry, plan)
+}
+
+// ExecuteCS returns an action response after changing the shell of a user
+func (ua UserAccount) ExecuteCS(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return execute(actions.ChangeShell, plan)
+}
+
+func execute(name string, plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	actionStartTime := uint64(time.Now().Unix())
+	progressInit := actions.FlattenPlan(plan)
+	progress, exitStatus := actions.ExecutePlan(plan, progressInit)
+
+	return rpi.Action{
+		Name:          name,
+		NumberOfSteps: uint16(len(progressInit)),
+		Progress:      progress,
+		ExitStatus:    exitStatus,
+		StartTime:     actionStartTime,
+		EndTime:       uint64(time.Now().Unix()),
+	}, nil
+}
@@ -0,0 +1,61 @@
+package sys_test
+
+import (
+	"testing"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/useraccount/platform/sys"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListG(t *testing.T) {
+	s := sys.UserAccount{}
+	result, err := s.ListG([]string{
+		"root:x:0:",
+		"sudo:x:27:pi,bob",
+		"#gpio:x:997:pi",
+		"video:x:44:pi,",
+		"invalid",
+		"spi:x:spi:pi",
+	})
+	assert.Equal(t, []rpi.UserGroup{
+		{Name: "root", Gid: 0, Members: []string{}},
+		{Name: "sudo", Gid: 27, Members: []string{"pi", "bob"}},
+		{Name: "video", Gid: 44, Members: []string{"pi"}},
+	}, result)
+	assert.Nil(t, err)
+}
+
+func TestExecute(t *testing.T) {
+	s := sys.UserAccount{}
+	cases := []struct {
+		name       string
+		execute    func(map[int](map[int]actions.Func)) (rpi.Action, error)
+		wantedName string
+	}{
+		{name: "groups", execute: s.ExecuteUG, wantedName: actions.UserGroups},
+		{name: "lock", execute: s.ExecuteLU, wantedName: actions.LockUser},
+		{name: "unlock", execute: s.ExecuteUU, wantedName: actions.UnlockUser},
+		{name: "password expiry", execute: s.ExecutePE, wantedName: actions.PasswordExpiry},
+		{name: "shell", execute: s.ExecuteCS, wantedName: actions.ChangeShell},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.execute(map[int](map[int]actions.Func){
+				1: {
+					1: {
+						Name:      "funcA",
+						Reference: func(arg interface{}) (rpi.Exec, error) { return rpi.Exec{ExitStatus: 1}, nil },
+						Argument:  []interface{}{actions.EBC{}},
+					},
+				},
+			})
+			assert.Equal(t, tc.wantedName, result.Name)
+			assert.Equal(t, uint16(1), result.NumberOfSteps)
+			assert.Equal(t, uint8(1), result.ExitStatus)
+			assert.Nil(t, err)
+		})
+	}
+}
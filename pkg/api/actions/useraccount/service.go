@@ -0,0 +1,55 @@
+package useraccount
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// Service represents all UserAccount application services.
+type Service interface {
+	ListG() ([]rpi.UserGroup, error)
+	ExecuteAG(string, string) (rpi.Action, error)
+	ExecuteRG(string, string) (rpi.Action, error)
+	ExecuteLU(string) (rpi.Action, error)
+	ExecuteUU(string) (rpi.Action, error)
+	ExecutePE(string, int, bool) (rpi.Action, error)
+	ExecuteCS(string, string) (rpi.Action, error)
+}
+
+// UserAccount represents a UserAccount application service.
+type UserAccount struct {
+	uasys UASYS
+	a     Actions
+	i     Infos
+	hu    HumanUser
+}
+
+// UASYS represents a UserAccount repository service.
+type UASYS interface {
+	ListG([]string) ([]rpi.UserGroup, error)
+	ExecuteUG(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteLU(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteUU(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecutePE(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteCS(map[int](map[int]actions.Func)) (rpi.Action, error)
+}
+
+// Actions represents the actions interface
+type Actions interface {
+	ExecuteBashCommand(interface{}) (rpi.Exec, error)
+}
+
+// Infos represents the infos interface
+type Infos interface {
+	ReadFile(string) ([]string, error)
+}
+
+// HumanUser represents the human user interface
+type HumanUser interface {
+	List() ([]rpi.HumanUser, error)
+}
+
+// New creates a UserAccount application service instance.
+func New(uasys UASYS, a Actions, i Infos, hu HumanUser) *UserAccount {
+	return &UserAccount{uasys: uasys, a: a, i: i, hu: hu}
+}
@@ -0,0 +1,99 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi/pkg/api/actions/useraccount"
+)
+
+// HTTP is a struct implementing a core application service.
+type HTTP struct {
+	svc useraccount.Service
+}
+
+// NewHTTP creates new useraccount http service
+func NewHTTP(svc useraccount.Service, r *echo.Group) {
+	h := HTTP{svc}
+	cr := r.Group("/useraccounts")
+	cr.GET("/groups", h.listGroups)
+	cr.POST("/:username/groups/:group/add", h.addGroup)
+	cr.POST("/:username/groups/:group/remove", h.removeGroup)
+	cr.POST("/:username/lock", h.lock)
+	cr.POST("/:username/unlock", h.unlock)
+	cr.POST("/:username/passwordexpiry", h.passwordExpiry)
+	cr.POST("/:username/shell", h.shell)
+}
+
+func (h *HTTP) listGroups(ctx echo.Context) error {
+	result, err := h.svc.ListG()
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) addGroup(ctx echo.Context) error {
+	result, err := h.svc.ExecuteAG(ctx.Param("username"), ctx.Param("group"))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) removeGroup(ctx echo.Context) error {
+	result, err := h.svc.ExecuteRG(ctx.Param("username"), ctx.Param("group"))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) lock(ctx echo.Context) error {
+	result, err := h.svc.ExecuteLU(ctx.Param("username"))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) unlock(ctx echo.Context) error {
+	result, err := h.svc.ExecuteUU(ctx.Param("username"))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) passwordExpiry(ctx echo.Context) error {
+	maxDays := 0
+	if ctx.QueryParam("maxDays") != "" {
+		var err error
+		if maxDays, err = strconv.Atoi(ctx.QueryParam("maxDays")); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid maxDays - should be a number of days")
+		}
+	}
+
+	isExpired := false
+	if ctx.QueryParam("expire") != "" {
+		var err error
+		if isExpired, err = strconv.ParseBool(ctx.QueryParam("expire")); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid expire - should be true or false")
+		}
+	}
+
+	result, err := h.svc.ExecutePE(ctx.Param("username"), maxDays, isExpired)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) shell(ctx echo.Context) error {
+	result, err := h.svc.ExecuteCS(ctx.Param("username"), ctx.QueryParam("shell"))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,162 @@
+package transport_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/useraccount"
+	"github.com/raspibuddy/rpi/pkg/api/actions/useraccount/transport"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/raspibuddy/rpi/pkg/utl/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserAccount(t *testing.T) {
+	cases := []struct {
+		name         string
+		method       string
+		req          string
+		executeErr   error
+		wantedStatus int
+	}{
+		{
+			name:         "success: list groups",
+			method:       http.MethodGet,
+			req:          "/groups",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "error: invalid group",
+			method:       http.MethodPost,
+			req:          "/pi/groups/root/add",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: unknown user",
+			method:       http.MethodPost,
+			req:          "/root/groups/gpio/add",
+			wantedStatus: http.StatusNotFound,
+		},
+		{
+			name:         "error: ExecuteAG result is nil",
+			method:       http.MethodPost,
+			req:          "/pi/groups/gpio/add",
+			executeErr:   errors.New("test error"),
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:         "success: add to group",
+			method:       http.MethodPost,
+			req:          "/pi/groups/gpio/add",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "success: remove from group",
+			method:       http.MethodPost,
+			req:          "/pi/groups/video/remove",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "success: lock",
+			method:       http.MethodPost,
+			req:          "/pi/lock",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "error: unlocked user",
+			method:       http.MethodPost,
+			req:          "/pi/unlock",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid maxDays",
+			method:       http.MethodPost,
+			req:          "/pi/passwordexpiry?maxDays=never",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid expire",
+			method:       http.MethodPost,
+			req:          "/pi/passwordexpiry?expire=soon",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "success: password expiry",
+			method:       http.MethodPost,
+			req:          "/pi/passwordexpiry?maxDays=90&expire=true",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "error: invalid shell",
+			method:       http.MethodPost,
+			req:          "/pi/shell?shell=/bin/zsh",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "success: change shell",
+			method:       http.MethodPost,
+			req:          "/pi/shell?shell=/bin/sh",
+			wantedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			i := mock.Infos{
+				ReadFileFn: func(path string) ([]string, error) {
+					if path == "/etc/shells" {
+						return []string{"/bin/sh", "/bin/bash"}, nil
+					}
+					return []string{"sudo:x:27:pi", "gpio:x:997:", "video:x:44:pi"}, nil
+				},
+			}
+			hu := mock.HumanUser{
+				ListFn: func() ([]rpi.HumanUser, error) {
+					return []rpi.HumanUser{
+						{Username: "pi", DefaultShell: "/bin/bash", Groups: []string{"pi", "video"}},
+					}, nil
+				},
+			}
+			execute := func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+				return rpi.Action{NumberOfSteps: 1}, tc.executeErr
+			}
+			uasys := &mocksys.UserAccount{
+				ListGFn: func([]string) ([]rpi.UserGroup, error) {
+					return []rpi.UserGroup{{Name: "sudo"}, {Name: "gpio"}, {Name: "video"}}, nil
+				},
+				ExecuteUGFn: execute,
+				ExecuteLUFn: execute,
+				ExecuteUUFn: execute,
+				ExecutePEFn: execute,
+				ExecuteCSFn: execute,
+			}
+			s := useraccount.New(uasys, actions.New(), i, hu)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/useraccounts" + tc.req
+
+			req, err := http.NewRequest(tc.method, path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
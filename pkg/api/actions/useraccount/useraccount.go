@@ -0,0 +1,243 @@
+package useraccount
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/constants"
+)
+
+const (
+	// Admin is the group granting the use of sudo
+	Admin = "sudo"
+
+	// MaxDays is the largest password maximum age, passwords then never expiring
+	MaxDays = 99999
+)
+
+// Groups lists the groups a human user can be added to or removed from
+var Groups = []string{Admin, "gpio", "i2c", "spi", "video", "dialout"}
+
+// ListG returns the groups of /etc/group along with their members, the ones of Groups being managed
+func (ua *UserAccount) ListG() ([]rpi.UserGroup, error) {
+	lines, err := ua.i.ReadFile(constants.ETCGROUP)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the groups")
+	}
+
+	groups, err := ua.uasys.ListG(lines)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the groups")
+	}
+
+	for i := range groups {
+		groups[i].IsManaged = contains(Groups, groups[i].Name)
+	}
+
+	return groups, nil
+}
+
+// ExecuteAG adds a human user to one of Groups, then returns an action
+func (ua *UserAccount) ExecuteAG(username string, group string) (rpi.Action, error) {
+	user, _, err := ua.membership(username, group)
+	if err != nil {
+		return rpi.Action{}, err
+	}
+
+	if contains(user.Groups, group) {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to an existing membership - %v already belongs to %v", username, group))
+	}
+
+	return ua.uasys.ExecuteUG(ua.command(fmt.Sprintf("usermod -aG '%v' '%v'", group, username)))
+}
+
+// ExecuteRG removes a human user from one of Groups, then returns an action.
+// The only unlocked human user of sudo is not removed from it, the device having no administrator left otherwise.
+func (ua *UserAccount) ExecuteRG(username string, group string) (rpi.Action, error) {
+	user, users, err := ua.membership(username, group)
+	if err != nil {
+		return rpi.Action{}, err
+	}
+
+	if !contains(user.Groups, group) {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to a missing membership - %v does not belong to %v", username, group))
+	}
+	if group == Admin && isLastAdmin(users, username) {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to the last administrator - %v is the only unlocked member of %v", username, Admin))
+	}
+
+	return ua.uasys.ExecuteUG(ua.command(fmt.Sprintf("gpasswd -d '%v' '%v'", username, group)))
+}
+
+// ExecuteLU locks the password of a human user and expires its account, then returns an action.
+// Expiring the account also refuses the logins with a ssh key, which a locked password does not.
+func (ua *UserAccount) ExecuteLU(username string) (rpi.Action, error) {
+	user, users, err := ua.user(username)
+	if err != nil {
+		return rpi.Action{}, err
+	}
+
+	if user.IsLocked {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to a locked user - %v is already locked", username))
+	}
+	if isLastAdmin(users, username) {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to the last administrator - %v is the only unlocked member of %v", username, Admin))
+	}
+
+	return ua.uasys.ExecuteLU(ua.command(fmt.Sprintf("usermod -L -e 1 '%v'", username)))
+}
+
+// ExecuteUU unlocks the password of a human user and removes the expiry of its account, then returns an action
+func (ua *UserAccount) ExecuteUU(username string) (rpi.Action, error) {
+	user, _, err := ua.user(username)
+	if err != nil {
+		return rpi.Action{}, err
+	}
+
+	if !user.IsLocked {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to an unlocked user - %v is not locked", username))
+	}
+
+	return ua.uasys.ExecuteUU(ua.command(fmt.Sprintf("usermod -U -e '' '%v'", username)))
+}
+
+// ExecutePE sets the maximum age of the password of a human user, then returns an action.
+// A maxDays of -1 removes the maximum age and 0 leaves it unchanged. When isExpired is true,
+// the password expires at once, the user having to change it at its next login.
+func (ua *UserAccount) ExecutePE(username string, maxDays int, isExpired bool) (rpi.Action, error) {
+	if maxDays < -1 || maxDays > MaxDays {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to an invalid maxDays - should be 1 to %v days, or -1 for no expiry", MaxDays))
+	}
+	if maxDays == 0 && !isExpired {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to missing options - should set maxDays or expire")
+	}
+
+	if _, _, err := ua.user(username); err != nil {
+		return rpi.Action{}, err
+	}
+
+	commands := []string{}
+	if maxDays != 0 {
+		commands = append(commands, fmt.Sprintf("chage -M %v '%v'", maxDays, username))
+	}
+	if isExpired {
+		commands = append(commands, fmt.Sprintf("chage -d 0 '%v'", username))
+	}
+
+	return ua.uasys.ExecutePE(ua.command(strings.Join(commands, " && ")))
+}
+
+// ExecuteCS changes the login shell of a human user to one of the shells of /etc/shells, then returns an action
+func (ua *UserAccount) ExecuteCS(username string, shell string) (rpi.Action, error) {
+	lines, err := ua.i.ReadFile(constants.ETCSHELLS)
+	if err != nil {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusInternalServerError, "could not read the login shells")
+	}
+
+	shells := []string{}
+	for _, l := range lines {
+		if l = strings.TrimSpace(l); l != "" && !strings.HasPrefix(l, "#") {
+			shells = append(shells, l)
+		}
+	}
+	if !contains(shells, shell) {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to an invalid shell - should be one of %v", strings.Join(shells, ", ")))
+	}
+
+	user, _, err := ua.user(username)
+	if err != nil {
+		return rpi.Action{}, err
+	}
+
+	if user.DefaultShell == shell {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to an unchanged shell - %v already uses %v", username, shell))
+	}
+
+	return ua.uasys.ExecuteCS(ua.command(fmt.Sprintf("usermod -s '%v' '%v'", shell, username)))
+}
+
+// user returns the human user having a username, along with all the human users
+func (ua *UserAccount) user(username string) (rpi.HumanUser, []rpi.HumanUser, error) {
+	users, err := ua.hu.List()
+	if err != nil {
+		return rpi.HumanUser{}, nil, err
+	}
+
+	for _, u := range users {
+		if u.Username == username {
+			return u, users, nil
+		}
+	}
+
+	return rpi.HumanUser{}, nil, echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Not found - %v is not a human user", username))
+}
+
+// membership returns the human user having a username, along with all the human users,
+// once checked that the group is one of Groups and exists on the device
+func (ua *UserAccount) membership(username string, group string) (rpi.HumanUser, []rpi.HumanUser, error) {
+	if !contains(Groups, group) {
+		return rpi.HumanUser{}, nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to an invalid group - should be one of %v", strings.Join(Groups, ", ")))
+	}
+
+	user, users, err := ua.user(username)
+	if err != nil {
+		return rpi.HumanUser{}, nil, err
+	}
+
+	groups, err := ua.ListG()
+	if err != nil {
+		return rpi.HumanUser{}, nil, err
+	}
+	for _, g := range groups {
+		if g.Name == group {
+			return user, users, nil
+		}
+	}
+
+	return rpi.HumanUser{}, nil, echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Not found - group %v does not exist", group))
+}
+
+// command returns a plan executing a command
+func (ua *UserAccount) command(command string) map[int](map[int]actions.Func) {
+	return map[int](map[int]actions.Func){
+		1: {
+			1: {
+				Name:      actions.ExecuteBashCommand,
+				Reference: ua.a.ExecuteBashCommand,
+				Argument: []interface{}{
+					actions.EBC{
+						Command: command,
+					},
+				},
+			},
+		},
+	}
+}
+
+// isLastAdmin returns true when a user is the only unlocked human user belonging to sudo
+func isLastAdmin(users []rpi.HumanUser, username string) bool {
+	isAdmin := false
+	for _, u := range users {
+		if u.IsLocked || !contains(u.Groups, Admin) {
+			continue
+		}
+		if u.Username != username {
+			return false
+		}
+		isAdmin = true
+	}
+	return isAdmin
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
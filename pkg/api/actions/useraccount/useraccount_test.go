@@ -0,0 +1,223 @@
+package useraccount_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/useraccount"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/stretchr/testify/assert"
+)
+
+var humanUser = &mock.HumanUser{
+	ListFn: func() ([]rpi.HumanUser, error) {
+		return []rpi.HumanUser{
+			{Username: "pi", DefaultShell: "/bin/bash", Groups: []string{"pi", "sudo", "gpio"}},
+			{Username: "bob", DefaultShell: "/bin/sh", Groups: []string{"bob", "sudo"}, IsLocked: true},
+		}, nil
+	},
+}
+
+func infos(files map[string][]string) *mock.Infos {
+	return &mock.Infos{
+		ReadFileFn: func(path string) ([]string, error) {
+			if lines, ok := files[path]; ok {
+				return lines, nil
+			}
+			return nil, errors.New("test error")
+		},
+	}
+}
+
+func uasys(plan *map[int](map[int]actions.Func)) *mocksys.UserAccount {
+	execute := func(p map[int](map[int]actions.Func)) (rpi.Action, error) {
+		*plan = p
+		return rpi.Action{NumberOfSteps: uint16(len(p))}, nil
+	}
+
+	return &mocksys.UserAccount{
+		ListGFn: func(lines []string) ([]rpi.UserGroup, error) {
+			result := []rpi.UserGroup{}
+			for _, l := range lines {
+				result = append(result, rpi.UserGroup{Name: l})
+			}
+			return result, nil
+		},
+		ExecuteUGFn: execute,
+		ExecuteLUFn: execute,
+		ExecuteUUFn: execute,
+		ExecutePEFn: execute,
+		ExecuteCSFn: execute,
+	}
+}
+
+// steps returns the command of each step of a plan
+func steps(plan map[int](map[int]actions.Func)) []string {
+	result := []string{}
+	for i := 1; i <= len(plan); i++ {
+		result = append(result, plan[i][1].Name+" "+plan[i][1].Argument[0].(actions.EBC).Command)
+	}
+	return result
+}
+
+func TestListG(t *testing.T) {
+	var plan map[int](map[int]actions.Func)
+
+	s := useraccount.New(uasys(&plan), actions.New(), infos(map[string][]string{}), humanUser)
+	_, err := s.ListG()
+	assert.Equal(t, echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the groups"), err)
+
+	s = useraccount.New(uasys(&plan), actions.New(), infos(map[string][]string{"/etc/group": {"root", "sudo", "pi"}}), humanUser)
+	groups, err := s.ListG()
+	assert.Nil(t, err)
+	assert.Equal(t, []rpi.UserGroup{{Name: "root"}, {Name: "sudo", IsManaged: true}, {Name: "pi"}}, groups)
+}
+
+func TestExecute(t *testing.T) {
+	cases := []struct {
+		name        string
+		execute     func(*useraccount.UserAccount) (rpi.Action, error)
+		wantedErr   error
+		wantedSteps []string
+	}{
+		{
+			name:      "error: invalid group",
+			execute:   func(s *useraccount.UserAccount) (rpi.Action, error) { return s.ExecuteAG("pi", "root") },
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid group - should be one of sudo, gpio, i2c, spi, video, dialout"),
+		},
+		{
+			name:      "error: unknown user",
+			execute:   func(s *useraccount.UserAccount) (rpi.Action, error) { return s.ExecuteAG("root", "video") },
+			wantedErr: echo.NewHTTPError(http.StatusNotFound, "Not found - root is not a human user"),
+		},
+		{
+			name:      "error: unknown group",
+			execute:   func(s *useraccount.UserAccount) (rpi.Action, error) { return s.ExecuteAG("pi", "i2c") },
+			wantedErr: echo.NewHTTPError(http.StatusNotFound, "Not found - group i2c does not exist"),
+		},
+		{
+			name:      "error: existing membership",
+			execute:   func(s *useraccount.UserAccount) (rpi.Action, error) { return s.ExecuteAG("pi", "gpio") },
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an existing membership - pi already belongs to gpio"),
+		},
+		{
+			name:        "success: add to group",
+			execute:     func(s *useraccount.UserAccount) (rpi.Action, error) { return s.ExecuteAG("pi", "video") },
+			wantedSteps: []string{"execute_bash_command usermod -aG 'video' 'pi'"},
+		},
+		{
+			name:      "error: missing membership",
+			execute:   func(s *useraccount.UserAccount) (rpi.Action, error) { return s.ExecuteRG("pi", "video") },
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to a missing membership - pi does not belong to video"),
+		},
+		{
+			name:      "error: remove the last administrator",
+			execute:   func(s *useraccount.UserAccount) (rpi.Action, error) { return s.ExecuteRG("pi", "sudo") },
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to the last administrator - pi is the only unlocked member of sudo"),
+		},
+		{
+			name:        "success: remove a locked administrator",
+			execute:     func(s *useraccount.UserAccount) (rpi.Action, error) { return s.ExecuteRG("bob", "sudo") },
+			wantedSteps: []string{"execute_bash_command gpasswd -d 'bob' 'sudo'"},
+		},
+		{
+			name:        "success: remove from group",
+			execute:     func(s *useraccount.UserAccount) (rpi.Action, error) { return s.ExecuteRG("pi", "gpio") },
+			wantedSteps: []string{"execute_bash_command gpasswd -d 'pi' 'gpio'"},
+		},
+		{
+			name:      "error: lock the last administrator",
+			execute:   func(s *useraccount.UserAccount) (rpi.Action, error) { return s.ExecuteLU("pi") },
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to the last administrator - pi is the only unlocked member of sudo"),
+		},
+		{
+			name:      "error: locked user",
+			execute:   func(s *useraccount.UserAccount) (rpi.Action, error) { return s.ExecuteLU("bob") },
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to a locked user - bob is already locked"),
+		},
+		{
+			name:      "error: unlocked user",
+			execute:   func(s *useraccount.UserAccount) (rpi.Action, error) { return s.ExecuteUU("pi") },
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an unlocked user - pi is not locked"),
+		},
+		{
+			name:        "success: unlock",
+			execute:     func(s *useraccount.UserAccount) (rpi.Action, error) { return s.ExecuteUU("bob") },
+			wantedSteps: []string{"execute_bash_command usermod -U -e '' 'bob'"},
+		},
+		{
+			name:      "error: invalid maxDays",
+			execute:   func(s *useraccount.UserAccount) (rpi.Action, error) { return s.ExecutePE("pi", -2, false) },
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid maxDays - should be 1 to 99999 days, or -1 for no expiry"),
+		},
+		{
+			name:      "error: missing options",
+			execute:   func(s *useraccount.UserAccount) (rpi.Action, error) { return s.ExecutePE("pi", 0, false) },
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to missing options - should set maxDays or expire"),
+		},
+		{
+			name:        "success: password expiry",
+			execute:     func(s *useraccount.UserAccount) (rpi.Action, error) { return s.ExecutePE("pi", 90, true) },
+			wantedSteps: []string{"execute_bash_command chage -M 90 'pi' && chage -d 0 'pi'"},
+		},
+		{
+			name:        "success: no password expiry",
+			execute:     func(s *useraccount.UserAccount) (rpi.Action, error) { return s.ExecutePE("pi", -1, false) },
+			wantedSteps: []string{"execute_bash_command chage -M -1 'pi'"},
+		},
+		{
+			name:      "error: invalid shell",
+			execute:   func(s *useraccount.UserAccount) (rpi.Action, error) { return s.ExecuteCS("pi", "/bin/zsh") },
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid shell - should be one of /bin/sh, /bin/bash"),
+		},
+		{
+			name:      "error: unchanged shell",
+			execute:   func(s *useraccount.UserAccount) (rpi.Action, error) { return s.ExecuteCS("pi", "/bin/bash") },
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an unchanged shell - pi already uses /bin/bash"),
+		},
+		{
+			name:        "success: change shell",
+			execute:     func(s *useraccount.UserAccount) (rpi.Action, error) { return s.ExecuteCS("bob", "/bin/bash") },
+			wantedSteps: []string{"execute_bash_command usermod -s '/bin/bash' 'bob'"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var plan map[int](map[int]actions.Func)
+
+			s := useraccount.New(uasys(&plan), actions.New(), infos(map[string][]string{
+				"/etc/group":  {"root", "sudo", "gpio", "video"},
+				"/etc/shells": {"# /etc/shells: valid login shells", "/bin/sh", "/bin/bash"},
+			}), humanUser)
+			_, err := tc.execute(s)
+			assert.Equal(t, tc.wantedErr, err)
+			if tc.wantedErr == nil {
+				assert.Equal(t, tc.wantedSteps, steps(plan))
+			}
+		})
+	}
+}
+
+func TestExecuteLU(t *testing.T) {
+	var plan map[int](map[int]actions.Func)
+
+	hu := &mock.HumanUser{
+		ListFn: func() ([]rpi.HumanUser, error) {
+			return []rpi.HumanUser{
+				{Username: "pi", Groups: []string{"pi", "sudo"}},
+				{Username: "bob", Groups: []string{"bob", "sudo"}},
+			}, nil
+		},
+	}
+
+	s := useraccount.New(uasys(&plan), actions.New(), infos(map[string][]string{}), hu)
+	_, err := s.ExecuteLU("bob")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"execute_bash_command usermod -L -e 1 'bob'"}, steps(plan))
+}
@@ -0,0 +1,147 @@
+package wifi
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/wifi"
+)
+
+// New creates a new Wifi logging service instance.
+func New(svc wifi.Service, logger rpi.Logger) *LogService {
+	return &LogService{
+		Service: svc,
+		logger:  logger,
+	}
+}
+
+// LogService represents a Wifi logging service.
+type LogService struct {
+	wifi.Service
+	logger rpi.Logger
+}
+
+const name = "wifi"
+
+// List is the logging function attached to the List wifi services and responsible for logging it out.
+func (ls *LogService) List(ctx echo.Context, iface string) (resp rpi.Wifi, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: list wifi networks of %v", iface), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.List(iface)
+}
+
+// Scan is the logging function attached to the Scan wifi services and responsible for logging it out.
+func (ls *LogService) Scan(ctx echo.Context, iface string) (resp []rpi.WifiScan, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: list wifi scan results of %v", iface), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.Scan(iface)
+}
+
+// ExecuteWS is the logging function attached to the ExecuteWS wifi services and responsible for logging it out.
+func (ls *LogService) ExecuteWS(ctx echo.Context, iface string) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: scan wifi networks with %v", iface), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteWS(iface)
+}
+
+// ExecuteAWN is the logging function attached to the ExecuteAWN wifi services and responsible for logging it out.
+// The passphrase is never logged.
+func (ls *LogService) ExecuteAWN(ctx echo.Context, iface string, ssid string, passphrase string, isHidden bool, priority int) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: add wifi network %v to %v", ssid, iface), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteAWN(iface, ssid, passphrase, isHidden, priority)
+}
+
+// ExecuteRWN is the logging function attached to the ExecuteRWN wifi services and responsible for logging it out.
+func (ls *LogService) ExecuteRWN(ctx echo.Context, iface string, id int) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: remove wifi network %v from %v", id, iface), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteRWN(iface, id)
+}
+
+// ExecutePWN is the logging function attached to the ExecutePWN wifi services and responsible for logging it out.
+func (ls *LogService) ExecutePWN(ctx echo.Context, iface string, id int, priority int) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: set priority of wifi network %v of %v to %v", id, iface, priority), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecutePWN(iface, id, priority)
+}
+
+// ExecuteSWN is the logging function attached to the ExecuteSWN wifi services and responsible for logging it out.
+func (ls *LogService) ExecuteSWN(ctx echo.Context, iface string, id int) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: select wifi network %v of %v", id, iface), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteSWN(iface, id)
+}
+
+// ExecuteRC is the logging function attached to the ExecuteRC wifi services and responsible for logging it out.
+func (ls *LogService) ExecuteRC(ctx echo.Context, iface string) (resp rpi.Action, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: reconfigure wifi networks of %v", iface), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ExecuteRC(iface)
+}
@@ -0,0 +1,155 @@
+package sys
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/wpaconf"
+)
+
+var flagsRegex = regexp.MustCompile(`\[([^\]]*)\]`)
+
+// Wifi represents an empty Wifi entity on the current system.
+type Wifi struct{}
+
+// List returns the networks configured in wpa_supplicant.conf, flagged with the state reported by
+// 'wpa_cli list_networks' (empty when wpa_supplicant does not control the interface)
+func (w Wifi) List(iface string, isWpaSupCom bool, config []string, networks []string) (rpi.Wifi, error) {
+	c := wpaconf.Parse(config)
+	country, _ := c.Get("country")
+
+	result := rpi.Wifi{
+		Interface:   iface,
+		IsWpaSupCom: isWpaSupCom,
+		Country:     country,
+		Networks:    []rpi.WifiNetwork{},
+	}
+
+	// network id / ssid / bssid / flags
+	flags := map[int]string{}
+	for _, line := range networks {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 4 {
+			continue
+		}
+		if id, err := strconv.Atoi(fields[0]); err == nil {
+			flags[id] = fields[3]
+		}
+	}
+
+	for _, n := range c.Networks() {
+		result.Networks = append(result.Networks, rpi.WifiNetwork{
+			ID:         n.ID,
+			SSID:       n.SSID,
+			KeyMgmt:    n.KeyMgmt,
+			IsSecured:  n.KeyMgmt != wpaconf.Open,
+			IsHidden:   n.IsHidden,
+			Priority:   n.Priority,
+			IsDisabled: n.IsDisabled || strings.Contains(flags[n.ID], "[DISABLED]"),
+			IsCurrent:  strings.Contains(flags[n.ID], "[CURRENT]"),
+		})
+	}
+
+	return result, nil
+}
+
+// Scan returns the networks listed by 'wpa_cli scan_results', strongest signal first
+func (w Wifi) Scan(results []string, wifi rpi.Wifi) ([]rpi.WifiScan, error) {
+	result := []rpi.WifiScan{}
+
+	// bssid / frequency / signal level / flags / ssid
+	for _, line := range results {
+		fields := strings.SplitN(line, "\t", 5)
+		if len(fields) < 4 {
+			continue
+		}
+
+		frequency, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		signal, _ := strconv.Atoi(fields[2])
+
+		s := rpi.WifiScan{
+			BSSID:     fields[0],
+			Frequency: frequency,
+			Signal:    signal,
+			Flags:     []string{},
+		}
+		if len(fields) == 5 {
+			s.SSID = unescape(fields[4])
+		}
+
+		for _, m := range flagsRegex.FindAllStringSubmatch(fields[3], -1) {
+			s.Flags = append(s.Flags, m[1])
+			if strings.HasPrefix(m[1], "WPA") || strings.HasPrefix(m[1], "RSN") || strings.HasPrefix(m[1], "WEP") || strings.HasPrefix(m[1], "SAE") {
+				s.IsSecured = true
+			}
+		}
+
+		for _, n := range wifi.Networks {
+			if s.SSID != "" && n.SSID == s.SSID {
+				s.IsConfigured = true
+			}
+		}
+
+		result = append(result, s)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Signal > result[j].Signal
+	})
+
+	return result, nil
+}
+
+// ExecuteWN returns an action response after adding, removing or prioritizing a network
+func (w Wifi) ExecuteWN(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return execute(actions.WifiNetwork, plan)
+}
+
+// ExecuteWS returns an action response after requesting a scan
+func (w Wifi) ExecuteWS(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return execute(actions.WifiScan, plan)
+}
+
+// ExecuteSWN returns an action response after selecting a network
+func (w Wifi) ExecuteSWN(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return execute(actions.WifiSelect, plan)
+}
+
+// ExecuteRC returns an action response after reloading wpa_supplicant.conf
+func (w Wifi) ExecuteRC(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return execute(actions.WifiReconfigure, plan)
+}
+
+func execute(name string, plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	actionStartTime := uint64(time.Now().Unix())
+	progressInit := actions.FlattenPlan(plan)
+	progress, exitStatus := actions.ExecutePlan(plan, progressInit)
+
+	return rpi.Action{
+		Name:          name,
+		NumberOfSteps: uint16(len(progressInit)),
+		Progress:      progress,
+		ExitStatus:    exitStatus,
+		StartTime:     actionStartTime,
+		EndTime:       uint64(time.Now().Unix()),
+	}, nil
+}
+
+// unescape decodes the escapes wpa_cli uses for the quotes, backslashes and non printable bytes of a ssid (ex: \xc3)
+func unescape(ssid string) string {
+	if !strings.Contains(ssid, `\`) {
+		return ssid
+	}
+	if s, err := strconv.Unquote(`"` + ssid + `"`); err == nil {
+		return s
+	}
+	return ssid
+}
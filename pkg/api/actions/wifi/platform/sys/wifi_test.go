@@ -0,0 +1,129 @@
+package sys_test
+
+import (
+	"testing"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/wifi/platform/sys"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/stretchr/testify/assert"
+)
+
+var config = []string{
+	"ctrl_interface=DIR=/var/run/wpa_supplicant GROUP=netdev",
+	"country=FR",
+	"",
+	"network={",
+	`	ssid="Home"`,
+	"	psk=f42c6fc52df0ebef9ebb4b90b38a5f902e83fe1b135a70e23aed762e9710a12e",
+	"	priority=2",
+	"}",
+	"",
+	"network={",
+	`	ssid="Guest"`,
+	"	key_mgmt=NONE",
+	"	scan_ssid=1",
+	"}",
+}
+
+func TestList(t *testing.T) {
+	cases := []struct {
+		name         string
+		isWpaSupCom  bool
+		networks     []string
+		wantedResult rpi.Wifi
+	}{
+		{
+			name: "not controlled by wpa_supplicant",
+			wantedResult: rpi.Wifi{
+				Interface: "wlan0",
+				Country:   "FR",
+				Networks: []rpi.WifiNetwork{
+					{ID: 0, SSID: "Home", KeyMgmt: "WPA-PSK WPA-EAP", IsSecured: true, Priority: 2},
+					{ID: 1, SSID: "Guest", KeyMgmt: "NONE", IsHidden: true},
+				},
+			},
+		},
+		{
+			name:        "selected network",
+			isWpaSupCom: true,
+			networks: []string{
+				"network id / ssid / bssid / flags",
+				"0\tHome\tany\t[DISABLED]",
+				"1\tGuest\tany\t[CURRENT]",
+			},
+			wantedResult: rpi.Wifi{
+				Interface:   "wlan0",
+				IsWpaSupCom: true,
+				Country:     "FR",
+				Networks: []rpi.WifiNetwork{
+					{ID: 0, SSID: "Home", KeyMgmt: "WPA-PSK WPA-EAP", IsSecured: true, Priority: 2, IsDisabled: true},
+					{ID: 1, SSID: "Guest", KeyMgmt: "NONE", IsHidden: true, IsCurrent: true},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := sys.Wifi{}
+			result, err := s.List("wlan0", tc.isWpaSupCom, config, tc.networks)
+			assert.Equal(t, tc.wantedResult, result)
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestScan(t *testing.T) {
+	s := sys.Wifi{}
+	result, err := s.Scan(
+		[]string{
+			"bssid / frequency / signal level / flags / ssid",
+			"aa:bb:cc:dd:ee:01\t2437\t-71\t[ESS]\tGuest",
+			"aa:bb:cc:dd:ee:02\t5180\t-45\t[WPA2-PSK-CCMP][WPS][ESS]\tHome",
+			"aa:bb:cc:dd:ee:03\t2412\t-80\t[WPA2-PSK-CCMP][ESS]\t",
+			"aa:bb:cc:dd:ee:04\t2462\t-60\t[RSN-SAE-CCMP][ESS]\tCaf\\xc3\\xa9",
+		},
+		rpi.Wifi{Networks: []rpi.WifiNetwork{{SSID: "Home"}, {SSID: "Guest"}}},
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []rpi.WifiScan{
+		{BSSID: "aa:bb:cc:dd:ee:02", SSID: "Home", Frequency: 5180, Signal: -45, Flags: []string{"WPA2-PSK-CCMP", "WPS", "ESS"}, IsSecured: true, IsConfigured: true},
+		{BSSID: "aa:bb:cc:dd:ee:04", SSID: "Café", Frequency: 2462, Signal: -60, Flags: []string{"RSN-SAE-CCMP", "ESS"}, IsSecured: true},
+		{BSSID: "aa:bb:cc:dd:ee:01", SSID: "Guest", Frequency: 2437, Signal: -71, Flags: []string{"ESS"}, IsConfigured: true},
+		{BSSID: "aa:bb:cc:dd:ee:03", Frequency: 2412, Signal: -80, Flags: []string{"WPA2-PSK-CCMP", "ESS"}, IsSecured: true},
+	}, result)
+}
+
+func TestExecute(t *testing.T) {
+	s := sys.Wifi{}
+	cases := []struct {
+		name       string
+		execute    func(map[int](map[int]actions.Func)) (rpi.Action, error)
+		wantedName string
+	}{
+		{name: "network", execute: s.ExecuteWN, wantedName: actions.WifiNetwork},
+		{name: "scan", execute: s.ExecuteWS, wantedName: actions.WifiScan},
+		{name: "select", execute: s.ExecuteSWN, wantedName: actions.WifiSelect},
+		{name: "reconfigure", execute: s.ExecuteRC, wantedName: actions.WifiReconfigure},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.execute(map[int](map[int]actions.Func){
+				1: {
+					1: {
+						Name:      "funcA",
+						Reference: func(arg interface{}) (rpi.Exec, error) { return rpi.Exec{ExitStatus: 1}, nil },
+						Argument:  []interface{}{actions.EBC{}},
+					},
+				},
+			})
+			assert.Equal(t, tc.wantedName, result.Name)
+			assert.Equal(t, uint16(1), result.NumberOfSteps)
+			assert.Equal(t, uint8(1), result.ExitStatus)
+			assert.Nil(t, err)
+		})
+	}
+}
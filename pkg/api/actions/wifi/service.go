@@ -0,0 +1,53 @@
+package wifi
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// Service represents all Wifi application services.
+type Service interface {
+	List(string) (rpi.Wifi, error)
+	Scan(string) ([]rpi.WifiScan, error)
+	ExecuteWS(string) (rpi.Action, error)
+	ExecuteAWN(string, string, string, bool, int) (rpi.Action, error)
+	ExecuteRWN(string, int) (rpi.Action, error)
+	ExecutePWN(string, int, int) (rpi.Action, error)
+	ExecuteSWN(string, int) (rpi.Action, error)
+	ExecuteRC(string) (rpi.Action, error)
+}
+
+// Wifi represents a Wifi application service.
+type Wifi struct {
+	wsys WSYS
+	a    Actions
+	i    Infos
+}
+
+// WSYS represents a Wifi repository service.
+type WSYS interface {
+	List(string, bool, []string, []string) (rpi.Wifi, error)
+	Scan([]string, rpi.Wifi) ([]rpi.WifiScan, error)
+	ExecuteWN(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteWS(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteSWN(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteRC(map[int](map[int]actions.Func)) (rpi.Action, error)
+}
+
+// Actions represents the actions interface
+type Actions interface {
+	EditWpaSupplicant(interface{}) (rpi.Exec, error)
+	ExecuteBashCommand(interface{}) (rpi.Exec, error)
+}
+
+// Infos represents the infos interface
+type Infos interface {
+	ReadFile(string) ([]string, error)
+	IsWpaSupCom() map[string]bool
+	WpaCli(string, ...string) ([]string, error)
+}
+
+// New creates a Wifi application service instance.
+func New(wsys WSYS, a Actions, i Infos) *Wifi {
+	return &Wifi{wsys: wsys, a: a, i: i}
+}
@@ -0,0 +1,170 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi/pkg/api/actions/wifi"
+)
+
+// DefaultInterface is the wifi interface used when none is given
+const DefaultInterface = "wlan0"
+
+// HTTP is a struct implementing a core application service.
+type HTTP struct {
+	svc wifi.Service
+}
+
+// NewHTTP creates new wifi http service
+func NewHTTP(svc wifi.Service, r *echo.Group) {
+	h := HTTP{svc}
+	cr := r.Group("/wifi")
+	cr.GET("", h.list)
+	cr.GET("/scan", h.scanResults)
+	cr.POST("/scan", h.scan)
+	cr.POST("/add", h.add)
+	cr.POST("/remove/:id", h.remove)
+	cr.POST("/priority/:id", h.priority)
+	cr.POST("/select/:id", h.selectNetwork)
+	cr.POST("/reconfigure", h.reconfigure)
+}
+
+// iface returns the iface query parameter, wlan0 by default
+func iface(ctx echo.Context) string {
+	if iface := ctx.QueryParam("iface"); iface != "" {
+		return iface
+	}
+	return DefaultInterface
+}
+
+// passphrase returns the passphrase of the form body. The query string is refused since it ends up in the logs.
+func passphrase(ctx echo.Context) (string, error) {
+	if ctx.QueryParam("passphrase") != "" {
+		return "", echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to a passphrase in the query - should be sent in the form body")
+	}
+	return ctx.Request().PostFormValue("passphrase"), nil
+}
+
+// networkID returns the network id path parameter
+func networkID(ctx echo.Context) (int, error) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || id < 0 {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid id - should be a network id")
+	}
+	return id, nil
+}
+
+// networkPriority returns the priority query parameter, 0 by default
+func networkPriority(ctx echo.Context) (int, error) {
+	if ctx.QueryParam("priority") == "" {
+		return 0, nil
+	}
+	priority, err := strconv.Atoi(ctx.QueryParam("priority"))
+	if err != nil || priority < 0 {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid priority - should be a positive number")
+	}
+	return priority, nil
+}
+
+func (h *HTTP) list(ctx echo.Context) error {
+	result, err := h.svc.List(iface(ctx))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) scanResults(ctx echo.Context) error {
+	result, err := h.svc.Scan(iface(ctx))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) scan(ctx echo.Context) error {
+	result, err := h.svc.ExecuteWS(iface(ctx))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) add(ctx echo.Context) error {
+	isHidden := false
+	if ctx.QueryParam("hidden") != "" {
+		var err error
+		if isHidden, err = strconv.ParseBool(ctx.QueryParam("hidden")); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid hidden - should be true or false")
+		}
+	}
+
+	priority, err := networkPriority(ctx)
+	if err != nil {
+		return err
+	}
+
+	psk, err := passphrase(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.svc.ExecuteAWN(iface(ctx), ctx.QueryParam("ssid"), psk, isHidden, priority)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) remove(ctx echo.Context) error {
+	id, err := networkID(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.svc.ExecuteRWN(iface(ctx), id)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) priority(ctx echo.Context) error {
+	id, err := networkID(ctx)
+	if err != nil {
+		return err
+	}
+
+	priority, err := networkPriority(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.svc.ExecutePWN(iface(ctx), id, priority)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) selectNetwork(ctx echo.Context) error {
+	id, err := networkID(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.svc.ExecuteSWN(iface(ctx), id)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) reconfigure(ctx echo.Context) error {
+	result, err := h.svc.ExecuteRC(iface(ctx))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,193 @@
+package transport_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/wifi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/wifi/transport"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/raspibuddy/rpi/pkg/utl/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWifi(t *testing.T) {
+	cases := []struct {
+		name         string
+		method       string
+		req          string
+		body         string
+		executeErr   error
+		wantedStatus int
+	}{
+		{
+			name:         "success: list",
+			method:       http.MethodGet,
+			req:          "",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "error: unknown interface",
+			method:       http.MethodGet,
+			req:          "?iface=eth0",
+			wantedStatus: http.StatusNotFound,
+		},
+		{
+			name:         "success: scan results",
+			method:       http.MethodGet,
+			req:          "/scan",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "error: interface not controlled",
+			method:       http.MethodPost,
+			req:          "/scan?iface=wlan1",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "success: scan",
+			method:       http.MethodPost,
+			req:          "/scan",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "error: invalid hidden",
+			method:       http.MethodPost,
+			req:          "/add?ssid=Home&hidden=maybe",
+			body:         "passphrase=password",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid priority",
+			method:       http.MethodPost,
+			req:          "/add?ssid=Home&priority=-1",
+			body:         "passphrase=password",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid passphrase of the form body",
+			method:       http.MethodPost,
+			req:          "/add?ssid=Home",
+			body:         "passphrase=short",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: passphrase in the query",
+			method:       http.MethodPost,
+			req:          "/add?ssid=Home&passphrase=password",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: ExecuteAWN result is nil",
+			method:       http.MethodPost,
+			req:          "/add?ssid=Home",
+			body:         "passphrase=password",
+			executeErr:   errors.New("test error"),
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:         "success: add",
+			method:       http.MethodPost,
+			req:          "/add?ssid=Home&hidden=true&priority=2",
+			body:         "passphrase=password",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "error: invalid id",
+			method:       http.MethodPost,
+			req:          "/remove/home",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: unknown id",
+			method:       http.MethodPost,
+			req:          "/remove/3",
+			wantedStatus: http.StatusNotFound,
+		},
+		{
+			name:         "success: remove",
+			method:       http.MethodPost,
+			req:          "/remove/0",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "success: priority",
+			method:       http.MethodPost,
+			req:          "/priority/0?priority=5",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "success: select",
+			method:       http.MethodPost,
+			req:          "/select/0",
+			wantedStatus: http.StatusOK,
+		},
+		{
+			name:         "success: reconfigure",
+			method:       http.MethodPost,
+			req:          "/reconfigure?iface=wlan0",
+			wantedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			i := mock.Infos{
+				ReadFileFn: func(string) ([]string, error) {
+					return []string{}, nil
+				},
+				IsWpaSupComFn: func() map[string]bool {
+					return map[string]bool{"wlan0": true, "wlan1": false}
+				},
+				WpaCliFn: func(string, ...string) ([]string, error) {
+					return []string{}, nil
+				},
+			}
+			execute := func(map[int](map[int]actions.Func)) (rpi.Action, error) {
+				return rpi.Action{NumberOfSteps: 1}, tc.executeErr
+			}
+			wsys := &mocksys.Wifi{
+				ListFn: func(iface string, isWpaSupCom bool, config []string, networks []string) (rpi.Wifi, error) {
+					return rpi.Wifi{Interface: iface, IsWpaSupCom: isWpaSupCom, Networks: []rpi.WifiNetwork{{ID: 0, SSID: "Home"}}}, nil
+				},
+				ScanFn: func([]string, rpi.Wifi) ([]rpi.WifiScan, error) {
+					return []rpi.WifiScan{}, nil
+				},
+				ExecuteWNFn:  execute,
+				ExecuteWSFn:  execute,
+				ExecuteSWNFn: execute,
+				ExecuteRCFn:  execute,
+			}
+			s := wifi.New(wsys, actions.New(), i)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/wifi" + tc.req
+
+			req, err := http.NewRequest(tc.method, path, strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
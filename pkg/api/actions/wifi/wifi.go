@@ -0,0 +1,206 @@
+package wifi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/constants"
+	"github.com/raspibuddy/rpi/pkg/utl/wpaconf"
+)
+
+// List populates and returns the Wifi model of a wifi interface.
+func (w *Wifi) List(iface string) (rpi.Wifi, error) {
+	isWpaSupCom, isFound := w.i.IsWpaSupCom()[iface]
+	if !isFound {
+		return rpi.Wifi{}, echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Not found - %v is not a wifi interface", iface))
+	}
+
+	config, err := w.i.ReadFile(constants.WPASUPPLICANT)
+	if err != nil {
+		return rpi.Wifi{}, echo.NewHTTPError(http.StatusInternalServerError, "could not read the wpa_supplicant config")
+	}
+
+	// the runtime state of the networks is only known while wpa_supplicant controls the interface
+	networks := []string{}
+	if isWpaSupCom {
+		networks, _ = w.i.WpaCli(iface, "list_networks")
+	}
+
+	return w.wsys.List(iface, isWpaSupCom, config, networks)
+}
+
+// Scan returns the networks found by the last scan of a wifi interface.
+func (w *Wifi) Scan(iface string) ([]rpi.WifiScan, error) {
+	wifi, err := w.controlled(iface)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := w.i.WpaCli(iface, "scan_results")
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "could not read the scan results")
+	}
+
+	return w.wsys.Scan(results, wifi)
+}
+
+// ExecuteWS requests a scan of a wifi interface and returns an action.
+// The results are available a few seconds later.
+func (w *Wifi) ExecuteWS(iface string) (rpi.Action, error) {
+	if _, err := w.controlled(iface); err != nil {
+		return rpi.Action{}, err
+	}
+
+	plan := w.commands(fmt.Sprintf("wpa_cli -i %v scan", iface))
+
+	return w.wsys.ExecuteWS(plan)
+}
+
+// ExecuteAWN adds a network to wpa_supplicant.conf, or replaces the network having the same ssid,
+// then reloads the config and returns an action. The passphrase is stored as its PBKDF2 hash,
+// no passphrase adding an open network.
+func (w *Wifi) ExecuteAWN(iface string, ssid string, passphrase string, isHidden bool, priority int) (rpi.Action, error) {
+	if _, err := w.controlled(iface); err != nil {
+		return rpi.Action{}, err
+	}
+
+	if len(ssid) == 0 || len(ssid) > 32 {
+		return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid ssid - should be 1 to 32 bytes long")
+	}
+
+	psk := ""
+	if passphrase != "" {
+		var err error
+		if psk, err = wpaconf.PSK(ssid, passphrase); err != nil {
+			return rpi.Action{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid passphrase - should be 8 to 63 printable ASCII characters")
+		}
+	}
+
+	return w.executeWN(iface, wpaconf.Add, wpaconf.Network{
+		SSID:     ssid,
+		PSK:      psk,
+		IsHidden: isHidden,
+		Priority: priority,
+	})
+}
+
+// ExecuteRWN removes a network from wpa_supplicant.conf, then reloads the config and returns an action.
+func (w *Wifi) ExecuteRWN(iface string, id int) (rpi.Action, error) {
+	if _, err := w.network(iface, id); err != nil {
+		return rpi.Action{}, err
+	}
+
+	return w.executeWN(iface, wpaconf.Remove, wpaconf.Network{ID: id})
+}
+
+// ExecutePWN sets the priority of a network in wpa_supplicant.conf, then reloads the config and returns an action.
+// The network having the highest priority is preferred when several are in range.
+func (w *Wifi) ExecutePWN(iface string, id int, priority int) (rpi.Action, error) {
+	if _, err := w.network(iface, id); err != nil {
+		return rpi.Action{}, err
+	}
+
+	return w.executeWN(iface, wpaconf.Priority, wpaconf.Network{ID: id, Priority: priority})
+}
+
+// ExecuteSWN connects a wifi interface to a network and returns an action.
+// The other networks are disabled until the config is reloaded.
+func (w *Wifi) ExecuteSWN(iface string, id int) (rpi.Action, error) {
+	if _, err := w.network(iface, id); err != nil {
+		return rpi.Action{}, err
+	}
+
+	plan := w.commands(fmt.Sprintf("wpa_cli -i %v select_network %v", iface, id))
+
+	return w.wsys.ExecuteSWN(plan)
+}
+
+// ExecuteRC reloads wpa_supplicant.conf and returns an action.
+func (w *Wifi) ExecuteRC(iface string) (rpi.Action, error) {
+	if _, err := w.controlled(iface); err != nil {
+		return rpi.Action{}, err
+	}
+
+	plan := w.commands(fmt.Sprintf("wpa_cli -i %v reconfigure", iface))
+
+	return w.wsys.ExecuteRC(plan)
+}
+
+// executeWN applies an operation to a network of wpa_supplicant.conf and reloads it
+func (w *Wifi) executeWN(iface string, operation string, network wpaconf.Network) (rpi.Action, error) {
+	plan := map[int](map[int]actions.Func){
+		1: {
+			1: {
+				Name:      actions.EditWpaSupplicant,
+				Reference: w.a.EditWpaSupplicant,
+				Argument: []interface{}{
+					actions.WPO{
+						Path:      constants.WPASUPPLICANT,
+						Operation: operation,
+						Network:   network,
+					},
+				},
+			},
+		},
+	}
+
+	for k, v := range w.commands(fmt.Sprintf("wpa_cli -i %v reconfigure", iface)) {
+		plan[len(plan)+k] = v
+	}
+
+	return w.wsys.ExecuteWN(plan)
+}
+
+// controlled returns the Wifi model of an interface controlled by wpa_supplicant
+func (w *Wifi) controlled(iface string) (rpi.Wifi, error) {
+	wifi, err := w.List(iface)
+	if err != nil {
+		return rpi.Wifi{}, err
+	}
+
+	if !wifi.IsWpaSupCom {
+		return rpi.Wifi{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request due to an unavailable interface - wpa_supplicant does not control %v", iface))
+	}
+
+	return wifi, nil
+}
+
+// network returns a network configured for an interface controlled by wpa_supplicant
+func (w *Wifi) network(iface string, id int) (rpi.WifiNetwork, error) {
+	wifi, err := w.controlled(iface)
+	if err != nil {
+		return rpi.WifiNetwork{}, err
+	}
+
+	for _, n := range wifi.Networks {
+		if n.ID == id {
+			return n, nil
+		}
+	}
+
+	return rpi.WifiNetwork{}, echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Not found - no network with id %v", id))
+}
+
+// commands returns a plan running each command in its own step, in order
+func (w *Wifi) commands(commands ...string) map[int](map[int]actions.Func) {
+	plan := map[int](map[int]actions.Func){}
+
+	for _, c := range commands {
+		plan[len(plan)+1] = map[int]actions.Func{
+			1: {
+				Name:      actions.ExecuteBashCommand,
+				Reference: w.a.ExecuteBashCommand,
+				Argument: []interface{}{
+					actions.EBC{
+						Command: c,
+					},
+				},
+			},
+		}
+	}
+
+	return plan
+}
@@ -0,0 +1,191 @@
+package wifi_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/actions/wifi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/constants"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/raspibuddy/rpi/pkg/utl/wpaconf"
+	"github.com/stretchr/testify/assert"
+)
+
+func infos(readErr error, wpaErr error) *mock.Infos {
+	return &mock.Infos{
+		ReadFileFn: func(string) ([]string, error) {
+			return []string{}, readErr
+		},
+		IsWpaSupComFn: func() map[string]bool {
+			return map[string]bool{"wlan0": true, "wlan1": false}
+		},
+		WpaCliFn: func(iface string, args ...string) ([]string, error) {
+			return args, wpaErr
+		},
+	}
+}
+
+func wsys(plan *map[int](map[int]actions.Func), cli *[]string) *mocksys.Wifi {
+	execute := func(p map[int](map[int]actions.Func)) (rpi.Action, error) {
+		*plan = p
+		return rpi.Action{NumberOfSteps: uint16(len(p))}, nil
+	}
+
+	return &mocksys.Wifi{
+		ListFn: func(iface string, isWpaSupCom bool, config []string, networks []string) (rpi.Wifi, error) {
+			*cli = networks
+			return rpi.Wifi{
+				Interface:   iface,
+				IsWpaSupCom: isWpaSupCom,
+				Networks:    []rpi.WifiNetwork{{ID: 0, SSID: "Home"}},
+			}, nil
+		},
+		ScanFn: func(results []string, w rpi.Wifi) ([]rpi.WifiScan, error) {
+			*cli = results
+			return []rpi.WifiScan{{SSID: "Home", IsConfigured: true}}, nil
+		},
+		ExecuteWNFn:  execute,
+		ExecuteWSFn:  execute,
+		ExecuteSWNFn: execute,
+		ExecuteRCFn:  execute,
+	}
+}
+
+func TestList(t *testing.T) {
+	var plan map[int](map[int]actions.Func)
+	var cli []string
+
+	s := wifi.New(wsys(&plan, &cli), actions.New(), infos(nil, nil))
+	_, err := s.List("eth0")
+	assert.Equal(t, echo.NewHTTPError(http.StatusNotFound, "Not found - eth0 is not a wifi interface"), err)
+
+	s = wifi.New(wsys(&plan, &cli), actions.New(), infos(errors.New("test error"), nil))
+	_, err = s.List("wlan0")
+	assert.Equal(t, echo.NewHTTPError(http.StatusInternalServerError, "could not read the wpa_supplicant config"), err)
+
+	s = wifi.New(wsys(&plan, &cli), actions.New(), infos(nil, nil))
+	result, err := s.List("wlan0")
+	assert.Nil(t, err)
+	assert.True(t, result.IsWpaSupCom)
+	assert.Equal(t, []string{"list_networks"}, cli)
+
+	result, err = s.List("wlan1")
+	assert.Nil(t, err)
+	assert.False(t, result.IsWpaSupCom)
+	assert.Equal(t, []string{}, cli)
+}
+
+func TestScan(t *testing.T) {
+	var plan map[int](map[int]actions.Func)
+	var cli []string
+
+	s := wifi.New(wsys(&plan, &cli), actions.New(), infos(nil, nil))
+	_, err := s.Scan("wlan1")
+	assert.Equal(t, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an unavailable interface - wpa_supplicant does not control wlan1"), err)
+
+	result, err := s.Scan("wlan0")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(result))
+	assert.Equal(t, []string{"scan_results"}, cli)
+
+	s = wifi.New(wsys(&plan, &cli), actions.New(), infos(nil, errors.New("test error")))
+	_, err = s.Scan("wlan0")
+	assert.Equal(t, echo.NewHTTPError(http.StatusInternalServerError, "could not read the scan results"), err)
+}
+
+func TestExecuteAWN(t *testing.T) {
+	cases := []struct {
+		name          string
+		ssid          string
+		passphrase    string
+		wantedNetwork wpaconf.Network
+		wantedErr     error
+	}{
+		{
+			name:      "error: invalid ssid",
+			ssid:      "",
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid ssid - should be 1 to 32 bytes long"),
+		},
+		{
+			name:       "error: invalid passphrase",
+			ssid:       "IEEE",
+			passphrase: "short",
+			wantedErr:  echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid passphrase - should be 8 to 63 printable ASCII characters"),
+		},
+		{
+			name:       "success: psk",
+			ssid:       "IEEE",
+			passphrase: "password",
+			wantedNetwork: wpaconf.Network{
+				SSID:     "IEEE",
+				PSK:      "f42c6fc52df0ebef9ebb4b90b38a5f902e83fe1b135a70e23aed762e9710a12e",
+				IsHidden: true,
+				Priority: 3,
+			},
+		},
+		{
+			name:          "success: open",
+			ssid:          "Guest",
+			wantedNetwork: wpaconf.Network{SSID: "Guest", IsHidden: true, Priority: 3},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var plan map[int](map[int]actions.Func)
+			var cli []string
+
+			s := wifi.New(wsys(&plan, &cli), actions.New(), infos(nil, nil))
+			result, err := s.ExecuteAWN("wlan0", tc.ssid, tc.passphrase, true, 3)
+			assert.Equal(t, tc.wantedErr, err)
+
+			if tc.wantedErr == nil {
+				assert.Equal(t, uint16(2), result.NumberOfSteps)
+				assert.Equal(t, actions.WPO{
+					Path:      constants.WPASUPPLICANT,
+					Operation: wpaconf.Add,
+					Network:   tc.wantedNetwork,
+				}, plan[1][1].Argument[0].(actions.WPO))
+				assert.Equal(t, "wpa_cli -i wlan0 reconfigure", plan[2][1].Argument[0].(actions.EBC).Command)
+			}
+		})
+	}
+}
+
+func TestExecuteNetwork(t *testing.T) {
+	var plan map[int](map[int]actions.Func)
+	var cli []string
+
+	s := wifi.New(wsys(&plan, &cli), actions.New(), infos(nil, nil))
+
+	_, err := s.ExecuteRWN("wlan0", 4)
+	assert.Equal(t, echo.NewHTTPError(http.StatusNotFound, "Not found - no network with id 4"), err)
+	_, err = s.ExecuteSWN("wlan1", 0)
+	assert.Equal(t, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an unavailable interface - wpa_supplicant does not control wlan1"), err)
+
+	_, err = s.ExecuteRWN("wlan0", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, actions.WPO{Path: constants.WPASUPPLICANT, Operation: wpaconf.Remove, Network: wpaconf.Network{ID: 0}}, plan[1][1].Argument[0].(actions.WPO))
+
+	_, err = s.ExecutePWN("wlan0", 0, 7)
+	assert.Nil(t, err)
+	assert.Equal(t, actions.WPO{Path: constants.WPASUPPLICANT, Operation: wpaconf.Priority, Network: wpaconf.Network{ID: 0, Priority: 7}}, plan[1][1].Argument[0].(actions.WPO))
+	assert.Equal(t, "wpa_cli -i wlan0 reconfigure", plan[2][1].Argument[0].(actions.EBC).Command)
+
+	_, err = s.ExecuteSWN("wlan0", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "wpa_cli -i wlan0 select_network 0", plan[1][1].Argument[0].(actions.EBC).Command)
+
+	_, err = s.ExecuteWS("wlan0")
+	assert.Nil(t, err)
+	assert.Equal(t, "wpa_cli -i wlan0 scan", plan[1][1].Argument[0].(actions.EBC).Command)
+
+	_, err = s.ExecuteRC("wlan0")
+	assert.Nil(t, err)
+	assert.Equal(t, "wpa_cli -i wlan0 reconfigure", plan[1][1].Argument[0].(actions.EBC).Command)
+}
@@ -0,0 +1,151 @@
+package bundle
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/constants"
+)
+
+// Export reads the tracked files and returns them as a gzipped tar archive.
+func (b *Bundle) Export() ([]byte, error) {
+	var files []rpi.BundleFile
+
+	for _, t := range b.tracked() {
+		paths := []string{t}
+		if strings.HasSuffix(t, "*") {
+			paths = b.i.ListFiles(t)
+		}
+
+		for _, path := range paths {
+			content, mode, err := b.i.ReadFileBytes(path)
+			// not every tracked file exists on every device
+			if err != nil {
+				continue
+			}
+			files = append(files, rpi.BundleFile{Path: path, Mode: mode, Content: content})
+		}
+	}
+
+	installed, err := b.i.InstalledPackages()
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the installed packages")
+	}
+
+	return b.bsys.Export(b.hostname(), uint64(time.Now().Unix()), files, installed)
+}
+
+// Import validates an archive and returns its differences with the device.
+// When apply is set, the added and modified files are restored, after being backed up,
+// and the missing packages are installed.
+func (b *Bundle) Import(archive []byte, apply bool) (rpi.BundleImport, error) {
+	manifest, files, err := b.bsys.Open(archive, b.tracked())
+	if err != nil {
+		return rpi.BundleImport{}, err
+	}
+
+	current := make(map[string][]byte)
+	for _, f := range files {
+		if content, _, err := b.i.ReadFileBytes(f.Path); err == nil {
+			current[f.Path] = content
+		}
+	}
+
+	installed, err := b.i.InstalledPackages()
+	if err != nil {
+		return rpi.BundleImport{}, echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the installed packages")
+	}
+
+	result, err := b.bsys.Diff(manifest, files, current, installed)
+	if err != nil || !apply {
+		return result, err
+	}
+
+	contents := make(map[string]rpi.BundleFile)
+	for _, f := range files {
+		contents[f.Path] = f
+	}
+
+	backupDir := filepath.Join(constants.BACKUPS, strconv.FormatInt(time.Now().Unix(), 10))
+	plan := map[int](map[int]actions.Func){}
+
+	for _, d := range result.Files {
+		if d.Status == "unchanged" {
+			continue
+		}
+		plan[len(plan)+1] = map[int]actions.Func{
+			1: {
+				Name:      actions.RestoreFile,
+				Reference: b.a.RestoreFile,
+				Argument: []interface{}{
+					actions.RF{
+						Path:    d.Path,
+						Backup:  backupDir + d.Path,
+						Content: contents[d.Path].Content,
+						Mode:    contents[d.Path].Mode,
+					},
+				},
+			},
+		}
+	}
+
+	if len(result.MissingPackages) > 0 {
+		plan[len(plan)+1] = map[int]actions.Func{
+			1: {
+				Name:      actions.ExecuteBashCommand,
+				Reference: b.a.ExecuteBashCommand,
+				Argument: []interface{}{
+					actions.EBC{
+						Command: fmt.Sprintf("apt-get install -y %v", strings.Join(result.MissingPackages, " ")),
+					},
+				},
+			},
+		}
+	}
+
+	if len(plan) == 0 {
+		return result, nil
+	}
+
+	action, err := b.bsys.ExecuteIMP(plan)
+	if err != nil {
+		return result, err
+	}
+	result.Action = &action
+
+	return result, nil
+}
+
+// tracked lists the paths exported: the config files, the kernel modules, the wifi networks,
+// the groups and the vpn configs. A path ending with * stands for every file below it.
+func (b *Bundle) tracked() []string {
+	var result []string
+	for _, f := range b.i.GetConfigFiles() {
+		result = append(result, f.Path)
+	}
+	sort.Strings(result)
+
+	return append(
+		result,
+		constants.ETCGROUP,
+		constants.ETCMODULES,
+		constants.WPASUPPLICANT,
+		constants.OPENVPN+"/wov_*",
+	)
+}
+
+func (b *Bundle) hostname() string {
+	lines, err := b.i.ReadFile(b.i.GetConfigFiles()["hostname"].Path)
+	if err != nil || len(lines) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(lines[0])
+}
@@ -0,0 +1,184 @@
+package bundle_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/admin/bundle"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/stretchr/testify/assert"
+)
+
+func infos(installed []string, err error) mock.Infos {
+	return mock.Infos{
+		GetConfigFilesFn: func() map[string]rpi.ConfigFileDetails {
+			return map[string]rpi.ConfigFileDetails{
+				"hosts":    {Path: "/etc/hosts"},
+				"hostname": {Path: "/etc/hostname"},
+			}
+		},
+		ReadFileFn: func(string) ([]string, error) {
+			return []string{"raspberrypi"}, nil
+		},
+		ReadFileBytesFn: func(path string) ([]byte, uint32, error) {
+			switch path {
+			case "/etc/hosts":
+				return []byte("127.0.0.1 localhost\n"), 0644, nil
+			case "/etc/openvpn/wov_nordvpn/vpnconfigs/fr.ovpn":
+				return []byte("remote 1.2.3.4\n"), 0600, nil
+			}
+			return nil, 0, errors.New("no such file")
+		},
+		ListFilesFn: func(pattern string) []string {
+			if pattern == "/etc/openvpn/wov_*" {
+				return []string{"/etc/openvpn/wov_nordvpn/vpnconfigs/fr.ovpn"}
+			}
+			return nil
+		},
+		InstalledPackagesFn: func() ([]string, error) {
+			return installed, err
+		},
+	}
+}
+
+func TestExport(t *testing.T) {
+	cases := []struct {
+		name       string
+		infos      mock.Infos
+		bsys       mocksys.Bundle
+		wantedData []byte
+		wantedErr  error
+	}{
+		{
+			name:       "error: installed packages",
+			infos:      infos(nil, errors.New("test error")),
+			wantedData: nil,
+			wantedErr:  echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the installed packages"),
+		},
+		{
+			name:  "success",
+			infos: infos([]string{"vim"}, nil),
+			bsys: mocksys.Bundle{
+				ExportFn: func(hostname string, _ uint64, files []rpi.BundleFile, installed []string) ([]byte, error) {
+					assert.Equal(t, "raspberrypi", hostname)
+					assert.Equal(t, []rpi.BundleFile{
+						{Path: "/etc/hosts", Mode: 0644, Content: []byte("127.0.0.1 localhost\n")},
+						{Path: "/etc/openvpn/wov_nordvpn/vpnconfigs/fr.ovpn", Mode: 0600, Content: []byte("remote 1.2.3.4\n")},
+					}, files)
+					assert.Equal(t, []string{"vim"}, installed)
+					return []byte("archive"), nil
+				},
+			},
+			wantedData: []byte("archive"),
+			wantedErr:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := bundle.New(tc.bsys, mock.Actions{}, tc.infos)
+			result, err := s.Export()
+			assert.Equal(t, tc.wantedData, result)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestImport(t *testing.T) {
+	files := []rpi.BundleFile{
+		{Path: "/etc/hosts", Kind: "file", Mode: 0644, Content: []byte("127.0.0.1 localhost\n")},
+		{Path: "/etc/modules", Kind: "file", Mode: 0644, Content: []byte("i2c-dev\n")},
+	}
+	diff := rpi.BundleImport{
+		Files: []rpi.BundleDiff{
+			{Path: "/etc/hosts", Status: "unchanged"},
+			{Path: "/etc/modules", Status: "added"},
+		},
+		MissingPackages: []string{"git", "htop"},
+	}
+
+	cases := []struct {
+		name       string
+		apply      bool
+		bsys       mocksys.Bundle
+		wantedData rpi.BundleImport
+		wantedErr  error
+	}{
+		{
+			name: "error: invalid archive",
+			bsys: mocksys.Bundle{
+				OpenFn: func([]byte, []string) (rpi.BundleManifest, []rpi.BundleFile, error) {
+					return rpi.BundleManifest{}, nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid archive - manifest is missing")
+				},
+			},
+			wantedData: rpi.BundleImport{},
+			wantedErr:  echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid archive - manifest is missing"),
+		},
+		{
+			name:  "success: diff only",
+			apply: false,
+			bsys: mocksys.Bundle{
+				OpenFn: func(_ []byte, tracked []string) (rpi.BundleManifest, []rpi.BundleFile, error) {
+					assert.Equal(t, []string{
+						"/etc/hostname",
+						"/etc/hosts",
+						"/etc/group",
+						"/etc/modules",
+						"/etc/wpa_supplicant/wpa_supplicant.conf",
+						"/etc/openvpn/wov_*",
+					}, tracked)
+					return rpi.BundleManifest{}, files, nil
+				},
+				DiffFn: func(_ rpi.BundleManifest, _ []rpi.BundleFile, current map[string][]byte, _ []string) (rpi.BundleImport, error) {
+					assert.Equal(t, map[string][]byte{"/etc/hosts": []byte("127.0.0.1 localhost\n")}, current)
+					return diff, nil
+				},
+			},
+			wantedData: diff,
+			wantedErr:  nil,
+		},
+		{
+			name:  "success: apply",
+			apply: true,
+			bsys: mocksys.Bundle{
+				OpenFn: func([]byte, []string) (rpi.BundleManifest, []rpi.BundleFile, error) {
+					return rpi.BundleManifest{}, files, nil
+				},
+				DiffFn: func(rpi.BundleManifest, []rpi.BundleFile, map[string][]byte, []string) (rpi.BundleImport, error) {
+					return diff, nil
+				},
+				ExecuteIMPFn: func(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+					assert.Equal(t, 2, len(plan))
+					rf := plan[1][1].Argument[0].(actions.RF)
+					assert.Equal(t, "/etc/modules", rf.Path)
+					assert.True(t, strings.HasPrefix(rf.Backup, "/etc/raspibuddy/backups/"))
+					assert.True(t, strings.HasSuffix(rf.Backup, "/etc/modules"))
+					assert.Equal(t, []byte("i2c-dev\n"), rf.Content)
+					assert.Equal(t, actions.EBC{Command: "apt-get install -y git htop"}, plan[2][1].Argument[0])
+					return rpi.Action{Name: actions.ImportConfiguration, NumberOfSteps: 2}, nil
+				},
+			},
+			wantedData: rpi.BundleImport{
+				Files:           diff.Files,
+				MissingPackages: diff.MissingPackages,
+				Action:          &rpi.Action{Name: actions.ImportConfiguration, NumberOfSteps: 2},
+			},
+			wantedErr: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := bundle.New(tc.bsys, mock.Actions{}, infos([]string{"vim"}, nil))
+			result, err := s.Import([]byte("archive"), tc.apply)
+			assert.Equal(t, tc.wantedData, result)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
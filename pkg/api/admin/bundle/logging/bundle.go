@@ -0,0 +1,59 @@
+package bundle
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/admin/bundle"
+)
+
+// New creates a new bundle logging service instance.
+func New(svc bundle.Service, logger rpi.Logger) *LogService {
+	return &LogService{
+		Service: svc,
+		logger:  logger,
+	}
+}
+
+// LogService represents a bundle logging service.
+type LogService struct {
+	bundle.Service
+	logger rpi.Logger
+}
+
+const name = "bundle"
+
+// Export is the logging function attached to the Export service and responsible for logging it out.
+func (ls *LogService) Export(ctx echo.Context) (resp []byte, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name,
+			"request: export configuration",
+			err,
+			map[string]interface{}{
+				"size": len(resp),
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.Export()
+}
+
+// Import is the logging function attached to the Import service and responsible for logging it out.
+func (ls *LogService) Import(ctx echo.Context, archive []byte, apply bool) (resp rpi.BundleImport, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name,
+			"request: import configuration",
+			err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.Import(archive, apply)
+}
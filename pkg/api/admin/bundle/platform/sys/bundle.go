@@ -0,0 +1,354 @@
+package sys
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/constants"
+)
+
+// packageRegex is the regex used to validate a debian package name
+var packageRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9+.\-]+$`)
+
+// Bundle represents an empty Bundle entity on the current system.
+type Bundle struct{}
+
+const (
+	// manifestName is the name of the manifest in the archive
+	manifestName = "manifest.json"
+
+	// packages is the path of the installed packages list in the manifest
+	packages = "packages"
+)
+
+// Export builds a gzipped tar archive holding the files, the installed packages list and their manifest
+func (b Bundle) Export(hostname string, createdAt uint64, files []rpi.BundleFile, installed []string) ([]byte, error) {
+	files = append(files, rpi.BundleFile{
+		Path:    packages,
+		Mode:    actions.DefaultFilePerm,
+		Content: []byte(strings.Join(installed, "\n") + "\n"),
+	})
+
+	manifest := rpi.BundleManifest{
+		Hostname:  hostname,
+		CreatedAt: createdAt,
+		Entries:   []rpi.BundleEntry{},
+	}
+	for _, f := range files {
+		manifest.Entries = append(manifest.Entries, rpi.BundleEntry{
+			Path:   f.Path,
+			Kind:   Kind(f.Path),
+			Mode:   f.Mode,
+			Size:   int64(len(f.Content)),
+			SHA256: Checksum(f.Content),
+		})
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "could not build the archive")
+	}
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	modTime := time.Unix(int64(createdAt), 0)
+
+	if err := write(tw, manifestName, actions.DefaultFilePerm, modTime, data); err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "could not build the archive")
+	}
+	for _, f := range files {
+		if err := write(tw, Name(f.Path), f.Mode, modTime, f.Content); err != nil {
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, "could not build the archive")
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "could not build the archive")
+	}
+	if err := gw.Close(); err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "could not build the archive")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Open validates an archive against its manifest and returns the files it holds.
+// Every file must be listed in the manifest with a matching checksum, a mode readable by its owner and belong to the tracked paths.
+func (b Bundle) Open(archive []byte, tracked []string) (rpi.BundleManifest, []rpi.BundleFile, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return rpi.BundleManifest{}, nil, invalid("not a gzipped tar archive")
+	}
+
+	members := make(map[string][]byte)
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return rpi.BundleManifest{}, nil, invalid("not a gzipped tar archive")
+		}
+		if hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeRegA {
+			return rpi.BundleManifest{}, nil, invalid(hdr.Name + " is not a regular file")
+		}
+		content, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return rpi.BundleManifest{}, nil, invalid("not a gzipped tar archive")
+		}
+		members[hdr.Name] = content
+	}
+
+	var manifest rpi.BundleManifest
+	data, ok := members[manifestName]
+	if !ok {
+		return rpi.BundleManifest{}, nil, invalid("manifest is missing")
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return rpi.BundleManifest{}, nil, invalid("manifest is not valid")
+	}
+	delete(members, manifestName)
+
+	files := []rpi.BundleFile{}
+	for _, e := range manifest.Entries {
+		if !IsTracked(e.Path, tracked) || e.Kind != Kind(e.Path) {
+			return rpi.BundleManifest{}, nil, invalid(e.Path + " is not tracked")
+		}
+
+		content, ok := members[Name(e.Path)]
+		if !ok {
+			return rpi.BundleManifest{}, nil, invalid(e.Path + " is missing")
+		}
+		if int64(len(content)) != e.Size || Checksum(content) != e.SHA256 {
+			return rpi.BundleManifest{}, nil, invalid("checksum mismatch for " + e.Path)
+		}
+		delete(members, Name(e.Path))
+
+		// the mode ends up on the restored file: no special bits, and root keeps reading it
+		mode := e.Mode & 0777
+		if mode&0400 == 0 {
+			return rpi.BundleManifest{}, nil, invalid("mode of " + e.Path + " is not valid")
+		}
+
+		// package names end up in an apt-get command line
+		if e.Path == packages {
+			for _, name := range Names(content) {
+				if !packageRegex.MatchString(name) {
+					return rpi.BundleManifest{}, nil, invalid("package name " + name + " is not valid")
+				}
+			}
+		}
+
+		files = append(files, rpi.BundleFile{
+			Path:    e.Path,
+			Kind:    e.Kind,
+			Mode:    mode,
+			Content: content,
+		})
+	}
+
+	for name := range members {
+		return rpi.BundleManifest{}, nil, invalid(name + " is not listed in the manifest")
+	}
+
+	return manifest, files, nil
+}
+
+// Diff compares the archived files to the ones on the device.
+// Files are reported as added, modified or unchanged; users, groups and packages
+// are reported when they are missing on the device.
+func (b Bundle) Diff(manifest rpi.BundleManifest, files []rpi.BundleFile, current map[string][]byte, installed []string) (rpi.BundleImport, error) {
+	result := rpi.BundleImport{
+		Manifest:        manifest,
+		Files:           []rpi.BundleDiff{},
+		MissingUsers:    []string{},
+		MissingGroups:   []string{},
+		MissingPackages: []string{},
+	}
+
+	for _, f := range files {
+		switch f.Path {
+		case constants.ETCPASSWD:
+			result.MissingUsers = missing(Usernames(f.Content), Usernames(current[f.Path]))
+		case constants.ETCGROUP:
+			result.MissingGroups = missing(Names(f.Content), Names(current[f.Path]))
+		case packages:
+			result.MissingPackages = missing(Names(f.Content), installed)
+		default:
+			content, ok := current[f.Path]
+			diff := rpi.BundleDiff{Path: f.Path}
+			switch {
+			case !ok:
+				diff.Status = "added"
+			case bytes.Equal(content, f.Content):
+				diff.Status = "unchanged"
+			default:
+				diff.Status = "modified"
+				if utf8.Valid(content) && utf8.Valid(f.Content) {
+					diff.Added, diff.Removed = LineDiff(content, f.Content)
+				}
+			}
+			result.Files = append(result.Files, diff)
+		}
+	}
+
+	return result, nil
+}
+
+// ExecuteIMP returns an action response after restoring the archived files
+func (b Bundle) ExecuteIMP(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	actionStartTime := uint64(time.Now().Unix())
+	progressInit := actions.FlattenPlan(plan)
+	progress, exitStatus := actions.ExecutePlan(plan, progressInit)
+
+	return rpi.Action{
+		Name:          actions.ImportConfiguration,
+		NumberOfSteps: uint16(len(progressInit)),
+		Progress:      progress,
+		ExitStatus:    exitStatus,
+		StartTime:     actionStartTime,
+		EndTime:       uint64(time.Now().Unix()),
+	}, nil
+}
+
+// Kind returns "list" for the files which are only compared (users, groups and packages)
+// and "file" for the ones which are restored
+func Kind(path string) string {
+	if path == constants.ETCPASSWD || path == constants.ETCGROUP || path == packages {
+		return "list"
+	}
+	return "file"
+}
+
+// Name returns the name of a file in the archive
+func Name(path string) string {
+	if Kind(path) == "list" {
+		return "lists/" + filepath.Base(path)
+	}
+	return "files" + path
+}
+
+// Checksum returns the hex encoded sha256 of a content
+func Checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// IsTracked checks if a path is one of the tracked paths.
+// A tracked path ending with * matches every path starting with it.
+func IsTracked(path string, tracked []string) bool {
+	if path == packages {
+		return true
+	}
+	if !filepath.IsAbs(path) || filepath.Clean(path) != path {
+		return false
+	}
+
+	for _, t := range tracked {
+		if t == path || (strings.HasSuffix(t, "*") && strings.HasPrefix(path, strings.TrimSuffix(t, "*"))) {
+			return true
+		}
+	}
+	return false
+}
+
+// Usernames returns the human users (uid >= 1000, except nobody) of a passwd file
+func Usernames(passwd []byte) []string {
+	var result []string
+	for _, line := range strings.Split(string(passwd), "\n") {
+		fields := strings.Split(line, ":")
+		if len(fields) != 7 || fields[0] == "nobody" {
+			continue
+		}
+		if uid, err := strconv.Atoi(fields[2]); err == nil && uid >= 1000 {
+			result = append(result, fields[0])
+		}
+	}
+	return result
+}
+
+// Names returns the first field of every line of a group file or a packages list
+func Names(content []byte) []string {
+	var result []string
+	for _, line := range strings.Split(string(content), "\n") {
+		if name := strings.TrimSpace(strings.Split(line, ":")[0]); name != "" && !strings.HasPrefix(name, "#") {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// LineDiff returns the lines added to and removed from a content
+func LineDiff(old []byte, new []byte) ([]string, []string) {
+	var added, removed []string
+
+	count := make(map[string]int)
+	for _, line := range strings.Split(string(old), "\n") {
+		count[line]++
+	}
+	for _, line := range strings.Split(string(new), "\n") {
+		if count[line] > 0 {
+			count[line]--
+		} else {
+			added = append(added, line)
+		}
+	}
+	for _, line := range strings.Split(string(old), "\n") {
+		if count[line] > 0 {
+			count[line]--
+			removed = append(removed, line)
+		}
+	}
+
+	return added, removed
+}
+
+// missing returns the wanted items which are not in have
+func missing(wanted []string, have []string) []string {
+	result := []string{}
+	set := make(map[string]bool)
+	for _, h := range have {
+		set[h] = true
+	}
+	for _, w := range wanted {
+		if !set[w] {
+			result = append(result, w)
+		}
+	}
+	return result
+}
+
+func invalid(reason string) error {
+	return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid archive - "+reason)
+}
+
+func write(tw *tar.Writer, name string, mode uint32, modTime time.Time, content []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    int64(mode),
+		Size:    int64(len(content)),
+		ModTime: modTime,
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(content)
+	return err
+}
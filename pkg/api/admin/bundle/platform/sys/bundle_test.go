@@ -0,0 +1,267 @@
+package sys_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/admin/bundle/platform/sys"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/test_utl"
+	"github.com/stretchr/testify/assert"
+)
+
+var tracked = []string{"/etc/hosts", "/etc/hostname", "/etc/passwd", "/etc/openvpn/wov_*"}
+
+// archive builds a gzipped tar archive holding a manifest and members
+func archive(t *testing.T, manifest interface{}, members map[string]string) []byte {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+
+	if manifest != nil {
+		data, _ := json.Marshal(manifest)
+		members["manifest.json"] = string(data)
+	}
+	for name, content := range members {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tw.Close()
+	gw.Close()
+
+	return buf.Bytes()
+}
+
+func entry(path string, kind string, content string) rpi.BundleEntry {
+	return rpi.BundleEntry{
+		Path:   path,
+		Kind:   kind,
+		Mode:   0644,
+		Size:   int64(len(content)),
+		SHA256: sys.Checksum([]byte(content)),
+	}
+}
+
+func TestExportOpen(t *testing.T) {
+	files := []rpi.BundleFile{
+		{Path: "/etc/hostname", Mode: 0644, Content: []byte("raspberrypi\n")},
+		{Path: "/etc/passwd", Mode: 0644, Content: []byte("pi:x:1000:1000:,,,:/home/pi:/bin/bash\n")},
+		{Path: "/etc/openvpn/wov_nordvpn/vpnconfigs/fr.ovpn", Mode: 0600, Content: []byte("remote 1.2.3.4\n")},
+	}
+
+	s := sys.Bundle{}
+	data, err := s.Export("raspberrypi", 1600000000, files, []string{"git", "vim"})
+	assert.Nil(t, err)
+
+	manifest, opened, err := s.Open(data, tracked)
+	assert.Nil(t, err)
+	assert.Equal(t, "raspberrypi", manifest.Hostname)
+	assert.Equal(t, uint64(1600000000), manifest.CreatedAt)
+	assert.Equal(t, []rpi.BundleEntry{
+		entry("/etc/hostname", "file", "raspberrypi\n"),
+		entry("/etc/passwd", "list", "pi:x:1000:1000:,,,:/home/pi:/bin/bash\n"),
+		{
+			Path:   "/etc/openvpn/wov_nordvpn/vpnconfigs/fr.ovpn",
+			Kind:   "file",
+			Mode:   0600,
+			Size:   15,
+			SHA256: sys.Checksum([]byte("remote 1.2.3.4\n")),
+		},
+		entry("packages", "list", "git\nvim\n"),
+	}, manifest.Entries)
+	assert.Equal(t, []rpi.BundleFile{
+		{Path: "/etc/hostname", Kind: "file", Mode: 0644, Content: []byte("raspberrypi\n")},
+		{Path: "/etc/passwd", Kind: "list", Mode: 0644, Content: []byte("pi:x:1000:1000:,,,:/home/pi:/bin/bash\n")},
+		{Path: "/etc/openvpn/wov_nordvpn/vpnconfigs/fr.ovpn", Kind: "file", Mode: 0600, Content: []byte("remote 1.2.3.4\n")},
+		{Path: "packages", Kind: "list", Mode: 0644, Content: []byte("git\nvim\n")},
+	}, opened)
+}
+
+func TestOpen(t *testing.T) {
+	cases := []struct {
+		name      string
+		archive   func(t *testing.T) []byte
+		wantedErr error
+	}{
+		{
+			name: "error: not an archive",
+			archive: func(t *testing.T) []byte {
+				return []byte("dummy")
+			},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid archive - not a gzipped tar archive"),
+		},
+		{
+			name: "error: manifest missing",
+			archive: func(t *testing.T) []byte {
+				return archive(t, nil, map[string]string{"files/etc/hosts": "127.0.0.1 localhost\n"})
+			},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid archive - manifest is missing"),
+		},
+		{
+			name: "error: path traversal",
+			archive: func(t *testing.T) []byte {
+				m := rpi.BundleManifest{Entries: []rpi.BundleEntry{entry("/etc/openvpn/wov_a/../../shadow", "file", "x")}}
+				return archive(t, m, map[string]string{"files/etc/openvpn/wov_a/../../shadow": "x"})
+			},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid archive - /etc/openvpn/wov_a/../../shadow is not tracked"),
+		},
+		{
+			name: "error: list restored as a file",
+			archive: func(t *testing.T) []byte {
+				m := rpi.BundleManifest{Entries: []rpi.BundleEntry{entry("/etc/passwd", "file", "x")}}
+				return archive(t, m, map[string]string{"lists/passwd": "x"})
+			},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid archive - /etc/passwd is not tracked"),
+		},
+		{
+			name: "error: file missing",
+			archive: func(t *testing.T) []byte {
+				m := rpi.BundleManifest{Entries: []rpi.BundleEntry{entry("/etc/hosts", "file", "x")}}
+				return archive(t, m, map[string]string{})
+			},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid archive - /etc/hosts is missing"),
+		},
+		{
+			name: "error: checksum mismatch",
+			archive: func(t *testing.T) []byte {
+				m := rpi.BundleManifest{Entries: []rpi.BundleEntry{entry("/etc/hosts", "file", "x")}}
+				return archive(t, m, map[string]string{"files/etc/hosts": "y"})
+			},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid archive - checksum mismatch for /etc/hosts"),
+		},
+		{
+			name: "error: mode not readable by the owner",
+			archive: func(t *testing.T) []byte {
+				e := entry("/etc/hosts", "file", "x")
+				e.Mode = 0044
+				m := rpi.BundleManifest{Entries: []rpi.BundleEntry{e}}
+				return archive(t, m, map[string]string{"files/etc/hosts": "x"})
+			},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid archive - mode of /etc/hosts is not valid"),
+		},
+		{
+			name: "error: unlisted file",
+			archive: func(t *testing.T) []byte {
+				return archive(t, rpi.BundleManifest{}, map[string]string{"files/etc/shadow": "x"})
+			},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid archive - files/etc/shadow is not listed in the manifest"),
+		},
+		{
+			name: "error: invalid package name",
+			archive: func(t *testing.T) []byte {
+				m := rpi.BundleManifest{Entries: []rpi.BundleEntry{entry("packages", "list", "vim\n;reboot\n")}}
+				return archive(t, m, map[string]string{"lists/packages": "vim\n;reboot\n"})
+			},
+			wantedErr: echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid archive - package name ;reboot is not valid"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := sys.Bundle{}
+			_, _, err := s.Open(tc.archive(t), tracked)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+
+	// the special bits are dropped
+	e := entry("/etc/hosts", "file", "x")
+	e.Mode = 04755
+	_, files, err := sys.Bundle{}.Open(archive(t, rpi.BundleManifest{Entries: []rpi.BundleEntry{e}}, map[string]string{"files/etc/hosts": "x"}), tracked)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(0755), files[0].Mode)
+}
+
+func TestDiff(t *testing.T) {
+	manifest := rpi.BundleManifest{Hostname: "raspberrypi"}
+	files := []rpi.BundleFile{
+		{Path: "/etc/hostname", Kind: "file", Content: []byte("raspberrypi\n")},
+		{Path: "/etc/hosts", Kind: "file", Content: []byte("127.0.0.1 localhost\n127.0.1.1 raspberrypi\n")},
+		{Path: "/etc/modules", Kind: "file", Content: []byte("i2c-dev\n")},
+		{Path: "/etc/passwd", Kind: "list", Content: []byte("root:x:0:0:root:/root:/bin/bash\npi:x:1000:1000:,,,:/home/pi:/bin/bash\nbob:x:1001:1001:,,,:/home/bob:/bin/bash\n")},
+		{Path: "/etc/group", Kind: "list", Content: []byte("root:x:0:\ngpio:x:997:pi\n")},
+		{Path: "packages", Kind: "list", Content: []byte("git\nvim\n")},
+	}
+	current := map[string][]byte{
+		"/etc/hostname": []byte("raspberrypi\n"),
+		"/etc/hosts":    []byte("127.0.0.1 localhost\n127.0.1.1 pi-old\n"),
+		"/etc/passwd":   []byte("root:x:0:0:root:/root:/bin/bash\npi:x:1000:1000:,,,:/home/pi:/bin/bash\n"),
+		"/etc/group":    []byte("root:x:0:\n"),
+	}
+
+	s := sys.Bundle{}
+	result, err := s.Diff(manifest, files, current, []string{"vim"})
+	assert.Nil(t, err)
+	assert.Equal(t, rpi.BundleImport{
+		Manifest: manifest,
+		Files: []rpi.BundleDiff{
+			{Path: "/etc/hostname", Status: "unchanged"},
+			{
+				Path:    "/etc/hosts",
+				Status:  "modified",
+				Added:   []string{"127.0.1.1 raspberrypi"},
+				Removed: []string{"127.0.1.1 pi-old"},
+			},
+			{Path: "/etc/modules", Status: "added"},
+		},
+		MissingUsers:    []string{"bob"},
+		MissingGroups:   []string{"gpio"},
+		MissingPackages: []string{"git"},
+	}, result)
+}
+
+func TestIsTracked(t *testing.T) {
+	cases := []struct {
+		name   string
+		path   string
+		wanted bool
+	}{
+		{name: "exact path", path: "/etc/hosts", wanted: true},
+		{name: "path below a tracked directory", path: "/etc/openvpn/wov_ipvanish/vpnconfigs/fr.ovpn", wanted: true},
+		{name: "packages list", path: "packages", wanted: true},
+		{name: "untracked path", path: "/etc/shadow", wanted: false},
+		{name: "relative path", path: "etc/hosts", wanted: false},
+		{name: "path traversal", path: "/etc/openvpn/wov_a/../../shadow", wanted: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wanted, sys.IsTracked(tc.path, tracked))
+		})
+	}
+}
+
+func TestExecuteIMP(t *testing.T) {
+	plan := map[int](map[int]actions.Func){
+		1: {
+			1: {
+				Name:      actions.RestoreFile,
+				Reference: test_utl.FuncA,
+				Argument: []interface{}{
+					test_utl.ArgFuncA{
+						Arg0: "string0",
+						Arg1: "string1",
+					},
+				},
+			},
+		},
+	}
+
+	s := sys.Bundle{}
+	result, err := s.ExecuteIMP(plan)
+	assert.Equal(t, "import_configuration", result.Name)
+	assert.Equal(t, uint16(1), result.NumberOfSteps)
+	assert.Equal(t, "string0-string1", result.Progress["1<|>1"].Stdout)
+	assert.Equal(t, uint8(0), result.ExitStatus)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,47 @@
+package bundle
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// Service represents all Bundle application services.
+type Service interface {
+	Export() ([]byte, error)
+	Import([]byte, bool) (rpi.BundleImport, error)
+}
+
+// Bundle represents a Bundle application service.
+type Bundle struct {
+	bsys BSYS
+	a    Actions
+	i    Infos
+}
+
+// BSYS represents a Bundle repository service.
+type BSYS interface {
+	Export(string, uint64, []rpi.BundleFile, []string) ([]byte, error)
+	Open([]byte, []string) (rpi.BundleManifest, []rpi.BundleFile, error)
+	Diff(rpi.BundleManifest, []rpi.BundleFile, map[string][]byte, []string) (rpi.BundleImport, error)
+	ExecuteIMP(map[int](map[int]actions.Func)) (rpi.Action, error)
+}
+
+// Actions represents the actions interface
+type Actions interface {
+	RestoreFile(interface{}) (rpi.Exec, error)
+	ExecuteBashCommand(interface{}) (rpi.Exec, error)
+}
+
+// Infos represents the infos interface
+type Infos interface {
+	GetConfigFiles() map[string]rpi.ConfigFileDetails
+	ReadFile(string) ([]string, error)
+	ReadFileBytes(string) ([]byte, uint32, error)
+	ListFiles(string) []string
+	InstalledPackages() ([]string, error)
+}
+
+// New creates a Bundle application service instance.
+func New(bsys BSYS, a Actions, i Infos) *Bundle {
+	return &Bundle{bsys: bsys, a: a, i: i}
+}
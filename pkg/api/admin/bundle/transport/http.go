@@ -0,0 +1,60 @@
+package transport
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi/pkg/api/admin/bundle"
+)
+
+// HTTP is a struct implementing a bundle application service.
+type HTTP struct {
+	svc bundle.Service
+}
+
+// maxArchiveSize is the maximum size, in bytes, of an imported archive
+const maxArchiveSize = 64 << 20
+
+// NewHTTP creates new bundle http service
+func NewHTTP(svc bundle.Service, r *echo.Group) {
+	h := HTTP{svc}
+	r.GET("/export", h.export)
+	r.POST("/import", h.importArchive)
+}
+
+func (h *HTTP) export(ctx echo.Context) error {
+	result, err := h.svc.Export()
+	if err != nil {
+		return err
+	}
+
+	ctx.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="raspibuddy-export.tar.gz"`)
+	return ctx.Blob(http.StatusOK, "application/gzip", result)
+}
+
+func (h *HTTP) importArchive(ctx echo.Context) error {
+	apply := false
+	if a := ctx.QueryParam("apply"); a != "" {
+		var err error
+		if apply, err = strconv.ParseBool(a); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid apply - should be true or false")
+		}
+	}
+
+	archive, err := ioutil.ReadAll(io.LimitReader(ctx.Request().Body, maxArchiveSize+1))
+	if err != nil || len(archive) == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an empty archive")
+	}
+	if len(archive) > maxArchiveSize {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an archive bigger than 64MB")
+	}
+
+	result, err := h.svc.Import(archive, apply)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,159 @@
+package transport_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/admin/bundle"
+	"github.com/raspibuddy/rpi/pkg/api/admin/bundle/transport"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/raspibuddy/rpi/pkg/utl/server"
+	"github.com/stretchr/testify/assert"
+)
+
+var i = mock.Infos{
+	GetConfigFilesFn: func() map[string]rpi.ConfigFileDetails {
+		return map[string]rpi.ConfigFileDetails{}
+	},
+	ReadFileFn: func(string) ([]string, error) {
+		return []string{"raspberrypi"}, nil
+	},
+	ReadFileBytesFn: func(string) ([]byte, uint32, error) {
+		return nil, 0, errors.New("no such file")
+	},
+	ListFilesFn: func(string) []string {
+		return nil
+	},
+	InstalledPackagesFn: func() ([]string, error) {
+		return []string{}, nil
+	},
+}
+
+func TestExport(t *testing.T) {
+	cases := []struct {
+		name         string
+		bsys         *mocksys.Bundle
+		wantedStatus int
+		wantedResp   []byte
+	}{
+		{
+			name: "error: Export result is nil",
+			bsys: &mocksys.Bundle{
+				ExportFn: func(string, uint64, []rpi.BundleFile, []string) ([]byte, error) {
+					return nil, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			bsys: &mocksys.Bundle{
+				ExportFn: func(string, uint64, []rpi.BundleFile, []string) ([]byte, error) {
+					return []byte("archive"), nil
+				},
+			},
+			wantedStatus: http.StatusOK,
+			wantedResp:   []byte("archive"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			s := bundle.New(tc.bsys, mock.Actions{}, i)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+			defer ts.Close()
+
+			res, err := http.Get(ts.URL + "/export")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+
+			if tc.wantedResp != nil {
+				body, _ := ioutil.ReadAll(res.Body)
+				assert.Equal(t, tc.wantedResp, body)
+				assert.Equal(t, "application/gzip", res.Header.Get("Content-Type"))
+				assert.Equal(t, `attachment; filename="raspibuddy-export.tar.gz"`, res.Header.Get("Content-Disposition"))
+			}
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestImport(t *testing.T) {
+	cases := []struct {
+		name         string
+		req          string
+		body         []byte
+		bsys         *mocksys.Bundle
+		wantedStatus int
+	}{
+		{
+			name:         "error: invalid apply",
+			req:          "?apply=maybe",
+			body:         []byte("archive"),
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: empty archive",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: archive too big",
+			body:         make([]byte, 64<<20+1),
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error: Open result is nil",
+			body: []byte("archive"),
+			bsys: &mocksys.Bundle{
+				OpenFn: func([]byte, []string) (rpi.BundleManifest, []rpi.BundleFile, error) {
+					return rpi.BundleManifest{}, nil, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			req:  "?apply=false",
+			body: []byte("archive"),
+			bsys: &mocksys.Bundle{
+				OpenFn: func([]byte, []string) (rpi.BundleManifest, []rpi.BundleFile, error) {
+					return rpi.BundleManifest{}, nil, nil
+				},
+				DiffFn: func(rpi.BundleManifest, []rpi.BundleFile, map[string][]byte, []string) (rpi.BundleImport, error) {
+					return rpi.BundleImport{}, nil
+				},
+			},
+			wantedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			s := bundle.New(tc.bsys, mock.Actions{}, i)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+			defer ts.Close()
+
+			res, err := http.Post(ts.URL+"/import"+tc.req, "application/gzip", bytes.NewReader(tc.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package desiredstate
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/constants"
+)
+
+// Drift reads the current state of the device and returns how it differs from the desired one.
+func (ds *DesiredState) Drift(desired rpi.DesiredState) (rpi.DriftReport, error) {
+	observed, err := ds.observe(desired)
+	if err != nil {
+		return rpi.DriftReport{}, err
+	}
+
+	return ds.dssys.Drift(desired, observed)
+}
+
+// Reconcile applies the configure and appinstall actions needed to converge to the desired state.
+func (ds *DesiredState) Reconcile(desired rpi.DesiredState) (rpi.Reconciliation, error) {
+	report, err := ds.Drift(desired)
+	if err != nil {
+		return rpi.Reconciliation{}, err
+	}
+
+	// packages are installed first because some settings (vnc) depend on them
+	var steps []func() (rpi.Action, error)
+	var pkgs []string
+	for _, d := range report.Drifts {
+		if d.Field == "package" {
+			pkgs = append(pkgs, d.Desired)
+		}
+	}
+	if len(pkgs) > 0 {
+		steps = append(steps, func() (rpi.Action, error) {
+			return ds.ap.AppInstall.ExecuteAG("install", strings.Join(pkgs, actions.Separator))
+		})
+	}
+
+	// every step is resolved before executing anything so that
+	// a document which cannot be applied leaves the device untouched
+	for _, d := range report.Drifts {
+		step, err := ds.converge(desired, d)
+		if err != nil {
+			return rpi.Reconciliation{}, err
+		}
+		if step != nil {
+			steps = append(steps, step)
+		}
+	}
+
+	result := rpi.Reconciliation{
+		Drifts:  report.Drifts,
+		Actions: []rpi.Action{},
+	}
+	for _, step := range steps {
+		action, err := step()
+		if err != nil {
+			return result, err
+		}
+		result.Actions = append(result.Actions, action)
+		if action.ExitStatus > result.ExitStatus {
+			result.ExitStatus = action.ExitStatus
+		}
+	}
+
+	return result, nil
+}
+
+// observe gathers the current readings compared to the desired state
+func (ds *DesiredState) observe(desired rpi.DesiredState) (rpi.ObservedState, error) {
+	hostname, errH := ds.i.ReadFile(ds.i.GetConfigFiles()["hostname"].Path)
+	rpInterface, errI := ds.r.RpInterface.List()
+	boot, errB := ds.r.Boot.List()
+	display, errD := ds.r.Display.List()
+	users, errU := ds.r.HumanUser.List()
+
+	if errH != nil || errI != nil || errB != nil || errD != nil || errU != nil {
+		return rpi.ObservedState{}, echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the current state")
+	}
+
+	observed := rpi.ObservedState{
+		RpInterface: rpInterface,
+		Boot:        boot,
+		Display:     display,
+		Users:       users,
+		Packages:    make(map[string]bool),
+	}
+
+	if len(hostname) > 0 {
+		observed.Hostname = strings.TrimSpace(hostname[0])
+	}
+
+	if ifaces := ds.i.ListWifiInterfaces(constants.NETWORKINTERFACES); len(ifaces) > 0 {
+		observed.WifiCountry = ds.i.WifiCountry(ifaces[0])
+	}
+
+	for _, p := range desired.Packages {
+		software, err := ds.r.Software.View(p)
+		if err != nil {
+			return rpi.ObservedState{}, echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the current state")
+		}
+		observed.Packages[p] = software.IsSpecificSoftwareInstalled
+	}
+
+	return observed, nil
+}
+
+// converge returns the step bringing a drifted field back to its desired value
+func (ds *DesiredState) converge(desired rpi.DesiredState, d rpi.Drift) (func() (rpi.Action, error), error) {
+	con := ds.ap.Configure
+	executes := map[string]func(string) (rpi.Action, error){
+		"hostname":       con.ExecuteCH,
+		"ssh":            con.ExecuteSSH,
+		"vnc":            con.ExecuteVNC,
+		"spi":            con.ExecuteSPI,
+		"i2c":            con.ExecuteI2C,
+		"oneWire":        con.ExecuteONW,
+		"camera":         con.ExecuteCA,
+		"remoteGpio":     con.ExecuteRG,
+		"waitForNetwork": con.ExecuteWNB,
+		"overscan":       con.ExecuteOV,
+		"blanking":       con.ExecuteBL,
+	}
+
+	if execute, ok := executes[d.Field]; ok {
+		return func() (rpi.Action, error) {
+			return execute(d.Desired)
+		}, nil
+	}
+
+	switch d.Field {
+	case "wifiCountry":
+		ifaces := ds.i.ListWifiInterfaces(constants.NETWORKINTERFACES)
+		if len(ifaces) == 0 {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to a wifi country set on a device without wifi interface")
+		}
+		return func() (rpi.Action, error) {
+			return con.ExecuteWC(ifaces[0], d.Desired)
+		}, nil
+	case "user":
+		for _, u := range desired.Users {
+			if u.Username == d.Desired && u.Password != "" {
+				return func() (rpi.Action, error) {
+					return con.ExecuteAUS(u.Username, u.Password)
+				}, nil
+			}
+		}
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to a missing password - required to create the user "+d.Desired)
+	}
+
+	// packages are installed all at once
+	return nil, nil
+}
@@ -0,0 +1,232 @@
+package desiredstate_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/admin/desiredstate"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/stretchr/testify/assert"
+)
+
+func infos(ifaces []string) mock.Infos {
+	return mock.Infos{
+		GetConfigFilesFn: func() map[string]rpi.ConfigFileDetails {
+			return map[string]rpi.ConfigFileDetails{
+				"hostname": {Path: "/etc/hostname"},
+			}
+		},
+		ReadFileFn: func(string) ([]string, error) {
+			return []string{"raspberrypi"}, nil
+		},
+		ListWifiInterfacesFn: func(string) []string {
+			return ifaces
+		},
+		WifiCountryFn: func(string) string {
+			return "FR"
+		},
+	}
+}
+
+var readers = desiredstate.Readers{
+	RpInterface: mock.RpInterface{
+		ListFn: func() (rpi.RpInterface, error) {
+			return rpi.RpInterface{IsSSH: true}, nil
+		},
+	},
+	Boot: mock.Boot{
+		ListFn: func() (rpi.Boot, error) {
+			return rpi.Boot{}, nil
+		},
+	},
+	Display: mock.Display{
+		ListFn: func() (rpi.Display, error) {
+			return rpi.Display{}, nil
+		},
+	},
+	Software: mock.Software{
+		ViewFn: func(pkg string) (rpi.Software, error) {
+			return rpi.Software{IsSpecificSoftwareInstalled: pkg == "vim"}, nil
+		},
+	},
+	HumanUser: mock.HumanUser{
+		ListFn: func() ([]rpi.HumanUser, error) {
+			return []rpi.HumanUser{{Username: "pi"}}, nil
+		},
+	},
+}
+
+func TestDrift(t *testing.T) {
+	cases := []struct {
+		name       string
+		readers    desiredstate.Readers
+		dssys      mocksys.DesiredState
+		wantedData rpi.DriftReport
+		wantedErr  error
+	}{
+		{
+			name: "error: current state",
+			readers: desiredstate.Readers{
+				RpInterface: readers.RpInterface,
+				Boot:        readers.Boot,
+				Display:     readers.Display,
+				Software:    readers.Software,
+				HumanUser: mock.HumanUser{
+					ListFn: func() ([]rpi.HumanUser, error) {
+						return nil, errors.New("test error")
+					},
+				},
+			},
+			wantedData: rpi.DriftReport{},
+			wantedErr:  echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the current state"),
+		},
+		{
+			name:    "success",
+			readers: readers,
+			dssys: mocksys.DesiredState{
+				DriftFn: func(desired rpi.DesiredState, observed rpi.ObservedState) (rpi.DriftReport, error) {
+					assert.Equal(t, "raspberrypi", observed.Hostname)
+					assert.Equal(t, "FR", observed.WifiCountry)
+					assert.Equal(t, map[string]bool{"vim": true, "git": false}, observed.Packages)
+					return rpi.DriftReport{
+						InSync: false,
+						Drifts: []rpi.Drift{
+							{Field: "package", Desired: "git", Current: "not installed"},
+						},
+					}, nil
+				},
+			},
+			wantedData: rpi.DriftReport{
+				InSync: false,
+				Drifts: []rpi.Drift{
+					{Field: "package", Desired: "git", Current: "not installed"},
+				},
+			},
+			wantedErr: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := desiredstate.New(tc.dssys, infos([]string{"wlan0"}), tc.readers, desiredstate.Appliers{})
+			result, err := s.Drift(rpi.DesiredState{Packages: []string{"vim", "git"}})
+			assert.Equal(t, tc.wantedData, result)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	var executed []string
+
+	appliers := desiredstate.Appliers{
+		Configure: mock.Configure{
+			ExecuteSSHFn: func(action string) (rpi.Action, error) {
+				executed = append(executed, "ssh "+action)
+				return rpi.Action{Name: "configure_ssh", ExitStatus: 1}, nil
+			},
+			ExecuteAUSFn: func(username string, password string) (rpi.Action, error) {
+				executed = append(executed, "adduser "+username)
+				return rpi.Action{Name: "add_user"}, nil
+			},
+			ExecuteWCFn: func(iface string, country string) (rpi.Action, error) {
+				executed = append(executed, "wificountry "+iface+" "+country)
+				return rpi.Action{Name: "wifi_country"}, nil
+			},
+		},
+		AppInstall: mock.AppInstall{
+			ExecuteAGFn: func(action string, pkg string) (rpi.Action, error) {
+				executed = append(executed, action+" "+pkg)
+				return rpi.Action{Name: "apt_get"}, nil
+			},
+		},
+	}
+
+	cases := []struct {
+		name        string
+		ifaces      []string
+		desired     rpi.DesiredState
+		drifts      []rpi.Drift
+		wantedData  rpi.Reconciliation
+		wantedSteps []string
+		wantedErr   error
+	}{
+		{
+			name: "error: user without password",
+			desired: rpi.DesiredState{
+				Users: []rpi.DesiredUser{{Username: "bob"}},
+			},
+			drifts: []rpi.Drift{
+				{Field: "user", Desired: "bob", Current: "absent"},
+			},
+			wantedData: rpi.Reconciliation{},
+			wantedErr:  echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to a missing password - required to create the user bob"),
+		},
+		{
+			name:    "error: no wifi interface",
+			desired: rpi.DesiredState{WifiCountry: "GB"},
+			drifts: []rpi.Drift{
+				{Field: "wifiCountry", Desired: "GB", Current: ""},
+			},
+			wantedData: rpi.Reconciliation{},
+			wantedErr:  echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to a wifi country set on a device without wifi interface"),
+		},
+		{
+			name:   "success",
+			ifaces: []string{"wlan0"},
+			desired: rpi.DesiredState{
+				Users: []rpi.DesiredUser{{Username: "bob", Password: "secret"}},
+			},
+			drifts: []rpi.Drift{
+				{Field: "ssh", Desired: "disable", Current: "enable"},
+				{Field: "wifiCountry", Desired: "GB", Current: "FR"},
+				{Field: "user", Desired: "bob", Current: "absent"},
+				{Field: "package", Desired: "git", Current: "not installed"},
+				{Field: "package", Desired: "htop", Current: "not installed"},
+			},
+			wantedData: rpi.Reconciliation{
+				Drifts: []rpi.Drift{
+					{Field: "ssh", Desired: "disable", Current: "enable"},
+					{Field: "wifiCountry", Desired: "GB", Current: "FR"},
+					{Field: "user", Desired: "bob", Current: "absent"},
+					{Field: "package", Desired: "git", Current: "not installed"},
+					{Field: "package", Desired: "htop", Current: "not installed"},
+				},
+				Actions: []rpi.Action{
+					{Name: "apt_get"},
+					{Name: "configure_ssh", ExitStatus: 1},
+					{Name: "wifi_country"},
+					{Name: "add_user"},
+				},
+				ExitStatus: 1,
+			},
+			wantedSteps: []string{
+				"install git<|>htop",
+				"ssh disable",
+				"wificountry wlan0 GB",
+				"adduser bob",
+			},
+			wantedErr: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			executed = nil
+			dssys := mocksys.DesiredState{
+				DriftFn: func(rpi.DesiredState, rpi.ObservedState) (rpi.DriftReport, error) {
+					return rpi.DriftReport{InSync: false, Drifts: tc.drifts}, nil
+				},
+			}
+			s := desiredstate.New(dssys, infos(tc.ifaces), readers, appliers)
+			result, err := s.Reconcile(tc.desired)
+			assert.Equal(t, tc.wantedData, result)
+			assert.Equal(t, tc.wantedSteps, executed)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
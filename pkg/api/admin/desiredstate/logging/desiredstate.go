@@ -0,0 +1,59 @@
+package desiredstate
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/admin/desiredstate"
+)
+
+// New creates a new desiredstate logging service instance.
+func New(svc desiredstate.Service, logger rpi.Logger) *LogService {
+	return &LogService{
+		Service: svc,
+		logger:  logger,
+	}
+}
+
+// LogService represents a desiredstate logging service.
+type LogService struct {
+	desiredstate.Service
+	logger rpi.Logger
+}
+
+const name = "desiredstate"
+
+// Drift is the logging function attached to the Drift service and responsible for logging it out.
+func (ls *LogService) Drift(ctx echo.Context, desired rpi.DesiredState) (resp rpi.DriftReport, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name,
+			"request: drift detection",
+			err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.Drift(desired)
+}
+
+// Reconcile is the logging function attached to the Reconcile service and responsible for logging it out.
+func (ls *LogService) Reconcile(ctx echo.Context, desired rpi.DesiredState) (resp rpi.Reconciliation, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name,
+			"request: reconcile",
+			err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.Reconcile(desired)
+}
@@ -0,0 +1,78 @@
+package sys
+
+import (
+	"github.com/raspibuddy/rpi"
+)
+
+// DesiredState represents an empty DesiredState entity on the current system.
+type DesiredState struct{}
+
+// Drift compares a desired state to the observed one and returns every difference found
+func (ds DesiredState) Drift(desired rpi.DesiredState, observed rpi.ObservedState) (rpi.DriftReport, error) {
+	drifts := []rpi.Drift{}
+
+	if desired.Hostname != "" && desired.Hostname != observed.Hostname {
+		drifts = append(drifts, rpi.Drift{Field: "hostname", Desired: desired.Hostname, Current: observed.Hostname})
+	}
+
+	toggles := []struct {
+		field   string
+		desired *bool
+		current bool
+	}{
+		{"ssh", desired.SSH, observed.RpInterface.IsSSH},
+		{"vnc", desired.VNC, observed.RpInterface.IsVNC},
+		{"spi", desired.SPI, observed.RpInterface.IsSPI},
+		{"i2c", desired.I2C, observed.RpInterface.IsI2C},
+		{"oneWire", desired.OneWire, observed.RpInterface.IsOneWire},
+		{"camera", desired.Camera, observed.RpInterface.IsCamera},
+		{"remoteGpio", desired.RemoteGpio, observed.RpInterface.IsRemoteGpio},
+		{"waitForNetwork", desired.WaitForNetwork, observed.Boot.IsWaitForNetwork},
+		{"overscan", desired.Overscan, observed.Display.IsOverscan},
+		{"blanking", desired.Blanking, observed.Display.IsBlanking},
+	}
+	for _, t := range toggles {
+		if t.desired != nil && *t.desired != t.current {
+			drifts = append(drifts, rpi.Drift{Field: t.field, Desired: Toggle(*t.desired), Current: Toggle(t.current)})
+		}
+	}
+
+	if desired.WifiCountry != "" && desired.WifiCountry != observed.WifiCountry {
+		drifts = append(drifts, rpi.Drift{Field: "wifiCountry", Desired: desired.WifiCountry, Current: observed.WifiCountry})
+	}
+
+	for _, u := range desired.Users {
+		if !IsUser(u.Username, observed.Users) {
+			drifts = append(drifts, rpi.Drift{Field: "user", Desired: u.Username, Current: "absent"})
+		}
+	}
+
+	for _, p := range desired.Packages {
+		if !observed.Packages[p] {
+			drifts = append(drifts, rpi.Drift{Field: "package", Desired: p, Current: "not installed"})
+		}
+	}
+
+	return rpi.DriftReport{
+		InSync: len(drifts) == 0,
+		Drifts: drifts,
+	}, nil
+}
+
+// Toggle returns the action matching a boolean setting
+func Toggle(b bool) string {
+	if b {
+		return "enable"
+	}
+	return "disable"
+}
+
+// IsUser checks if a username belongs to the human users
+func IsUser(username string, users []rpi.HumanUser) bool {
+	for _, u := range users {
+		if u.Username == username {
+			return true
+		}
+	}
+	return false
+}
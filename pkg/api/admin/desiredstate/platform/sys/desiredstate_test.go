@@ -0,0 +1,107 @@
+package sys_test
+
+import (
+	"testing"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/admin/desiredstate/platform/sys"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrift(t *testing.T) {
+	on := true
+	off := false
+
+	observed := rpi.ObservedState{
+		Hostname:    "raspberrypi",
+		WifiCountry: "FR",
+		RpInterface: rpi.RpInterface{
+			IsSSH: true,
+			IsSPI: false,
+		},
+		Boot: rpi.Boot{
+			IsWaitForNetwork: true,
+		},
+		Display: rpi.Display{
+			IsBlanking: true,
+		},
+		Users: []rpi.HumanUser{
+			{Username: "pi"},
+		},
+		Packages: map[string]bool{
+			"vim": true,
+			"git": false,
+		},
+	}
+
+	cases := []struct {
+		name       string
+		desired    rpi.DesiredState
+		wantedData rpi.DriftReport
+		wantedErr  error
+	}{
+		{
+			name:    "success: empty document",
+			desired: rpi.DesiredState{},
+			wantedData: rpi.DriftReport{
+				InSync: true,
+				Drifts: []rpi.Drift{},
+			},
+			wantedErr: nil,
+		},
+		{
+			name: "success: in sync",
+			desired: rpi.DesiredState{
+				Hostname:       "raspberrypi",
+				SSH:            &on,
+				SPI:            &off,
+				WaitForNetwork: &on,
+				WifiCountry:    "FR",
+				Users:          []rpi.DesiredUser{{Username: "pi"}},
+				Packages:       []string{"vim"},
+			},
+			wantedData: rpi.DriftReport{
+				InSync: true,
+				Drifts: []rpi.Drift{},
+			},
+			wantedErr: nil,
+		},
+		{
+			name: "success: drift",
+			desired: rpi.DesiredState{
+				Hostname:    "pi-kitchen",
+				SSH:         &off,
+				SPI:         &on,
+				Blanking:    &off,
+				WifiCountry: "GB",
+				Users: []rpi.DesiredUser{
+					{Username: "pi"},
+					{Username: "bob", Password: "secret"},
+				},
+				Packages: []string{"vim", "git"},
+			},
+			wantedData: rpi.DriftReport{
+				InSync: false,
+				Drifts: []rpi.Drift{
+					{Field: "hostname", Desired: "pi-kitchen", Current: "raspberrypi"},
+					{Field: "ssh", Desired: "disable", Current: "enable"},
+					{Field: "spi", Desired: "enable", Current: "disable"},
+					{Field: "blanking", Desired: "disable", Current: "enable"},
+					{Field: "wifiCountry", Desired: "GB", Current: "FR"},
+					{Field: "user", Desired: "bob", Current: "absent"},
+					{Field: "package", Desired: "git", Current: "not installed"},
+				},
+			},
+			wantedErr: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := sys.DesiredState{}
+			result, err := s.Drift(tc.desired, observed)
+			assert.Equal(t, tc.wantedData, result)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
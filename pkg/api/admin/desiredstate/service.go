@@ -0,0 +1,78 @@
+package desiredstate
+
+import (
+	"github.com/raspibuddy/rpi"
+)
+
+// Service represents all DesiredState application services.
+type Service interface {
+	Drift(rpi.DesiredState) (rpi.DriftReport, error)
+	Reconcile(rpi.DesiredState) (rpi.Reconciliation, error)
+}
+
+// DesiredState represents a DesiredState application service.
+type DesiredState struct {
+	dssys DSSYS
+	i     Infos
+	r     Readers
+	ap    Appliers
+}
+
+// DSSYS represents a DesiredState repository service.
+type DSSYS interface {
+	Drift(rpi.DesiredState, rpi.ObservedState) (rpi.DriftReport, error)
+}
+
+// Infos represents the infos interface
+type Infos interface {
+	ReadFile(string) ([]string, error)
+	GetConfigFiles() map[string]rpi.ConfigFileDetails
+	ListWifiInterfaces(string) []string
+	WifiCountry(string) string
+}
+
+// Readers represents the services reading the current state of the device
+type Readers struct {
+	RpInterface interface {
+		List() (rpi.RpInterface, error)
+	}
+	Boot interface {
+		List() (rpi.Boot, error)
+	}
+	Display interface {
+		List() (rpi.Display, error)
+	}
+	Software interface {
+		View(string) (rpi.Software, error)
+	}
+	HumanUser interface {
+		List() ([]rpi.HumanUser, error)
+	}
+}
+
+// Appliers represents the services converging the device to its desired state
+type Appliers struct {
+	Configure interface {
+		ExecuteCH(string) (rpi.Action, error)
+		ExecuteWNB(string) (rpi.Action, error)
+		ExecuteOV(string) (rpi.Action, error)
+		ExecuteBL(string) (rpi.Action, error)
+		ExecuteAUS(string, string) (rpi.Action, error)
+		ExecuteCA(string) (rpi.Action, error)
+		ExecuteSSH(string) (rpi.Action, error)
+		ExecuteVNC(string) (rpi.Action, error)
+		ExecuteSPI(string) (rpi.Action, error)
+		ExecuteI2C(string) (rpi.Action, error)
+		ExecuteONW(string) (rpi.Action, error)
+		ExecuteRG(string) (rpi.Action, error)
+		ExecuteWC(string, string) (rpi.Action, error)
+	}
+	AppInstall interface {
+		ExecuteAG(string, string) (rpi.Action, error)
+	}
+}
+
+// New creates a DesiredState application service instance.
+func New(dssys DSSYS, i Infos, r Readers, ap Appliers) *DesiredState {
+	return &DesiredState{dssys: dssys, i: i, r: r, ap: ap}
+}
@@ -0,0 +1,90 @@
+package transport
+
+import (
+	"io/ioutil"
+	"net/http"
+	"regexp"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/admin/desiredstate"
+	"gopkg.in/yaml.v2"
+)
+
+// HTTP is a struct implementing a desiredstate application service.
+type HTTP struct {
+	svc desiredstate.Service
+}
+
+var (
+	hostnameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9]$`)
+	countryRegex  = regexp.MustCompile(`^[A-Z]{2}$`)
+	usernameRegex = regexp.MustCompile(`^[a-z_][a-z0-9_-]*$`)
+	packageRegex  = regexp.MustCompile(`^[a-z0-9][a-z0-9+.\-]+$`)
+)
+
+// NewHTTP creates new desiredstate http service
+func NewHTTP(svc desiredstate.Service, r *echo.Group) {
+	h := HTTP{svc}
+	cr := r.Group("/desiredstate")
+	cr.POST("/drift", h.drift)
+	cr.POST("/reconcile", h.reconcile)
+}
+
+func (h *HTTP) drift(ctx echo.Context) error {
+	desired, err := parse(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.svc.Drift(desired)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) reconcile(ctx echo.Context) error {
+	desired, err := parse(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.svc.Reconcile(desired)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+// parse reads the desired state document (yaml or json) from the request body
+func parse(ctx echo.Context) (rpi.DesiredState, error) {
+	var desired rpi.DesiredState
+
+	body, err := ioutil.ReadAll(ctx.Request().Body)
+	if err != nil || len(body) == 0 || yaml.UnmarshalStrict(body, &desired) != nil {
+		return rpi.DesiredState{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid desired state document")
+	}
+
+	if desired.Hostname != "" && !hostnameRegex.MatchString(desired.Hostname) {
+		return rpi.DesiredState{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid hostname")
+	}
+
+	if desired.WifiCountry != "" && !countryRegex.MatchString(desired.WifiCountry) {
+		return rpi.DesiredState{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid wifiCountry - should be an ISO 3166 alpha-2 code")
+	}
+
+	for _, u := range desired.Users {
+		if !usernameRegex.MatchString(u.Username) {
+			return rpi.DesiredState{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid username")
+		}
+	}
+
+	for _, p := range desired.Packages {
+		if !packageRegex.MatchString(p) {
+			return rpi.DesiredState{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid package name")
+		}
+	}
+
+	return desired, nil
+}
@@ -0,0 +1,236 @@
+package transport_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/admin/desiredstate"
+	"github.com/raspibuddy/rpi/pkg/api/admin/desiredstate/transport"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/raspibuddy/rpi/pkg/utl/server"
+	"github.com/stretchr/testify/assert"
+)
+
+var i = mock.Infos{
+	GetConfigFilesFn: func() map[string]rpi.ConfigFileDetails {
+		return map[string]rpi.ConfigFileDetails{}
+	},
+	ReadFileFn: func(string) ([]string, error) {
+		return []string{"raspberrypi"}, nil
+	},
+	ListWifiInterfacesFn: func(string) []string {
+		return nil
+	},
+}
+
+var readers = desiredstate.Readers{
+	RpInterface: mock.RpInterface{
+		ListFn: func() (rpi.RpInterface, error) {
+			return rpi.RpInterface{}, nil
+		},
+	},
+	Boot: mock.Boot{
+		ListFn: func() (rpi.Boot, error) {
+			return rpi.Boot{}, nil
+		},
+	},
+	Display: mock.Display{
+		ListFn: func() (rpi.Display, error) {
+			return rpi.Display{}, nil
+		},
+	},
+	Software: mock.Software{
+		ViewFn: func(string) (rpi.Software, error) {
+			return rpi.Software{}, nil
+		},
+	},
+	HumanUser: mock.HumanUser{
+		ListFn: func() ([]rpi.HumanUser, error) {
+			return []rpi.HumanUser{}, nil
+		},
+	},
+}
+
+var appliers = desiredstate.Appliers{
+	Configure: mock.Configure{
+		ExecuteCHFn: func(string) (rpi.Action, error) {
+			return rpi.Action{Name: "change_hostname"}, nil
+		},
+	},
+}
+
+func TestDrift(t *testing.T) {
+	var response rpi.DriftReport
+
+	cases := []struct {
+		name         string
+		req          string
+		dssys        *mocksys.DesiredState
+		wantedStatus int
+		wantedResp   rpi.DriftReport
+	}{
+		{
+			name:         "error: empty document",
+			req:          "",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: unknown field",
+			req:          "sshd: true",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid hostname",
+			req:          "hostname: -pi",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid wifi country",
+			req:          `{"wifiCountry": "france"}`,
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid username",
+			req:          "users:\n  - username: Bob\n",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid package",
+			req:          "packages: [\"vim; reboot\"]",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error: Drift result is nil",
+			req:  "ssh: true",
+			dssys: &mocksys.DesiredState{
+				DriftFn: func(rpi.DesiredState, rpi.ObservedState) (rpi.DriftReport, error) {
+					return rpi.DriftReport{}, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			req:  "hostname: pi-kitchen\nssh: true\npackages:\n  - git\n",
+			dssys: &mocksys.DesiredState{
+				DriftFn: func(desired rpi.DesiredState, _ rpi.ObservedState) (rpi.DriftReport, error) {
+					return rpi.DriftReport{
+						InSync: false,
+						Drifts: []rpi.Drift{
+							{Field: "hostname", Desired: desired.Hostname, Current: "raspberrypi"},
+						},
+					}, nil
+				},
+			},
+			wantedStatus: http.StatusOK,
+			wantedResp: rpi.DriftReport{
+				InSync: false,
+				Drifts: []rpi.Drift{
+					{Field: "hostname", Desired: "pi-kitchen", Current: "raspberrypi"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			s := desiredstate.New(tc.dssys, i, readers, appliers)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+			defer ts.Close()
+			path := ts.URL + "/desiredstate/drift"
+
+			res, err := http.Post(path, "application/x-yaml", strings.NewReader(tc.req))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+
+			if tc.wantedStatus == http.StatusOK {
+				body, _ := ioutil.ReadAll(res.Body)
+				if err := json.Unmarshal(body, &response); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, tc.wantedResp, response)
+			}
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	var response rpi.Reconciliation
+
+	cases := []struct {
+		name         string
+		req          string
+		dssys        *mocksys.DesiredState
+		wantedStatus int
+		wantedResp   rpi.Reconciliation
+	}{
+		{
+			name:         "error: invalid document",
+			req:          "ssh: [",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "success",
+			req:  `{"hostname": "pi-kitchen"}`,
+			dssys: &mocksys.DesiredState{
+				DriftFn: func(rpi.DesiredState, rpi.ObservedState) (rpi.DriftReport, error) {
+					return rpi.DriftReport{
+						InSync: false,
+						Drifts: []rpi.Drift{
+							{Field: "hostname", Desired: "pi-kitchen", Current: "raspberrypi"},
+						},
+					}, nil
+				},
+			},
+			wantedStatus: http.StatusOK,
+			wantedResp: rpi.Reconciliation{
+				Drifts: []rpi.Drift{
+					{Field: "hostname", Desired: "pi-kitchen", Current: "raspberrypi"},
+				},
+				Actions: []rpi.Action{
+					{Name: "change_hostname"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			s := desiredstate.New(tc.dssys, i, readers, appliers)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+			defer ts.Close()
+			path := ts.URL + "/desiredstate/reconcile"
+
+			res, err := http.Post(path, "application/json", strings.NewReader(tc.req))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+
+			if tc.wantedStatus == http.StatusOK {
+				body, _ := ioutil.ReadAll(res.Body)
+				if err := json.Unmarshal(body, &response); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, tc.wantedResp, response)
+			}
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
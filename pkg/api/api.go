@@ -1,6 +1,10 @@
 package api
 
 import (
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/raspibuddy/rpi/pkg/api/actions/appaction"
 	aal "github.com/raspibuddy/rpi/pkg/api/actions/appaction/logging"
 	aas "github.com/raspibuddy/rpi/pkg/api/actions/appaction/platform/sys"
@@ -9,6 +13,10 @@ import (
 	ail "github.com/raspibuddy/rpi/pkg/api/actions/appinstall/logging"
 	ais "github.com/raspibuddy/rpi/pkg/api/actions/appinstall/platform/sys"
 	ait "github.com/raspibuddy/rpi/pkg/api/actions/appinstall/transport"
+	"github.com/raspibuddy/rpi/pkg/api/actions/bootconfig"
+	abcl "github.com/raspibuddy/rpi/pkg/api/actions/bootconfig/logging"
+	abcs "github.com/raspibuddy/rpi/pkg/api/actions/bootconfig/platform/sys"
+	abct "github.com/raspibuddy/rpi/pkg/api/actions/bootconfig/transport"
 	"github.com/raspibuddy/rpi/pkg/api/actions/configure"
 	acl "github.com/raspibuddy/rpi/pkg/api/actions/configure/logging"
 	acs "github.com/raspibuddy/rpi/pkg/api/actions/configure/platform/sys"
@@ -17,14 +25,74 @@ import (
 	adl "github.com/raspibuddy/rpi/pkg/api/actions/destroy/logging"
 	ads "github.com/raspibuddy/rpi/pkg/api/actions/destroy/platform/sys"
 	adt "github.com/raspibuddy/rpi/pkg/api/actions/destroy/transport"
+	"github.com/raspibuddy/rpi/pkg/api/actions/firewall"
+	afwl "github.com/raspibuddy/rpi/pkg/api/actions/firewall/logging"
+	afws "github.com/raspibuddy/rpi/pkg/api/actions/firewall/platform/sys"
+	afwt "github.com/raspibuddy/rpi/pkg/api/actions/firewall/transport"
 	"github.com/raspibuddy/rpi/pkg/api/actions/general"
 	agl "github.com/raspibuddy/rpi/pkg/api/actions/general/logging"
 	ags "github.com/raspibuddy/rpi/pkg/api/actions/general/platform/sys"
 	agt "github.com/raspibuddy/rpi/pkg/api/actions/general/transport"
+	"github.com/raspibuddy/rpi/pkg/api/actions/gpumem"
+	agml "github.com/raspibuddy/rpi/pkg/api/actions/gpumem/logging"
+	agms "github.com/raspibuddy/rpi/pkg/api/actions/gpumem/platform/sys"
+	agmt "github.com/raspibuddy/rpi/pkg/api/actions/gpumem/transport"
+	"github.com/raspibuddy/rpi/pkg/api/actions/hotspot"
+	ahsl "github.com/raspibuddy/rpi/pkg/api/actions/hotspot/logging"
+	ahss "github.com/raspibuddy/rpi/pkg/api/actions/hotspot/platform/sys"
+	ahst "github.com/raspibuddy/rpi/pkg/api/actions/hotspot/transport"
+	"github.com/raspibuddy/rpi/pkg/api/actions/localisation"
+	alcl "github.com/raspibuddy/rpi/pkg/api/actions/localisation/logging"
+	alcs "github.com/raspibuddy/rpi/pkg/api/actions/localisation/platform/sys"
+	alct "github.com/raspibuddy/rpi/pkg/api/actions/localisation/transport"
+	"github.com/raspibuddy/rpi/pkg/api/actions/netconfig"
+	ancl "github.com/raspibuddy/rpi/pkg/api/actions/netconfig/logging"
+	ancs "github.com/raspibuddy/rpi/pkg/api/actions/netconfig/platform/sys"
+	anct "github.com/raspibuddy/rpi/pkg/api/actions/netconfig/transport"
+	"github.com/raspibuddy/rpi/pkg/api/actions/overclock"
+	aocl "github.com/raspibuddy/rpi/pkg/api/actions/overclock/logging"
+	aocs "github.com/raspibuddy/rpi/pkg/api/actions/overclock/platform/sys"
+	aoct "github.com/raspibuddy/rpi/pkg/api/actions/overclock/transport"
+	"github.com/raspibuddy/rpi/pkg/api/actions/overlay"
+	aovl "github.com/raspibuddy/rpi/pkg/api/actions/overlay/logging"
+	aovs "github.com/raspibuddy/rpi/pkg/api/actions/overlay/platform/sys"
+	aovt "github.com/raspibuddy/rpi/pkg/api/actions/overlay/transport"
+	"github.com/raspibuddy/rpi/pkg/api/actions/processcontrol"
+	apcl "github.com/raspibuddy/rpi/pkg/api/actions/processcontrol/logging"
+	apcs "github.com/raspibuddy/rpi/pkg/api/actions/processcontrol/platform/sys"
+	apct "github.com/raspibuddy/rpi/pkg/api/actions/processcontrol/transport"
+	"github.com/raspibuddy/rpi/pkg/api/actions/ssh"
+	assl "github.com/raspibuddy/rpi/pkg/api/actions/ssh/logging"
+	asss "github.com/raspibuddy/rpi/pkg/api/actions/ssh/platform/sys"
+	asst "github.com/raspibuddy/rpi/pkg/api/actions/ssh/transport"
+	"github.com/raspibuddy/rpi/pkg/api/actions/timesync"
+	atsl "github.com/raspibuddy/rpi/pkg/api/actions/timesync/logging"
+	atss "github.com/raspibuddy/rpi/pkg/api/actions/timesync/platform/sys"
+	atst "github.com/raspibuddy/rpi/pkg/api/actions/timesync/transport"
+	"github.com/raspibuddy/rpi/pkg/api/actions/unitcontrol"
+	aucl "github.com/raspibuddy/rpi/pkg/api/actions/unitcontrol/logging"
+	aucs "github.com/raspibuddy/rpi/pkg/api/actions/unitcontrol/platform/sys"
+	auct "github.com/raspibuddy/rpi/pkg/api/actions/unitcontrol/transport"
+	"github.com/raspibuddy/rpi/pkg/api/actions/useraccount"
+	aual "github.com/raspibuddy/rpi/pkg/api/actions/useraccount/logging"
+	auas "github.com/raspibuddy/rpi/pkg/api/actions/useraccount/platform/sys"
+	auat "github.com/raspibuddy/rpi/pkg/api/actions/useraccount/transport"
+	"github.com/raspibuddy/rpi/pkg/api/actions/wifi"
+	awfl "github.com/raspibuddy/rpi/pkg/api/actions/wifi/logging"
+	awfs "github.com/raspibuddy/rpi/pkg/api/actions/wifi/platform/sys"
+	awft "github.com/raspibuddy/rpi/pkg/api/actions/wifi/transport"
+	"github.com/raspibuddy/rpi/pkg/api/admin/bundle"
+	bdl "github.com/raspibuddy/rpi/pkg/api/admin/bundle/logging"
+	bds "github.com/raspibuddy/rpi/pkg/api/admin/bundle/platform/sys"
+	bdt "github.com/raspibuddy/rpi/pkg/api/admin/bundle/transport"
 	"github.com/raspibuddy/rpi/pkg/api/admin/deployment"
 	del "github.com/raspibuddy/rpi/pkg/api/admin/deployment/logging"
 	des "github.com/raspibuddy/rpi/pkg/api/admin/deployment/platform/sys"
 	det "github.com/raspibuddy/rpi/pkg/api/admin/deployment/transport"
+	"github.com/raspibuddy/rpi/pkg/api/admin/desiredstate"
+	dsl "github.com/raspibuddy/rpi/pkg/api/admin/desiredstate/logging"
+	dss "github.com/raspibuddy/rpi/pkg/api/admin/desiredstate/platform/sys"
+	dst "github.com/raspibuddy/rpi/pkg/api/admin/desiredstate/transport"
 	"github.com/raspibuddy/rpi/pkg/api/infos/appconfig"
 	iacl "github.com/raspibuddy/rpi/pkg/api/infos/appconfig/logging"
 	iacs "github.com/raspibuddy/rpi/pkg/api/infos/appconfig/platform/sys"
@@ -49,6 +117,14 @@ import (
 	ihul "github.com/raspibuddy/rpi/pkg/api/infos/humanuser/logging"
 	ihus "github.com/raspibuddy/rpi/pkg/api/infos/humanuser/platform/sys"
 	ihut "github.com/raspibuddy/rpi/pkg/api/infos/humanuser/transport"
+	"github.com/raspibuddy/rpi/pkg/api/infos/journal"
+	ijol "github.com/raspibuddy/rpi/pkg/api/infos/journal/logging"
+	ijos "github.com/raspibuddy/rpi/pkg/api/infos/journal/platform/sys"
+	ijot "github.com/raspibuddy/rpi/pkg/api/infos/journal/transport"
+	"github.com/raspibuddy/rpi/pkg/api/infos/kernellog"
+	ikll "github.com/raspibuddy/rpi/pkg/api/infos/kernellog/logging"
+	ikls "github.com/raspibuddy/rpi/pkg/api/infos/kernellog/platform/sys"
+	iklt "github.com/raspibuddy/rpi/pkg/api/infos/kernellog/transport"
 	"github.com/raspibuddy/rpi/pkg/api/infos/port"
 	ptl "github.com/raspibuddy/rpi/pkg/api/infos/port/logging"
 	pts "github.com/raspibuddy/rpi/pkg/api/infos/port/platform/sys"
@@ -61,18 +137,42 @@ import (
 	isol "github.com/raspibuddy/rpi/pkg/api/infos/software/logging"
 	isos "github.com/raspibuddy/rpi/pkg/api/infos/software/platform/sys"
 	isot "github.com/raspibuddy/rpi/pkg/api/infos/software/transport"
+	"github.com/raspibuddy/rpi/pkg/api/infos/storagehealth"
+	ishl "github.com/raspibuddy/rpi/pkg/api/infos/storagehealth/logging"
+	ishs "github.com/raspibuddy/rpi/pkg/api/infos/storagehealth/platform/sys"
+	isht "github.com/raspibuddy/rpi/pkg/api/infos/storagehealth/transport"
+	"github.com/raspibuddy/rpi/pkg/api/infos/systemdunit"
+	isul "github.com/raspibuddy/rpi/pkg/api/infos/systemdunit/logging"
+	isus "github.com/raspibuddy/rpi/pkg/api/infos/systemdunit/platform/sys"
+	isut "github.com/raspibuddy/rpi/pkg/api/infos/systemdunit/transport"
 	"github.com/raspibuddy/rpi/pkg/api/infos/version"
 	vel "github.com/raspibuddy/rpi/pkg/api/infos/version/logging"
 	ves "github.com/raspibuddy/rpi/pkg/api/infos/version/platform/sys"
 	vet "github.com/raspibuddy/rpi/pkg/api/infos/version/transport"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/board"
+	bl "github.com/raspibuddy/rpi/pkg/api/metrics/board/logging"
+	bs "github.com/raspibuddy/rpi/pkg/api/metrics/board/platform/sys"
+	bt "github.com/raspibuddy/rpi/pkg/api/metrics/board/transport"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/cgroup"
+	cgl "github.com/raspibuddy/rpi/pkg/api/metrics/cgroup/logging"
+	cgs "github.com/raspibuddy/rpi/pkg/api/metrics/cgroup/platform/sys"
+	cgt "github.com/raspibuddy/rpi/pkg/api/metrics/cgroup/transport"
 	"github.com/raspibuddy/rpi/pkg/api/metrics/cpu"
 	cl "github.com/raspibuddy/rpi/pkg/api/metrics/cpu/logging"
 	cs "github.com/raspibuddy/rpi/pkg/api/metrics/cpu/platform/sys"
 	ct "github.com/raspibuddy/rpi/pkg/api/metrics/cpu/transport"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/cpufreq"
+	cfl "github.com/raspibuddy/rpi/pkg/api/metrics/cpufreq/logging"
+	cfs "github.com/raspibuddy/rpi/pkg/api/metrics/cpufreq/platform/sys"
+	cft "github.com/raspibuddy/rpi/pkg/api/metrics/cpufreq/transport"
 	"github.com/raspibuddy/rpi/pkg/api/metrics/disk"
 	dl "github.com/raspibuddy/rpi/pkg/api/metrics/disk/logging"
 	ds "github.com/raspibuddy/rpi/pkg/api/metrics/disk/platform/sys"
 	dt "github.com/raspibuddy/rpi/pkg/api/metrics/disk/transport"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/diskforecast"
+	dfl "github.com/raspibuddy/rpi/pkg/api/metrics/diskforecast/logging"
+	dfs "github.com/raspibuddy/rpi/pkg/api/metrics/diskforecast/platform/sys"
+	dft "github.com/raspibuddy/rpi/pkg/api/metrics/diskforecast/transport"
 	"github.com/raspibuddy/rpi/pkg/api/metrics/filestructure"
 	fsl "github.com/raspibuddy/rpi/pkg/api/metrics/filestructure/logging"
 	fss "github.com/raspibuddy/rpi/pkg/api/metrics/filestructure/platform/sys"
@@ -93,6 +193,10 @@ import (
 	nl "github.com/raspibuddy/rpi/pkg/api/metrics/net/logging"
 	ns "github.com/raspibuddy/rpi/pkg/api/metrics/net/platform/sys"
 	nt "github.com/raspibuddy/rpi/pkg/api/metrics/net/transport"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/pressure"
+	prl "github.com/raspibuddy/rpi/pkg/api/metrics/pressure/logging"
+	prs "github.com/raspibuddy/rpi/pkg/api/metrics/pressure/platform/sys"
+	prt "github.com/raspibuddy/rpi/pkg/api/metrics/pressure/transport"
 	"github.com/raspibuddy/rpi/pkg/api/metrics/process"
 	pl "github.com/raspibuddy/rpi/pkg/api/metrics/process/logging"
 	ps "github.com/raspibuddy/rpi/pkg/api/metrics/process/platform/sys"
@@ -107,6 +211,7 @@ import (
 	vt "github.com/raspibuddy/rpi/pkg/api/metrics/vcore/transport"
 	"github.com/raspibuddy/rpi/pkg/utl/actions"
 	"github.com/raspibuddy/rpi/pkg/utl/config"
+	"github.com/raspibuddy/rpi/pkg/utl/history"
 	"github.com/raspibuddy/rpi/pkg/utl/infos"
 	"github.com/raspibuddy/rpi/pkg/utl/metrics"
 	"github.com/raspibuddy/rpi/pkg/utl/server"
@@ -122,14 +227,32 @@ func Start(cfg *config.Configuration) error {
 	a := actions.New()
 	i := infos.New()
 
+	// mountpoint usage history feeding the disk fill-rate forecast
+	fc := forecastConfig(cfg.Forecast)
+	dh := history.New(fc.MaxSamples, fc.HistoryFile)
+	go dh.Run(time.Duration(fc.SampleInterval)*time.Minute, diskforecast.Collect(m), nil)
+
+	// cpu temperature history feeding the overclock pre-checks, persisted as it should outlive the reboots
+	var thFile string
+	if cfg.Overclock != nil {
+		thFile = cfg.Overclock.HistoryFile
+	}
+	th := history.New(overclock.TemperatureSamples, thFile)
+	go th.Run(overclock.TemperatureInterval, overclock.Collect(m), nil)
+
 	// metrics
 	ct.NewHTTP(cl.New(cpu.New(cs.CPU{}, m), log).Service, v1)
+	cft.NewHTTP(cfl.New(cpufreq.New(cfs.CPUFreq{}, m), log).Service, v1)
 	vt.NewHTTP(vl.New(vcore.New(vs.VCore{}, m), log).Service, v1)
 	mt.NewHTTP(ml.New(mem.New(ms.Mem{}, m), log).Service, v1)
+	prt.NewHTTP(prl.New(pressure.New(prs.Pressure{}, m), log).Service, v1)
+	cgt.NewHTTP(cgl.New(cgroup.New(cgs.CGroup{}, m), log).Service, v1)
 	dt.NewHTTP(dl.New(disk.New(ds.Disk{}, m), log).Service, v1)
+	dft.NewHTTP(dfl.New(diskforecast.New(dfs.DiskForecast{}, dh, time.Duration(fc.Horizon)*time.Hour), log).Service, v1)
 	lt.NewHTTP(ll.New(load.New(ls.Load{}, m), log).Service, v1)
 	pt.NewHTTP(pl.New(process.New(ps.Process{}, m), log).Service, v1)
 	ht.NewHTTP(hl.New(host.New(hs.Host{}, m), log).Service, v1)
+	bt.NewHTTP(bl.New(board.New(bs.Board{}, m), log).Service, v1)
 	ut.NewHTTP(ul.New(user.New(us.User{}, m), log).Service, v1)
 	nt.NewHTTP(nl.New(net.New(ns.Net{}, m), log).Service, v1)
 	fst.NewHTTP(fsl.New(filestructure.New(fss.FileStructure{}, m), log).Service, v1)
@@ -137,7 +260,21 @@ func Start(cfg *config.Configuration) error {
 	// actions
 	adt.NewHTTP(adl.New(destroy.New(ads.Destroy{}, a), log).Service, v1)
 	agt.NewHTTP(agl.New(general.New(ags.General{}, a), log).Service, v1)
+	apct.NewHTTP(apcl.New(processcontrol.New(apcs.ProcessControl{}, a), log).Service, v1)
+	auct.NewHTTP(aucl.New(unitcontrol.New(aucs.UnitControl{}, a, i), log).Service, v1)
 	act.NewHTTP(acl.New(configure.New(acs.Configure{}, a, i), log).Service, v1)
+	abct.NewHTTP(abcl.New(bootconfig.New(abcs.BootConfig{}, a, i), log).Service, v1)
+	aovt.NewHTTP(aovl.New(overlay.New(aovs.Overlay{}, a, i), log).Service, v1)
+	aoct.NewHTTP(aocl.New(overclock.New(aocs.Overclock{}, a, i, m, board.New(bs.Board{}, m), th), log).Service, v1)
+	agmt.NewHTTP(agml.New(gpumem.New(agms.GPUMem{}, a, i, m, board.New(bs.Board{}, m)), log).Service, v1)
+	alct.NewHTTP(alcl.New(localisation.New(alcs.Localisation{}, a, i), log).Service, v1)
+	atst.NewHTTP(atsl.New(timesync.New(atss.TimeSync{}, a, i, m), log).Service, v1)
+	awft.NewHTTP(awfl.New(wifi.New(awfs.Wifi{}, a, i), log).Service, v1)
+	anct.NewHTTP(ancl.New(netconfig.New(ancs.NetConfig{}, a, i, m), log).Service, v1)
+	ahst.NewHTTP(ahsl.New(hotspot.New(ahss.Hotspot{}, a, i), log).Service, v1)
+	afwt.NewHTTP(afwl.New(firewall.New(afws.Firewall{}, a, i, serverPort(cfg.Server.Port)), log).Service, v1)
+	asst.NewHTTP(assl.New(ssh.New(asss.SSH{}, a, i, humanuser.New(ihus.HumanUser{}, i)), log).Service, v1)
+	auat.NewHTTP(aual.New(useraccount.New(auas.UserAccount{}, a, i, humanuser.New(ihus.HumanUser{}, i)), log).Service, v1)
 	ait.NewHTTP(ail.New(appinstall.New(ais.Install{}, a, i), log).Service, v1)
 	aat.NewHTTP(aal.New(appaction.New(aas.AppAction{}, a, i), log).Service, v1)
 
@@ -151,10 +288,30 @@ func Start(cfg *config.Configuration) error {
 	iact.NewHTTP(iacl.New(appconfig.New(iacs.AppConfigVPNWithOvpn{}, i), log).Service, v1)
 	iast.NewHTTP(iasl.New(appstatus.New(iass.AppStatus{}, i), log).Service, v1)
 	ptt.NewHTTP(ptl.New(port.New(pts.Port{}, i), log).Service, v1)
+	isht.NewHTTP(ishl.New(storagehealth.New(ishs.StorageHealth{}, i), log).Service, v1)
+	iklt.NewHTTP(ikll.New(kernellog.New(ikls.KernelLog{}, i), log).Service, v1)
+	ijot.NewHTTP(ijol.New(journal.New(ijos.Journal{}, i), log).Service, v1)
+	isut.NewHTTP(isul.New(systemdunit.New(isus.SystemdUnit{}, i), log).Service, v1)
 
 	// admin
 	vet.NewHTTP(vel.New(version.New(ves.Version{}, i), log).Service, v1)
 	det.NewHTTP(del.New(deployment.New(des.Deployment{}, a), log).Service, v1)
+	bdt.NewHTTP(bdl.New(bundle.New(bds.Bundle{}, a, i), log).Service, v1)
+	dst.NewHTTP(dsl.New(desiredstate.New(
+		dss.DesiredState{},
+		i,
+		desiredstate.Readers{
+			RpInterface: rpinterface.New(iins.RpInterface{}, i),
+			Boot:        boot.New(ibos.Boot{}, i),
+			Display:     display.New(idis.Display{}, i),
+			Software:    software.New(isos.Software{}, i),
+			HumanUser:   humanuser.New(ihus.HumanUser{}, i),
+		},
+		desiredstate.Appliers{
+			Configure:  configure.New(acs.Configure{}, a, i),
+			AppInstall: appinstall.New(ais.Install{}, a, i),
+		},
+	), log).Service, v1)
 
 	server.Start(e, &server.Config{
 		Port:                cfg.Server.Port,
@@ -165,3 +322,38 @@ func Start(cfg *config.Configuration) error {
 
 	return nil
 }
+
+// serverPort returns the port of the address the api listens on (ex: 3333 for :3333), 0 when it has none
+func serverPort(address string) uint16 {
+	port, err := strconv.ParseUint(address[strings.LastIndex(address, ":")+1:], 10, 16)
+	if err != nil {
+		return 0
+	}
+	return uint16(port)
+}
+
+// forecastConfig fills the missing disk forecast settings with their default values.
+func forecastConfig(fc *config.Forecast) config.Forecast {
+	result := config.Forecast{
+		SampleInterval: 10,
+		MaxSamples:     1008,
+		Horizon:        72,
+	}
+
+	if fc == nil {
+		return result
+	}
+
+	if fc.SampleInterval > 0 {
+		result.SampleInterval = fc.SampleInterval
+	}
+	if fc.MaxSamples > 0 {
+		result.MaxSamples = fc.MaxSamples
+	}
+	if fc.Horizon > 0 {
+		result.Horizon = fc.Horizon
+	}
+	result.HistoryFile = fc.HistoryFile
+
+	return result
+}
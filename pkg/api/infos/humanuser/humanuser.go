@@ -5,9 +5,11 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/constants"
 )
 
 // List populates and returns an array of HumanUser model.
+// /etc/shadow being readable by root only, the users are reported unlocked when it cannot be read.
 func (hu *HumanUser) List() ([]rpi.HumanUser, error) {
 	etcPasswdPath := hu.i.GetConfigFiles()["etcpasswd"].Path
 	humanUsers, err := hu.i.ReadFile(etcPasswdPath)
@@ -16,5 +18,15 @@ func (hu *HumanUser) List() ([]rpi.HumanUser, error) {
 		return nil, echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the human users")
 	}
 
-	return hu.humsys.List(humanUsers)
+	groups, err := hu.i.ReadFile(constants.ETCGROUP)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the groups of the human users")
+	}
+
+	shadow, err := hu.i.ReadFile(constants.ETCSHADOW)
+	if err != nil {
+		shadow = []string{}
+	}
+
+	return hu.humsys.List(humanUsers, groups, shadow, hu.i.LastLog())
 }
@@ -38,6 +38,26 @@ func TestList(t *testing.T) {
 			wantedData: nil,
 			wantedErr:  echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the human users"),
 		},
+		{
+			name: "error: groups cannot be read",
+			infos: mock.Infos{
+				GetConfigFilesFn: func() map[string]rpi.ConfigFileDetails {
+					return map[string]rpi.ConfigFileDetails{
+						"etcpasswd": {
+							Path: "/etc/passwd",
+						},
+					}
+				},
+				ReadFileFn: func(path string) ([]string, error) {
+					if path == "/etc/group" {
+						return nil, errors.New("test error info")
+					}
+					return []string{"pi:x:1000:1000:,,,:/home/pi:/bin/bash"}, nil
+				},
+			},
+			wantedData: nil,
+			wantedErr:  echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the groups of the human users"),
+		},
 		{
 			name: "success",
 			infos: mock.Infos{
@@ -48,7 +68,13 @@ func TestList(t *testing.T) {
 						},
 					}
 				},
-				ReadFileFn: func(string) ([]string, error) {
+				ReadFileFn: func(path string) ([]string, error) {
+					switch path {
+					case "/etc/group":
+						return []string{"pi:x:1000:", "sudo:x:27:pi"}, nil
+					case "/etc/shadow":
+						return nil, errors.New("permission denied")
+					}
 					return []string{
 						"systemd-network:x:101:103:systemd Network Management,,,:/run/systemd:/usr/sbin/nologin",
 						"systemd-resolve:x:102:104:systemd Resolver,,,:/run/systemd:/usr/sbin/nologin",
@@ -60,18 +86,25 @@ func TestList(t *testing.T) {
 						"statd:x:106:65534::/var/lib/nfs:/usr/sbin/nologin",
 					}, nil
 				},
+				LastLogFn: func() []string {
+					return []string{"pi               pts/0    192.168.1.10     Mon Oct 19 10:00:00 +0200 2026"}
+				},
 			},
 			humsys: mocksys.HumanUser{
-				ListFn: func([]string) ([]rpi.HumanUser, error) {
+				ListFn: func(lines []string, groups []string, shadow []string, lastLog []string) ([]rpi.HumanUser, error) {
+					if len(lines) != 8 || len(groups) != 2 || len(shadow) != 0 || len(lastLog) != 1 {
+						return nil, errors.New("unexpected lines")
+					}
 					return []rpi.HumanUser{
 						{
 							Username:       "pi",
-							Password:       "x",
 							Uid:            1000,
 							Gid:            1000,
 							AdditionalInfo: nil,
 							HomeDirectory:  "/home/pi",
 							DefaultShell:   "/bin/bash",
+							Groups:         []string{"pi", "sudo"},
+							LastLogin:      1792396800,
 						},
 					}, nil
 				},
@@ -79,12 +112,13 @@ func TestList(t *testing.T) {
 			wantedData: []rpi.HumanUser{
 				{
 					Username:       "pi",
-					Password:       "x",
 					Uid:            1000,
 					Gid:            1000,
 					AdditionalInfo: nil,
 					HomeDirectory:  "/home/pi",
 					DefaultShell:   "/bin/bash",
+					Groups:         []string{"pi", "sudo"},
+					LastLogin:      1792396800,
 				},
 			},
 			wantedErr: nil,
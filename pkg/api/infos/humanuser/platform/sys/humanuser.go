@@ -3,15 +3,22 @@ package sys
 import (
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/raspibuddy/rpi"
 )
 
+// lastLogLayout is the layout of the dates printed by lastlog
+const lastLogLayout = "Mon Jan 2 15:04:05 -0700 2006"
+
 // HumanUser represents a HumanUser entity on the current system.
 type HumanUser struct{}
 
-// List returns a list of HumanUser info
-func (hu HumanUser) List(listUsers []string) ([]rpi.HumanUser, error) {
+// List returns a list of HumanUser info out of the lines of /etc/passwd, /etc/group, /etc/shadow and of lastlog.
+// The shadow and lastlog lines may be empty, the users then being reported unlocked and never logged in.
+func (hu HumanUser) List(listUsers []string, groups []string, shadow []string, lastLog []string) ([]rpi.HumanUser, error) {
+	logins := lastLogins(lastLog)
+
 	var humanUsers []rpi.HumanUser
 
 	for _, v := range listUsers {
@@ -47,16 +54,21 @@ func (hu HumanUser) List(listUsers []string) ([]rpi.HumanUser, error) {
 						}
 					}
 
+					isLocked, maxDays := passwordStatus(shadow, lineSlice[0])
+
 					humanUsers = append(
 						humanUsers,
 						rpi.HumanUser{
-							Username:       lineSlice[0],
-							Password:       lineSlice[1],
-							Uid:            uid,
-							Gid:            gid,
-							AdditionalInfo: additionalInfo,
-							HomeDirectory:  lineSlice[5],
-							DefaultShell:   lineSlice[6],
+							Username:        lineSlice[0],
+							Uid:             uid,
+							Gid:             gid,
+							AdditionalInfo:  additionalInfo,
+							HomeDirectory:   lineSlice[5],
+							DefaultShell:    lineSlice[6],
+							Groups:          userGroups(groups, lineSlice[0], gid),
+							LastLogin:       logins[lineSlice[0]],
+							IsLocked:        isLocked,
+							PasswordMaxDays: maxDays,
 						},
 					)
 				}
@@ -66,3 +78,72 @@ func (hu HumanUser) List(listUsers []string) ([]rpi.HumanUser, error) {
 
 	return humanUsers, nil
 }
+
+// userGroups returns the names of the groups of a user, its primary group first
+func userGroups(groups []string, username string, gid int) []string {
+	result := []string{}
+
+	for _, g := range groups {
+		// ex: gpio:x:997:pi,bob
+		fields := strings.Split(g, ":")
+		if len(fields) != 4 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		if id, err := strconv.Atoi(fields[2]); err == nil && id == gid {
+			result = append([]string{fields[0]}, result...)
+			continue
+		}
+
+		for _, member := range strings.Split(fields[3], ",") {
+			if strings.TrimSpace(member) == username {
+				result = append(result, fields[0])
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// passwordStatus returns whether the password of a user is locked, along with its maximum age in days, 0 when it does not expire
+func passwordStatus(shadow []string, username string) (bool, int) {
+	for _, s := range shadow {
+		// ex: pi:$y$j9T$...:19650:0:99999:7:::
+		fields := strings.Split(s, ":")
+		if len(fields) < 5 || fields[0] != username {
+			continue
+		}
+
+		maxDays, err := strconv.Atoi(fields[4])
+		if err != nil || maxDays >= 99999 {
+			maxDays = 0
+		}
+
+		return strings.HasPrefix(fields[1], "!"), maxDays
+	}
+
+	return false, 0
+}
+
+// lastLogins returns the unix time of the most recent login of each user having logged in
+func lastLogins(lastLog []string) map[string]uint64 {
+	result := map[string]uint64{}
+
+	for _, l := range lastLog {
+		// ex: pi               pts/0    192.168.1.10     Mon Oct 19 10:00:00 +0200 2026
+		// the port and the origin are missing for the local logins
+		fields := strings.Fields(l)
+		if len(fields) < 7 || strings.Contains(l, "**Never logged in**") {
+			continue
+		}
+
+		t, err := time.Parse(lastLogLayout, strings.Join(fields[len(fields)-6:], " "))
+		if err != nil {
+			continue
+		}
+		result[fields[0]] = uint64(t.Unix())
+	}
+
+	return result
+}
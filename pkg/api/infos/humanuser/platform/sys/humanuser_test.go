@@ -13,6 +13,9 @@ func TestList(t *testing.T) {
 	cases := []struct {
 		name       string
 		lines      []string
+		groups     []string
+		shadow     []string
+		lastLog    []string
 		wantedData []rpi.HumanUser
 		wantedErr  error
 	}{
@@ -30,33 +33,57 @@ func TestList(t *testing.T) {
 				"_rpc:x:105:65534::/run/rpcbind:/usr/sbin/nologin",
 				"statd:x:106:65534::/var/lib/nfs:/usr/sbin/nologin",
 			},
+			groups: []string{
+				"root:x:0:",
+				"sudo:x:27:pi",
+				"video:x:44:pi,pi3",
+				"#gpio:x:997:pi4",
+				"pi:x:1000:",
+				"pi3:x:1002:",
+			},
+			shadow: []string{
+				"root:*:19650:0:99999:7:::",
+				"pi:$y$j9T$abc:19650:0:99999:7:::",
+				"pi3:!$y$j9T$def:19650:0:90:7:::",
+			},
+			lastLog: []string{
+				"Username         Port     From             Latest",
+				"root                                       **Never logged in**",
+				"pi               pts/0    192.168.1.10     Mon Oct 19 10:00:00 +0200 2026",
+				"pi3              tty1                      Fri Oct  9 08:30:00 +0000 2026",
+				"pi4                                        **Never logged in**",
+			},
 			wantedData: []rpi.HumanUser{
 				{
 					Username:       "pi",
-					Password:       "x",
 					Uid:            1000,
 					Gid:            1000,
 					AdditionalInfo: nil,
 					HomeDirectory:  "/home/pi",
 					DefaultShell:   "/bin/bash",
+					Groups:         []string{"pi", "sudo", "video"},
+					LastLogin:      1792396800,
 				},
 				{
-					Username:       "pi3",
-					Password:       "x",
-					Uid:            1002,
-					Gid:            1002,
-					AdditionalInfo: []string{"A", "B", "C"},
-					HomeDirectory:  "/home/pi3",
-					DefaultShell:   "/bin/bash",
+					Username:        "pi3",
+					Uid:             1002,
+					Gid:             1002,
+					AdditionalInfo:  []string{"A", "B", "C"},
+					HomeDirectory:   "/home/pi3",
+					DefaultShell:    "/bin/bash",
+					Groups:          []string{"pi3", "video"},
+					LastLogin:       1791534600,
+					IsLocked:        true,
+					PasswordMaxDays: 90,
 				},
 				{
 					Username:       "pi4",
-					Password:       "x",
 					Uid:            1003,
 					Gid:            1003,
 					AdditionalInfo: nil,
 					HomeDirectory:  "/home/pi4",
 					DefaultShell:   "/bin/bash",
+					Groups:         []string{},
 				},
 			},
 			wantedErr: nil,
@@ -66,7 +93,7 @@ func TestList(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			s := humanuser.HUMSYS(sys.HumanUser{})
-			humanUsers, err := s.List(tc.lines)
+			humanUsers, err := s.List(tc.lines, tc.groups, tc.shadow, tc.lastLog)
 			assert.Equal(t, tc.wantedData, humanUsers)
 			assert.Equal(t, tc.wantedErr, err)
 		})
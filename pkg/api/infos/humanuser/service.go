@@ -17,13 +17,14 @@ type HumanUser struct {
 
 // HUMSYS represents an HumanUser repository service.
 type HUMSYS interface {
-	List([]string) ([]rpi.HumanUser, error)
+	List([]string, []string, []string, []string) ([]rpi.HumanUser, error)
 }
 
 // Infos represents the infos interface
 type Infos interface {
 	ReadFile(string) ([]string, error)
 	GetConfigFiles() map[string]rpi.ConfigFileDetails
+	LastLog() []string
 }
 
 // New creates a HumanUser application service instance.
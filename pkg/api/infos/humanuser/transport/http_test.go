@@ -33,7 +33,7 @@ func TestList(t *testing.T) {
 		{
 			name: "error: List result is nil",
 			humsys: &mocksys.HumanUser{
-				ListFn: func([]string) ([]rpi.HumanUser, error) {
+				ListFn: func([]string, []string, []string, []string) ([]rpi.HumanUser, error) {
 					return nil, errors.New("test error")
 				},
 			},
@@ -42,11 +42,10 @@ func TestList(t *testing.T) {
 		{
 			name: "success",
 			humsys: &mocksys.HumanUser{
-				ListFn: func([]string) ([]rpi.HumanUser, error) {
+				ListFn: func([]string, []string, []string, []string) ([]rpi.HumanUser, error) {
 					return []rpi.HumanUser{
 						{
 							Username:       "pi",
-							Password:       "x",
 							Uid:            1000,
 							Gid:            1000,
 							AdditionalInfo: nil,
@@ -60,7 +59,6 @@ func TestList(t *testing.T) {
 			wantedResp: []rpi.HumanUser{
 				{
 					Username:       "pi",
-					Password:       "x",
 					Uid:            1000,
 					Gid:            1000,
 					AdditionalInfo: nil,
@@ -0,0 +1,18 @@
+package journal
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+)
+
+// List populates and returns the journal entries matching the query.
+func (j *Journal) List(q rpi.JournalQuery) ([]rpi.JournalEntry, error) {
+	lines, err := j.i.Journalctl(j.jsys.Args(q))
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "could not read the journal")
+	}
+
+	return j.jsys.List(lines)
+}
@@ -0,0 +1,70 @@
+package journal_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/infos/journal"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	cases := []struct {
+		name       string
+		infos      mock.Infos
+		jsys       mocksys.Journal
+		wantedData []rpi.JournalEntry
+		wantedErr  error
+	}{
+		{
+			name: "error: journalctl",
+			infos: mock.Infos{
+				JournalctlFn: func([]string) ([]string, error) {
+					return nil, errors.New("test error")
+				},
+			},
+			jsys: mocksys.Journal{
+				ArgsFn: func(rpi.JournalQuery) []string {
+					return []string{"--unit=ssh.service"}
+				},
+			},
+			wantedData: nil,
+			wantedErr:  echo.NewHTTPError(http.StatusInternalServerError, "could not read the journal"),
+		},
+		{
+			name: "success",
+			infos: mock.Infos{
+				JournalctlFn: func(args []string) ([]string, error) {
+					if len(args) != 1 || args[0] != "--unit=ssh.service" {
+						return nil, errors.New("test error")
+					}
+					return []string{`{"MESSAGE":"Server listening on 0.0.0.0 port 22."}`}, nil
+				},
+			},
+			jsys: mocksys.Journal{
+				ArgsFn: func(rpi.JournalQuery) []string {
+					return []string{"--unit=ssh.service"}
+				},
+				ListFn: func([]string) ([]rpi.JournalEntry, error) {
+					return []rpi.JournalEntry{{Unit: "ssh.service", Message: "Server listening on 0.0.0.0 port 22."}}, nil
+				},
+			},
+			wantedData: []rpi.JournalEntry{{Unit: "ssh.service", Message: "Server listening on 0.0.0.0 port 22."}},
+			wantedErr:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := journal.New(&tc.jsys, tc.infos)
+			entries, err := s.List(rpi.JournalQuery{Unit: "ssh.service"})
+			assert.Equal(t, tc.wantedData, entries)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
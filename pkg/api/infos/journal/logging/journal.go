@@ -0,0 +1,41 @@
+package journal
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/infos/journal"
+)
+
+// New creates a new journal logging service instance.
+func New(svc journal.Service, logger rpi.Logger) *LogService {
+	return &LogService{
+		Service: svc,
+		logger:  logger,
+	}
+}
+
+// LogService represents a journal logging service.
+type LogService struct {
+	journal.Service
+	logger rpi.Logger
+}
+
+const name = "journal"
+
+// List is the logging function attached to the List journal services and responsible for logging it out.
+func (ls *LogService) List(ctx echo.Context, q rpi.JournalQuery) (resp []rpi.JournalEntry, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: listing journal entries (%+v)", q), err,
+			map[string]interface{}{
+				"entries": len(resp),
+				"took":    time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.List(q)
+}
@@ -0,0 +1,105 @@
+package sys
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/raspibuddy/rpi"
+)
+
+// Levels lists the journal priority names indexed by their value
+var Levels = []string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"}
+
+// Journal represents a Journal entity on the current system.
+type Journal struct{}
+
+// Args returns the journalctl arguments matching a query
+func (j Journal) Args(q rpi.JournalQuery) []string {
+	args := []string{"--no-pager", "--output=json"}
+
+	if q.Unit != "" {
+		args = append(args, fmt.Sprintf("--unit=%v", q.Unit))
+	}
+	if q.Priority != "" {
+		args = append(args, fmt.Sprintf("--priority=%v", q.Priority))
+	}
+	if q.Boot != "" {
+		args = append(args, fmt.Sprintf("--boot=%v", q.Boot))
+	}
+	if q.Since != "" {
+		args = append(args, fmt.Sprintf("--since=%v", q.Since))
+	}
+	if q.Until != "" {
+		args = append(args, fmt.Sprintf("--until=%v", q.Until))
+	}
+	if q.Cursor != "" {
+		args = append(args, fmt.Sprintf("--after-cursor=%v", q.Cursor))
+	}
+	if q.Lines > 0 {
+		args = append(args, fmt.Sprintf("--lines=%v", q.Lines))
+	}
+
+	return args
+}
+
+// List parses the json lines returned by journalctl into journal entries
+func (j Journal) List(lines []string) ([]rpi.JournalEntry, error) {
+	result := []rpi.JournalEntry{}
+
+	for _, l := range lines {
+		var fields map[string]interface{}
+		if err := json.Unmarshal([]byte(l), &fields); err != nil {
+			continue
+		}
+		result = append(result, Entry(fields))
+	}
+
+	return result, nil
+}
+
+// Entry converts the fields of a journal entry into a JournalEntry object
+func Entry(fields map[string]interface{}) rpi.JournalEntry {
+	result := rpi.JournalEntry{
+		Cursor:     Field(fields, "__CURSOR"),
+		BootID:     Field(fields, "_BOOT_ID"),
+		Hostname:   Field(fields, "_HOSTNAME"),
+		Unit:       Field(fields, "_SYSTEMD_UNIT"),
+		Identifier: Field(fields, "SYSLOG_IDENTIFIER"),
+		Message:    Field(fields, "MESSAGE"),
+		Priority:   -1,
+	}
+
+	// messages logged by systemd about a unit carry it in the UNIT field
+	if unit := Field(fields, "UNIT"); unit != "" {
+		result.Unit = unit
+	}
+
+	result.Timestamp, _ = strconv.ParseUint(Field(fields, "__REALTIME_TIMESTAMP"), 10, 64)
+	result.PID, _ = strconv.Atoi(Field(fields, "_PID"))
+
+	if p, err := strconv.Atoi(Field(fields, "PRIORITY")); err == nil && p >= 0 && p < len(Levels) {
+		result.Priority = p
+		result.Level = Levels[p]
+	}
+
+	return result
+}
+
+// Field returns a journal field as a string, binary fields being serialized as arrays of bytes
+func Field(fields map[string]interface{}, key string) string {
+	switch v := fields[key].(type) {
+	case string:
+		return v
+	case []interface{}:
+		b := make([]byte, 0, len(v))
+		for _, c := range v {
+			if f, ok := c.(float64); ok {
+				b = append(b, byte(f))
+			}
+		}
+		return string(b)
+	default:
+		return ""
+	}
+}
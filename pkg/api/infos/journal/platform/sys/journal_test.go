@@ -0,0 +1,92 @@
+package sys_test
+
+import (
+	"testing"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/infos/journal/platform/sys"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArgs(t *testing.T) {
+	cases := []struct {
+		name       string
+		query      rpi.JournalQuery
+		wantedArgs []string
+	}{
+		{
+			name:       "success: no filter",
+			query:      rpi.JournalQuery{},
+			wantedArgs: []string{"--no-pager", "--output=json"},
+		},
+		{
+			name: "success: all filters",
+			query: rpi.JournalQuery{
+				Unit:     "ssh.service",
+				Priority: "err",
+				Boot:     "-1",
+				Since:    "2020-01-01 10:00:00",
+				Until:    "today",
+				Cursor:   "s=abc;i=1",
+				Lines:    50,
+			},
+			wantedArgs: []string{
+				"--no-pager",
+				"--output=json",
+				"--unit=ssh.service",
+				"--priority=err",
+				"--boot=-1",
+				"--since=2020-01-01 10:00:00",
+				"--until=today",
+				"--after-cursor=s=abc;i=1",
+				"--lines=50",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := sys.Journal{}
+			assert.Equal(t, tc.wantedArgs, s.Args(tc.query))
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	lines := []string{
+		`{"__CURSOR":"s=abc;i=1","__REALTIME_TIMESTAMP":"1600000000000000","_BOOT_ID":"b1","_HOSTNAME":"raspberrypi","_SYSTEMD_UNIT":"ssh.service","SYSLOG_IDENTIFIER":"sshd","_PID":"512","PRIORITY":"6","MESSAGE":"Server listening on 0.0.0.0 port 22."}`,
+		`{"__CURSOR":"s=abc;i=2","_SYSTEMD_UNIT":"init.scope","UNIT":"nginx.service","PRIORITY":"3","MESSAGE":"Failed to start A high performance web server."}`,
+		`{"__CURSOR":"s=abc;i=3","MESSAGE":[104,105,10]}`,
+		`not a json line`,
+	}
+
+	s := sys.Journal{}
+	entries, err := s.List(lines)
+	assert.Nil(t, err)
+	assert.Equal(t, []rpi.JournalEntry{
+		{
+			Cursor:     "s=abc;i=1",
+			Timestamp:  1600000000000000,
+			BootID:     "b1",
+			Hostname:   "raspberrypi",
+			Unit:       "ssh.service",
+			Identifier: "sshd",
+			PID:        512,
+			Priority:   6,
+			Level:      "info",
+			Message:    "Server listening on 0.0.0.0 port 22.",
+		},
+		{
+			Cursor:   "s=abc;i=2",
+			Unit:     "nginx.service",
+			Priority: 3,
+			Level:    "err",
+			Message:  "Failed to start A high performance web server.",
+		},
+		{
+			Cursor:   "s=abc;i=3",
+			Priority: -1,
+			Message:  "hi\n",
+		},
+	}, entries)
+}
@@ -0,0 +1,32 @@
+package journal
+
+import (
+	"github.com/raspibuddy/rpi"
+)
+
+// Service represents all Journal application services.
+type Service interface {
+	List(rpi.JournalQuery) ([]rpi.JournalEntry, error)
+}
+
+// Journal represents a Journal application service.
+type Journal struct {
+	jsys JSYS
+	i    Infos
+}
+
+// JSYS represents a Journal repository service.
+type JSYS interface {
+	Args(rpi.JournalQuery) []string
+	List([]string) ([]rpi.JournalEntry, error)
+}
+
+// Infos represents the infos interface
+type Infos interface {
+	Journalctl([]string) ([]string, error)
+}
+
+// New creates a Journal application service instance.
+func New(jsys JSYS, i Infos) *Journal {
+	return &Journal{jsys: jsys, i: i}
+}
@@ -0,0 +1,142 @@
+package transport
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/infos/journal"
+)
+
+// HTTP is a struct implementing a journal application service.
+type HTTP struct {
+	svc journal.Service
+}
+
+// NewHTTP creates new journal http service
+func NewHTTP(svc journal.Service, r *echo.Group) {
+	h := HTTP{svc}
+	cr := r.Group("/journal")
+	cr.GET("", h.list)
+	cr.GET("-ws", h.listws)
+}
+
+// defaultLines is the number of entries returned when no time range nor cursor is given
+const defaultLines = 100
+
+var (
+	unitRegex     = regexp.MustCompile(`^[a-zA-Z0-9@:._\-]+$`)
+	priorityRegex = regexp.MustCompile(`^([0-7]|emerg|alert|crit|err|warning|notice|info|debug)$`)
+	bootRegex     = regexp.MustCompile(`^(-?[0-9]+|[0-9a-f]{32})$`)
+	timeRegex     = regexp.MustCompile(`^[0-9a-zA-Z:+. \-]+$`)
+	cursorRegex   = regexp.MustCompile(`^[0-9a-zA-Z=;]+$`)
+)
+
+// query returns the journal query built from the request params
+func query(ctx echo.Context) (rpi.JournalQuery, error) {
+	q := rpi.JournalQuery{
+		Unit:     ctx.QueryParam("unit"),
+		Priority: ctx.QueryParam("priority"),
+		Boot:     ctx.QueryParam("boot"),
+		Since:    ctx.QueryParam("since"),
+		Until:    ctx.QueryParam("until"),
+		Cursor:   ctx.QueryParam("cursor"),
+	}
+
+	if q.Unit != "" && !unitRegex.MatchString(q.Unit) {
+		return rpi.JournalQuery{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid unit - should be a unit name")
+	}
+	if q.Priority != "" && !priorityRegex.MatchString(q.Priority) {
+		return rpi.JournalQuery{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid priority - should be a level name or an integer between 0 and 7")
+	}
+	if q.Boot != "" && !bootRegex.MatchString(q.Boot) {
+		return rpi.JournalQuery{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid boot - should be an offset or a boot id")
+	}
+	if (q.Since != "" && !timeRegex.MatchString(q.Since)) || (q.Until != "" && !timeRegex.MatchString(q.Until)) {
+		return rpi.JournalQuery{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid time range - should be a journalctl timestamp")
+	}
+	if q.Cursor != "" && !cursorRegex.MatchString(q.Cursor) {
+		return rpi.JournalQuery{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid cursor")
+	}
+
+	if l := ctx.QueryParam("lines"); l != "" {
+		v, err := strconv.Atoi(l)
+		if err != nil || v <= 0 {
+			return rpi.JournalQuery{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid lines - should be a positive integer")
+		}
+		q.Lines = v
+	} else if q.Since == "" && q.Until == "" && q.Cursor == "" {
+		q.Lines = defaultLines
+	}
+
+	return q, nil
+}
+
+func (h *HTTP) list(ctx echo.Context) error {
+	q, err := query(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.svc.List(q)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+var upgrader = websocket.Upgrader{}
+
+func (h *HTTP) listws(ctx echo.Context) error {
+	q, err := query(ctx)
+	if err != nil {
+		return err
+	}
+
+	ws, err := upgrader.Upgrade(ctx.Response(), ctx.Request(), nil)
+	if err != nil {
+		return err
+	}
+
+	defer ws.Close()
+
+	// Read incoming messages from client until it closes the connection
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			if _, _, errR := ws.ReadMessage(); errR != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		// Compose message made of the entries logged after the last cursor sent
+		msg, errL := h.svc.List(q)
+		if errL != nil {
+			ctx.Logger().Error(errL)
+			break
+		}
+
+		if len(msg) > 0 {
+			if errW := ws.WriteJSON(msg); errW != nil {
+				ctx.Logger().Error(errW)
+				break
+			}
+			q.Cursor = msg[len(msg)-1].Cursor
+			q.Since, q.Lines = "", 0
+		}
+
+		select {
+		case <-done:
+			return nil
+		case <-time.After(time.Second):
+		}
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package transport_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/infos/journal"
+	"github.com/raspibuddy/rpi/pkg/api/infos/journal/transport"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/raspibuddy/rpi/pkg/utl/server"
+	"github.com/stretchr/testify/assert"
+)
+
+var i = mock.Infos{
+	JournalctlFn: func([]string) ([]string, error) {
+		return []string{}, nil
+	},
+}
+
+func TestList(t *testing.T) {
+	var response []rpi.JournalEntry
+
+	cases := []struct {
+		name         string
+		req          string
+		jsys         *mocksys.Journal
+		wantedStatus int
+		wantedResp   []rpi.JournalEntry
+	}{
+		{
+			name:         "error: invalid unit",
+			req:          "?unit=" + url.QueryEscape("ssh;reboot"),
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid priority",
+			req:          "?priority=8",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid boot",
+			req:          "?boot=last",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid since",
+			req:          "?since=" + url.QueryEscape("$(reboot)"),
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid cursor",
+			req:          "?cursor=" + url.QueryEscape("s=a b"),
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid lines",
+			req:          "?lines=0",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error: List result is nil",
+			jsys: &mocksys.Journal{
+				ArgsFn: func(rpi.JournalQuery) []string {
+					return nil
+				},
+				ListFn: func([]string) ([]rpi.JournalEntry, error) {
+					return nil, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "success: default lines",
+			req:  "?unit=ssh.service&priority=err&boot=0",
+			jsys: &mocksys.Journal{
+				ArgsFn: func(q rpi.JournalQuery) []string {
+					if q.Unit != "ssh.service" || q.Priority != "err" || q.Boot != "0" || q.Lines != 100 {
+						return []string{"unexpected"}
+					}
+					return nil
+				},
+				ListFn: func(lines []string) ([]rpi.JournalEntry, error) {
+					return []rpi.JournalEntry{{Cursor: "s=abc;i=1", Unit: "ssh.service"}}, nil
+				},
+			},
+			wantedStatus: http.StatusOK,
+			wantedResp:   []rpi.JournalEntry{{Cursor: "s=abc;i=1", Unit: "ssh.service"}},
+		},
+		{
+			name: "success: time range",
+			req:  "?since=" + url.QueryEscape("2020-01-01 10:00:00") + "&until=today",
+			jsys: &mocksys.Journal{
+				ArgsFn: func(q rpi.JournalQuery) []string {
+					return nil
+				},
+				ListFn: func([]string) ([]rpi.JournalEntry, error) {
+					return []rpi.JournalEntry{{Cursor: "s=abc;i=2"}}, nil
+				},
+			},
+			wantedStatus: http.StatusOK,
+			wantedResp:   []rpi.JournalEntry{{Cursor: "s=abc;i=2"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			ii := i
+			ii.JournalctlFn = func(args []string) ([]string, error) {
+				if len(args) > 0 {
+					return nil, errors.New("unexpected query")
+				}
+				return []string{}, nil
+			}
+			s := journal.New(tc.jsys, ii)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/journal" + tc.req
+			res, err := http.Get(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+
+			body, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				panic(err)
+			}
+
+			if tc.wantedResp != nil {
+				if err := json.Unmarshal(body, &response); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, tc.wantedResp, response)
+			}
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestListWS(t *testing.T) {
+	var response []rpi.JournalEntry
+
+	jsys := &mocksys.Journal{
+		ArgsFn: func(rpi.JournalQuery) []string {
+			return nil
+		},
+		ListFn: func([]string) ([]rpi.JournalEntry, error) {
+			return []rpi.JournalEntry{{Cursor: "s=abc;i=1", Unit: "ssh.service"}}, nil
+		},
+	}
+
+	r := server.New()
+	rg := r.Group("")
+	s := journal.New(jsys, i)
+	transport.NewHTTP(s, rg)
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	pathWS := "ws" + strings.TrimPrefix(ts.URL, "http") + "/journal-ws?unit=ssh.service"
+	ws, _, err := websocket.DefaultDialer.Dial(pathWS, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+
+	if err := ws.ReadJSON(&response); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []rpi.JournalEntry{{Cursor: "s=abc;i=1", Unit: "ssh.service"}}, response)
+}
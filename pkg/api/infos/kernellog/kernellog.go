@@ -0,0 +1,20 @@
+package kernellog
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/constants"
+)
+
+// List populates and returns the kernel log records at least as severe as level
+// and whose sequence number is greater than or equal to since.
+func (kl *KernelLog) List(level int, since uint64) ([]rpi.KernelLog, error) {
+	records, err := kl.i.ReadKmsg(constants.KMSG)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "could not read the kernel log")
+	}
+
+	return kl.klsys.List(records, level, since)
+}
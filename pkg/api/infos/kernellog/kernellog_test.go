@@ -0,0 +1,59 @@
+package kernellog_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/infos/kernellog"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	cases := []struct {
+		name       string
+		infos      mock.Infos
+		klsys      mocksys.KernelLog
+		wantedData []rpi.KernelLog
+		wantedErr  error
+	}{
+		{
+			name: "error: reading kmsg",
+			infos: mock.Infos{
+				ReadKmsgFn: func(string) ([]string, error) {
+					return nil, errors.New("test error")
+				},
+			},
+			wantedData: nil,
+			wantedErr:  echo.NewHTTPError(http.StatusInternalServerError, "could not read the kernel log"),
+		},
+		{
+			name: "success",
+			infos: mock.Infos{
+				ReadKmsgFn: func(string) ([]string, error) {
+					return []string{"3,12,100,-;under-voltage detected!"}, nil
+				},
+			},
+			klsys: mocksys.KernelLog{
+				ListFn: func([]string, int, uint64) ([]rpi.KernelLog, error) {
+					return []rpi.KernelLog{{Sequence: 12, Priority: 3, Message: "under-voltage detected!"}}, nil
+				},
+			},
+			wantedData: []rpi.KernelLog{{Sequence: 12, Priority: 3, Message: "under-voltage detected!"}},
+			wantedErr:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := kernellog.New(&tc.klsys, tc.infos)
+			logs, err := s.List(4, 10)
+			assert.Equal(t, tc.wantedData, logs)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
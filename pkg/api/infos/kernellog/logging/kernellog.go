@@ -0,0 +1,41 @@
+package kernellog
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/infos/kernellog"
+)
+
+// New creates a new kernellog logging service instance.
+func New(svc kernellog.Service, logger rpi.Logger) *LogService {
+	return &LogService{
+		Service: svc,
+		logger:  logger,
+	}
+}
+
+// LogService represents a kernellog logging service.
+type LogService struct {
+	kernellog.Service
+	logger rpi.Logger
+}
+
+const name = "kernellog"
+
+// List is the logging function attached to the List kernellog services and responsible for logging it out.
+func (ls *LogService) List(ctx echo.Context, level int, since uint64) (resp []rpi.KernelLog, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: listing kernel log (level %v, since %v)", level, since), err,
+			map[string]interface{}{
+				"records": len(resp),
+				"took":    time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.List(level, since)
+}
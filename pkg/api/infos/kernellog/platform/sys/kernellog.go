@@ -0,0 +1,125 @@
+package sys
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/raspibuddy/rpi"
+)
+
+// Levels lists the kernel log level names indexed by their value
+var Levels = []string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"}
+
+// Facilities lists the syslog facility names indexed by their value
+var Facilities = []string{
+	"kern", "user", "mail", "daemon", "auth", "syslog", "lpr", "news",
+	"uucp", "cron", "authpriv", "ftp", "ntp", "security", "console", "solaris-cron",
+	"local0", "local1", "local2", "local3", "local4", "local5", "local6", "local7",
+}
+
+// KernelLog represents a KernelLog entity on the current system.
+type KernelLog struct{}
+
+// List returns the kernel log records at least as severe as level and whose sequence is greater than or equal to since
+func (kl KernelLog) List(records []string, level int, since uint64) ([]rpi.KernelLog, error) {
+	result := []rpi.KernelLog{}
+
+	for _, r := range records {
+		k, err := ParseRecord(r)
+		if err != nil {
+			continue
+		}
+		if int(k.Priority) > level || k.Sequence < since {
+			continue
+		}
+		result = append(result, k)
+	}
+
+	return result, nil
+}
+
+// ParseRecord parses a /dev/kmsg record, i.e. "prio,seq,ts,flag[,...];message" and its " KEY=value" continuation lines
+func ParseRecord(record string) (rpi.KernelLog, error) {
+	lines := strings.Split(record, "\n")
+
+	parts := strings.SplitN(lines[0], ";", 2)
+	if len(parts) != 2 {
+		return rpi.KernelLog{}, errors.New("record badly formatted")
+	}
+
+	header := strings.Split(parts[0], ",")
+	if len(header) < 3 {
+		return rpi.KernelLog{}, errors.New("record header badly formatted")
+	}
+
+	prio, errP := strconv.ParseUint(header[0], 10, 32)
+	seq, errS := strconv.ParseUint(header[1], 10, 64)
+	ts, errT := strconv.ParseUint(header[2], 10, 64)
+	if errP != nil || errS != nil || errT != nil {
+		return rpi.KernelLog{}, errors.New("record header badly formatted")
+	}
+
+	// the priority holds the facility in its upper bits and the level in its 3 lowest bits
+	result := rpi.KernelLog{
+		Sequence:  seq,
+		Priority:  uint8(prio & 7),
+		Level:     Levels[prio&7],
+		Facility:  uint8(prio >> 3),
+		Timestamp: ts,
+		Message:   Unescape(parts[1]),
+	}
+	if int(result.Facility) < len(Facilities) {
+		result.FacilityName = Facilities[result.Facility]
+	}
+
+	for _, l := range lines[1:] {
+		kv := strings.SplitN(strings.TrimPrefix(l, " "), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		if result.Properties == nil {
+			result.Properties = make(map[string]string)
+		}
+		result.Properties[kv[0]] = Unescape(kv[1])
+	}
+
+	return result, nil
+}
+
+// Unescape replaces the \xNN sequences used by the kernel to escape non printable characters
+func Unescape(s string) string {
+	if !strings.Contains(s, `\x`) {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) && s[i+1] == 'x' {
+			if v, err := strconv.ParseUint(s[i+2:i+4], 16, 8); err == nil {
+				b.WriteByte(byte(v))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+
+	return b.String()
+}
+
+// ParseLevel returns the value of a level given either as a name or as a number between 0 and 7
+func ParseLevel(level string) (int, error) {
+	for i, l := range Levels {
+		if l == level {
+			return i, nil
+		}
+	}
+
+	v, err := strconv.Atoi(level)
+	if err != nil || v < 0 || v >= len(Levels) {
+		return 0, errors.New("level is not valid")
+	}
+
+	return v, nil
+}
@@ -0,0 +1,159 @@
+package sys_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/infos/kernellog/platform/sys"
+	"github.com/stretchr/testify/assert"
+)
+
+var records = []string{
+	"6,339,5140900,-;NET: Registered protocol family 10",
+	"2,340,5141000,-;Under-voltage detected! (0x00050005)",
+	"4,1040,14592931,-;usb 1-1.2: USB disconnect, device number 3\n SUBSYSTEM=usb\n DEVICE=c189:2",
+	"30,1041,14600000,-;systemd[1]: Started Journal Service.",
+	"badly formatted record",
+}
+
+func TestList(t *testing.T) {
+	cases := []struct {
+		name       string
+		level      int
+		since      uint64
+		wantedSeqs []uint64
+	}{
+		{
+			name:       "success: all records",
+			level:      7,
+			wantedSeqs: []uint64{339, 340, 1040, 1041},
+		},
+		{
+			name:       "success: warning and above",
+			level:      4,
+			wantedSeqs: []uint64{340, 1040},
+		},
+		{
+			name:       "success: since sequence",
+			level:      7,
+			since:      1040,
+			wantedSeqs: []uint64{1040, 1041},
+		},
+		{
+			name:       "success: nothing new",
+			level:      7,
+			since:      2000,
+			wantedSeqs: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := sys.KernelLog{}
+			logs, err := s.List(records, tc.level, tc.since)
+			var seqs []uint64
+			for _, l := range logs {
+				seqs = append(seqs, l.Sequence)
+			}
+			assert.Equal(t, tc.wantedSeqs, seqs)
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestParseRecord(t *testing.T) {
+	cases := []struct {
+		name       string
+		record     string
+		wantedData rpi.KernelLog
+		wantedErr  error
+	}{
+		{
+			name:      "error: no message",
+			record:    "6,339,5140900,-",
+			wantedErr: errors.New("record badly formatted"),
+		},
+		{
+			name:      "error: bad header",
+			record:    "a,339,5140900,-;message",
+			wantedErr: errors.New("record header badly formatted"),
+		},
+		{
+			name:   "success: with properties",
+			record: records[2],
+			wantedData: rpi.KernelLog{
+				Sequence:     1040,
+				Priority:     4,
+				Level:        "warning",
+				Facility:     0,
+				FacilityName: "kern",
+				Timestamp:    14592931,
+				Message:      "usb 1-1.2: USB disconnect, device number 3",
+				Properties: map[string]string{
+					"SUBSYSTEM": "usb",
+					"DEVICE":    "c189:2",
+				},
+			},
+		},
+		{
+			name:   "success: daemon facility and escaped message",
+			record: `30,1041,14600000,-;line one\x0aline two`,
+			wantedData: rpi.KernelLog{
+				Sequence:     1041,
+				Priority:     6,
+				Level:        "info",
+				Facility:     3,
+				FacilityName: "daemon",
+				Timestamp:    14600000,
+				Message:      "line one\nline two",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			k, err := sys.ParseRecord(tc.record)
+			assert.Equal(t, tc.wantedData, k)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	cases := []struct {
+		name        string
+		level       string
+		wantedLevel int
+		wantedErr   error
+	}{
+		{
+			name:        "success: name",
+			level:       "err",
+			wantedLevel: 3,
+		},
+		{
+			name:        "success: number",
+			level:       "5",
+			wantedLevel: 5,
+		},
+		{
+			name:      "error: out of range",
+			level:     "8",
+			wantedErr: errors.New("level is not valid"),
+		},
+		{
+			name:      "error: unknown name",
+			level:     "verbose",
+			wantedErr: errors.New("level is not valid"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			level, err := sys.ParseLevel(tc.level)
+			assert.Equal(t, tc.wantedLevel, level)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
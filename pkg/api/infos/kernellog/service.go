@@ -0,0 +1,31 @@
+package kernellog
+
+import (
+	"github.com/raspibuddy/rpi"
+)
+
+// Service represents all KernelLog application services.
+type Service interface {
+	List(int, uint64) ([]rpi.KernelLog, error)
+}
+
+// KernelLog represents a KernelLog application service.
+type KernelLog struct {
+	klsys KLSYS
+	i     Infos
+}
+
+// KLSYS represents a KernelLog repository service.
+type KLSYS interface {
+	List([]string, int, uint64) ([]rpi.KernelLog, error)
+}
+
+// Infos represents the infos interface
+type Infos interface {
+	ReadKmsg(string) ([]string, error)
+}
+
+// New creates a KernelLog application service instance.
+func New(klsys KLSYS, i Infos) *KernelLog {
+	return &KernelLog{klsys: klsys, i: i}
+}
@@ -0,0 +1,112 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi/pkg/api/infos/kernellog"
+	"github.com/raspibuddy/rpi/pkg/api/infos/kernellog/platform/sys"
+)
+
+// HTTP is a struct implementing a kernellog application service.
+type HTTP struct {
+	svc kernellog.Service
+}
+
+// NewHTTP creates new kernellog http service
+func NewHTTP(svc kernellog.Service, r *echo.Group) {
+	h := HTTP{svc}
+	cr := r.Group("/kernel")
+	cr.GET("/log", h.list)
+	cr.GET("/log-ws", h.listws)
+}
+
+// params returns the level and since query params, defaulting to every record
+func params(ctx echo.Context) (int, uint64, error) {
+	level := len(sys.Levels) - 1
+	if l := ctx.QueryParam("level"); l != "" {
+		v, err := sys.ParseLevel(l)
+		if err != nil {
+			return 0, 0, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid level - should be a level name or an integer between 0 and 7")
+		}
+		level = v
+	}
+
+	var since uint64
+	if s := ctx.QueryParam("since"); s != "" {
+		v, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return 0, 0, echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid since - should be a sequence number")
+		}
+		since = v
+	}
+
+	return level, since, nil
+}
+
+func (h *HTTP) list(ctx echo.Context) error {
+	level, since, err := params(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.svc.List(level, since)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+var upgrader = websocket.Upgrader{}
+
+func (h *HTTP) listws(ctx echo.Context) error {
+	level, since, err := params(ctx)
+	if err != nil {
+		return err
+	}
+
+	ws, err := upgrader.Upgrade(ctx.Response(), ctx.Request(), nil)
+	if err != nil {
+		return err
+	}
+
+	defer ws.Close()
+
+	// Read incoming messages from client until it closes the connection
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			if _, _, errR := ws.ReadMessage(); errR != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		// Compose message made of the records logged since the previous one
+		msg, errL := h.svc.List(level, since)
+		if errL != nil {
+			ctx.Logger().Error(errL)
+			break
+		}
+
+		if len(msg) > 0 {
+			if errW := ws.WriteJSON(msg); errW != nil {
+				ctx.Logger().Error(errW)
+				break
+			}
+			since = msg[len(msg)-1].Sequence + 1
+		}
+
+		select {
+		case <-done:
+			return nil
+		case <-time.After(time.Second):
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package transport_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/infos/kernellog"
+	"github.com/raspibuddy/rpi/pkg/api/infos/kernellog/transport"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/raspibuddy/rpi/pkg/utl/server"
+	"github.com/stretchr/testify/assert"
+)
+
+var i = mock.Infos{
+	ReadKmsgFn: func(string) ([]string, error) {
+		return []string{"3,12,100,-;under-voltage detected!"}, nil
+	},
+}
+
+func TestList(t *testing.T) {
+	var response []rpi.KernelLog
+
+	cases := []struct {
+		name         string
+		req          string
+		klsys        *mocksys.KernelLog
+		wantedStatus int
+		wantedResp   []rpi.KernelLog
+	}{
+		{
+			name:         "error: invalid level",
+			req:          "?level=verbose",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid since",
+			req:          "?since=-1",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error: List result is nil",
+			klsys: &mocksys.KernelLog{
+				ListFn: func([]string, int, uint64) ([]rpi.KernelLog, error) {
+					return nil, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			req:  "?level=warning&since=10",
+			klsys: &mocksys.KernelLog{
+				ListFn: func(_ []string, level int, since uint64) ([]rpi.KernelLog, error) {
+					if level != 4 || since != 10 {
+						return nil, errors.New("test error")
+					}
+					return []rpi.KernelLog{{Sequence: 12, Priority: 3, Message: "under-voltage detected!"}}, nil
+				},
+			},
+			wantedStatus: http.StatusOK,
+			wantedResp:   []rpi.KernelLog{{Sequence: 12, Priority: 3, Message: "under-voltage detected!"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			s := kernellog.New(tc.klsys, i)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/kernel/log" + tc.req
+			res, err := http.Get(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+
+			body, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				panic(err)
+			}
+
+			if tc.wantedResp != nil {
+				if err := json.Unmarshal(body, &response); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, tc.wantedResp, response)
+			}
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestListWS(t *testing.T) {
+	var response []rpi.KernelLog
+
+	klsys := &mocksys.KernelLog{
+		ListFn: func(_ []string, level int, since uint64) ([]rpi.KernelLog, error) {
+			if since > 12 {
+				return []rpi.KernelLog{}, nil
+			}
+			return []rpi.KernelLog{{Sequence: 12, Priority: 3, Message: "under-voltage detected!"}}, nil
+		},
+	}
+
+	r := server.New()
+	rg := r.Group("")
+	s := kernellog.New(klsys, i)
+	transport.NewHTTP(s, rg)
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	pathWS := "ws" + strings.TrimPrefix(ts.URL, "http") + "/kernel/log-ws?level=err"
+	ws, _, err := websocket.DefaultDialer.Dial(pathWS, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+
+	if err := ws.ReadJSON(&response); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []rpi.KernelLog{{Sequence: 12, Priority: 3, Message: "under-voltage detected!"}}, response)
+}
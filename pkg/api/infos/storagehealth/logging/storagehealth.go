@@ -0,0 +1,56 @@
+package storagehealth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/infos/storagehealth"
+)
+
+// New creates a new storagehealth logging service instance.
+func New(svc storagehealth.Service, logger rpi.Logger) *LogService {
+	return &LogService{
+		Service: svc,
+		logger:  logger,
+	}
+}
+
+// LogService represents a storagehealth logging service.
+type LogService struct {
+	storagehealth.Service
+	logger rpi.Logger
+}
+
+const name = "storagehealth"
+
+// List is the logging function attached to the List storagehealth services and responsible for logging it out.
+func (ls *LogService) List(ctx echo.Context) (resp []rpi.StorageHealth, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, "request: listing storage health", err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.List()
+}
+
+// View is the logging function attached to the View storagehealth services and responsible for logging it out.
+func (ls *LogService) View(ctx echo.Context, id string) (resp rpi.StorageHealth, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: viewing storage health #%v", id), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.View(id)
+}
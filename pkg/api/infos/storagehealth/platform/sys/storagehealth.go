@@ -0,0 +1,235 @@
+package sys
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/infos"
+)
+
+// StorageHealth represents a StorageHealth entity on the current system.
+type StorageHealth struct{}
+
+// SMART attribute ids
+const (
+	reallocatedSectorCount = 5
+	currentPendingSector   = 197
+)
+
+// List returns a list of block device health reports
+func (sh StorageHealth) List(
+	devices []string,
+	mmc map[string]map[string]string,
+	smart map[string]infos.SmartReport,
+	roRemounts []string,
+) ([]rpi.StorageHealth, error) {
+	var result []rpi.StorageHealth
+
+	for _, d := range devices {
+		result = append(result, Health(d, mmc, smart, roRemounts))
+	}
+
+	return result, nil
+}
+
+// View returns the health report of a block device
+func (sh StorageHealth) View(
+	id string,
+	devices []string,
+	mmc map[string]map[string]string,
+	smart map[string]infos.SmartReport,
+	roRemounts []string,
+) (rpi.StorageHealth, error) {
+	for _, d := range devices {
+		if d == id {
+			return Health(d, mmc, smart, roRemounts), nil
+		}
+	}
+
+	return rpi.StorageHealth{}, echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("%v does not exist", id))
+}
+
+// Health aggregates the SD/MMC attributes, the SMART report and
+// the read-only remount events of a block device into a health report
+func Health(
+	device string,
+	mmc map[string]map[string]string,
+	smart map[string]infos.SmartReport,
+	roRemounts []string,
+) rpi.StorageHealth {
+	result := rpi.StorageHealth{
+		ID:               device,
+		Type:             DeviceType(device),
+		ReadOnlyRemounts: []string{},
+	}
+
+	if attributes, ok := mmc[device]; ok {
+		result.MMC = MMC(attributes)
+		result.Model = result.MMC.Name
+		result.Serial = result.MMC.Serial
+	}
+
+	if report, ok := smart[device]; ok {
+		result.Smart = Smart(report)
+		result.Model = report.ModelName
+		result.Serial = report.SerialNumber
+	}
+
+	// the device or one of its partitions (ex: sda1, mmcblk0p2) but not another device (ex: sdaa, mmcblk01)
+	partition := `[0-9]+`
+	if regexp.MustCompile(`[0-9]$`).MatchString(device) {
+		partition = `p[0-9]+`
+	}
+	deviceRegex := regexp.MustCompile(`(^|[^A-Za-z0-9])` + regexp.QuoteMeta(device) + `(` + partition + `)?($|[^A-Za-z0-9])`)
+
+	for _, line := range roRemounts {
+		if deviceRegex.MatchString(line) {
+			result.ReadOnlyRemounts = append(result.ReadOnlyRemounts, line)
+		}
+	}
+
+	result.Score = Score(result)
+	result.Status = Status(result)
+
+	return result
+}
+
+// DeviceType returns the kind of a block device based on its name
+func DeviceType(device string) string {
+	switch {
+	case strings.HasPrefix(device, "mmcblk"):
+		return "mmc"
+	case strings.HasPrefix(device, "nvme"):
+		return "nvme"
+	default:
+		return "disk"
+	}
+}
+
+// MMC builds an MMCHealth object out of the sysfs attributes of an SD/MMC card
+func MMC(attributes map[string]string) *rpi.MMCHealth {
+	result := &rpi.MMCHealth{
+		CID:            attributes["cid"],
+		ManufacturerID: attributes["manfid"],
+		OEMID:          attributes["oemid"],
+		Name:           attributes["name"],
+		Serial:         attributes["serial"],
+		Date:           attributes["date"],
+		Type:           attributes["type"],
+		PreEOLInfo:     attributes["pre_eol_info"],
+	}
+
+	// life_time holds two estimates (type A and type B memories)
+	// each of them ranging from 0x01 (0-10% used) to 0x0B (exceeded)
+	lifeTime := strings.Fields(attributes["life_time"])
+	if len(lifeTime) == 2 {
+		result.LifeTimeEstimateA = lifeTime[0]
+		result.LifeTimeEstimateB = lifeTime[1]
+	}
+
+	for _, v := range lifeTime {
+		estimate, err := strconv.ParseInt(strings.TrimPrefix(v, "0x"), 16, 64)
+		if err != nil || estimate < 1 {
+			continue
+		}
+
+		used := int((estimate - 1) * 10)
+		if used > 100 {
+			used = 100
+		}
+		if used > result.LifeTimeUsedPercent {
+			result.LifeTimeUsedPercent = used
+		}
+	}
+
+	return result
+}
+
+// Smart builds a SmartHealth object out of a smartctl report
+func Smart(report infos.SmartReport) *rpi.SmartHealth {
+	result := &rpi.SmartHealth{
+		Passed:          true,
+		Temperature:     report.Temperature.Current,
+		PowerOnHours:    report.PowerOnTime.Hours,
+		PowerCycleCount: report.PowerCycleCount,
+	}
+
+	if report.SmartStatus != nil {
+		result.Passed = report.SmartStatus.Passed
+	}
+
+	for _, a := range report.AtaSmartAttributes.Table {
+		switch a.ID {
+		case reallocatedSectorCount:
+			result.ReallocatedSectors = a.Raw.Value
+		case currentPendingSector:
+			result.PendingSectors = a.Raw.Value
+		}
+	}
+
+	if report.NvmeSmartHealthInformationLog != nil {
+		result.PercentageUsed = report.NvmeSmartHealthInformationLog.PercentageUsed
+		result.MediaErrors = report.NvmeSmartHealthInformationLog.MediaErrors
+	}
+
+	return result
+}
+
+// Score computes a health score between 0 (failing) and 100 (healthy)
+func Score(sh rpi.StorageHealth) int {
+	score := 100
+
+	if sh.MMC != nil {
+		score -= sh.MMC.LifeTimeUsedPercent * 6 / 10
+
+		switch sh.MMC.PreEOLInfo {
+		case "0x02":
+			score -= 20
+		case "0x03":
+			score -= 40
+		}
+	}
+
+	if sh.Smart != nil {
+		if !sh.Smart.Passed {
+			score -= 60
+		}
+		if sh.Smart.ReallocatedSectors > 0 {
+			score -= 10
+		}
+		if sh.Smart.PendingSectors > 0 {
+			score -= 10
+		}
+		if sh.Smart.MediaErrors > 0 {
+			score -= 20
+		}
+		score -= int(sh.Smart.PercentageUsed) * 6 / 10
+	}
+
+	score -= 15 * len(sh.ReadOnlyRemounts)
+
+	if score < 0 {
+		score = 0
+	}
+
+	return score
+}
+
+// Status converts a health score into a status
+func Status(sh rpi.StorageHealth) string {
+	switch {
+	case sh.MMC == nil && sh.Smart == nil && len(sh.ReadOnlyRemounts) == 0:
+		return "unknown"
+	case sh.Score >= 80:
+		return "good"
+	case sh.Score >= 50:
+		return "warning"
+	default:
+		return "critical"
+	}
+}
@@ -0,0 +1,221 @@
+package sys_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/infos/storagehealth/platform/sys"
+	"github.com/raspibuddy/rpi/pkg/utl/infos"
+	"github.com/stretchr/testify/assert"
+)
+
+func smartReport(t *testing.T, raw string) infos.SmartReport {
+	var report infos.SmartReport
+	if err := json.Unmarshal([]byte(raw), &report); err != nil {
+		t.Fatal(err)
+	}
+	return report
+}
+
+func TestList(t *testing.T) {
+	cases := []struct {
+		name       string
+		devices    []string
+		mmc        map[string]map[string]string
+		smart      map[string]infos.SmartReport
+		roRemounts []string
+		wantedData []rpi.StorageHealth
+		wantedErr  error
+	}{
+		{
+			name:       "success: no block device",
+			wantedData: nil,
+		},
+		{
+			name:    "success: worn sd card remounted read-only and healthy usb drive",
+			devices: []string{"mmcblk0", "sda", "sdb"},
+			mmc: map[string]map[string]string{
+				"mmcblk0": {
+					"cid":          "1b534d53433136475a1c6a3b9b013f00",
+					"manfid":       "0x00001b",
+					"oemid":        "0x534d",
+					"name":         "SC16G",
+					"serial":       "0x1c6a3b9b",
+					"date":         "03/2019",
+					"type":         "SD",
+					"life_time":    "0x05 0x03",
+					"pre_eol_info": "0x02",
+				},
+			},
+			smart: map[string]infos.SmartReport{
+				"sda": smartReport(t, `{
+					"model_name": "Samsung SSD",
+					"serial_number": "S3Z",
+					"smart_status": {"passed": true},
+					"temperature": {"current": 35},
+					"power_on_time": {"hours": 1200},
+					"power_cycle_count": 40,
+					"ata_smart_attributes": {"table": [
+						{"id": 5, "name": "Reallocated_Sector_Ct", "raw": {"value": 0}},
+						{"id": 197, "name": "Current_Pending_Sector", "raw": {"value": 0}}
+					]}
+				}`),
+			},
+			roRemounts: []string{
+				"[   12.345] EXT4-fs (mmcblk0p2): Remounting filesystem read-only",
+				"[   20.100] EXT4-fs (sdaa1): Remounting filesystem read-only",
+				"[   20.200] EXT4-fs (mmcblk01p1): Remounting filesystem read-only",
+			},
+			wantedData: []rpi.StorageHealth{
+				{
+					ID:     "mmcblk0",
+					Type:   "mmc",
+					Model:  "SC16G",
+					Serial: "0x1c6a3b9b",
+					MMC: &rpi.MMCHealth{
+						CID:                 "1b534d53433136475a1c6a3b9b013f00",
+						ManufacturerID:      "0x00001b",
+						OEMID:               "0x534d",
+						Name:                "SC16G",
+						Serial:              "0x1c6a3b9b",
+						Date:                "03/2019",
+						Type:                "SD",
+						LifeTimeEstimateA:   "0x05",
+						LifeTimeEstimateB:   "0x03",
+						PreEOLInfo:          "0x02",
+						LifeTimeUsedPercent: 40,
+					},
+					ReadOnlyRemounts: []string{
+						"[   12.345] EXT4-fs (mmcblk0p2): Remounting filesystem read-only",
+					},
+					Score:  41,
+					Status: "critical",
+				},
+				{
+					ID:     "sda",
+					Type:   "disk",
+					Model:  "Samsung SSD",
+					Serial: "S3Z",
+					Smart: &rpi.SmartHealth{
+						Passed:          true,
+						Temperature:     35,
+						PowerOnHours:    1200,
+						PowerCycleCount: 40,
+					},
+					ReadOnlyRemounts: []string{},
+					Score:            100,
+					Status:           "good",
+				},
+				{
+					ID:               "sdb",
+					Type:             "disk",
+					ReadOnlyRemounts: []string{},
+					Score:            100,
+					Status:           "unknown",
+				},
+			},
+		},
+		{
+			name:    "success: remounted partitions",
+			devices: []string{"sda", "nvme0n1"},
+			roRemounts: []string{
+				"[    8.000] EXT4-fs (sda1): Remounting filesystem read-only",
+				"[    9.000] EXT4-fs error (device nvme0n1p2): remounted read-only",
+				"[   10.000] EXT4-fs (nvme0n10p1): Remounting filesystem read-only",
+			},
+			wantedData: []rpi.StorageHealth{
+				{
+					ID:               "sda",
+					Type:             "disk",
+					ReadOnlyRemounts: []string{"[    8.000] EXT4-fs (sda1): Remounting filesystem read-only"},
+					Score:            85,
+					Status:           "good",
+				},
+				{
+					ID:               "nvme0n1",
+					Type:             "nvme",
+					ReadOnlyRemounts: []string{"[    9.000] EXT4-fs error (device nvme0n1p2): remounted read-only"},
+					Score:            85,
+					Status:           "good",
+				},
+			},
+		},
+		{
+			name:    "success: failing nvme drive",
+			devices: []string{"nvme0n1"},
+			smart: map[string]infos.SmartReport{
+				"nvme0n1": smartReport(t, `{
+					"model_name": "WD Blue",
+					"smart_status": {"passed": false},
+					"nvme_smart_health_information_log": {"percentage_used": 50, "media_errors": 3}
+				}`),
+			},
+			wantedData: []rpi.StorageHealth{
+				{
+					ID:    "nvme0n1",
+					Type:  "nvme",
+					Model: "WD Blue",
+					Smart: &rpi.SmartHealth{
+						Passed:         false,
+						PercentageUsed: 50,
+						MediaErrors:    3,
+					},
+					ReadOnlyRemounts: []string{},
+					Score:            0,
+					Status:           "critical",
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := sys.StorageHealth{}
+			health, err := s.List(tc.devices, tc.mmc, tc.smart, tc.roRemounts)
+			assert.Equal(t, tc.wantedData, health)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestView(t *testing.T) {
+	cases := []struct {
+		name       string
+		id         string
+		devices    []string
+		wantedData rpi.StorageHealth
+		wantedErr  error
+	}{
+		{
+			name:       "error: device does not exist",
+			id:         "sdz",
+			devices:    []string{"sda"},
+			wantedData: rpi.StorageHealth{},
+			wantedErr:  echo.NewHTTPError(http.StatusNotFound, "sdz does not exist"),
+		},
+		{
+			name:    "success",
+			id:      "sda",
+			devices: []string{"mmcblk0", "sda"},
+			wantedData: rpi.StorageHealth{
+				ID:               "sda",
+				Type:             "disk",
+				ReadOnlyRemounts: []string{},
+				Score:            100,
+				Status:           "unknown",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := sys.StorageHealth{}
+			health, err := s.View(tc.id, tc.devices, nil, nil, nil)
+			assert.Equal(t, tc.wantedData, health)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package storagehealth
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/infos"
+)
+
+// Service represents all StorageHealth application services.
+type Service interface {
+	List() ([]rpi.StorageHealth, error)
+	View(string) (rpi.StorageHealth, error)
+}
+
+// StorageHealth represents a StorageHealth application service.
+type StorageHealth struct {
+	shsys SHSYS
+	i     Infos
+}
+
+// SHSYS represents a StorageHealth repository service.
+type SHSYS interface {
+	List([]string, map[string]map[string]string, map[string]infos.SmartReport, []string) ([]rpi.StorageHealth, error)
+	View(string, []string, map[string]map[string]string, map[string]infos.SmartReport, []string) (rpi.StorageHealth, error)
+}
+
+// Infos represents the infos interface
+type Infos interface {
+	ListBlockDevices(string) []string
+	ReadSysfsAttributes(string, []string) map[string]string
+	Smartctl(string) (infos.SmartReport, error)
+	ReadOnlyRemounts() []string
+}
+
+// New creates a StorageHealth application service instance.
+func New(shsys SHSYS, i Infos) *StorageHealth {
+	return &StorageHealth{shsys: shsys, i: i}
+}
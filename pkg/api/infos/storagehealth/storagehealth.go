@@ -0,0 +1,52 @@
+package storagehealth
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/constants"
+	"github.com/raspibuddy/rpi/pkg/utl/infos"
+)
+
+// MMCAttributes are the sysfs attributes read from an SD/MMC card device directory.
+var MMCAttributes = []string{
+	"cid",
+	"manfid",
+	"oemid",
+	"name",
+	"serial",
+	"date",
+	"type",
+	"life_time",
+	"pre_eol_info",
+}
+
+// List populates and returns an array of StorageHealth models.
+func (sh *StorageHealth) List() ([]rpi.StorageHealth, error) {
+	devices, mmc, smart := sh.collect()
+	return sh.shsys.List(devices, mmc, smart, sh.i.ReadOnlyRemounts())
+}
+
+// View populates and returns a StorageHealth model.
+func (sh *StorageHealth) View(id string) (rpi.StorageHealth, error) {
+	devices, mmc, smart := sh.collect()
+	return sh.shsys.View(id, devices, mmc, smart, sh.i.ReadOnlyRemounts())
+}
+
+// collect reads the SD/MMC attributes and the SMART reports of every block device.
+func (sh *StorageHealth) collect() ([]string, map[string]map[string]string, map[string]infos.SmartReport) {
+	mmc := make(map[string]map[string]string)
+	smart := make(map[string]infos.SmartReport)
+
+	devices := sh.i.ListBlockDevices(constants.BLOCKDEVICES)
+	for _, d := range devices {
+		if strings.HasPrefix(d, "mmcblk") {
+			mmc[d] = sh.i.ReadSysfsAttributes(fmt.Sprintf("%v/%v/device", constants.BLOCKDEVICES, d), MMCAttributes)
+		} else if report, err := sh.i.Smartctl(d); err == nil {
+			smart[d] = report
+		}
+	}
+
+	return devices, mmc, smart
+}
@@ -0,0 +1,154 @@
+package storagehealth_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/infos/storagehealth"
+	"github.com/raspibuddy/rpi/pkg/utl/infos"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	cases := []struct {
+		name       string
+		infos      mock.Infos
+		shsys      mocksys.StorageHealth
+		wantedData []rpi.StorageHealth
+		wantedErr  error
+	}{
+		{
+			name: "success: one sd card and one usb drive",
+			infos: mock.Infos{
+				ListBlockDevicesFn: func(string) []string {
+					return []string{"mmcblk0", "sda", "sdb"}
+				},
+				ReadSysfsAttributesFn: func(path string, attributes []string) map[string]string {
+					if path != "/sys/block/mmcblk0/device" {
+						t.Fatalf("unexpected sysfs path %v", path)
+					}
+					return map[string]string{"name": "SC16G"}
+				},
+				SmartctlFn: func(device string) (infos.SmartReport, error) {
+					if device == "sdb" {
+						return infos.SmartReport{}, errors.New("test error smartctl")
+					}
+					return infos.SmartReport{ModelName: "USB Drive"}, nil
+				},
+				ReadOnlyRemountsFn: func() []string {
+					return []string{"EXT4-fs (mmcblk0p2): Remounting filesystem read-only"}
+				},
+			},
+			shsys: mocksys.StorageHealth{
+				ListFn: func(
+					devices []string,
+					mmc map[string]map[string]string,
+					smart map[string]infos.SmartReport,
+					roRemounts []string,
+				) ([]rpi.StorageHealth, error) {
+					assert.Equal(t, []string{"mmcblk0", "sda", "sdb"}, devices)
+					assert.Equal(t, map[string]map[string]string{"mmcblk0": {"name": "SC16G"}}, mmc)
+					assert.Equal(t, map[string]infos.SmartReport{"sda": {ModelName: "USB Drive"}}, smart)
+					assert.Equal(t, 1, len(roRemounts))
+					return []rpi.StorageHealth{
+						{ID: "mmcblk0", Score: 85, Status: "good"},
+						{ID: "sda", Score: 100, Status: "good"},
+						{ID: "sdb", Score: 100, Status: "unknown"},
+					}, nil
+				},
+			},
+			wantedData: []rpi.StorageHealth{
+				{ID: "mmcblk0", Score: 85, Status: "good"},
+				{ID: "sda", Score: 100, Status: "good"},
+				{ID: "sdb", Score: 100, Status: "unknown"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := storagehealth.New(&tc.shsys, tc.infos)
+			health, err := s.List()
+			assert.Equal(t, tc.wantedData, health)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestView(t *testing.T) {
+	cases := []struct {
+		name       string
+		id         string
+		infos      mock.Infos
+		shsys      mocksys.StorageHealth
+		wantedData rpi.StorageHealth
+		wantedErr  error
+	}{
+		{
+			name: "error: device does not exist",
+			id:   "sdz",
+			infos: mock.Infos{
+				ListBlockDevicesFn: func(string) []string {
+					return nil
+				},
+				ReadOnlyRemountsFn: func() []string {
+					return nil
+				},
+			},
+			shsys: mocksys.StorageHealth{
+				ViewFn: func(
+					string,
+					[]string,
+					map[string]map[string]string,
+					map[string]infos.SmartReport,
+					[]string,
+				) (rpi.StorageHealth, error) {
+					return rpi.StorageHealth{}, echo.NewHTTPError(http.StatusNotFound, "sdz does not exist")
+				},
+			},
+			wantedData: rpi.StorageHealth{},
+			wantedErr:  echo.NewHTTPError(http.StatusNotFound, "sdz does not exist"),
+		},
+		{
+			name: "success",
+			id:   "sda",
+			infos: mock.Infos{
+				ListBlockDevicesFn: func(string) []string {
+					return []string{"sda"}
+				},
+				SmartctlFn: func(string) (infos.SmartReport, error) {
+					return infos.SmartReport{ModelName: "USB Drive"}, nil
+				},
+				ReadOnlyRemountsFn: func() []string {
+					return nil
+				},
+			},
+			shsys: mocksys.StorageHealth{
+				ViewFn: func(
+					string,
+					[]string,
+					map[string]map[string]string,
+					map[string]infos.SmartReport,
+					[]string,
+				) (rpi.StorageHealth, error) {
+					return rpi.StorageHealth{ID: "sda", Model: "USB Drive", Score: 100, Status: "good"}, nil
+				},
+			},
+			wantedData: rpi.StorageHealth{ID: "sda", Model: "USB Drive", Score: 100, Status: "good"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := storagehealth.New(&tc.shsys, tc.infos)
+			health, err := s.View(tc.id)
+			assert.Equal(t, tc.wantedData, health)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi/pkg/api/infos/storagehealth"
+)
+
+// HTTP is a struct implementing a storagehealth application service.
+type HTTP struct {
+	svc storagehealth.Service
+}
+
+// NewHTTP creates new storagehealth http service
+func NewHTTP(svc storagehealth.Service, r *echo.Group) {
+	h := HTTP{svc}
+	cr := r.Group("/storagehealth")
+	cr.GET("", h.list)
+	cr.GET("/:id", h.view)
+}
+
+func (h *HTTP) list(ctx echo.Context) error {
+	result, err := h.svc.List()
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) view(ctx echo.Context) error {
+	result, err := h.svc.View(ctx.Param("id"))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,111 @@
+package transport_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/infos/storagehealth"
+	"github.com/raspibuddy/rpi/pkg/api/infos/storagehealth/transport"
+	"github.com/raspibuddy/rpi/pkg/utl/infos"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/raspibuddy/rpi/pkg/utl/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestView(t *testing.T) {
+	var response rpi.StorageHealth
+
+	cases := []struct {
+		name         string
+		req          string
+		shsys        *mocksys.StorageHealth
+		wantedStatus int
+		wantedResp   rpi.StorageHealth
+	}{
+		{
+			name: "error: View result is nil",
+			req:  "sda",
+			shsys: &mocksys.StorageHealth{
+				ViewFn: func(
+					string,
+					[]string,
+					map[string]map[string]string,
+					map[string]infos.SmartReport,
+					[]string,
+				) (rpi.StorageHealth, error) {
+					return rpi.StorageHealth{}, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			req:  "mmcblk0",
+			shsys: &mocksys.StorageHealth{
+				ViewFn: func(
+					string,
+					[]string,
+					map[string]map[string]string,
+					map[string]infos.SmartReport,
+					[]string,
+				) (rpi.StorageHealth, error) {
+					return rpi.StorageHealth{
+						ID:               "mmcblk0",
+						Type:             "mmc",
+						MMC:              &rpi.MMCHealth{Name: "SC16G", LifeTimeUsedPercent: 10},
+						ReadOnlyRemounts: []string{},
+						Score:            94,
+						Status:           "good",
+					}, nil
+				},
+			},
+			wantedStatus: http.StatusOK,
+			wantedResp: rpi.StorageHealth{
+				ID:               "mmcblk0",
+				Type:             "mmc",
+				MMC:              &rpi.MMCHealth{Name: "SC16G", LifeTimeUsedPercent: 10},
+				ReadOnlyRemounts: []string{},
+				Score:            94,
+				Status:           "good",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			i := infos.New()
+			s := storagehealth.New(tc.shsys, i)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/storagehealth/" + tc.req
+			res, err := http.Get(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer res.Body.Close()
+
+			body, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				panic(err)
+			}
+
+			if tc.wantedResp.ID != "" {
+				if err := json.Unmarshal(body, &response); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, tc.wantedResp, response)
+			}
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
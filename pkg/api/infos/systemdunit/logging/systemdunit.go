@@ -0,0 +1,56 @@
+package systemdunit
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/infos/systemdunit"
+)
+
+// New creates a new systemdunit logging service instance.
+func New(svc systemdunit.Service, logger rpi.Logger) *LogService {
+	return &LogService{
+		Service: svc,
+		logger:  logger,
+	}
+}
+
+// LogService represents a systemdunit logging service.
+type LogService struct {
+	systemdunit.Service
+	logger rpi.Logger
+}
+
+const name = "systemdunit"
+
+// List is the logging function attached to the List systemdunit services and responsible for logging it out.
+func (ls *LogService) List(ctx echo.Context) (resp []rpi.SystemdUnit, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, "request: listing systemd units", err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.List()
+}
+
+// View is the logging function attached to the View systemdunit services and responsible for logging it out.
+func (ls *LogService) View(ctx echo.Context, id string) (resp rpi.SystemdUnit, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: viewing systemd unit %v", id), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.View(id)
+}
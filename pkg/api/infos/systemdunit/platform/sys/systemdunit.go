@@ -0,0 +1,103 @@
+package sys
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+)
+
+// SystemdUnit represents a SystemdUnit entity on the current system.
+type SystemdUnit struct{}
+
+// List merges the loaded units with the installed unit files and returns them sorted by name
+func (su SystemdUnit) List(units []string, unitFiles []string) ([]rpi.SystemdUnit, error) {
+	result := []rpi.SystemdUnit{}
+	states := FileStates(unitFiles)
+	loaded := make(map[string]bool)
+
+	for _, line := range units {
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		loaded[fields[0]] = true
+		result = append(result, rpi.SystemdUnit{
+			ID:            fields[0],
+			LoadState:     fields[1],
+			ActiveState:   fields[2],
+			SubState:      fields[3],
+			Description:   strings.Join(fields[4:], " "),
+			UnitFileState: states[fields[0]],
+		})
+	}
+
+	// units which are installed but not loaded are inactive
+	for id, state := range states {
+		if loaded[id] {
+			continue
+		}
+		result = append(result, rpi.SystemdUnit{
+			ID:            id,
+			LoadState:     "not-loaded",
+			ActiveState:   "inactive",
+			SubState:      "dead",
+			UnitFileState: state,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	return result, nil
+}
+
+// View returns the status of a unit, provided systemctl lists it
+func (su SystemdUnit) View(id string, units []string, unitFiles []string, show map[string]string) (rpi.SystemdUnit, error) {
+	if !IsListed(id, units, unitFiles) {
+		return rpi.SystemdUnit{}, echo.NewHTTPError(http.StatusNotFound, "unit does not exist")
+	}
+
+	result := rpi.SystemdUnit{
+		ID:            id,
+		Description:   show["Description"],
+		LoadState:     show["LoadState"],
+		ActiveState:   show["ActiveState"],
+		SubState:      show["SubState"],
+		UnitFileState: show["UnitFileState"],
+		Since:         show["ActiveEnterTimestamp"],
+		FragmentPath:  show["FragmentPath"],
+	}
+	result.MainPID, _ = strconv.Atoi(show["MainPID"])
+	// MemoryCurrent is "[not set]" when memory accounting is disabled
+	result.MemoryCurrent, _ = strconv.ParseUint(show["MemoryCurrent"], 10, 64)
+
+	return result, nil
+}
+
+// FileStates returns the state (enabled, disabled, masked, static...) of every installed unit file
+func FileStates(unitFiles []string) map[string]string {
+	result := make(map[string]string)
+	for _, line := range unitFiles {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 {
+			result[fields[0]] = fields[1]
+		}
+	}
+	return result
+}
+
+// IsListed checks if a unit appears either in the loaded units or in the installed unit files
+func IsListed(id string, units []string, unitFiles []string) bool {
+	for _, line := range append(append([]string{}, units...), unitFiles...) {
+		fields := strings.Fields(line)
+		if len(fields) > 0 && fields[0] == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,189 @@
+package sys_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/infos/systemdunit/platform/sys"
+	"github.com/stretchr/testify/assert"
+)
+
+var units = []string{
+	"ssh.service     loaded active   running OpenBSD Secure Shell server",
+	"cron.service    loaded active   running Regular background program processing daemon",
+	"broken",
+}
+
+var unitFiles = []string{
+	"cron.service      enabled  enabled",
+	"hciuart.service   disabled enabled",
+	"ssh.service       enabled  enabled",
+}
+
+func TestList(t *testing.T) {
+	cases := []struct {
+		name       string
+		units      []string
+		unitFiles  []string
+		wantedData []rpi.SystemdUnit
+		wantedErr  error
+	}{
+		{
+			name:       "success: no unit",
+			wantedData: []rpi.SystemdUnit{},
+			wantedErr:  nil,
+		},
+		{
+			name:      "success",
+			units:     units,
+			unitFiles: unitFiles,
+			wantedData: []rpi.SystemdUnit{
+				{
+					ID:            "cron.service",
+					Description:   "Regular background program processing daemon",
+					LoadState:     "loaded",
+					ActiveState:   "active",
+					SubState:      "running",
+					UnitFileState: "enabled",
+				},
+				{
+					ID:            "hciuart.service",
+					LoadState:     "not-loaded",
+					ActiveState:   "inactive",
+					SubState:      "dead",
+					UnitFileState: "disabled",
+				},
+				{
+					ID:            "ssh.service",
+					Description:   "OpenBSD Secure Shell server",
+					LoadState:     "loaded",
+					ActiveState:   "active",
+					SubState:      "running",
+					UnitFileState: "enabled",
+				},
+			},
+			wantedErr: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := sys.SystemdUnit{}
+			result, err := s.List(tc.units, tc.unitFiles)
+			assert.Equal(t, tc.wantedData, result)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestView(t *testing.T) {
+	cases := []struct {
+		name       string
+		id         string
+		show       map[string]string
+		wantedData rpi.SystemdUnit
+		wantedErr  error
+	}{
+		{
+			name:       "error: unit does not exist",
+			id:         "dummy.service",
+			wantedData: rpi.SystemdUnit{},
+			wantedErr:  echo.NewHTTPError(http.StatusNotFound, "unit does not exist"),
+		},
+		{
+			name: "success: memory accounting disabled",
+			id:   "hciuart.service",
+			show: map[string]string{
+				"Id":            "hciuart.service",
+				"Description":   "Configure Bluetooth Modems connected by UART",
+				"LoadState":     "loaded",
+				"ActiveState":   "inactive",
+				"SubState":      "dead",
+				"UnitFileState": "disabled",
+				"MainPID":       "0",
+				"MemoryCurrent": "[not set]",
+				"FragmentPath":  "/lib/systemd/system/hciuart.service",
+			},
+			wantedData: rpi.SystemdUnit{
+				ID:            "hciuart.service",
+				Description:   "Configure Bluetooth Modems connected by UART",
+				LoadState:     "loaded",
+				ActiveState:   "inactive",
+				SubState:      "dead",
+				UnitFileState: "disabled",
+				FragmentPath:  "/lib/systemd/system/hciuart.service",
+			},
+			wantedErr: nil,
+		},
+		{
+			name: "success",
+			id:   "ssh.service",
+			show: map[string]string{
+				"Id":                   "ssh.service",
+				"Description":          "OpenBSD Secure Shell server",
+				"LoadState":            "loaded",
+				"ActiveState":          "active",
+				"SubState":             "running",
+				"UnitFileState":        "enabled",
+				"MainPID":              "512",
+				"MemoryCurrent":        "4194304",
+				"ActiveEnterTimestamp": "Mon 2026-10-19 08:00:00 UTC",
+				"FragmentPath":         "/lib/systemd/system/ssh.service",
+			},
+			wantedData: rpi.SystemdUnit{
+				ID:            "ssh.service",
+				Description:   "OpenBSD Secure Shell server",
+				LoadState:     "loaded",
+				ActiveState:   "active",
+				SubState:      "running",
+				UnitFileState: "enabled",
+				MainPID:       512,
+				MemoryCurrent: 4194304,
+				Since:         "Mon 2026-10-19 08:00:00 UTC",
+				FragmentPath:  "/lib/systemd/system/ssh.service",
+			},
+			wantedErr: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := sys.SystemdUnit{}
+			result, err := s.View(tc.id, units, unitFiles, tc.show)
+			assert.Equal(t, tc.wantedData, result)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestIsListed(t *testing.T) {
+	cases := []struct {
+		name   string
+		id     string
+		wanted bool
+	}{
+		{
+			name:   "loaded unit",
+			id:     "ssh.service",
+			wanted: true,
+		},
+		{
+			name:   "unit file only",
+			id:     "hciuart.service",
+			wanted: true,
+		},
+		{
+			name:   "unknown unit",
+			id:     "dummy.service",
+			wanted: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wanted, sys.IsListed(tc.id, units, unitFiles))
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package systemdunit
+
+import (
+	"github.com/raspibuddy/rpi"
+)
+
+// Service represents all SystemdUnit application services.
+type Service interface {
+	List() ([]rpi.SystemdUnit, error)
+	View(string) (rpi.SystemdUnit, error)
+}
+
+// SystemdUnit represents a SystemdUnit application service.
+type SystemdUnit struct {
+	susys SUSYS
+	i     Infos
+}
+
+// SUSYS represents a SystemdUnit repository service.
+type SUSYS interface {
+	List([]string, []string) ([]rpi.SystemdUnit, error)
+	View(string, []string, []string, map[string]string) (rpi.SystemdUnit, error)
+}
+
+// Infos represents the infos interface
+type Infos interface {
+	ListUnits() []string
+	ListUnitFiles() []string
+	ShowUnit(string) (map[string]string, error)
+}
+
+// New creates a SystemdUnit application service instance.
+func New(susys SUSYS, i Infos) *SystemdUnit {
+	return &SystemdUnit{susys: susys, i: i}
+}
@@ -0,0 +1,26 @@
+package systemdunit
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+)
+
+// List populates and returns an array of SystemdUnit models.
+func (su *SystemdUnit) List() ([]rpi.SystemdUnit, error) {
+	return su.susys.List(su.i.ListUnits(), su.i.ListUnitFiles())
+}
+
+// View populates and returns the status of one SystemdUnit.
+func (su *SystemdUnit) View(id string) (rpi.SystemdUnit, error) {
+	units := su.i.ListUnits()
+	unitFiles := su.i.ListUnitFiles()
+
+	show, err := su.i.ShowUnit(id)
+	if err != nil {
+		return rpi.SystemdUnit{}, echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the unit status")
+	}
+
+	return su.susys.View(id, units, unitFiles, show)
+}
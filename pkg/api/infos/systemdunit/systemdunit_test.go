@@ -0,0 +1,128 @@
+package systemdunit_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/infos/systemdunit"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	cases := []struct {
+		name       string
+		infos      mock.Infos
+		susys      mocksys.SystemdUnit
+		wantedData []rpi.SystemdUnit
+		wantedErr  error
+	}{
+		{
+			name: "success",
+			infos: mock.Infos{
+				ListUnitsFn: func() []string {
+					return []string{"ssh.service loaded active running OpenBSD Secure Shell server"}
+				},
+				ListUnitFilesFn: func() []string {
+					return []string{"ssh.service enabled enabled"}
+				},
+			},
+			susys: mocksys.SystemdUnit{
+				ListFn: func([]string, []string) ([]rpi.SystemdUnit, error) {
+					return []rpi.SystemdUnit{
+						{
+							ID:          "ssh.service",
+							ActiveState: "active",
+						},
+					}, nil
+				},
+			},
+			wantedData: []rpi.SystemdUnit{
+				{
+					ID:          "ssh.service",
+					ActiveState: "active",
+				},
+			},
+			wantedErr: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := systemdunit.New(tc.susys, tc.infos)
+			units, err := s.List()
+			assert.Equal(t, tc.wantedData, units)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestView(t *testing.T) {
+	cases := []struct {
+		name       string
+		id         string
+		infos      mock.Infos
+		susys      mocksys.SystemdUnit
+		wantedData rpi.SystemdUnit
+		wantedErr  error
+	}{
+		{
+			name: "error: systemctl show",
+			id:   "ssh.service",
+			infos: mock.Infos{
+				ListUnitsFn: func() []string {
+					return nil
+				},
+				ListUnitFilesFn: func() []string {
+					return nil
+				},
+				ShowUnitFn: func(string) (map[string]string, error) {
+					return nil, errors.New("test error")
+				},
+			},
+			wantedData: rpi.SystemdUnit{},
+			wantedErr:  echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the unit status"),
+		},
+		{
+			name: "success",
+			id:   "ssh.service",
+			infos: mock.Infos{
+				ListUnitsFn: func() []string {
+					return nil
+				},
+				ListUnitFilesFn: func() []string {
+					return nil
+				},
+				ShowUnitFn: func(string) (map[string]string, error) {
+					return map[string]string{"Id": "ssh.service"}, nil
+				},
+			},
+			susys: mocksys.SystemdUnit{
+				ViewFn: func(string, []string, []string, map[string]string) (rpi.SystemdUnit, error) {
+					return rpi.SystemdUnit{
+						ID:      "ssh.service",
+						MainPID: 512,
+					}, nil
+				},
+			},
+			wantedData: rpi.SystemdUnit{
+				ID:      "ssh.service",
+				MainPID: 512,
+			},
+			wantedErr: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := systemdunit.New(tc.susys, tc.infos)
+			unit, err := s.View(tc.id)
+			assert.Equal(t, tc.wantedData, unit)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
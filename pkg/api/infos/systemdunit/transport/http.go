@@ -0,0 +1,44 @@
+package transport
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi/pkg/api/infos/systemdunit"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// HTTP is a struct implementing a systemdunit application service.
+type HTTP struct {
+	svc systemdunit.Service
+}
+
+// NewHTTP creates new systemdunit http service
+func NewHTTP(svc systemdunit.Service, r *echo.Group) {
+	h := HTTP{svc}
+	cr := r.Group("/systemdunits")
+	cr.GET("", h.list)
+	cr.GET("/:id", h.view)
+}
+
+func (h *HTTP) list(ctx echo.Context) error {
+	result, err := h.svc.List()
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) view(ctx echo.Context) error {
+	id := ctx.Param("id")
+	if !regexp.MustCompile(actions.UnitNameRegex).MatchString(id) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid id - should be a service unit name")
+	}
+
+	result, err := h.svc.View(id)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,170 @@
+package transport_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/infos/systemdunit"
+	"github.com/raspibuddy/rpi/pkg/api/infos/systemdunit/transport"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/raspibuddy/rpi/pkg/utl/server"
+	"github.com/stretchr/testify/assert"
+)
+
+var i = mock.Infos{
+	ListUnitsFn: func() []string {
+		return nil
+	},
+	ListUnitFilesFn: func() []string {
+		return nil
+	},
+	ShowUnitFn: func(string) (map[string]string, error) {
+		return map[string]string{}, nil
+	},
+}
+
+func TestList(t *testing.T) {
+	var response []rpi.SystemdUnit
+
+	cases := []struct {
+		name         string
+		susys        *mocksys.SystemdUnit
+		wantedStatus int
+		wantedResp   []rpi.SystemdUnit
+	}{
+		{
+			name: "error: List result is nil",
+			susys: &mocksys.SystemdUnit{
+				ListFn: func([]string, []string) ([]rpi.SystemdUnit, error) {
+					return nil, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			susys: &mocksys.SystemdUnit{
+				ListFn: func([]string, []string) ([]rpi.SystemdUnit, error) {
+					return []rpi.SystemdUnit{
+						{
+							ID:          "ssh.service",
+							ActiveState: "active",
+						},
+					}, nil
+				},
+			},
+			wantedStatus: http.StatusOK,
+			wantedResp: []rpi.SystemdUnit{
+				{
+					ID:          "ssh.service",
+					ActiveState: "active",
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			s := systemdunit.New(tc.susys, i)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+			defer ts.Close()
+			path := ts.URL + "/systemdunits"
+
+			res, err := http.Get(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+
+			if tc.wantedResp != nil {
+				body, _ := ioutil.ReadAll(res.Body)
+				if err := json.Unmarshal(body, &response); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, tc.wantedResp, response)
+			}
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestView(t *testing.T) {
+	var response rpi.SystemdUnit
+
+	cases := []struct {
+		name         string
+		req          string
+		susys        *mocksys.SystemdUnit
+		wantedStatus int
+		wantedResp   rpi.SystemdUnit
+	}{
+		{
+			name:         "error: invalid id",
+			req:          "ssh.socket",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error: View result is nil",
+			req:  "ssh.service",
+			susys: &mocksys.SystemdUnit{
+				ViewFn: func(string, []string, []string, map[string]string) (rpi.SystemdUnit, error) {
+					return rpi.SystemdUnit{}, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			req:  "ssh.service",
+			susys: &mocksys.SystemdUnit{
+				ViewFn: func(string, []string, []string, map[string]string) (rpi.SystemdUnit, error) {
+					return rpi.SystemdUnit{
+						ID:      "ssh.service",
+						MainPID: 512,
+					}, nil
+				},
+			},
+			wantedStatus: http.StatusOK,
+			wantedResp: rpi.SystemdUnit{
+				ID:      "ssh.service",
+				MainPID: 512,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			s := systemdunit.New(tc.susys, i)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+			defer ts.Close()
+			path := ts.URL + "/systemdunits/" + tc.req
+
+			res, err := http.Get(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+
+			if tc.wantedStatus == http.StatusOK {
+				body, _ := ioutil.ReadAll(res.Body)
+				if err := json.Unmarshal(body, &response); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, tc.wantedResp, response)
+			}
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
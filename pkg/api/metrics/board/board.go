@@ -0,0 +1,19 @@
+package board
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+)
+
+// List populates and returns a Board model.
+func (b *Board) List() (rpi.Board, error) {
+	revision, stdErr, err := b.m.Revision()
+
+	if (err != nil && stdErr != "") || revision == "" {
+		return rpi.Board{}, echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the board revision")
+	}
+
+	return b.bsys.List(revision)
+}
@@ -0,0 +1,74 @@
+package board_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/board"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	cases := []struct {
+		name       string
+		metrics    mock.Metrics
+		bsys       mocksys.Board
+		wantedData rpi.Board
+		wantedErr  error
+	}{
+		{
+			name: "error: revision command failed",
+			metrics: mock.Metrics{
+				RevisionFn: func() (string, string, error) {
+					return "", "test stderr", errors.New("test error revision")
+				},
+			},
+			wantedData: rpi.Board{},
+			wantedErr:  echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the board revision"),
+		},
+		{
+			name: "error: no revision",
+			metrics: mock.Metrics{
+				RevisionFn: func() (string, string, error) {
+					return "", "", nil
+				},
+			},
+			wantedData: rpi.Board{},
+			wantedErr:  echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the board revision"),
+		},
+		{
+			name: "success",
+			metrics: mock.Metrics{
+				RevisionFn: func() (string, string, error) {
+					return "c03111", "", nil
+				},
+			},
+			bsys: mocksys.Board{
+				ListFn: func(string) (rpi.Board, error) {
+					return rpi.Board{
+						Revision: "c03111",
+						Type:     "4B",
+					}, nil
+				},
+			},
+			wantedData: rpi.Board{
+				Revision: "c03111",
+				Type:     "4B",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := board.New(&tc.bsys, tc.metrics)
+			b, err := s.List()
+			assert.Equal(t, tc.wantedData, b)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package board
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/board"
+)
+
+// New creates a new board logging service instance.
+func New(svc board.Service, logger rpi.Logger) *LogService {
+	return &LogService{
+		Service: svc,
+		logger:  logger,
+	}
+}
+
+// LogService represents a board logging service.
+type LogService struct {
+	board.Service
+	logger rpi.Logger
+}
+
+const name = "board"
+
+// List is the logging function attached to the List board services and responsible for logging it out.
+func (ls *LogService) List(ctx echo.Context) (resp rpi.Board, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, "request: listing board", err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.List()
+}
@@ -0,0 +1,178 @@
+package sys
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+)
+
+// Board represents a Board entity on the current system.
+type Board struct{}
+
+// List returns the board decoded from its revision code
+func (b Board) List(revision string) (rpi.Board, error) {
+	board, err := DecodeRevision(revision)
+	if err != nil {
+		return rpi.Board{}, echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return board, nil
+}
+
+// new-style revision code bits
+// see https://www.raspberrypi.com/documentation/computers/raspberry-pi.html#raspberry-pi-revision-codes
+const (
+	newStyleFlag               = 1 << 23
+	warrantyVoidedFlag         = 1 << 25
+	otpReadingDisallowedFlag   = 1 << 29
+	otpProgrammingDisallowFlag = 1 << 30
+	overvoltageDisallowedFlag  = 1 << 31
+	legacyWarrantyVoidedFlag   = 1 << 24
+)
+
+// boardTypes maps the new-style type field to a board type
+var boardTypes = map[uint64]string{
+	0x00: "A",
+	0x01: "B",
+	0x02: "A+",
+	0x03: "B+",
+	0x04: "2B",
+	0x05: "Alpha",
+	0x06: "CM1",
+	0x08: "3B",
+	0x09: "Zero",
+	0x0a: "CM3",
+	0x0c: "Zero W",
+	0x0d: "3B+",
+	0x0e: "3A+",
+	0x0f: "Internal",
+	0x10: "CM3+",
+	0x11: "4B",
+	0x12: "Zero 2 W",
+	0x13: "400",
+	0x14: "CM4",
+	0x15: "CM4S",
+	0x17: "5",
+	0x18: "CM5",
+	0x19: "500",
+	0x1a: "CM5 Lite",
+}
+
+// socs maps the new-style processor field to a SoC
+var socs = map[uint64]string{
+	0: "BCM2835",
+	1: "BCM2836",
+	2: "BCM2837",
+	3: "BCM2711",
+	4: "BCM2712",
+}
+
+// manufacturers maps the new-style manufacturer field to a manufacturer
+var manufacturers = map[uint64]string{
+	0: "Sony UK",
+	1: "Egoman",
+	2: "Embest",
+	3: "Sony Japan",
+	4: "Embest",
+	5: "Stadium",
+}
+
+// legacyBoards maps the old-style revision codes to their boards
+var legacyBoards = map[uint64]rpi.Board{
+	0x0002: {Type: "B", PCBRevision: "1.0", RAM: 256, Manufacturer: "Egoman"},
+	0x0003: {Type: "B", PCBRevision: "1.0", RAM: 256, Manufacturer: "Egoman"},
+	0x0004: {Type: "B", PCBRevision: "2.0", RAM: 256, Manufacturer: "Sony UK"},
+	0x0005: {Type: "B", PCBRevision: "2.0", RAM: 256, Manufacturer: "Qisda"},
+	0x0006: {Type: "B", PCBRevision: "2.0", RAM: 256, Manufacturer: "Egoman"},
+	0x0007: {Type: "A", PCBRevision: "2.0", RAM: 256, Manufacturer: "Egoman"},
+	0x0008: {Type: "A", PCBRevision: "2.0", RAM: 256, Manufacturer: "Sony UK"},
+	0x0009: {Type: "A", PCBRevision: "2.0", RAM: 256, Manufacturer: "Qisda"},
+	0x000d: {Type: "B", PCBRevision: "2.0", RAM: 512, Manufacturer: "Egoman"},
+	0x000e: {Type: "B", PCBRevision: "2.0", RAM: 512, Manufacturer: "Sony UK"},
+	0x000f: {Type: "B", PCBRevision: "2.0", RAM: 512, Manufacturer: "Egoman"},
+	0x0010: {Type: "B+", PCBRevision: "1.2", RAM: 512, Manufacturer: "Sony UK"},
+	0x0011: {Type: "CM1", PCBRevision: "1.0", RAM: 512, Manufacturer: "Sony UK"},
+	0x0012: {Type: "A+", PCBRevision: "1.1", RAM: 256, Manufacturer: "Sony UK"},
+	0x0013: {Type: "B+", PCBRevision: "1.2", RAM: 512, Manufacturer: "Embest"},
+	0x0014: {Type: "CM1", PCBRevision: "1.0", RAM: 512, Manufacturer: "Embest"},
+	0x0015: {Type: "A+", PCBRevision: "1.1", RAM: 256, Manufacturer: "Embest"},
+}
+
+// capabilities maps a board type to its hardware features
+var capabilities = map[string]rpi.BoardCapabilities{
+	"B":        {Ethernet: true},
+	"B+":       {Ethernet: true},
+	"2B":       {Ethernet: true},
+	"3B":       {Wifi: true, Bluetooth: true, Ethernet: true},
+	"3B+":      {Wifi: true, Bluetooth: true, Ethernet: true, PoEHeader: true},
+	"3A+":      {Wifi: true, Bluetooth: true},
+	"Zero W":   {Wifi: true, Bluetooth: true},
+	"Zero 2 W": {Wifi: true, Bluetooth: true},
+	"4B":       {Wifi: true, Bluetooth: true, Ethernet: true, PoEHeader: true},
+	"400":      {Wifi: true, Bluetooth: true, Ethernet: true},
+	"5":        {Wifi: true, Bluetooth: true, Ethernet: true, PoEHeader: true},
+	"500":      {Wifi: true, Bluetooth: true, Ethernet: true},
+}
+
+// DecodeRevision decodes a /proc/cpuinfo revision code (new-style bitfield or legacy code)
+func DecodeRevision(revision string) (rpi.Board, error) {
+	code, err := strconv.ParseUint(strings.TrimSpace(revision), 16, 32)
+	if err != nil {
+		return rpi.Board{}, fmt.Errorf("%v is not a valid revision code", revision)
+	}
+
+	var board rpi.Board
+
+	if code&newStyleFlag != 0 {
+		board = rpi.Board{
+			IsNewStyleRevision:         true,
+			Type:                       boardTypes[(code>>4)&0xff],
+			SoC:                        socs[(code>>12)&0xf],
+			RAM:                        256 << ((code >> 20) & 0x7),
+			Manufacturer:               manufacturers[(code>>16)&0xf],
+			PCBRevision:                fmt.Sprintf("1.%v", code&0xf),
+			IsOvervoltageDisallowed:    code&overvoltageDisallowedFlag != 0,
+			IsOTPProgrammingDisallowed: code&otpProgrammingDisallowFlag != 0,
+			IsOTPReadingDisallowed:     code&otpReadingDisallowedFlag != 0,
+			IsWarrantyVoided:           code&warrantyVoidedFlag != 0,
+		}
+
+		if board.Type == "" {
+			return rpi.Board{}, fmt.Errorf("%v is an unknown board type", revision)
+		}
+	} else {
+		legacy, ok := legacyBoards[code&0xffffff]
+		if !ok {
+			return rpi.Board{}, fmt.Errorf("%v is an unknown legacy revision code", revision)
+		}
+
+		board = legacy
+		board.SoC = "BCM2835"
+		board.IsWarrantyVoided = code&legacyWarrantyVoidedFlag != 0
+	}
+
+	board.Revision = strings.TrimSpace(revision)
+	board.Model = ModelName(board.Type)
+	board.Capabilities = capabilities[board.Type]
+
+	return board, nil
+}
+
+// ModelName returns the marketing name of a board type
+func ModelName(boardType string) string {
+	switch {
+	case strings.HasPrefix(boardType, "CM"):
+		return fmt.Sprintf("Raspberry Pi Compute Module %v", strings.TrimPrefix(boardType, "CM"))
+	case strings.HasPrefix(boardType, "Zero"), boardType == "400", boardType == "500", boardType == "Alpha", boardType == "Internal":
+		return fmt.Sprintf("Raspberry Pi %v", boardType)
+	case len(boardType) > 1 && boardType[0] >= '2' && boardType[0] <= '9':
+		return fmt.Sprintf("Raspberry Pi %v Model %v", boardType[:1], boardType[1:])
+	case boardType == "5":
+		return "Raspberry Pi 5"
+	default:
+		return fmt.Sprintf("Raspberry Pi Model %v", boardType)
+	}
+}
@@ -0,0 +1,142 @@
+package sys_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/board/platform/sys"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	cases := []struct {
+		name       string
+		revision   string
+		wantedData rpi.Board
+		wantedErr  error
+	}{
+		{
+			name:       "error: not an hexadecimal code",
+			revision:   "xyz",
+			wantedData: rpi.Board{},
+			wantedErr:  echo.NewHTTPError(http.StatusInternalServerError, "xyz is not a valid revision code"),
+		},
+		{
+			name:       "error: unknown legacy code",
+			revision:   "0001",
+			wantedData: rpi.Board{},
+			wantedErr:  echo.NewHTTPError(http.StatusInternalServerError, "0001 is an unknown legacy revision code"),
+		},
+		{
+			name:       "error: unknown new-style board type",
+			revision:   "800ff0",
+			wantedData: rpi.Board{},
+			wantedErr:  echo.NewHTTPError(http.StatusInternalServerError, "800ff0 is an unknown board type"),
+		},
+		{
+			name:     "success: pi 4 model b 4GB",
+			revision: "c03111",
+			wantedData: rpi.Board{
+				Revision:           "c03111",
+				IsNewStyleRevision: true,
+				Model:              "Raspberry Pi 4 Model B",
+				Type:               "4B",
+				SoC:                "BCM2711",
+				RAM:                4096,
+				Manufacturer:       "Sony UK",
+				PCBRevision:        "1.1",
+				Capabilities: rpi.BoardCapabilities{
+					Wifi:      true,
+					Bluetooth: true,
+					Ethernet:  true,
+					PoEHeader: true,
+				},
+			},
+		},
+		{
+			name:     "success: pi 3 model b+ with overvoltage and warranty bits",
+			revision: "82a020d3",
+			wantedData: rpi.Board{
+				Revision:                "82a020d3",
+				IsNewStyleRevision:      true,
+				Model:                   "Raspberry Pi 3 Model B+",
+				Type:                    "3B+",
+				SoC:                     "BCM2837",
+				RAM:                     1024,
+				Manufacturer:            "Sony UK",
+				PCBRevision:             "1.3",
+				IsOvervoltageDisallowed: true,
+				IsWarrantyVoided:        true,
+				Capabilities: rpi.BoardCapabilities{
+					Wifi:      true,
+					Bluetooth: true,
+					Ethernet:  true,
+					PoEHeader: true,
+				},
+			},
+		},
+		{
+			name:     "success: compute module 4",
+			revision: "b03140",
+			wantedData: rpi.Board{
+				Revision:           "b03140",
+				IsNewStyleRevision: true,
+				Model:              "Raspberry Pi Compute Module 4",
+				Type:               "CM4",
+				SoC:                "BCM2711",
+				RAM:                2048,
+				Manufacturer:       "Sony UK",
+				PCBRevision:        "1.0",
+			},
+		},
+		{
+			name:     "success: legacy model b with warranty voided",
+			revision: "100000e",
+			wantedData: rpi.Board{
+				Revision:         "100000e",
+				Model:            "Raspberry Pi Model B",
+				Type:             "B",
+				SoC:              "BCM2835",
+				RAM:              512,
+				Manufacturer:     "Sony UK",
+				PCBRevision:      "2.0",
+				IsWarrantyVoided: true,
+				Capabilities: rpi.BoardCapabilities{
+					Ethernet: true,
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := sys.Board{}
+			b, err := s.List(tc.revision)
+			assert.Equal(t, tc.wantedData, b)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestModelName(t *testing.T) {
+	cases := []struct {
+		boardType  string
+		wantedData string
+	}{
+		{boardType: "A+", wantedData: "Raspberry Pi Model A+"},
+		{boardType: "2B", wantedData: "Raspberry Pi 2 Model B"},
+		{boardType: "3A+", wantedData: "Raspberry Pi 3 Model A+"},
+		{boardType: "Zero 2 W", wantedData: "Raspberry Pi Zero 2 W"},
+		{boardType: "400", wantedData: "Raspberry Pi 400"},
+		{boardType: "5", wantedData: "Raspberry Pi 5"},
+		{boardType: "CM3+", wantedData: "Raspberry Pi Compute Module 3+"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.boardType, func(t *testing.T) {
+			assert.Equal(t, tc.wantedData, sys.ModelName(tc.boardType))
+		})
+	}
+}
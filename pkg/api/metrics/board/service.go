@@ -0,0 +1,31 @@
+package board
+
+import (
+	"github.com/raspibuddy/rpi"
+)
+
+// Service represents all Board application services.
+type Service interface {
+	List() (rpi.Board, error)
+}
+
+// Board represents a Board application service.
+type Board struct {
+	bsys BSYS
+	m    Metrics
+}
+
+// BSYS represents a Board repository service.
+type BSYS interface {
+	List(string) (rpi.Board, error)
+}
+
+// Metrics represents the system metrics interface
+type Metrics interface {
+	Revision() (string, string, error)
+}
+
+// New creates a Board application service instance.
+func New(bsys BSYS, m Metrics) *Board {
+	return &Board{bsys: bsys, m: m}
+}
@@ -0,0 +1,28 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/board"
+)
+
+// HTTP is a struct implementing a board application service.
+type HTTP struct {
+	svc board.Service
+}
+
+// NewHTTP creates new board http service
+func NewHTTP(svc board.Service, r *echo.Group) {
+	h := HTTP{svc}
+	cr := r.Group("/boards")
+	cr.GET("", h.list)
+}
+
+func (h *HTTP) list(ctx echo.Context) error {
+	result, err := h.svc.List()
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,97 @@
+package transport_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/board"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/board/transport"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/raspibuddy/rpi/pkg/utl/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	var response rpi.Board
+
+	m := mock.Metrics{
+		RevisionFn: func() (string, string, error) {
+			return "c03111", "", nil
+		},
+	}
+
+	cases := []struct {
+		name         string
+		bsys         *mocksys.Board
+		wantedStatus int
+		wantedResp   rpi.Board
+	}{
+		{
+			name: "error: List result is nil",
+			bsys: &mocksys.Board{
+				ListFn: func(string) (rpi.Board, error) {
+					return rpi.Board{}, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			bsys: &mocksys.Board{
+				ListFn: func(revision string) (rpi.Board, error) {
+					return rpi.Board{
+						Revision: revision,
+						Model:    "Raspberry Pi 4 Model B",
+						Type:     "4B",
+						RAM:      4096,
+					}, nil
+				},
+			},
+			wantedStatus: http.StatusOK,
+			wantedResp: rpi.Board{
+				Revision: "c03111",
+				Model:    "Raspberry Pi 4 Model B",
+				Type:     "4B",
+				RAM:      4096,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			s := board.New(tc.bsys, m)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/boards"
+			res, err := http.Get(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer res.Body.Close()
+
+			body, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				panic(err)
+			}
+
+			if tc.wantedResp.Revision != "" {
+				if err := json.Unmarshal(body, &response); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, tc.wantedResp, response)
+			}
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package cgroup
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+)
+
+// List populates and returns an array of CGroup models, filtered by type and sorted.
+func (cg *CGroup) List(cgType string, sortBy string) ([]rpi.CGroup, error) {
+	cgstats, err := cg.m.CGroupStats(sampleInterval)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the cgroup metrics")
+	}
+
+	return cg.cgsys.List(cgstats, cgType, sortBy)
+}
+
+// View populates and returns the CGroup model of a slice or service.
+func (cg *CGroup) View(id string) (rpi.CGroup, error) {
+	cgstats, err := cg.m.CGroupStats(sampleInterval)
+	if err != nil {
+		return rpi.CGroup{}, echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the cgroup metrics")
+	}
+
+	return cg.cgsys.View(id, cgstats)
+}
@@ -0,0 +1,106 @@
+package cgroup_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/cgroup"
+	"github.com/raspibuddy/rpi/pkg/utl/metrics"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	cases := []struct {
+		name       string
+		metrics    mock.Metrics
+		cgsys      mocksys.CGroup
+		wantedData []rpi.CGroup
+		wantedErr  error
+	}{
+		{
+			name: "error: cgroup stats",
+			metrics: mock.Metrics{
+				CGroupStatsFn: func(time.Duration) (map[string]metrics.CGStats, error) {
+					return nil, errors.New("test error")
+				},
+			},
+			wantedData: nil,
+			wantedErr:  echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the cgroup metrics"),
+		},
+		{
+			name: "success",
+			metrics: mock.Metrics{
+				CGroupStatsFn: func(time.Duration) (map[string]metrics.CGStats, error) {
+					return map[string]metrics.CGStats{"system.slice/ssh.service": {}}, nil
+				},
+			},
+			cgsys: mocksys.CGroup{
+				ListFn: func(map[string]metrics.CGStats, string, string) ([]rpi.CGroup, error) {
+					return []rpi.CGroup{{ID: "ssh.service", Type: "service"}}, nil
+				},
+			},
+			wantedData: []rpi.CGroup{{ID: "ssh.service", Type: "service"}},
+			wantedErr:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := cgroup.New(&tc.cgsys, tc.metrics)
+			cgroups, err := s.List("service", "cpu")
+			assert.Equal(t, tc.wantedData, cgroups)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestView(t *testing.T) {
+	cases := []struct {
+		name       string
+		metrics    mock.Metrics
+		cgsys      mocksys.CGroup
+		wantedData rpi.CGroup
+		wantedErr  error
+	}{
+		{
+			name: "error: cgroup stats",
+			metrics: mock.Metrics{
+				CGroupStatsFn: func(time.Duration) (map[string]metrics.CGStats, error) {
+					return nil, errors.New("test error")
+				},
+			},
+			wantedData: rpi.CGroup{},
+			wantedErr:  echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the cgroup metrics"),
+		},
+		{
+			name: "success",
+			metrics: mock.Metrics{
+				CGroupStatsFn: func(time.Duration) (map[string]metrics.CGStats, error) {
+					return map[string]metrics.CGStats{"system.slice/ssh.service": {}}, nil
+				},
+			},
+			cgsys: mocksys.CGroup{
+				ViewFn: func(id string, _ map[string]metrics.CGStats) (rpi.CGroup, error) {
+					return rpi.CGroup{ID: id, Type: "service"}, nil
+				},
+			},
+			wantedData: rpi.CGroup{ID: "ssh.service", Type: "service"},
+			wantedErr:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := cgroup.New(&tc.cgsys, tc.metrics)
+			cg, err := s.View("ssh.service")
+			assert.Equal(t, tc.wantedData, cg)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
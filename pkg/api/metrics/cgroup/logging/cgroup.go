@@ -0,0 +1,56 @@
+package cgroup
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/cgroup"
+)
+
+// New creates a new cgroup logging service instance.
+func New(svc cgroup.Service, logger rpi.Logger) *LogService {
+	return &LogService{
+		Service: svc,
+		logger:  logger,
+	}
+}
+
+// LogService represents a cgroup logging service.
+type LogService struct {
+	cgroup.Service
+	logger rpi.Logger
+}
+
+const name = "cgroup"
+
+// List is the logging function attached to the List cgroup services and responsible for logging it out.
+func (ls *LogService) List(ctx echo.Context, cgType string, sortBy string) (resp []rpi.CGroup, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, "request: listing cgroups", err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.List(cgType, sortBy)
+}
+
+// View is the logging function attached to the View cgroup services and responsible for logging it out.
+func (ls *LogService) View(ctx echo.Context, id string) (resp rpi.CGroup, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: viewing cgroup %v", id), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.View(id)
+}
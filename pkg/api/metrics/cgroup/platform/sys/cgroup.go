@@ -0,0 +1,88 @@
+package sys
+
+import (
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/metrics"
+)
+
+// CGroup represents a CGroup entity on the current system.
+type CGroup struct{}
+
+// List returns the resource usage of the slices and services matching cgType ("" for all), sorted by sortBy
+func (cg CGroup) List(cgstats map[string]metrics.CGStats, cgType string, sortBy string) ([]rpi.CGroup, error) {
+	result := []rpi.CGroup{}
+
+	for path, cgs := range cgstats {
+		c := Usage(path, cgs)
+		if cgType != "" && c.Type != cgType {
+			continue
+		}
+		result = append(result, c)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		switch sortBy {
+		case "cpu":
+			return result[i].CPUPercent > result[j].CPUPercent
+		case "memory":
+			return result[i].MemoryCurrent > result[j].MemoryCurrent
+		case "io":
+			return result[i].IOReadRate+result[i].IOWriteRate > result[j].IOReadRate+result[j].IOWriteRate
+		default:
+			return result[i].Path < result[j].Path
+		}
+	})
+
+	return result, nil
+}
+
+// View returns the resource usage of a slice or service
+func (cg CGroup) View(id string, cgstats map[string]metrics.CGStats) (rpi.CGroup, error) {
+	for path, cgs := range cgstats {
+		if filepath.Base(path) == id {
+			return Usage(path, cgs), nil
+		}
+	}
+
+	return rpi.CGroup{}, echo.NewHTTPError(http.StatusNotFound, "slice or service does not exist")
+}
+
+// Usage converts the two samples of a cgroup into a CGroup object
+func Usage(path string, cgs metrics.CGStats) rpi.CGroup {
+	id := filepath.Base(path)
+
+	result := rpi.CGroup{
+		ID:              id,
+		Path:            path,
+		Type:            strings.TrimPrefix(filepath.Ext(id), "."),
+		CPUUsageSeconds: float64(cgs.After.CPUUsage) / 1e6,
+		MemoryCurrent:   cgs.After.MemoryCurrent,
+		SwapCurrent:     cgs.After.SwapCurrent,
+		IORead:          cgs.After.IORead,
+		IOWrite:         cgs.After.IOWrite,
+		PIDs:            cgs.After.PIDs,
+	}
+
+	seconds := cgs.Interval.Seconds()
+	if seconds > 0 {
+		result.CPUPercent = float64(delta(cgs.Before.CPUUsage, cgs.After.CPUUsage)) / (seconds * 1e6) * 100
+		result.IOReadRate = float64(delta(cgs.Before.IORead, cgs.After.IORead)) / seconds
+		result.IOWriteRate = float64(delta(cgs.Before.IOWrite, cgs.After.IOWrite)) / seconds
+	}
+
+	return result
+}
+
+// delta returns the increase of a counter, or zero if it has been reset
+func delta(before uint64, after uint64) uint64 {
+	if after < before {
+		return 0
+	}
+	return after - before
+}
@@ -0,0 +1,134 @@
+package sys_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/cgroup/platform/sys"
+	"github.com/raspibuddy/rpi/pkg/utl/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+var cgstats = map[string]metrics.CGStats{
+	"system.slice": {
+		Before:   metrics.CGSample{CPUUsage: 1000000, MemoryCurrent: 500},
+		After:    metrics.CGSample{CPUUsage: 1500000, MemoryCurrent: 600},
+		Interval: time.Second,
+	},
+	"system.slice/ssh.service": {
+		Before:   metrics.CGSample{CPUUsage: 2000000, IORead: 1000, IOWrite: 4000},
+		After:    metrics.CGSample{CPUUsage: 2100000, MemoryCurrent: 100, SwapCurrent: 10, IORead: 3000, IOWrite: 8000, PIDs: 3},
+		Interval: 2 * time.Second,
+	},
+	"user.slice": {
+		Before:   metrics.CGSample{CPUUsage: 900},
+		After:    metrics.CGSample{CPUUsage: 100, MemoryCurrent: 900},
+		Interval: time.Second,
+	},
+}
+
+var systemSlice = rpi.CGroup{
+	ID:              "system.slice",
+	Path:            "system.slice",
+	Type:            "slice",
+	CPUUsageSeconds: 1.5,
+	CPUPercent:      50,
+	MemoryCurrent:   600,
+}
+
+var sshService = rpi.CGroup{
+	ID:              "ssh.service",
+	Path:            "system.slice/ssh.service",
+	Type:            "service",
+	CPUUsageSeconds: 2.1,
+	CPUPercent:      5,
+	MemoryCurrent:   100,
+	SwapCurrent:     10,
+	IORead:          3000,
+	IOWrite:         8000,
+	IOReadRate:      1000,
+	IOWriteRate:     2000,
+	PIDs:            3,
+}
+
+var userSlice = rpi.CGroup{
+	ID:              "user.slice",
+	Path:            "user.slice",
+	Type:            "slice",
+	CPUUsageSeconds: 0.0001,
+	MemoryCurrent:   900,
+}
+
+func TestList(t *testing.T) {
+	cases := []struct {
+		name       string
+		cgType     string
+		sortBy     string
+		wantedData []rpi.CGroup
+	}{
+		{
+			name:       "success: sorted by path",
+			wantedData: []rpi.CGroup{systemSlice, sshService, userSlice},
+		},
+		{
+			name:       "success: slices sorted by cpu",
+			cgType:     "slice",
+			sortBy:     "cpu",
+			wantedData: []rpi.CGroup{systemSlice, userSlice},
+		},
+		{
+			name:       "success: sorted by memory",
+			sortBy:     "memory",
+			wantedData: []rpi.CGroup{userSlice, systemSlice, sshService},
+		},
+		{
+			name:       "success: services sorted by io",
+			cgType:     "service",
+			sortBy:     "io",
+			wantedData: []rpi.CGroup{sshService},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := sys.CGroup{}
+			cgroups, err := s.List(cgstats, tc.cgType, tc.sortBy)
+			assert.Equal(t, tc.wantedData, cgroups)
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestView(t *testing.T) {
+	cases := []struct {
+		name       string
+		id         string
+		wantedData rpi.CGroup
+		wantedErr  error
+	}{
+		{
+			name:       "error: slice or service does not exist",
+			id:         "nginx.service",
+			wantedData: rpi.CGroup{},
+			wantedErr:  echo.NewHTTPError(http.StatusNotFound, "slice or service does not exist"),
+		},
+		{
+			name:       "success",
+			id:         "ssh.service",
+			wantedData: sshService,
+			wantedErr:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := sys.CGroup{}
+			cg, err := s.View(tc.id, cgstats)
+			assert.Equal(t, tc.wantedData, cg)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
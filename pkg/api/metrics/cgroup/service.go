@@ -0,0 +1,39 @@
+package cgroup
+
+import (
+	"time"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/metrics"
+)
+
+// Service represents all CGroup application services.
+type Service interface {
+	List(string, string) ([]rpi.CGroup, error)
+	View(string) (rpi.CGroup, error)
+}
+
+// CGroup represents a CGroup application service.
+type CGroup struct {
+	cgsys CGSYS
+	m     Metrics
+}
+
+// CGSYS represents a CGroup repository service.
+type CGSYS interface {
+	List(map[string]metrics.CGStats, string, string) ([]rpi.CGroup, error)
+	View(string, map[string]metrics.CGStats) (rpi.CGroup, error)
+}
+
+// Metrics represents the system metrics interface
+type Metrics interface {
+	CGroupStats(time.Duration) (map[string]metrics.CGStats, error)
+}
+
+// sampleInterval is the time between the two samples used to compute the usage rates
+const sampleInterval = time.Second
+
+// New creates a CGroup application service instance.
+func New(cgsys CGSYS, m Metrics) *CGroup {
+	return &CGroup{cgsys: cgsys, m: m}
+}
@@ -0,0 +1,53 @@
+package transport
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/cgroup"
+)
+
+// HTTP is a struct implementing a cgroup application service.
+type HTTP struct {
+	svc cgroup.Service
+}
+
+// NewHTTP creates new cgroup http service
+func NewHTTP(svc cgroup.Service, r *echo.Group) {
+	h := HTTP{svc}
+	cr := r.Group("/cgroups")
+	cr.GET("", h.list)
+	cr.GET("/:id", h.view)
+}
+
+func (h *HTTP) list(ctx echo.Context) error {
+	cgType := ctx.QueryParam("type")
+	if cgType != "" && cgType != "slice" && cgType != "service" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid type - should be slice or service")
+	}
+
+	sortBy := ctx.QueryParam("sort")
+	if sortBy != "" && sortBy != "cpu" && sortBy != "memory" && sortBy != "io" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid sort - should be cpu, memory or io")
+	}
+
+	result, err := h.svc.List(cgType, sortBy)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) view(ctx echo.Context) error {
+	id := ctx.Param("id")
+	if !regexp.MustCompile(`^[a-zA-Z0-9@:._-]+\.(slice|service)$`).MatchString(id) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid id - should be a slice or service unit name")
+	}
+
+	result, err := h.svc.View(id)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
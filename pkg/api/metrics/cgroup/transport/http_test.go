@@ -0,0 +1,173 @@
+package transport_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/cgroup"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/cgroup/transport"
+	"github.com/raspibuddy/rpi/pkg/utl/metrics"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/raspibuddy/rpi/pkg/utl/server"
+	"github.com/stretchr/testify/assert"
+)
+
+var m = mock.Metrics{
+	CGroupStatsFn: func(time.Duration) (map[string]metrics.CGStats, error) {
+		return map[string]metrics.CGStats{"system.slice/ssh.service": {}}, nil
+	},
+}
+
+func TestList(t *testing.T) {
+	var response []rpi.CGroup
+
+	cases := []struct {
+		name         string
+		req          string
+		cgsys        *mocksys.CGroup
+		wantedStatus int
+		wantedResp   []rpi.CGroup
+	}{
+		{
+			name:         "error: invalid type",
+			req:          "?type=scope",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid sort",
+			req:          "?sort=pids",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error: List result is nil",
+			cgsys: &mocksys.CGroup{
+				ListFn: func(map[string]metrics.CGStats, string, string) ([]rpi.CGroup, error) {
+					return nil, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			req:  "?type=service&sort=cpu",
+			cgsys: &mocksys.CGroup{
+				ListFn: func(_ map[string]metrics.CGStats, cgType string, sortBy string) ([]rpi.CGroup, error) {
+					if cgType != "service" || sortBy != "cpu" {
+						return nil, errors.New("test error")
+					}
+					return []rpi.CGroup{{ID: "ssh.service", Type: "service"}}, nil
+				},
+			},
+			wantedStatus: http.StatusOK,
+			wantedResp:   []rpi.CGroup{{ID: "ssh.service", Type: "service"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			s := cgroup.New(tc.cgsys, m)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/cgroups" + tc.req
+			res, err := http.Get(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+
+			body, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				panic(err)
+			}
+
+			if tc.wantedResp != nil {
+				if err := json.Unmarshal(body, &response); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, tc.wantedResp, response)
+			}
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestView(t *testing.T) {
+	var response rpi.CGroup
+
+	cases := []struct {
+		name         string
+		req          string
+		cgsys        *mocksys.CGroup
+		wantedStatus int
+		wantedResp   rpi.CGroup
+	}{
+		{
+			name:         "error: invalid id",
+			req:          "session-1.scope",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error: View result is nil",
+			req:  "ssh.service",
+			cgsys: &mocksys.CGroup{
+				ViewFn: func(string, map[string]metrics.CGStats) (rpi.CGroup, error) {
+					return rpi.CGroup{}, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			req:  "ssh.service",
+			cgsys: &mocksys.CGroup{
+				ViewFn: func(id string, _ map[string]metrics.CGStats) (rpi.CGroup, error) {
+					return rpi.CGroup{ID: id, Type: "service"}, nil
+				},
+			},
+			wantedStatus: http.StatusOK,
+			wantedResp:   rpi.CGroup{ID: "ssh.service", Type: "service"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			s := cgroup.New(tc.cgsys, m)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/cgroups/" + tc.req
+			res, err := http.Get(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+
+			body, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				panic(err)
+			}
+
+			if tc.wantedResp.ID != "" {
+				if err := json.Unmarshal(body, &response); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, tc.wantedResp, response)
+			}
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
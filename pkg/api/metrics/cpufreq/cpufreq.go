@@ -0,0 +1,30 @@
+package cpufreq
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+)
+
+// List populates and returns an array of CPUFreq models.
+func (cf *CPUFreq) List() ([]rpi.CPUFreq, error) {
+	fstats, err := cf.m.CPUFreqStats()
+
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the cpu frequency metrics")
+	}
+
+	return cf.cfsys.List(fstats)
+}
+
+// View populates and returns one single CPUFreq model.
+func (cf *CPUFreq) View(id int) (rpi.CPUFreq, error) {
+	fstats, err := cf.m.CPUFreqStats()
+
+	if err != nil {
+		return rpi.CPUFreq{}, echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the cpu frequency metrics")
+	}
+
+	return cf.cfsys.View(id, fstats)
+}
@@ -0,0 +1,108 @@
+package cpufreq_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/cpufreq"
+	"github.com/raspibuddy/rpi/pkg/utl/metrics"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	cases := []struct {
+		name       string
+		metrics    mock.Metrics
+		cfsys      mocksys.CPUFreq
+		wantedData []rpi.CPUFreq
+		wantedErr  error
+	}{
+		{
+			name: "error: cpufreq stats",
+			metrics: mock.Metrics{
+				CPUFreqStatsFn: func() ([]metrics.FStats, error) {
+					return nil, errors.New("test error")
+				},
+			},
+			wantedData: nil,
+			wantedErr:  echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the cpu frequency metrics"),
+		},
+		{
+			name: "success",
+			metrics: mock.Metrics{
+				CPUFreqStatsFn: func() ([]metrics.FStats, error) {
+					return []metrics.FStats{{CPU: 0, Cur: 600000}}, nil
+				},
+			},
+			cfsys: mocksys.CPUFreq{
+				ListFn: func([]metrics.FStats) ([]rpi.CPUFreq, error) {
+					return []rpi.CPUFreq{{ID: 1, CurrentMhz: 600}}, nil
+				},
+			},
+			wantedData: []rpi.CPUFreq{{ID: 1, CurrentMhz: 600}},
+			wantedErr:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := cpufreq.New(&tc.cfsys, tc.metrics)
+			freqs, err := s.List()
+			assert.Equal(t, tc.wantedData, freqs)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestView(t *testing.T) {
+	cases := []struct {
+		name       string
+		id         int
+		metrics    mock.Metrics
+		cfsys      mocksys.CPUFreq
+		wantedData rpi.CPUFreq
+		wantedErr  error
+	}{
+		{
+			name: "error: cpufreq stats",
+			id:   1,
+			metrics: mock.Metrics{
+				CPUFreqStatsFn: func() ([]metrics.FStats, error) {
+					return nil, errors.New("test error")
+				},
+			},
+			wantedData: rpi.CPUFreq{},
+			wantedErr:  echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the cpu frequency metrics"),
+		},
+		{
+			name: "success",
+			id:   1,
+			metrics: mock.Metrics{
+				CPUFreqStatsFn: func() ([]metrics.FStats, error) {
+					return []metrics.FStats{{CPU: 0, Cur: 600000}}, nil
+				},
+			},
+			cfsys: mocksys.CPUFreq{
+				ViewFn: func(int, []metrics.FStats) (rpi.CPUFreq, error) {
+					return rpi.CPUFreq{ID: 1, CurrentMhz: 600}, nil
+				},
+			},
+			wantedData: rpi.CPUFreq{ID: 1, CurrentMhz: 600},
+			wantedErr:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := cpufreq.New(&tc.cfsys, tc.metrics)
+			freq, err := s.View(tc.id)
+			assert.Equal(t, tc.wantedData, freq)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
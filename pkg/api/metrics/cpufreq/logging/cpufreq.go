@@ -0,0 +1,56 @@
+package cpufreq
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/cpufreq"
+)
+
+// New creates a new cpufreq logging service instance.
+func New(svc cpufreq.Service, logger rpi.Logger) *LogService {
+	return &LogService{
+		Service: svc,
+		logger:  logger,
+	}
+}
+
+// LogService represents a cpufreq logging service.
+type LogService struct {
+	cpufreq.Service
+	logger rpi.Logger
+}
+
+const name = "cpufreq"
+
+// List is the logging function attached to the List cpufreq services and responsible for logging it out.
+func (ls *LogService) List(ctx echo.Context) (resp []rpi.CPUFreq, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, "request: listing cpu frequencies", err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.List()
+}
+
+// View is the logging function attached to the View cpufreq services and responsible for logging it out.
+func (ls *LogService) View(ctx echo.Context, id int) (resp rpi.CPUFreq, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: viewing cpu frequency #%v", id), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.View(id)
+}
@@ -0,0 +1,71 @@
+package sys
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/metrics"
+)
+
+// CPUFreq represents a CPUFreq entity on the current system.
+type CPUFreq struct{}
+
+// List returns the frequency scaling state of every cpu core
+func (cf CPUFreq) List(fstats []metrics.FStats) ([]rpi.CPUFreq, error) {
+	var result []rpi.CPUFreq
+
+	for _, fs := range fstats {
+		result = append(result, Freq(fs))
+	}
+
+	return result, nil
+}
+
+// View returns the frequency scaling state of a cpu core
+func (cf CPUFreq) View(id int, fstats []metrics.FStats) (rpi.CPUFreq, error) {
+	for _, fs := range fstats {
+		if fs.CPU+1 == id {
+			return Freq(fs), nil
+		}
+	}
+
+	return rpi.CPUFreq{}, echo.NewHTTPError(http.StatusNotFound, "id out of range")
+}
+
+// Freq converts the cpufreq stats of a core (in kHz) into a CPUFreq object (in MHz)
+func Freq(fs metrics.FStats) rpi.CPUFreq {
+	result := rpi.CPUFreq{
+		ID:                 fs.CPU + 1,
+		CurrentMhz:         float64(fs.Cur) / 1000,
+		MinMhz:             float64(fs.Min) / 1000,
+		MaxMhz:             float64(fs.Max) / 1000,
+		Governor:           fs.Governor,
+		AvailableGovernors: fs.AvailableGovernors,
+		TimeInState:        []rpi.CPUFreqTime{},
+	}
+
+	var total uint64
+	for _, ticks := range fs.TimeInState {
+		total += ticks
+	}
+
+	for freq, ticks := range fs.TimeInState {
+		fts := rpi.CPUFreqTime{
+			Mhz: float64(freq) / 1000,
+			// time_in_state is expressed in 10ms units
+			Time: float64(ticks) / 100,
+		}
+		if total > 0 {
+			fts.Percent = float64(ticks) / float64(total) * 100
+		}
+		result.TimeInState = append(result.TimeInState, fts)
+	}
+
+	sort.Slice(result.TimeInState, func(i, j int) bool {
+		return result.TimeInState[i].Mhz < result.TimeInState[j].Mhz
+	})
+
+	return result
+}
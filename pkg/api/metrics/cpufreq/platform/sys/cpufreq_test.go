@@ -0,0 +1,91 @@
+package sys_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/cpufreq/platform/sys"
+	"github.com/raspibuddy/rpi/pkg/utl/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+var fstats = []metrics.FStats{
+	{
+		CPU:                0,
+		Cur:                600000,
+		Min:                600000,
+		Max:                1500000,
+		Governor:           "ondemand",
+		AvailableGovernors: []string{"conservative", "ondemand", "userspace", "powersave", "performance", "schedutil"},
+		TimeInState: map[uint64]uint64{
+			1500000: 100,
+			600000:  300,
+		},
+	},
+	{
+		CPU:         1,
+		Cur:         1500000,
+		Governor:    "performance",
+		TimeInState: map[uint64]uint64{},
+	},
+}
+
+var cpu1 = rpi.CPUFreq{
+	ID:                 1,
+	CurrentMhz:         600,
+	MinMhz:             600,
+	MaxMhz:             1500,
+	Governor:           "ondemand",
+	AvailableGovernors: []string{"conservative", "ondemand", "userspace", "powersave", "performance", "schedutil"},
+	TimeInState: []rpi.CPUFreqTime{
+		{Mhz: 600, Time: 3, Percent: 75},
+		{Mhz: 1500, Time: 1, Percent: 25},
+	},
+}
+
+var cpu2 = rpi.CPUFreq{
+	ID:          2,
+	CurrentMhz:  1500,
+	Governor:    "performance",
+	TimeInState: []rpi.CPUFreqTime{},
+}
+
+func TestList(t *testing.T) {
+	s := sys.CPUFreq{}
+	freqs, err := s.List(fstats)
+	assert.Equal(t, []rpi.CPUFreq{cpu1, cpu2}, freqs)
+	assert.Nil(t, err)
+}
+
+func TestView(t *testing.T) {
+	cases := []struct {
+		name       string
+		id         int
+		wantedData rpi.CPUFreq
+		wantedErr  error
+	}{
+		{
+			name:       "error: id out of range",
+			id:         3,
+			wantedData: rpi.CPUFreq{},
+			wantedErr:  echo.NewHTTPError(http.StatusNotFound, "id out of range"),
+		},
+		{
+			name:       "success",
+			id:         2,
+			wantedData: cpu2,
+			wantedErr:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := sys.CPUFreq{}
+			freq, err := s.View(tc.id, fstats)
+			assert.Equal(t, tc.wantedData, freq)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package cpufreq
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/metrics"
+)
+
+// Service represents all CPUFreq application services.
+type Service interface {
+	List() ([]rpi.CPUFreq, error)
+	View(int) (rpi.CPUFreq, error)
+}
+
+// CPUFreq represents a CPUFreq application service.
+type CPUFreq struct {
+	cfsys CFSYS
+	m     Metrics
+}
+
+// CFSYS represents a CPUFreq repository service.
+type CFSYS interface {
+	List([]metrics.FStats) ([]rpi.CPUFreq, error)
+	View(int, []metrics.FStats) (rpi.CPUFreq, error)
+}
+
+// Metrics represents the system metrics interface
+type Metrics interface {
+	CPUFreqStats() ([]metrics.FStats, error)
+}
+
+// New creates a CPUFreq application service instance.
+func New(cfsys CFSYS, m Metrics) *CPUFreq {
+	return &CPUFreq{cfsys: cfsys, m: m}
+}
@@ -0,0 +1,157 @@
+package transport_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/cpufreq"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/cpufreq/transport"
+	"github.com/raspibuddy/rpi/pkg/utl/metrics"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/raspibuddy/rpi/pkg/utl/server"
+	"github.com/stretchr/testify/assert"
+)
+
+var m = mock.Metrics{
+	CPUFreqStatsFn: func() ([]metrics.FStats, error) {
+		return []metrics.FStats{{CPU: 0, Cur: 600000}}, nil
+	},
+}
+
+func TestList(t *testing.T) {
+	var response []rpi.CPUFreq
+
+	cases := []struct {
+		name         string
+		cfsys        *mocksys.CPUFreq
+		wantedStatus int
+		wantedResp   []rpi.CPUFreq
+	}{
+		{
+			name: "error: List result is nil",
+			cfsys: &mocksys.CPUFreq{
+				ListFn: func([]metrics.FStats) ([]rpi.CPUFreq, error) {
+					return nil, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			cfsys: &mocksys.CPUFreq{
+				ListFn: func([]metrics.FStats) ([]rpi.CPUFreq, error) {
+					return []rpi.CPUFreq{{ID: 1, CurrentMhz: 600, Governor: "ondemand"}}, nil
+				},
+			},
+			wantedStatus: http.StatusOK,
+			wantedResp:   []rpi.CPUFreq{{ID: 1, CurrentMhz: 600, Governor: "ondemand"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			s := cpufreq.New(tc.cfsys, m)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/cpufreqs"
+			res, err := http.Get(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+
+			body, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				panic(err)
+			}
+
+			if tc.wantedResp != nil {
+				if err := json.Unmarshal(body, &response); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, tc.wantedResp, response)
+			}
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestView(t *testing.T) {
+	var response rpi.CPUFreq
+
+	cases := []struct {
+		name         string
+		req          string
+		cfsys        *mocksys.CPUFreq
+		wantedStatus int
+		wantedResp   rpi.CPUFreq
+	}{
+		{
+			name:         "error: invalid id",
+			req:          "a",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error: View result is nil",
+			req:  "1",
+			cfsys: &mocksys.CPUFreq{
+				ViewFn: func(int, []metrics.FStats) (rpi.CPUFreq, error) {
+					return rpi.CPUFreq{}, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			req:  "1",
+			cfsys: &mocksys.CPUFreq{
+				ViewFn: func(id int, _ []metrics.FStats) (rpi.CPUFreq, error) {
+					return rpi.CPUFreq{ID: id, CurrentMhz: 600, Governor: "ondemand"}, nil
+				},
+			},
+			wantedStatus: http.StatusOK,
+			wantedResp:   rpi.CPUFreq{ID: 1, CurrentMhz: 600, Governor: "ondemand"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			s := cpufreq.New(tc.cfsys, m)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/cpufreqs/" + tc.req
+			res, err := http.Get(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+
+			body, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				panic(err)
+			}
+
+			if tc.wantedResp.ID != 0 {
+				if err := json.Unmarshal(body, &response); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, tc.wantedResp, response)
+			}
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
@@ -28,3 +28,25 @@ func (d *Disk) View(dev string) (rpi.Disk, error) {
 
 	return d.dsys.View(dev, dstats)
 }
+
+// ListIO populates and returns an array of DiskIO models.
+func (d *Disk) ListIO() ([]rpi.DiskIO, error) {
+	diostats, err := d.m.DiskIOStats(ioInterval)
+
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "could not list the disk io metrics")
+	}
+
+	return d.dsys.ListIO(diostats)
+}
+
+// ViewIO populates and returns a DiskIO model.
+func (d *Disk) ViewIO(dev string) (rpi.DiskIO, error) {
+	diostats, err := d.m.DiskIOStats(ioInterval)
+
+	if err != nil {
+		return rpi.DiskIO{}, echo.NewHTTPError(http.StatusInternalServerError, "could not view the disk io metrics")
+	}
+
+	return d.dsys.ViewIO(dev, diostats)
+}
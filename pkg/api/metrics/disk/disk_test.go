@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/raspibuddy/rpi"
@@ -785,3 +786,122 @@ func TestView(t *testing.T) {
 		})
 	}
 }
+
+func TestListIO(t *testing.T) {
+	cases := []struct {
+		name       string
+		metrics    mock.Metrics
+		dsys       mocksys.Disk
+		wantedData []rpi.DiskIO
+		wantedErr  error
+	}{
+		{
+			name: "error: diostats is nil",
+			metrics: mock.Metrics{
+				DiskIOStatsFn: func(time.Duration) (map[string]metrics.DIOStats, error) {
+					return nil, errors.New("test error diostats")
+				},
+			},
+			wantedData: nil,
+			wantedErr:  echo.NewHTTPError(http.StatusInternalServerError, "could not list the disk io metrics"),
+		},
+		{
+			name: "success",
+			metrics: mock.Metrics{
+				DiskIOStatsFn: func(time.Duration) (map[string]metrics.DIOStats, error) {
+					return map[string]metrics.DIOStats{
+						"sda": {
+							After: dext.IOCountersStat{ReadCount: 1},
+						},
+					}, nil
+				},
+			},
+			dsys: mocksys.Disk{
+				ListIOFn: func(map[string]metrics.DIOStats) ([]rpi.DiskIO, error) {
+					return []rpi.DiskIO{
+						{
+							ID:        "sda",
+							Device:    "sda",
+							ReadCount: 1,
+						},
+					}, nil
+				},
+			},
+			wantedData: []rpi.DiskIO{
+				{
+					ID:        "sda",
+					Device:    "sda",
+					ReadCount: 1,
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := disk.New(&tc.dsys, tc.metrics)
+			diskio, err := s.ListIO()
+			assert.Equal(t, tc.wantedData, diskio)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestViewIO(t *testing.T) {
+	cases := []struct {
+		name       string
+		id         string
+		metrics    mock.Metrics
+		dsys       mocksys.Disk
+		wantedData rpi.DiskIO
+		wantedErr  error
+	}{
+		{
+			name: "error: diostats is nil",
+			id:   "sda",
+			metrics: mock.Metrics{
+				DiskIOStatsFn: func(time.Duration) (map[string]metrics.DIOStats, error) {
+					return nil, errors.New("test error diostats")
+				},
+			},
+			wantedData: rpi.DiskIO{},
+			wantedErr:  echo.NewHTTPError(http.StatusInternalServerError, "could not view the disk io metrics"),
+		},
+		{
+			name: "success",
+			id:   "sda",
+			metrics: mock.Metrics{
+				DiskIOStatsFn: func(time.Duration) (map[string]metrics.DIOStats, error) {
+					return map[string]metrics.DIOStats{
+						"sda": {
+							After: dext.IOCountersStat{WriteCount: 2},
+						},
+					}, nil
+				},
+			},
+			dsys: mocksys.Disk{
+				ViewIOFn: func(string, map[string]metrics.DIOStats) (rpi.DiskIO, error) {
+					return rpi.DiskIO{
+						ID:         "sda",
+						Device:     "sda",
+						WriteCount: 2,
+					}, nil
+				},
+			},
+			wantedData: rpi.DiskIO{
+				ID:         "sda",
+				Device:     "sda",
+				WriteCount: 2,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := disk.New(&tc.dsys, tc.metrics)
+			diskio, err := s.ViewIO(tc.id)
+			assert.Equal(t, tc.wantedData, diskio)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
@@ -54,3 +54,33 @@ func (ls *LogService) View(ctx echo.Context, dev string) (resp rpi.Disk, err err
 	}(time.Now())
 	return ls.Service.View(dev)
 }
+
+// ListIO is the logging function attached to the ListIO disk services and responsible for logging it out.
+func (ls *LogService) ListIO(ctx echo.Context) (resp []rpi.DiskIO, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, "request: listing disk io", err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ListIO()
+}
+
+// ViewIO is the logging function attached to the ViewIO disk services and responsible for logging it out.
+func (ls *LogService) ViewIO(ctx echo.Context, dev string) (resp rpi.DiskIO, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: viewing disk io #%v", dev), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ViewIO(dev)
+}
@@ -0,0 +1,133 @@
+package sys
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/metrics"
+)
+
+// ListIO returns a list of block device I/O stats
+func (d Disk) ListIO(listDev map[string]metrics.DIOStats) ([]rpi.DiskIO, error) {
+	var result []rpi.DiskIO
+
+	for dev, diostats := range listDev {
+		id := ExtractDeviceID(dev)
+		if len(id) != 1 {
+			return nil, echo.NewHTTPError(http.StatusNotFound, "parsing id was unsuccessful")
+		}
+
+		result = append(result, DiskIO(id[0], diostats))
+	}
+
+	result = LinkPartitions(result)
+
+	sort.Slice(result[:], func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	return result, nil
+}
+
+// ViewIO returns some block device I/O stats
+func (d Disk) ViewIO(device string, listDev map[string]metrics.DIOStats) (rpi.DiskIO, error) {
+	result, err := d.ListIO(listDev)
+	if err != nil {
+		return rpi.DiskIO{}, err
+	}
+
+	for _, v := range result {
+		if v.ID == device {
+			return v, nil
+		}
+	}
+
+	return rpi.DiskIO{}, echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("%v does not exist", device))
+}
+
+// DiskIO builds a DiskIO object out of two I/O counters samples
+func DiskIO(id string, s metrics.DIOStats) rpi.DiskIO {
+	device, isPartition := ParentDeviceID(id)
+
+	result := rpi.DiskIO{
+		ID:               id,
+		Device:           device,
+		IsPartition:      isPartition,
+		ReadCount:        s.After.ReadCount,
+		MergedReadCount:  s.After.MergedReadCount,
+		WriteCount:       s.After.WriteCount,
+		MergedWriteCount: s.After.MergedWriteCount,
+		ReadBytes:        s.After.ReadBytes,
+		WriteBytes:       s.After.WriteBytes,
+		ReadTime:         s.After.ReadTime,
+		WriteTime:        s.After.WriteTime,
+		IopsInProgress:   s.After.IopsInProgress,
+		IoTime:           s.After.IoTime,
+		WeightedIoTime:   s.After.WeightedIO,
+	}
+
+	seconds := s.Interval.Seconds()
+	if seconds <= 0 {
+		return result
+	}
+
+	result.ReadsPerSec = float64(Delta(s.Before.ReadCount, s.After.ReadCount)) / seconds
+	result.WritesPerSec = float64(Delta(s.Before.WriteCount, s.After.WriteCount)) / seconds
+	result.ReadBytesPerSec = float64(Delta(s.Before.ReadBytes, s.After.ReadBytes)) / seconds
+	result.WriteBytesPerSec = float64(Delta(s.Before.WriteBytes, s.After.WriteBytes)) / seconds
+
+	// io time is expressed in milliseconds
+	util := float64(Delta(s.Before.IoTime, s.After.IoTime)) / (seconds * 10)
+	if util > 100 {
+		util = 100
+	}
+	result.UtilPercent = util
+
+	return result
+}
+
+// Delta returns the difference between two counters, zero if the counter was reset
+func Delta(before uint64, after uint64) uint64 {
+	if after < before {
+		return 0
+	}
+	return after - before
+}
+
+// ParentDeviceID returns the whole device ID a partition belongs to
+// and whether the given ID is a partition (e.g. mmcblk0p2 -> mmcblk0, sda1 -> sda)
+func ParentDeviceID(id string) (string, bool) {
+	r := regexp.MustCompile(`^((?:mmcblk|nvme\d+n|loop)\d+)p\d+$|^((?:[shv]|xv)d[a-z]+)\d+$`)
+	res := r.FindStringSubmatch(id)
+	if res == nil {
+		return id, false
+	}
+
+	if res[1] != "" {
+		return res[1], true
+	}
+	return res[2], true
+}
+
+// LinkPartitions attaches to every whole device the list of its partitions
+func LinkPartitions(dio []rpi.DiskIO) []rpi.DiskIO {
+	partitions := make(map[string][]string)
+	for _, v := range dio {
+		if v.IsPartition {
+			partitions[v.Device] = append(partitions[v.Device], v.ID)
+		}
+	}
+
+	for i := range dio {
+		if p, ok := partitions[dio[i].ID]; ok {
+			sort.Strings(p)
+			dio[i].Partitions = p
+		}
+	}
+
+	return dio
+}
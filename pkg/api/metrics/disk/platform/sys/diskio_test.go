@@ -0,0 +1,215 @@
+package sys
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/metrics"
+	dext "github.com/shirou/gopsutil/disk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParentDeviceID(t *testing.T) {
+	cases := []struct {
+		name              string
+		id                string
+		wantedDevice      string
+		wantedIsPartition bool
+	}{
+		{
+			name:              "success: sd card partition",
+			id:                "mmcblk0p2",
+			wantedDevice:      "mmcblk0",
+			wantedIsPartition: true,
+		},
+		{
+			name:              "success: sd card device",
+			id:                "mmcblk0",
+			wantedDevice:      "mmcblk0",
+			wantedIsPartition: false,
+		},
+		{
+			name:              "success: usb drive partition",
+			id:                "sda1",
+			wantedDevice:      "sda",
+			wantedIsPartition: true,
+		},
+		{
+			name:              "success: nvme partition",
+			id:                "nvme0n1p1",
+			wantedDevice:      "nvme0n1",
+			wantedIsPartition: true,
+		},
+		{
+			name:              "success: ram device",
+			id:                "ram1",
+			wantedDevice:      "ram1",
+			wantedIsPartition: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			device, isPartition := ParentDeviceID(tc.id)
+			assert.Equal(t, tc.wantedDevice, device)
+			assert.Equal(t, tc.wantedIsPartition, isPartition)
+		})
+	}
+}
+
+func TestDelta(t *testing.T) {
+	cases := []struct {
+		name       string
+		before     uint64
+		after      uint64
+		wantedData uint64
+	}{
+		{
+			name:       "success: counter increasing",
+			before:     10,
+			after:      25,
+			wantedData: 15,
+		},
+		{
+			name:       "success: counter reset",
+			before:     25,
+			after:      10,
+			wantedData: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantedData, Delta(tc.before, tc.after))
+		})
+	}
+}
+
+func TestListIO(t *testing.T) {
+	cases := []struct {
+		name       string
+		diostats   map[string]metrics.DIOStats
+		wantedData []rpi.DiskIO
+		wantedErr  error
+	}{
+		{
+			name: "error: parsing id was unsuccessful",
+			diostats: map[string]metrics.DIOStats{
+				"/": {},
+			},
+			wantedData: nil,
+			wantedErr:  echo.NewHTTPError(http.StatusNotFound, "parsing id was unsuccessful"),
+		},
+		{
+			name: "success: one device and one partition",
+			diostats: map[string]metrics.DIOStats{
+				"mmcblk0p1": {
+					Before:   dext.IOCountersStat{ReadCount: 10, WriteCount: 20, ReadBytes: 1000, WriteBytes: 2000, IoTime: 100},
+					After:    dext.IOCountersStat{ReadCount: 30, WriteCount: 60, ReadBytes: 3000, WriteBytes: 6000, IoTime: 600},
+					Interval: 2 * time.Second,
+				},
+				"mmcblk0": {
+					Before:   dext.IOCountersStat{ReadCount: 10, IoTime: 100},
+					After:    dext.IOCountersStat{ReadCount: 12, IoTime: 5000},
+					Interval: time.Second,
+				},
+			},
+			wantedData: []rpi.DiskIO{
+				{
+					ID:          "mmcblk0",
+					Device:      "mmcblk0",
+					IsPartition: false,
+					ReadCount:   12,
+					IoTime:      5000,
+					ReadsPerSec: 2,
+					UtilPercent: 100,
+					Partitions:  []string{"mmcblk0p1"},
+				},
+				{
+					ID:               "mmcblk0p1",
+					Device:           "mmcblk0",
+					IsPartition:      true,
+					ReadCount:        30,
+					WriteCount:       60,
+					ReadBytes:        3000,
+					WriteBytes:       6000,
+					IoTime:           600,
+					ReadsPerSec:      10,
+					WritesPerSec:     20,
+					ReadBytesPerSec:  1000,
+					WriteBytesPerSec: 2000,
+					UtilPercent:      25,
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := Disk{}
+			diskio, err := s.ListIO(tc.diostats)
+			assert.Equal(t, tc.wantedData, diskio)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestViewIO(t *testing.T) {
+	cases := []struct {
+		name       string
+		id         string
+		diostats   map[string]metrics.DIOStats
+		wantedData rpi.DiskIO
+		wantedErr  error
+	}{
+		{
+			name: "error: parsing id was unsuccessful",
+			id:   "sda",
+			diostats: map[string]metrics.DIOStats{
+				"/": {},
+			},
+			wantedData: rpi.DiskIO{},
+			wantedErr:  echo.NewHTTPError(http.StatusNotFound, "parsing id was unsuccessful"),
+		},
+		{
+			name: "error: device does not exist",
+			id:   "sdb",
+			diostats: map[string]metrics.DIOStats{
+				"sda": {},
+			},
+			wantedData: rpi.DiskIO{},
+			wantedErr:  echo.NewHTTPError(http.StatusNotFound, "sdb does not exist"),
+		},
+		{
+			name: "success: view partition",
+			id:   "sda1",
+			diostats: map[string]metrics.DIOStats{
+				"sda": {},
+				"sda1": {
+					Before:   dext.IOCountersStat{WriteBytes: 0},
+					After:    dext.IOCountersStat{WriteBytes: 4096},
+					Interval: time.Second,
+				},
+			},
+			wantedData: rpi.DiskIO{
+				ID:               "sda1",
+				Device:           "sda",
+				IsPartition:      true,
+				WriteBytes:       4096,
+				WriteBytesPerSec: 4096,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := Disk{}
+			diskio, err := s.ViewIO(tc.id, tc.diostats)
+			assert.Equal(t, tc.wantedData, diskio)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
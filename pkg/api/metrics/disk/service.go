@@ -1,6 +1,8 @@
 package disk
 
 import (
+	"time"
+
 	"github.com/raspibuddy/rpi"
 	"github.com/raspibuddy/rpi/pkg/utl/metrics"
 )
@@ -9,6 +11,8 @@ import (
 type Service interface {
 	List() ([]rpi.Disk, error)
 	View(string) (rpi.Disk, error)
+	ListIO() ([]rpi.DiskIO, error)
+	ViewIO(string) (rpi.DiskIO, error)
 }
 
 // Disk represents a disk application service.
@@ -21,13 +25,19 @@ type Disk struct {
 type DSYS interface {
 	List(map[string][]metrics.DStats) ([]rpi.Disk, error)
 	View(string, map[string][]metrics.DStats) (rpi.Disk, error)
+	ListIO(map[string]metrics.DIOStats) ([]rpi.DiskIO, error)
+	ViewIO(string, map[string]metrics.DIOStats) (rpi.DiskIO, error)
 }
 
 // Metrics represents the system metrics interface
 type Metrics interface {
 	DiskStats(bool) (map[string][]metrics.DStats, error)
+	DiskIOStats(time.Duration) (map[string]metrics.DIOStats, error)
 }
 
+// ioInterval is the delay between the two /proc/diskstats samples used to compute the I/O rates.
+const ioInterval = time.Second
+
 // New creates a Disk application service instance.
 func New(dsys DSYS, m Metrics) *Disk {
 	return &Disk{dsys: dsys, m: m}
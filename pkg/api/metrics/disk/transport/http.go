@@ -18,6 +18,8 @@ func NewHTTP(svc disk.Service, r *echo.Group) {
 	cr := r.Group("/disks")
 	cr.GET("", h.list)
 	cr.GET("/:id", h.view)
+	cr.GET("/io", h.listio)
+	cr.GET("/:id/io", h.viewio)
 }
 
 func (h *HTTP) list(ctx echo.Context) error {
@@ -36,3 +38,20 @@ func (h *HTTP) view(ctx echo.Context) error {
 
 	return ctx.JSON(http.StatusOK, result)
 }
+
+func (h *HTTP) listio(ctx echo.Context) error {
+	result, err := h.svc.ListIO()
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) viewio(ctx echo.Context) error {
+	result, err := h.svc.ViewIO(ctx.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, result)
+}
@@ -231,3 +231,82 @@ func TestView(t *testing.T) {
 		})
 	}
 }
+
+func TestViewIO(t *testing.T) {
+	var response rpi.DiskIO
+
+	cases := []struct {
+		name         string
+		req          string
+		dsys         *mocksys.Disk
+		wantedStatus int
+		wantedResp   rpi.DiskIO
+	}{
+		{
+			name: "error: ViewIO result is nil",
+			req:  "a",
+			dsys: &mocksys.Disk{
+				ViewIOFn: func(string, map[string]metrics.DIOStats) (rpi.DiskIO, error) {
+					return rpi.DiskIO{}, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			req:  "mmcblk0",
+			dsys: &mocksys.Disk{
+				ViewIOFn: func(string, map[string]metrics.DIOStats) (rpi.DiskIO, error) {
+					return rpi.DiskIO{
+						ID:          "mmcblk0",
+						Device:      "mmcblk0",
+						ReadCount:   1,
+						UtilPercent: 2.2,
+						Partitions:  []string{"mmcblk0p1", "mmcblk0p2"},
+					}, nil
+				},
+			},
+			wantedStatus: http.StatusOK,
+			wantedResp: rpi.DiskIO{
+				ID:          "mmcblk0",
+				Device:      "mmcblk0",
+				ReadCount:   1,
+				UtilPercent: 2.2,
+				Partitions:  []string{"mmcblk0p1", "mmcblk0p2"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			m := metrics.New(metrics.Service{})
+			s := disk.New(tc.dsys, m)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/disks/" + tc.req + "/io"
+			res, err := http.Get(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer res.Body.Close()
+
+			body, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				panic(err)
+			}
+
+			if tc.wantedResp.ID != "" {
+				if err := json.Unmarshal(body, &response); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, tc.wantedResp, response)
+			}
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
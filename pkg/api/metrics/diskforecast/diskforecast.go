@@ -0,0 +1,61 @@
+package diskforecast
+
+import (
+	"time"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/history"
+)
+
+// List populates and returns an array of DiskForecast models.
+// A zero horizon falls back to the default one and
+// onlyExhausted keeps the mountpoints exhausted within the horizon.
+func (df *DiskForecast) List(horizon time.Duration, onlyExhausted bool) ([]rpi.DiskForecast, error) {
+	if horizon <= 0 {
+		horizon = df.horizon
+	}
+
+	forecasts, err := df.dfsys.List(df.h.Samples(), horizon, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if !onlyExhausted {
+		return forecasts, nil
+	}
+
+	result := []rpi.DiskForecast{}
+	for _, v := range forecasts {
+		if v.IsExhaustedWithinHorizon {
+			result = append(result, v)
+		}
+	}
+
+	return result, nil
+}
+
+// Collect returns a function sampling the used space of every mountpoint,
+// meant to feed the mountpoint usage history.
+func Collect(m Metrics) func() map[string]history.Sample {
+	return func() map[string]history.Sample {
+		result := make(map[string]history.Sample)
+
+		dstats, err := m.DiskStats(false)
+		if err != nil {
+			return result
+		}
+
+		now := time.Now().Unix()
+		for _, v := range dstats {
+			for _, d := range v {
+				result[d.Mountpoint.Path] = history.Sample{
+					Time:  now,
+					Value: d.Mountpoint.Used,
+					Total: d.Mountpoint.Total,
+				}
+			}
+		}
+
+		return result
+	}
+}
@@ -0,0 +1,143 @@
+package diskforecast_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/diskforecast"
+	"github.com/raspibuddy/rpi/pkg/utl/history"
+	"github.com/raspibuddy/rpi/pkg/utl/metrics"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	dext "github.com/shirou/gopsutil/disk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	cases := []struct {
+		name          string
+		horizon       time.Duration
+		onlyExhausted bool
+		dfsys         mocksys.DiskForecast
+		wantedHorizon time.Duration
+		wantedData    []rpi.DiskForecast
+		wantedErr     error
+	}{
+		{
+			name: "error: forecast failed",
+			dfsys: mocksys.DiskForecast{
+				ListFn: func(map[string][]history.Sample, time.Duration, time.Time) ([]rpi.DiskForecast, error) {
+					return nil, errors.New("test error")
+				},
+			},
+			wantedErr: errors.New("test error"),
+		},
+		{
+			name:          "success: default horizon",
+			wantedHorizon: 72 * time.Hour,
+			dfsys: mocksys.DiskForecast{
+				ListFn: func(map[string][]history.Sample, time.Duration, time.Time) ([]rpi.DiskForecast, error) {
+					return []rpi.DiskForecast{
+						{Mountpoint: "/"},
+						{Mountpoint: "/boot", IsExhaustedWithinHorizon: true},
+					}, nil
+				},
+			},
+			wantedData: []rpi.DiskForecast{
+				{Mountpoint: "/"},
+				{Mountpoint: "/boot", IsExhaustedWithinHorizon: true},
+			},
+		},
+		{
+			name:          "success: custom horizon and only exhausted mountpoints",
+			horizon:       24 * time.Hour,
+			onlyExhausted: true,
+			wantedHorizon: 24 * time.Hour,
+			dfsys: mocksys.DiskForecast{
+				ListFn: func(map[string][]history.Sample, time.Duration, time.Time) ([]rpi.DiskForecast, error) {
+					return []rpi.DiskForecast{
+						{Mountpoint: "/"},
+						{Mountpoint: "/boot", IsExhaustedWithinHorizon: true},
+					}, nil
+				},
+			},
+			wantedData: []rpi.DiskForecast{
+				{Mountpoint: "/boot", IsExhaustedWithinHorizon: true},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			listFn := tc.dfsys.ListFn
+			tc.dfsys.ListFn = func(s map[string][]history.Sample, h time.Duration, now time.Time) ([]rpi.DiskForecast, error) {
+				if tc.wantedHorizon != 0 {
+					assert.Equal(t, tc.wantedHorizon, h)
+				}
+				return listFn(s, h, now)
+			}
+
+			s := diskforecast.New(&tc.dfsys, history.New(10, ""), 72*time.Hour)
+			forecasts, err := s.List(tc.horizon, tc.onlyExhausted)
+			assert.Equal(t, tc.wantedData, forecasts)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestCollect(t *testing.T) {
+	cases := []struct {
+		name       string
+		metrics    mock.Metrics
+		wantedData map[string]history.Sample
+	}{
+		{
+			name: "success: dstats error",
+			metrics: mock.Metrics{
+				DiskStatsFn: func(bool) (map[string][]metrics.DStats, error) {
+					return nil, errors.New("test error dstats")
+				},
+			},
+			wantedData: map[string]history.Sample{},
+		},
+		{
+			name: "success: two mountpoints",
+			metrics: mock.Metrics{
+				DiskStatsFn: func(bool) (map[string][]metrics.DStats, error) {
+					return map[string][]metrics.DStats{
+						"/dev/mmcblk0p1": {
+							{
+								Partition:  &dext.PartitionStat{Mountpoint: "/boot"},
+								Mountpoint: &dext.UsageStat{Path: "/boot", Total: 100, Used: 10},
+							},
+						},
+						"/dev/root": {
+							{
+								Partition:  &dext.PartitionStat{Mountpoint: "/"},
+								Mountpoint: &dext.UsageStat{Path: "/", Total: 200, Used: 50},
+							},
+						},
+					}, nil
+				},
+			},
+			wantedData: map[string]history.Sample{
+				"/boot": {Value: 10, Total: 100},
+				"/":     {Value: 50, Total: 200},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			samples := diskforecast.Collect(tc.metrics)()
+			for k, v := range samples {
+				assert.NotZero(t, v.Time)
+				v.Time = 0
+				samples[k] = v
+			}
+			assert.Equal(t, tc.wantedData, samples)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package diskforecast
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/diskforecast"
+)
+
+// New creates a new diskforecast logging service instance.
+func New(svc diskforecast.Service, logger rpi.Logger) *LogService {
+	return &LogService{
+		Service: svc,
+		logger:  logger,
+	}
+}
+
+// LogService represents a diskforecast logging service.
+type LogService struct {
+	diskforecast.Service
+	logger rpi.Logger
+}
+
+const name = "diskforecast"
+
+// List is the logging function attached to the List diskforecast services and responsible for logging it out.
+func (ls *LogService) List(ctx echo.Context, horizon time.Duration, onlyExhausted bool) (resp []rpi.DiskForecast, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, "request: listing disk forecast", err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.List(horizon, onlyExhausted)
+}
@@ -0,0 +1,119 @@
+package sys
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/history"
+)
+
+// DiskForecast represents a DiskForecast entity on the current system.
+type DiskForecast struct{}
+
+// minSamples is the number of samples from which a trend is fully trusted
+const minSamples = 12
+
+// List returns the fill-rate forecast of every mountpoint having a usage history
+func (df DiskForecast) List(
+	samples map[string][]history.Sample,
+	horizon time.Duration,
+	now time.Time,
+) ([]rpi.DiskForecast, error) {
+	result := []rpi.DiskForecast{}
+
+	for mp, s := range samples {
+		if len(s) == 0 {
+			continue
+		}
+		result = append(result, Forecast(mp, s, horizon, now))
+	}
+
+	sort.Slice(result[:], func(i, j int) bool {
+		return result[i].Mountpoint < result[j].Mountpoint
+	})
+
+	return result, nil
+}
+
+// Forecast fits a linear trend on the used space history of a mountpoint
+// and projects the date at which the mountpoint will be full
+func Forecast(mountpoint string, samples []history.Sample, horizon time.Duration, now time.Time) rpi.DiskForecast {
+	last := samples[len(samples)-1]
+
+	result := rpi.DiskForecast{
+		Mountpoint:   mountpoint,
+		Total:        last.Total,
+		Used:         last.Value,
+		Samples:      len(samples),
+		HorizonHours: horizon.Hours(),
+	}
+
+	if last.Total > 0 {
+		result.UsedPercent = float64(last.Value) / float64(last.Total) * 100
+	}
+
+	slope, r2, ok := Trend(samples)
+	if !ok {
+		return result
+	}
+
+	result.GrowthPerHour = slope
+	result.Confidence = r2 * math.Min(1, float64(len(samples))/minSamples)
+
+	if slope <= 0 {
+		return result
+	}
+
+	var remaining float64
+	if last.Total > last.Value {
+		remaining = float64(last.Total - last.Value)
+	}
+
+	fullAt := last.Time + int64(remaining/slope*3600)
+	result.FullAt = fullAt
+	result.HoursUntilFull = math.Max(0, float64(fullAt-now.Unix())/3600)
+	result.IsExhaustedWithinHorizon = fullAt <= now.Add(horizon).Unix()
+
+	return result
+}
+
+// Trend returns the slope (per hour) of the least squares line fitting the samples
+// and its coefficient of determination; ok is false when no trend can be fitted
+func Trend(samples []history.Sample) (slope float64, r2 float64, ok bool) {
+	n := float64(len(samples))
+	if n < 2 {
+		return 0, 0, false
+	}
+
+	origin := samples[0].Time
+	var sumX, sumY float64
+	for _, s := range samples {
+		sumX += float64(s.Time-origin) / 3600
+		sumY += float64(s.Value)
+	}
+	meanX, meanY := sumX/n, sumY/n
+
+	var sxx, sxy, syy float64
+	for _, s := range samples {
+		dx := float64(s.Time-origin)/3600 - meanX
+		dy := float64(s.Value) - meanY
+		sxx += dx * dx
+		sxy += dx * dy
+		syy += dy * dy
+	}
+
+	if sxx == 0 {
+		return 0, 0, false
+	}
+
+	slope = sxy / sxx
+
+	// a flat history is perfectly described by a flat line
+	if syy == 0 {
+		return slope, 1, true
+	}
+
+	return slope, (sxy * sxy) / (sxx * syy), true
+}
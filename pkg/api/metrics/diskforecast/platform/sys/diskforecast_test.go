@@ -0,0 +1,182 @@
+package sys_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/diskforecast/platform/sys"
+	"github.com/raspibuddy/rpi/pkg/utl/history"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrend(t *testing.T) {
+	cases := []struct {
+		name        string
+		samples     []history.Sample
+		wantedSlope float64
+		wantedR2    float64
+		wantedOk    bool
+	}{
+		{
+			name:    "no trend: one sample",
+			samples: []history.Sample{{Time: 0, Value: 10}},
+		},
+		{
+			name:    "no trend: samples taken at the same time",
+			samples: []history.Sample{{Time: 0, Value: 10}, {Time: 0, Value: 20}},
+		},
+		{
+			name:        "success: flat usage",
+			samples:     []history.Sample{{Time: 0, Value: 10}, {Time: 3600, Value: 10}},
+			wantedSlope: 0,
+			wantedR2:    1,
+			wantedOk:    true,
+		},
+		{
+			name:        "success: perfectly linear growth",
+			samples:     []history.Sample{{Time: 0, Value: 10}, {Time: 3600, Value: 20}, {Time: 7200, Value: 30}},
+			wantedSlope: 10,
+			wantedR2:    1,
+			wantedOk:    true,
+		},
+		{
+			name:        "success: noisy growth",
+			samples:     []history.Sample{{Time: 0, Value: 10}, {Time: 3600, Value: 30}, {Time: 7200, Value: 30}},
+			wantedSlope: 10,
+			wantedR2:    0.75,
+			wantedOk:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			slope, r2, ok := sys.Trend(tc.samples)
+			assert.InDelta(t, tc.wantedSlope, slope, 1e-9)
+			assert.InDelta(t, tc.wantedR2, r2, 1e-9)
+			assert.Equal(t, tc.wantedOk, ok)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	now := time.Unix(36000, 0)
+
+	cases := []struct {
+		name       string
+		samples    map[string][]history.Sample
+		horizon    time.Duration
+		wantedData []rpi.DiskForecast
+	}{
+		{
+			name:       "success: no history",
+			horizon:    72 * time.Hour,
+			wantedData: []rpi.DiskForecast{},
+		},
+		{
+			name:    "success: growing, shrinking and single sample mountpoints",
+			horizon: 72 * time.Hour,
+			samples: map[string][]history.Sample{
+				// +10 per hour, 100 left at the last sample: full 10 hours later
+				"/": {
+					{Time: 0, Value: 800, Total: 1000},
+					{Time: 3600, Value: 810, Total: 1000},
+					{Time: 7200, Value: 820, Total: 1000},
+					{Time: 10800, Value: 830, Total: 1000},
+					{Time: 14400, Value: 840, Total: 1000},
+					{Time: 18000, Value: 850, Total: 1000},
+					{Time: 21600, Value: 860, Total: 1000},
+					{Time: 25200, Value: 870, Total: 1000},
+					{Time: 28800, Value: 880, Total: 1000},
+					{Time: 32400, Value: 890, Total: 1000},
+					{Time: 36000, Value: 900, Total: 1000},
+					{Time: 39600, Value: 910, Total: 1000},
+				},
+				"/boot": {
+					{Time: 0, Value: 50, Total: 100},
+					{Time: 36000, Value: 40, Total: 100},
+				},
+				"/home": {
+					{Time: 36000, Value: 50, Total: 200},
+				},
+				"/tmp": {},
+			},
+			wantedData: []rpi.DiskForecast{
+				{
+					Mountpoint:               "/",
+					Total:                    1000,
+					Used:                     910,
+					UsedPercent:              91,
+					Samples:                  12,
+					GrowthPerHour:            10,
+					FullAt:                   39600 + 9*3600,
+					HoursUntilFull:           10,
+					Confidence:               1,
+					HorizonHours:             72,
+					IsExhaustedWithinHorizon: true,
+				},
+				{
+					Mountpoint:    "/boot",
+					Total:         100,
+					Used:          40,
+					UsedPercent:   40,
+					Samples:       2,
+					GrowthPerHour: -1,
+					Confidence:    1.0 / 6,
+					HorizonHours:  72,
+				},
+				{
+					Mountpoint:   "/home",
+					Total:        200,
+					Used:         50,
+					UsedPercent:  25,
+					Samples:      1,
+					HorizonHours: 72,
+				},
+			},
+		},
+		{
+			name:    "success: growing mountpoint full beyond the horizon",
+			horizon: 24 * time.Hour,
+			samples: map[string][]history.Sample{
+				"/": {
+					{Time: 0, Value: 100, Total: 1000},
+					{Time: 36000, Value: 200, Total: 1000},
+				},
+			},
+			wantedData: []rpi.DiskForecast{
+				{
+					Mountpoint:     "/",
+					Total:          1000,
+					Used:           200,
+					UsedPercent:    20,
+					Samples:        2,
+					GrowthPerHour:  10,
+					FullAt:         36000 + 80*3600,
+					HoursUntilFull: 80,
+					Confidence:     1.0 / 6,
+					HorizonHours:   24,
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := sys.DiskForecast{}
+			forecasts, err := s.List(tc.samples, tc.horizon, now)
+			assert.Nil(t, err)
+			assert.Equal(t, len(tc.wantedData), len(forecasts))
+			for i := range tc.wantedData {
+				w, f := tc.wantedData[i], forecasts[i]
+				assert.InDelta(t, w.GrowthPerHour, f.GrowthPerHour, 1e-9)
+				assert.InDelta(t, w.Confidence, f.Confidence, 1e-9)
+				assert.InDelta(t, w.UsedPercent, f.UsedPercent, 1e-9)
+				w.GrowthPerHour, f.GrowthPerHour = 0, 0
+				w.Confidence, f.Confidence = 0, 0
+				w.UsedPercent, f.UsedPercent = 0, 0
+				assert.Equal(t, w, f)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package diskforecast
+
+import (
+	"time"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/history"
+	"github.com/raspibuddy/rpi/pkg/utl/metrics"
+)
+
+// Service represents all DiskForecast application services.
+type Service interface {
+	List(time.Duration, bool) ([]rpi.DiskForecast, error)
+}
+
+// DiskForecast represents a DiskForecast application service.
+type DiskForecast struct {
+	dfsys   DFSYS
+	h       History
+	horizon time.Duration
+}
+
+// DFSYS represents a DiskForecast repository service.
+type DFSYS interface {
+	List(map[string][]history.Sample, time.Duration, time.Time) ([]rpi.DiskForecast, error)
+}
+
+// History represents the mountpoint usage history interface
+type History interface {
+	Samples() map[string][]history.Sample
+}
+
+// Metrics represents the system metrics interface
+type Metrics interface {
+	DiskStats(bool) (map[string][]metrics.DStats, error)
+}
+
+// New creates a DiskForecast application service instance.
+// horizon is the default period within which a mountpoint exhaustion is flagged.
+func New(dfsys DFSYS, h History, horizon time.Duration) *DiskForecast {
+	return &DiskForecast{dfsys: dfsys, h: h, horizon: horizon}
+}
@@ -0,0 +1,41 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/diskforecast"
+)
+
+// HTTP is a struct implementing a diskforecast application service.
+type HTTP struct {
+	svc diskforecast.Service
+}
+
+// NewHTTP creates new diskforecast http service
+func NewHTTP(svc diskforecast.Service, r *echo.Group) {
+	h := HTTP{svc}
+	cr := r.Group("/diskforecasts")
+	cr.GET("", h.list)
+}
+
+func (h *HTTP) list(ctx echo.Context) error {
+	var horizon time.Duration
+	if ctx.QueryParam("horizon") != "" {
+		hours, err := strconv.Atoi(ctx.QueryParam("horizon"))
+		if err != nil || hours <= 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid horizon - should be a positive number of hours")
+		}
+		horizon = time.Duration(hours) * time.Hour
+	}
+
+	onlyExhausted := ctx.QueryParam("exhausted") == "true"
+
+	result, err := h.svc.List(horizon, onlyExhausted)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
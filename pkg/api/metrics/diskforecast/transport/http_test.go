@@ -0,0 +1,101 @@
+package transport_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/diskforecast"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/diskforecast/transport"
+	"github.com/raspibuddy/rpi/pkg/utl/history"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/raspibuddy/rpi/pkg/utl/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	var response []rpi.DiskForecast
+
+	cases := []struct {
+		name         string
+		req          string
+		dfsys        *mocksys.DiskForecast
+		wantedStatus int
+		wantedResp   []rpi.DiskForecast
+	}{
+		{
+			name:         "error: invalid horizon",
+			req:          "?horizon=abc",
+			dfsys:        &mocksys.DiskForecast{},
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: negative horizon",
+			req:          "?horizon=-1",
+			dfsys:        &mocksys.DiskForecast{},
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error: List result is nil",
+			dfsys: &mocksys.DiskForecast{
+				ListFn: func(map[string][]history.Sample, time.Duration, time.Time) ([]rpi.DiskForecast, error) {
+					return nil, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "success: exhausted mountpoints within 24 hours",
+			req:  "?horizon=24&exhausted=true",
+			dfsys: &mocksys.DiskForecast{
+				ListFn: func(_ map[string][]history.Sample, horizon time.Duration, _ time.Time) ([]rpi.DiskForecast, error) {
+					return []rpi.DiskForecast{
+						{Mountpoint: "/", HorizonHours: horizon.Hours()},
+						{Mountpoint: "/boot", HorizonHours: horizon.Hours(), IsExhaustedWithinHorizon: true},
+					}, nil
+				},
+			},
+			wantedStatus: http.StatusOK,
+			wantedResp: []rpi.DiskForecast{
+				{Mountpoint: "/boot", HorizonHours: 24, IsExhaustedWithinHorizon: true},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			s := diskforecast.New(tc.dfsys, history.New(10, ""), 72*time.Hour)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/diskforecasts" + tc.req
+			res, err := http.Get(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer res.Body.Close()
+
+			body, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				panic(err)
+			}
+
+			if tc.wantedResp != nil {
+				if err := json.Unmarshal(body, &response); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, tc.wantedResp, response)
+			}
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
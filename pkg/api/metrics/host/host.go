@@ -18,13 +18,14 @@ func (h *Host) List() (rpi.Host, error) {
 	temp, stdErrT, errT := h.mt.Temperature()
 	serialNumber, stdErrSN, errSN := h.mt.SerialNumber()
 	rpiv, stdErrR, errR := h.mt.RaspModel()
+	revision, stdErrRev, errRev := h.mt.Revision()
 	load, errL := h.mt.LoadAvg()
 	listDev, errD := h.mt.DiskStats(false)
 	netInfo, errNI := h.mt.NetInfo()
 
-	if errNI != nil || errD != nil || errL != nil || errI != nil || errU != nil || errC != nil || errVC != nil || errV != nil || errS != nil || (errSN != nil && stdErrSN != "") || (errT != nil && stdErrT != "") || (errR != nil && stdErrR != "") {
+	if errNI != nil || errD != nil || errL != nil || errI != nil || errU != nil || errC != nil || errVC != nil || errV != nil || errS != nil || (errSN != nil && stdErrSN != "") || (errT != nil && stdErrT != "") || (errR != nil && stdErrR != "") || (errRev != nil && stdErrRev != "") {
 		return rpi.Host{}, echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the host metrics")
 	}
 
-	return h.hsys.List(info, users, cpus, vcores, vMem, sMemPer, load, temp, serialNumber, rpiv, listDev, netInfo)
+	return h.hsys.List(info, users, cpus, vcores, vMem, sMemPer, load, temp, serialNumber, rpiv, revision, listDev, netInfo)
 }
@@ -63,6 +63,9 @@ func TestList(t *testing.T) {
 				RaspModelFn: func() (string, string, error) {
 					return "", "", errors.New("test error info")
 				},
+				RevisionFn: func() (string, string, error) {
+					return "", "", errors.New("test error info")
+				},
 				DiskStatsFn: func(bool) (map[string][]metrics.DStats, error) {
 					return nil, errors.New("test error dstats")
 				},
@@ -106,6 +109,9 @@ func TestList(t *testing.T) {
 				RaspModelFn: func() (string, string, error) {
 					return "pi zero", "", errors.New("test error info")
 				},
+				RevisionFn: func() (string, string, error) {
+					return "c03111", "", errors.New("test error info")
+				},
 				DiskStatsFn: func(bool) (map[string][]metrics.DStats, error) {
 					return nil, errors.New("test error dstats")
 				},
@@ -149,6 +155,9 @@ func TestList(t *testing.T) {
 				RaspModelFn: func() (string, string, error) {
 					return "", "", errors.New("test error info")
 				},
+				RevisionFn: func() (string, string, error) {
+					return "", "", errors.New("test error info")
+				},
 				DiskStatsFn: func(bool) (map[string][]metrics.DStats, error) {
 					return nil, errors.New("test error dstats")
 				},
@@ -204,6 +213,9 @@ func TestList(t *testing.T) {
 				RaspModelFn: func() (string, string, error) {
 					return "", "", errors.New("test error info")
 				},
+				RevisionFn: func() (string, string, error) {
+					return "", "", errors.New("test error info")
+				},
 				DiskStatsFn: func(bool) (map[string][]metrics.DStats, error) {
 					return nil, errors.New("test error dstats")
 				},
@@ -257,6 +269,9 @@ func TestList(t *testing.T) {
 				RaspModelFn: func() (string, string, error) {
 					return "", "", errors.New("test error info")
 				},
+				RevisionFn: func() (string, string, error) {
+					return "", "", errors.New("test error info")
+				},
 				DiskStatsFn: func(bool) (map[string][]metrics.DStats, error) {
 					return nil, errors.New("test error dstats")
 				},
@@ -306,6 +321,9 @@ func TestList(t *testing.T) {
 				RaspModelFn: func() (string, string, error) {
 					return "", "", errors.New("test error info")
 				},
+				RevisionFn: func() (string, string, error) {
+					return "", "", errors.New("test error info")
+				},
 				DiskStatsFn: func(bool) (map[string][]metrics.DStats, error) {
 					return nil, errors.New("test error dstats")
 				},
@@ -383,6 +401,9 @@ func TestList(t *testing.T) {
 				RaspModelFn: func() (string, string, error) {
 					return "pi zero", "", errors.New("test error info")
 				},
+				RevisionFn: func() (string, string, error) {
+					return "c03111", "", errors.New("test error info")
+				},
 				DiskStatsFn: func(bool) (map[string][]metrics.DStats, error) {
 					return map[string][]metrics.DStats{
 						"/dev1": {
@@ -449,6 +470,7 @@ func TestList(t *testing.T) {
 					string,
 					string,
 					string,
+					string,
 					map[string][]metrics.DStats,
 					[]net.InterfaceStat) (rpi.Host, error) {
 					return rpi.Host{
@@ -9,6 +9,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/raspibuddy/rpi"
+	boardsys "github.com/raspibuddy/rpi/pkg/api/metrics/board/platform/sys"
 	disksys "github.com/raspibuddy/rpi/pkg/api/metrics/disk/platform/sys"
 	netsys "github.com/raspibuddy/rpi/pkg/api/metrics/net/platform/sys"
 	"github.com/raspibuddy/rpi/pkg/utl/metrics"
@@ -34,6 +35,7 @@ func (h Host) List(
 	temp string,
 	serialNumber string,
 	rpiv string,
+	revision string,
 	listDev map[string][]metrics.DStats,
 	netInfo []net.InterfaceStat) (rpi.Host, error) {
 	hyperThreading := false
@@ -123,9 +125,16 @@ func (h Host) List(
 		allUsers = append(allUsers, data)
 	}
 
+	// an unknown revision code leaves the board with its revision only
+	board, err := boardsys.DecodeRevision(revision)
+	if err != nil {
+		board = rpi.Board{Revision: revision}
+	}
+
 	result := rpi.Host{
 		ID:                 serialNumber,
 		RaspModel:          rpiv,
+		Board:              board,
 		Hostname:           info.Hostname,
 		UpTime:             info.Uptime,
 		BootTime:           info.BootTime,
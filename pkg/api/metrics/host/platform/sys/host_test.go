@@ -62,6 +62,7 @@ func TestList(t *testing.T) {
 		temp         string
 		serialNumber string
 		rpiv         string
+		revision     string
 		listDev      map[string][]metrics.DStats
 		netInfo      []net.InterfaceStat
 		wantedData   rpi.Host
@@ -384,6 +385,7 @@ func TestList(t *testing.T) {
 			temp:         "temp=20.9.C",
 			serialNumber: "sn1",
 			rpiv:         "pi zero",
+			revision:     "9000c1",
 			wantedData: rpi.Host{
 				ID:                 "sn1",
 				Hostname:           "hostname_test",
@@ -408,6 +410,20 @@ func TestList(t *testing.T) {
 				ActiveVirtualUsers: 0,
 				Temperature:        20.9,
 				RaspModel:          "pi zero",
+				Board: rpi.Board{
+					Revision:           "9000c1",
+					IsNewStyleRevision: true,
+					Model:              "Raspberry Pi Zero W",
+					Type:               "Zero W",
+					SoC:                "BCM2835",
+					RAM:                512,
+					Manufacturer:       "Sony UK",
+					PCBRevision:        "1.1",
+					Capabilities: rpi.BoardCapabilities{
+						Wifi:      true,
+						Bluetooth: true,
+					},
+				},
 				Nets: []rpi.Net{
 					{
 						ID:   1,
@@ -943,6 +959,7 @@ func TestList(t *testing.T) {
 				tc.temp,
 				tc.serialNumber,
 				tc.rpiv,
+				tc.revision,
 				tc.listDev,
 				tc.netInfo)
 
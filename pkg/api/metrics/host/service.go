@@ -35,6 +35,7 @@ type HSYS interface {
 		string,
 		string,
 		string,
+		string,
 		map[string][]metrics.DStats,
 		[]net.InterfaceStat) (rpi.Host, error)
 }
@@ -51,6 +52,7 @@ type Metrics interface {
 	Temperature() (string, string, error)
 	SerialNumber() (string, string, error)
 	RaspModel() (string, string, error)
+	Revision() (string, string, error)
 	DiskStats(bool) (map[string][]metrics.DStats, error)
 	NetInfo() ([]net.InterfaceStat, error)
 }
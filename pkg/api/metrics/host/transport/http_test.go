@@ -52,6 +52,7 @@ func TestList(t *testing.T) {
 					string,
 					string,
 					string,
+					string,
 					map[string][]metrics.DStats,
 					[]net.InterfaceStat) (rpi.Host, error) {
 					return rpi.Host{}, errors.New("test error")
@@ -73,6 +74,7 @@ func TestList(t *testing.T) {
 					string,
 					string,
 					string,
+					string,
 					map[string][]metrics.DStats,
 					[]net.InterfaceStat) (rpi.Host, error) {
 					return rpi.Host{
@@ -324,6 +326,7 @@ func TestListWs(t *testing.T) {
 					string,
 					string,
 					string,
+					string,
 					map[string][]metrics.DStats,
 					[]net.InterfaceStat) (rpi.Host, error) {
 					return rpi.Host{}, errors.New("test error")
@@ -345,6 +348,7 @@ func TestListWs(t *testing.T) {
 					string,
 					string,
 					string,
+					string,
 					map[string][]metrics.DStats,
 					[]net.InterfaceStat) (rpi.Host, error) {
 					return rpi.Host{
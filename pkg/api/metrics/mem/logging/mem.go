@@ -1,6 +1,7 @@
 package mem
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -38,3 +39,33 @@ func (ls *LogService) List(ctx echo.Context) (resp rpi.Mem, err error) {
 	}(time.Now())
 	return ls.Service.List()
 }
+
+// ViewDetail is the logging function attached to the ViewDetail mem services and responsible for logging it out.
+func (ls *LogService) ViewDetail(ctx echo.Context) (resp rpi.MemDetail, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, "request: viewing mem detail", err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ViewDetail()
+}
+
+// ListTop is the logging function attached to the ListTop mem services and responsible for logging it out.
+func (ls *LogService) ListTop(ctx echo.Context, sortBy string, limit int) (resp []rpi.MemConsumer, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: listing top %v processes by %v", limit, sortBy), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.ListTop(sortBy, limit)
+}
@@ -18,3 +18,29 @@ func (m *Mem) List() (rpi.Mem, error) {
 
 	return m.msys.List(smem, vmem)
 }
+
+// ViewDetail populates and returns a detailed breakdown of the memory.
+func (m *Mem) ViewDetail() (rpi.MemDetail, error) {
+	meminfo, err := m.mt.MemInfo()
+	if err != nil {
+		return rpi.MemDetail{}, echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the mem metrics")
+	}
+
+	// the gpu split is only available on a Raspberry Pi with vcgencmd
+	gpu, err := m.mt.GPUMemory()
+	if err != nil {
+		gpu = 0
+	}
+
+	return m.msys.ViewDetail(meminfo, gpu)
+}
+
+// ListTop populates and returns the top memory consuming processes sorted by rss or swap.
+func (m *Mem) ListTop(sortBy string, limit int) ([]rpi.MemConsumer, error) {
+	pmstats, err := m.mt.ProcessesMemory()
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the processes mem metrics")
+	}
+
+	return m.msys.ListTop(pmstats, sortBy, limit)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/raspibuddy/rpi"
 	"github.com/raspibuddy/rpi/pkg/api/metrics/mem"
+	"github.com/raspibuddy/rpi/pkg/utl/metrics"
 	"github.com/raspibuddy/rpi/pkg/utl/mock"
 	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
 	mext "github.com/shirou/gopsutil/mem"
@@ -122,3 +123,114 @@ func TestList(t *testing.T) {
 		})
 	}
 }
+
+func TestViewDetail(t *testing.T) {
+	cases := []struct {
+		name       string
+		metrics    *mock.Metrics
+		msys       *mocksys.Mem
+		wantedData rpi.MemDetail
+		wantedErr  error
+	}{
+		{
+			name: "error: meminfo is nil",
+			metrics: &mock.Metrics{
+				MemInfoFn: func() (map[string]uint64, error) {
+					return nil, errors.New("test error info")
+				},
+			},
+			wantedData: rpi.MemDetail{},
+			wantedErr:  echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the mem metrics"),
+		},
+		{
+			name: "success: gpu split not available",
+			metrics: &mock.Metrics{
+				MemInfoFn: func() (map[string]uint64, error) {
+					return map[string]uint64{"MemTotal": 999}, nil
+				},
+				GPUMemoryFn: func() (uint64, error) {
+					return 0, errors.New("test error info")
+				},
+			},
+			msys: &mocksys.Mem{
+				ViewDetailFn: func(meminfo map[string]uint64, gpu uint64) (rpi.MemDetail, error) {
+					return rpi.MemDetail{Total: meminfo["MemTotal"], GPU: gpu}, nil
+				},
+			},
+			wantedData: rpi.MemDetail{Total: 999},
+			wantedErr:  nil,
+		},
+		{
+			name: "success",
+			metrics: &mock.Metrics{
+				MemInfoFn: func() (map[string]uint64, error) {
+					return map[string]uint64{"MemTotal": 999}, nil
+				},
+				GPUMemoryFn: func() (uint64, error) {
+					return 111, nil
+				},
+			},
+			msys: &mocksys.Mem{
+				ViewDetailFn: func(meminfo map[string]uint64, gpu uint64) (rpi.MemDetail, error) {
+					return rpi.MemDetail{Total: meminfo["MemTotal"], GPU: gpu}, nil
+				},
+			},
+			wantedData: rpi.MemDetail{Total: 999, GPU: 111},
+			wantedErr:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := mem.New(tc.msys, tc.metrics)
+			detail, err := s.ViewDetail()
+			assert.Equal(t, tc.wantedData, detail)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestListTop(t *testing.T) {
+	cases := []struct {
+		name       string
+		metrics    *mock.Metrics
+		msys       *mocksys.Mem
+		wantedData []rpi.MemConsumer
+		wantedErr  error
+	}{
+		{
+			name: "error: processes memory is nil",
+			metrics: &mock.Metrics{
+				ProcessesMemFn: func() ([]metrics.PMStats, error) {
+					return nil, errors.New("test error info")
+				},
+			},
+			wantedData: nil,
+			wantedErr:  echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the processes mem metrics"),
+		},
+		{
+			name: "success",
+			metrics: &mock.Metrics{
+				ProcessesMemFn: func() ([]metrics.PMStats, error) {
+					return []metrics.PMStats{{PID: 1, Name: "systemd", RSS: 999}}, nil
+				},
+			},
+			msys: &mocksys.Mem{
+				ListTopFn: func([]metrics.PMStats, string, int) ([]rpi.MemConsumer, error) {
+					return []rpi.MemConsumer{{PID: 1, Name: "systemd", RSS: 999}}, nil
+				},
+			},
+			wantedData: []rpi.MemConsumer{{PID: 1, Name: "systemd", RSS: 999}},
+			wantedErr:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := mem.New(tc.msys, tc.metrics)
+			top, err := s.ListTop("rss", 10)
+			assert.Equal(t, tc.wantedData, top)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
@@ -1,7 +1,11 @@
 package sys
 
 import (
+	"os/user"
+	"sort"
+
 	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/metrics"
 	"github.com/shirou/gopsutil/mem"
 )
 
@@ -23,3 +27,69 @@ func (m Mem) List(swap mem.SwapMemoryStat, vmem mem.VirtualMemoryStat) (rpi.Mem,
 
 	return result, nil
 }
+
+// ViewDetail returns a detailed breakdown of the memory including the gpu split
+func (m Mem) ViewDetail(meminfo map[string]uint64, gpu uint64) (rpi.MemDetail, error) {
+	result := rpi.MemDetail{
+		Total:        meminfo["MemTotal"],
+		Free:         meminfo["MemFree"],
+		Available:    meminfo["MemAvailable"],
+		Buffers:      meminfo["Buffers"],
+		Cached:       meminfo["Cached"],
+		SwapCached:   meminfo["SwapCached"],
+		Active:       meminfo["Active"],
+		Inactive:     meminfo["Inactive"],
+		Slab:         meminfo["Slab"],
+		SReclaimable: meminfo["SReclaimable"],
+		SUnreclaim:   meminfo["SUnreclaim"],
+		Shmem:        meminfo["Shmem"],
+		Dirty:        meminfo["Dirty"],
+		Writeback:    meminfo["Writeback"],
+		CMATotal:     meminfo["CmaTotal"],
+		CMAFree:      meminfo["CmaFree"],
+		SwapTotal:    meminfo["SwapTotal"],
+		SwapFree:     meminfo["SwapFree"],
+		GPU:          gpu,
+	}
+
+	return result, nil
+}
+
+// ListTop returns the processes using the most memory, sorted by rss or swap
+func (m Mem) ListTop(pmstats []metrics.PMStats, sortBy string, limit int) ([]rpi.MemConsumer, error) {
+	sorted := make([]metrics.PMStats, len(pmstats))
+	copy(sorted, pmstats)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sortBy == "swap" {
+			return sorted[i].Swap > sorted[j].Swap
+		}
+		return sorted[i].RSS > sorted[j].RSS
+	})
+
+	if limit > 0 && limit < len(sorted) {
+		sorted = sorted[:limit]
+	}
+
+	result := []rpi.MemConsumer{}
+	for _, pm := range sorted {
+		result = append(result, rpi.MemConsumer{
+			PID:      pm.PID,
+			Name:     pm.Name,
+			Username: Username(pm.UID),
+			RSS:      pm.RSS,
+			Swap:     pm.Swap,
+		})
+	}
+
+	return result, nil
+}
+
+// Username returns the name of the user owning uid, or uid itself if it cannot be resolved
+func Username(uid string) string {
+	u, err := user.LookupId(uid)
+	if err != nil {
+		return uid
+	}
+	return u.Username
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/raspibuddy/rpi"
 	"github.com/raspibuddy/rpi/pkg/api/metrics/mem"
 	"github.com/raspibuddy/rpi/pkg/api/metrics/mem/platform/sys"
+	"github.com/raspibuddy/rpi/pkg/utl/metrics"
 	mext "github.com/shirou/gopsutil/mem"
 
 	"github.com/stretchr/testify/assert"
@@ -119,3 +120,88 @@ func TestList(t *testing.T) {
 		})
 	}
 }
+
+func TestViewDetail(t *testing.T) {
+	meminfo := map[string]uint64{
+		"MemTotal":     999,
+		"MemFree":      111,
+		"MemAvailable": 222,
+		"Buffers":      11,
+		"Cached":       22,
+		"Slab":         33,
+		"Shmem":        44,
+		"Dirty":        55,
+		"Writeback":    66,
+		"CmaTotal":     77,
+		"CmaFree":      88,
+		"SwapTotal":    100,
+		"SwapFree":     90,
+		"Unknown":      1,
+	}
+
+	s := mem.MSYS(sys.Mem{})
+	detail, err := s.ViewDetail(meminfo, 76)
+	assert.Equal(t, rpi.MemDetail{
+		Total:     999,
+		Free:      111,
+		Available: 222,
+		Buffers:   11,
+		Cached:    22,
+		Slab:      33,
+		Shmem:     44,
+		Dirty:     55,
+		Writeback: 66,
+		CMATotal:  77,
+		CMAFree:   88,
+		SwapTotal: 100,
+		SwapFree:  90,
+		GPU:       76,
+	}, detail)
+	assert.Nil(t, err)
+}
+
+func TestListTop(t *testing.T) {
+	pmstats := []metrics.PMStats{
+		{PID: 10, Name: "a", UID: "0", RSS: 100, Swap: 300},
+		{PID: 20, Name: "b", UID: "0", RSS: 300, Swap: 100},
+		{PID: 30, Name: "c", UID: "0", RSS: 200, Swap: 200},
+	}
+
+	cases := []struct {
+		name       string
+		sortBy     string
+		limit      int
+		wantedPIDs []int32
+	}{
+		{
+			name:       "success: by rss",
+			sortBy:     "rss",
+			limit:      10,
+			wantedPIDs: []int32{20, 30, 10},
+		},
+		{
+			name:       "success: by swap with limit",
+			sortBy:     "swap",
+			limit:      2,
+			wantedPIDs: []int32{10, 30},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := mem.MSYS(sys.Mem{})
+			top, err := s.ListTop(pmstats, tc.sortBy, tc.limit)
+			var pids []int32
+			for _, c := range top {
+				pids = append(pids, c.PID)
+			}
+			assert.Equal(t, tc.wantedPIDs, pids)
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestUsername(t *testing.T) {
+	assert.Equal(t, "root", sys.Username("0"))
+	assert.Equal(t, "123456789", sys.Username("123456789"))
+}
@@ -2,12 +2,15 @@ package mem
 
 import (
 	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/metrics"
 	"github.com/shirou/gopsutil/mem"
 )
 
 // Service represents all MEM application services.
 type Service interface {
 	List() (rpi.Mem, error)
+	ViewDetail() (rpi.MemDetail, error)
+	ListTop(string, int) ([]rpi.MemConsumer, error)
 }
 
 // Mem represents a MEM application service.
@@ -19,12 +22,17 @@ type Mem struct {
 // MSYS represents a MEM repository service.
 type MSYS interface {
 	List(mem.SwapMemoryStat, mem.VirtualMemoryStat) (rpi.Mem, error)
+	ViewDetail(map[string]uint64, uint64) (rpi.MemDetail, error)
+	ListTop([]metrics.PMStats, string, int) ([]rpi.MemConsumer, error)
 }
 
 // Metrics represents the system metrics interface
 type Metrics interface {
 	SwapMemory() (mem.SwapMemoryStat, error)
 	VirtualMemory() (mem.VirtualMemoryStat, error)
+	MemInfo() (map[string]uint64, error)
+	GPUMemory() (uint64, error)
+	ProcessesMemory() ([]metrics.PMStats, error)
 }
 
 // New creates a MEM application service instance.
@@ -2,6 +2,7 @@ package transport
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 	"github.com/raspibuddy/rpi/pkg/api/metrics/mem"
@@ -17,6 +18,8 @@ func NewHTTP(svc mem.Service, r *echo.Group) {
 	h := HTTP{svc}
 	cr := r.Group("/mems")
 	cr.GET("", h.list)
+	cr.GET("/detail", h.viewdetail)
+	cr.GET("/top", h.listtop)
 }
 
 func (h *HTTP) list(ctx echo.Context) error {
@@ -26,3 +29,36 @@ func (h *HTTP) list(ctx echo.Context) error {
 	}
 	return ctx.JSON(http.StatusOK, result)
 }
+
+func (h *HTTP) viewdetail(ctx echo.Context) error {
+	result, err := h.svc.ViewDetail()
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) listtop(ctx echo.Context) error {
+	sortBy := ctx.QueryParam("sort")
+	if sortBy == "" {
+		sortBy = "rss"
+	}
+	if sortBy != "rss" && sortBy != "swap" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid sort - should be rss or swap")
+	}
+
+	limit := 10
+	if l := ctx.QueryParam("limit"); l != "" {
+		v, err := strconv.Atoi(l)
+		if err != nil || v <= 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid limit - should be a positive integer")
+		}
+		limit = v
+	}
+
+	result, err := h.svc.ListTop(sortBy, limit)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/raspibuddy/rpi/pkg/api/metrics/mem"
 	"github.com/raspibuddy/rpi/pkg/api/metrics/mem/transport"
 	"github.com/raspibuddy/rpi/pkg/utl/metrics"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
 	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
 	"github.com/raspibuddy/rpi/pkg/utl/server"
 	mext "github.com/shirou/gopsutil/mem"
@@ -103,3 +104,160 @@ func TestList(t *testing.T) {
 		})
 	}
 }
+
+func TestViewDetail(t *testing.T) {
+	var response rpi.MemDetail
+
+	m := mock.Metrics{
+		MemInfoFn: func() (map[string]uint64, error) {
+			return map[string]uint64{"MemTotal": 999}, nil
+		},
+		GPUMemoryFn: func() (uint64, error) {
+			return 76, nil
+		},
+	}
+
+	cases := []struct {
+		name         string
+		msys         *mocksys.Mem
+		wantedStatus int
+		wantedResp   rpi.MemDetail
+	}{
+		{
+			name: "error: ViewDetail result is nil",
+			msys: &mocksys.Mem{
+				ViewDetailFn: func(map[string]uint64, uint64) (rpi.MemDetail, error) {
+					return rpi.MemDetail{}, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			msys: &mocksys.Mem{
+				ViewDetailFn: func(meminfo map[string]uint64, gpu uint64) (rpi.MemDetail, error) {
+					return rpi.MemDetail{Total: meminfo["MemTotal"], GPU: gpu}, nil
+				},
+			},
+			wantedStatus: http.StatusOK,
+			wantedResp:   rpi.MemDetail{Total: 999, GPU: 76},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			s := mem.New(tc.msys, m)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/mems/detail"
+			res, err := http.Get(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer res.Body.Close()
+
+			body, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				panic(err)
+			}
+
+			if (tc.wantedResp != rpi.MemDetail{}) {
+				if err := json.Unmarshal(body, &response); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, tc.wantedResp, response)
+			}
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestListTop(t *testing.T) {
+	var response []rpi.MemConsumer
+
+	m := mock.Metrics{
+		ProcessesMemFn: func() ([]metrics.PMStats, error) {
+			return []metrics.PMStats{{PID: 1, Name: "systemd", RSS: 999}}, nil
+		},
+	}
+
+	cases := []struct {
+		name         string
+		req          string
+		msys         *mocksys.Mem
+		wantedStatus int
+		wantedResp   []rpi.MemConsumer
+	}{
+		{
+			name:         "error: invalid sort",
+			req:          "?sort=cpu",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: invalid limit",
+			req:          "?limit=-1",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error: ListTop result is nil",
+			req:  "",
+			msys: &mocksys.Mem{
+				ListTopFn: func([]metrics.PMStats, string, int) ([]rpi.MemConsumer, error) {
+					return nil, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			req:  "?sort=swap&limit=1",
+			msys: &mocksys.Mem{
+				ListTopFn: func(pmstats []metrics.PMStats, sortBy string, limit int) ([]rpi.MemConsumer, error) {
+					if sortBy != "swap" || limit != 1 {
+						return nil, errors.New("test error")
+					}
+					return []rpi.MemConsumer{{PID: 1, Name: "systemd", RSS: 999}}, nil
+				},
+			},
+			wantedStatus: http.StatusOK,
+			wantedResp:   []rpi.MemConsumer{{PID: 1, Name: "systemd", RSS: 999}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			s := mem.New(tc.msys, m)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/mems/top" + tc.req
+			res, err := http.Get(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer res.Body.Close()
+
+			body, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				panic(err)
+			}
+
+			if tc.wantedResp != nil {
+				if err := json.Unmarshal(body, &response); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, tc.wantedResp, response)
+			}
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
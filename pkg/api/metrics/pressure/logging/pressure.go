@@ -0,0 +1,56 @@
+package pressure
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/pressure"
+)
+
+// New creates a new pressure logging service instance.
+func New(svc pressure.Service, logger rpi.Logger) *LogService {
+	return &LogService{
+		Service: svc,
+		logger:  logger,
+	}
+}
+
+// LogService represents a pressure logging service.
+type LogService struct {
+	pressure.Service
+	logger rpi.Logger
+}
+
+const name = "pressure"
+
+// List is the logging function attached to the List pressure services and responsible for logging it out.
+func (ls *LogService) List(ctx echo.Context) (resp []rpi.Pressure, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, "request: listing pressures", err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.List()
+}
+
+// View is the logging function attached to the View pressure services and responsible for logging it out.
+func (ls *LogService) View(ctx echo.Context, resource string) (resp rpi.Pressure, err error) {
+	defer func(begin time.Time) {
+		ls.logger.Log(
+			ctx,
+			name, fmt.Sprintf("request: viewing %v pressure", resource), err,
+			map[string]interface{}{
+				"resp": resp,
+				"took": time.Since(begin),
+			},
+		)
+	}(time.Now())
+	return ls.Service.View(resource)
+}
@@ -0,0 +1,57 @@
+package sys
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/metrics"
+)
+
+// Resources lists the resources tracked by the pressure stall information, in display order
+var Resources = []string{"cpu", "memory", "io"}
+
+// Pressure represents a Pressure entity on the current system.
+type Pressure struct{}
+
+// List returns the pressure stall information of every available resource
+func (p Pressure) List(pstats map[string]metrics.PStats) ([]rpi.Pressure, error) {
+	result := []rpi.Pressure{}
+
+	for _, r := range Resources {
+		if ps, ok := pstats[r]; ok {
+			result = append(result, Stall(r, ps))
+		}
+	}
+
+	return result, nil
+}
+
+// View returns the pressure stall information of a resource
+func (p Pressure) View(resource string, pstats map[string]metrics.PStats) (rpi.Pressure, error) {
+	ps, ok := pstats[resource]
+	if !ok {
+		return rpi.Pressure{}, echo.NewHTTPError(http.StatusNotFound, "resource does not exist")
+	}
+
+	return Stall(resource, ps), nil
+}
+
+// Stall converts the pressure stats of a resource into a Pressure object
+func Stall(resource string, ps metrics.PStats) rpi.Pressure {
+	return rpi.Pressure{
+		Resource: resource,
+		Some: rpi.PressureStall{
+			Avg10:  ps.Some.Avg10,
+			Avg60:  ps.Some.Avg60,
+			Avg300: ps.Some.Avg300,
+			Total:  ps.Some.Total,
+		},
+		Full: rpi.PressureStall{
+			Avg10:  ps.Full.Avg10,
+			Avg60:  ps.Full.Avg60,
+			Avg300: ps.Full.Avg300,
+			Total:  ps.Full.Total,
+		},
+	}
+}
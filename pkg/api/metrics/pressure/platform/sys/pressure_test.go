@@ -0,0 +1,71 @@
+package sys_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/pressure/platform/sys"
+	"github.com/raspibuddy/rpi/pkg/utl/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+var pstats = map[string]metrics.PStats{
+	"io": {
+		Some: metrics.PSIStats{Avg10: 12.5, Avg60: 8.25, Avg300: 2, Total: 123456},
+		Full: metrics.PSIStats{Avg10: 10, Avg60: 6, Avg300: 1.5, Total: 100000},
+	},
+	"cpu": {
+		Some: metrics.PSIStats{Avg10: 0.5, Total: 42},
+	},
+}
+
+var io = rpi.Pressure{
+	Resource: "io",
+	Some:     rpi.PressureStall{Avg10: 12.5, Avg60: 8.25, Avg300: 2, Total: 123456},
+	Full:     rpi.PressureStall{Avg10: 10, Avg60: 6, Avg300: 1.5, Total: 100000},
+}
+
+var cpu = rpi.Pressure{
+	Resource: "cpu",
+	Some:     rpi.PressureStall{Avg10: 0.5, Total: 42},
+}
+
+func TestList(t *testing.T) {
+	s := sys.Pressure{}
+	pressures, err := s.List(pstats)
+	assert.Equal(t, []rpi.Pressure{cpu, io}, pressures)
+	assert.Nil(t, err)
+}
+
+func TestView(t *testing.T) {
+	cases := []struct {
+		name       string
+		resource   string
+		wantedData rpi.Pressure
+		wantedErr  error
+	}{
+		{
+			name:       "error: resource does not exist",
+			resource:   "memory",
+			wantedData: rpi.Pressure{},
+			wantedErr:  echo.NewHTTPError(http.StatusNotFound, "resource does not exist"),
+		},
+		{
+			name:       "success",
+			resource:   "io",
+			wantedData: io,
+			wantedErr:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := sys.Pressure{}
+			p, err := s.View(tc.resource, pstats)
+			assert.Equal(t, tc.wantedData, p)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
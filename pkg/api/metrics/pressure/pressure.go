@@ -0,0 +1,28 @@
+package pressure
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+)
+
+// List populates and returns an array of Pressure models.
+func (p *Pressure) List() ([]rpi.Pressure, error) {
+	pstats, err := p.m.PressureStats()
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the pressure metrics - psi=1 may be missing from the kernel command line")
+	}
+
+	return p.psys.List(pstats)
+}
+
+// View populates and returns the Pressure model of one resource.
+func (p *Pressure) View(resource string) (rpi.Pressure, error) {
+	pstats, err := p.m.PressureStats()
+	if err != nil {
+		return rpi.Pressure{}, echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the pressure metrics - psi=1 may be missing from the kernel command line")
+	}
+
+	return p.psys.View(resource, pstats)
+}
@@ -0,0 +1,108 @@
+package pressure_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/pressure"
+	"github.com/raspibuddy/rpi/pkg/utl/metrics"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	cases := []struct {
+		name       string
+		metrics    mock.Metrics
+		psys       mocksys.Pressure
+		wantedData []rpi.Pressure
+		wantedErr  error
+	}{
+		{
+			name: "error: pressure stats",
+			metrics: mock.Metrics{
+				PressureStatsFn: func() (map[string]metrics.PStats, error) {
+					return nil, errors.New("test error")
+				},
+			},
+			wantedData: nil,
+			wantedErr:  echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the pressure metrics - psi=1 may be missing from the kernel command line"),
+		},
+		{
+			name: "success",
+			metrics: mock.Metrics{
+				PressureStatsFn: func() (map[string]metrics.PStats, error) {
+					return map[string]metrics.PStats{"cpu": {Some: metrics.PSIStats{Avg10: 1.5}}}, nil
+				},
+			},
+			psys: mocksys.Pressure{
+				ListFn: func(map[string]metrics.PStats) ([]rpi.Pressure, error) {
+					return []rpi.Pressure{{Resource: "cpu", Some: rpi.PressureStall{Avg10: 1.5}}}, nil
+				},
+			},
+			wantedData: []rpi.Pressure{{Resource: "cpu", Some: rpi.PressureStall{Avg10: 1.5}}},
+			wantedErr:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := pressure.New(&tc.psys, tc.metrics)
+			pressures, err := s.List()
+			assert.Equal(t, tc.wantedData, pressures)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestView(t *testing.T) {
+	cases := []struct {
+		name       string
+		resource   string
+		metrics    mock.Metrics
+		psys       mocksys.Pressure
+		wantedData rpi.Pressure
+		wantedErr  error
+	}{
+		{
+			name:     "error: pressure stats",
+			resource: "io",
+			metrics: mock.Metrics{
+				PressureStatsFn: func() (map[string]metrics.PStats, error) {
+					return nil, errors.New("test error")
+				},
+			},
+			wantedData: rpi.Pressure{},
+			wantedErr:  echo.NewHTTPError(http.StatusInternalServerError, "could not retrieve the pressure metrics - psi=1 may be missing from the kernel command line"),
+		},
+		{
+			name:     "success",
+			resource: "io",
+			metrics: mock.Metrics{
+				PressureStatsFn: func() (map[string]metrics.PStats, error) {
+					return map[string]metrics.PStats{"io": {Full: metrics.PSIStats{Total: 99}}}, nil
+				},
+			},
+			psys: mocksys.Pressure{
+				ViewFn: func(resource string, _ map[string]metrics.PStats) (rpi.Pressure, error) {
+					return rpi.Pressure{Resource: resource, Full: rpi.PressureStall{Total: 99}}, nil
+				},
+			},
+			wantedData: rpi.Pressure{Resource: "io", Full: rpi.PressureStall{Total: 99}},
+			wantedErr:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := pressure.New(&tc.psys, tc.metrics)
+			p, err := s.View(tc.resource)
+			assert.Equal(t, tc.wantedData, p)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
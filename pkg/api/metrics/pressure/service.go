@@ -0,0 +1,34 @@
+package pressure
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/metrics"
+)
+
+// Service represents all Pressure application services.
+type Service interface {
+	List() ([]rpi.Pressure, error)
+	View(string) (rpi.Pressure, error)
+}
+
+// Pressure represents a Pressure application service.
+type Pressure struct {
+	psys PSYS
+	m    Metrics
+}
+
+// PSYS represents a Pressure repository service.
+type PSYS interface {
+	List(map[string]metrics.PStats) ([]rpi.Pressure, error)
+	View(string, map[string]metrics.PStats) (rpi.Pressure, error)
+}
+
+// Metrics represents the system metrics interface
+type Metrics interface {
+	PressureStats() (map[string]metrics.PStats, error)
+}
+
+// New creates a Pressure application service instance.
+func New(psys PSYS, m Metrics) *Pressure {
+	return &Pressure{psys: psys, m: m}
+}
@@ -0,0 +1,42 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/pressure"
+)
+
+// HTTP is a struct implementing a pressure application service.
+type HTTP struct {
+	svc pressure.Service
+}
+
+// NewHTTP creates new pressure http service
+func NewHTTP(svc pressure.Service, r *echo.Group) {
+	h := HTTP{svc}
+	cr := r.Group("/pressures")
+	cr.GET("", h.list)
+	cr.GET("/:resource", h.view)
+}
+
+func (h *HTTP) list(ctx echo.Context) error {
+	result, err := h.svc.List()
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h *HTTP) view(ctx echo.Context) error {
+	resource := ctx.Param("resource")
+	if resource != "cpu" && resource != "memory" && resource != "io" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request due to an invalid resource - should be cpu, memory or io")
+	}
+
+	result, err := h.svc.View(resource)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
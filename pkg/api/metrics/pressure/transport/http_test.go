@@ -0,0 +1,157 @@
+package transport_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/pressure"
+	"github.com/raspibuddy/rpi/pkg/api/metrics/pressure/transport"
+	"github.com/raspibuddy/rpi/pkg/utl/metrics"
+	"github.com/raspibuddy/rpi/pkg/utl/mock"
+	"github.com/raspibuddy/rpi/pkg/utl/mock/mocksys"
+	"github.com/raspibuddy/rpi/pkg/utl/server"
+	"github.com/stretchr/testify/assert"
+)
+
+var m = mock.Metrics{
+	PressureStatsFn: func() (map[string]metrics.PStats, error) {
+		return map[string]metrics.PStats{"cpu": {}}, nil
+	},
+}
+
+func TestList(t *testing.T) {
+	var response []rpi.Pressure
+
+	cases := []struct {
+		name         string
+		psys         *mocksys.Pressure
+		wantedStatus int
+		wantedResp   []rpi.Pressure
+	}{
+		{
+			name: "error: List result is nil",
+			psys: &mocksys.Pressure{
+				ListFn: func(map[string]metrics.PStats) ([]rpi.Pressure, error) {
+					return nil, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			psys: &mocksys.Pressure{
+				ListFn: func(map[string]metrics.PStats) ([]rpi.Pressure, error) {
+					return []rpi.Pressure{{Resource: "cpu", Some: rpi.PressureStall{Avg10: 1.5}}}, nil
+				},
+			},
+			wantedStatus: http.StatusOK,
+			wantedResp:   []rpi.Pressure{{Resource: "cpu", Some: rpi.PressureStall{Avg10: 1.5}}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			s := pressure.New(tc.psys, m)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/pressures"
+			res, err := http.Get(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+
+			body, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				panic(err)
+			}
+
+			if tc.wantedResp != nil {
+				if err := json.Unmarshal(body, &response); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, tc.wantedResp, response)
+			}
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestView(t *testing.T) {
+	var response rpi.Pressure
+
+	cases := []struct {
+		name         string
+		req          string
+		psys         *mocksys.Pressure
+		wantedStatus int
+		wantedResp   rpi.Pressure
+	}{
+		{
+			name:         "error: invalid resource",
+			req:          "disk",
+			wantedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error: View result is nil",
+			req:  "cpu",
+			psys: &mocksys.Pressure{
+				ViewFn: func(string, map[string]metrics.PStats) (rpi.Pressure, error) {
+					return rpi.Pressure{}, errors.New("test error")
+				},
+			},
+			wantedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			req:  "cpu",
+			psys: &mocksys.Pressure{
+				ViewFn: func(resource string, _ map[string]metrics.PStats) (rpi.Pressure, error) {
+					return rpi.Pressure{Resource: resource, Some: rpi.PressureStall{Avg10: 1.5}}, nil
+				},
+			},
+			wantedStatus: http.StatusOK,
+			wantedResp:   rpi.Pressure{Resource: "cpu", Some: rpi.PressureStall{Avg10: 1.5}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := server.New()
+			rg := r.Group("")
+			s := pressure.New(tc.psys, m)
+			transport.NewHTTP(s, rg)
+			ts := httptest.NewServer(r)
+
+			defer ts.Close()
+			path := ts.URL + "/pressures/" + tc.req
+			res, err := http.Get(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+
+			body, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				panic(err)
+			}
+
+			if tc.wantedResp.Resource != "" {
+				if err := json.Unmarshal(body, &response); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, tc.wantedResp, response)
+			}
+			assert.Equal(t, tc.wantedStatus, res.StatusCode)
+		})
+	}
+}
@@ -1,21 +1,29 @@
 package actions
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"net"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/bootconfig"
 	"github.com/raspibuddy/rpi/pkg/utl/constants"
 	"github.com/raspibuddy/rpi/pkg/utl/infos"
+	"github.com/raspibuddy/rpi/pkg/utl/wpaconf"
 	"github.com/shirou/gopsutil/host"
 )
 
@@ -80,6 +88,18 @@ const (
 	// GpuMemRegex regex
 	GpuMemRegex = `^\s*gpu_mem\s*=.*`
 
+	// CPUListRegex is the regex used to validate a cpu list (ex: 0,2-3)
+	CPUListRegex = `^[0-9]+(-[0-9]+)?(,[0-9]+(-[0-9]+)?)*$`
+
+	// UnitNameRegex is the regex used to validate a systemd service unit name
+	UnitNameRegex = `^[a-zA-Z0-9@:._\-]+\.service$`
+
+	// NTPServerRegex is the regex used to validate a ntp server (host name or ip address)
+	NTPServerRegex = `^[a-zA-Z0-9:._\-]+$`
+
+	// OverclockSettingRegex is the regex used to validate the section, key and value of an overclock setting
+	OverclockSettingRegex = `^[a-zA-Z0-9:._+\-]+$`
+
 	// GpuMemRegex regex
 	// GpuMemCameraRegex = `^\s*gpu_mem\s*=\s*([0-1]\s*[0-2]\s*[0-7]\s*.*|\s*)$`
 
@@ -110,6 +130,24 @@ const (
 	// StopUserSession is the name of the disconnect user action
 	StopUserSession = "stop_user_sessions"
 
+	// SignalProcess is the name of the signal process exec
+	SignalProcess = "signal_process"
+
+	// ReniceProcess is the name of the renice process exec
+	ReniceProcess = "renice_process"
+
+	// IoniceProcess is the name of the ionice process exec
+	IoniceProcess = "ionice_process"
+
+	// SetProcessAffinity is the name of the set process cpu affinity exec
+	SetProcessAffinity = "set_process_affinity"
+
+	// SuspendProcess is the name of the suspend process action
+	SuspendProcess = "suspend_process"
+
+	// ResumeProcess is the name of the resume process action
+	ResumeProcess = "resume_process"
+
 	// ChangeHostname is the name of the change username action
 	ChangeHostname = "change_hostname"
 
@@ -208,6 +246,159 @@ const (
 
 	// ConfirmVPNAuthentication is the name of the action to confirm if VPN authentication workded or not
 	ConfirmVPNAuthentication = "confirm_vpn_auth"
+
+	// CPUGovernor is the name of the cpu governor method
+	CPUGovernor = "cpu_governor"
+
+	// SetCPUGovernor is the name of the set cpu governor exec
+	SetCPUGovernor = "set_cpu_governor"
+
+	// PersistCPUGovernor is the name of the persist cpu governor at boot exec
+	PersistCPUGovernor = "persist_cpu_governor"
+
+	// UnitControl is the name of the systemd unit control method
+	UnitControl = "unit_control"
+
+	// ManageUnit is the name of the manage systemd unit exec
+	ManageUnit = "manage_unit"
+
+	// ImportConfiguration is the name of the configuration import method
+	ImportConfiguration = "import_configuration"
+
+	// RestoreFile is the name of the restore file exec
+	RestoreFile = "restore_file"
+
+	// BootConfig is the name of the boot config edition method
+	BootConfig = "boot_config"
+
+	// EditBootConfig is the name of the edit boot config exec
+	EditBootConfig = "edit_boot_config"
+
+	// Overlay is the name of the device tree overlay method
+	Overlay = "overlay"
+
+	// DtParam is the name of the device tree parameter method
+	DtParam = "dtparam"
+
+	// Overclock is the name of the overclock profile method
+	Overclock = "overclock"
+
+	// RestoreOverclock is the name of the restore overclock method
+	RestoreOverclock = "restore_overclock"
+
+	// SaveOverclockState is the name of the save overclock state exec
+	SaveOverclockState = "save_overclock_state"
+
+	// PersistOverclockGuard is the name of the persist overclock boot guard exec
+	PersistOverclockGuard = "persist_overclock_guard"
+
+	// ClearOverclockState is the name of the clear overclock state exec
+	ClearOverclockState = "clear_overclock_state"
+
+	// GPUMemory is the name of the gpu memory split method
+	GPUMemory = "gpu_memory"
+
+	// Timezone is the name of the change timezone method
+	Timezone = "timezone"
+
+	// Locale is the name of the change locale method
+	Locale = "locale"
+
+	// Keyboard is the name of the configure keyboard method
+	Keyboard = "keyboard"
+
+	// NTPServers is the name of the set ntp servers method
+	NTPServers = "ntp_servers"
+
+	// TimeSync is the name of the enable or disable time synchronization method
+	TimeSync = "time_sync"
+
+	// SetTime is the name of the set time method
+	SetTime = "set_time"
+
+	// SetNTPServers is the name of the set ntp servers exec
+	SetNTPServers = "set_ntp_servers"
+
+	// WifiNetwork is the name of the wifi network method
+	WifiNetwork = "wifi_network"
+
+	// WifiScan is the name of the wifi scan method
+	WifiScan = "wifi_scan"
+
+	// WifiSelect is the name of the select wifi network method
+	WifiSelect = "wifi_select"
+
+	// WifiReconfigure is the name of the wifi reconfigure method
+	WifiReconfigure = "wifi_reconfigure"
+
+	// EditWpaSupplicant is the name of the edit wpa_supplicant.conf exec
+	EditWpaSupplicant = "edit_wpa_supplicant"
+
+	// NetworkConfig is the name of the configure network interface method
+	NetworkConfig = "network_config"
+
+	// ApplyNetworkConfig is the name of the apply network config exec
+	ApplyNetworkConfig = "apply_network_config"
+
+	// EnableHotspot is the name of the enable hotspot method
+	EnableHotspot = "enable_hotspot"
+
+	// DisableHotspot is the name of the disable hotspot method
+	DisableHotspot = "disable_hotspot"
+
+	// FirewallRules is the name of the apply firewall rules method
+	FirewallRules = "firewall_rules"
+
+	// ConfirmFirewall is the name of the confirm firewall rules method
+	ConfirmFirewall = "confirm_firewall"
+
+	// AuthorizedKeys is the name of the add or remove authorized key method
+	AuthorizedKeys = "authorized_keys"
+
+	// SSHOptions is the name of the set sshd options method
+	SSHOptions = "ssh_options"
+
+	// RegenerateHostKeys is the name of the regenerate ssh host keys method
+	RegenerateHostKeys = "regenerate_host_keys"
+
+	// UserGroups is the name of the add or remove user to a group method
+	UserGroups = "user_groups"
+
+	// LockUser is the name of the lock user method
+	LockUser = "lock_user"
+
+	// UnlockUser is the name of the unlock user method
+	UnlockUser = "unlock_user"
+
+	// PasswordExpiry is the name of the set password expiry method
+	PasswordExpiry = "password_expiry"
+
+	// ChangeShell is the name of the change user shell method
+	ChangeShell = "change_shell"
+)
+
+// files kept in the overclock state directory
+const (
+	// OverclockStateFile holds the profile applied and the previous values, as json
+	OverclockStateFile = "state.json"
+
+	// OverclockSnapshotFile is a copy of config.txt taken before applying a profile
+	OverclockSnapshotFile = "config.txt"
+
+	// OverclockPendingFile exists until the board booted healthy with the profile applied
+	OverclockPendingFile = "pending"
+
+	// OverclockBootsFile counts the boots attempted while the profile is pending
+	OverclockBootsFile = "boots"
+
+	// OverclockSuccessFile is the boot-success marker, holding its timestamp
+	OverclockSuccessFile = "success"
+
+	// OverclockRestoredFile holds the reason why the previous values were restored
+	OverclockRestoredFile = "restored"
+
+	// OverclockGuardFile is the script run at boot by the guard unit
+	OverclockGuardFile = "guard.sh"
 )
 
 var (
@@ -216,6 +407,48 @@ var (
 
 	// RepTypeEntireLine is a flag meaning all occurrences of an entire file line should be replaced
 	RepTypeEntireLine = "entire_line"
+
+	// Signals lists the signals that can be sent to a process
+	Signals = map[string]syscall.Signal{
+		"HUP":  syscall.SIGHUP,
+		"INT":  syscall.SIGINT,
+		"QUIT": syscall.SIGQUIT,
+		"KILL": syscall.SIGKILL,
+		"USR1": syscall.SIGUSR1,
+		"USR2": syscall.SIGUSR2,
+		"TERM": syscall.SIGTERM,
+		"STOP": syscall.SIGSTOP,
+		"CONT": syscall.SIGCONT,
+		"TSTP": syscall.SIGTSTP,
+	}
+
+	// IoniceClasses lists the io scheduling classes accepted by ionice
+	IoniceClasses = map[string]string{
+		"realtime":    "1",
+		"best-effort": "2",
+		"idle":        "3",
+	}
+
+	// CPUGovernors lists the cpu frequency governors that can be set
+	CPUGovernors = []string{"ondemand", "performance", "powersave"}
+
+	// UnitActions lists the systemctl commands that can be run against a unit
+	UnitActions = []string{"enable", "disable", "restart", "reload", "mask", "unmask"}
+
+	// OverlayActions lists the actions that can be applied to a device tree overlay
+	OverlayActions = []string{"add", "update", "remove"}
+
+	// DtParamActions lists the actions that can be applied to a device tree parameter
+	DtParamActions = []string{"set", "remove"}
+
+	// OverclockProfiles lists the overclock profiles that can be applied
+	OverclockProfiles = []string{"none", "modest", "high", "custom"}
+
+	// OverclockKeys lists the config.txt keys set by the overclock profiles
+	OverclockKeys = []string{"arm_freq", "over_voltage", "gpu_freq", "force_turbo"}
+
+	// GPUMemoryKeys lists the config.txt keys setting the gpu memory split
+	GPUMemoryKeys = []string{"gpu_mem", "gpu_mem_256", "gpu_mem_512", "gpu_mem_1024"}
 )
 
 // Service represents several system scripts.
@@ -356,6 +589,1180 @@ func (s Service) KillProcessByName(arg interface{}) (rpi.Exec, error) {
 	}, nil
 }
 
+// SP is the argument when sending a signal to a process
+type SP struct {
+	Pid    string
+	Signal string
+}
+
+// SignalProcess sends a signal (HUP, STOP, CONT, etc.) to a given process
+func (s Service) SignalProcess(arg interface{}) (rpi.Exec, error) {
+	var pid string
+	var signal string
+
+	switch v := arg.(type) {
+	case SP:
+		pid = v.Pid
+		signal = v.Signal
+	case OtherParams:
+		pid = arg.(OtherParams).Value["pid"]
+		signal = arg.(OtherParams).Value["signal"]
+	default:
+		return rpi.Exec{ExitStatus: 1}, &Error{[]string{"pid", "signal"}}
+	}
+
+	// execution start time
+	startTime := uint64(time.Now().Unix())
+	exitStatus := 0
+	var stdErr string
+
+	pidNum, err := strconv.Atoi(pid)
+	sig, isSignal := Signals[strings.TrimPrefix(strings.ToUpper(signal), "SIG")]
+
+	// pid 0 and negative pids target process groups, -1 every process
+	if err != nil {
+		exitStatus = 1
+		stdErr = "pid is not an int"
+	} else if pidNum <= 0 {
+		exitStatus = 1
+		stdErr = "pid should be greater than 0"
+	} else if !isSignal {
+		exitStatus = 1
+		stdErr = "signal is not supported"
+	} else if e := syscall.Kill(pidNum, sig); e != nil {
+		exitStatus = 1
+		stdErr = fmt.Sprint(e)
+	}
+
+	// execution end time
+	endTime := uint64(time.Now().Unix())
+
+	return rpi.Exec{
+		Name:       SignalProcess,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		ExitStatus: uint8(exitStatus),
+		Stderr:     stdErr,
+	}, nil
+}
+
+// RNP is the argument when changing the priority of a process
+type RNP struct {
+	Pid      string
+	Priority string
+}
+
+// ReniceProcess changes the scheduling priority (nice value) of a given process
+func (s Service) ReniceProcess(arg interface{}) (rpi.Exec, error) {
+	var pid string
+	var priority string
+
+	switch v := arg.(type) {
+	case RNP:
+		pid = v.Pid
+		priority = v.Priority
+	case OtherParams:
+		pid = arg.(OtherParams).Value["pid"]
+		priority = arg.(OtherParams).Value["priority"]
+	default:
+		return rpi.Exec{ExitStatus: 1}, &Error{[]string{"pid", "priority"}}
+	}
+
+	// execution start time
+	startTime := uint64(time.Now().Unix())
+	exitStatus := 0
+	var stdErr string
+
+	pidNum, errP := strconv.Atoi(pid)
+	priorityNum, errN := strconv.Atoi(priority)
+
+	// pid 0 targets the calling process
+	if errP != nil {
+		exitStatus = 1
+		stdErr = "pid is not an int"
+	} else if pidNum <= 0 {
+		exitStatus = 1
+		stdErr = "pid should be greater than 0"
+	} else if errN != nil || priorityNum < -20 || priorityNum > 19 {
+		exitStatus = 1
+		stdErr = "priority should be an int between -20 and 19"
+	} else if e := syscall.Setpriority(syscall.PRIO_PROCESS, pidNum, priorityNum); e != nil {
+		exitStatus = 1
+		stdErr = fmt.Sprint(e)
+	}
+
+	// execution end time
+	endTime := uint64(time.Now().Unix())
+
+	return rpi.Exec{
+		Name:       ReniceProcess,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		ExitStatus: uint8(exitStatus),
+		Stderr:     stdErr,
+	}, nil
+}
+
+// INP is the argument when changing the io scheduling of a process
+type INP struct {
+	Pid   string
+	Class string
+	Level string
+}
+
+// IoniceProcess changes the io scheduling class and level of a given process
+func (s Service) IoniceProcess(arg interface{}) (rpi.Exec, error) {
+	var pid string
+	var class string
+	var level string
+
+	switch v := arg.(type) {
+	case INP:
+		pid = v.Pid
+		class = v.Class
+		level = v.Level
+	case OtherParams:
+		pid = arg.(OtherParams).Value["pid"]
+		class = arg.(OtherParams).Value["class"]
+		level = arg.(OtherParams).Value["level"]
+	default:
+		return rpi.Exec{ExitStatus: 1}, &Error{[]string{"pid", "class", "level"}}
+	}
+
+	// execution start time
+	startTime := uint64(time.Now().Unix())
+	exitStatus := 0
+	var stdErr string
+
+	classNum, isClass := IoniceClasses[class]
+
+	if pidNum, err := strconv.Atoi(pid); err != nil {
+		exitStatus = 1
+		stdErr = "pid is not an int"
+	} else if pidNum <= 0 {
+		exitStatus = 1
+		stdErr = "pid should be greater than 0"
+	} else if !isClass {
+		exitStatus = 1
+		stdErr = "class should be realtime, best-effort or idle"
+	} else {
+		args := []string{"-c", classNum, "-p", pid}
+
+		// the idle class does not take any level
+		if class != "idle" {
+			if levelNum, err := strconv.Atoi(level); err != nil || levelNum < 0 || levelNum > 7 {
+				exitStatus = 1
+				stdErr = "level should be an int between 0 and 7"
+			} else {
+				args = []string{"-c", classNum, "-n", level, "-p", pid}
+			}
+		}
+
+		if exitStatus == 0 {
+			if _, err := exec.Command("ionice", args...).Output(); err != nil {
+				exitStatus = 1
+				stdErr = fmt.Sprint(err)
+			}
+		}
+	}
+
+	// execution end time
+	endTime := uint64(time.Now().Unix())
+
+	return rpi.Exec{
+		Name:       IoniceProcess,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		ExitStatus: uint8(exitStatus),
+		Stderr:     stdErr,
+	}, nil
+}
+
+// SPA is the argument when pinning a process to one or several cpus
+type SPA struct {
+	Pid     string
+	CPUList string
+}
+
+// SetProcessAffinity pins all the threads of a given process to a cpu list (ex: 0,2-3)
+func (s Service) SetProcessAffinity(arg interface{}) (rpi.Exec, error) {
+	var pid string
+	var cpuList string
+
+	switch v := arg.(type) {
+	case SPA:
+		pid = v.Pid
+		cpuList = v.CPUList
+	case OtherParams:
+		pid = arg.(OtherParams).Value["pid"]
+		cpuList = arg.(OtherParams).Value["cpuList"]
+	default:
+		return rpi.Exec{ExitStatus: 1}, &Error{[]string{"pid", "cpuList"}}
+	}
+
+	// execution start time
+	startTime := uint64(time.Now().Unix())
+	exitStatus := 0
+	var stdErr string
+
+	re := regexp.MustCompile(CPUListRegex)
+
+	if pidNum, err := strconv.Atoi(pid); err != nil {
+		exitStatus = 1
+		stdErr = "pid is not an int"
+	} else if pidNum <= 0 {
+		exitStatus = 1
+		stdErr = "pid should be greater than 0"
+	} else if !re.MatchString(cpuList) {
+		exitStatus = 1
+		stdErr = "cpu list badly formatted"
+	} else if _, err := exec.Command("taskset", "-a", "-p", "-c", cpuList, pid).Output(); err != nil {
+		exitStatus = 1
+		stdErr = fmt.Sprint(err)
+	}
+
+	// execution end time
+	endTime := uint64(time.Now().Unix())
+
+	return rpi.Exec{
+		Name:       SetProcessAffinity,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		ExitStatus: uint8(exitStatus),
+		Stderr:     stdErr,
+	}, nil
+}
+
+// SCG is the argument when setting the cpu frequency governor
+type SCG struct {
+	Governor string
+	Path     string
+}
+
+// SetCPUGovernor sets the frequency governor of every cpu core (path is the sysfs cpu directory)
+func (s Service) SetCPUGovernor(arg interface{}) (rpi.Exec, error) {
+	var governor string
+	var path string
+
+	switch v := arg.(type) {
+	case SCG:
+		governor = v.Governor
+		path = v.Path
+	case OtherParams:
+		governor = arg.(OtherParams).Value["governor"]
+		path = arg.(OtherParams).Value["path"]
+	default:
+		return rpi.Exec{ExitStatus: 1}, &Error{[]string{"governor", "path"}}
+	}
+
+	// execution start time
+	startTime := uint64(time.Now().Unix())
+	exitStatus := 0
+	var stdErr string
+
+	files, _ := filepath.Glob(path + "/cpu[0-9]*/cpufreq/scaling_governor")
+
+	if !infos.StringItemExists(CPUGovernors, governor) {
+		exitStatus = 1
+		stdErr = "governor is not supported"
+	} else if len(files) == 0 {
+		exitStatus = 1
+		stdErr = "no cpufreq scaling governor found"
+	} else {
+		for _, f := range files {
+			if err := ioutil.WriteFile(f, []byte(governor), 0644); err != nil {
+				exitStatus = 1
+				stdErr = fmt.Sprint(err)
+				break
+			}
+		}
+	}
+
+	// execution end time
+	endTime := uint64(time.Now().Unix())
+
+	return rpi.Exec{
+		Name:       SetCPUGovernor,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		ExitStatus: uint8(exitStatus),
+		Stderr:     stdErr,
+	}, nil
+}
+
+// PersistCPUGovernor writes a systemd unit (path is the unit file) setting the cpu frequency governor at boot
+func (s Service) PersistCPUGovernor(arg interface{}) (rpi.Exec, error) {
+	var governor string
+	var path string
+
+	switch v := arg.(type) {
+	case SCG:
+		governor = v.Governor
+		path = v.Path
+	case OtherParams:
+		governor = arg.(OtherParams).Value["governor"]
+		path = arg.(OtherParams).Value["path"]
+	default:
+		return rpi.Exec{ExitStatus: 1}, &Error{[]string{"governor", "path"}}
+	}
+
+	// execution start time
+	startTime := uint64(time.Now().Unix())
+	exitStatus := 0
+	var stdErr string
+
+	if !infos.StringItemExists(CPUGovernors, governor) {
+		exitStatus = 1
+		stdErr = "governor is not supported"
+	} else {
+		// raspi-config sets its own governor at boot, hence the ordering
+		err := OverwriteToFile(WriteToFileArg{
+			File: path,
+			Data: []string{
+				"[Unit]",
+				"Description=Set the cpu frequency governor",
+				"After=raspi-config.service",
+				"",
+				"[Service]",
+				"Type=oneshot",
+				fmt.Sprintf(
+					"ExecStart=/bin/sh -c 'for f in /sys/devices/system/cpu/cpu[0-9]*/cpufreq/scaling_governor ; do echo %v > $f ; done'",
+					governor,
+				),
+				"",
+				"[Install]",
+				"WantedBy=multi-user.target",
+			},
+			Multiline:   true,
+			Permissions: 0644,
+		})
+
+		// if error, it is logged here
+		if err != nil {
+			exitStatus = 1
+			stdErr = fmt.Sprint(err)
+		}
+	}
+
+	// execution end time
+	endTime := uint64(time.Now().Unix())
+
+	return rpi.Exec{
+		Name:       PersistCPUGovernor,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		ExitStatus: uint8(exitStatus),
+		Stderr:     stdErr,
+	}, nil
+}
+
+// MU is the argument when managing a systemd unit
+type MU struct {
+	Action string
+	Unit   string
+}
+
+// ManageUnit runs a systemctl command (enable, disable, restart, reload, mask or unmask) against a unit
+func (s Service) ManageUnit(arg interface{}) (rpi.Exec, error) {
+	var action string
+	var unit string
+
+	switch v := arg.(type) {
+	case MU:
+		action = v.Action
+		unit = v.Unit
+	case OtherParams:
+		action = arg.(OtherParams).Value["action"]
+		unit = arg.(OtherParams).Value["unit"]
+	default:
+		return rpi.Exec{ExitStatus: 1}, &Error{[]string{"action", "unit"}}
+	}
+
+	// execution start time
+	startTime := uint64(time.Now().Unix())
+	exitStatus := 0
+	var stdOut, stdErr string
+
+	if !infos.StringItemExists(UnitActions, action) {
+		exitStatus = 1
+		stdErr = "unit action is not supported"
+	} else if !regexp.MustCompile(UnitNameRegex).MatchString(unit) {
+		exitStatus = 1
+		stdErr = "unit name is not valid"
+	} else {
+		cmd := exec.Command("systemctl", action, unit)
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			exitStatus = 1
+		}
+		stdOut, stdErr = strings.TrimSpace(stdout.String()), strings.TrimSpace(stderr.String())
+	}
+
+	// execution end time
+	endTime := uint64(time.Now().Unix())
+
+	return rpi.Exec{
+		Name:       ManageUnit,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		ExitStatus: uint8(exitStatus),
+		Stdout:     stdOut,
+		Stderr:     stdErr,
+	}, nil
+}
+
+// RF is the argument when restoring a file
+type RF struct {
+	Path    string
+	Backup  string
+	Content []byte
+	Mode    uint32
+}
+
+// RestoreFile copies a file to a backup location, if it exists, then overwrites it with new content.
+// The file is put back from the backup when the overwrite fails.
+func (s Service) RestoreFile(arg interface{}) (rpi.Exec, error) {
+	var path string
+	var backup string
+	var content []byte
+	var mode uint32
+
+	switch v := arg.(type) {
+	case RF:
+		path = v.Path
+		backup = v.Backup
+		content = v.Content
+		mode = v.Mode
+	case OtherParams:
+		path = arg.(OtherParams).Value["path"]
+		backup = arg.(OtherParams).Value["backup"]
+		content = []byte(arg.(OtherParams).Value["content"])
+		m, _ := strconv.ParseUint(arg.(OtherParams).Value["mode"], 8, 32)
+		mode = uint32(m)
+	default:
+		return rpi.Exec{ExitStatus: 1}, &Error{[]string{"path", "backup", "content", "mode"}}
+	}
+
+	// execution start time
+	startTime := uint64(time.Now().Unix())
+	exitStatus := 0
+	var stdErr string
+
+	isBackup := false
+	var backupPerm uint32
+	if stat, err := os.Stat(path); err == nil {
+		backupPerm = uint32(stat.Mode().Perm())
+		if err := os.MkdirAll(filepath.Dir(backup), 0755); err != nil {
+			exitStatus = 1
+			stdErr = "creating backup directory failed"
+		} else if err := CopyFile(path, backup, backupPerm); err != nil {
+			exitStatus = 1
+			stdErr = "backuping file failed"
+		}
+		isBackup = true
+	}
+
+	if exitStatus == 0 {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			exitStatus = 1
+			stdErr = "creating directory failed"
+		} else if err := ioutil.WriteFile(path, content, os.FileMode(mode)); err != nil {
+			exitStatus = 1
+			stdErr = "writing file failed"
+			if isBackup {
+				CopyFile(backup, path, backupPerm)
+			}
+		} else if err := ApplyPermissionsToFile(path, mode); err != nil {
+			exitStatus = 1
+			stdErr = fmt.Sprint(err)
+		}
+	}
+
+	// execution end time
+	endTime := uint64(time.Now().Unix())
+
+	return rpi.Exec{
+		Name:       RestoreFile,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		ExitStatus: uint8(exitStatus),
+		Stderr:     stdErr,
+	}, nil
+}
+
+// BCO is the argument when applying an operation to a key of /boot/config.txt
+type BCO struct {
+	Path      string
+	Operation string
+	Section   string
+	Key       string
+	Value     string
+}
+
+// EditBootConfig applies an operation (set, unset, comment, uncomment, min) to a key
+// within a conditional section of /boot/config.txt, keeping the other lines untouched
+func (s Service) EditBootConfig(arg interface{}) (rpi.Exec, error) {
+	var path string
+	var operation string
+	var section string
+	var key string
+	var value string
+
+	switch v := arg.(type) {
+	case BCO:
+		path = v.Path
+		operation = v.Operation
+		section = v.Section
+		key = v.Key
+		value = v.Value
+	case OtherParams:
+		path = arg.(OtherParams).Value["path"]
+		operation = arg.(OtherParams).Value["operation"]
+		section = arg.(OtherParams).Value["section"]
+		key = arg.(OtherParams).Value["key"]
+		value = arg.(OtherParams).Value["value"]
+	default:
+		return rpi.Exec{ExitStatus: 1}, &Error{[]string{"path", "operation", "section", "key", "value"}}
+	}
+
+	// execution start time
+	startTime := uint64(time.Now().Unix())
+	exitStatus := 0
+	var stdErr string
+
+	var lines []string
+	if _, err := os.Stat(path); err == nil {
+		if lines, err = infos.New().ReadFile(path); err != nil {
+			exitStatus = 1
+			stdErr = fmt.Sprint(err)
+		}
+	}
+
+	if exitStatus == 0 {
+		c := bootconfig.Parse(lines)
+		if err := c.Apply(operation, section, key, value); err != nil {
+			exitStatus = 1
+			stdErr = fmt.Sprint(err)
+		} else if err := OverwriteToFile(WriteToFileArg{
+			File:        path,
+			Data:        c.Raw(),
+			Multiline:   true,
+			Permissions: DefaultFilePerm,
+		}); err != nil {
+			exitStatus = 1
+			stdErr = fmt.Sprint(err)
+		}
+	}
+
+	// execution end time
+	endTime := uint64(time.Now().Unix())
+
+	return rpi.Exec{
+		Name:       EditBootConfig,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		ExitStatus: uint8(exitStatus),
+		Stderr:     stdErr,
+	}, nil
+}
+
+// OCS is the argument when saving the overclock state before applying a profile
+type OCS struct {
+	Dir        string
+	BootConfig string
+	State      []byte
+}
+
+// SaveOverclockState saves a snapshot of the boot config and the overclock state (dir is the state directory),
+// then flags the profile as pending until the guard writes the boot-success marker
+func (s Service) SaveOverclockState(arg interface{}) (rpi.Exec, error) {
+	var dir string
+	var bootConfig string
+	var state []byte
+
+	switch v := arg.(type) {
+	case OCS:
+		dir = v.Dir
+		bootConfig = v.BootConfig
+		state = v.State
+	case OtherParams:
+		dir = arg.(OtherParams).Value["dir"]
+		bootConfig = arg.(OtherParams).Value["bootconfig"]
+		state = []byte(arg.(OtherParams).Value["state"])
+	default:
+		return rpi.Exec{ExitStatus: 1}, &Error{[]string{"dir", "bootconfig", "state"}}
+	}
+
+	// execution start time
+	startTime := uint64(time.Now().Unix())
+	exitStatus := 0
+	var stdErr string
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		exitStatus = 1
+		stdErr = "creating state directory failed"
+	} else if _, err := os.Stat(bootConfig); err != nil {
+		exitStatus = 1
+		stdErr = "boot config does not exist"
+	} else if err := CopyFile(bootConfig, filepath.Join(dir, OverclockSnapshotFile), DefaultFilePerm); err != nil {
+		exitStatus = 1
+		stdErr = fmt.Sprint(err)
+	} else if err := ioutil.WriteFile(filepath.Join(dir, OverclockStateFile), state, os.FileMode(DefaultFilePerm)); err != nil {
+		exitStatus = 1
+		stdErr = "writing state failed"
+	} else {
+		for _, f := range []string{OverclockBootsFile, OverclockSuccessFile, OverclockRestoredFile} {
+			os.Remove(filepath.Join(dir, f))
+		}
+
+		pending := []byte(strconv.FormatInt(time.Now().Unix(), 10))
+		if err := ioutil.WriteFile(filepath.Join(dir, OverclockPendingFile), pending, os.FileMode(DefaultFilePerm)); err != nil {
+			exitStatus = 1
+			stdErr = "writing pending marker failed"
+		}
+	}
+
+	// execution end time
+	endTime := uint64(time.Now().Unix())
+
+	return rpi.Exec{
+		Name:       SaveOverclockState,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		ExitStatus: uint8(exitStatus),
+		Stderr:     stdErr,
+	}, nil
+}
+
+// OCG is the argument when persisting the overclock boot guard
+type OCG struct {
+	Dir            string
+	BootConfig     string
+	Path           string
+	Delay          int
+	MaxTemperature int
+	Previous       []rpi.OverclockSetting
+}
+
+// PersistOverclockGuard writes the guard script and its systemd unit (path is the unit file).
+// While a profile is pending, the guard puts the previous values of the profile keys back and reboots when
+// the previous boot did not complete or when the board is throttled or too hot after the delay (in seconds).
+// Otherwise it writes the boot-success marker. The guard disables its unit once the profile is confirmed or restored.
+func (s Service) PersistOverclockGuard(arg interface{}) (rpi.Exec, error) {
+	var dir string
+	var bootConfig string
+	var path string
+	var delay int
+	var maxTemperature int
+	var previous []rpi.OverclockSetting
+
+	switch v := arg.(type) {
+	case OCG:
+		dir = v.Dir
+		bootConfig = v.BootConfig
+		path = v.Path
+		delay = v.Delay
+		maxTemperature = v.MaxTemperature
+		previous = v.Previous
+	case OtherParams:
+		dir = arg.(OtherParams).Value["dir"]
+		bootConfig = arg.(OtherParams).Value["bootconfig"]
+		path = arg.(OtherParams).Value["path"]
+		delay, _ = strconv.Atoi(arg.(OtherParams).Value["delay"])
+		maxTemperature, _ = strconv.Atoi(arg.(OtherParams).Value["maxtemperature"])
+		json.Unmarshal([]byte(arg.(OtherParams).Value["previous"]), &previous)
+	default:
+		return rpi.Exec{ExitStatus: 1}, &Error{[]string{"dir", "bootconfig", "path", "delay", "maxtemperature", "previous"}}
+	}
+
+	// execution start time
+	startTime := uint64(time.Now().Unix())
+	exitStatus := 0
+	var stdErr string
+
+	script := filepath.Join(dir, OverclockGuardFile)
+	unit := filepath.Base(path)
+
+	// the previous settings end up in the script
+	isValid := true
+	for _, p := range previous {
+		r := regexp.MustCompile(OverclockSettingRegex)
+		if !r.MatchString(p.Section) || !r.MatchString(p.Key) || (p.IsSet && !r.MatchString(p.Value)) {
+			isValid = false
+		}
+	}
+
+	if delay <= 0 || maxTemperature <= 0 {
+		exitStatus = 1
+		stdErr = "delay and max temperature should be positive"
+	} else if !isValid {
+		exitStatus = 1
+		stdErr = "previous settings are not valid"
+	} else if err := os.MkdirAll(dir, 0755); err != nil {
+		exitStatus = 1
+		stdErr = "creating state directory failed"
+	} else if err := OverwriteToFile(WriteToFileArg{
+		File: script,
+		Data: []string{
+			"#!/bin/sh",
+			"# restores the values replaced by an overclock profile",
+			"# when the board does not boot or does not run healthy with it",
+			fmt.Sprintf("dir=%v", dir),
+			fmt.Sprintf("[ -f \"$dir/%v\" ] || exit 0", OverclockPendingFile),
+			"restore() {",
+			fmt.Sprintf("\tawk '%v' %v > \"$dir/%v.new\" && cp \"$dir/%v.new\" %v", restoreOverclock(previous), bootConfig, OverclockSnapshotFile, OverclockSnapshotFile, bootConfig),
+			fmt.Sprintf("\techo \"$1\" > \"$dir/%v\"", OverclockRestoredFile),
+			fmt.Sprintf("\trm -f \"$dir/%v\" \"$dir/%v\"", OverclockPendingFile, OverclockBootsFile),
+			fmt.Sprintf("\tsystemctl disable %v", unit),
+			"\tsync",
+			"\tsystemctl reboot",
+			"\texit 0",
+			"}",
+			fmt.Sprintf("boots=$(cat \"$dir/%v\" 2>/dev/null)", OverclockBootsFile),
+			"[ \"${boots:-0}\" -ge 1 ] && restore \"previous boot did not complete\"",
+			fmt.Sprintf("echo $((${boots:-0} + 1)) > \"$dir/%v\"", OverclockBootsFile),
+			"sync",
+			fmt.Sprintf("sleep %v", delay),
+			"throttled=$(vcgencmd get_throttled | cut -d= -f2)",
+			"[ $((${throttled:-0} & 0x5)) -ne 0 ] && restore \"under-voltage or throttling detected\"",
+			"temp=$(cat /sys/class/thermal/thermal_zone0/temp)",
+			fmt.Sprintf("[ \"${temp:-0}\" -ge %v ] && restore \"temperature above %v'C\"", maxTemperature*1000, maxTemperature),
+			fmt.Sprintf("date +%%s > \"$dir/%v\"", OverclockSuccessFile),
+			fmt.Sprintf("rm -f \"$dir/%v\" \"$dir/%v\"", OverclockPendingFile, OverclockBootsFile),
+			fmt.Sprintf("systemctl disable %v", unit),
+		},
+		Multiline:   true,
+		Permissions: 0755,
+	}); err != nil {
+		exitStatus = 1
+		stdErr = fmt.Sprint(err)
+	} else if err := OverwriteToFile(WriteToFileArg{
+		File: path,
+		Data: []string{
+			"[Unit]",
+			"Description=Restore the previous overclock settings when the board does not boot healthy",
+			"After=multi-user.target",
+			"",
+			"[Service]",
+			"Type=simple",
+			fmt.Sprintf("ExecStart=/bin/sh %v", script),
+			"",
+			"[Install]",
+			"WantedBy=multi-user.target",
+		},
+		Multiline:   true,
+		Permissions: 0644,
+	}); err != nil {
+		exitStatus = 1
+		stdErr = fmt.Sprint(err)
+	}
+
+	// execution end time
+	endTime := uint64(time.Now().Unix())
+
+	return rpi.Exec{
+		Name:       PersistOverclockGuard,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		ExitStatus: uint8(exitStatus),
+		Stderr:     stdErr,
+	}, nil
+}
+
+// restoreOverclock returns the awk program putting the previous values of the overclock keys back in config.txt,
+// the other lines being kept. The first active line of a key within its section gets the previous value and the
+// others are removed, a key missing from its section being appended at the end of the file within its section.
+func restoreOverclock(previous []rpi.OverclockSetting) string {
+	program := []string{"BEGIN {", fmt.Sprintf("s = %q", bootconfig.DefaultSection)}
+	for i, p := range previous {
+		value := ""
+		if p.IsSet {
+			value = p.Value
+		}
+		program = append(program, fmt.Sprintf("n++; k[%[1]v] = %[2]q SUBSEP %[3]q; sec[%[1]v] = %[2]q; key[%[1]v] = %[3]q; v[k[%[1]v]] = %[4]q", i+1, p.Section, p.Key, value))
+	}
+	program = append(
+		program,
+		"}",
+		`/^[ \t]*\[[^]]+\]/ { s = $0; sub(/^[ \t]*\[/, "", s); sub(/\].*$/, "", s); print; next }`,
+		`/^[ \t]*[A-Za-z0-9_]+[ \t]*=/ { name = $0; sub(/^[ \t]*/, "", name); sub(/[ \t]*=.*$/, "", name); c = s SUBSEP name }`,
+		`/^[ \t]*[A-Za-z0-9_]+[ \t]*=/ && (c in v) { if (!(c in done) && v[c] != "") print name "=" v[c]; done[c] = 1; next }`,
+		"{ print }",
+		`END { last = s; for (i = 1; i <= n; i++) if (!(k[i] in done) && v[k[i]] != "") { if (sec[i] != last) print "[" sec[i] "]"; last = sec[i]; print key[i] "=" v[k[i]] } }`,
+	)
+	return strings.Join(program, "\n")
+}
+
+// ClearOverclockState ends a pending profile (path is the state directory) once its previous values were restored
+func (s Service) ClearOverclockState(arg interface{}) (rpi.Exec, error) {
+	var dir string
+
+	switch v := arg.(type) {
+	case FileOrDirectory:
+		dir = v.Path
+	case OtherParams:
+		dir = arg.(OtherParams).Value["path"]
+	default:
+		return rpi.Exec{ExitStatus: 1}, &Error{[]string{"path"}}
+	}
+
+	// execution start time
+	startTime := uint64(time.Now().Unix())
+	exitStatus := 0
+	var stdErr string
+
+	for _, f := range []string{OverclockPendingFile, OverclockBootsFile} {
+		os.Remove(filepath.Join(dir, f))
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, OverclockRestoredFile), []byte("restored on request"), os.FileMode(DefaultFilePerm)); err != nil {
+		exitStatus = 1
+		stdErr = "writing restored marker failed"
+	}
+
+	// execution end time
+	endTime := uint64(time.Now().Unix())
+
+	return rpi.Exec{
+		Name:       ClearOverclockState,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		ExitStatus: uint8(exitStatus),
+		Stderr:     stdErr,
+	}, nil
+}
+
+// NTPS is the argument when setting the ntp servers of systemd-timesyncd
+type NTPS struct {
+	Path    string
+	Servers []string
+}
+
+// SetNTPServers sets the NTP key of the [Time] section of timesyncd.conf (path), no server restoring the default ones
+func (s Service) SetNTPServers(arg interface{}) (rpi.Exec, error) {
+	var path string
+	var servers []string
+
+	switch v := arg.(type) {
+	case NTPS:
+		path = v.Path
+		servers = v.Servers
+	case OtherParams:
+		path = arg.(OtherParams).Value["path"]
+		servers = strings.Fields(arg.(OtherParams).Value["servers"])
+	default:
+		return rpi.Exec{ExitStatus: 1}, &Error{[]string{"path", "servers"}}
+	}
+
+	// execution start time
+	startTime := uint64(time.Now().Unix())
+	exitStatus := 0
+	var stdErr string
+
+	for _, server := range servers {
+		if !regexp.MustCompile(NTPServerRegex).MatchString(server) {
+			exitStatus = 1
+			stdErr = "ntp server is not valid"
+		}
+	}
+
+	var lines []string
+	if _, err := os.Stat(path); exitStatus == 0 && err == nil {
+		if lines, err = infos.New().ReadFile(path); err != nil {
+			exitStatus = 1
+			stdErr = fmt.Sprint(err)
+		}
+	}
+
+	if exitStatus == 0 {
+		line := "#NTP="
+		if len(servers) > 0 {
+			line = "NTP=" + strings.Join(servers, " ")
+		}
+
+		if err := OverwriteToFile(WriteToFileArg{
+			File:        path,
+			Data:        setTimeKey(lines, line),
+			Multiline:   true,
+			Permissions: DefaultFilePerm,
+		}); err != nil {
+			exitStatus = 1
+			stdErr = fmt.Sprint(err)
+		}
+	}
+
+	// execution end time
+	endTime := uint64(time.Now().Unix())
+
+	return rpi.Exec{
+		Name:       SetNTPServers,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		ExitStatus: uint8(exitStatus),
+		Stderr:     stdErr,
+	}, nil
+}
+
+// setTimeKey replaces the NTP lines, commented or not, of the [Time] section by line.
+// The section is added when missing.
+func setTimeKey(lines []string, line string) []string {
+	result := []string{}
+	re := regexp.MustCompile(`^#?\s*NTP\s*=`)
+
+	section, isSet := "", false
+	for _, l := range lines {
+		t := strings.TrimSpace(l)
+		if strings.HasPrefix(t, "[") {
+			if section == "[Time]" && !isSet {
+				// before the blank lines separating the sections
+				k := len(result)
+				for k > 0 && strings.TrimSpace(result[k-1]) == "" {
+					k--
+				}
+				result = append(result[:k], append([]string{line}, result[k:]...)...)
+				isSet = true
+			}
+			section = t
+		}
+
+		if section == "[Time]" && re.MatchString(t) {
+			if !isSet {
+				result = append(result, line)
+				isSet = true
+			}
+			continue
+		}
+		result = append(result, l)
+	}
+
+	if !isSet {
+		if section != "[Time]" {
+			result = append(result, "[Time]")
+		}
+		result = append(result, line)
+	}
+
+	return result
+}
+
+// WPO is the argument when applying an operation to a network of wpa_supplicant.conf
+type WPO struct {
+	Path      string
+	Operation string
+	Network   wpaconf.Network
+}
+
+// EditWpaSupplicant applies an operation (add, remove, priority) to a network of wpa_supplicant.conf (path)
+func (s Service) EditWpaSupplicant(arg interface{}) (rpi.Exec, error) {
+	var path string
+	var operation string
+	var network wpaconf.Network
+
+	switch v := arg.(type) {
+	case WPO:
+		path = v.Path
+		operation = v.Operation
+		network = v.Network
+	case OtherParams:
+		path = arg.(OtherParams).Value["path"]
+		operation = arg.(OtherParams).Value["operation"]
+		network.ID, _ = strconv.Atoi(arg.(OtherParams).Value["id"])
+		network.SSID = arg.(OtherParams).Value["ssid"]
+		network.PSK = arg.(OtherParams).Value["psk"]
+		network.IsHidden = arg.(OtherParams).Value["hidden"] == "true"
+		network.Priority, _ = strconv.Atoi(arg.(OtherParams).Value["priority"])
+	default:
+		return rpi.Exec{ExitStatus: 1}, &Error{[]string{"path", "operation", "id", "ssid", "psk", "hidden", "priority"}}
+	}
+
+	// execution start time
+	startTime := uint64(time.Now().Unix())
+	exitStatus := 0
+	var stdErr string
+
+	lines, err := infos.New().ReadFile(path)
+	if err != nil {
+		exitStatus = 1
+		stdErr = fmt.Sprint(err)
+	}
+
+	if exitStatus == 0 {
+		c := wpaconf.Parse(lines)
+		if err := c.Apply(operation, network); err != nil {
+			exitStatus = 1
+			stdErr = fmt.Sprint(err)
+		} else if err := OverwriteToFile(WriteToFileArg{
+			File:        path,
+			Data:        c.Raw(),
+			Multiline:   true,
+			Permissions: 0600,
+		}); err != nil {
+			exitStatus = 1
+			stdErr = fmt.Sprint(err)
+		}
+	}
+
+	// execution end time
+	endTime := uint64(time.Now().Unix())
+
+	return rpi.Exec{
+		Name:       EditWpaSupplicant,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		ExitStatus: uint8(exitStatus),
+		Stderr:     stdErr,
+	}, nil
+}
+
+// ANC is the argument when applying the ip configuration of an interface
+type ANC struct {
+	Interface string
+	Command   string
+	Rollback  string
+	Path      string
+	Backup    string
+	IsNew     bool
+	Addresses []string
+	Timeout   time.Duration
+}
+
+// ApplyNetworkConfig runs the command applying the ip configuration written to a file (path), then waits for
+// the interface to come up with the expected addresses (any address when none is expected).
+// When it does not, the previous file is put back from the backup (removed when new) and the rollback
+// command is run, so that the board is reachable again even if the client lost its connection.
+func (s Service) ApplyNetworkConfig(arg interface{}) (rpi.Exec, error) {
+	var iface string
+	var command string
+	var rollback string
+	var path string
+	var backup string
+	var isNew bool
+	var addresses []string
+	var timeout time.Duration
+
+	switch v := arg.(type) {
+	case ANC:
+		iface = v.Interface
+		command = v.Command
+		rollback = v.Rollback
+		path = v.Path
+		backup = v.Backup
+		isNew = v.IsNew
+		addresses = v.Addresses
+		timeout = v.Timeout
+	case OtherParams:
+		iface = arg.(OtherParams).Value["interface"]
+		command = arg.(OtherParams).Value["command"]
+		rollback = arg.(OtherParams).Value["rollback"]
+		path = arg.(OtherParams).Value["path"]
+		backup = arg.(OtherParams).Value["backup"]
+		isNew = arg.(OtherParams).Value["new"] == "true"
+		if arg.(OtherParams).Value["addresses"] != "" {
+			addresses = strings.Split(arg.(OtherParams).Value["addresses"], ",")
+		}
+		seconds, _ := strconv.Atoi(arg.(OtherParams).Value["timeout"])
+		timeout = time.Duration(seconds) * time.Second
+	default:
+		return rpi.Exec{ExitStatus: 1}, &Error{[]string{"interface", "command", "rollback", "path", "backup", "new", "addresses", "timeout"}}
+	}
+
+	// execution start time
+	startTime := uint64(time.Now().Unix())
+	exitStatus := 0
+	var stdErr string
+
+	var perm uint32 = 0600
+	if stat, err := os.Stat(path); err == nil {
+		perm = uint32(stat.Mode().Perm())
+	}
+
+	if out, err := exec.Command("sh", "-c", command).CombinedOutput(); err != nil {
+		exitStatus = 1
+		stdErr = fmt.Sprintf("applying configuration failed: %v", strings.TrimSpace(string(out)))
+	} else if !waitForInterface(iface, addresses, timeout) {
+		exitStatus = 1
+		stdErr = fmt.Sprintf("%v did not come up", iface)
+	}
+
+	if exitStatus == 1 {
+		var err error
+		if isNew {
+			err = os.Remove(path)
+		} else {
+			err = CopyFile(backup, path, perm)
+		}
+
+		if err != nil {
+			stdErr += ", restoring previous configuration failed"
+		} else if _, err := exec.Command("sh", "-c", rollback).Output(); err != nil {
+			stdErr += ", applying previous configuration failed"
+		} else {
+			stdErr += ", previous configuration restored"
+		}
+	}
+
+	// execution end time
+	endTime := uint64(time.Now().Unix())
+
+	return rpi.Exec{
+		Name:       ApplyNetworkConfig,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		ExitStatus: uint8(exitStatus),
+		Stderr:     stdErr,
+	}, nil
+}
+
+// waitForInterface polls an interface until it is up with the expected addresses, or the timeout is reached
+func waitForInterface(iface string, addresses []string, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for {
+		if isInterfaceUp(iface, addresses) {
+			return true
+		}
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+// isInterfaceUp returns true when an interface is up with the expected addresses, in CIDR notation,
+// or with any address other than link-local when none is expected
+func isInterfaceUp(name string, addresses []string) bool {
+	iface, err := net.InterfaceByName(name)
+	if err != nil || iface.Flags&net.FlagUp == 0 {
+		return false
+	}
+
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return false
+	}
+
+	current := map[string]bool{}
+	for _, a := range addrs {
+		if ip, _, err := net.ParseCIDR(a.String()); err == nil && !ip.IsLinkLocalUnicast() {
+			current[ip.String()] = true
+		}
+	}
+
+	if len(addresses) == 0 {
+		return len(current) > 0
+	}
+
+	for _, a := range addresses {
+		ip, _, err := net.ParseCIDR(a)
+		if err != nil || !current[ip.String()] {
+			return false
+		}
+	}
+
+	return true
+}
+
 // FileOrDirectory is the argument used when wanting to modified a file only (ex: comment)
 type FileOrDirectory struct {
 	Path string
@@ -1066,6 +2473,8 @@ type COUSLINF struct {
 const CommentOrUncommentInFile = "comment_or_uncomment_in_file"
 
 // CommentInFile comments overscan lines
+//
+// Deprecated: config.txt is edited with EditBootConfig, which keeps to the section of a setting.
 func (s Service) CommentOrUncommentInFile(arg interface{}) (rpi.Exec, error) {
 	var functionName string
 	var action string
@@ -1229,6 +2638,8 @@ type EODC struct {
 const DisableOrEnableConfig = "disable_or_enable_config"
 
 // DisableOrEnableConfig disables or enables a config in a file
+//
+// Deprecated: config.txt is edited with EditBootConfig, which keeps to the section of a setting.
 func (s Service) DisableOrEnableConfig(arg interface{}) (rpi.Exec, error) {
 	var functionName string
 	var action string
@@ -1327,6 +2738,8 @@ type SVICF struct {
 }
 
 // DisableOrEnableConfig disables or enables a config in a file
+//
+// Deprecated: config.txt is edited with EditBootConfig, which keeps to the section of a setting.
 func (s Service) SetVariableInConfigFile(arg interface{}) (rpi.Exec, error) {
 	var file string
 	var regex string
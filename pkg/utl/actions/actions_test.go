@@ -4,9 +4,12 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -15,6 +18,7 @@ import (
 	"github.com/raspibuddy/rpi/pkg/utl/actions"
 	"github.com/raspibuddy/rpi/pkg/utl/infos"
 	"github.com/raspibuddy/rpi/pkg/utl/test_utl"
+	"github.com/raspibuddy/rpi/pkg/utl/wpaconf"
 	"github.com/shirou/gopsutil/host"
 	"github.com/stretchr/testify/assert"
 )
@@ -262,6 +266,1043 @@ func TestKillProcessByName(t *testing.T) {
 		})
 	}
 }
+func TestSignalProcess(t *testing.T) {
+	cases := []struct {
+		name             string
+		pidAlive         bool
+		argument         interface{}
+		signal           string
+		wantedExitStatus uint8
+		wantedStderr     string
+		wantedErr        error
+	}{
+		{
+			name:             "error wrong type",
+			argument:         "dummy",
+			wantedExitStatus: 1,
+			wantedErr:        &actions.Error{Arguments: []string{"pid", "signal"}},
+		},
+		{
+			name:             "error pid convertion issue",
+			argument:         actions.SP{Pid: "ABC", Signal: "HUP"},
+			wantedExitStatus: 1,
+			wantedStderr:     "pid is not an int",
+		},
+		{
+			name:             "error pid of every process",
+			argument:         actions.SP{Pid: "-1", Signal: "KILL"},
+			wantedExitStatus: 1,
+			wantedStderr:     "pid should be greater than 0",
+		},
+		{
+			name:             "error pid of the api",
+			argument:         actions.SP{Pid: "0", Signal: "KILL"},
+			wantedExitStatus: 1,
+			wantedStderr:     "pid should be greater than 0",
+		},
+		{
+			name:             "error signal not supported",
+			argument:         actions.SP{Pid: "1", Signal: "DUMMY"},
+			wantedExitStatus: 1,
+			wantedStderr:     "signal is not supported",
+		},
+		{
+			name:             "success suspending process",
+			pidAlive:         true,
+			signal:           "SIGSTOP",
+			wantedExitStatus: 0,
+		},
+		{
+			name:             "success resuming process",
+			pidAlive:         true,
+			signal:           "CONT",
+			wantedExitStatus: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := actions.New()
+			var signalProcess rpi.Exec
+			var err error
+
+			if tc.pidAlive {
+				cmd := exec.Command("sleep", "10")
+				if err := cmd.Start(); err != nil {
+					t.Fatalf("Failed to start test process: %v", err)
+				}
+				signalProcess, err = a.SignalProcess(actions.OtherParams{
+					Value: map[string]string{
+						"pid":    fmt.Sprint(cmd.Process.Pid),
+						"signal": tc.signal,
+					},
+				})
+				_ = cmd.Process.Kill()
+				_ = cmd.Wait()
+			} else {
+				signalProcess, err = a.SignalProcess(tc.argument)
+			}
+
+			assert.Equal(t, tc.wantedExitStatus, signalProcess.ExitStatus)
+			assert.Equal(t, tc.wantedStderr, signalProcess.Stderr)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestReniceProcess(t *testing.T) {
+	cases := []struct {
+		name             string
+		pidAlive         bool
+		argument         interface{}
+		priority         string
+		wantedExitStatus uint8
+		wantedStderr     string
+		wantedErr        error
+	}{
+		{
+			name:             "error wrong type",
+			argument:         "dummy",
+			wantedExitStatus: 1,
+			wantedErr:        &actions.Error{Arguments: []string{"pid", "priority"}},
+		},
+		{
+			name:             "error pid convertion issue",
+			argument:         actions.RNP{Pid: "ABC", Priority: "10"},
+			wantedExitStatus: 1,
+			wantedStderr:     "pid is not an int",
+		},
+		{
+			name:             "error pid of every process",
+			argument:         actions.RNP{Pid: "-1", Priority: "10"},
+			wantedExitStatus: 1,
+			wantedStderr:     "pid should be greater than 0",
+		},
+		{
+			name:             "error pid of the api",
+			argument:         actions.RNP{Pid: "0", Priority: "10"},
+			wantedExitStatus: 1,
+			wantedStderr:     "pid should be greater than 0",
+		},
+		{
+			name:             "error priority out of range",
+			argument:         actions.RNP{Pid: "1", Priority: "20"},
+			wantedExitStatus: 1,
+			wantedStderr:     "priority should be an int between -20 and 19",
+		},
+		{
+			name:             "success lowering process priority",
+			pidAlive:         true,
+			priority:         "19",
+			wantedExitStatus: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := actions.New()
+			var reniceProcess rpi.Exec
+			var err error
+
+			if tc.pidAlive {
+				cmd := exec.Command("sleep", "10")
+				if err := cmd.Start(); err != nil {
+					t.Fatalf("Failed to start test process: %v", err)
+				}
+				reniceProcess, err = a.ReniceProcess(actions.RNP{
+					Pid:      fmt.Sprint(cmd.Process.Pid),
+					Priority: tc.priority,
+				})
+				_ = cmd.Process.Kill()
+				_ = cmd.Wait()
+			} else {
+				reniceProcess, err = a.ReniceProcess(tc.argument)
+			}
+
+			assert.Equal(t, tc.wantedExitStatus, reniceProcess.ExitStatus)
+			assert.Equal(t, tc.wantedStderr, reniceProcess.Stderr)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestIoniceProcess(t *testing.T) {
+	cases := []struct {
+		name             string
+		argument         interface{}
+		wantedExitStatus uint8
+		wantedStderr     string
+		wantedErr        error
+	}{
+		{
+			name:             "error wrong type",
+			argument:         "dummy",
+			wantedExitStatus: 1,
+			wantedErr:        &actions.Error{Arguments: []string{"pid", "class", "level"}},
+		},
+		{
+			name:             "error pid convertion issue",
+			argument:         actions.INP{Pid: "ABC", Class: "idle"},
+			wantedExitStatus: 1,
+			wantedStderr:     "pid is not an int",
+		},
+		{
+			name:             "error pid of every process",
+			argument:         actions.INP{Pid: "-1", Class: "idle"},
+			wantedExitStatus: 1,
+			wantedStderr:     "pid should be greater than 0",
+		},
+		{
+			name:             "error pid of the api",
+			argument:         actions.INP{Pid: "0", Class: "idle"},
+			wantedExitStatus: 1,
+			wantedStderr:     "pid should be greater than 0",
+		},
+		{
+			name:             "error bad class",
+			argument:         actions.INP{Pid: "1", Class: "dummy", Level: "1"},
+			wantedExitStatus: 1,
+			wantedStderr:     "class should be realtime, best-effort or idle",
+		},
+		{
+			name:             "error bad level",
+			argument:         actions.INP{Pid: "1", Class: "best-effort", Level: "8"},
+			wantedExitStatus: 1,
+			wantedStderr:     "level should be an int between 0 and 7",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := actions.New()
+			ioniceProcess, err := a.IoniceProcess(tc.argument)
+			assert.Equal(t, tc.wantedExitStatus, ioniceProcess.ExitStatus)
+			assert.Equal(t, tc.wantedStderr, ioniceProcess.Stderr)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestSetProcessAffinity(t *testing.T) {
+	cases := []struct {
+		name             string
+		argument         interface{}
+		wantedExitStatus uint8
+		wantedStderr     string
+		wantedErr        error
+	}{
+		{
+			name:             "error wrong type",
+			argument:         "dummy",
+			wantedExitStatus: 1,
+			wantedErr:        &actions.Error{Arguments: []string{"pid", "cpuList"}},
+		},
+		{
+			name:             "error pid convertion issue",
+			argument:         actions.SPA{Pid: "ABC", CPUList: "0"},
+			wantedExitStatus: 1,
+			wantedStderr:     "pid is not an int",
+		},
+		{
+			name:             "error pid of every process",
+			argument:         actions.SPA{Pid: "-1", CPUList: "0"},
+			wantedExitStatus: 1,
+			wantedStderr:     "pid should be greater than 0",
+		},
+		{
+			name:             "error pid of the api",
+			argument:         actions.SPA{Pid: "0", CPUList: "0"},
+			wantedExitStatus: 1,
+			wantedStderr:     "pid should be greater than 0",
+		},
+		{
+			name:             "error cpu list badly formatted",
+			argument:         actions.SPA{Pid: "1", CPUList: "0;rm -rf /"},
+			wantedExitStatus: 1,
+			wantedStderr:     "cpu list badly formatted",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := actions.New()
+			setProcessAffinity, err := a.SetProcessAffinity(tc.argument)
+			assert.Equal(t, tc.wantedExitStatus, setProcessAffinity.ExitStatus)
+			assert.Equal(t, tc.wantedStderr, setProcessAffinity.Stderr)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestSetCPUGovernor(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cpu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	governorFile := filepath.Join(dir, "cpu0", "cpufreq", "scaling_governor")
+	if err := os.MkdirAll(filepath.Dir(governorFile), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(governorFile, []byte("ondemand\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name             string
+		argument         interface{}
+		wantedExitStatus uint8
+		wantedStderr     string
+		wantedGovernor   string
+		wantedErr        error
+	}{
+		{
+			name:             "error wrong type",
+			argument:         "dummy",
+			wantedExitStatus: 1,
+			wantedGovernor:   "ondemand\n",
+			wantedErr:        &actions.Error{Arguments: []string{"governor", "path"}},
+		},
+		{
+			name:             "error governor not supported",
+			argument:         actions.SCG{Governor: "userspace", Path: dir},
+			wantedExitStatus: 1,
+			wantedStderr:     "governor is not supported",
+			wantedGovernor:   "ondemand\n",
+		},
+		{
+			name:             "error no scaling governor",
+			argument:         actions.SCG{Governor: "performance", Path: filepath.Join(dir, "dummy")},
+			wantedExitStatus: 1,
+			wantedStderr:     "no cpufreq scaling governor found",
+			wantedGovernor:   "ondemand\n",
+		},
+		{
+			name:             "success",
+			argument:         actions.OtherParams{Value: map[string]string{"governor": "performance", "path": dir}},
+			wantedExitStatus: 0,
+			wantedGovernor:   "performance",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := actions.New()
+			setCPUGovernor, err := a.SetCPUGovernor(tc.argument)
+			governor, _ := ioutil.ReadFile(governorFile)
+			assert.Equal(t, tc.wantedExitStatus, setCPUGovernor.ExitStatus)
+			assert.Equal(t, tc.wantedStderr, setCPUGovernor.Stderr)
+			assert.Equal(t, tc.wantedGovernor, string(governor))
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestPersistCPUGovernor(t *testing.T) {
+	dir, err := ioutil.TempDir("", "systemd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	unit := filepath.Join(dir, "raspibuddy-cpugovernor.service")
+
+	cases := []struct {
+		name             string
+		argument         interface{}
+		wantedExitStatus uint8
+		wantedStderr     string
+		wantedErr        error
+	}{
+		{
+			name:             "error wrong type",
+			argument:         "dummy",
+			wantedExitStatus: 1,
+			wantedErr:        &actions.Error{Arguments: []string{"governor", "path"}},
+		},
+		{
+			name:             "error governor not supported",
+			argument:         actions.SCG{Governor: "userspace", Path: unit},
+			wantedExitStatus: 1,
+			wantedStderr:     "governor is not supported",
+		},
+		{
+			name:             "success",
+			argument:         actions.SCG{Governor: "powersave", Path: unit},
+			wantedExitStatus: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := actions.New()
+			persistCPUGovernor, err := a.PersistCPUGovernor(tc.argument)
+			assert.Equal(t, tc.wantedExitStatus, persistCPUGovernor.ExitStatus)
+			assert.Equal(t, tc.wantedStderr, persistCPUGovernor.Stderr)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+
+	content, err := ioutil.ReadFile(unit)
+	assert.Nil(t, err)
+	assert.Contains(t, string(content), "echo powersave > $f")
+	assert.Contains(t, string(content), "WantedBy=multi-user.target")
+}
+
+func TestManageUnit(t *testing.T) {
+	cases := []struct {
+		name             string
+		argument         interface{}
+		wantedExitStatus uint8
+		wantedStderr     string
+		wantedErr        error
+	}{
+		{
+			name:             "error wrong type",
+			argument:         "dummy",
+			wantedExitStatus: 1,
+			wantedErr:        &actions.Error{Arguments: []string{"action", "unit"}},
+		},
+		{
+			name:             "error action not supported",
+			argument:         actions.MU{Action: "kill", Unit: "ssh.service"},
+			wantedExitStatus: 1,
+			wantedStderr:     "unit action is not supported",
+		},
+		{
+			name:             "error unit not valid",
+			argument:         actions.OtherParams{Value: map[string]string{"action": "restart", "unit": "--now"}},
+			wantedExitStatus: 1,
+			wantedStderr:     "unit name is not valid",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := actions.New()
+			manageUnit, err := a.ManageUnit(tc.argument)
+			assert.Equal(t, tc.wantedExitStatus, manageUnit.ExitStatus)
+			assert.Equal(t, tc.wantedStderr, manageUnit.Stderr)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
+func TestRestoreFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "restore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	existing := filepath.Join(dir, "etc", "hosts")
+	if err := os.MkdirAll(filepath.Dir(existing), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(existing, []byte("127.0.1.1 pi-old\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name             string
+		argument         interface{}
+		path             string
+		backup           string
+		wantedExitStatus uint8
+		wantedContent    string
+		wantedBackup     string
+		wantedMode       os.FileMode
+		wantedErr        error
+	}{
+		{
+			name:             "error wrong type",
+			argument:         "dummy",
+			wantedExitStatus: 1,
+			wantedErr:        &actions.Error{Arguments: []string{"path", "backup", "content", "mode"}},
+		},
+		{
+			name: "success new file",
+			argument: actions.RF{
+				Path:    filepath.Join(dir, "etc", "openvpn", "wov_a", "fr.ovpn"),
+				Backup:  filepath.Join(dir, "backups", "etc", "openvpn", "wov_a", "fr.ovpn"),
+				Content: []byte("remote 1.2.3.4\n"),
+				Mode:    0600,
+			},
+			path:             filepath.Join(dir, "etc", "openvpn", "wov_a", "fr.ovpn"),
+			backup:           filepath.Join(dir, "backups", "etc", "openvpn", "wov_a", "fr.ovpn"),
+			wantedExitStatus: 0,
+			wantedContent:    "remote 1.2.3.4\n",
+			wantedMode:       0600,
+		},
+		{
+			name: "success existing file",
+			argument: actions.OtherParams{
+				Value: map[string]string{
+					"path":    existing,
+					"backup":  filepath.Join(dir, "backups", "etc", "hosts"),
+					"content": "127.0.1.1 raspberrypi\n",
+					"mode":    "644",
+				},
+			},
+			path:             existing,
+			backup:           filepath.Join(dir, "backups", "etc", "hosts"),
+			wantedExitStatus: 0,
+			wantedContent:    "127.0.1.1 raspberrypi\n",
+			wantedBackup:     "127.0.1.1 pi-old\n",
+			wantedMode:       0644,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := actions.New()
+			restoreFile, err := a.RestoreFile(tc.argument)
+			assert.Equal(t, tc.wantedExitStatus, restoreFile.ExitStatus)
+			assert.Equal(t, tc.wantedErr, err)
+
+			if tc.path != "" {
+				content, _ := ioutil.ReadFile(tc.path)
+				backup, _ := ioutil.ReadFile(tc.backup)
+				stat, _ := os.Stat(tc.path)
+				assert.Equal(t, tc.wantedContent, string(content))
+				assert.Equal(t, tc.wantedBackup, string(backup))
+				assert.Equal(t, tc.wantedMode, stat.Mode().Perm())
+			}
+		})
+	}
+}
+
+func TestEditBootConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bootconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	existing := filepath.Join(dir, "config.txt")
+	if err := ioutil.WriteFile(existing, []byte("# comment\n#dtparam=spi=on\n\n[pi4]\nmax_framebuffers=2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name             string
+		argument         interface{}
+		path             string
+		wantedExitStatus uint8
+		wantedStderr     string
+		wantedContent    string
+		wantedErr        error
+	}{
+		{
+			name:             "error wrong type",
+			argument:         "dummy",
+			wantedExitStatus: 1,
+			wantedErr:        &actions.Error{Arguments: []string{"path", "operation", "section", "key", "value"}},
+		},
+		{
+			name: "error bad operation",
+			argument: actions.BCO{
+				Path:      existing,
+				Operation: "toggle",
+				Section:   "all",
+				Key:       "dtparam=spi",
+			},
+			path:             existing,
+			wantedExitStatus: 1,
+			wantedStderr:     "bad operation",
+			wantedContent:    "# comment\n#dtparam=spi=on\n\n[pi4]\nmax_framebuffers=2\n",
+		},
+		{
+			name: "success existing file",
+			argument: actions.BCO{
+				Path:      existing,
+				Operation: "set",
+				Section:   "all",
+				Key:       "dtparam=spi",
+				Value:     "on",
+			},
+			path:             existing,
+			wantedExitStatus: 0,
+			wantedContent:    "# comment\ndtparam=spi=on\n\n[pi4]\nmax_framebuffers=2\n",
+		},
+		{
+			name: "success pi3+ section",
+			argument: actions.BCO{
+				Path:      existing,
+				Operation: "set",
+				Section:   "pi3+",
+				Key:       "arm_freq",
+				Value:     "1450",
+			},
+			path:             existing,
+			wantedExitStatus: 0,
+			wantedContent:    "# comment\ndtparam=spi=on\n\n[pi4]\nmax_framebuffers=2\n\n[pi3+]\narm_freq=1450\n",
+		},
+		{
+			name: "success new file",
+			argument: actions.OtherParams{
+				Value: map[string]string{
+					"path":      filepath.Join(dir, "new.txt"),
+					"operation": "set",
+					"section":   "pi4",
+					"key":       "arm_freq",
+					"value":     "1800",
+				},
+			},
+			path:             filepath.Join(dir, "new.txt"),
+			wantedExitStatus: 0,
+			wantedContent:    "[pi4]\narm_freq=1800\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := actions.New()
+			editBootConfig, err := a.EditBootConfig(tc.argument)
+			assert.Equal(t, tc.wantedExitStatus, editBootConfig.ExitStatus)
+			assert.Equal(t, tc.wantedStderr, editBootConfig.Stderr)
+			assert.Equal(t, tc.wantedErr, err)
+
+			if tc.path != "" {
+				content, _ := ioutil.ReadFile(tc.path)
+				assert.Equal(t, tc.wantedContent, string(content))
+			}
+		})
+	}
+}
+
+func TestSaveOverclockState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "overclock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	config := filepath.Join(dir, "config.txt")
+	if err := ioutil.WriteFile(config, []byte("[pi4]\narm_freq=1800\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stateDir := filepath.Join(dir, "state")
+	if err := os.MkdirAll(stateDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(stateDir, actions.OverclockSuccessFile), []byte("1600000000"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name             string
+		argument         interface{}
+		wantedExitStatus uint8
+		wantedStderr     string
+		wantedErr        error
+	}{
+		{
+			name:             "error wrong type",
+			argument:         "dummy",
+			wantedExitStatus: 1,
+			wantedErr:        &actions.Error{Arguments: []string{"dir", "bootconfig", "state"}},
+		},
+		{
+			name:             "error boot config does not exist",
+			argument:         actions.OCS{Dir: stateDir, BootConfig: filepath.Join(dir, "dummy.txt")},
+			wantedExitStatus: 1,
+			wantedStderr:     "boot config does not exist",
+		},
+		{
+			name:             "success",
+			argument:         actions.OCS{Dir: stateDir, BootConfig: config, State: []byte(`{"profile":"high"}`)},
+			wantedExitStatus: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := actions.New()
+			saveOverclockState, err := a.SaveOverclockState(tc.argument)
+			assert.Equal(t, tc.wantedExitStatus, saveOverclockState.ExitStatus)
+			assert.Equal(t, tc.wantedStderr, saveOverclockState.Stderr)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+
+	snapshot, err := ioutil.ReadFile(filepath.Join(stateDir, actions.OverclockSnapshotFile))
+	assert.Nil(t, err)
+	assert.Equal(t, "[pi4]\narm_freq=1800\n", string(snapshot))
+
+	state, err := ioutil.ReadFile(filepath.Join(stateDir, actions.OverclockStateFile))
+	assert.Nil(t, err)
+	assert.Equal(t, `{"profile":"high"}`, string(state))
+
+	_, err = os.Stat(filepath.Join(stateDir, actions.OverclockPendingFile))
+	assert.Nil(t, err)
+	_, err = os.Stat(filepath.Join(stateDir, actions.OverclockSuccessFile))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestPersistOverclockGuard(t *testing.T) {
+	dir, err := ioutil.TempDir("", "overclock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	unit := filepath.Join(dir, "raspibuddy-overclock-guard.service")
+
+	cases := []struct {
+		name             string
+		argument         interface{}
+		wantedExitStatus uint8
+		wantedStderr     string
+		wantedErr        error
+	}{
+		{
+			name:             "error wrong type",
+			argument:         "dummy",
+			wantedExitStatus: 1,
+			wantedErr:        &actions.Error{Arguments: []string{"dir", "bootconfig", "path", "delay", "maxtemperature", "previous"}},
+		},
+		{
+			name:             "error no delay",
+			argument:         actions.OCG{Dir: dir, BootConfig: "/boot/config.txt", Path: unit, MaxTemperature: 80},
+			wantedExitStatus: 1,
+			wantedStderr:     "delay and max temperature should be positive",
+		},
+		{
+			name: "error invalid previous value",
+			argument: actions.OCG{
+				Dir: dir, BootConfig: "/boot/config.txt", Path: unit, Delay: 120, MaxTemperature: 80,
+				Previous: []rpi.OverclockSetting{{Section: "pi4", Key: "arm_freq", Value: "1500' /etc/shadow '", IsSet: true}},
+			},
+			wantedExitStatus: 1,
+			wantedStderr:     "previous settings are not valid",
+		},
+		{
+			name: "success pi3+ section",
+			argument: actions.OCG{
+				Dir: dir, BootConfig: "/boot/config.txt", Path: unit, Delay: 120, MaxTemperature: 80,
+				Previous: []rpi.OverclockSetting{{Section: "pi3+", Key: "arm_freq", Value: "1400", IsSet: true}},
+			},
+			wantedExitStatus: 0,
+		},
+		{
+			name: "success",
+			argument: actions.OCG{
+				Dir: dir, BootConfig: "/boot/config.txt", Path: unit, Delay: 120, MaxTemperature: 80,
+				Previous: []rpi.OverclockSetting{{Section: "all", Key: "arm_freq"}, {Section: "pi4", Key: "arm_freq", Value: "1500", IsSet: true}},
+			},
+			wantedExitStatus: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := actions.New()
+			persistOverclockGuard, err := a.PersistOverclockGuard(tc.argument)
+			assert.Equal(t, tc.wantedExitStatus, persistOverclockGuard.ExitStatus)
+			assert.Equal(t, tc.wantedStderr, persistOverclockGuard.Stderr)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+
+	content, err := ioutil.ReadFile(unit)
+	assert.Nil(t, err)
+	assert.Contains(t, string(content), "ExecStart=/bin/sh "+filepath.Join(dir, actions.OverclockGuardFile))
+
+	script, err := ioutil.ReadFile(filepath.Join(dir, actions.OverclockGuardFile))
+	assert.Nil(t, err)
+	assert.Contains(t, string(script), "' /boot/config.txt > \"$dir/config.txt.new\" && cp \"$dir/config.txt.new\" /boot/config.txt")
+	assert.Contains(t, string(script), `v[k[2]] = "1500"`)
+	assert.Contains(t, string(script), "sleep 120")
+	assert.Contains(t, string(script), "-ge 80000 ]")
+	assert.Contains(t, string(script), "systemctl disable raspibuddy-overclock-guard.service")
+
+	// the restore program puts the previous values back and keeps the other lines
+	program := regexp.MustCompile(`(?s)awk '(.*?)'`).FindStringSubmatch(string(script))
+	assert.Equal(t, 2, len(program))
+	if _, err := exec.LookPath("awk"); err == nil && len(program) == 2 {
+		config := filepath.Join(dir, "config.txt")
+		if err := ioutil.WriteFile(config, []byte("arm_freq=1750\ndtoverlay=vc4-kms-v3d\n[pi4]\ngpu_mem=128\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		restored, err := exec.Command("awk", program[1], config).Output()
+		assert.Nil(t, err)
+		assert.Equal(t, "dtoverlay=vc4-kms-v3d\n[pi4]\ngpu_mem=128\narm_freq=1500\n", string(restored))
+	}
+}
+
+func TestClearOverclockState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "overclock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, f := range []string{actions.OverclockPendingFile, actions.OverclockBootsFile} {
+		if err := ioutil.WriteFile(filepath.Join(dir, f), []byte("1"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	a := actions.New()
+	_, err = a.ClearOverclockState("dummy")
+	assert.Equal(t, &actions.Error{Arguments: []string{"path"}}, err)
+
+	clearOverclockState, err := a.ClearOverclockState(actions.FileOrDirectory{Path: dir})
+	assert.Nil(t, err)
+	assert.Equal(t, uint8(0), clearOverclockState.ExitStatus)
+
+	_, err = os.Stat(filepath.Join(dir, actions.OverclockPendingFile))
+	assert.True(t, os.IsNotExist(err))
+	restored, err := ioutil.ReadFile(filepath.Join(dir, actions.OverclockRestoredFile))
+	assert.Nil(t, err)
+	assert.Equal(t, "restored on request", string(restored))
+}
+
+func TestSetNTPServers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "timesyncd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cases := []struct {
+		name             string
+		content          string
+		argument         func(string) interface{}
+		wantedExitStatus uint8
+		wantedStderr     string
+		wantedErr        error
+		wantedContent    string
+	}{
+		{
+			name:             "error wrong type",
+			argument:         func(string) interface{} { return "dummy" },
+			wantedExitStatus: 1,
+			wantedErr:        &actions.Error{Arguments: []string{"path", "servers"}},
+		},
+		{
+			name: "error invalid server",
+			argument: func(path string) interface{} {
+				return actions.NTPS{Path: path, Servers: []string{"pool.ntp.org; reboot"}}
+			},
+			wantedExitStatus: 1,
+			wantedStderr:     "ntp server is not valid",
+		},
+		{
+			name:    "success commented key",
+			content: "[Time]\n#NTP=\n#FallbackNTP=0.debian.pool.ntp.org\n",
+			argument: func(path string) interface{} {
+				return actions.NTPS{Path: path, Servers: []string{"time.cloudflare.com", "192.168.1.1"}}
+			},
+			wantedContent: "[Time]\nNTP=time.cloudflare.com 192.168.1.1\n#FallbackNTP=0.debian.pool.ntp.org\n",
+		},
+		{
+			name:    "success default servers",
+			content: "[Time]\nNTP=pool.ntp.org\nNTP=time.google.com\n\n[Other]\nNTP=dummy\n",
+			argument: func(path string) interface{} {
+				return actions.OtherParams{Value: map[string]string{"path": path, "servers": ""}}
+			},
+			wantedContent: "[Time]\n#NTP=\n\n[Other]\nNTP=dummy\n",
+		},
+		{
+			name:    "success missing key",
+			content: "[Time]\n\n[Other]\n",
+			argument: func(path string) interface{} {
+				return actions.NTPS{Path: path, Servers: []string{"pool.ntp.org"}}
+			},
+			wantedContent: "[Time]\nNTP=pool.ntp.org\n\n[Other]\n",
+		},
+		{
+			name: "success missing file",
+			argument: func(path string) interface{} {
+				return actions.NTPS{Path: path, Servers: []string{"pool.ntp.org"}}
+			},
+			wantedContent: "[Time]\nNTP=pool.ntp.org\n",
+		},
+	}
+
+	for k, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(dir, fmt.Sprintf("timesyncd%v.conf", k))
+			if tc.content != "" {
+				if err := ioutil.WriteFile(path, []byte(tc.content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			a := actions.New()
+			setNTPServers, err := a.SetNTPServers(tc.argument(path))
+			assert.Equal(t, tc.wantedExitStatus, setNTPServers.ExitStatus)
+			assert.Equal(t, tc.wantedStderr, setNTPServers.Stderr)
+			assert.Equal(t, tc.wantedErr, err)
+
+			if tc.wantedContent != "" {
+				content, err := ioutil.ReadFile(path)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.wantedContent, string(content))
+			}
+		})
+	}
+}
+
+func TestEditWpaSupplicant(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wpa_supplicant")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "wpa_supplicant.conf")
+	if err := ioutil.WriteFile(path, []byte("country=GB\n\nnetwork={\n\tssid=\"Home\"\n}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name             string
+		argument         interface{}
+		wantedExitStatus uint8
+		wantedStderr     string
+		wantedErr        error
+	}{
+		{
+			name:             "error wrong type",
+			argument:         "dummy",
+			wantedExitStatus: 1,
+			wantedErr:        &actions.Error{Arguments: []string{"path", "operation", "id", "ssid", "psk", "hidden", "priority"}},
+		},
+		{
+			name:             "error missing file",
+			argument:         actions.WPO{Path: filepath.Join(dir, "dummy.conf"), Operation: wpaconf.Remove},
+			wantedExitStatus: 1,
+			wantedStderr:     "opening file failed",
+		},
+		{
+			name:             "error bad id",
+			argument:         actions.WPO{Path: path, Operation: wpaconf.Remove, Network: wpaconf.Network{ID: 3}},
+			wantedExitStatus: 1,
+			wantedStderr:     "bad id",
+		},
+		{
+			name:     "success add",
+			argument: actions.WPO{Path: path, Operation: wpaconf.Add, Network: wpaconf.Network{SSID: "Office", IsHidden: true}},
+		},
+		{
+			name: "success priority",
+			argument: actions.OtherParams{
+				Value: map[string]string{"path": path, "operation": wpaconf.Priority, "id": "1", "priority": "2"},
+			},
+		},
+		{
+			name:     "success remove",
+			argument: actions.WPO{Path: path, Operation: wpaconf.Remove, Network: wpaconf.Network{ID: 0}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := actions.New()
+			editWpaSupplicant, err := a.EditWpaSupplicant(tc.argument)
+			assert.Equal(t, tc.wantedExitStatus, editWpaSupplicant.ExitStatus)
+			assert.Equal(t, tc.wantedStderr, editWpaSupplicant.Stderr)
+			assert.Equal(t, tc.wantedErr, err)
+		})
+	}
+
+	content, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "country=GB\n\nnetwork={\n\tssid=\"Office\"\n\tkey_mgmt=NONE\n\tscan_ssid=1\n\tpriority=2\n}\n", string(content))
+}
+
+func TestApplyNetworkConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "netconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "dhcpcd.conf")
+	backup := filepath.Join(dir, "dhcpcd.conf.bak")
+
+	cases := []struct {
+		name             string
+		argument         interface{}
+		wantedExitStatus uint8
+		wantedStderr     string
+		wantedErr        error
+		wantedContent    string
+	}{
+		{
+			name:             "error wrong type",
+			argument:         "dummy",
+			wantedExitStatus: 1,
+			wantedErr:        &actions.Error{Arguments: []string{"interface", "command", "rollback", "path", "backup", "new", "addresses", "timeout"}},
+			wantedContent:    "new",
+		},
+		{
+			name:             "error command failing",
+			argument:         actions.ANC{Interface: "lo", Command: "echo down; exit 1", Rollback: "true", Path: path, Backup: backup},
+			wantedExitStatus: 1,
+			wantedStderr:     "applying configuration failed: down, previous configuration restored",
+			wantedContent:    "old",
+		},
+		{
+			name:             "error interface not up",
+			argument:         actions.ANC{Interface: "dummy0", Command: "true", Rollback: "true", Path: path, Backup: backup},
+			wantedExitStatus: 1,
+			wantedStderr:     "dummy0 did not come up, previous configuration restored",
+			wantedContent:    "old",
+		},
+		{
+			name:             "error address missing",
+			argument:         actions.ANC{Interface: "lo", Command: "true", Rollback: "false", Path: path, Backup: backup, Addresses: []string{"127.0.0.2/8"}},
+			wantedExitStatus: 1,
+			wantedStderr:     "lo did not come up, applying previous configuration failed",
+			wantedContent:    "old",
+		},
+		{
+			name:             "error new file removed",
+			argument:         actions.ANC{Interface: "dummy0", Command: "true", Rollback: "true", Path: path, Backup: backup, IsNew: true},
+			wantedExitStatus: 1,
+			wantedStderr:     "dummy0 did not come up, previous configuration restored",
+		},
+		{
+			name:          "success",
+			argument:      actions.ANC{Interface: "lo", Command: "true", Path: path, Backup: backup, Addresses: []string{"127.0.0.1/8"}},
+			wantedContent: "new",
+		},
+		{
+			name: "success other params",
+			argument: actions.OtherParams{
+				Value: map[string]string{"interface": "lo", "command": "true", "path": path, "backup": backup, "timeout": "1"},
+			},
+			wantedContent: "new",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := ioutil.WriteFile(path, []byte("new"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := ioutil.WriteFile(backup, []byte("old"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			a := actions.New()
+			applyNetworkConfig, err := a.ApplyNetworkConfig(tc.argument)
+			assert.Equal(t, tc.wantedExitStatus, applyNetworkConfig.ExitStatus)
+			assert.Equal(t, tc.wantedStderr, applyNetworkConfig.Stderr)
+			assert.Equal(t, tc.wantedErr, err)
+
+			content, _ := ioutil.ReadFile(path)
+			assert.Equal(t, tc.wantedContent, string(content))
+		})
+	}
+}
+
 func TestFlattenPlan(t *testing.T) {
 	cases := []struct {
 		name       string
@@ -0,0 +1,374 @@
+// Package bootconfig parses and writes the raspberry pi /boot/config.txt file.
+// Every line keeps its original text so that comments, blank lines and ordering
+// are left untouched when the file is written back.
+package bootconfig
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultSection is the filter applying to every board, used before any filter line
+	DefaultSection = "all"
+
+	// Blank is the kind of an empty line
+	Blank = "blank"
+
+	// Text is the kind of a comment which is not a commented setting
+	Text = "text"
+
+	// Section is the kind of a conditional filter line (ex: [pi4])
+	Section = "section"
+
+	// Setting is the kind of a key=value line, commented or not
+	Setting = "setting"
+
+	// Include is the kind of an include directive line
+	Include = "include"
+
+	// Set sets the value of a key
+	Set = "set"
+
+	// Unset removes a key
+	Unset = "unset"
+
+	// Comment comments a key
+	Comment = "comment"
+
+	// Uncomment uncomments a key
+	Uncomment = "uncomment"
+
+	// Min sets the value of a numeric key only when it is lower than the given value
+	Min = "min"
+)
+
+var (
+	// Operations lists the operations that can be applied to a key
+	Operations = []string{Set, Unset, Comment, Uncomment, Min}
+
+	settingRegex = regexp.MustCompile(`^\s*(#\s*)?([A-Za-z0-9_]+)\s*=\s*(.*?)\s*$`)
+	sectionRegex = regexp.MustCompile(`^\s*\[([^\]]+)\]\s*(#.*)?$`)
+	includeRegex = regexp.MustCompile(`^\s*include\s+(\S+)\s*$`)
+	keyRegex     = regexp.MustCompile(`^([A-Za-z0-9_]+|(dtparam|dtoverlay)=[A-Za-z0-9_\-]+)$`)
+	filterRegex  = regexp.MustCompile(`^[A-Za-z0-9_:=+\-]+$`)
+)
+
+// Line represents a single line of config.txt
+type Line struct {
+	Raw       string
+	Kind      string
+	Section   string
+	Key       string
+	Value     string
+	Commented bool
+}
+
+// Config represents a parsed config.txt
+type Config struct {
+	Lines []Line
+}
+
+// Parse parses the lines of a config.txt file
+func Parse(lines []string) Config {
+	section := DefaultSection
+	c := Config{}
+
+	for _, raw := range lines {
+		line := Line{Raw: raw, Kind: Text, Section: section}
+		trimmed := strings.TrimSpace(raw)
+
+		if trimmed == "" {
+			line.Kind = Blank
+		} else if m := sectionRegex.FindStringSubmatch(raw); m != nil {
+			section = strings.TrimSpace(m[1])
+			line.Kind = Section
+			line.Section = section
+		} else if m := includeRegex.FindStringSubmatch(raw); m != nil {
+			line.Kind = Include
+			line.Value = m[1]
+		} else if m := settingRegex.FindStringSubmatch(raw); m != nil {
+			line.Kind = Setting
+			line.Commented = m[1] != ""
+			line.Key, line.Value = splitKey(m[2], m[3])
+		}
+
+		c.Lines = append(c.Lines, line)
+	}
+
+	return c
+}
+
+// splitKey moves the parameter or overlay name of dtparam and dtoverlay lines into the key
+// so that each of them can be addressed on its own (ex: dtparam=spi=on has key dtparam=spi)
+func splitKey(key string, value string) (string, string) {
+	switch key {
+	case "dtparam":
+		if i := strings.Index(value, "="); i >= 0 {
+			return key + "=" + value[:i], value[i+1:]
+		}
+		return key + "=" + value, ""
+	case "dtoverlay":
+		if i := strings.IndexAny(value, ",:"); i >= 0 {
+			return key + "=" + value[:i], value[i+1:]
+		}
+		return key + "=" + value, ""
+	}
+
+	return key, value
+}
+
+// Format returns the config.txt representation of a key and its value
+func Format(key string, value string, commented bool) string {
+	var line string
+
+	switch {
+	case strings.HasPrefix(key, "dtparam="):
+		line = key
+		if value != "" {
+			line += "=" + value
+		}
+	case strings.HasPrefix(key, "dtoverlay="):
+		line = key
+		if value != "" {
+			line += "," + value
+		}
+	default:
+		line = key + "=" + value
+	}
+
+	if commented {
+		return "#" + line
+	}
+
+	return line
+}
+
+// OverlayParams returns the parameters set by the value of a dtoverlay key (ex: gpiopin=4,pullup)
+func OverlayParams(value string) map[string]string {
+	result := map[string]string{}
+	for _, p := range strings.Split(value, ",") {
+		if p = strings.TrimSpace(p); p == "" {
+			continue
+		}
+
+		kv := strings.SplitN(p, "=", 2)
+		if len(kv) == 2 {
+			result[kv[0]] = kv[1]
+		} else {
+			result[kv[0]] = ""
+		}
+	}
+	return result
+}
+
+// FormatOverlayParams returns the value of a dtoverlay key setting parameters, sorted by name
+func FormatOverlayParams(params map[string]string) string {
+	names := []string{}
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := []string{}
+	for _, name := range names {
+		if params[name] == "" {
+			result = append(result, name)
+		} else {
+			result = append(result, name+"="+params[name])
+		}
+	}
+	return strings.Join(result, ",")
+}
+
+// IsKey checks if a key can be edited
+func IsKey(key string) bool {
+	return keyRegex.MatchString(key)
+}
+
+// IsSection checks if a conditional filter is well formed (ex: pi4, HDMI:0, gpio4=1)
+func IsSection(section string) bool {
+	return filterRegex.MatchString(section)
+}
+
+// Raw returns the lines of the config file, as they should be written
+func (c Config) Raw() []string {
+	result := make([]string, len(c.Lines))
+	for i, l := range c.Lines {
+		result[i] = l.Raw
+	}
+	return result
+}
+
+// Includes returns the files included by the config file
+func (c Config) Includes() []string {
+	result := []string{}
+	for _, l := range c.Lines {
+		if l.Kind == Include {
+			result = append(result, l.Value)
+		}
+	}
+	return result
+}
+
+// Get returns the value of the last active occurrence of a key in a section
+func (c Config) Get(section string, key string) (string, bool) {
+	value, isFound := "", false
+	for _, l := range c.Lines {
+		if l.Kind == Setting && !l.Commented && l.Section == section && l.Key == key {
+			value, isFound = l.Value, true
+		}
+	}
+	return value, isFound
+}
+
+// Apply applies an operation to a key within a section
+func (c *Config) Apply(operation string, section string, key string, value string) error {
+	if !IsSection(section) {
+		return fmt.Errorf("bad section")
+	}
+
+	if !IsKey(key) {
+		return fmt.Errorf("bad key")
+	}
+
+	if strings.ContainsAny(value, "\r\n") {
+		return fmt.Errorf("bad value")
+	}
+
+	switch operation {
+	case Set:
+		c.set(section, key, value)
+	case Unset:
+		c.unset(section, key)
+	case Comment:
+		c.comment(section, key)
+	case Uncomment:
+		c.uncomment(section, key, value)
+	case Min:
+		wanted, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("bad value")
+		}
+
+		if current, isFound := c.Get(section, key); isFound {
+			if n, err := strconv.Atoi(current); err == nil && n >= wanted {
+				return nil
+			}
+		}
+		c.set(section, key, value)
+	default:
+		return fmt.Errorf("bad operation")
+	}
+
+	return nil
+}
+
+// indexes returns the indexes of the lines holding a key in a section
+func (c Config) indexes(section string, key string, commented bool) []int {
+	result := []int{}
+	for i, l := range c.Lines {
+		if l.Kind == Setting && l.Commented == commented && l.Section == section && l.Key == key {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+func (c *Config) write(i int, value string, commented bool) {
+	c.Lines[i].Value = value
+	c.Lines[i].Commented = commented
+	c.Lines[i].Raw = Format(c.Lines[i].Key, value, commented)
+}
+
+func (c *Config) set(section string, key string, value string) {
+	if active := c.indexes(section, key, false); len(active) > 0 {
+		for _, i := range active {
+			c.write(i, value, false)
+		}
+		return
+	}
+
+	if commented := c.indexes(section, key, true); len(commented) > 0 {
+		c.write(commented[len(commented)-1], value, false)
+		return
+	}
+
+	c.insert(section, key, value)
+}
+
+func (c *Config) unset(section string, key string) {
+	lines := []Line{}
+	for _, l := range c.Lines {
+		if l.Kind == Setting && !l.Commented && l.Section == section && l.Key == key {
+			continue
+		}
+		lines = append(lines, l)
+	}
+	c.Lines = lines
+}
+
+func (c *Config) comment(section string, key string) {
+	for _, i := range c.indexes(section, key, false) {
+		c.write(i, c.Lines[i].Value, true)
+	}
+}
+
+func (c *Config) uncomment(section string, key string, value string) {
+	if len(c.indexes(section, key, false)) > 0 {
+		return
+	}
+
+	if commented := c.indexes(section, key, true); len(commented) > 0 {
+		i := commented[len(commented)-1]
+		if value == "" {
+			value = c.Lines[i].Value
+		}
+		c.write(i, value, false)
+		return
+	}
+
+	c.insert(section, key, value)
+}
+
+// insert adds a key after the last non blank line of the last block of a section.
+// The section is appended at the end of the file when it does not exist yet.
+func (c *Config) insert(section string, key string, value string) {
+	line := Line{
+		Raw:     Format(key, value, false),
+		Kind:    Setting,
+		Section: section,
+		Key:     key,
+		Value:   value,
+	}
+
+	position := -1
+	isFound := false
+	for i, l := range c.Lines {
+		if l.Section != section {
+			continue
+		}
+		if !isFound || l.Kind != Blank {
+			position = i
+		}
+		isFound = true
+	}
+
+	if len(c.Lines) == 0 && section == DefaultSection {
+		c.Lines = append(c.Lines, line)
+		return
+	}
+
+	if !isFound {
+		if len(c.Lines) > 0 && c.Lines[len(c.Lines)-1].Kind != Blank {
+			c.Lines = append(c.Lines, Line{Kind: Blank, Section: c.Lines[len(c.Lines)-1].Section})
+		}
+		c.Lines = append(c.Lines, Line{Raw: "[" + section + "]", Kind: Section, Section: section}, line)
+		return
+	}
+
+	c.Lines = append(c.Lines[:position+1], append([]Line{line}, c.Lines[position+1:]...)...)
+}
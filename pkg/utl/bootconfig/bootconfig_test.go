@@ -0,0 +1,227 @@
+package bootconfig_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/raspibuddy/rpi/pkg/utl/bootconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+var lines = []string{
+	"# For more options and information see",
+	"#disable_overscan=1",
+	"dtparam=i2c_arm=on",
+	"#dtparam=spi=on",
+	"dtparam=audio=on",
+	"include extraconfig.txt",
+	"",
+	"[pi4]",
+	"dtoverlay=vc4-fkms-v3d,cma-128",
+	"max_framebuffers=2",
+	"",
+	"[all]",
+	"#dtoverlay=w1-gpio",
+	"gpu_mem=64",
+}
+
+func TestParse(t *testing.T) {
+	c := bootconfig.Parse(lines)
+
+	assert.Equal(t, lines, c.Raw())
+	assert.Equal(t, []string{"extraconfig.txt"}, c.Includes())
+	assert.Equal(t, bootconfig.Line{
+		Raw:       "#dtparam=spi=on",
+		Kind:      bootconfig.Setting,
+		Section:   "all",
+		Key:       "dtparam=spi",
+		Value:     "on",
+		Commented: true,
+	}, c.Lines[3])
+	assert.Equal(t, bootconfig.Line{
+		Raw:     "dtoverlay=vc4-fkms-v3d,cma-128",
+		Kind:    bootconfig.Setting,
+		Section: "pi4",
+		Key:     "dtoverlay=vc4-fkms-v3d",
+		Value:   "cma-128",
+	}, c.Lines[8])
+	assert.Equal(t, bootconfig.Text, c.Lines[0].Kind)
+	assert.Equal(t, bootconfig.Section, c.Lines[7].Kind)
+
+	value, isFound := c.Get("pi4", "max_framebuffers")
+	assert.Equal(t, "2", value)
+	assert.True(t, isFound)
+
+	_, isFound = c.Get("all", "max_framebuffers")
+	assert.False(t, isFound)
+}
+
+func TestApply(t *testing.T) {
+	cases := []struct {
+		name        string
+		operation   string
+		section     string
+		key         string
+		value       string
+		wantedLines []string
+		wantedErr   error
+	}{
+		{
+			name:      "error: bad operation",
+			operation: "toggle",
+			section:   "all",
+			key:       "gpu_mem",
+			wantedErr: fmt.Errorf("bad operation"),
+		},
+		{
+			name:      "error: bad section",
+			operation: bootconfig.Set,
+			section:   "pi4]",
+			key:       "gpu_mem",
+			wantedErr: fmt.Errorf("bad section"),
+		},
+		{
+			name:      "error: bad key",
+			operation: bootconfig.Set,
+			section:   "all",
+			key:       "gpu mem",
+			wantedErr: fmt.Errorf("bad key"),
+		},
+		{
+			name:      "error: bad min value",
+			operation: bootconfig.Min,
+			section:   "all",
+			key:       "gpu_mem",
+			value:     "a lot",
+			wantedErr: fmt.Errorf("bad value"),
+		},
+		{
+			name:      "success: set existing key",
+			operation: bootconfig.Set,
+			section:   "all",
+			key:       "dtparam=i2c_arm",
+			value:     "off",
+			wantedLines: append(append([]string{}, lines[:2]...), append([]string{
+				"dtparam=i2c_arm=off",
+			}, lines[3:]...)...),
+		},
+		{
+			name:      "success: set commented key",
+			operation: bootconfig.Set,
+			section:   "all",
+			key:       "disable_overscan",
+			value:     "0",
+			wantedLines: append(append([]string{}, lines[:1]...), append([]string{
+				"disable_overscan=0",
+			}, lines[2:]...)...),
+		},
+		{
+			name:        "success: set new key in last block of the section",
+			operation:   bootconfig.Set,
+			section:     "all",
+			key:         "start_x",
+			value:       "1",
+			wantedLines: append(append([]string{}, lines...), "start_x=1"),
+		},
+		{
+			name:        "success: set new key in a new section",
+			operation:   bootconfig.Set,
+			section:     "pi3",
+			key:         "arm_freq",
+			value:       "1300",
+			wantedLines: append(append([]string{}, lines...), "", "[pi3]", "arm_freq=1300"),
+		},
+		{
+			name:        "success: set new key in a pi3+ section",
+			operation:   bootconfig.Set,
+			section:     "pi3+",
+			key:         "arm_freq",
+			value:       "1450",
+			wantedLines: append(append([]string{}, lines...), "", "[pi3+]", "arm_freq=1450"),
+		},
+		{
+			name:      "success: set new key in an existing section",
+			operation: bootconfig.Set,
+			section:   "pi4",
+			key:       "arm_freq",
+			value:     "1800",
+			wantedLines: append(append([]string{}, lines[:10]...), append([]string{
+				"arm_freq=1800",
+			}, lines[10:]...)...),
+		},
+		{
+			name:        "success: unset",
+			operation:   bootconfig.Unset,
+			section:     "all",
+			key:         "dtparam=audio",
+			wantedLines: append(append([]string{}, lines[:4]...), lines[5:]...),
+		},
+		{
+			name:      "success: comment",
+			operation: bootconfig.Comment,
+			section:   "pi4",
+			key:       "dtoverlay=vc4-fkms-v3d",
+			wantedLines: append(append([]string{}, lines[:8]...), append([]string{
+				"#dtoverlay=vc4-fkms-v3d,cma-128",
+			}, lines[9:]...)...),
+		},
+		{
+			name:        "success: comment in another section does nothing",
+			operation:   bootconfig.Comment,
+			section:     "all",
+			key:         "max_framebuffers",
+			wantedLines: lines,
+		},
+		{
+			name:      "success: uncomment",
+			operation: bootconfig.Uncomment,
+			section:   "all",
+			key:       "dtoverlay=w1-gpio",
+			wantedLines: append(append([]string{}, lines[:12]...), append([]string{
+				"dtoverlay=w1-gpio",
+			}, lines[13:]...)...),
+		},
+		{
+			name:        "success: min raises lower value",
+			operation:   bootconfig.Min,
+			section:     "all",
+			key:         "gpu_mem",
+			value:       "128",
+			wantedLines: append(append([]string{}, lines[:13]...), "gpu_mem=128"),
+		},
+		{
+			name:        "success: min keeps higher value",
+			operation:   bootconfig.Min,
+			section:     "all",
+			key:         "gpu_mem",
+			value:       "32",
+			wantedLines: lines,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := bootconfig.Parse(lines)
+			err := c.Apply(tc.operation, tc.section, tc.key, tc.value)
+			assert.Equal(t, tc.wantedErr, err)
+			if tc.wantedErr == nil {
+				assert.Equal(t, tc.wantedLines, c.Raw())
+				assert.Equal(t, tc.wantedLines, bootconfig.Parse(c.Raw()).Raw())
+			}
+		})
+	}
+}
+
+func TestApplyEmptyFile(t *testing.T) {
+	c := bootconfig.Parse(nil)
+	assert.Nil(t, c.Apply(bootconfig.Set, bootconfig.DefaultSection, "dtparam=spi", "on"))
+	assert.Equal(t, []string{"dtparam=spi=on"}, c.Raw())
+}
+
+func TestOverlayParams(t *testing.T) {
+	params := bootconfig.OverlayParams("gpiopin=4, pullup,,extpullup=17")
+	assert.Equal(t, map[string]string{"gpiopin": "4", "pullup": "", "extpullup": "17"}, params)
+	assert.Equal(t, "extpullup=17,gpiopin=4,pullup", bootconfig.FormatOverlayParams(params))
+	assert.Equal(t, map[string]string{}, bootconfig.OverlayParams(""))
+	assert.Equal(t, "", bootconfig.FormatOverlayParams(map[string]string{}))
+}
@@ -22,7 +22,9 @@ func Load(path string) (*Configuration, error) {
 
 // Configuration holds data necessary for configuring application
 type Configuration struct {
-	Server *Server `yaml:"server,omitempty"`
+	Server    *Server    `yaml:"server,omitempty"`
+	Forecast  *Forecast  `yaml:"forecast,omitempty"`
+	Overclock *Overclock `yaml:"overclock,omitempty"`
 }
 
 // Server holds data necessary for server configuration
@@ -32,3 +34,16 @@ type Server struct {
 	ReadTimeout  int    `yaml:"read_timeout_seconds,omitempty"`
 	WriteTimeout int    `yaml:"write_timeout_seconds,omitempty"`
 }
+
+// Forecast holds data necessary for the disk fill-rate forecast
+type Forecast struct {
+	SampleInterval int    `yaml:"sample_interval_minutes,omitempty"`
+	MaxSamples     int    `yaml:"max_samples,omitempty"`
+	Horizon        int    `yaml:"horizon_hours,omitempty"`
+	HistoryFile    string `yaml:"history_file,omitempty"`
+}
+
+// Overclock holds data necessary for the overclock pre-checks
+type Overclock struct {
+	HistoryFile string `yaml:"history_file,omitempty"`
+}
@@ -16,6 +16,147 @@ const (
 
 	// NETWORKINTERFACES directory
 	NETWORKINTERFACES = "/sys/class/net"
+
+	// BLOCKDEVICES directory
+	BLOCKDEVICES = "/sys/block"
+
+	// CPUDEVICES directory
+	CPUDEVICES = "/sys/devices/system/cpu"
+
+	// CPUGOVERNORSERVICE file
+	CPUGOVERNORSERVICE = "/etc/systemd/system/raspibuddy-cpugovernor.service"
+
+	// PROC directory
+	PROC = "/proc"
+
+	// PRESSURE directory
+	PRESSURE = "/proc/pressure"
+
+	// CGROUP directory
+	CGROUP = "/sys/fs/cgroup"
+
+	// KMSG device
+	KMSG = "/dev/kmsg"
+
+	// ETCPASSWD file
+	ETCPASSWD = "/etc/passwd"
+
+	// ETCGROUP file
+	ETCGROUP = "/etc/group"
+
+	// ETCSHADOW file
+	ETCSHADOW = "/etc/shadow"
+
+	// ETCSHELLS file
+	ETCSHELLS = "/etc/shells"
+
+	// ETCMODULES file
+	ETCMODULES = "/etc/modules"
+
+	// WPASUPPLICANT file
+	WPASUPPLICANT = "/etc/wpa_supplicant/wpa_supplicant.conf"
+
+	// OPENVPN directory
+	OPENVPN = "/etc/openvpn"
+
+	// BACKUPS directory
+	BACKUPS = "/etc/raspibuddy/backups"
+
+	// OVERLAYS directory
+	OVERLAYS = "/boot/overlays"
+
+	// DEVICETREE directory
+	DEVICETREE = "/proc/device-tree"
+
+	// THERMAL directory
+	THERMAL = "/sys/class/thermal"
+
+	// OVERCLOCK directory
+	OVERCLOCK = "/etc/raspibuddy/overclock"
+
+	// OVERCLOCKGUARDSERVICE file
+	OVERCLOCKGUARDSERVICE = "/etc/systemd/system/raspibuddy-overclock-guard.service"
+
+	// ZONEINFO directory
+	ZONEINFO = "/usr/share/zoneinfo"
+
+	// ETCTIMEZONE file
+	ETCTIMEZONE = "/etc/timezone"
+
+	// I18NSUPPORTED file
+	I18NSUPPORTED = "/usr/share/i18n/SUPPORTED"
+
+	// LOCALEGEN file
+	LOCALEGEN = "/etc/locale.gen"
+
+	// DEFAULTLOCALE file
+	DEFAULTLOCALE = "/etc/default/locale"
+
+	// XKBRULES file
+	XKBRULES = "/usr/share/X11/xkb/rules/base.lst"
+
+	// DEFAULTKEYBOARD file
+	DEFAULTKEYBOARD = "/etc/default/keyboard"
+
+	// TIMESYNCDCONF file
+	TIMESYNCDCONF = "/etc/systemd/timesyncd.conf"
+
+	// DHCPCDCONF file
+	DHCPCDCONF = "/etc/dhcpcd.conf"
+
+	// NMCONNECTIONS directory
+	NMCONNECTIONS = "/etc/NetworkManager/system-connections"
+
+	// NETCONFIG directory
+	NETCONFIG = "/etc/raspibuddy/netconfig"
+
+	// HOTSPOT directory
+	HOTSPOT = "/etc/raspibuddy/hotspot"
+
+	// HOSTAPDCONF file
+	HOSTAPDCONF = "/etc/hostapd/hostapd.conf"
+
+	// DNSMASQHOTSPOT file
+	DNSMASQHOTSPOT = "/etc/dnsmasq.d/raspibuddy-hotspot.conf"
+
+	// DNSMASQLEASES file
+	DNSMASQLEASES = "/var/lib/misc/dnsmasq.leases"
+
+	// NMHOTSPOT file
+	NMHOTSPOT = "/etc/NetworkManager/system-connections/raspibuddy-hotspot.nmconnection"
+
+	// NMDNSMASQHOTSPOT file
+	NMDNSMASQHOTSPOT = "/etc/NetworkManager/dnsmasq-shared.d/raspibuddy-hotspot.conf"
+
+	// NMLEASES directory
+	NMLEASES = "/var/lib/NetworkManager"
+
+	// FIREWALL directory
+	FIREWALL = "/etc/raspibuddy/firewall"
+
+	// NFTABLESCONF file
+	NFTABLESCONF = "/etc/nftables.conf"
+
+	// NFTABLESRULES file
+	NFTABLESRULES = "/etc/nftables.d/raspibuddy.nft"
+
+	// FIREWALLPENDING file
+	FIREWALLPENDING = "/run/raspibuddy/firewall.nft"
+
+	// SSH directory
+	SSH = "/etc/raspibuddy/ssh"
+
+	// SSHDCONFIG file
+	SSHDCONFIG = "/etc/ssh/sshd_config"
+
+	// SSHDCONFIGDIR directory
+	SSHDCONFIGDIR = "/etc/ssh/sshd_config.d"
+
+	// SSHDRASPIBUDDY file, first of the included files so that its options win
+	SSHDRASPIBUDDY = "/etc/ssh/sshd_config.d/00-raspibuddy.conf"
+
+	// SSHHOSTKEYS pattern
+	SSHHOSTKEYS = "/etc/ssh/ssh_host_*_key.pub"
 )
 
 var COUNTRIES = []string{
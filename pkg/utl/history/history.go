@@ -0,0 +1,133 @@
+package history
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Sample represents a value recorded at a given time.
+type Sample struct {
+	Time  int64  `json:"time"`
+	Value uint64 `json:"value"`
+	Total uint64 `json:"total"`
+}
+
+// Store keeps a bounded history of samples per key
+// and optionally persists it to a json file.
+type Store struct {
+	mu         sync.RWMutex
+	maxSamples int
+	path       string
+	samples    map[string][]Sample
+}
+
+// New creates a Store keeping at most maxSamples samples per key.
+// If path is not empty, the history previously saved in this file is loaded.
+func New(maxSamples int, path string) *Store {
+	s := &Store{
+		maxSamples: maxSamples,
+		path:       path,
+		samples:    make(map[string][]Sample),
+	}
+
+	if path != "" {
+		if content, err := ioutil.ReadFile(path); err == nil {
+			_ = json.Unmarshal(content, &s.samples)
+		}
+	}
+
+	return s
+}
+
+// Add appends a sample to the history of a key and drops the oldest samples beyond the limit.
+func (s *Store) Add(key string, sample Sample) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	samples := append(s.samples[key], sample)
+	if s.maxSamples > 0 && len(samples) > s.maxSamples {
+		samples = samples[len(samples)-s.maxSamples:]
+	}
+	s.samples[key] = samples
+}
+
+// Samples returns a copy of the history of every key.
+func (s *Store) Samples() map[string][]Sample {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make(map[string][]Sample, len(s.samples))
+	for k, v := range s.samples {
+		result[k] = append([]Sample(nil), v...)
+	}
+
+	return result
+}
+
+// Keys returns the sorted list of keys having a history.
+func (s *Store) Keys() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var keys []string
+	for k := range s.samples {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Save persists the history to the store file, if any, creating its directory when missing.
+func (s *Store) Save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	content, err := json.Marshal(s.samples)
+	s.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	tmp := s.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, content, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, s.path)
+}
+
+// Run calls collect every interval, records the returned samples and saves the history.
+// It returns when stop is closed.
+func (s *Store) Run(interval time.Duration, collect func() map[string]Sample, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for k, v := range collect() {
+			s.Add(k, v)
+		}
+		if err := s.Save(); err != nil {
+			log.Error().Err(err).Str("path", s.path).Msg("could not save the history")
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package history_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/raspibuddy/rpi/pkg/utl/history"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdd(t *testing.T) {
+	cases := []struct {
+		name       string
+		maxSamples int
+		samples    []history.Sample
+		wantedData map[string][]history.Sample
+	}{
+		{
+			name:       "success: below the limit",
+			maxSamples: 3,
+			samples:    []history.Sample{{Time: 1, Value: 10}, {Time: 2, Value: 20}},
+			wantedData: map[string][]history.Sample{
+				"/": {{Time: 1, Value: 10}, {Time: 2, Value: 20}},
+			},
+		},
+		{
+			name:       "success: oldest samples dropped",
+			maxSamples: 2,
+			samples:    []history.Sample{{Time: 1, Value: 10}, {Time: 2, Value: 20}, {Time: 3, Value: 30}},
+			wantedData: map[string][]history.Sample{
+				"/": {{Time: 2, Value: 20}, {Time: 3, Value: 30}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := history.New(tc.maxSamples, "")
+			for _, v := range tc.samples {
+				s.Add("/", v)
+			}
+			assert.Equal(t, tc.wantedData, s.Samples())
+			assert.Equal(t, []string{"/"}, s.Keys())
+		})
+	}
+}
+
+func TestSave(t *testing.T) {
+	dir, err := ioutil.TempDir("", "history")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "metrics", "history.json")
+
+	s := history.New(10, path)
+	s.Add("/boot", history.Sample{Time: 1, Value: 10, Total: 100})
+	assert.Nil(t, s.Save())
+
+	loaded := history.New(10, path)
+	assert.Equal(t, s.Samples(), loaded.Samples())
+}
+
+func TestRun(t *testing.T) {
+	s := history.New(10, "")
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	calls := 0
+
+	go func() {
+		s.Run(time.Millisecond, func() map[string]history.Sample {
+			calls++
+			if calls == 3 {
+				close(stop)
+			}
+			return map[string]history.Sample{"/": {Time: int64(calls), Value: uint64(calls)}}
+		}, stop)
+		close(done)
+	}()
+
+	<-done
+	assert.Equal(t, 3, len(s.Samples()["/"]))
+}
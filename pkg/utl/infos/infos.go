@@ -2,6 +2,7 @@ package infos
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -14,6 +15,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/karrick/godirwalk"
@@ -156,6 +158,12 @@ func (s Service) IsFileExists(filePath string) bool {
 	}
 }
 
+// IsSymlink checks if a file is a symbolic link, without following it
+func (s Service) IsSymlink(filePath string) bool {
+	stat, err := os.Lstat(filePath)
+	return err == nil && stat.Mode()&os.ModeSymlink != 0
+}
+
 // IsDirectory determines if a file represented
 // by `path` is a directory or not
 // func (s Service) IsDirectory(path string) (bool, error) {
@@ -486,6 +494,60 @@ func (s Service) IsWpaSupCom() map[string]bool {
 	return result
 }
 
+// WifiCountry returns the regulatory country set in wpa_supplicant for a wifi interface
+func (s Service) WifiCountry(iface string) string {
+	res, err := exec.Command("wpa_cli", "-i", iface, "get", "country").Output()
+	if err != nil {
+		return ""
+	}
+
+	country := strings.TrimSpace(string(res))
+	if country == "FAIL" {
+		return ""
+	}
+	return country
+}
+
+// WpaCli returns the output lines of a wpa_cli command run against a wifi interface (ex: scan_results)
+func (s Service) WpaCli(iface string, args ...string) ([]string, error) {
+	res, err := exec.Command("wpa_cli", append([]string{"-i", iface}, args...)...).Output()
+	if err != nil {
+		return nil, err
+	}
+
+	out := strings.TrimSpace(string(res))
+	if strings.HasPrefix(out, "FAIL") {
+		return nil, fmt.Errorf("wpa_cli %v failed", strings.Join(args, " "))
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// NMConnections returns the connections known by NetworkManager, in the terse format of
+// 'nmcli connection show' (name:uuid:device:filename, device being empty when inactive)
+func (s Service) NMConnections() []string {
+	return commandLines("nmcli", "-t", "-f", "NAME,UUID,DEVICE,FILENAME", "connection", "show")
+}
+
+// StationDump returns the stations associated with a wifi interface, as listed by 'iw dev <iface> station dump'
+func (s Service) StationDump(iface string) []string {
+	return commandLines("iw", "dev", iface, "station", "dump")
+}
+
+// NftRuleset returns the ruleset of nftables in JSON, as listed by 'nft -j list ruleset'
+func (s Service) NftRuleset() []string {
+	return commandLines("nft", "-j", "list", "ruleset")
+}
+
+// SshdConfig returns the options used by sshd, as printed by 'sshd -T' (ex: port 22)
+func (s Service) SshdConfig() []string {
+	return commandLines("sshd", "-T")
+}
+
+// LastLog returns the most recent login of all users, as printed by 'lastlog' (ex: pi pts/0 192.168.1.10 Mon Oct 19 10:00:00 +0200 2026)
+func (s Service) LastLog() []string {
+	return commandLines("lastlog")
+}
+
 func (s Service) ZoneInfo(filePath string) map[string]string {
 	result := make(map[string]string)
 	zi, err := s.ReadFile(filePath)
@@ -840,3 +902,391 @@ func (s Service) IsPortListening(port int32) bool {
 
 	return strings.Contains(resClean, "listen")
 }
+
+// ListBlockDevices returns a list of physical block devices
+// (loop, ram, zram, optical, device-mapper and software raid devices excluded, as well as the eMMC boot and rpmb areas)
+func (s Service) ListBlockDevices(directoryPath string) []string {
+	var blockDevices []string
+
+	files, err := ioutil.ReadDir(directoryPath)
+	if err != nil {
+		return nil
+	}
+
+	r := regexp.MustCompile("^((loop|ram|zram|sr|dm-|md)[0-9]+|mmcblk[0-9]+(boot[0-9]+|rpmb))$")
+	for _, f := range files {
+		if !r.MatchString(f.Name()) {
+			blockDevices = append(blockDevices, f.Name())
+		}
+	}
+
+	sort.Strings(blockDevices)
+
+	return blockDevices
+}
+
+// ReadSysfsAttributes reads single value sysfs attributes located in a directory
+// attributes which do not exist or cannot be read are left out of the result
+func (s Service) ReadSysfsAttributes(directoryPath string, attributes []string) map[string]string {
+	result := make(map[string]string)
+
+	for _, a := range attributes {
+		content, err := ioutil.ReadFile(filepath.Join(directoryPath, a))
+		if err != nil {
+			continue
+		}
+		result[a] = strings.TrimSpace(string(content))
+	}
+
+	return result
+}
+
+// SmartAttribute represents an ATA SMART attribute as returned by smartctl
+type SmartAttribute struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Raw  struct {
+		Value int64 `json:"value"`
+	} `json:"raw"`
+}
+
+// SmartReport represents the subset of a smartctl json report used to assess a drive health
+type SmartReport struct {
+	ModelName    string `json:"model_name"`
+	SerialNumber string `json:"serial_number"`
+	SmartStatus  *struct {
+		Passed bool `json:"passed"`
+	} `json:"smart_status"`
+	Temperature struct {
+		Current int `json:"current"`
+	} `json:"temperature"`
+	PowerOnTime struct {
+		Hours int64 `json:"hours"`
+	} `json:"power_on_time"`
+	PowerCycleCount    int64 `json:"power_cycle_count"`
+	AtaSmartAttributes struct {
+		Table []SmartAttribute `json:"table"`
+	} `json:"ata_smart_attributes"`
+	NvmeSmartHealthInformationLog *struct {
+		PercentageUsed int64 `json:"percentage_used"`
+		MediaErrors    int64 `json:"media_errors"`
+	} `json:"nvme_smart_health_information_log"`
+}
+
+// Smartctl returns the smartctl json report of a block device
+func (s Service) Smartctl(device string) (SmartReport, error) {
+	var report SmartReport
+
+	// smartctl exit status is a bit mask which is often
+	// different from 0 even though a report is printed out
+	// hence the exit status is ignored as long as stdout is not empty
+	res, err := exec.Command("smartctl", "--json", "-a", fmt.Sprintf("/dev/%v", device)).Output()
+	if len(res) == 0 {
+		if err == nil {
+			err = fmt.Errorf("smartctl returned an empty report")
+		}
+		return SmartReport{}, err
+	}
+
+	if err := json.Unmarshal(res, &report); err != nil {
+		return SmartReport{}, err
+	}
+
+	return report, nil
+}
+
+// ReadOnlyRemounts returns the kernel log lines reporting a filesystem remounted read-only
+func (s Service) ReadOnlyRemounts() []string {
+	var result []string
+
+	// grep returns an exit status 1 when no line matches
+	// thus this error is not checked
+	command := "dmesg 2> /dev/null | grep -i -E \"remount(ing|ed)? .*read-only\""
+	res, _ := exec.Command("sh", "-c", command).Output()
+
+	for _, line := range strings.Split(string(res), "\n") {
+		if strings.TrimSpace(line) != "" {
+			result = append(result, strings.TrimSpace(line))
+		}
+	}
+
+	return result
+}
+
+// ReadKmsg returns the raw records currently held in the kernel ring buffer (path is usually /dev/kmsg).
+// A record is made of a "prio,seq,ts,flag;message" line followed by optional continuation lines starting with a space.
+func (s Service) ReadKmsg(path string) ([]string, error) {
+	// the file is opened in non-blocking mode so the read stops at the end of the buffer
+	// instead of waiting for new messages
+	fd, err := syscall.Open(path, syscall.O_RDONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer syscall.Close(fd)
+
+	var content strings.Builder
+	buf := make([]byte, 8192)
+	for {
+		n, err := syscall.Read(fd, buf)
+		if err == syscall.EAGAIN {
+			break
+		}
+		// EPIPE means some records were overwritten while reading, the next read resumes with the oldest one
+		if err == syscall.EPIPE {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 {
+			break
+		}
+		content.Write(buf[:n])
+	}
+
+	var records []string
+	for _, line := range strings.Split(content.String(), "\n") {
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, " ") && len(records) > 0 {
+			records[len(records)-1] += "\n" + line
+			continue
+		}
+		records = append(records, line)
+	}
+
+	return records, nil
+}
+
+// Journalctl runs journalctl with args and returns its output lines
+func (s Service) Journalctl(args []string) ([]string, error) {
+	var result []string
+
+	out, err := exec.Command("journalctl", args...).Output()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range strings.Split(string(out), "\n") {
+		if strings.TrimSpace(line) != "" {
+			result = append(result, line)
+		}
+	}
+
+	return result, nil
+}
+
+// ListUnits returns the lines of 'systemctl list-units' for every service unit loaded in memory
+// i.e. "UNIT LOAD ACTIVE SUB DESCRIPTION"
+func (s Service) ListUnits() []string {
+	return commandLines("systemctl", "list-units", "--all", "--type=service", "--no-legend", "--no-pager", "--plain")
+}
+
+// ListUnitFiles returns the lines of 'systemctl list-unit-files' for every service unit installed
+// i.e. "UNIT STATE [PRESET]"
+func (s Service) ListUnitFiles() []string {
+	return commandLines("systemctl", "list-unit-files", "--type=service", "--no-legend", "--no-pager")
+}
+
+// IsUnit checks if a service unit is known by systemctl, either loaded or installed
+func (s Service) IsUnit(name string) bool {
+	for _, line := range append(s.ListUnits(), s.ListUnitFiles()...) {
+		fields := strings.Fields(line)
+		if len(fields) > 0 && fields[0] == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ShowUnit returns the properties of a unit as listed by 'systemctl show'
+func (s Service) ShowUnit(name string) (map[string]string, error) {
+	out, err := exec.Command(
+		"systemctl",
+		"show",
+		"--no-pager",
+		"--property=Id,Description,LoadState,ActiveState,SubState,UnitFileState,MainPID,MemoryCurrent,ActiveEnterTimestamp,FragmentPath",
+		name,
+	).Output()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string)
+	for _, line := range strings.Split(string(out), "\n") {
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) == 2 {
+			result[kv[0]] = kv[1]
+		}
+	}
+
+	return result, nil
+}
+
+// ReadFileBytes returns the raw content and the permission bits of a file
+func (s Service) ReadFileBytes(path string) ([]byte, uint32, error) {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return content, uint32(stat.Mode().Perm()), nil
+}
+
+// ListFiles returns every regular file found under the directories matching a glob pattern
+func (s Service) ListFiles(pattern string) []string {
+	var result []string
+
+	dirs, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil
+	}
+
+	for _, dir := range dirs {
+		filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err == nil && info.Mode().IsRegular() {
+				result = append(result, path)
+			}
+			return nil
+		})
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+// InstalledPackages returns the name of every package installed with dpkg
+func (s Service) InstalledPackages() ([]string, error) {
+	var result []string
+
+	out, err := exec.Command("dpkg-query", "-W", "-f=${db:Status-Abbrev} ${Package}\n").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range strings.Split(string(out), "\n") {
+		// "ii " means the package is wanted and installed
+		if fields := strings.Fields(line); len(fields) == 2 && fields[0] == "ii" {
+			result = append(result, fields[1])
+		}
+	}
+
+	return result, nil
+}
+
+// DtoverlayList returns the overlays loaded at runtime, as listed by dtoverlay -l
+func (s Service) DtoverlayList() []string {
+	return commandLines("dtoverlay", "-l")
+}
+
+// DeviceTreeStatus returns the status (okay, disabled) of every device tree node having an alias.
+// A node without status property is enabled.
+func (s Service) DeviceTreeStatus(directoryPath string) map[string]string {
+	result := map[string]string{}
+
+	aliases, err := ioutil.ReadDir(filepath.Join(directoryPath, "aliases"))
+	if err != nil {
+		return result
+	}
+
+	for _, alias := range aliases {
+		if alias.IsDir() || alias.Name() == "name" {
+			continue
+		}
+
+		node, err := ioutil.ReadFile(filepath.Join(directoryPath, "aliases", alias.Name()))
+		if err != nil {
+			continue
+		}
+
+		nodePath := filepath.Join(directoryPath, strings.TrimRight(string(node), "\x00\n"))
+		if _, err := os.Stat(nodePath); err != nil {
+			continue
+		}
+
+		status := "okay"
+		if raw, err := ioutil.ReadFile(filepath.Join(nodePath, "status")); err == nil {
+			status = strings.TrimRight(string(raw), "\x00\n")
+		}
+		result[alias.Name()] = status
+	}
+
+	return result
+}
+
+// Timezones returns the name of every timezone of a zoneinfo directory (ex: Europe/Paris), sorted.
+// The posix and right copies of the database are skipped.
+func (s Service) Timezones(directoryPath string) []string {
+	result := []string{}
+
+	filepath.Walk(directoryPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+
+		name, _ := filepath.Rel(directoryPath, path)
+		if info.IsDir() {
+			if name == "posix" || name == "right" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if name == "posixrules" || name == "localtime" || name == "Factory" {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return nil
+		}
+		defer f.Close()
+
+		magic := make([]byte, 4)
+		if n, _ := f.Read(magic); n == 4 && string(magic) == "TZif" {
+			result = append(result, filepath.ToSlash(name))
+		}
+		return nil
+	})
+
+	sort.Strings(result)
+	return result
+}
+
+// CoolingDevices returns the type of every thermal cooling device (ex: pwm-fan, rpi-poe-fan).
+func (s Service) CoolingDevices(directoryPath string) []string {
+	result := []string{}
+
+	files, _ := filepath.Glob(filepath.Join(directoryPath, "cooling_device*", "type"))
+	for _, f := range files {
+		content, err := ioutil.ReadFile(f)
+		if err != nil {
+			continue
+		}
+		if t := strings.TrimSpace(string(content)); t != "" {
+			result = append(result, t)
+		}
+	}
+
+	return result
+}
+
+// commandLines runs a command and returns its non empty output lines, ignoring its exit status
+func commandLines(name string, args ...string) []string {
+	var result []string
+
+	out, _ := exec.Command(name, args...).Output()
+	for _, line := range strings.Split(string(out), "\n") {
+		if strings.TrimSpace(line) != "" {
+			result = append(result, line)
+		}
+	}
+
+	return result
+}
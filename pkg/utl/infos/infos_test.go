@@ -2,8 +2,10 @@ package infos_test
 
 import (
 	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -1489,3 +1491,151 @@ func TestIsPortListening(t *testing.T) {
 		})
 	}
 }
+
+func TestReadKmsg(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kmsg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	i := infos.New()
+
+	_, err = i.ReadKmsg(filepath.Join(dir, "dummy"))
+	assert.NotNil(t, err)
+
+	path := filepath.Join(dir, "kmsg")
+	content := "6,339,5140900,-;NET: Registered protocol family 10\n" +
+		"4,1040,14592931,-;usb 1-1.2: USB disconnect, device number 3\n" +
+		" SUBSYSTEM=usb\n" +
+		" DEVICE=c189:2\n"
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := i.ReadKmsg(path)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"6,339,5140900,-;NET: Registered protocol family 10",
+		"4,1040,14592931,-;usb 1-1.2: USB disconnect, device number 3\n SUBSYSTEM=usb\n DEVICE=c189:2",
+	}, records)
+}
+
+func TestDeviceTreeStatus(t *testing.T) {
+	dir, err := ioutil.TempDir("", "devicetree")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	i := infos.New()
+	assert.Equal(t, map[string]string{}, i.DeviceTreeStatus(filepath.Join(dir, "dummy")))
+
+	files := map[string]string{
+		"aliases/name":                   "aliases\x00",
+		"aliases/spi0":                   "/soc/spi@7e204000\x00",
+		"aliases/i2c1":                   "/soc/i2c@7e804000\x00",
+		"aliases/serial0":                "/soc/serial@7e201000\x00",
+		"aliases/missing":                "/soc/missing\x00",
+		"soc/spi@7e204000/status":        "disabled\x00",
+		"soc/i2c@7e804000/status":        "okay\x00",
+		"soc/serial@7e201000/compatible": "brcm,bcm2835-aux-uart\x00",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assert.Equal(t, map[string]string{
+		"spi0":    "disabled",
+		"i2c1":    "okay",
+		"serial0": "okay",
+	}, i.DeviceTreeStatus(dir))
+}
+
+func TestCoolingDevices(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thermal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	i := infos.New()
+	assert.Equal(t, []string{}, i.CoolingDevices(filepath.Join(dir, "dummy")))
+
+	files := map[string]string{
+		"cooling_device0/type":  "pwm-fan\n",
+		"cooling_device1/type":  "\n",
+		"thermal_zone0/type":    "cpu-thermal\n",
+		"cooling_device2/dummy": "dummy\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assert.Equal(t, []string{"pwm-fan"}, i.CoolingDevices(dir))
+}
+
+func TestListBlockDevices(t *testing.T) {
+	dir, err := ioutil.TempDir("", "block")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	i := infos.New()
+	assert.Nil(t, i.ListBlockDevices(filepath.Join(dir, "dummy")))
+
+	for _, name := range []string{"sda", "mmcblk0", "nvme0n1", "loop0", "ram1", "zram0", "sr0", "dm-0", "md127", "mmcblk0boot0", "mmcblk0boot1", "mmcblk0rpmb"} {
+		if err := os.Mkdir(filepath.Join(dir, name), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assert.Equal(t, []string{"mmcblk0", "nvme0n1", "sda"}, i.ListBlockDevices(dir))
+}
+
+func TestTimezones(t *testing.T) {
+	dir, err := ioutil.TempDir("", "zoneinfo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	i := infos.New()
+	assert.Equal(t, []string{}, i.Timezones(filepath.Join(dir, "dummy")))
+
+	files := map[string]string{
+		"Europe/Paris":              "TZif2...",
+		"America/Argentina/Cordoba": "TZif2...",
+		"UTC":                       "TZif2...",
+		"posix/Europe/Paris":        "TZif2...",
+		"right/UTC":                 "TZif2...",
+		"posixrules":                "TZif2...",
+		"Factory":                   "TZif2...",
+		"zone.tab":                  "FR\t+4852+00220\tEurope/Paris\n",
+		"tzdata.zi":                 "# version 2020a\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assert.Equal(t, []string{"America/Argentina/Cordoba", "Europe/Paris", "UTC"}, i.Timezones(dir))
+}
@@ -4,17 +4,20 @@ import (
 	"bufio"
 	"bytes"
 	"errors"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"os/user"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/constants"
 	"github.com/rs/zerolog/log"
 	"github.com/shirou/gopsutil/cpu"
 	"github.com/shirou/gopsutil/disk"
@@ -59,6 +62,58 @@ type DStats struct {
 	Mountpoint *disk.UsageStat
 }
 
+// DIOStats represents two samples of a block device I/O counters taken one interval apart.
+type DIOStats struct {
+	Before   disk.IOCountersStat
+	After    disk.IOCountersStat
+	Interval time.Duration
+}
+
+// PSIStats represents one line (some or full) of a pressure stall information file.
+type PSIStats struct {
+	Avg10  float64
+	Avg60  float64
+	Avg300 float64
+	// Total is the absolute stall time (in microseconds)
+	Total uint64
+}
+
+// PStats represents the pressure stall information of a resource.
+type PStats struct {
+	Some PSIStats
+	Full PSIStats
+}
+
+// CGSample represents the cgroup v2 resource counters of a control group.
+type CGSample struct {
+	// CPUUsage is the cpu time consumed (in microseconds)
+	CPUUsage      uint64
+	MemoryCurrent uint64
+	SwapCurrent   uint64
+	IORead        uint64
+	IOWrite       uint64
+	PIDs          uint64
+}
+
+// CGStats represents two samples of a control group counters taken one interval apart.
+type CGStats struct {
+	Before   CGSample
+	After    CGSample
+	Interval time.Duration
+}
+
+// FStats represents the cpufreq sysfs attributes of a cpu core (frequencies in kHz).
+type FStats struct {
+	CPU                int
+	Cur                uint64
+	Min                uint64
+	Max                uint64
+	Governor           string
+	AvailableGovernors []string
+	// TimeInState maps a frequency to the time spent at it (in 10ms units)
+	TimeInState map[uint64]uint64
+}
+
 // PathSize represents a tuple composed of a file path and a file size
 type PathSize struct {
 	Path string
@@ -177,6 +232,390 @@ func (s Service) DiskStats(all bool) (map[string][]DStats, error) {
 	return dstats, nil
 }
 
+// DiskIOStats returns two samples of the /proc/diskstats counters of every block device.
+func (s Service) DiskIOStats(interval time.Duration) (map[string]DIOStats, error) {
+	dstats := make(map[string]DIOStats)
+
+	before, err := disk.IOCounters()
+	if err != nil {
+		return nil, err
+	}
+
+	time.Sleep(interval)
+
+	after, err := disk.IOCounters()
+	if err != nil {
+		return nil, err
+	}
+
+	for dev, a := range after {
+		b, ok := before[dev]
+		if !ok {
+			b = a
+		}
+
+		dstats[dev] = DIOStats{
+			Before:   b,
+			After:    a,
+			Interval: interval,
+		}
+	}
+
+	return dstats, nil
+}
+
+// CPUFreqStats returns the cpufreq stats of every cpu core.
+func (s Service) CPUFreqStats() ([]FStats, error) {
+	return ReadCPUFreq(constants.CPUDEVICES)
+}
+
+// ReadCPUFreq reads the cpufreq stats of every cpu core located in a sysfs cpu directory.
+func ReadCPUFreq(path string) ([]FStats, error) {
+	var fstats []FStats
+
+	dirs, err := filepath.Glob(filepath.Join(path, "cpu[0-9]*"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range dirs {
+		id, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(d), "cpu"))
+		if err != nil {
+			continue
+		}
+
+		freqDir := filepath.Join(d, "cpufreq")
+		if _, err := os.Stat(freqDir); err != nil {
+			continue
+		}
+
+		fs := FStats{
+			CPU:                id,
+			Cur:                readUint(filepath.Join(freqDir, "scaling_cur_freq")),
+			Min:                readUint(filepath.Join(freqDir, "scaling_min_freq")),
+			Max:                readUint(filepath.Join(freqDir, "scaling_max_freq")),
+			Governor:           readString(filepath.Join(freqDir, "scaling_governor")),
+			AvailableGovernors: strings.Fields(readString(filepath.Join(freqDir, "scaling_available_governors"))),
+			TimeInState:        make(map[uint64]uint64),
+		}
+
+		for _, line := range strings.Split(readString(filepath.Join(freqDir, "stats", "time_in_state")), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) != 2 {
+				continue
+			}
+			freq, errF := strconv.ParseUint(fields[0], 10, 64)
+			ticks, errT := strconv.ParseUint(fields[1], 10, 64)
+			if errF == nil && errT == nil {
+				fs.TimeInState[freq] = ticks
+			}
+		}
+
+		fstats = append(fstats, fs)
+	}
+
+	if len(fstats) == 0 {
+		return nil, errors.New("cpufreq is not available")
+	}
+
+	sort.Slice(fstats, func(i, j int) bool {
+		return fstats[i].CPU < fstats[j].CPU
+	})
+
+	return fstats, nil
+}
+
+// readString returns the trimmed content of a file or an empty string.
+func readString(path string) string {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(content))
+}
+
+// readUint returns the content of a file as an unsigned integer or zero.
+func readUint(path string) uint64 {
+	v, err := strconv.ParseUint(readString(path), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+// PMStats represents the memory usage of a process, read from /proc/<pid>/status (in bytes).
+type PMStats struct {
+	PID  int32
+	Name string
+	UID  string
+	RSS  uint64
+	Swap uint64
+}
+
+// MemInfo returns the content of /proc/meminfo (in bytes).
+func (s Service) MemInfo() (map[string]uint64, error) {
+	return ReadMemInfo(filepath.Join(constants.PROC, "meminfo"))
+}
+
+// ReadMemInfo parses a meminfo file and returns its values converted in bytes.
+func ReadMemInfo(path string) (map[string]uint64, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]uint64)
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		v, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		if len(fields) == 3 && fields[2] == "kB" {
+			v *= 1024
+		}
+		result[strings.TrimSuffix(fields[0], ":")] = v
+	}
+
+	return result, nil
+}
+
+// GPUMemory returns the memory split allocated to the GPU (in bytes).
+func (s Service) GPUMemory() (uint64, error) {
+	out, err := exec.Command("vcgencmd", "get_mem", "gpu").Output()
+	if err != nil {
+		return 0, err
+	}
+	return ParseGPUMemory(string(out))
+}
+
+// ParseGPUMemory parses the output of 'vcgencmd get_mem gpu' (e.g. gpu=76M) and returns it in bytes.
+func ParseGPUMemory(out string) (uint64, error) {
+	value := strings.TrimPrefix(strings.TrimSpace(out), "gpu=")
+	if !strings.HasSuffix(value, "M") {
+		return 0, errors.New("unexpected gpu memory format")
+	}
+	v, err := strconv.ParseUint(strings.TrimSuffix(value, "M"), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return v * 1024 * 1024, nil
+}
+
+// ProcessesMemory returns the memory usage of every running process.
+func (s Service) ProcessesMemory() ([]PMStats, error) {
+	return ReadProcessesMemory(constants.PROC)
+}
+
+// ReadProcessesMemory reads the status file of every process found in path.
+func ReadProcessesMemory(path string) ([]PMStats, error) {
+	var pmstats []PMStats
+
+	dirs, err := filepath.Glob(filepath.Join(path, "[0-9]*"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range dirs {
+		pid, err := strconv.ParseInt(filepath.Base(d), 10, 32)
+		if err != nil {
+			continue
+		}
+
+		// the process may have exited in the meantime
+		content, err := ioutil.ReadFile(filepath.Join(d, "status"))
+		if err != nil {
+			continue
+		}
+
+		pm := PMStats{PID: int32(pid)}
+		for _, line := range strings.Split(string(content), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) < 2 {
+				continue
+			}
+			switch fields[0] {
+			case "Name:":
+				pm.Name = fields[1]
+			case "Uid:":
+				pm.UID = fields[1]
+			case "VmRSS:":
+				v, _ := strconv.ParseUint(fields[1], 10, 64)
+				pm.RSS = v * 1024
+			case "VmSwap:":
+				v, _ := strconv.ParseUint(fields[1], 10, 64)
+				pm.Swap = v * 1024
+			}
+		}
+
+		pmstats = append(pmstats, pm)
+	}
+
+	return pmstats, nil
+}
+
+// PressureStats returns the pressure stall information of the cpu, memory and io resources.
+func (s Service) PressureStats() (map[string]PStats, error) {
+	return ReadPressure(constants.PRESSURE)
+}
+
+// ReadPressure parses the pressure stall information files found in path.
+func ReadPressure(path string) (map[string]PStats, error) {
+	pstats := make(map[string]PStats)
+
+	for _, resource := range []string{"cpu", "memory", "io"} {
+		content, err := ioutil.ReadFile(filepath.Join(path, resource))
+		if err != nil {
+			continue
+		}
+
+		var ps PStats
+		for _, line := range strings.Split(string(content), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) != 5 {
+				continue
+			}
+
+			var psi PSIStats
+			for _, f := range fields[1:] {
+				kv := strings.SplitN(f, "=", 2)
+				if len(kv) != 2 {
+					continue
+				}
+				switch kv[0] {
+				case "avg10":
+					psi.Avg10, _ = strconv.ParseFloat(kv[1], 64)
+				case "avg60":
+					psi.Avg60, _ = strconv.ParseFloat(kv[1], 64)
+				case "avg300":
+					psi.Avg300, _ = strconv.ParseFloat(kv[1], 64)
+				case "total":
+					psi.Total, _ = strconv.ParseUint(kv[1], 10, 64)
+				}
+			}
+
+			switch fields[0] {
+			case "some":
+				ps.Some = psi
+			case "full":
+				ps.Full = psi
+			}
+		}
+
+		pstats[resource] = ps
+	}
+
+	if len(pstats) == 0 {
+		return nil, errors.New("pressure stall information is not available")
+	}
+
+	return pstats, nil
+}
+
+// CGroupStats returns two samples, one interval apart, of the counters of every systemd slice and service.
+func (s Service) CGroupStats(interval time.Duration) (map[string]CGStats, error) {
+	cgstats := make(map[string]CGStats)
+
+	before, err := ReadCGroups(constants.CGROUP)
+	if err != nil {
+		return nil, err
+	}
+
+	time.Sleep(interval)
+
+	after, err := ReadCGroups(constants.CGROUP)
+	if err != nil {
+		return nil, err
+	}
+
+	for cg, a := range after {
+		b, ok := before[cg]
+		if !ok {
+			continue
+		}
+		cgstats[cg] = CGStats{
+			Before:   b,
+			After:    a,
+			Interval: interval,
+		}
+	}
+
+	return cgstats, nil
+}
+
+// ReadCGroups walks a cgroup v2 hierarchy and returns the counters of every slice and service,
+// keyed by their path relative to root.
+func ReadCGroups(root string) (map[string]CGSample, error) {
+	if _, err := os.Stat(filepath.Join(root, "cgroup.controllers")); err != nil {
+		return nil, errors.New("cgroup v2 is not available")
+	}
+
+	cgsamples := make(map[string]CGSample)
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.IsDir() || path == root {
+			return nil
+		}
+		if !strings.HasSuffix(path, ".slice") && !strings.HasSuffix(path, ".service") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil
+		}
+
+		cgsamples[rel] = ReadCGroup(path)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cgsamples, nil
+}
+
+// ReadCGroup returns the counters of a single cgroup v2 directory.
+func ReadCGroup(path string) CGSample {
+	cg := CGSample{
+		MemoryCurrent: readUint(filepath.Join(path, "memory.current")),
+		SwapCurrent:   readUint(filepath.Join(path, "memory.swap.current")),
+		PIDs:          readUint(filepath.Join(path, "pids.current")),
+	}
+
+	for _, line := range strings.Split(readString(filepath.Join(path, "cpu.stat")), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == "usage_usec" {
+			cg.CPUUsage, _ = strconv.ParseUint(fields[1], 10, 64)
+		}
+	}
+
+	// io.stat holds one line per device, e.g. "179:0 rbytes=1 wbytes=2 rios=3 wios=4 ..."
+	for _, line := range strings.Split(readString(filepath.Join(path, "io.stat")), "\n") {
+		for _, f := range strings.Fields(line) {
+			kv := strings.SplitN(f, "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			v, err := strconv.ParseUint(kv[1], 10, 64)
+			if err != nil {
+				continue
+			}
+			switch kv[0] {
+			case "rbytes":
+				cg.IORead += v
+			case "wbytes":
+				cg.IOWrite += v
+			}
+		}
+	}
+
+	return cg
+}
+
 // LoadAvg returns some host load stats.
 func (s Service) LoadAvg() (load.AvgStat, error) {
 	temp, err := load.Avg()
@@ -213,7 +652,7 @@ func (s Service) Processes(id ...int32) ([]PInfo, error) {
 
 	ps, err := process.Processes()
 	if err != nil {
-		error.Error(err)
+		return nil, err
 	}
 
 	pid := int32(-1)
@@ -457,6 +896,48 @@ func (s Service) Temperature() (string, string, error) {
 	return outStd, errStd, nil
 }
 
+// Throttled returns the throttling state of the board reported by the firmware.
+func (s Service) Throttled() (string, string, error) {
+	cmd := exec.Command("sh", "-c", "vcgencmd get_throttled")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if err != nil {
+		log.Error()
+	}
+	outStd, errStd := strings.TrimSpace(stdout.String()), stderr.String()
+	return outStd, errStd, nil
+}
+
+// TimeDate returns the clock and synchronization properties reported by timedatectl.
+func (s Service) TimeDate() (string, string, error) {
+	cmd := exec.Command("sh", "-c", "timedatectl show")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if err != nil {
+		log.Error()
+	}
+	outStd, errStd := strings.TrimSpace(stdout.String()), stderr.String()
+	return outStd, errStd, nil
+}
+
+// TimeSync returns the properties of systemd-timesyncd (servers in use, last poll) reported by timedatectl.
+func (s Service) TimeSync() (string, string, error) {
+	cmd := exec.Command("sh", "-c", "timedatectl show-timesync")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if err != nil {
+		log.Error()
+	}
+	outStd, errStd := strings.TrimSpace(stdout.String()), stderr.String()
+	return outStd, errStd, nil
+}
+
 // SerialNumber returns the host serial number.
 func (s Service) SerialNumber() (string, string, error) {
 	cmd := exec.Command("sh", "-c", "cat /proc/cpuinfo | grep -i serial | cut -d ' ' -f 2-")
@@ -486,6 +967,20 @@ func (s Service) RaspModel() (string, string, error) {
 	return outStd, errStd, nil
 }
 
+// Revision returns the host Raspberry revision code.
+func (s Service) Revision() (string, string, error) {
+	cmd := exec.Command("sh", "-c", "cat /proc/cpuinfo | grep \"^Revision\" | cut -d ':' -f 2")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if err != nil {
+		log.Error()
+	}
+	outStd, errStd := strings.TrimSpace(stdout.String()), stderr.String()
+	return outStd, errStd, nil
+}
+
 // NetInfo returns the host net interface info.
 func (s Service) NetInfo() ([]net.InterfaceStat, error) {
 	netInfo, err := net.Interfaces()
@@ -3,7 +3,9 @@ package metrics_test
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -623,3 +625,191 @@ func TestDirSize(t *testing.T) {
 	// To be analyzed with Docker
 	// NewTestFolder does now work here
 }
+
+func TestReadCPUFreq(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cpufreq")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	_, err = metrics.ReadCPUFreq(dir)
+	assert.EqualError(t, err, "cpufreq is not available")
+
+	files := map[string]string{
+		"cpu1/cpufreq/scaling_cur_freq":            "1500000\n",
+		"cpu1/cpufreq/scaling_governor":            "performance\n",
+		"cpu0/cpufreq/scaling_cur_freq":            "600000\n",
+		"cpu0/cpufreq/scaling_min_freq":            "600000\n",
+		"cpu0/cpufreq/scaling_max_freq":            "1500000\n",
+		"cpu0/cpufreq/scaling_governor":            "ondemand\n",
+		"cpu0/cpufreq/scaling_available_governors": "ondemand powersave performance \n",
+		"cpu0/cpufreq/stats/time_in_state":         "600000 300\n1500000 100\n",
+		"cpuidle/current_driver":                   "none\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fstats, err := metrics.ReadCPUFreq(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, []metrics.FStats{
+		{
+			CPU:                0,
+			Cur:                600000,
+			Min:                600000,
+			Max:                1500000,
+			Governor:           "ondemand",
+			AvailableGovernors: []string{"ondemand", "powersave", "performance"},
+			TimeInState:        map[uint64]uint64{600000: 300, 1500000: 100},
+		},
+		{
+			CPU:                1,
+			Cur:                1500000,
+			Governor:           "performance",
+			AvailableGovernors: []string{},
+			TimeInState:        map[uint64]uint64{},
+		},
+	}, fstats)
+}
+
+func TestReadMemInfo(t *testing.T) {
+	dir, err := ioutil.TempDir("", "meminfo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	_, err = metrics.ReadMemInfo(filepath.Join(dir, "dummy"))
+	assert.NotNil(t, err)
+
+	path := filepath.Join(dir, "meminfo")
+	content := "MemTotal:        3930172 kB\nCmaTotal:         262144 kB\nHugePages_Total:       0\nbadline\n"
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	meminfo, err := metrics.ReadMemInfo(path)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]uint64{
+		"MemTotal":        3930172 * 1024,
+		"CmaTotal":        262144 * 1024,
+		"HugePages_Total": 0,
+	}, meminfo)
+}
+
+func TestParseGPUMemory(t *testing.T) {
+	gpu, err := metrics.ParseGPUMemory("gpu=76M\n")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(76*1024*1024), gpu)
+
+	_, err = metrics.ParseGPUMemory("error=1")
+	assert.EqualError(t, err, "unexpected gpu memory format")
+}
+
+func TestReadProcessesMemory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "proc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"42/status":   "Name:\tnginx\nUid:\t33\t33\t33\t33\nVmRSS:\t    2048 kB\nVmSwap:\t     512 kB\n",
+		"self/status": "Name:\tself\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// a process which exited between the listing and the reading
+	if err := os.MkdirAll(filepath.Join(dir, "43"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	pmstats, err := metrics.ReadProcessesMemory(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, []metrics.PMStats{
+		{PID: 42, Name: "nginx", UID: "33", RSS: 2048 * 1024, Swap: 512 * 1024},
+	}, pmstats)
+}
+
+func TestReadPressure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pressure")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	_, err = metrics.ReadPressure(dir)
+	assert.EqualError(t, err, "pressure stall information is not available")
+
+	content := "some avg10=1.50 avg60=0.75 avg300=0.10 total=123456\nfull avg10=0.50 avg60=0.25 avg300=0.00 total=4567\n"
+	if err := ioutil.WriteFile(filepath.Join(dir, "io"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pstats, err := metrics.ReadPressure(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]metrics.PStats{
+		"io": {
+			Some: metrics.PSIStats{Avg10: 1.5, Avg60: 0.75, Avg300: 0.1, Total: 123456},
+			Full: metrics.PSIStats{Avg10: 0.5, Avg60: 0.25, Avg300: 0, Total: 4567},
+		},
+	}, pstats)
+}
+
+func TestReadCGroups(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cgroup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	_, err = metrics.ReadCGroups(dir)
+	assert.EqualError(t, err, "cgroup v2 is not available")
+
+	files := map[string]string{
+		"cgroup.controllers":                           "cpu io memory pids\n",
+		"system.slice/cpu.stat":                        "usage_usec 5000\nuser_usec 3000\n",
+		"system.slice/ssh.service/cpu.stat":            "usage_usec 1000\n",
+		"system.slice/ssh.service/memory.current":      "4096\n",
+		"system.slice/ssh.service/memory.swap.current": "0\n",
+		"system.slice/ssh.service/pids.current":        "2\n",
+		"system.slice/ssh.service/io.stat":             "179:0 rbytes=100 wbytes=200 rios=1 wios=2\n8:0 rbytes=10 wbytes=20\n",
+		"init.scope/cpu.stat":                          "usage_usec 1\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cgsamples, err := metrics.ReadCGroups(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]metrics.CGSample{
+		"system.slice": {CPUUsage: 5000},
+		"system.slice/ssh.service": {
+			CPUUsage:      1000,
+			MemoryCurrent: 4096,
+			IORead:        110,
+			IOWrite:       220,
+			PIDs:          2,
+		},
+	}, cgsamples)
+}
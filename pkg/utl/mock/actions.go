@@ -23,6 +23,21 @@ type Actions struct {
 	ExecuteBashCommandFn           func(arg interface{}) (rpi.Exec, error)
 	DisableOrEnableRemoteGpioFn    func(arg interface{}) (rpi.Exec, error)
 	ConfirmVPNAuthenticationFn     func(arg interface{}) (rpi.Exec, error)
+	SignalProcessFn                func(arg interface{}) (rpi.Exec, error)
+	ReniceProcessFn                func(arg interface{}) (rpi.Exec, error)
+	IoniceProcessFn                func(arg interface{}) (rpi.Exec, error)
+	SetProcessAffinityFn           func(arg interface{}) (rpi.Exec, error)
+	SetCPUGovernorFn               func(arg interface{}) (rpi.Exec, error)
+	PersistCPUGovernorFn           func(arg interface{}) (rpi.Exec, error)
+	ManageUnitFn                   func(arg interface{}) (rpi.Exec, error)
+	RestoreFileFn                  func(arg interface{}) (rpi.Exec, error)
+	EditBootConfigFn               func(arg interface{}) (rpi.Exec, error)
+	SaveOverclockStateFn           func(arg interface{}) (rpi.Exec, error)
+	PersistOverclockGuardFn        func(arg interface{}) (rpi.Exec, error)
+	ClearOverclockStateFn          func(arg interface{}) (rpi.Exec, error)
+	SetNTPServersFn                func(arg interface{}) (rpi.Exec, error)
+	EditWpaSupplicantFn            func(arg interface{}) (rpi.Exec, error)
+	ApplyNetworkConfigFn           func(arg interface{}) (rpi.Exec, error)
 }
 
 // DeleteFile mock
@@ -101,3 +116,78 @@ func (a Actions) DisableOrEnableRemoteGpio(arg interface{}) (rpi.Exec, error) {
 func (a Actions) ConfirmVPNAuthentication(arg interface{}) (rpi.Exec, error) {
 	return a.ConfirmVPNAuthenticationFn(arg)
 }
+
+// SignalProcess mock
+func (a Actions) SignalProcess(arg interface{}) (rpi.Exec, error) {
+	return a.SignalProcessFn(arg)
+}
+
+// ReniceProcess mock
+func (a Actions) ReniceProcess(arg interface{}) (rpi.Exec, error) {
+	return a.ReniceProcessFn(arg)
+}
+
+// IoniceProcess mock
+func (a Actions) IoniceProcess(arg interface{}) (rpi.Exec, error) {
+	return a.IoniceProcessFn(arg)
+}
+
+// SetProcessAffinity mock
+func (a Actions) SetProcessAffinity(arg interface{}) (rpi.Exec, error) {
+	return a.SetProcessAffinityFn(arg)
+}
+
+// SetCPUGovernor mock
+func (a Actions) SetCPUGovernor(arg interface{}) (rpi.Exec, error) {
+	return a.SetCPUGovernorFn(arg)
+}
+
+// PersistCPUGovernor mock
+func (a Actions) PersistCPUGovernor(arg interface{}) (rpi.Exec, error) {
+	return a.PersistCPUGovernorFn(arg)
+}
+
+// ManageUnit mock
+func (a Actions) ManageUnit(arg interface{}) (rpi.Exec, error) {
+	return a.ManageUnitFn(arg)
+}
+
+// RestoreFile mock
+func (a Actions) RestoreFile(arg interface{}) (rpi.Exec, error) {
+	return a.RestoreFileFn(arg)
+}
+
+// EditBootConfig mock
+func (a Actions) EditBootConfig(arg interface{}) (rpi.Exec, error) {
+	return a.EditBootConfigFn(arg)
+}
+
+// SaveOverclockState mock
+func (a Actions) SaveOverclockState(arg interface{}) (rpi.Exec, error) {
+	return a.SaveOverclockStateFn(arg)
+}
+
+// PersistOverclockGuard mock
+func (a Actions) PersistOverclockGuard(arg interface{}) (rpi.Exec, error) {
+	return a.PersistOverclockGuardFn(arg)
+}
+
+// ClearOverclockState mock
+func (a Actions) ClearOverclockState(arg interface{}) (rpi.Exec, error) {
+	return a.ClearOverclockStateFn(arg)
+}
+
+// SetNTPServers mock
+func (a Actions) SetNTPServers(arg interface{}) (rpi.Exec, error) {
+	return a.SetNTPServersFn(arg)
+}
+
+// EditWpaSupplicant mock
+func (a Actions) EditWpaSupplicant(arg interface{}) (rpi.Exec, error) {
+	return a.EditWpaSupplicantFn(arg)
+}
+
+// ApplyNetworkConfig mock
+func (a Actions) ApplyNetworkConfig(arg interface{}) (rpi.Exec, error) {
+	return a.ApplyNetworkConfigFn(arg)
+}
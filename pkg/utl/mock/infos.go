@@ -2,6 +2,7 @@ package mock
 
 import (
 	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/infos"
 )
 
 // Infos mock
@@ -19,6 +20,7 @@ type Infos struct {
 	IsVariableSetFn              func([]string, string, string) bool
 	ListWifiInterfacesFn         func(string) []string
 	IsWpaSupComFn                func() map[string]bool
+	WifiCountryFn                func(string) string
 	ZoneInfoFn                   func(string) map[string]string
 	ListNameFilesInDirectoryFn   func(string) []string
 	VPNCountriesFn               func(string) map[string](map[string]string)
@@ -30,6 +32,30 @@ type Infos struct {
 	IsFileContainsUntilFn        func(string, string, string, int) (string, error)
 	ApiVersionFn                 func(string, string) string
 	IsPortListeningFn            func(int32) bool
+	ListBlockDevicesFn           func(string) []string
+	ReadSysfsAttributesFn        func(string, []string) map[string]string
+	SmartctlFn                   func(string) (infos.SmartReport, error)
+	ReadOnlyRemountsFn           func() []string
+	ReadKmsgFn                   func(string) ([]string, error)
+	JournalctlFn                 func([]string) ([]string, error)
+	ListUnitsFn                  func() []string
+	ListUnitFilesFn              func() []string
+	IsUnitFn                     func(string) bool
+	ShowUnitFn                   func(string) (map[string]string, error)
+	ReadFileBytesFn              func(string) ([]byte, uint32, error)
+	ListFilesFn                  func(string) []string
+	InstalledPackagesFn          func() ([]string, error)
+	DtoverlayListFn              func() []string
+	DeviceTreeStatusFn           func(directoryPath string) map[string]string
+	CoolingDevicesFn             func(string) []string
+	TimezonesFn                  func(directoryPath string) []string
+	WpaCliFn                     func(iface string, args ...string) ([]string, error)
+	NMConnectionsFn              func() []string
+	StationDumpFn                func(iface string) []string
+	NftRulesetFn                 func() []string
+	SshdConfigFn                 func() []string
+	LastLogFn                    func() []string
+	IsSymlinkFn                  func(path string) bool
 }
 
 // ReadFile mock
@@ -97,6 +123,11 @@ func (i Infos) IsWpaSupCom() map[string]bool {
 	return i.IsWpaSupComFn()
 }
 
+// WifiCountry mock
+func (i Infos) WifiCountry(iface string) string {
+	return i.WifiCountryFn(iface)
+}
+
 // ZoneInfo mock
 func (i Infos) ZoneInfo(filePath string) map[string]string {
 	return i.ZoneInfoFn(filePath)
@@ -151,3 +182,123 @@ func (i Infos) ApiVersion(apiPath string, apiPrefix string) string {
 func (i Infos) IsPortListening(port int32) bool {
 	return i.IsPortListeningFn(port)
 }
+
+// ListBlockDevices mock
+func (i Infos) ListBlockDevices(directoryPath string) []string {
+	return i.ListBlockDevicesFn(directoryPath)
+}
+
+// ReadSysfsAttributes mock
+func (i Infos) ReadSysfsAttributes(directoryPath string, attributes []string) map[string]string {
+	return i.ReadSysfsAttributesFn(directoryPath, attributes)
+}
+
+// Smartctl mock
+func (i Infos) Smartctl(device string) (infos.SmartReport, error) {
+	return i.SmartctlFn(device)
+}
+
+// ReadOnlyRemounts mock
+func (i Infos) ReadOnlyRemounts() []string {
+	return i.ReadOnlyRemountsFn()
+}
+
+// ReadKmsg mock
+func (i Infos) ReadKmsg(path string) ([]string, error) {
+	return i.ReadKmsgFn(path)
+}
+
+// Journalctl mock
+func (i Infos) Journalctl(args []string) ([]string, error) {
+	return i.JournalctlFn(args)
+}
+
+// ListUnits mock
+func (i Infos) ListUnits() []string {
+	return i.ListUnitsFn()
+}
+
+// ListUnitFiles mock
+func (i Infos) ListUnitFiles() []string {
+	return i.ListUnitFilesFn()
+}
+
+// IsUnit mock
+func (i Infos) IsUnit(name string) bool {
+	return i.IsUnitFn(name)
+}
+
+// ShowUnit mock
+func (i Infos) ShowUnit(name string) (map[string]string, error) {
+	return i.ShowUnitFn(name)
+}
+
+// ReadFileBytes mock
+func (i Infos) ReadFileBytes(path string) ([]byte, uint32, error) {
+	return i.ReadFileBytesFn(path)
+}
+
+// ListFiles mock
+func (i Infos) ListFiles(pattern string) []string {
+	return i.ListFilesFn(pattern)
+}
+
+// InstalledPackages mock
+func (i Infos) InstalledPackages() ([]string, error) {
+	return i.InstalledPackagesFn()
+}
+
+// DtoverlayList mock
+func (i Infos) DtoverlayList() []string {
+	return i.DtoverlayListFn()
+}
+
+// DeviceTreeStatus mock
+func (i Infos) DeviceTreeStatus(directoryPath string) map[string]string {
+	return i.DeviceTreeStatusFn(directoryPath)
+}
+
+// CoolingDevices mock
+func (i Infos) CoolingDevices(path string) []string {
+	return i.CoolingDevicesFn(path)
+}
+
+// Timezones mock
+func (i Infos) Timezones(directoryPath string) []string {
+	return i.TimezonesFn(directoryPath)
+}
+
+// WpaCli mock
+func (i Infos) WpaCli(iface string, args ...string) ([]string, error) {
+	return i.WpaCliFn(iface, args...)
+}
+
+// NMConnections mock
+func (i Infos) NMConnections() []string {
+	return i.NMConnectionsFn()
+}
+
+// StationDump mock
+func (i Infos) StationDump(iface string) []string {
+	return i.StationDumpFn(iface)
+}
+
+// NftRuleset mock
+func (i Infos) NftRuleset() []string {
+	return i.NftRulesetFn()
+}
+
+// SshdConfig mock
+func (i Infos) SshdConfig() []string {
+	return i.SshdConfigFn()
+}
+
+// LastLog mock
+func (i Infos) LastLog() []string {
+	return i.LastLogFn()
+}
+
+// IsSymlink mock
+func (i Infos) IsSymlink(path string) bool {
+	return i.IsSymlinkFn(path)
+}
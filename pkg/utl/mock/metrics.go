@@ -20,7 +20,14 @@ type Metrics struct {
 	CPUTimesFn       func(bool) ([]cpu.TimesStat, error)
 	SwapMemFn        func() (mem.SwapMemoryStat, error)
 	VirtualMemFn     func() (mem.VirtualMemoryStat, error)
+	MemInfoFn        func() (map[string]uint64, error)
+	GPUMemoryFn      func() (uint64, error)
+	ProcessesMemFn   func() ([]metrics.PMStats, error)
+	PressureStatsFn  func() (map[string]metrics.PStats, error)
+	CGroupStatsFn    func(time.Duration) (map[string]metrics.CGStats, error)
 	DiskStatsFn      func(bool) (map[string][]metrics.DStats, error)
+	DiskIOStatsFn    func(time.Duration) (map[string]metrics.DIOStats, error)
+	CPUFreqStatsFn   func() ([]metrics.FStats, error)
 	LoadAvgFn        func() (load.AvgStat, error)
 	LoadProcsFn      func() (load.MiscStat, error)
 	ProcessesFn      func(id ...int32) ([]metrics.PInfo, error)
@@ -39,8 +46,12 @@ type Metrics struct {
 	HostInfoFn       func() (host.InfoStat, error)
 	UsersFn          func() ([]host.UserStat, error)
 	TemperatureFn    func() (string, string, error)
+	ThrottledFn      func() (string, string, error)
+	TimeDateFn       func() (string, string, error)
+	TimeSyncFn       func() (string, string, error)
 	SerialNumberFn   func() (string, string, error)
 	RaspModelFn      func() (string, string, error)
+	RevisionFn       func() (string, string, error)
 	NetInfoFn        func() ([]net.InterfaceStat, error)
 	NetStatsFn       func() ([]net.IOCountersStat, error)
 	WalkFolderFn     func(
@@ -78,11 +89,46 @@ func (m Metrics) VirtualMemory() (mem.VirtualMemoryStat, error) {
 	return m.VirtualMemFn()
 }
 
+// MemInfo mock
+func (m Metrics) MemInfo() (map[string]uint64, error) {
+	return m.MemInfoFn()
+}
+
+// GPUMemory mock
+func (m Metrics) GPUMemory() (uint64, error) {
+	return m.GPUMemoryFn()
+}
+
+// ProcessesMemory mock
+func (m Metrics) ProcessesMemory() ([]metrics.PMStats, error) {
+	return m.ProcessesMemFn()
+}
+
+// PressureStats mock
+func (m Metrics) PressureStats() (map[string]metrics.PStats, error) {
+	return m.PressureStatsFn()
+}
+
+// CGroupStats mock
+func (m Metrics) CGroupStats(interval time.Duration) (map[string]metrics.CGStats, error) {
+	return m.CGroupStatsFn(interval)
+}
+
 // DiskStats mock
 func (m Metrics) DiskStats(all bool) (map[string][]metrics.DStats, error) {
 	return m.DiskStatsFn(all)
 }
 
+// CPUFreqStats mock
+func (m Metrics) CPUFreqStats() ([]metrics.FStats, error) {
+	return m.CPUFreqStatsFn()
+}
+
+// DiskIOStats mock
+func (m Metrics) DiskIOStats(interval time.Duration) (map[string]metrics.DIOStats, error) {
+	return m.DiskIOStatsFn(interval)
+}
+
 // LoadAvg mock
 func (m Metrics) LoadAvg() (load.AvgStat, error) {
 	return m.LoadAvgFn()
@@ -181,11 +227,31 @@ func (m Metrics) Temperature() (string, string, error) {
 	return m.TemperatureFn()
 }
 
+// Throttled mock
+func (m Metrics) Throttled() (string, string, error) {
+	return m.ThrottledFn()
+}
+
+// TimeDate mock
+func (m Metrics) TimeDate() (string, string, error) {
+	return m.TimeDateFn()
+}
+
+// TimeSync mock
+func (m Metrics) TimeSync() (string, string, error) {
+	return m.TimeSyncFn()
+}
+
 // RaspModel mock
 func (m Metrics) RaspModel() (string, string, error) {
 	return m.RaspModelFn()
 }
 
+// Revision mock
+func (m Metrics) Revision() (string, string, error) {
+	return m.RevisionFn()
+}
+
 // NetInfo mock
 func (m Metrics) NetInfo() ([]net.InterfaceStat, error) {
 	return m.NetInfoFn()
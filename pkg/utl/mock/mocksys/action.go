@@ -34,6 +34,16 @@ type Action struct {
 	ExecuteRBSFn    func(map[int](map[int]actions.Func)) (rpi.Action, error)
 	ExecuteDPTOOLFn func(map[int](map[int]actions.Func)) (rpi.Action, error)
 	ExecuteSASOFn   func(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteSGFn     func(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteRNFn     func(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteINFn     func(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteAFFn     func(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteSPSFn    func(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteRPSFn    func(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteCGFn     func(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteMUFn     func(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteOCFn     func(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteOCRFn    func(map[int](map[int]actions.Func)) (rpi.Action, error)
 }
 
 // ExecuteDF mock
@@ -170,3 +180,53 @@ func (a *Action) ExecuteDPTOOL(plan map[int](map[int]actions.Func)) (rpi.Action,
 func (a *Action) ExecuteSASO(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
 	return a.ExecuteSASOFn(plan)
 }
+
+// ExecuteSG mock
+func (a *Action) ExecuteSG(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return a.ExecuteSGFn(plan)
+}
+
+// ExecuteRN mock
+func (a *Action) ExecuteRN(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return a.ExecuteRNFn(plan)
+}
+
+// ExecuteIN mock
+func (a *Action) ExecuteIN(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return a.ExecuteINFn(plan)
+}
+
+// ExecuteAF mock
+func (a *Action) ExecuteAF(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return a.ExecuteAFFn(plan)
+}
+
+// ExecuteSPS mock
+func (a *Action) ExecuteSPS(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return a.ExecuteSPSFn(plan)
+}
+
+// ExecuteRPS mock
+func (a *Action) ExecuteRPS(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return a.ExecuteRPSFn(plan)
+}
+
+// ExecuteCG mock
+func (a *Action) ExecuteCG(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return a.ExecuteCGFn(plan)
+}
+
+// ExecuteMU mock
+func (a *Action) ExecuteMU(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return a.ExecuteMUFn(plan)
+}
+
+// ExecuteOC mock
+func (a *Action) ExecuteOC(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return a.ExecuteOCFn(plan)
+}
+
+// ExecuteOCR mock
+func (a *Action) ExecuteOCR(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return a.ExecuteOCRFn(plan)
+}
@@ -0,0 +1,15 @@
+package mocksys
+
+import (
+	"github.com/raspibuddy/rpi"
+)
+
+// Board mock
+type Board struct {
+	ListFn func(string) (rpi.Board, error)
+}
+
+// List mock
+func (b Board) List(revision string) (rpi.Board, error) {
+	return b.ListFn(revision)
+}
@@ -0,0 +1,22 @@
+package mocksys
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// BootConfig mock
+type BootConfig struct {
+	ViewFn       func(string, []string) (rpi.BootConfig, error)
+	ExecuteBCOFn func(map[int](map[int]actions.Func)) (rpi.Action, error)
+}
+
+// View mock
+func (bc BootConfig) View(path string, lines []string) (rpi.BootConfig, error) {
+	return bc.ViewFn(path, lines)
+}
+
+// ExecuteBCO mock
+func (bc BootConfig) ExecuteBCO(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return bc.ExecuteBCOFn(plan)
+}
@@ -0,0 +1,34 @@
+package mocksys
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// Bundle mock
+type Bundle struct {
+	ExportFn     func(string, uint64, []rpi.BundleFile, []string) ([]byte, error)
+	OpenFn       func([]byte, []string) (rpi.BundleManifest, []rpi.BundleFile, error)
+	DiffFn       func(rpi.BundleManifest, []rpi.BundleFile, map[string][]byte, []string) (rpi.BundleImport, error)
+	ExecuteIMPFn func(map[int](map[int]actions.Func)) (rpi.Action, error)
+}
+
+// Export mock
+func (b Bundle) Export(hostname string, createdAt uint64, files []rpi.BundleFile, installed []string) ([]byte, error) {
+	return b.ExportFn(hostname, createdAt, files, installed)
+}
+
+// Open mock
+func (b Bundle) Open(archive []byte, tracked []string) (rpi.BundleManifest, []rpi.BundleFile, error) {
+	return b.OpenFn(archive, tracked)
+}
+
+// Diff mock
+func (b Bundle) Diff(manifest rpi.BundleManifest, files []rpi.BundleFile, current map[string][]byte, installed []string) (rpi.BundleImport, error) {
+	return b.DiffFn(manifest, files, current, installed)
+}
+
+// ExecuteIMP mock
+func (b Bundle) ExecuteIMP(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return b.ExecuteIMPFn(plan)
+}
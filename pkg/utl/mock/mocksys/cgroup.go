@@ -0,0 +1,22 @@
+package mocksys
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/metrics"
+)
+
+// CGroup mock
+type CGroup struct {
+	ListFn func(map[string]metrics.CGStats, string, string) ([]rpi.CGroup, error)
+	ViewFn func(string, map[string]metrics.CGStats) (rpi.CGroup, error)
+}
+
+// List mock
+func (cg CGroup) List(cgstats map[string]metrics.CGStats, cgType string, sortBy string) ([]rpi.CGroup, error) {
+	return cg.ListFn(cgstats, cgType, sortBy)
+}
+
+// View mock
+func (cg CGroup) View(id string, cgstats map[string]metrics.CGStats) (rpi.CGroup, error) {
+	return cg.ViewFn(id, cgstats)
+}
@@ -0,0 +1,22 @@
+package mocksys
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/metrics"
+)
+
+// CPUFreq mock
+type CPUFreq struct {
+	ListFn func([]metrics.FStats) ([]rpi.CPUFreq, error)
+	ViewFn func(int, []metrics.FStats) (rpi.CPUFreq, error)
+}
+
+// List mock
+func (cf CPUFreq) List(fstats []metrics.FStats) ([]rpi.CPUFreq, error) {
+	return cf.ListFn(fstats)
+}
+
+// View mock
+func (cf CPUFreq) View(id int, fstats []metrics.FStats) (rpi.CPUFreq, error) {
+	return cf.ViewFn(id, fstats)
+}
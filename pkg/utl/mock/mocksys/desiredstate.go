@@ -0,0 +1,15 @@
+package mocksys
+
+import (
+	"github.com/raspibuddy/rpi"
+)
+
+// DesiredState mock
+type DesiredState struct {
+	DriftFn func(rpi.DesiredState, rpi.ObservedState) (rpi.DriftReport, error)
+}
+
+// Drift mock
+func (ds DesiredState) Drift(desired rpi.DesiredState, observed rpi.ObservedState) (rpi.DriftReport, error) {
+	return ds.DriftFn(desired, observed)
+}
@@ -7,8 +7,10 @@ import (
 
 // Disk mock
 type Disk struct {
-	ListFn func(map[string][]metrics.DStats) ([]rpi.Disk, error)
-	ViewFn func(string, map[string][]metrics.DStats) (rpi.Disk, error)
+	ListFn   func(map[string][]metrics.DStats) ([]rpi.Disk, error)
+	ViewFn   func(string, map[string][]metrics.DStats) (rpi.Disk, error)
+	ListIOFn func(map[string]metrics.DIOStats) ([]rpi.DiskIO, error)
+	ViewIOFn func(string, map[string]metrics.DIOStats) (rpi.DiskIO, error)
 }
 
 // List mock
@@ -20,3 +22,13 @@ func (d *Disk) List(dstats map[string][]metrics.DStats) ([]rpi.Disk, error) {
 func (d *Disk) View(id string, dstats map[string][]metrics.DStats) (rpi.Disk, error) {
 	return d.ViewFn(id, dstats)
 }
+
+// ListIO mock
+func (d *Disk) ListIO(diostats map[string]metrics.DIOStats) ([]rpi.DiskIO, error) {
+	return d.ListIOFn(diostats)
+}
+
+// ViewIO mock
+func (d *Disk) ViewIO(id string, diostats map[string]metrics.DIOStats) (rpi.DiskIO, error) {
+	return d.ViewIOFn(id, diostats)
+}
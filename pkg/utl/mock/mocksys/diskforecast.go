@@ -0,0 +1,22 @@
+package mocksys
+
+import (
+	"time"
+
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/history"
+)
+
+// DiskForecast mock
+type DiskForecast struct {
+	ListFn func(map[string][]history.Sample, time.Duration, time.Time) ([]rpi.DiskForecast, error)
+}
+
+// List mock
+func (df DiskForecast) List(
+	samples map[string][]history.Sample,
+	horizon time.Duration,
+	now time.Time,
+) ([]rpi.DiskForecast, error) {
+	return df.ListFn(samples, horizon, now)
+}
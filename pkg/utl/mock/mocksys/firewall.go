@@ -0,0 +1,28 @@
+package mocksys
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// Firewall mock
+type Firewall struct {
+	ListFn      func(bool, bool, []string) (rpi.Firewall, error)
+	ExecuteFRFn func(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteCFFn func(map[int](map[int]actions.Func)) (rpi.Action, error)
+}
+
+// List mock
+func (f Firewall) List(isInstalled bool, isPending bool, ruleset []string) (rpi.Firewall, error) {
+	return f.ListFn(isInstalled, isPending, ruleset)
+}
+
+// ExecuteFR mock
+func (f Firewall) ExecuteFR(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return f.ExecuteFRFn(plan)
+}
+
+// ExecuteCF mock
+func (f Firewall) ExecuteCF(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return f.ExecuteCFFn(plan)
+}
@@ -0,0 +1,28 @@
+package mocksys
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// GPUMem mock
+type GPUMem struct {
+	ListFn      func(rpi.Board, []string, uint64) (rpi.GPUMemory, error)
+	CheckFn     func(rpi.GPUMemory, string, uint64) error
+	ExecuteGMFn func(map[int](map[int]actions.Func)) (rpi.Action, error)
+}
+
+// List mock
+func (g GPUMem) List(board rpi.Board, config []string, running uint64) (rpi.GPUMemory, error) {
+	return g.ListFn(board, config, running)
+}
+
+// Check mock
+func (g GPUMem) Check(gm rpi.GPUMemory, key string, value uint64) error {
+	return g.CheckFn(gm, key, value)
+}
+
+// ExecuteGM mock
+func (g GPUMem) ExecuteGM(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return g.ExecuteGMFn(plan)
+}
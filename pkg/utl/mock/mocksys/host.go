@@ -23,6 +23,7 @@ type Host struct {
 		string,
 		string,
 		string,
+		string,
 		map[string][]metrics.DStats,
 		[]net.InterfaceStat) (rpi.Host, error)
 }
@@ -38,7 +39,8 @@ func (h Host) List(infos host.InfoStat,
 	temp string,
 	serialNumber string,
 	rpiv string,
+	revision string,
 	listDev map[string][]metrics.DStats,
 	netInfo []net.InterfaceStat) (rpi.Host, error) {
-	return h.ListFn(infos, users, cpus, vcores, vmem, smem, load, temp, serialNumber, rpiv, listDev, netInfo)
+	return h.ListFn(infos, users, cpus, vcores, vmem, smem, load, temp, serialNumber, rpiv, revision, listDev, netInfo)
 }
@@ -0,0 +1,28 @@
+package mocksys
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// Hotspot mock
+type Hotspot struct {
+	ListFn      func(string, string, bool, []string, []string, []string, []string, []string) (rpi.Hotspot, error)
+	ExecuteEHFn func(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteDHFn func(map[int](map[int]actions.Func)) (rpi.Action, error)
+}
+
+// List mock
+func (h Hotspot) List(backend string, iface string, isEnabled bool, config []string, dnsmasq []string, dhcpcd []string, stations []string, leases []string) (rpi.Hotspot, error) {
+	return h.ListFn(backend, iface, isEnabled, config, dnsmasq, dhcpcd, stations, leases)
+}
+
+// ExecuteEH mock
+func (h Hotspot) ExecuteEH(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return h.ExecuteEHFn(plan)
+}
+
+// ExecuteDH mock
+func (h Hotspot) ExecuteDH(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return h.ExecuteDHFn(plan)
+}
@@ -6,10 +6,10 @@ import (
 
 // HumanUser mock
 type HumanUser struct {
-	ListFn func(lines []string) ([]rpi.HumanUser, error)
+	ListFn func(lines []string, groups []string, shadow []string, lastLog []string) ([]rpi.HumanUser, error)
 }
 
 // List mock
-func (hu HumanUser) List(lines []string) ([]rpi.HumanUser, error) {
-	return hu.ListFn(lines)
+func (hu HumanUser) List(lines []string, groups []string, shadow []string, lastLog []string) ([]rpi.HumanUser, error) {
+	return hu.ListFn(lines, groups, shadow, lastLog)
 }
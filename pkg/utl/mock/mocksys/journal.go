@@ -0,0 +1,21 @@
+package mocksys
+
+import (
+	"github.com/raspibuddy/rpi"
+)
+
+// Journal mock
+type Journal struct {
+	ArgsFn func(rpi.JournalQuery) []string
+	ListFn func([]string) ([]rpi.JournalEntry, error)
+}
+
+// Args mock
+func (j Journal) Args(q rpi.JournalQuery) []string {
+	return j.ArgsFn(q)
+}
+
+// List mock
+func (j Journal) List(lines []string) ([]rpi.JournalEntry, error) {
+	return j.ListFn(lines)
+}
@@ -0,0 +1,15 @@
+package mocksys
+
+import (
+	"github.com/raspibuddy/rpi"
+)
+
+// KernelLog mock
+type KernelLog struct {
+	ListFn func([]string, int, uint64) ([]rpi.KernelLog, error)
+}
+
+// List mock
+func (kl KernelLog) List(records []string, level int, since uint64) ([]rpi.KernelLog, error) {
+	return kl.ListFn(records, level, since)
+}
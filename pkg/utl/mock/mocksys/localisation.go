@@ -0,0 +1,46 @@
+package mocksys
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// Localisation mock
+type Localisation struct {
+	ListFn      func([]string, []string, []string) (rpi.Localisation, error)
+	LocalesFn   func([]string) ([]rpi.Locale, error)
+	KeyboardsFn func([]string) ([]rpi.KeyboardLayout, error)
+	ExecuteTZFn func(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteLCFn func(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteKBFn func(map[int](map[int]actions.Func)) (rpi.Action, error)
+}
+
+// List mock
+func (l Localisation) List(timezone []string, locale []string, keyboard []string) (rpi.Localisation, error) {
+	return l.ListFn(timezone, locale, keyboard)
+}
+
+// Locales mock
+func (l Localisation) Locales(supported []string) ([]rpi.Locale, error) {
+	return l.LocalesFn(supported)
+}
+
+// Keyboards mock
+func (l Localisation) Keyboards(rules []string) ([]rpi.KeyboardLayout, error) {
+	return l.KeyboardsFn(rules)
+}
+
+// ExecuteTZ mock
+func (l Localisation) ExecuteTZ(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return l.ExecuteTZFn(plan)
+}
+
+// ExecuteLC mock
+func (l Localisation) ExecuteLC(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return l.ExecuteLCFn(plan)
+}
+
+// ExecuteKB mock
+func (l Localisation) ExecuteKB(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return l.ExecuteKBFn(plan)
+}
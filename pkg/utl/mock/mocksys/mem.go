@@ -2,15 +2,28 @@ package mocksys
 
 import (
 	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/metrics"
 	"github.com/shirou/gopsutil/mem"
 )
 
 // Mem mock
 type Mem struct {
-	ListFn func(mem.SwapMemoryStat, mem.VirtualMemoryStat) (rpi.Mem, error)
+	ListFn       func(mem.SwapMemoryStat, mem.VirtualMemoryStat) (rpi.Mem, error)
+	ViewDetailFn func(map[string]uint64, uint64) (rpi.MemDetail, error)
+	ListTopFn    func([]metrics.PMStats, string, int) ([]rpi.MemConsumer, error)
 }
 
 // List mock
 func (m Mem) List(smem mem.SwapMemoryStat, vmem mem.VirtualMemoryStat) (rpi.Mem, error) {
 	return m.ListFn(smem, vmem)
 }
+
+// ViewDetail mock
+func (m Mem) ViewDetail(meminfo map[string]uint64, gpu uint64) (rpi.MemDetail, error) {
+	return m.ViewDetailFn(meminfo, gpu)
+}
+
+// ListTop mock
+func (m Mem) ListTop(pmstats []metrics.PMStats, sortBy string, limit int) ([]rpi.MemConsumer, error) {
+	return m.ListTopFn(pmstats, sortBy, limit)
+}
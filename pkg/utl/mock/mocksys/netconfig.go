@@ -0,0 +1,23 @@
+package mocksys
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/shirou/gopsutil/net"
+)
+
+// NetConfig mock
+type NetConfig struct {
+	ListFn      func(string, []string, []string, map[string][]string, []net.InterfaceStat) (rpi.NetworkConfig, error)
+	ExecuteNCFn func(map[int](map[int]actions.Func)) (rpi.Action, error)
+}
+
+// List mock
+func (n NetConfig) List(backend string, dhcpcd []string, connections []string, keyfiles map[string][]string, interfaces []net.InterfaceStat) (rpi.NetworkConfig, error) {
+	return n.ListFn(backend, dhcpcd, connections, keyfiles, interfaces)
+}
+
+// ExecuteNC mock
+func (n NetConfig) ExecuteNC(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return n.ExecuteNCFn(plan)
+}
@@ -0,0 +1,35 @@
+package mocksys
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+	"github.com/raspibuddy/rpi/pkg/utl/history"
+)
+
+// Overclock mock
+type Overclock struct {
+	ListFn       func(rpi.Board, []string, string, map[string]string, []string, []history.Sample, string) (rpi.Overclock, error)
+	SettingsFn   func(rpi.Overclock, rpi.Board, string, map[string]string) ([]rpi.OverclockSetting, error)
+	ExecuteOCFn  func(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteOCRFn func(map[int](map[int]actions.Func)) (rpi.Action, error)
+}
+
+// List mock
+func (o Overclock) List(board rpi.Board, config []string, state string, markers map[string]string, cooling []string, temperatures []history.Sample, throttled string) (rpi.Overclock, error) {
+	return o.ListFn(board, config, state, markers, cooling, temperatures, throttled)
+}
+
+// Settings mock
+func (o Overclock) Settings(oc rpi.Overclock, board rpi.Board, profile string, custom map[string]string) ([]rpi.OverclockSetting, error) {
+	return o.SettingsFn(oc, board, profile, custom)
+}
+
+// ExecuteOC mock
+func (o Overclock) ExecuteOC(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return o.ExecuteOCFn(plan)
+}
+
+// ExecuteOCR mock
+func (o Overclock) ExecuteOCR(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return o.ExecuteOCRFn(plan)
+}
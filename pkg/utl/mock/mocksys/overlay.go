@@ -0,0 +1,34 @@
+package mocksys
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// Overlay mock
+type Overlay struct {
+	ListFn       func([]string, []string, []string, []string, map[string]string) (rpi.Overlays, error)
+	ViewFn       func(string, rpi.Overlays) (rpi.Overlay, error)
+	ExecuteOVLFn func(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteDTPFn func(map[int](map[int]actions.Func)) (rpi.Action, error)
+}
+
+// List mock
+func (o Overlay) List(files []string, readme []string, config []string, loaded []string, runtime map[string]string) (rpi.Overlays, error) {
+	return o.ListFn(files, readme, config, loaded, runtime)
+}
+
+// View mock
+func (o Overlay) View(name string, overlays rpi.Overlays) (rpi.Overlay, error) {
+	return o.ViewFn(name, overlays)
+}
+
+// ExecuteOVL mock
+func (o Overlay) ExecuteOVL(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return o.ExecuteOVLFn(plan)
+}
+
+// ExecuteDTP mock
+func (o Overlay) ExecuteDTP(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return o.ExecuteDTPFn(plan)
+}
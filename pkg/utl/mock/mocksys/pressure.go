@@ -0,0 +1,22 @@
+package mocksys
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/metrics"
+)
+
+// Pressure mock
+type Pressure struct {
+	ListFn func(map[string]metrics.PStats) ([]rpi.Pressure, error)
+	ViewFn func(string, map[string]metrics.PStats) (rpi.Pressure, error)
+}
+
+// List mock
+func (p Pressure) List(pstats map[string]metrics.PStats) ([]rpi.Pressure, error) {
+	return p.ListFn(pstats)
+}
+
+// View mock
+func (p Pressure) View(resource string, pstats map[string]metrics.PStats) (rpi.Pressure, error) {
+	return p.ViewFn(resource, pstats)
+}
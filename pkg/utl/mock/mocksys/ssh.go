@@ -0,0 +1,40 @@
+package mocksys
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// SSH mock
+type SSH struct {
+	ListFn       func([]string, []string) (rpi.SSH, error)
+	ListAKFn     func([]string) ([]rpi.SSHKey, error)
+	ExecuteAKFn  func(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteSOFn  func(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteRHKFn func(map[int](map[int]actions.Func)) (rpi.Action, error)
+}
+
+// List mock
+func (s SSH) List(options []string, hostKeys []string) (rpi.SSH, error) {
+	return s.ListFn(options, hostKeys)
+}
+
+// ListAK mock
+func (s SSH) ListAK(lines []string) ([]rpi.SSHKey, error) {
+	return s.ListAKFn(lines)
+}
+
+// ExecuteAK mock
+func (s SSH) ExecuteAK(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return s.ExecuteAKFn(plan)
+}
+
+// ExecuteSO mock
+func (s SSH) ExecuteSO(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return s.ExecuteSOFn(plan)
+}
+
+// ExecuteRHK mock
+func (s SSH) ExecuteRHK(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return s.ExecuteRHKFn(plan)
+}
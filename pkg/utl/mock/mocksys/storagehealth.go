@@ -0,0 +1,33 @@
+package mocksys
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/infos"
+)
+
+// StorageHealth mock
+type StorageHealth struct {
+	ListFn func([]string, map[string]map[string]string, map[string]infos.SmartReport, []string) ([]rpi.StorageHealth, error)
+	ViewFn func(string, []string, map[string]map[string]string, map[string]infos.SmartReport, []string) (rpi.StorageHealth, error)
+}
+
+// List mock
+func (sh StorageHealth) List(
+	devices []string,
+	mmc map[string]map[string]string,
+	smart map[string]infos.SmartReport,
+	roRemounts []string,
+) ([]rpi.StorageHealth, error) {
+	return sh.ListFn(devices, mmc, smart, roRemounts)
+}
+
+// View mock
+func (sh StorageHealth) View(
+	id string,
+	devices []string,
+	mmc map[string]map[string]string,
+	smart map[string]infos.SmartReport,
+	roRemounts []string,
+) (rpi.StorageHealth, error) {
+	return sh.ViewFn(id, devices, mmc, smart, roRemounts)
+}
@@ -0,0 +1,21 @@
+package mocksys
+
+import (
+	"github.com/raspibuddy/rpi"
+)
+
+// SystemdUnit mock
+type SystemdUnit struct {
+	ListFn func([]string, []string) ([]rpi.SystemdUnit, error)
+	ViewFn func(string, []string, []string, map[string]string) (rpi.SystemdUnit, error)
+}
+
+// List mock
+func (su SystemdUnit) List(units []string, unitFiles []string) ([]rpi.SystemdUnit, error) {
+	return su.ListFn(units, unitFiles)
+}
+
+// View mock
+func (su SystemdUnit) View(id string, units []string, unitFiles []string, show map[string]string) (rpi.SystemdUnit, error) {
+	return su.ViewFn(id, units, unitFiles, show)
+}
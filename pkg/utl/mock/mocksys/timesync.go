@@ -0,0 +1,34 @@
+package mocksys
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// TimeSync mock
+type TimeSync struct {
+	ListFn       func(string, string, []string, int64) (rpi.Time, error)
+	ExecuteNTPFn func(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteTSFn  func(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteSTFn  func(map[int](map[int]actions.Func)) (rpi.Action, error)
+}
+
+// List mock
+func (ts TimeSync) List(show string, timesync string, config []string, now int64) (rpi.Time, error) {
+	return ts.ListFn(show, timesync, config, now)
+}
+
+// ExecuteNTP mock
+func (ts TimeSync) ExecuteNTP(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return ts.ExecuteNTPFn(plan)
+}
+
+// ExecuteTS mock
+func (ts TimeSync) ExecuteTS(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return ts.ExecuteTSFn(plan)
+}
+
+// ExecuteST mock
+func (ts TimeSync) ExecuteST(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return ts.ExecuteSTFn(plan)
+}
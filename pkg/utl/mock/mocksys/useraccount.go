@@ -0,0 +1,46 @@
+package mocksys
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// UserAccount mock
+type UserAccount struct {
+	ListGFn     func([]string) ([]rpi.UserGroup, error)
+	ExecuteUGFn func(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteLUFn func(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteUUFn func(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecutePEFn func(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteCSFn func(map[int](map[int]actions.Func)) (rpi.Action, error)
+}
+
+// ListG mock
+func (ua UserAccount) ListG(lines []string) ([]rpi.UserGroup, error) {
+	return ua.ListGFn(lines)
+}
+
+// ExecuteUG mock
+func (ua UserAccount) ExecuteUG(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return ua.ExecuteUGFn(plan)
+}
+
+// ExecuteLU mock
+func (ua UserAccount) ExecuteLU(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return ua.ExecuteLUFn(plan)
+}
+
+// ExecuteUU mock
+func (ua UserAccount) ExecuteUU(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return ua.ExecuteUUFn(plan)
+}
+
+// ExecutePE mock
+func (ua UserAccount) ExecutePE(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return ua.ExecutePEFn(plan)
+}
+
+// ExecuteCS mock
+func (ua UserAccount) ExecuteCS(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return ua.ExecuteCSFn(plan)
+}
@@ -0,0 +1,46 @@
+package mocksys
+
+import (
+	"github.com/raspibuddy/rpi"
+	"github.com/raspibuddy/rpi/pkg/utl/actions"
+)
+
+// Wifi mock
+type Wifi struct {
+	ListFn       func(string, bool, []string, []string) (rpi.Wifi, error)
+	ScanFn       func([]string, rpi.Wifi) ([]rpi.WifiScan, error)
+	ExecuteWNFn  func(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteWSFn  func(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteSWNFn func(map[int](map[int]actions.Func)) (rpi.Action, error)
+	ExecuteRCFn  func(map[int](map[int]actions.Func)) (rpi.Action, error)
+}
+
+// List mock
+func (w Wifi) List(iface string, isWpaSupCom bool, config []string, networks []string) (rpi.Wifi, error) {
+	return w.ListFn(iface, isWpaSupCom, config, networks)
+}
+
+// Scan mock
+func (w Wifi) Scan(results []string, wifi rpi.Wifi) ([]rpi.WifiScan, error) {
+	return w.ScanFn(results, wifi)
+}
+
+// ExecuteWN mock
+func (w Wifi) ExecuteWN(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return w.ExecuteWNFn(plan)
+}
+
+// ExecuteWS mock
+func (w Wifi) ExecuteWS(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return w.ExecuteWSFn(plan)
+}
+
+// ExecuteSWN mock
+func (w Wifi) ExecuteSWN(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return w.ExecuteSWNFn(plan)
+}
+
+// ExecuteRC mock
+func (w Wifi) ExecuteRC(plan map[int](map[int]actions.Func)) (rpi.Action, error) {
+	return w.ExecuteRCFn(plan)
+}
@@ -0,0 +1,157 @@
+package mock
+
+import (
+	"github.com/raspibuddy/rpi"
+)
+
+// RpInterface mock
+type RpInterface struct {
+	ListFn func() (rpi.RpInterface, error)
+}
+
+// List mock
+func (in RpInterface) List() (rpi.RpInterface, error) {
+	return in.ListFn()
+}
+
+// Boot mock
+type Boot struct {
+	ListFn func() (rpi.Boot, error)
+}
+
+// List mock
+func (b Boot) List() (rpi.Boot, error) {
+	return b.ListFn()
+}
+
+// Display mock
+type Display struct {
+	ListFn func() (rpi.Display, error)
+}
+
+// List mock
+func (d Display) List() (rpi.Display, error) {
+	return d.ListFn()
+}
+
+// Board mock
+type Board struct {
+	ListFn func() (rpi.Board, error)
+}
+
+// List mock
+func (b Board) List() (rpi.Board, error) {
+	return b.ListFn()
+}
+
+// Software mock
+type Software struct {
+	ViewFn func(string) (rpi.Software, error)
+}
+
+// View mock
+func (so Software) View(pkg string) (rpi.Software, error) {
+	return so.ViewFn(pkg)
+}
+
+// HumanUser mock
+type HumanUser struct {
+	ListFn func() ([]rpi.HumanUser, error)
+}
+
+// List mock
+func (hu HumanUser) List() ([]rpi.HumanUser, error) {
+	return hu.ListFn()
+}
+
+// Configure mock
+type Configure struct {
+	ExecuteCHFn  func(string) (rpi.Action, error)
+	ExecuteWNBFn func(string) (rpi.Action, error)
+	ExecuteOVFn  func(string) (rpi.Action, error)
+	ExecuteBLFn  func(string) (rpi.Action, error)
+	ExecuteAUSFn func(string, string) (rpi.Action, error)
+	ExecuteCAFn  func(string) (rpi.Action, error)
+	ExecuteSSHFn func(string) (rpi.Action, error)
+	ExecuteVNCFn func(string) (rpi.Action, error)
+	ExecuteSPIFn func(string) (rpi.Action, error)
+	ExecuteI2CFn func(string) (rpi.Action, error)
+	ExecuteONWFn func(string) (rpi.Action, error)
+	ExecuteRGFn  func(string) (rpi.Action, error)
+	ExecuteWCFn  func(string, string) (rpi.Action, error)
+}
+
+// ExecuteCH mock
+func (con Configure) ExecuteCH(hostname string) (rpi.Action, error) {
+	return con.ExecuteCHFn(hostname)
+}
+
+// ExecuteWNB mock
+func (con Configure) ExecuteWNB(action string) (rpi.Action, error) {
+	return con.ExecuteWNBFn(action)
+}
+
+// ExecuteOV mock
+func (con Configure) ExecuteOV(action string) (rpi.Action, error) {
+	return con.ExecuteOVFn(action)
+}
+
+// ExecuteBL mock
+func (con Configure) ExecuteBL(action string) (rpi.Action, error) {
+	return con.ExecuteBLFn(action)
+}
+
+// ExecuteAUS mock
+func (con Configure) ExecuteAUS(username string, password string) (rpi.Action, error) {
+	return con.ExecuteAUSFn(username, password)
+}
+
+// ExecuteCA mock
+func (con Configure) ExecuteCA(action string) (rpi.Action, error) {
+	return con.ExecuteCAFn(action)
+}
+
+// ExecuteSSH mock
+func (con Configure) ExecuteSSH(action string) (rpi.Action, error) {
+	return con.ExecuteSSHFn(action)
+}
+
+// ExecuteVNC mock
+func (con Configure) ExecuteVNC(action string) (rpi.Action, error) {
+	return con.ExecuteVNCFn(action)
+}
+
+// ExecuteSPI mock
+func (con Configure) ExecuteSPI(action string) (rpi.Action, error) {
+	return con.ExecuteSPIFn(action)
+}
+
+// ExecuteI2C mock
+func (con Configure) ExecuteI2C(action string) (rpi.Action, error) {
+	return con.ExecuteI2CFn(action)
+}
+
+// ExecuteONW mock
+func (con Configure) ExecuteONW(action string) (rpi.Action, error) {
+	return con.ExecuteONWFn(action)
+}
+
+// ExecuteRG mock
+func (con Configure) ExecuteRG(action string) (rpi.Action, error) {
+	return con.ExecuteRGFn(action)
+}
+
+// ExecuteWC mock
+func (con Configure) ExecuteWC(iface string, country string) (rpi.Action, error) {
+	return con.ExecuteWCFn(iface, country)
+}
+
+// AppInstall mock
+type AppInstall struct {
+	ExecuteAGFn func(string, string) (rpi.Action, error)
+}
+
+// ExecuteAG mock
+func (ins AppInstall) ExecuteAG(action string, pkg string) (rpi.Action, error) {
+	return ins.ExecuteAGFn(action, pkg)
+}
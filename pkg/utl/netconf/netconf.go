@@ -0,0 +1,423 @@
+// Package netconf parses and writes the ip configuration of the network interfaces, kept in
+// /etc/dhcpcd.conf up to Raspberry Pi OS Bullseye and in NetworkManager keyfiles from Bookworm.
+// Only the settings being edited are rewritten, every other line is left untouched.
+package netconf
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Dhcpcd is the backend configuring the interfaces through /etc/dhcpcd.conf
+	Dhcpcd = "dhcpcd"
+
+	// NetworkManager is the backend configuring the interfaces through keyfiles
+	NetworkManager = "networkmanager"
+
+	// Hostapd is the backend hosting an access point with hostapd and dnsmasq, next to dhcpcd
+	Hostapd = "hostapd"
+
+	// Ethernet is the type of a wired connection keyfile
+	Ethernet = "ethernet"
+)
+
+var (
+	blockRegex   = regexp.MustCompile(`^\s*(interface|ssid)\s+(\S+)`)
+	staticRegex  = regexp.MustCompile(`^\s*static\s+([a-z0-9_]+)\s*=\s*(.*?)\s*$`)
+	sectionRegex = regexp.MustCompile(`^\s*\[(.+)\]\s*$`)
+	keyRegex     = regexp.MustCompile(`^\s*([A-Za-z0-9_.\-]+)\s*=\s*(.*?)\s*$`)
+	managedRegex = regexp.MustCompile(`^(method|address\d*|addresses|gateway|dns|ignore-auto-dns)$`)
+
+	// dhcpcdKeys lists the static options written by SetDhcpcd
+	dhcpcdKeys = map[string]bool{"ip_address": true, "ip6_address": true, "routers": true, "domain_name_servers": true}
+)
+
+// Settings represents the ip configuration of an interface.
+// Addresses are in CIDR notation, the dns servers are used whether the interface is static or not.
+type Settings struct {
+	IsStatic bool
+	IPv4     []string
+	Router   string
+	IPv6     []string
+	Router6  string
+	DNS      []string
+}
+
+// Keyfile represents a NetworkManager connection keyfile
+type Keyfile struct {
+	ID        string
+	UUID      string
+	Type      string
+	Interface string
+	Settings  Settings
+}
+
+// DhcpcdSettings returns the static settings of the interface block of dhcpcd.conf
+func DhcpcdSettings(lines []string, iface string) Settings {
+	s := Settings{IPv4: []string{}, IPv6: []string{}, DNS: []string{}}
+
+	start, end := dhcpcdBlock(lines, iface)
+	if start < 0 {
+		return s
+	}
+
+	for _, line := range lines[start+1 : end] {
+		m := staticRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		values := strings.Fields(m[2])
+		switch m[1] {
+		case "ip_address":
+			s.IPv4 = append(s.IPv4, values...)
+		case "ip6_address":
+			s.IPv6 = append(s.IPv6, values...)
+		case "routers":
+			if len(values) > 0 {
+				s.Router = values[0]
+			}
+		case "domain_name_servers":
+			s.DNS = append(s.DNS, values...)
+		}
+	}
+	s.IsStatic = len(s.IPv4) > 0
+
+	return s
+}
+
+// SetDhcpcd returns the lines of dhcpcd.conf with the static settings of an interface replaced.
+// The interface block is added when missing, and removed once it has no option left.
+// dhcpcd has no static ipv6 router, it is learnt from the router advertisements.
+func SetDhcpcd(lines []string, iface string, s Settings) []string {
+	static := []string{}
+	if s.IsStatic {
+		static = append(static, "static ip_address="+strings.Join(s.IPv4, " "))
+		if len(s.IPv6) > 0 {
+			static = append(static, "static ip6_address="+strings.Join(s.IPv6, " "))
+		}
+		if s.Router != "" {
+			static = append(static, "static routers="+s.Router)
+		}
+	}
+	if len(s.DNS) > 0 {
+		static = append(static, "static domain_name_servers="+strings.Join(s.DNS, " "))
+	}
+
+	result := append([]string{}, lines...)
+	start, end := dhcpcdBlock(result, iface)
+
+	if start < 0 {
+		if len(static) == 0 {
+			return result
+		}
+		if len(result) > 0 && strings.TrimSpace(result[len(result)-1]) != "" {
+			result = append(result, "")
+		}
+		return append(append(result, "interface "+iface), static...)
+	}
+
+	block := []string{}
+	isEmpty := true
+	for _, line := range result[start+1 : end] {
+		if m := staticRegex.FindStringSubmatch(line); m != nil && dhcpcdKeys[m[1]] {
+			continue
+		}
+		if strings.TrimSpace(line) != "" {
+			isEmpty = false
+		}
+		block = append(block, line)
+	}
+
+	if len(static) == 0 && isEmpty {
+		return removeBlock(result, start, end)
+	}
+
+	edited := append(append([]string{result[start]}, static...), block...)
+	return append(append(append([]string{}, result[:start]...), edited...), result[end:]...)
+}
+
+// SetDhcpcdOption returns the lines of dhcpcd.conf with an option (ex: nohook wpa_supplicant) added to
+// or removed from the block of an interface, the block being added when missing and removed once empty
+func SetDhcpcdOption(lines []string, iface string, option string, isSet bool) []string {
+	result := append([]string{}, lines...)
+	start, end := dhcpcdBlock(result, iface)
+
+	if start < 0 {
+		if !isSet {
+			return result
+		}
+		if len(result) > 0 && strings.TrimSpace(result[len(result)-1]) != "" {
+			result = append(result, "")
+		}
+		return append(result, "interface "+iface, option)
+	}
+
+	block := []string{}
+	isEmpty := true
+	for _, line := range result[start+1 : end] {
+		if strings.Join(strings.Fields(line), " ") == option {
+			continue
+		}
+		if strings.TrimSpace(line) != "" {
+			isEmpty = false
+		}
+		block = append(block, line)
+	}
+
+	if !isSet && isEmpty {
+		return removeBlock(result, start, end)
+	}
+
+	edited := []string{result[start]}
+	if isSet {
+		edited = append(edited, option)
+	}
+	edited = append(edited, block...)
+	return append(append(append([]string{}, result[:start]...), edited...), result[end:]...)
+}
+
+// ParseKeyfile parses the connection and ip settings of a NetworkManager keyfile
+func ParseKeyfile(lines []string) Keyfile {
+	sections := KeyfileSections(lines)
+
+	k := Keyfile{
+		ID:        sections["connection"]["id"],
+		UUID:      sections["connection"]["uuid"],
+		Type:      sections["connection"]["type"],
+		Interface: sections["connection"]["interface-name"],
+		Settings:  Settings{IPv4: []string{}, IPv6: []string{}, DNS: []string{}},
+	}
+
+	ipv4, router := keyfileAddresses(sections["ipv4"])
+	ipv6, router6 := keyfileAddresses(sections["ipv6"])
+
+	if sections["ipv4"]["method"] == "manual" {
+		k.Settings.IsStatic = true
+		k.Settings.IPv4 = ipv4
+		k.Settings.Router = router
+	}
+	if sections["ipv6"]["method"] == "manual" {
+		k.Settings.IPv6 = ipv6
+		k.Settings.Router6 = router6
+	}
+	for _, section := range []string{"ipv4", "ipv6"} {
+		k.Settings.DNS = append(k.Settings.DNS, split(sections[section]["dns"])...)
+	}
+
+	return k
+}
+
+// SetKeyfile returns the lines of a NetworkManager keyfile with the ip settings replaced.
+// A method other than manual (ex: shared) is kept when the interface is not static.
+func SetKeyfile(lines []string, s Settings) []string {
+	sections := KeyfileSections(lines)
+
+	dns4, dns6 := []string{}, []string{}
+	for _, d := range s.DNS {
+		if ip := net.ParseIP(d); ip != nil && ip.To4() != nil {
+			dns4 = append(dns4, d)
+		} else {
+			dns6 = append(dns6, d)
+		}
+	}
+
+	var ipv4 []string
+	if s.IsStatic {
+		ipv4 = keyfileValues("manual", s.IPv4, s.Router, dns4)
+	} else {
+		ipv4 = keyfileValues(method(sections["ipv4"]["method"]), nil, "", dns4)
+	}
+
+	var ipv6 []string
+	if s.IsStatic && len(s.IPv6) > 0 {
+		ipv6 = keyfileValues("manual", s.IPv6, s.Router6, dns6)
+	} else {
+		ipv6 = keyfileValues(method(sections["ipv6"]["method"]), nil, "", dns6)
+	}
+
+	result := setSection(lines, "ipv4", ipv4)
+	return setSection(result, "ipv6", ipv6)
+}
+
+// NewKeyfile returns the lines of a keyfile connecting an interface with dhcp
+func NewKeyfile(id string, uuid string, connectionType string, iface string) []string {
+	return []string{
+		"[connection]",
+		"id=" + id,
+		"uuid=" + uuid,
+		"type=" + connectionType,
+		"interface-name=" + iface,
+		"autoconnect-priority=10",
+		"",
+		"[" + connectionType + "]",
+		"",
+		"[ipv4]",
+		"method=auto",
+		"",
+		"[ipv6]",
+		"method=auto",
+		"",
+		"[proxy]",
+	}
+}
+
+// NewUUID returns a random (version 4) uuid, identifying a new connection
+func NewUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// dhcpcdBlock returns the first line of the block of an interface and the first line after it, -1 when missing
+func dhcpcdBlock(lines []string, iface string) (int, int) {
+	start := -1
+	for i, line := range lines {
+		m := blockRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		if start >= 0 {
+			return start, i
+		}
+		if m[1] == "interface" && m[2] == iface {
+			start = i
+		}
+	}
+	return start, len(lines)
+}
+
+// removeBlock removes the lines of a block, and the blank line left before it
+func removeBlock(lines []string, start int, end int) []string {
+	result := append(lines[:start], lines[end:]...)
+	if start > 0 && strings.TrimSpace(result[start-1]) == "" && (start == len(result) || strings.TrimSpace(result[start]) == "") {
+		result = append(result[:start-1], result[start:]...)
+	}
+	return result
+}
+
+// KeyfileSections returns the keys of each section of a keyfile
+func KeyfileSections(lines []string) map[string]map[string]string {
+	result := map[string]map[string]string{}
+	section := ""
+
+	for _, line := range lines {
+		if m := sectionRegex.FindStringSubmatch(line); m != nil {
+			section = m[1]
+			if _, ok := result[section]; !ok {
+				result[section] = map[string]string{}
+			}
+			continue
+		}
+		if m := keyRegex.FindStringSubmatch(line); m != nil && section != "" {
+			result[section][m[1]] = m[2]
+		}
+	}
+
+	return result
+}
+
+// keyfileAddresses returns the addresses and the gateway of an ip section.
+// The gateway is either a key of its own or follows the first address (ex: address1=10.0.0.2/24,10.0.0.1).
+func keyfileAddresses(keys map[string]string) ([]string, string) {
+	addresses := []string{}
+	gateway := keys["gateway"]
+
+	for i := 0; i < 256; i++ {
+		value, ok := keys["address"+strconv.Itoa(i)]
+		if !ok {
+			if i > 0 {
+				break
+			}
+			continue
+		}
+		fields := strings.Split(value, ",")
+		addresses = append(addresses, fields[0])
+		if len(fields) > 1 && gateway == "" {
+			gateway = fields[1]
+		}
+	}
+
+	for _, value := range split(keys["addresses"]) {
+		fields := strings.Split(value, ",")
+		addresses = append(addresses, fields[0])
+		if len(fields) > 1 && gateway == "" {
+			gateway = fields[1]
+		}
+	}
+
+	return addresses, gateway
+}
+
+// keyfileValues returns the lines of an ip section
+func keyfileValues(method string, addresses []string, gateway string, dns []string) []string {
+	result := []string{"method=" + method}
+	for i, a := range addresses {
+		result = append(result, "address"+strconv.Itoa(i+1)+"="+a)
+	}
+	if gateway != "" {
+		result = append(result, "gateway="+gateway)
+	}
+	if len(dns) > 0 {
+		result = append(result, "dns="+strings.Join(dns, ";")+";", "ignore-auto-dns=true")
+	}
+	return result
+}
+
+// setSection replaces the ip settings of a section, adding the section when missing
+func setSection(lines []string, section string, values []string) []string {
+	result := []string{}
+	current := ""
+	isFound := false
+
+	for _, line := range lines {
+		if m := sectionRegex.FindStringSubmatch(line); m != nil {
+			current = m[1]
+			result = append(result, line)
+			if current == section && !isFound {
+				result = append(result, values...)
+				isFound = true
+			}
+			continue
+		}
+		if m := keyRegex.FindStringSubmatch(line); m != nil && current == section && managedRegex.MatchString(m[1]) {
+			continue
+		}
+		result = append(result, line)
+	}
+
+	if !isFound {
+		if len(result) > 0 && strings.TrimSpace(result[len(result)-1]) != "" {
+			result = append(result, "")
+		}
+		result = append(append(result, "["+section+"]"), values...)
+	}
+
+	return result
+}
+
+// method returns the method of a section which is not static anymore
+func method(current string) string {
+	if current == "" || current == "manual" {
+		return "auto"
+	}
+	return current
+}
+
+// split returns the values of a list separated by semicolons (ex: 1.1.1.1;8.8.8.8;)
+func split(value string) []string {
+	result := []string{}
+	for _, v := range strings.Split(value, ";") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
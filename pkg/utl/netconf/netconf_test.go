@@ -0,0 +1,339 @@
+package netconf_test
+
+import (
+	"testing"
+
+	"github.com/raspibuddy/rpi/pkg/utl/netconf"
+	"github.com/stretchr/testify/assert"
+)
+
+var dhcpcd = []string{
+	"# A sample configuration for dhcpcd.",
+	"hostname",
+	"",
+	"interface eth0",
+	"static ip_address=192.168.1.10/24",
+	"static ip6_address=fd51:42f8:caae:d92e::ff/64",
+	"static routers=192.168.1.1",
+	"static domain_name_servers=192.168.1.1 8.8.8.8",
+	"",
+	"interface wlan0",
+	"nohook wpa_supplicant",
+}
+
+var keyfile = []string{
+	"[connection]",
+	"id=Wired connection 1",
+	"uuid=3b5e1f2e-6f2b-4d52-9d1c-6a4f0f9e1c2a",
+	"type=ethernet",
+	"interface-name=eth0",
+	"",
+	"[ethernet]",
+	"",
+	"[ipv4]",
+	"address1=192.168.1.10/24,192.168.1.1",
+	"dns=1.1.1.1;",
+	"method=manual",
+	"",
+	"[ipv6]",
+	"addr-gen-mode=default",
+	"method=auto",
+	"",
+	"[proxy]",
+}
+
+func TestDhcpcdSettings(t *testing.T) {
+	assert.Equal(t, netconf.Settings{
+		IsStatic: true,
+		IPv4:     []string{"192.168.1.10/24"},
+		Router:   "192.168.1.1",
+		IPv6:     []string{"fd51:42f8:caae:d92e::ff/64"},
+		DNS:      []string{"192.168.1.1", "8.8.8.8"},
+	}, netconf.DhcpcdSettings(dhcpcd, "eth0"))
+
+	assert.Equal(t, netconf.Settings{IPv4: []string{}, IPv6: []string{}, DNS: []string{}}, netconf.DhcpcdSettings(dhcpcd, "wlan0"))
+	assert.Equal(t, netconf.Settings{IPv4: []string{}, IPv6: []string{}, DNS: []string{}}, netconf.DhcpcdSettings(dhcpcd, "usb0"))
+}
+
+func TestSetDhcpcd(t *testing.T) {
+	cases := []struct {
+		name        string
+		lines       []string
+		iface       string
+		settings    netconf.Settings
+		wantedLines []string
+	}{
+		{
+			name:  "success: replace the static settings",
+			iface: "eth0",
+			settings: netconf.Settings{
+				IsStatic: true,
+				IPv4:     []string{"10.0.0.2/8"},
+				Router:   "10.0.0.1",
+			},
+			wantedLines: []string{
+				"# A sample configuration for dhcpcd.",
+				"hostname",
+				"",
+				"interface eth0",
+				"static ip_address=10.0.0.2/8",
+				"static routers=10.0.0.1",
+				"",
+				"interface wlan0",
+				"nohook wpa_supplicant",
+			},
+		},
+		{
+			name:  "success: remove the block of an interface back to dhcp",
+			iface: "eth0",
+			wantedLines: []string{
+				"# A sample configuration for dhcpcd.",
+				"hostname",
+				"",
+				"interface wlan0",
+				"nohook wpa_supplicant",
+			},
+		},
+		{
+			name:     "success: keep the other options of a block",
+			iface:    "wlan0",
+			settings: netconf.Settings{DNS: []string{"1.1.1.1"}},
+			wantedLines: []string{
+				"# A sample configuration for dhcpcd.",
+				"hostname",
+				"",
+				"interface eth0",
+				"static ip_address=192.168.1.10/24",
+				"static ip6_address=fd51:42f8:caae:d92e::ff/64",
+				"static routers=192.168.1.1",
+				"static domain_name_servers=192.168.1.1 8.8.8.8",
+				"",
+				"interface wlan0",
+				"static domain_name_servers=1.1.1.1",
+				"nohook wpa_supplicant",
+			},
+		},
+		{
+			name:  "success: add the block of an interface",
+			iface: "usb0",
+			settings: netconf.Settings{
+				IsStatic: true,
+				IPv4:     []string{"10.55.0.1/24"},
+			},
+			wantedLines: append(append([]string{}, dhcpcd...), "", "interface usb0", "static ip_address=10.55.0.1/24"),
+		},
+		{
+			name:        "success: nothing to remove",
+			iface:       "usb0",
+			wantedLines: dhcpcd,
+		},
+		{
+			name:  "success: keep the blocks following a growing block",
+			lines: []string{"interface eth0", "", "interface wlan0", "nohook wpa_supplicant"},
+			iface: "eth0",
+			settings: netconf.Settings{
+				IsStatic: true,
+				IPv4:     []string{"10.0.0.2/8"},
+				Router:   "10.0.0.1",
+			},
+			wantedLines: []string{
+				"interface eth0",
+				"static ip_address=10.0.0.2/8",
+				"static routers=10.0.0.1",
+				"",
+				"interface wlan0",
+				"nohook wpa_supplicant",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			lines := dhcpcd
+			if tc.lines != nil {
+				lines = tc.lines
+			}
+			assert.Equal(t, tc.wantedLines, netconf.SetDhcpcd(lines, tc.iface, tc.settings))
+		})
+	}
+}
+
+func TestSetDhcpcdOption(t *testing.T) {
+	cases := []struct {
+		name        string
+		iface       string
+		isSet       bool
+		wantedLines []string
+	}{
+		{
+			name:  "success: add to a block",
+			iface: "eth0",
+			isSet: true,
+			wantedLines: []string{
+				"# A sample configuration for dhcpcd.",
+				"hostname",
+				"",
+				"interface eth0",
+				"nohook wpa_supplicant",
+				"static ip_address=192.168.1.10/24",
+				"static ip6_address=fd51:42f8:caae:d92e::ff/64",
+				"static routers=192.168.1.1",
+				"static domain_name_servers=192.168.1.1 8.8.8.8",
+				"",
+				"interface wlan0",
+				"nohook wpa_supplicant",
+			},
+		},
+		{
+			name:  "success: remove the last option of a block",
+			iface: "wlan0",
+			wantedLines: []string{
+				"# A sample configuration for dhcpcd.",
+				"hostname",
+				"",
+				"interface eth0",
+				"static ip_address=192.168.1.10/24",
+				"static ip6_address=fd51:42f8:caae:d92e::ff/64",
+				"static routers=192.168.1.1",
+				"static domain_name_servers=192.168.1.1 8.8.8.8",
+			},
+		},
+		{
+			name:        "success: add a block",
+			iface:       "wlan1",
+			isSet:       true,
+			wantedLines: append(append([]string{}, dhcpcd...), "", "interface wlan1", "nohook wpa_supplicant"),
+		},
+		{
+			name:        "success: nothing to remove",
+			iface:       "wlan1",
+			wantedLines: dhcpcd,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantedLines, netconf.SetDhcpcdOption(dhcpcd, tc.iface, "nohook wpa_supplicant", tc.isSet))
+		})
+	}
+}
+
+func TestParseKeyfile(t *testing.T) {
+	assert.Equal(t, netconf.Keyfile{
+		ID:        "Wired connection 1",
+		UUID:      "3b5e1f2e-6f2b-4d52-9d1c-6a4f0f9e1c2a",
+		Type:      "ethernet",
+		Interface: "eth0",
+		Settings: netconf.Settings{
+			IsStatic: true,
+			IPv4:     []string{"192.168.1.10/24"},
+			Router:   "192.168.1.1",
+			IPv6:     []string{},
+			DNS:      []string{"1.1.1.1"},
+		},
+	}, netconf.ParseKeyfile(keyfile))
+}
+
+func TestSetKeyfile(t *testing.T) {
+	cases := []struct {
+		name        string
+		lines       []string
+		settings    netconf.Settings
+		wantedLines []string
+	}{
+		{
+			name:  "success: static ipv4 and ipv6",
+			lines: keyfile,
+			settings: netconf.Settings{
+				IsStatic: true,
+				IPv4:     []string{"10.0.0.2/8", "10.0.0.3/8"},
+				Router:   "10.0.0.1",
+				IPv6:     []string{"fd00::2/64"},
+				Router6:  "fd00::1",
+				DNS:      []string{"10.0.0.1", "fd00::1"},
+			},
+			wantedLines: []string{
+				"[connection]",
+				"id=Wired connection 1",
+				"uuid=3b5e1f2e-6f2b-4d52-9d1c-6a4f0f9e1c2a",
+				"type=ethernet",
+				"interface-name=eth0",
+				"",
+				"[ethernet]",
+				"",
+				"[ipv4]",
+				"method=manual",
+				"address1=10.0.0.2/8",
+				"address2=10.0.0.3/8",
+				"gateway=10.0.0.1",
+				"dns=10.0.0.1;",
+				"ignore-auto-dns=true",
+				"",
+				"[ipv6]",
+				"method=manual",
+				"address1=fd00::2/64",
+				"gateway=fd00::1",
+				"dns=fd00::1;",
+				"ignore-auto-dns=true",
+				"addr-gen-mode=default",
+				"",
+				"[proxy]",
+			},
+		},
+		{
+			name:  "success: back to dhcp",
+			lines: keyfile,
+			wantedLines: []string{
+				"[connection]",
+				"id=Wired connection 1",
+				"uuid=3b5e1f2e-6f2b-4d52-9d1c-6a4f0f9e1c2a",
+				"type=ethernet",
+				"interface-name=eth0",
+				"",
+				"[ethernet]",
+				"",
+				"[ipv4]",
+				"method=auto",
+				"",
+				"[ipv6]",
+				"method=auto",
+				"addr-gen-mode=default",
+				"",
+				"[proxy]",
+			},
+		},
+		{
+			name:     "success: keep a shared connection and add the missing section",
+			lines:    []string{"[connection]", "id=hotspot", "", "[ipv4]", "method=shared"},
+			settings: netconf.Settings{DNS: []string{"fd00::1"}},
+			wantedLines: []string{
+				"[connection]",
+				"id=hotspot",
+				"",
+				"[ipv4]",
+				"method=shared",
+				"",
+				"[ipv6]",
+				"method=auto",
+				"dns=fd00::1;",
+				"ignore-auto-dns=true",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantedLines, netconf.SetKeyfile(tc.lines, tc.settings))
+		})
+	}
+}
+
+func TestNewKeyfile(t *testing.T) {
+	k := netconf.ParseKeyfile(netconf.NewKeyfile("raspibuddy-eth0", "3b5e1f2e-6f2b-4d52-9d1c-6a4f0f9e1c2a", netconf.Ethernet, "eth0"))
+
+	assert.Equal(t, "raspibuddy-eth0", k.ID)
+	assert.Equal(t, "3b5e1f2e-6f2b-4d52-9d1c-6a4f0f9e1c2a", k.UUID)
+	assert.Equal(t, netconf.Ethernet, k.Type)
+	assert.Equal(t, "eth0", k.Interface)
+	assert.False(t, k.Settings.IsStatic)
+}
@@ -0,0 +1,169 @@
+// Package sshconf parses the public keys of the authorized_keys files and edits the sshd config files.
+// The lines which are not keys or options being edited (comments, other options) are left untouched.
+package sshconf
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"regexp"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+var (
+	// Types lists the accepted key types, dsa keys being rejected by OpenSSH
+	Types = []string{
+		ssh.KeyAlgoED25519,
+		ssh.KeyAlgoSKED25519,
+		ssh.KeyAlgoECDSA256,
+		ssh.KeyAlgoECDSA384,
+		ssh.KeyAlgoECDSA521,
+		ssh.KeyAlgoSKECDSA256,
+		ssh.KeyAlgoRSA,
+	}
+
+	optionRegex = regexp.MustCompile(`^\s*([A-Za-z0-9]+)(\s+|\s*=\s*)(.*?)\s*$`)
+)
+
+// Key represents a public key.
+// ID is the index of the key among the keys of a file, the other lines not counting.
+type Key struct {
+	ID          int
+	Type        string
+	Bits        int
+	Fingerprint string
+	Comment     string
+}
+
+// ParseKey returns the public key of an authorized_keys line (ex: ssh-ed25519 AAAA... pi@raspberrypi),
+// its fingerprint being the SHA256 one printed by 'ssh-keygen -l'
+func ParseKey(line string) (Key, error) {
+	pub, comment, _, _, err := ssh.ParseAuthorizedKey([]byte(line))
+	if err != nil {
+		return Key{}, err
+	}
+
+	result := Key{
+		Type:        pub.Type(),
+		Bits:        256,
+		Fingerprint: ssh.FingerprintSHA256(pub),
+		Comment:     comment,
+	}
+	if c, ok := pub.(ssh.CryptoPublicKey); ok {
+		switch k := c.CryptoPublicKey().(type) {
+		case *rsa.PublicKey:
+			result.Bits = k.N.BitLen()
+		case *ecdsa.PublicKey:
+			result.Bits = k.Curve.Params().BitSize
+		}
+	}
+
+	return result, nil
+}
+
+// Keys returns the public keys of the lines of an authorized_keys file, skipping the comments and the invalid lines
+func Keys(lines []string) []Key {
+	result := []Key{}
+	for _, line := range lines {
+		if isComment(line) {
+			continue
+		}
+		if k, err := ParseKey(line); err == nil {
+			k.ID = len(result)
+			result = append(result, k)
+		}
+	}
+	return result
+}
+
+// RemoveKey returns the lines of an authorized_keys file without a key, false when it is not found
+func RemoveKey(lines []string, id int) ([]string, bool) {
+	result := []string{}
+	isFound := false
+
+	i := 0
+	for _, line := range lines {
+		if !isComment(line) {
+			if _, err := ParseKey(line); err == nil {
+				i++
+				if i-1 == id {
+					isFound = true
+					continue
+				}
+			}
+		}
+		result = append(result, line)
+	}
+
+	return result, isFound
+}
+
+// SetOption returns the lines of a sshd config file with an option set, sshd keeping the first value it reads.
+// Its first occurrence before any Match block is replaced, else it is added before the first Match block.
+// sshd accumulating the values of Port and ListenAddress, their other occurrences before any Match block are commented.
+func SetOption(lines []string, option string, value string) []string {
+	result := []string{}
+	isSet := false
+	isMatch := false
+
+	for _, line := range lines {
+		m := optionRegex.FindStringSubmatch(line)
+		switch {
+		case m == nil || isMatch:
+		case strings.EqualFold(m[1], "Match"):
+			isMatch = true
+			if !isSet {
+				result = append(result, option+" "+value, "")
+				isSet = true
+			}
+		case strings.EqualFold(m[1], option) && !isSet:
+			result = append(result, option+" "+value)
+			isSet = true
+			continue
+		case strings.EqualFold(m[1], option) && isCumulative(option):
+			line = "#" + line
+		}
+		result = append(result, line)
+	}
+
+	if !isSet {
+		result = append(result, option+" "+value)
+	}
+
+	return result
+}
+
+// CommentOption returns the lines of a sshd config file with the occurrences of an option before any Match block commented,
+// false when there is none
+func CommentOption(lines []string, option string) ([]string, bool) {
+	result := []string{}
+	isCommented := false
+	isMatch := false
+
+	for _, line := range lines {
+		if m := optionRegex.FindStringSubmatch(line); m != nil && !isMatch {
+			switch {
+			case strings.EqualFold(m[1], "Match"):
+				isMatch = true
+			case strings.EqualFold(m[1], option):
+				line = "#" + line
+				isCommented = true
+			}
+		}
+		result = append(result, line)
+	}
+
+	return result, isCommented
+}
+
+// isCumulative returns true when sshd uses every value of an option instead of the first one
+func isCumulative(option string) bool {
+	return strings.EqualFold(option, "Port") || strings.EqualFold(option, "ListenAddress")
+}
+
+// isComment returns true when a line is blank or a comment
+func isComment(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed == "" || strings.HasPrefix(trimmed, "#")
+}
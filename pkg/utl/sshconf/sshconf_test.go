@@ -0,0 +1,147 @@
+package sshconf_test
+
+import (
+	"testing"
+
+	"github.com/raspibuddy/rpi/pkg/utl/sshconf"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	ed25519 = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIHk5jHIU3SV09U8gHZ6MtUb1NLPfJ1m4aE5l7IwfEyDd pi@raspberrypi"
+	ecdsa   = "ecdsa-sha2-nistp384 AAAAE2VjZHNhLXNoYTItbmlzdHAzODQAAAAIbmlzdHAzODQAAABhBCwNMK/sj3KIjPDq1801Np3PaJoPRDURrdgdQTgBd68xO/AzLezK1tINllE4vySvF89YxzeIqbePRaLlFQ70zKr3a3b/g2JIZ4JKnyuO7MNgCk6/Lm8gs9zfqbUHY641DQ== laptop"
+	rsa     = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAAAgQCo4dGs0c6edG0hcoIt4fd7Nszp+9X8H6SfSmqzO5TaSomqA1IKBHarL1jXr3zdrwJvMbBPCauQIY8AtPxCPiJ6ZPMt8nuThfFl+P7e7cpEZik4OKRhc/ZlJlfz7I2LpN7rHKJnFePCCBNoY1bR/sug8ysg+IaPl6o/IZUIeEA6ew== old"
+)
+
+var keys = []string{
+	"# laptop",
+	`from="192.168.1.0/24" ` + ecdsa,
+	"",
+	ed25519,
+	"ssh-ed25519 AAAAtruncated",
+	rsa,
+}
+
+func TestParseKey(t *testing.T) {
+	cases := []struct {
+		name      string
+		line      string
+		wantedKey sshconf.Key
+		wantedErr bool
+	}{
+		{
+			name:      "ed25519",
+			line:      ed25519,
+			wantedKey: sshconf.Key{Type: "ssh-ed25519", Bits: 256, Fingerprint: "SHA256:M5WiaiGAUUDnyQ11r6OesYH3EZ0nXJ7/oRjX4pp8zhA", Comment: "pi@raspberrypi"},
+		},
+		{
+			name:      "ecdsa with options",
+			line:      `from="192.168.1.0/24",no-agent-forwarding ` + ecdsa,
+			wantedKey: sshconf.Key{Type: "ecdsa-sha2-nistp384", Bits: 384, Fingerprint: "SHA256:7ylZMr0Kw/MdGxwqHOkyR9Soe9/H2iBVhTVJA9vfY4A", Comment: "laptop"},
+		},
+		{
+			name:      "rsa",
+			line:      rsa,
+			wantedKey: sshconf.Key{Type: "ssh-rsa", Bits: 1024, Fingerprint: "SHA256:Qmno9yVbcBptLUz/ubZGB063X0ahVatL+5YFvSXEl6s", Comment: "old"},
+		},
+		{
+			name:      "invalid key",
+			line:      "ssh-ed25519 AAAAtruncated",
+			wantedErr: true,
+		},
+		{
+			name:      "not a key",
+			line:      "hello",
+			wantedErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := sshconf.ParseKey(tc.line)
+			assert.Equal(t, tc.wantedKey, key)
+			assert.Equal(t, tc.wantedErr, err != nil)
+		})
+	}
+}
+
+func TestKeys(t *testing.T) {
+	result := sshconf.Keys(keys)
+	assert.Equal(t, 3, len(result))
+	assert.Equal(t, []int{0, 1, 2}, []int{result[0].ID, result[1].ID, result[2].ID})
+	assert.Equal(t, []string{"laptop", "pi@raspberrypi", "old"}, []string{result[0].Comment, result[1].Comment, result[2].Comment})
+
+	assert.Equal(t, []sshconf.Key{}, sshconf.Keys([]string{"# none"}))
+}
+
+func TestRemoveKey(t *testing.T) {
+	result, isFound := sshconf.RemoveKey(keys, 1)
+	assert.True(t, isFound)
+	assert.Equal(t, []string{"# laptop", `from="192.168.1.0/24" ` + ecdsa, "", "ssh-ed25519 AAAAtruncated", rsa}, result)
+
+	result, isFound = sshconf.RemoveKey(keys, 3)
+	assert.False(t, isFound)
+	assert.Equal(t, keys, result)
+}
+
+func TestSetOption(t *testing.T) {
+	cases := []struct {
+		name        string
+		lines       []string
+		option      string
+		value       string
+		wantedLines []string
+	}{
+		{
+			name:        "empty file",
+			lines:       []string{},
+			option:      "Port",
+			value:       "2222",
+			wantedLines: []string{"Port 2222"},
+		},
+		{
+			name:        "commented option",
+			lines:       []string{"Include /etc/ssh/sshd_config.d/*.conf", "#Port 22", "UsePAM yes"},
+			option:      "Port",
+			value:       "2222",
+			wantedLines: []string{"Include /etc/ssh/sshd_config.d/*.conf", "#Port 22", "UsePAM yes", "Port 2222"},
+		},
+		{
+			name:        "first occurrence",
+			lines:       []string{"passwordauthentication yes", "PasswordAuthentication=yes"},
+			option:      "PasswordAuthentication",
+			value:       "no",
+			wantedLines: []string{"PasswordAuthentication no", "PasswordAuthentication=yes"},
+		},
+		{
+			name:        "before match block",
+			lines:       []string{"UsePAM yes", "Match User backup", "\tPermitRootLogin yes"},
+			option:      "PermitRootLogin",
+			value:       "no",
+			wantedLines: []string{"UsePAM yes", "PermitRootLogin no", "", "Match User backup", "\tPermitRootLogin yes"},
+		},
+		{
+			name:        "every port",
+			lines:       []string{"Port 22", "port=2200", "#Port 2300", "Match LocalPort 2200", "\tPermitRootLogin yes", "Port 2400"},
+			option:      "Port",
+			value:       "2222",
+			wantedLines: []string{"Port 2222", "#port=2200", "#Port 2300", "Match LocalPort 2200", "\tPermitRootLogin yes", "Port 2400"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantedLines, sshconf.SetOption(tc.lines, tc.option, tc.value))
+		})
+	}
+}
+
+func TestCommentOption(t *testing.T) {
+	result, isCommented := sshconf.CommentOption([]string{"Include /etc/ssh/sshd_config.d/*.conf", "Port 22", "#Port 2200", "port 2300", "Match User backup", "\tPort 2400"}, "Port")
+	assert.True(t, isCommented)
+	assert.Equal(t, []string{"Include /etc/ssh/sshd_config.d/*.conf", "#Port 22", "#Port 2200", "#port 2300", "Match User backup", "\tPort 2400"}, result)
+
+	result, isCommented = sshconf.CommentOption([]string{"#Port 22", "UsePAM yes"}, "Port")
+	assert.False(t, isCommented)
+	assert.Equal(t, []string{"#Port 22", "UsePAM yes"}, result)
+}
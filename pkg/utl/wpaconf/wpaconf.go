@@ -0,0 +1,270 @@
+// Package wpaconf parses and writes the wpa_supplicant.conf file.
+// Only the network blocks being edited are rewritten, every other line
+// (comments, global settings, other networks) is left untouched.
+package wpaconf
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	// Add adds a network, replacing the network having the same ssid
+	Add = "add"
+
+	// Remove removes a network
+	Remove = "remove"
+
+	// Priority sets the priority of a network
+	Priority = "priority"
+
+	// Open is the key management of a network without passphrase
+	Open = "NONE"
+
+	// DefaultKeyMgmt is the key management of a network not setting key_mgmt
+	DefaultKeyMgmt = "WPA-PSK WPA-EAP"
+)
+
+var (
+	// Operations lists the operations that can be applied to a network
+	Operations = []string{Add, Remove, Priority}
+
+	settingRegex = regexp.MustCompile(`^\s*([A-Za-z0-9_]+)\s*=\s*(.*?)\s*$`)
+	pskRegex     = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+)
+
+// Network represents a network block of wpa_supplicant.conf.
+// ID is the index of the block, which is the id given by wpa_supplicant when it loads the file.
+type Network struct {
+	ID         int
+	SSID       string
+	PSK        string
+	KeyMgmt    string
+	IsHidden   bool
+	Priority   int
+	IsDisabled bool
+}
+
+// Config represents a parsed wpa_supplicant.conf
+type Config struct {
+	Lines []string
+}
+
+// block is the first and last line of a network block
+type block struct {
+	start int
+	end   int
+}
+
+// Parse parses the lines of a wpa_supplicant.conf file
+func Parse(lines []string) Config {
+	return Config{Lines: append([]string{}, lines...)}
+}
+
+// Raw returns the lines of the config file, as they should be written
+func (c Config) Raw() []string {
+	return c.Lines
+}
+
+// Get returns the value of a global setting (ex: country)
+func (c Config) Get(key string) (string, bool) {
+	blocks := c.blocks()
+	for i, line := range c.Lines {
+		if inBlock(blocks, i) {
+			continue
+		}
+		if m := settingRegex.FindStringSubmatch(line); m != nil && m[1] == key {
+			return m[2], true
+		}
+	}
+	return "", false
+}
+
+// Networks returns the networks of the config file, in order
+func (c Config) Networks() []Network {
+	result := []Network{}
+
+	for id, b := range c.blocks() {
+		n := Network{ID: id, KeyMgmt: DefaultKeyMgmt}
+		for _, line := range c.Lines[b.start+1 : b.end] {
+			m := settingRegex.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			switch m[1] {
+			case "ssid":
+				n.SSID = ParseSSID(m[2])
+			case "psk":
+				n.PSK = m[2]
+			case "key_mgmt":
+				n.KeyMgmt = m[2]
+			case "scan_ssid":
+				n.IsHidden = m[2] == "1"
+			case "priority":
+				n.Priority, _ = strconv.Atoi(m[2])
+			case "disabled":
+				n.IsDisabled = m[2] == "1"
+			}
+		}
+		result = append(result, n)
+	}
+
+	return result
+}
+
+// Apply applies an operation to a network. Add ignores the id of the network.
+func (c *Config) Apply(operation string, n Network) error {
+	if n.Priority < 0 {
+		return fmt.Errorf("bad priority")
+	}
+
+	switch operation {
+	case Add:
+		if len(n.SSID) == 0 || len(n.SSID) > 32 {
+			return fmt.Errorf("bad ssid")
+		}
+		if n.PSK != "" && !pskRegex.MatchString(n.PSK) {
+			return fmt.Errorf("bad psk")
+		}
+		c.add(n)
+	case Remove:
+		b, err := c.block(n.ID)
+		if err != nil {
+			return err
+		}
+		start := b.start
+		if start > 0 && strings.TrimSpace(c.Lines[start-1]) == "" {
+			start--
+		}
+		c.Lines = append(c.Lines[:start], c.Lines[b.end+1:]...)
+	case Priority:
+		b, err := c.block(n.ID)
+		if err != nil {
+			return err
+		}
+		c.setPriority(b, n.Priority)
+	default:
+		return fmt.Errorf("bad operation")
+	}
+
+	return nil
+}
+
+// PSK returns the 256-bit key derived from a WPA passphrase and the ssid (PBKDF2-SHA1, 4096 iterations),
+// as wpa_passphrase does, so that the passphrase is never written down.
+func PSK(ssid string, passphrase string) (string, error) {
+	if len(passphrase) < 8 || len(passphrase) > 63 {
+		return "", fmt.Errorf("bad passphrase length")
+	}
+	for _, r := range passphrase {
+		if r < 0x20 || r > 0x7e {
+			return "", fmt.Errorf("bad passphrase character")
+		}
+	}
+
+	return hex.EncodeToString(pbkdf2.Key([]byte(passphrase), []byte(ssid), 4096, 32, sha1.New)), nil
+}
+
+// FormatSSID returns the value of the ssid setting, quoted or hex encoded when not printable
+func FormatSSID(ssid string) string {
+	for i := 0; i < len(ssid); i++ {
+		if ssid[i] < 0x20 || ssid[i] > 0x7e {
+			return hex.EncodeToString([]byte(ssid))
+		}
+	}
+	return `"` + ssid + `"`
+}
+
+// ParseSSID returns the ssid of a ssid setting value, quoted or hex encoded
+func ParseSSID(value string) string {
+	value = strings.TrimPrefix(value, "P")
+	if strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) && len(value) >= 2 {
+		return value[1 : len(value)-1]
+	}
+	if b, err := hex.DecodeString(value); err == nil {
+		return string(b)
+	}
+	return value
+}
+
+// blocks returns the network blocks of the config file
+func (c Config) blocks() []block {
+	result := []block{}
+	start := -1
+	for i, line := range c.Lines {
+		t := strings.ReplaceAll(strings.TrimSpace(line), " ", "")
+		if start < 0 && strings.HasPrefix(t, "network={") {
+			start = i
+		} else if start >= 0 && t == "}" {
+			result = append(result, block{start: start, end: i})
+			start = -1
+		}
+	}
+	return result
+}
+
+// block returns the network block having an id
+func (c Config) block(id int) (block, error) {
+	blocks := c.blocks()
+	if id < 0 || id >= len(blocks) {
+		return block{}, fmt.Errorf("bad id")
+	}
+	return blocks[id], nil
+}
+
+func inBlock(blocks []block, i int) bool {
+	for _, b := range blocks {
+		if i >= b.start && i <= b.end {
+			return true
+		}
+	}
+	return false
+}
+
+// add appends a network block, or replaces the block of the network having the same ssid
+func (c *Config) add(n Network) {
+	lines := []string{"network={", "\tssid=" + FormatSSID(n.SSID)}
+	if n.PSK != "" {
+		lines = append(lines, "\tpsk="+strings.ToLower(n.PSK))
+	} else {
+		lines = append(lines, "\tkey_mgmt="+Open)
+	}
+	if n.IsHidden {
+		lines = append(lines, "\tscan_ssid=1")
+	}
+	if n.Priority > 0 {
+		lines = append(lines, fmt.Sprintf("\tpriority=%v", n.Priority))
+	}
+	lines = append(lines, "}")
+
+	for _, existing := range c.Networks() {
+		if existing.SSID == n.SSID {
+			b, _ := c.block(existing.ID)
+			c.Lines = append(c.Lines[:b.start], append(lines, c.Lines[b.end+1:]...)...)
+			return
+		}
+	}
+
+	if len(c.Lines) > 0 && strings.TrimSpace(c.Lines[len(c.Lines)-1]) != "" {
+		c.Lines = append(c.Lines, "")
+	}
+	c.Lines = append(c.Lines, lines...)
+}
+
+// setPriority replaces the priority of a network block, or inserts it before the end of the block
+func (c *Config) setPriority(b block, priority int) {
+	line := fmt.Sprintf("\tpriority=%v", priority)
+	for i := b.start + 1; i < b.end; i++ {
+		if m := settingRegex.FindStringSubmatch(c.Lines[i]); m != nil && m[1] == "priority" {
+			c.Lines[i] = line
+			return
+		}
+	}
+	c.Lines = append(c.Lines[:b.end], append([]string{line}, c.Lines[b.end:]...)...)
+}
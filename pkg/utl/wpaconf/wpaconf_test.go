@@ -0,0 +1,165 @@
+package wpaconf_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/raspibuddy/rpi/pkg/utl/wpaconf"
+	"github.com/stretchr/testify/assert"
+)
+
+var lines = []string{
+	"ctrl_interface=DIR=/var/run/wpa_supplicant GROUP=netdev",
+	"update_config=1",
+	"country=GB",
+	"",
+	"network={",
+	`	ssid="Home"`,
+	`	psk="plain passphrase"`,
+	"}",
+	"",
+	"network={",
+	"	ssid=436166c3a9",
+	"	key_mgmt=NONE",
+	"	scan_ssid=1",
+	"	priority=3",
+	"	disabled=1",
+	"}",
+}
+
+func TestParse(t *testing.T) {
+	c := wpaconf.Parse(lines)
+
+	assert.Equal(t, lines, c.Raw())
+
+	country, isFound := c.Get("country")
+	assert.True(t, isFound)
+	assert.Equal(t, "GB", country)
+
+	_, isFound = c.Get("ssid")
+	assert.False(t, isFound)
+
+	assert.Equal(t, []wpaconf.Network{
+		{ID: 0, SSID: "Home", PSK: `"plain passphrase"`, KeyMgmt: wpaconf.DefaultKeyMgmt},
+		{ID: 1, SSID: "Café", KeyMgmt: wpaconf.Open, IsHidden: true, Priority: 3, IsDisabled: true},
+	}, c.Networks())
+}
+
+func TestApply(t *testing.T) {
+	psk := "f42c6fc52df0ebef9ebb4b90b38a5f902e83fe1b135a70e23aed762e9710a12e"
+
+	cases := []struct {
+		name        string
+		operation   string
+		network     wpaconf.Network
+		wantedLines []string
+		wantedErr   error
+	}{
+		{
+			name:      "error: bad operation",
+			operation: "dummy",
+			wantedErr: fmt.Errorf("bad operation"),
+		},
+		{
+			name:      "error: bad ssid",
+			operation: wpaconf.Add,
+			network:   wpaconf.Network{SSID: "a very long ssid exceeding 32 bytes"},
+			wantedErr: fmt.Errorf("bad ssid"),
+		},
+		{
+			name:      "error: bad psk",
+			operation: wpaconf.Add,
+			network:   wpaconf.Network{SSID: "Office", PSK: "plain passphrase"},
+			wantedErr: fmt.Errorf("bad psk"),
+		},
+		{
+			name:      "error: bad id",
+			operation: wpaconf.Remove,
+			network:   wpaconf.Network{ID: 2},
+			wantedErr: fmt.Errorf("bad id"),
+		},
+		{
+			name:      "error: bad priority",
+			operation: wpaconf.Priority,
+			network:   wpaconf.Network{ID: 0, Priority: -1},
+			wantedErr: fmt.Errorf("bad priority"),
+		},
+		{
+			name:      "success: add",
+			operation: wpaconf.Add,
+			network:   wpaconf.Network{SSID: "Office", PSK: psk, IsHidden: true, Priority: 5},
+			wantedLines: append(append([]string{}, lines...),
+				"",
+				"network={",
+				`	ssid="Office"`,
+				"	psk="+psk,
+				"	scan_ssid=1",
+				"	priority=5",
+				"}",
+			),
+		},
+		{
+			name:      "success: add replaces the same ssid",
+			operation: wpaconf.Add,
+			network:   wpaconf.Network{SSID: "Home", PSK: psk},
+			wantedLines: append(append(append([]string{}, lines[:5]...),
+				`	ssid="Home"`,
+				"	psk="+psk,
+			), lines[7:]...),
+		},
+		{
+			name:        "success: remove",
+			operation:   wpaconf.Remove,
+			network:     wpaconf.Network{ID: 0},
+			wantedLines: append(append([]string{}, lines[:3]...), lines[8:]...),
+		},
+		{
+			name:      "success: set priority",
+			operation: wpaconf.Priority,
+			network:   wpaconf.Network{ID: 1, Priority: 10},
+			wantedLines: append(append(append([]string{}, lines[:13]...),
+				"	priority=10",
+			), lines[14:]...),
+		},
+		{
+			name:      "success: insert priority",
+			operation: wpaconf.Priority,
+			network:   wpaconf.Network{ID: 0, Priority: 1},
+			wantedLines: append(append(append([]string{}, lines[:7]...),
+				"	priority=1",
+			), lines[7:]...),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := wpaconf.Parse(lines)
+			err := c.Apply(tc.operation, tc.network)
+			assert.Equal(t, tc.wantedErr, err)
+			if tc.wantedErr == nil {
+				assert.Equal(t, tc.wantedLines, c.Raw())
+			}
+		})
+	}
+}
+
+func TestPSK(t *testing.T) {
+	// IEEE 802.11i test vector
+	psk, err := wpaconf.PSK("IEEE", "password")
+	assert.Nil(t, err)
+	assert.Equal(t, "f42c6fc52df0ebef9ebb4b90b38a5f902e83fe1b135a70e23aed762e9710a12e", psk)
+
+	_, err = wpaconf.PSK("IEEE", "short")
+	assert.Equal(t, fmt.Errorf("bad passphrase length"), err)
+
+	_, err = wpaconf.PSK("IEEE", "pass\nword")
+	assert.Equal(t, fmt.Errorf("bad passphrase character"), err)
+}
+
+func TestSSID(t *testing.T) {
+	assert.Equal(t, `"Home"`, wpaconf.FormatSSID("Home"))
+	assert.Equal(t, "436166c3a9", wpaconf.FormatSSID("Café"))
+	assert.Equal(t, "Home", wpaconf.ParseSSID(`"Home"`))
+	assert.Equal(t, "Café", wpaconf.ParseSSID("436166c3a9"))
+	assert.Equal(t, "Home", wpaconf.ParseSSID(`P"Home"`))
+}
@@ -0,0 +1,17 @@
+package rpi
+
+// Pressure represents the pressure stall information of a resource (cpu, memory or io).
+type Pressure struct {
+	Resource string        `json:"resource"`
+	Some     PressureStall `json:"some"`
+	Full     PressureStall `json:"full"`
+}
+
+// PressureStall represents the share of time some or all tasks were stalled on a resource.
+type PressureStall struct {
+	Avg10  float64 `json:"avg10"`
+	Avg60  float64 `json:"avg60"`
+	Avg300 float64 `json:"avg300"`
+	// Total stall time in microseconds
+	Total uint64 `json:"total"`
+}
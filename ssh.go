@@ -0,0 +1,19 @@
+package rpi
+
+// SSH represents the options used by sshd and the host keys of the system
+type SSH struct {
+	PasswordAuthentication bool     `json:"passwordAuthentication"`
+	PermitRootLogin        string   `json:"permitRootLogin"`
+	Port                   int      `json:"port"`
+	HostKeys               []SSHKey `json:"hostKeys"`
+}
+
+// SSHKey represents a public key, of the host or authorized to log in as a user.
+// ID is the index of the key among the keys of its file.
+type SSHKey struct {
+	ID          int    `json:"id"`
+	Type        string `json:"type"`
+	Bits        int    `json:"bits"`
+	Fingerprint string `json:"fingerprint"`
+	Comment     string `json:"comment"`
+}
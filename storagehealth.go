@@ -0,0 +1,41 @@
+package rpi
+
+// StorageHealth represents the health report of a current host block device.
+type StorageHealth struct {
+	ID               string       `json:"id"`
+	Type             string       `json:"type"`
+	Model            string       `json:"model"`
+	Serial           string       `json:"serial"`
+	MMC              *MMCHealth   `json:"mmc,omitempty"`
+	Smart            *SmartHealth `json:"smart,omitempty"`
+	ReadOnlyRemounts []string     `json:"readOnlyRemounts"`
+	Score            int          `json:"score"`
+	Status           string       `json:"status"`
+}
+
+// MMCHealth represents the attributes exposed by an SD/MMC card.
+type MMCHealth struct {
+	CID                 string `json:"cid"`
+	ManufacturerID      string `json:"manufacturerId"`
+	OEMID               string `json:"oemId"`
+	Name                string `json:"name"`
+	Serial              string `json:"serial"`
+	Date                string `json:"date"`
+	Type                string `json:"type"`
+	LifeTimeEstimateA   string `json:"lifeTimeEstimateA,omitempty"`
+	LifeTimeEstimateB   string `json:"lifeTimeEstimateB,omitempty"`
+	PreEOLInfo          string `json:"preEolInfo,omitempty"`
+	LifeTimeUsedPercent int    `json:"lifeTimeUsedPercent"`
+}
+
+// SmartHealth represents the SMART attributes of a USB, SATA or NVMe drive.
+type SmartHealth struct {
+	Passed             bool  `json:"passed"`
+	Temperature        int   `json:"temperature"`
+	PowerOnHours       int64 `json:"powerOnHours"`
+	PowerCycleCount    int64 `json:"powerCycleCount"`
+	ReallocatedSectors int64 `json:"reallocatedSectors"`
+	PendingSectors     int64 `json:"pendingSectors"`
+	PercentageUsed     int64 `json:"percentageUsed"`
+	MediaErrors        int64 `json:"mediaErrors"`
+}
@@ -0,0 +1,16 @@
+package rpi
+
+// SystemdUnit represents a systemd service unit.
+type SystemdUnit struct {
+	ID            string `json:"id"`
+	Description   string `json:"description"`
+	LoadState     string `json:"loadState"`
+	ActiveState   string `json:"activeState"`
+	SubState      string `json:"subState"`
+	UnitFileState string `json:"unitFileState"`
+	MainPID       int    `json:"mainPid"`
+	// MemoryCurrent is expressed in bytes
+	MemoryCurrent uint64 `json:"memoryCurrent"`
+	Since         string `json:"since"`
+	FragmentPath  string `json:"fragmentPath"`
+}
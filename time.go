@@ -0,0 +1,15 @@
+package rpi
+
+// Time represents the clock of the system and its synchronization with NTP servers
+type Time struct {
+	Time            int64    `json:"time"`
+	Timezone        string   `json:"timezone"`
+	IsLocalRTC      bool     `json:"isLocalRTC"`
+	IsNTPAvailable  bool     `json:"isNTPAvailable"`
+	IsNTPEnabled    bool     `json:"isNTPEnabled"`
+	IsSynchronized  bool     `json:"isSynchronized"`
+	Servers         []string `json:"servers"`
+	FallbackServers []string `json:"fallbackServers"`
+	ServerName      string   `json:"serverName"`
+	ServerAddress   string   `json:"serverAddress"`
+}
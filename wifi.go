@@ -0,0 +1,33 @@
+package rpi
+
+// Wifi represents the networks of a wifi interface managed by wpa_supplicant
+type Wifi struct {
+	Interface   string        `json:"interface"`
+	IsWpaSupCom bool          `json:"isWpaSupCom"`
+	Country     string        `json:"country"`
+	Networks    []WifiNetwork `json:"networks"`
+}
+
+// WifiNetwork represents a network configured in wpa_supplicant.conf.
+// The id is the one used by wpa_cli, given in the order of the file.
+type WifiNetwork struct {
+	ID         int    `json:"id"`
+	SSID       string `json:"ssid"`
+	KeyMgmt    string `json:"keyMgmt"`
+	IsSecured  bool   `json:"isSecured"`
+	IsHidden   bool   `json:"isHidden"`
+	Priority   int    `json:"priority"`
+	IsDisabled bool   `json:"isDisabled"`
+	IsCurrent  bool   `json:"isCurrent"`
+}
+
+// WifiScan represents a network found by the last scan of a wifi interface
+type WifiScan struct {
+	BSSID        string   `json:"bssid"`
+	SSID         string   `json:"ssid"`
+	Frequency    uint64   `json:"frequency"`
+	Signal       int      `json:"signal"`
+	Flags        []string `json:"flags"`
+	IsSecured    bool     `json:"isSecured"`
+	IsConfigured bool     `json:"isConfigured"`
+}